@@ -42,6 +42,14 @@ func buildExecution(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	verifyMIR, err := cmd.Flags().GetBool("verify-mir")
+	if err != nil {
+		return err
+	}
+	optimize, err := cmd.Flags().GetBool("opt")
+	if err != nil {
+		return err
+	}
 	keepTmpFlag, err := cmd.Flags().GetBool("keep-tmp")
 	if err != nil {
 		return err
@@ -50,6 +58,18 @@ func buildExecution(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	noRuntimeChecks, err := cmd.Flags().GetBool("no-runtime-checks")
+	if err != nil {
+		return err
+	}
+	emitValue, err := cmd.Flags().GetString("emit")
+	if err != nil {
+		return err
+	}
+	targetTriple, err := cmd.Flags().GetString("target")
+	if err != nil {
+		return err
+	}
 	uiValue, err := cmd.Flags().GetString("ui")
 	if err != nil {
 		return err
@@ -61,11 +81,28 @@ func buildExecution(cmd *cobra.Command, args []string) error {
 	if emitLLVM && backendValue != string(buildpipeline.BackendLLVM) {
 		return fmt.Errorf("--emit-llvm requires --backend=llvm")
 	}
+	if noRuntimeChecks && backendValue != string(buildpipeline.BackendLLVM) {
+		return fmt.Errorf("--no-runtime-checks requires --backend=llvm")
+	}
+	emitKind := buildpipeline.EmitKind(emitValue)
+	if emitKind != buildpipeline.EmitBin && emitKind != buildpipeline.EmitObj && emitKind != buildpipeline.EmitLL {
+		return fmt.Errorf("unsupported --emit value: %s (supported: bin, obj, ll)", emitValue)
+	}
+	if emitKind != buildpipeline.EmitBin && backendValue != string(buildpipeline.BackendLLVM) {
+		return fmt.Errorf("--emit=%s requires --backend=llvm", emitValue)
+	}
+	if targetTriple != "" && backendValue != string(buildpipeline.BackendLLVM) {
+		return fmt.Errorf("--target requires --backend=llvm")
+	}
 
 	uiModeValue, err := readUIMode(uiValue)
 	if err != nil {
 		return err
 	}
+	cfgSettings, err := readCfgSettings(cmd, targetTriple)
+	if err != nil {
+		return err
+	}
 
 	argsBeforeDash, _ := splitArgsAtDash(cmd, args)
 
@@ -126,18 +163,24 @@ func buildExecution(cmd *cobra.Command, args []string) error {
 		DirInfo:        toPipelineDirInfo(dirInfo),
 		Files:          displayFiles,
 		Backend:        buildpipeline.Backend(backendValue),
+		VerifyMIR:      verifyMIR || dev,
+		Optimize:       optimize,
+		Cfg:            cfgSettings,
 	}
 
 	buildReq := buildpipeline.BuildRequest{
-		CompileRequest: compileReq,
-		OutputName:     outputName,
-		OutputRoot:     outputRoot,
-		Profile:        profile,
-		Backend:        buildpipeline.Backend(backendValue),
-		EmitMIR:        emitMIR,
-		EmitLLVM:       emitLLVM,
-		KeepTmp:        keepTmpFlag,
-		PrintCommands:  printCommands,
+		CompileRequest:  compileReq,
+		OutputName:      outputName,
+		OutputRoot:      outputRoot,
+		Profile:         profile,
+		Backend:         buildpipeline.Backend(backendValue),
+		EmitMIR:         emitMIR,
+		EmitLLVM:        emitLLVM,
+		KeepTmp:         keepTmpFlag,
+		PrintCommands:   printCommands,
+		NoRuntimeChecks: noRuntimeChecks,
+		Emit:            emitKind,
+		TargetTriple:    targetTriple,
 	}
 	if selected.usesManifest {
 		buildReq.ManifestRoot = selected.manifestRoot
@@ -190,6 +233,12 @@ func init() {
 	buildCmd.Flags().String("ui", "auto", "user interface (auto|on|off)")
 	buildCmd.Flags().Bool("emit-mir", false, "emit MIR dump to target/.tmp")
 	buildCmd.Flags().Bool("emit-llvm", false, "emit LLVM IR to target/.tmp (llvm backend only)")
+	buildCmd.Flags().Bool("verify-mir", false, "verify MIR before codegen (implied by --dev)")
+	buildCmd.Flags().Bool("opt", false, "fold constant expressions and drop dead blocks in MIR")
 	buildCmd.Flags().Bool("keep-tmp", false, "preserve target/.tmp contents")
 	buildCmd.Flags().Bool("print-commands", false, "print LLVM build commands")
+	buildCmd.Flags().String("emit", string(buildpipeline.EmitBin), "LLVM build output: bin (linked executable), obj (object file), ll (LLVM IR)")
+	buildCmd.Flags().String("target", "", "LLVM target triple, x86_64 or aarch64 (default x86_64-linux-gnu)")
+	buildCmd.Flags().StringArray("cfg", nil, cfgFlagUsage)
+	buildCmd.Flags().Bool("no-runtime-checks", false, "elide division-by-zero and integer overflow checks in LLVM output (release builds)")
 }
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"surge/internal/symbols"
+)
+
+const cfgFlagUsage = "set a build setting for @cfg items: key=value, or key for \"true\" (repeatable)"
+
+// readCfgSettings returns the @cfg settings for a command: os/arch of the
+// target triple (the host when empty) overridden by --cfg flags.
+func readCfgSettings(cmd *cobra.Command, triple string) (symbols.CfgSettings, error) {
+	flags, err := cmd.Flags().GetStringArray("cfg")
+	if err != nil {
+		return nil, err
+	}
+	settings := symbols.CfgSettingsForTarget(triple)
+	if err := settings.ApplyFlags(flags); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
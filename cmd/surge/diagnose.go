@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
@@ -22,7 +25,7 @@ import (
 )
 
 var diagCmd = &cobra.Command{
-	Use:   "diag [flags] <file.sg|directory>",
+	Use:   "diag [flags] <file.sg|directory|->",
 	Short: "Run diagnostics on a surge source file or directory",
 	Long:  `Run diagnostics to find syntax and semantic issues in surge source files or all *.sg files within a directory`,
 	Args:  cobra.ExactArgs(1),
@@ -38,12 +41,18 @@ func init() {
 	diagCmd.Flags().Bool("no-warnings", false, "ignore warnings in diagnostics")
 	diagCmd.Flags().Bool("warnings-as-errors", false, "treat warnings as errors")
 	diagCmd.Flags().Bool("no-alien-hints", false, "disable extra alien-hint diagnostics (enabled by default)")
+	diagCmd.Flags().StringSlice("hints", nil, "opt-in hint passes (dialect)")
 	diagCmd.Flags().Int("jobs", 0, "max parallel workers for directory processing (0=auto)")
 	diagCmd.Flags().Bool("with-notes", false, "include diagnostic notes in output")
 	diagCmd.Flags().Bool("suggest", false, "include fix suggestions in output")
 	diagCmd.Flags().Bool("preview", false, "preview changes without modifying files")
 	diagCmd.Flags().Bool("fullpath", false, "emit absolute file paths in output")
+	diagCmd.Flags().Bool("json-snippets", false, "embed source lines and caret columns in --format json output")
+	diagCmd.Flags().Bool("report", false, "print files ranked by error/warning counts instead of individual diagnostics")
 	diagCmd.Flags().Bool("disk-cache", false, "enable persistent disk cache for module metadata (experimental)")
+	diagCmd.Flags().String("cache-dir", "", "cache per-module diagnostics in this directory and skip re-checking unchanged modules (off unless set)")
+	diagCmd.Flags().Bool("no-cache", false, "disable the diagnostics cache (--cache-dir and the in-memory one of --watch) and re-check every module")
+	diagCmd.Flags().Bool("watch", false, "re-run diagnostics whenever the input files or their imports change")
 	diagCmd.Flags().String("directives", "off", "directive processing mode (off|collect|gen|run)")
 	diagCmd.Flags().String("directives-filter", "test", "comma-separated directive namespaces to process")
 	diagCmd.Flags().Bool("emit-hir", false, "emit HIR (High-level IR) representation after successful analysis")
@@ -53,6 +62,7 @@ func init() {
 	diagCmd.Flags().Bool("emit-mir", false, "emit MIR (Mid-level IR) for monomorphized program (requires sema)")
 	diagCmd.Flags().Bool("mono-dce", false, "enable DCE for monomorphized output (experimental)")
 	diagCmd.Flags().Int("mono-max-depth", 64, "max monomorphization recursion depth")
+	diagCmd.Flags().StringArray("cfg", nil, cfgFlagUsage)
 }
 
 // runDiagnose executes the "diag" command: it parses command flags, runs diagnostics
@@ -105,6 +115,21 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get no-alien-hints flag: %w", err)
 	}
 
+	hints, err := cmd.Flags().GetStringSlice("hints")
+	if err != nil {
+		return fmt.Errorf("failed to get hints flag: %w", err)
+	}
+	dialectHints := false
+	for _, hint := range hints {
+		switch strings.TrimSpace(hint) {
+		case driver.HintDialect:
+			dialectHints = true
+		case "":
+		default:
+			return fmt.Errorf("unknown hints value: %s", hint)
+		}
+	}
+
 	if noWarnings && warningsAsErrors {
 		return fmt.Errorf("no-warnings and warnings-as-errors flags cannot be used together")
 	}
@@ -129,10 +154,36 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get fullpath flag: %w", err)
 	}
 
+	report, err := cmd.Flags().GetBool("report")
+	if err != nil {
+		return fmt.Errorf("failed to get report flag: %w", err)
+	}
+	jsonSnippets, err := cmd.Flags().GetBool("json-snippets")
+	if err != nil {
+		return fmt.Errorf("failed to get json-snippets flag: %w", err)
+	}
+	if jsonSnippets && (format != "json" || report) {
+		return fmt.Errorf("--json-snippets requires --format json")
+	}
 	enableDiskCache, err := cmd.Flags().GetBool("disk-cache")
 	if err != nil {
 		return fmt.Errorf("failed to get disk-cache flag: %w", err)
 	}
+	cacheDir, err := cmd.Flags().GetString("cache-dir")
+	if err != nil {
+		return fmt.Errorf("failed to get cache-dir flag: %w", err)
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return fmt.Errorf("failed to get no-cache flag: %w", err)
+	}
+	watch, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return fmt.Errorf("failed to get watch flag: %w", err)
+	}
+	if watch && filePath == stdinArg {
+		return fmt.Errorf("--watch cannot be used with stdin input")
+	}
 
 	directivesStr, err := cmd.Flags().GetString("directives")
 	if err != nil {
@@ -224,6 +275,11 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unknown directives value: %s", directivesStr)
 	}
 
+	cfgSettings, err := readCfgSettings(cmd, "")
+	if err != nil {
+		return err
+	}
+
 	// Создаём опции диагностики
 	printHIR := emitHIR || emitBorrow
 	buildHIR := printHIR || emitMono || emitMIR
@@ -235,24 +291,51 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 		IgnoreWarnings:     noWarnings,
 		WarningsAsErrors:   warningsAsErrors,
 		NoAlienHints:       noAlienHints,
+		DialectHints:       dialectHints,
 		EnableTimings:      showTimings,
 		EnableDiskCache:    enableDiskCache,
 		DirectiveMode:      directiveMode,
 		DirectiveFilter:    directiveFilter,
 		EmitHIR:            buildHIR,
 		EmitInstantiations: buildInstantiations,
+		Cfg:                cfgSettings,
 	}
 
-	st, err := os.Stat(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to stat path: %w", err)
+	isDir := false
+	if filePath == stdinArg {
+		// "-" читает исходник из stdin и регистрирует его как виртуальный файл
+		content, readErr := readStdinSource(cmd)
+		if readErr != nil {
+			return readErr
+		}
+		diagOpts.ProjectRoot = stdinName
+		diagOpts.Source = content
+	} else {
+		st, statErr := os.Stat(filePath)
+		if statErr != nil {
+			return fmt.Errorf("failed to stat path: %w", statErr)
+		}
+		isDir = st.IsDir()
 	}
-	if st.IsDir() && emitMono {
+	if isDir && emitMono {
 		return fmt.Errorf("--emit-mono is only supported for single files")
 	}
-	if st.IsDir() && emitMIR {
+	if isDir && emitMIR {
 		return fmt.Errorf("--emit-mir is only supported for single files")
 	}
+	// JSON output carries semantic data that the cache does not store.
+	if cacheDir != "" && !noCache && format != "json" {
+		cache, cacheErr := driver.OpenDiagCache(cacheDir)
+		if cacheErr != nil {
+			return fmt.Errorf("failed to open cache dir: %w", cacheErr)
+		}
+		diagOpts.DiagCache = cache
+	}
+	// Watch re-runs keep module results in memory, so only edited modules
+	// and the modules whose imports changed are checked again.
+	if watch && diagOpts.DiagCache == nil && !noCache && format != "json" {
+		diagOpts.DiagCache = driver.NewMemoryDiagCache()
+	}
 
 	cleanup, err := setupProfiling(cmd)
 	if err != nil {
@@ -262,6 +345,8 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 	var (
 		exitCode  int
 		resultErr error
+		// FileSet of the last run: --watch polls the files it loaded.
+		lastFileSet *source.FileSet
 	)
 
 	runFile := func() (int, error) {
@@ -276,6 +361,7 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 		if result == nil {
 			return 0, fmt.Errorf("diagnosis failed: missing file results")
 		}
+		lastFileSet = result.FileSet
 
 		exit := 0
 		if result.Bag.HasErrors() {
@@ -288,23 +374,27 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 		}
 		showFixes := suggest || preview
 
-		var colorFlag string
-		colorFlag, err = cmd.Root().PersistentFlags().GetString("color")
+		var colorOpts diagfmt.PrettyOpts
+		colorOpts, err = prettyOutputOpts(cmd, os.Stdout)
 		if err != nil {
 			return 0, err
 		}
-		useColor := colorFlag == "on" || (colorFlag == "auto" && isTerminal(os.Stdout))
 
+		if report {
+			format = "report"
+		}
 		switch format {
-		case "pretty":
-			opts := diagfmt.PrettyOpts{
-				Color:       useColor,
-				Context:     2,
-				PathMode:    pathMode,
-				ShowNotes:   withNotes,
-				ShowFixes:   showFixes,
-				ShowPreview: preview,
+		case "report":
+			if printErr := printDensityReport(result.Bag.Items(), result.FileSet); printErr != nil {
+				return 0, printErr
 			}
+		case "pretty":
+			opts := colorOpts
+			opts.Context = 2
+			opts.PathMode = pathMode
+			opts.ShowNotes = withNotes
+			opts.ShowFixes = showFixes
+			opts.ShowPreview = preview
 			diagfmt.Pretty(os.Stdout, result.Bag, result.FileSet, opts)
 		case "short":
 			output := diag.FormatShortDiagnostics(result.Bag.Items(), result.FileSet, withNotes)
@@ -321,6 +411,7 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 				IncludeNotes:     withNotes,
 				IncludeFixes:     showFixes,
 				IncludePreviews:  preview,
+				IncludeSnippets:  jsonSnippets,
 			}
 			var semantics *diagfmt.SemanticsInput
 			if result.Symbols != nil && result.Builder != nil && result.FileID != 0 {
@@ -339,7 +430,9 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 				ToolName:    "surge",
 				ToolVersion: "0.1.0",
 			}
-			diagfmt.Sarif(os.Stdout, result.Bag, result.FileSet, meta)
+			if err = diagfmt.Sarif(os.Stdout, result.Bag, result.FileSet, meta); err != nil {
+				return 0, fmt.Errorf("failed to format diagnostics: %w", err)
+			}
 		default:
 			return 0, fmt.Errorf("unknown format: %s", format)
 		}
@@ -472,6 +565,7 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 		}
 		fs := workspace.DirFileSet
 		results := workspace.DirResults
+		lastFileSet = fs
 
 		exit := 0
 		for _, r := range results {
@@ -481,37 +575,45 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		colorFlag, err := cmd.Root().PersistentFlags().GetString("color")
+		prettyOpts, err := prettyOutputOpts(cmd, os.Stdout)
 		if err != nil {
 			return 0, err
 		}
-		useColor := colorFlag == "on" || (colorFlag == "auto" && isTerminal(os.Stdout))
 		pathMode := diagfmt.PathModeAuto
 		if fullPath {
 			pathMode = diagfmt.PathModeAbsolute
 		}
 		showFixes := suggest || preview
-		prettyOpts := diagfmt.PrettyOpts{
-			Color:       useColor,
-			Context:     2,
-			PathMode:    pathMode,
-			ShowNotes:   withNotes,
-			ShowFixes:   showFixes,
-			ShowPreview: preview,
-		}
+		prettyOpts.Context = 2
+		prettyOpts.PathMode = pathMode
+		prettyOpts.ShowNotes = withNotes
+		prettyOpts.ShowFixes = showFixes
+		prettyOpts.ShowPreview = preview
 		jsonOpts := diagfmt.JSONOpts{
 			IncludePositions: true,
 			PathMode:         pathMode,
 			IncludeNotes:     withNotes,
 			IncludeFixes:     showFixes,
 			IncludePreviews:  preview,
+			IncludeSnippets:  jsonSnippets,
 		}
 		meta := diagfmt.SarifRunMeta{
 			ToolName:    "surge",
 			ToolVersion: "0.1.0",
 		}
 
+		if report {
+			format = "report"
+		}
 		switch format {
+		case "report":
+			allDiagnostics := make([]*diag.Diagnostic, 0, len(results))
+			for _, r := range results {
+				allDiagnostics = append(allDiagnostics, r.Bag.Items()...)
+			}
+			if printErr := printDensityReport(allDiagnostics, fs); printErr != nil {
+				return 0, printErr
+			}
 		case "short":
 			allDiagnostics := make([]*diag.Diagnostic, 0, len(results))
 			for _, r := range results {
@@ -591,8 +693,13 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 				return 0, fmt.Errorf("failed to encode diagnostics output: %w", err)
 			}
 		case "sarif":
+			// Один SARIF-лог на весь каталог: заметки могут ссылаться между файлами.
+			merged := diag.NewBag(0)
 			for _, r := range results {
-				diagfmt.Sarif(os.Stdout, r.Bag, fs, meta)
+				merged.Merge(r.Bag)
+			}
+			if err := diagfmt.Sarif(os.Stdout, merged, fs, meta); err != nil {
+				return 0, fmt.Errorf("failed to format diagnostics: %w", err)
 			}
 		default:
 			return 0, fmt.Errorf("unknown format: %s", format)
@@ -601,11 +708,16 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 		return exit, nil
 	}
 
-	if !st.IsDir() {
-		exitCode, resultErr = runFile()
-	} else {
-		exitCode, resultErr = runDir()
+	runOnce := runFile
+	if isDir {
+		runOnce = runDir
+	}
+	if watch {
+		runWatch(cmd, filePath, runOnce, func() *source.FileSet { return lastFileSet })
+		cleanup()
+		return nil
 	}
+	exitCode, resultErr = runOnce()
 
 	// Always cleanup profiler
 	cleanup()
@@ -626,6 +738,36 @@ func runDiagnose(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runWatch re-runs diagnostics until SIGINT/SIGTERM, clearing the terminal
+// before each render. Errors of a single run are reported and watching goes on.
+func runWatch(cmd *cobra.Command, filePath string, runOnce func() (int, error), lastFileSet func() *source.FileSet) {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	clearScreen := isTerminal(os.Stdout)
+	driver.Watch(ctx, driver.WatchOptions{}, func(context.Context) []string {
+		if clearScreen {
+			fmt.Fprint(os.Stdout, "\x1b[H\x1b[2J")
+		}
+		if _, err := runOnce(); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		fmt.Fprintf(os.Stderr, "watching %s for changes (Ctrl+C to exit)\n", filePath)
+		return driver.WatchPaths(lastFileSet(), filePath)
+	})
+}
+
+// printDensityReport prints files ranked by diagnostic counts (--report).
+func printDensityReport(diags []*diag.Diagnostic, fs *source.FileSet) error {
+	output := diag.FormatDensityReport(diag.DensityReport(diags, fs))
+	if output == "" {
+		return nil
+	}
+	if _, err := fmt.Fprintln(os.Stdout, output); err != nil {
+		return fmt.Errorf("failed to print output: %w", err)
+	}
+	return nil
+}
+
 func flushAndCloseTracer(tracer trace.Tracer) {
 	if tracer == nil || tracer == trace.Nop {
 		return
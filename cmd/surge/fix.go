@@ -28,6 +28,9 @@ func init() {
 	fixCmd.Flags().Bool("all", false, "apply all safe fixes")
 	fixCmd.Flags().Bool("once", false, "apply the first available fix (default)")
 	fixCmd.Flags().String("id", "", "apply fix with a specific identifier")
+	fixCmd.Flags().Bool("fix-all", false, "apply every always-safe fix in one atomic pass (fails on overlapping edits)")
+	fixCmd.Flags().Bool("dry-run", false, "print a unified diff instead of rewriting files; exit non-zero if anything would change")
+	fixCmd.Flags().String("only", "", "only consider fixes for diagnostics with this code (e.g. SYN2106)")
 }
 
 func runFix(cmd *cobra.Command, args []string) error {
@@ -46,22 +49,44 @@ func runFix(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if targetID != "" && (applyAll || applyOnceFlag) {
-		return fmt.Errorf("--id cannot be combined with --all or --once")
+	fixAll, err := cmd.Flags().GetBool("fix-all")
+	if err != nil {
+		return err
+	}
+
+	if targetID != "" && (applyAll || applyOnceFlag || fixAll) {
+		return fmt.Errorf("--id cannot be combined with --all, --once or --fix-all")
 	}
 	if applyAll && applyOnceFlag {
 		return fmt.Errorf("--all and --once are mutually exclusive")
 	}
+	if fixAll && (applyAll || applyOnceFlag) {
+		return fmt.Errorf("--fix-all cannot be combined with --all or --once")
+	}
 
 	mode := fix.ApplyModeOnce
-	if targetID != "" {
+	switch {
+	case targetID != "":
 		mode = fix.ApplyModeID
-	} else if applyAll {
+	case fixAll:
+		mode = fix.ApplyModeBatch
+	case applyAll:
 		mode = fix.ApplyModeAll
 	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+	onlyCode, err := cmd.Flags().GetString("only")
+	if err != nil {
+		return err
+	}
+
 	opts := fix.ApplyOptions{
 		Mode:     mode,
 		TargetID: targetID,
+		OnlyCode: onlyCode,
+		DryRun:   dryRun,
 	}
 
 	maxDiagnostics, err := cmd.Root().PersistentFlags().GetInt("max-diagnostics")
@@ -98,9 +123,15 @@ func runFix(cmd *cobra.Command, args []string) error {
 	}
 
 	if !info.IsDir() {
-		return runFixFile(cmd.Context(), targetPath, &driverOpts, opts)
+		err = runFixFile(cmd.Context(), targetPath, &driverOpts, opts)
+	} else {
+		err = runFixDir(cmd, targetPath, &driverOpts, opts)
+	}
+	if errors.Is(err, errFixDryRunChanges) {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
 	}
-	return runFixDir(cmd, targetPath, &driverOpts, opts)
+	return err
 }
 
 func runFixFile(ctx context.Context, path string, driverOpts *driver.DiagnoseOptions, opts fix.ApplyOptions) error {
@@ -114,7 +145,7 @@ func runFixFile(ctx context.Context, path string, driverOpts *driver.DiagnoseOpt
 		diagnostics = append(diagnostics, result.Bag.Items()...)
 	}
 	res, applyErr := fix.Apply(result.FileSet, diagnostics, opts)
-	return handleApplyResult(res, applyErr)
+	return handleApplyResult(res, applyErr, opts.DryRun)
 }
 
 func runFixDir(cmd *cobra.Command, path string, driverOpts *driver.DiagnoseOptions, opts fix.ApplyOptions) error {
@@ -133,17 +164,33 @@ func runFixDir(cmd *cobra.Command, path string, driverOpts *driver.DiagnoseOptio
 	}
 
 	res, applyErr := fix.Apply(fs, allDiagnostics, opts)
-	return handleApplyResult(res, applyErr)
+	return handleApplyResult(res, applyErr, opts.DryRun)
 }
 
-func handleApplyResult(res *fix.ApplyResult, applyErr error) error {
+// errFixDryRunChanges signals a non-zero exit for `fix --dry-run` when fixes would change files.
+var errFixDryRunChanges = errors.New("fix: dry run found changes")
+
+func handleApplyResult(res *fix.ApplyResult, applyErr error, dryRun bool) error {
 	if res == nil {
 		return applyErr
 	}
 	var printErr error
 
+	for _, preview := range res.Previews {
+		if _, printErr = fmt.Fprint(os.Stdout, fix.UnifiedDiff(preview.Path, preview.Before, preview.After, 3)); printErr != nil {
+			return printErr
+		}
+	}
+
+	appliedVerb := "Applied"
+	filesHeader := "Updated files:"
+	if dryRun {
+		appliedVerb = "Would apply"
+		filesHeader = "Would update files:"
+	}
+
 	if len(res.Applied) > 0 {
-		_, printErr = fmt.Fprintf(os.Stdout, "Applied %d fix(es):\n", len(res.Applied))
+		_, printErr = fmt.Fprintf(os.Stdout, "%s %d fix(es):\n", appliedVerb, len(res.Applied))
 		if printErr != nil {
 			return printErr
 		}
@@ -168,7 +215,7 @@ func handleApplyResult(res *fix.ApplyResult, applyErr error) error {
 	}
 
 	if len(res.FileChanges) > 0 {
-		_, printErr = fmt.Fprintln(os.Stdout, "Updated files:")
+		_, printErr = fmt.Fprintln(os.Stdout, filesHeader)
 		if printErr != nil {
 			return printErr
 		}
@@ -221,5 +268,8 @@ func handleApplyResult(res *fix.ApplyResult, applyErr error) error {
 			return printErr
 		}
 	}
+	if dryRun && len(res.Previews) > 0 {
+		return errFixDryRunChanges
+	}
 	return nil
 }
@@ -74,13 +74,13 @@ func runFmt(cmd *cobra.Command, args []string) error {
 	switch outputFormat {
 	case "text":
 		if writeToStdout {
-			renderFmtStdout(formatResults, &hasErrors)
+			renderFmtStdout("fmt", formatResults, &hasErrors)
 			if hasErrors {
 				return fmt.Errorf("fmt: failed to format some files")
 			}
 			return nil
 		}
-		renderFmtText(formatResults, check, quiet, &hasErrors, &hasChanges)
+		renderFmtText("fmt", "reformatted", formatResults, check, quiet, &hasErrors, &hasChanges)
 	case "json":
 		if err := renderFmtJSON(formatResults, check); err != nil {
 			return err
@@ -98,28 +98,28 @@ func runFmt(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func renderFmtStdout(results []driver.FormatResult, hasErrors *bool) {
+func renderFmtStdout(command string, results []driver.FormatResult, hasErrors *bool) {
 	for _, res := range results {
 		if res.Err != nil {
 			*hasErrors = true
-			fmt.Fprintf(os.Stderr, "fmt: %s: %v\n", res.Path, res.Err)
+			fmt.Fprintf(os.Stderr, "%s: %s: %v\n", command, res.Path, res.Err)
 			continue
 		}
 
 		if _, err := os.Stdout.Write(res.Formatted); err != nil {
 			*hasErrors = true
-			if _, printErr := fmt.Fprintf(os.Stderr, "fmt: failed to write formatted output: %v\n", err); printErr != nil {
+			if _, printErr := fmt.Fprintf(os.Stderr, "%s: failed to write formatted output: %v\n", command, err); printErr != nil {
 				panic(printErr)
 			}
 		}
 	}
 }
 
-func renderFmtText(results []driver.FormatResult, check, quiet bool, hasErrors, hasChanges *bool) {
+func renderFmtText(command, verb string, results []driver.FormatResult, check, quiet bool, hasErrors, hasChanges *bool) {
 	for _, res := range results {
 		if res.Err != nil {
 			*hasErrors = true
-			fmt.Fprintf(os.Stderr, "fmt: %s: %v\n", res.Path, res.Err)
+			fmt.Fprintf(os.Stderr, "%s: %s: %v\n", command, res.Path, res.Err)
 			continue
 		}
 
@@ -137,7 +137,7 @@ func renderFmtText(results []driver.FormatResult, check, quiet bool, hasErrors,
 		}
 
 		if res.Changed && !quiet {
-			_, printErr := fmt.Fprintf(os.Stdout, "reformatted %s\n", res.Path)
+			_, printErr := fmt.Fprintf(os.Stdout, "%s %s\n", verb, res.Path)
 			if printErr != nil {
 				panic(printErr)
 			}
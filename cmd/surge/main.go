@@ -11,6 +11,7 @@ import (
 
 	"golang.org/x/term"
 
+	"surge/internal/diagfmt"
 	"surge/internal/version"
 )
 
@@ -26,8 +27,19 @@ var (
 	traceCleanup    func()
 )
 
-// main configures the root CLI command (sets the version, registers subcommands, and defines persistent flags) and then executes it, exiting with status 1 if execution fails.
+// main configures the root CLI command and then executes it, exiting with status 1 if execution fails.
+// A panic dumps the recent trace spans before it propagates.
 func main() {
+	defer dumpTraceOnPanic()
+	setupRootCmd()
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// setupRootCmd sets the version, registers subcommands, and defines persistent flags.
+// It must be called exactly once before rootCmd is executed.
+func setupRootCmd() {
 	// Устанавливаем версию для автоматического флага --version
 	rootCmd.Version = version.String()
 	rootCmd.PersistentPreRunE = applyTimeout
@@ -38,12 +50,14 @@ func main() {
 	rootCmd.AddCommand(parseCmd)
 	rootCmd.AddCommand(diagCmd)
 	rootCmd.AddCommand(fmtCmd)
+	rootCmd.AddCommand(normalizeCmd)
 	rootCmd.AddCommand(fixCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(buildCmd)
 	rootCmd.AddCommand(cleanCmd)
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(testCmd)
 	rootCmd.AddCommand(lspCmd)
 	rootCmd.AddCommand(philosophyCmd)
 	rootCmd.AddCommand(moduleCmd)
@@ -51,6 +65,8 @@ func main() {
 
 	// Глобальные флаги
 	rootCmd.PersistentFlags().String("color", "auto", "colorize output (auto|on|off)")
+	rootCmd.PersistentFlags().String("color-theme", string(diagfmt.ThemeDark), "color theme for pretty diagnostics (dark|light|mono|high-contrast)")
+	rootCmd.PersistentFlags().Int("width", 0, "wrap pretty diagnostics at N columns (0 = terminal width)")
 	rootCmd.PersistentFlags().Bool("quiet", false, "suppress non-essential output")
 	rootCmd.PersistentFlags().Bool("timings", false, "show timing information")
 	rootCmd.PersistentFlags().Int("max-diagnostics", 100, "maximum number of diagnostics to show")
@@ -61,15 +77,12 @@ func main() {
 
 	// Tracing flags
 	rootCmd.PersistentFlags().String("trace", "", "trace output file (- for stderr, empty to disable)")
+	rootCmd.PersistentFlags().String("trace-file", "", "append trace events to a file, independent of --trace")
 	rootCmd.PersistentFlags().String("trace-level", "off", "trace level (off|error|phase|detail|debug)")
 	rootCmd.PersistentFlags().String("trace-mode", "ring", "storage mode (stream|ring|both)")
 	rootCmd.PersistentFlags().String("trace-format", "auto", "output format (auto|text|ndjson|chrome) - auto detects from file extension")
 	rootCmd.PersistentFlags().Int("trace-ring-size", 4096, "ring buffer capacity for trace events")
 	rootCmd.PersistentFlags().Duration("trace-heartbeat", 0, "heartbeat interval (0 to disable, e.g. 1s)")
-
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
-	}
 }
 
 // isTerminal проверяет, является ли файл терминалом
@@ -78,10 +91,63 @@ func isTerminal(f *os.File) bool {
 	return err == nil && term.IsTerminal(fd)
 }
 
+// useHyperlinks решает, оборачивать ли локации диагностик в OSC 8 ссылки.
+// Ссылки выводятся только в терминал и отключаются через --color=off или NO_COLOR.
+func useHyperlinks(colorFlag string, f *os.File) bool {
+	if colorFlag == "off" || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(f)
+}
+
+// prettyOutputOpts один раз разрешает --color, --color-theme, --width и NO_COLOR для вывода в f.
+// В режиме auto NO_COLOR выключает цвет; --color=on его переопределяет.
+// Без --width ширина берётся из терминала; вне терминала вывод не ограничен.
+func prettyOutputOpts(cmd *cobra.Command, f *os.File) (diagfmt.PrettyOpts, error) {
+	flags := cmd.Root().PersistentFlags()
+	colorFlag, err := flags.GetString("color")
+	if err != nil {
+		return diagfmt.PrettyOpts{}, err
+	}
+	themeFlag, err := flags.GetString("color-theme")
+	if err != nil {
+		return diagfmt.PrettyOpts{}, err
+	}
+	theme, err := diagfmt.ParseTheme(themeFlag)
+	if err != nil {
+		return diagfmt.PrettyOpts{}, err
+	}
+	widthFlag, err := flags.GetInt("width")
+	if err != nil {
+		return diagfmt.PrettyOpts{}, err
+	}
+	if widthFlag < 0 {
+		return diagfmt.PrettyOpts{}, fmt.Errorf("--width must be non-negative, got %d", widthFlag)
+	}
+	if widthFlag == 0 {
+		widthFlag = diagfmt.TerminalWidth(f)
+	}
+	width, err := safecast.Conv[uint16](widthFlag)
+	if err != nil {
+		return diagfmt.PrettyOpts{}, fmt.Errorf("--width %d is out of range", widthFlag)
+	}
+	useColor := colorFlag == "on" || (colorFlag == "auto" && os.Getenv("NO_COLOR") == "" && isTerminal(f))
+	return diagfmt.PrettyOpts{
+		Color:      useColor,
+		Theme:      theme,
+		Width:      width,
+		Hyperlinks: useHyperlinks(colorFlag, f),
+	}, nil
+}
+
 func applyTimeout(cmd *cobra.Command, _ []string) (err error) {
 	if cmd.Name() == "lsp" {
 		return nil
 	}
+	// diag --watch работает до Ctrl+C, как и lsp.
+	if watchFlag := cmd.Flags().Lookup("watch"); watchFlag != nil && watchFlag.Changed && watchFlag.Value.String() == "true" {
+		return nil
+	}
 	secs, err := cmd.Root().PersistentFlags().GetInt("timeout")
 	if err != nil {
 		return fmt.Errorf("failed to read timeout flag: %w", err)
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"surge/internal/driver"
+)
+
+var normalizeCmd = &cobra.Command{
+	Use:   "normalize [flags] <path> [path...]",
+	Short: "Apply targeted source normalizations without full formatting",
+	Long: `Normalize rewrites only the constructs selected by flags and keeps the rest
+of each file as written. By default it sorts and groups imports: absolute
+imports first, relative ones after a blank line, members of ::{...} groups
+ordered by name.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runNormalize,
+}
+
+func init() {
+	normalizeCmd.Flags().Bool("imports", true, "sort and group import statements")
+	normalizeCmd.Flags().Bool("commas", false, "normalize spacing around commas in parameter and argument lists")
+	normalizeCmd.Flags().Bool("check", false, "check if files are already normalized")
+	normalizeCmd.Flags().String("format", "text", "output format (text|json)")
+	normalizeCmd.Flags().Bool("stdout", false, "print normalized code to stdout instead of rewriting files")
+}
+
+func runNormalize(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	imports, err := cmd.Flags().GetBool("imports")
+	if err != nil {
+		return err
+	}
+	commas, err := cmd.Flags().GetBool("commas")
+	if err != nil {
+		return err
+	}
+	check, err := cmd.Flags().GetBool("check")
+	if err != nil {
+		return err
+	}
+	outputFormat, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	writeToStdout, err := cmd.Flags().GetBool("stdout")
+	if err != nil {
+		return err
+	}
+
+	if !imports && !commas {
+		return fmt.Errorf("normalize: no passes selected")
+	}
+	if writeToStdout && check {
+		return fmt.Errorf("normalize: --stdout cannot be used with --check")
+	}
+	if writeToStdout && outputFormat != "text" {
+		return fmt.Errorf("normalize: --stdout is only supported with text output")
+	}
+
+	maxDiagnostics, err := cmd.Root().PersistentFlags().GetInt("max-diagnostics")
+	if err != nil {
+		return err
+	}
+	quiet, err := cmd.Root().PersistentFlags().GetBool("quiet")
+	if err != nil {
+		return err
+	}
+
+	results, err := driver.NormalizePaths(cmd.Context(), args, driver.NormalizeOptions{
+		Check:          check,
+		MaxDiagnostics: maxDiagnostics,
+		Stdout:         writeToStdout,
+		Imports:        imports,
+		Commas:         commas,
+	})
+	if err != nil {
+		return err
+	}
+
+	var hasErrors bool
+	var hasChanges bool
+
+	switch outputFormat {
+	case "text":
+		if writeToStdout {
+			renderFmtStdout("normalize", results, &hasErrors)
+			if hasErrors {
+				return fmt.Errorf("normalize: failed to normalize some files")
+			}
+			return nil
+		}
+		renderFmtText("normalize", "normalized", results, check, quiet, &hasErrors, &hasChanges)
+	case "json":
+		if err := renderFmtJSON(results, check); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("normalize: unsupported output format %q", outputFormat)
+	}
+
+	if hasErrors {
+		return fmt.Errorf("normalize: failed to normalize some files")
+	}
+	if check && hasChanges {
+		return fmt.Errorf("normalize: changes required")
+	}
+	return nil
+}
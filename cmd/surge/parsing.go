@@ -13,7 +13,7 @@ import (
 )
 
 var parseCmd = &cobra.Command{
-	Use:   "parse [flags] <file.sg|directory>",
+	Use:   "parse [flags] <file.sg|directory|->",
 	Short: "Parse a surge source file or directory and output AST",
 	Long:  `Parse analyzes a surge source file or all *.sg files in a directory and outputs their Abstract Syntax Trees`,
 	Args:  cobra.ExactArgs(1),
@@ -21,7 +21,7 @@ var parseCmd = &cobra.Command{
 }
 
 func init() {
-	parseCmd.Flags().String("format", "pretty", "output format (pretty|json|tree)")
+	parseCmd.Flags().String("format", "pretty", "output format (pretty|json|sexpr|tree)")
 	parseCmd.Flags().Int("jobs", 0, "max parallel workers for directory processing (0=auto)")
 }
 
@@ -43,31 +43,39 @@ func runParse(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get quiet flag: %w", err)
 	}
 
-	// Проверяем, файл это или директория
-	st, err := os.Stat(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to stat path: %w", err)
+	// Проверяем, файл это или директория ("-" — чтение из stdin)
+	isStdin := filePath == stdinArg
+	var st os.FileInfo
+	if !isStdin {
+		st, err = os.Stat(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to stat path: %w", err)
+		}
 	}
 
-	if !st.IsDir() {
+	if isStdin || !st.IsDir() {
 		// Парсинг одного файла
 		var result *driver.ParseResult
-		result, err = driver.Parse(filePath, maxDiagnostics)
+		if isStdin {
+			var content []byte
+			if content, err = readStdinSource(cmd); err != nil {
+				return err
+			}
+			result, err = driver.ParseSource(stdinName, content, maxDiagnostics)
+		} else {
+			result, err = driver.Parse(filePath, maxDiagnostics)
+		}
 		if err != nil {
 			return fmt.Errorf("parsing failed: %w", err)
 		}
 
 		if result.Bag.HasErrors() || result.Bag.HasWarnings() {
-			var colorFlag string
-			colorFlag, err = cmd.Root().PersistentFlags().GetString("color")
+			var opts diagfmt.PrettyOpts
+			opts, err = prettyOutputOpts(cmd, os.Stderr)
 			if err != nil {
 				return err
 			}
-			useColor := colorFlag == "on" || (colorFlag == "auto" && isTerminal(os.Stderr))
-			opts := diagfmt.PrettyOpts{
-				Color:   useColor,
-				Context: 2,
-			}
+			opts.Context = 2
 			diagfmt.Pretty(os.Stderr, result.Bag, result.FileSet, opts)
 		}
 
@@ -76,6 +84,8 @@ func runParse(cmd *cobra.Command, args []string) error {
 			return diagfmt.FormatASTPretty(os.Stdout, result.Builder, result.FileID, result.FileSet)
 		case "json":
 			return diagfmt.FormatASTJSON(os.Stdout, result.Builder, result.FileID)
+		case "sexpr":
+			return diagfmt.FormatASTSExpr(os.Stdout, result.Builder, result.FileID)
 		case "tree":
 			return diagfmt.FormatASTTree(os.Stdout, result.Builder, result.FileID, result.FileSet)
 		default:
@@ -99,16 +109,11 @@ func runParse(cmd *cobra.Command, args []string) error {
 	}
 
 	// Обрабатываем результаты (они уже отсортированы)
-	var colorFlag string
-	colorFlag, err = cmd.Root().PersistentFlags().GetString("color")
+	prettyOpts, err := prettyOutputOpts(cmd, os.Stderr)
 	if err != nil {
 		return err
 	}
-	useColor := colorFlag == "on" || (colorFlag == "auto" && isTerminal(os.Stderr))
-	prettyOpts := diagfmt.PrettyOpts{
-		Color:   useColor,
-		Context: 2,
-	}
+	prettyOpts.Context = 2
 
 	for _, r := range results {
 		if r.Bag.HasErrors() || r.Bag.HasWarnings() {
@@ -167,9 +172,7 @@ func runParse(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return err
 			}
-			// Ensure distinct pointer per iteration
-			nodeCopy := node
-			output[displayPath] = &nodeCopy
+			output[displayPath] = node
 		}
 
 		encoder := json.NewEncoder(os.Stdout)
@@ -177,6 +180,36 @@ func runParse(cmd *cobra.Command, args []string) error {
 		if err := encoder.Encode(output); err != nil {
 			return err
 		}
+	case "sexpr":
+		for idx, r := range results {
+			displayPath := r.Path
+			if r.FileID != 0 && r.Builder != nil {
+				astFile := r.Builder.Files.Get(r.FileID)
+				sourceFileID := astFile.Span.File
+				file := fs.Get(sourceFileID)
+				displayPath = file.FormatPath("auto", fs.BaseDir())
+			}
+
+			if !quiet {
+				_, printErr := fmt.Fprintf(os.Stdout, "== %s ==\n", displayPath)
+				if printErr != nil {
+					return printErr
+				}
+			}
+
+			if r.Builder != nil {
+				if err := diagfmt.FormatASTSExpr(os.Stdout, r.Builder, r.FileID); err != nil {
+					return err
+				}
+			}
+
+			if !quiet && idx < len(results)-1 {
+				_, printErr := fmt.Fprintln(os.Stdout)
+				if printErr != nil {
+					return printErr
+				}
+			}
+		}
 	case "tree":
 		for idx, r := range results {
 			displayPath := r.Path
@@ -39,7 +39,12 @@ func init() {
 	runCmd.Flags().Bool("fuzz-scheduler", false, "enable fuzzed async scheduling")
 	runCmd.Flags().Uint64("fuzz-seed", 1, "seed for fuzzed async scheduling (default 1)")
 	runCmd.Flags().Bool("real-time", false, "use real-time async timers (monotonic clock)")
+	runCmd.Flags().Int("max-stack", vm.DefaultMaxStackDepth, "maximum VM call depth (0 disables the limit)")
+	runCmd.Flags().Int("max-heap-objects", 0, "maximum live VM heap objects (0 disables the limit)")
+	runCmd.Flags().Int("max-heap-bytes", 0, "maximum live VM heap bytes (0 disables the limit)")
+	runCmd.Flags().Bool("heap-report", false, "print VM heap usage and live objects after main returns")
 	runCmd.Flags().Bool("unsafe", false, "run even if diagnostics report errors")
+	runCmd.Flags().StringArray("cfg", nil, cfgFlagUsage)
 }
 
 func runExecution(cmd *cobra.Command, args []string) error {
@@ -132,6 +137,31 @@ func runExecution(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get real-time flag: %w", err)
 	}
+	maxStack, err := cmd.Flags().GetInt("max-stack")
+	if err != nil {
+		return fmt.Errorf("failed to get max-stack flag: %w", err)
+	}
+	if maxStack < 0 {
+		return fmt.Errorf("--max-stack must be >= 0")
+	}
+	maxHeapObjects, err := cmd.Flags().GetInt("max-heap-objects")
+	if err != nil {
+		return fmt.Errorf("failed to get max-heap-objects flag: %w", err)
+	}
+	if maxHeapObjects < 0 {
+		return fmt.Errorf("--max-heap-objects must be >= 0")
+	}
+	maxHeapBytes, err := cmd.Flags().GetInt("max-heap-bytes")
+	if err != nil {
+		return fmt.Errorf("failed to get max-heap-bytes flag: %w", err)
+	}
+	if maxHeapBytes < 0 {
+		return fmt.Errorf("--max-heap-bytes must be >= 0")
+	}
+	heapReport, err := cmd.Flags().GetBool("heap-report")
+	if err != nil {
+		return fmt.Errorf("failed to get heap-report flag: %w", err)
+	}
 	unsafeRun, err := cmd.Flags().GetBool("unsafe")
 	if err != nil {
 		return fmt.Errorf("failed to get unsafe flag: %w", err)
@@ -154,10 +184,15 @@ func runExecution(cmd *cobra.Command, args []string) error {
 	if backendValue != string(buildpipeline.BackendVM) && backendValue != string(buildpipeline.BackendLLVM) {
 		return fmt.Errorf("unsupported backend: %s (supported: vm, llvm)", backendValue)
 	}
-	if backendValue != string(buildpipeline.BackendVM) && (vmTrace || vmDebug || vmDebugScript != "" || len(vmBreaks) > 0 || len(vmBreakFns) > 0 || vmRecordPath != "" || vmReplayPath != "" || fuzzScheduler || realTime) {
+	if backendValue != string(buildpipeline.BackendVM) && (vmTrace || vmDebug || vmDebugScript != "" || len(vmBreaks) > 0 || len(vmBreakFns) > 0 || vmRecordPath != "" || vmReplayPath != "" || fuzzScheduler || realTime || heapReport) {
 		return fmt.Errorf("VM-only flags require --backend=vm")
 	}
 
+	cfgSettings, err := readCfgSettings(cmd, "")
+	if err != nil {
+		return err
+	}
+
 	useTUI := shouldUseTUI(uiModeValue)
 	printTimings := showTimings || useTUI
 	files, fileErr := collectProjectFiles(targetPath, dirInfo)
@@ -175,6 +210,7 @@ func runExecution(cmd *cobra.Command, args []string) error {
 		AllowDiagnosticsError: unsafeRun,
 		Files:                 displayFiles,
 		Backend:               buildpipeline.Backend(backendValue),
+		Cfg:                   cfgSettings,
 	}
 
 	outputRoot := baseDir
@@ -267,6 +303,12 @@ func runExecution(cmd *cobra.Command, args []string) error {
 		Seed:          fuzzSeed,
 		TimerMode:     timerMode,
 	}
+	vmInstance.Options.MaxStackDepth = maxStack
+	vmInstance.MaxHeapObjects = maxHeapObjects
+	vmInstance.MaxHeapBytes = maxHeapBytes
+	if heapReport {
+		vmInstance.HeapReport = os.Stderr
+	}
 	if recorder != nil {
 		vmInstance.Recorder = recorder
 	}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// stdinArg is the path argument that makes single-file commands read source from stdin.
+	stdinArg = "-"
+	// stdinName is the display name of source read from stdin.
+	stdinName = "<stdin>"
+)
+
+// readStdinSource reads the whole command input; the content is later registered as a virtual file.
+func readStdinSource(cmd *cobra.Command) ([]byte, error) {
+	content, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var rootCmdOnce sync.Once
+
+// runWithStdin executes the root command with src on stdin and returns its stdout.
+func runWithStdin(t *testing.T, src string, args ...string) (string, error) {
+	t.Helper()
+	rootCmdOnce.Do(setupRootCmd)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = stdout })
+
+	rootCmd.SetIn(strings.NewReader(src))
+	rootCmd.SetArgs(args)
+	t.Cleanup(func() {
+		// PersistentPostRun is skipped on error, so release the timeout watchdog here
+		cleanupTimeout(rootCmd, nil)
+		rootCmd.SetIn(nil)
+		rootCmd.SetArgs(nil)
+	})
+	runErr := rootCmd.Execute()
+
+	if closeErr := w.Close(); closeErr != nil {
+		t.Fatalf("close pipe: %v", closeErr)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	return string(out), runErr
+}
+
+func TestDiagReadsSourceFromStdin(t *testing.T) {
+	out, runErr := runWithStdin(t, "fn main() -> int { return missing; }\n", "diag", "--color", "off", "-")
+	if runErr == nil {
+		t.Fatalf("expected diag to fail on unresolved symbol\n%s", out)
+	}
+	if !strings.Contains(out, "<stdin>:1:27: ERROR SEM3005") {
+		t.Fatalf("expected diagnostics to reference <stdin>, got:\n%s", out)
+	}
+}
+
+func TestTokenizeReadsSourceFromStdin(t *testing.T) {
+	out, runErr := runWithStdin(t, "fn main() {}\n", "tokenize", "-")
+	if runErr != nil {
+		t.Fatalf("tokenize -: %v\n%s", runErr, out)
+	}
+	if !strings.Contains(out, `KwFn            "fn" at 1:1-1:3`) || !strings.Contains(out, `Ident           "main" at 1:4-1:8`) {
+		t.Fatalf("expected tokens of the stdin source, got:\n%s", out)
+	}
+}
+
+func TestParseReadsSourceFromStdin(t *testing.T) {
+	out, runErr := runWithStdin(t, "fn main() {}\n", "parse", "--color", "off", "-")
+	if runErr != nil {
+		t.Fatalf("parse -: %v\n%s", runErr, out)
+	}
+	if !strings.HasPrefix(out, "<stdin> (span:") || !strings.Contains(out, "Item[0]: Fn") || !strings.Contains(out, "Name: main") {
+		t.Fatalf("expected the AST of the stdin source, got:\n%s", out)
+	}
+}
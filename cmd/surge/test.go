@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"surge/internal/buildpipeline"
+	"surge/internal/diagfmt"
+	"surge/internal/directive"
+	"surge/internal/driver"
+	"surge/internal/parser"
+	"surge/internal/vm"
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test [flags] file.sg",
+	Short: "Run the directive tests of a Surge file",
+	Long: `Execute the /// test: directive blocks of a Surge source file on the VM.
+Each block is one test; a failing test.eq/test.ne/test.assert line fails it.
+Exits with status 1 when any test fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: testExecution,
+}
+
+func init() {
+	testCmd.Flags().String("directives-filter", "test", "comma-separated directive namespaces to run")
+}
+
+func testExecution(cmd *cobra.Command, args []string) error {
+	filterStr, err := cmd.Flags().GetString("directives-filter")
+	if err != nil {
+		return fmt.Errorf("failed to get directives-filter flag: %w", err)
+	}
+	maxDiagnostics, err := cmd.Root().PersistentFlags().GetInt("max-diagnostics")
+	if err != nil {
+		return fmt.Errorf("failed to get max-diagnostics flag: %w", err)
+	}
+	var filter []string
+	for _, ns := range strings.Split(filterStr, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			filter = append(filter, ns)
+		}
+	}
+
+	filePath := args[0]
+	st, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat path: %w", err)
+	}
+	if st.IsDir() || filepath.Ext(filePath) != ".sg" {
+		return fmt.Errorf("surge test expects a .sg file, got %q", filePath)
+	}
+
+	diagRes, err := driver.DiagnoseWithOptions(cmd.Context(), filePath, &driver.DiagnoseOptions{
+		Stage:           driver.DiagnoseStageSema,
+		MaxDiagnostics:  maxDiagnostics,
+		DirectiveMode:   parser.DirectiveModeCollect,
+		DirectiveFilter: filter,
+	})
+	if err != nil {
+		return err
+	}
+	diagRes.MergeModuleDiagnostics()
+	if diagRes.Bag != nil && diagRes.Bag.HasErrors() {
+		prettyOpts, optsErr := prettyOutputOpts(cmd, os.Stderr)
+		if optsErr != nil {
+			return optsErr
+		}
+		prettyOpts.Context = 2
+		prettyOpts.ShowNotes = true
+		diagfmt.Pretty(os.Stderr, diagRes.Bag, diagRes.FileSet, prettyOpts)
+		return fmt.Errorf("diagnostics reported errors")
+	}
+
+	registry := diagRes.DirectiveRegistry
+	if registry == nil {
+		registry = directive.NewRegistry()
+	}
+	scenarios := registry.FilterByNamespace(filter)
+	var harness *directive.Harness
+	var compileRes buildpipeline.CompileResult
+	if len(scenarios) > 0 {
+		// #nosec G304 -- path comes from user-provided CLI argument
+		src, readErr := os.ReadFile(filePath)
+		if readErr != nil {
+			return fmt.Errorf("failed to read %s: %w", filePath, readErr)
+		}
+		harness, err = directive.BuildHarness(diagRes.Builder, diagRes.FileID, src, scenarios)
+		if err != nil {
+			return err
+		}
+		compileRes, err = compileHarness(cmd, filePath, harness, maxDiagnostics)
+		if err != nil {
+			return err
+		}
+	}
+
+	index := make(map[string]int, len(scenarios))
+	for i := range scenarios {
+		index[scenarios[i].FunctionName] = i
+	}
+	runner := directive.NewRunner(registry, directive.RunnerConfig{
+		Filter: filter,
+		Output: os.Stdout,
+		Exec: func(s *directive.Scenario) directive.Outcome {
+			i := index[s.FunctionName]
+			rt := vm.NewRuntimeWithArgs([]string{strconv.Itoa(i)})
+			vmInstance := vm.New(compileRes.MIR, rt, compileRes.Diagnose.FileSet, compileRes.Diagnose.Sema.TypeInterner, nil)
+			if vmErr := vmInstance.Run(); vmErr != nil {
+				return directive.Outcome{Status: directive.StatusFailed, Message: vmErr.Error()}
+			}
+			return harness.Outcome(i, vmInstance.ExitCode)
+		},
+	})
+	if result := runner.Run(); result.Failed > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// harnessFileName is the virtual name the harness is compiled under. It sits
+// next to the tested file, so relative imports keep resolving, but it is
+// never written to disk.
+const harnessFileName = "_surge_test_harness.sg"
+
+// compileHarness compiles the harness source from memory for the VM.
+func compileHarness(cmd *cobra.Command, filePath string, harness *directive.Harness, maxDiagnostics int) (buildpipeline.CompileResult, error) {
+	dir := filepath.Dir(filePath)
+	return buildpipeline.Compile(cmd.Context(), &buildpipeline.CompileRequest{
+		TargetPath:     filepath.Join(dir, harnessFileName),
+		BaseDir:        dir,
+		MaxDiagnostics: maxDiagnostics,
+		Backend:        buildpipeline.BackendVM,
+		Source:         []byte(harness.Source),
+	})
+}
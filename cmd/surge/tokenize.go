@@ -13,7 +13,7 @@ import (
 )
 
 var tokenizeCmd = &cobra.Command{
-	Use:   "tokenize [flags] <file.sg|directory>",
+	Use:   "tokenize [flags] <file.sg|directory|->",
 	Short: "Tokenize a surge source file or directory",
 	Long:  `Tokenize breaks down a surge source file or all *.sg files in a directory into their constituent tokens`,
 	Args:  cobra.ExactArgs(1),
@@ -44,32 +44,40 @@ func runTokenize(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get quiet flag: %w", err)
 	}
 
-	// Проверяем, файл это или директория
-	st, err := os.Stat(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to stat path: %w", err)
+	// Проверяем, файл это или директория ("-" — чтение из stdin)
+	isStdin := filePath == stdinArg
+	var st os.FileInfo
+	if !isStdin {
+		st, err = os.Stat(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to stat path: %w", err)
+		}
 	}
 
-	if !st.IsDir() {
+	if isStdin || !st.IsDir() {
 		// Токенизация одного файла
 		var result *driver.TokenizeResult
-		result, err = driver.Tokenize(filePath, maxDiagnostics)
-		if err != nil {
-			return fmt.Errorf("tokenization failed: %w", err)
+		if isStdin {
+			var content []byte
+			if content, err = readStdinSource(cmd); err != nil {
+				return err
+			}
+			result = driver.TokenizeSource(stdinName, content, maxDiagnostics)
+		} else {
+			result, err = driver.Tokenize(filePath, maxDiagnostics)
+			if err != nil {
+				return fmt.Errorf("tokenization failed: %w", err)
+			}
 		}
 
 		// Выводим диагностику в stderr, если есть
 		if result.Bag.HasErrors() || result.Bag.HasWarnings() {
-			var colorFlag string
-			colorFlag, err = cmd.Root().PersistentFlags().GetString("color")
+			var opts diagfmt.PrettyOpts
+			opts, err = prettyOutputOpts(cmd, os.Stderr)
 			if err != nil {
 				return err
 			}
-			useColor := colorFlag == "on" || (colorFlag == "auto" && isTerminal(os.Stderr))
-			opts := diagfmt.PrettyOpts{
-				Color:   useColor,
-				Context: 2,
-			}
+			opts.Context = 2
 			diagfmt.Pretty(os.Stderr, result.Bag, result.FileSet, opts)
 		}
 
@@ -100,16 +108,11 @@ func runTokenize(cmd *cobra.Command, args []string) error {
 	}
 
 	// Обрабатываем диагностику (они уже отсортированы)
-	var colorFlag string
-	colorFlag, err = cmd.Root().PersistentFlags().GetString("color")
+	prettyOpts, err := prettyOutputOpts(cmd, os.Stderr)
 	if err != nil {
 		return err
 	}
-	useColor := colorFlag == "on" || (colorFlag == "auto" && isTerminal(os.Stderr))
-	prettyOpts := diagfmt.PrettyOpts{
-		Color:   useColor,
-		Context: 2,
-	}
+	prettyOpts.Context = 2
 
 	for _, r := range results {
 		// Выводим диагностику в stderr, если есть
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"surge/internal/trace"
+)
+
+func TestPanicInTracedSpanDumpsRingBuffer(t *testing.T) {
+	ring := trace.NewRingTracer(64, trace.LevelDetail)
+	setupPanicHandler(ring, "", nil)
+	t.Cleanup(func() { setupPanicHandler(nil, "", nil) })
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	t.Cleanup(func() { os.Stderr = stderr })
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		defer dumpTraceOnPanic()
+
+		root := trace.Begin(ring, trace.ScopeDriver, "diagnose", 0)
+		parse := trace.Begin(ring, trace.ScopePass, "parse", root.ID())
+		parse.End("")
+		trace.Begin(ring, trace.ScopePass, "sema", root.ID())
+		panic("sema exploded")
+	}()
+
+	os.Stderr = stderr
+	if closeErr := w.Close(); closeErr != nil {
+		t.Fatalf("close pipe: %v", closeErr)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read stderr: %v", err)
+	}
+
+	if recovered != "sema exploded" {
+		t.Fatalf("expected the panic to propagate after the dump, got %v", recovered)
+	}
+	text := string(out)
+	for _, want := range []string{
+		"trace: panic detected: sema exploded",
+		"diagnose [driver] open\n",
+		"  parse [pass] ",
+		"  sema [pass] open\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in crash dump, got:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTraceFileKeepsStderrClean(t *testing.T) {
+	rootCmdOnce.Do(setupRootCmd)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "main.sg")
+	if err := os.WriteFile(src, []byte("fn main() -> int { return 0; }\n"), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	traceFile := filepath.Join(dir, "trace.log")
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	stderr, stdout := os.Stderr, os.Stdout
+	os.Stderr, os.Stdout = w, w
+	t.Cleanup(func() { os.Stderr, os.Stdout = stderr, stdout })
+
+	rootCmd.SetArgs([]string{"diag", "--color", "off", "--trace-file", traceFile, "--trace-level", "phase", src})
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		for name, value := range map[string]string{"trace-file": "", "trace-level": "off"} {
+			if err := rootCmd.PersistentFlags().Set(name, value); err != nil {
+				t.Errorf("reset --%s: %v", name, err)
+			}
+		}
+	})
+	runErr := rootCmd.Execute()
+
+	os.Stderr, os.Stdout = stderr, stdout
+	if closeErr := w.Close(); closeErr != nil {
+		t.Fatalf("close pipe: %v", closeErr)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if runErr != nil {
+		t.Fatalf("diag failed: %v\n%s", runErr, out)
+	}
+	if len(out) != 0 {
+		t.Fatalf("expected no terminal output with --trace-file, got:\n%s", out)
+	}
+
+	data, err := os.ReadFile(traceFile)
+	if err != nil {
+		t.Fatalf("read trace file: %v", err)
+	}
+	if !strings.Contains(string(data), "→ diagnose") {
+		t.Fatalf("expected phase events in trace file, got:\n%s", data)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -23,6 +24,11 @@ func setupTracing(cmd *cobra.Command) (func(), error) {
 		return nil, fmt.Errorf("failed to get trace flag: %w", err)
 	}
 
+	traceFile, err := root.PersistentFlags().GetString("trace-file")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trace-file flag: %w", err)
+	}
+
 	levelStr, err := root.PersistentFlags().GetString("trace-level")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get trace-level flag: %w", err)
@@ -55,7 +61,7 @@ func setupTracing(cmd *cobra.Command) (func(), error) {
 	}
 
 	// If level is off and no output specified, skip tracing
-	if level == trace.LevelOff && traceOutput == "" {
+	if level == trace.LevelOff && traceOutput == "" && traceFile == "" {
 		ctx := trace.WithTracer(cmd.Context(), trace.Nop)
 		cmd.SetContext(ctx)
 		return func() {}, nil
@@ -91,7 +97,7 @@ func setupTracing(cmd *cobra.Command) (func(), error) {
 	}
 
 	// Create tracer
-	tracer, err := trace.New(cfg)
+	tracer, err := newTracer(cfg, traceFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tracer: %w", err)
 	}
@@ -137,6 +143,35 @@ func setupTracing(cmd *cobra.Command) (func(), error) {
 	return cleanup, nil
 }
 
+// newTracer builds the --trace tracer and, with --trace-file, a stream tracer
+// appending to that file. Without --trace only the file receives events, so
+// stderr stays clean.
+func newTracer(cfg trace.Config, traceFile string) (trace.Tracer, error) {
+	if traceFile == "" {
+		return trace.New(cfg)
+	}
+	fileCfg := cfg
+	fileCfg.Mode = trace.ModeStream
+	fileCfg.Output = nil
+	fileCfg.OutputPath = traceFile
+	fileCfg.Append = true
+	fileTracer, err := trace.New(fileCfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.OutputPath == "" {
+		return fileTracer, nil
+	}
+	tracer, err := trace.New(cfg)
+	if err != nil {
+		if closeErr := fileTracer.Close(); closeErr != nil {
+			return nil, errors.Join(err, closeErr)
+		}
+		return nil, err
+	}
+	return trace.NewMultiTracer(cfg.Level, tracer, fileTracer), nil
+}
+
 // Global variables for panic recovery
 var (
 	panicTracer     trace.Tracer
@@ -164,32 +199,8 @@ func dumpTraceOnPanic() {
 			panicHeartbeat.Stop()
 		}
 
-		// Dump ring buffer if available
 		if panicTracer != nil {
-			if rt := findRingTracer(panicTracer); rt != nil && panicOutputPath != "" {
-				dumpPath := generateDumpPath(panicOutputPath, "panic")
-				// #nosec G304 -- dump path is derived from user-specified output path
-				if f, err := os.Create(dumpPath); err == nil {
-					if dumpErr := rt.Dump(f, trace.FormatText); dumpErr != nil {
-						_, printErr := fmt.Fprintf(os.Stderr, "trace: dump error: %v\n", dumpErr)
-						if printErr != nil {
-							panic(printErr)
-						}
-					} else {
-						_, printErr := fmt.Fprintf(os.Stderr, "trace: ring buffer saved to %s\n", dumpPath)
-						if printErr != nil {
-							panic(printErr)
-						}
-					}
-					if closeErr := f.Close(); closeErr != nil {
-						panic(closeErr)
-					}
-				} else {
-					if _, printErr := fmt.Fprintf(os.Stderr, "trace: failed to create dump file: %v\n", err); printErr != nil {
-						panic(printErr)
-					}
-				}
-			}
+			dumpRingOnCrash(panicTracer, panicOutputPath, "panic")
 
 			// Flush and close tracer
 			if err := panicTracer.Flush(); err != nil {
@@ -211,6 +222,45 @@ func dumpTraceOnPanic() {
 	}
 }
 
+// crashDumpSpans is how many of the most recent spans are printed on a crash.
+const crashDumpSpans = 32
+
+// dumpRingOnCrash prints the most recent spans with their nesting to stderr so
+// they can be pasted into a bug report. With an output path set, the whole ring
+// buffer is also saved to a file next to it.
+func dumpRingOnCrash(tracer trace.Tracer, outputPath, reason string) {
+	rt := findRingTracer(tracer)
+	if rt == nil {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "trace: last %d spans before %s (open = in progress):\n", crashDumpSpans, reason)
+	if err := rt.DumpRecentSpans(os.Stderr, crashDumpSpans); err != nil {
+		fmt.Fprintf(os.Stderr, "trace: dump error: %v\n", err)
+	}
+
+	if outputPath == "" {
+		return
+	}
+	dumpPath := generateDumpPath(outputPath, reason)
+	// #nosec G304 -- dump path is derived from user-specified output path
+	f, err := os.Create(dumpPath)
+	if err != nil {
+		if _, printErr := fmt.Fprintf(os.Stderr, "trace: failed to create dump file: %v\n", err); printErr != nil {
+			panic(printErr)
+		}
+		return
+	}
+	if dumpErr := rt.Dump(f, trace.FormatText); dumpErr != nil {
+		fmt.Fprintf(os.Stderr, "trace: dump error: %v\n", dumpErr)
+	} else {
+		fmt.Fprintf(os.Stderr, "trace: ring buffer saved to %s\n", dumpPath)
+	}
+	if closeErr := f.Close(); closeErr != nil {
+		panic(closeErr)
+	}
+}
+
 // findRingTracer tries to extract a RingTracer from the given tracer.
 // It handles both direct RingTracer and MultiTracer containing a RingTracer.
 func findRingTracer(tracer trace.Tracer) *trace.RingTracer {
@@ -226,7 +276,7 @@ func findRingTracer(tracer trace.Tracer) *trace.RingTracer {
 	// MultiTracer containing RingTracer - iterate through underlying tracers
 	if mt, ok := tracer.(*trace.MultiTracer); ok {
 		for _, t := range mt.Tracers() {
-			if rt, ok := t.(*trace.RingTracer); ok {
+			if rt := findRingTracer(t); rt != nil {
 				return rt
 			}
 		}
@@ -236,14 +286,14 @@ func findRingTracer(tracer trace.Tracer) *trace.RingTracer {
 }
 
 // setupSignalHandler installs signal handlers to save trace data on interruption.
-// When SIGINT or SIGTERM is received, it dumps the ring buffer (if available) and exits.
+// When SIGINT, SIGTERM or SIGABRT is received, it dumps the ring buffer (if available) and exits.
 func setupSignalHandler(tracer trace.Tracer, outputPath string, heartbeat *trace.Heartbeat) {
 	if tracer == nil {
 		return
 	}
 
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGABRT)
 
 	go func() {
 		sig := <-sigCh
@@ -254,25 +304,11 @@ func setupSignalHandler(tracer trace.Tracer, outputPath string, heartbeat *trace
 			heartbeat.Stop()
 		}
 
-		// Dump ring buffer if available
-		if rt := findRingTracer(tracer); rt != nil && outputPath != "" {
-			dumpPath := generateDumpPath(outputPath, "interrupt")
-			// #nosec G304 -- dump path is derived from user-specified output path
-			if f, err := os.Create(dumpPath); err == nil {
-				if dumpErr := rt.Dump(f, trace.FormatText); dumpErr != nil {
-					fmt.Fprintf(os.Stderr, "trace: dump error: %v\n", dumpErr)
-				} else {
-					fmt.Fprintf(os.Stderr, "trace: ring buffer saved to %s\n", dumpPath)
-				}
-				if closeErr := f.Close(); closeErr != nil {
-					panic(closeErr)
-				}
-			} else {
-				if _, printErr := fmt.Fprintf(os.Stderr, "trace: failed to create dump file: %v\n", err); printErr != nil {
-					panic(printErr)
-				}
-			}
+		reason := "interrupt"
+		if sig == syscall.SIGABRT {
+			reason = "abort"
 		}
+		dumpRingOnCrash(tracer, outputPath, reason)
 
 		// Flush and close tracer
 		if err := tracer.Flush(); err != nil {
@@ -289,8 +325,11 @@ func setupSignalHandler(tracer trace.Tracer, outputPath string, heartbeat *trace
 		}
 
 		// Exit with signal-appropriate code
-		if sig == syscall.SIGINT {
+		switch sig {
+		case syscall.SIGINT:
 			os.Exit(130) // 128 + SIGINT
+		case syscall.SIGABRT:
+			os.Exit(134) // 128 + SIGABRT
 		}
 		os.Exit(143) // 128 + SIGTERM
 	}()
@@ -90,6 +90,7 @@ var attrRegistry = map[string]AttrSpec{
 	"nonblocking":   {Name: "nonblocking", Targets: AttrTargetFn},
 	"drop":          {Name: "drop", Targets: AttrTargetStmt},
 	"failfast":      {Name: "failfast", Targets: AttrTargetBlock | AttrTargetFn},
+	"cfg":           {Name: "cfg", Targets: AttrTargetFn | AttrTargetType | AttrTargetLet},
 	"copy":          {Name: "copy", Targets: AttrTargetType},
 }
 
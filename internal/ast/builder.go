@@ -51,6 +51,13 @@ func NewBuilder(hints Hints, stringsInterner *source.Interner) *Builder {
 	}
 }
 
+// NodeCount returns the number of items, statements, expressions and type
+// expressions allocated so far.
+func (b *Builder) NodeCount() uint64 {
+	return uint64(b.Items.Arena.Len()) + uint64(b.Stmts.Arena.Len()) +
+		uint64(b.Exprs.Arena.Len()) + uint64(b.Types.Arena.Len())
+}
+
 // NewFile creates a new file ID.
 func (b *Builder) NewFile(sp source.Span) FileID {
 	return b.Files.New(sp)
@@ -302,6 +302,8 @@ const (
 	ExprLitFalse
 	// ExprLitNothing represents a nothing literal.
 	ExprLitNothing
+	// ExprLitChar represents a character literal; Value keeps the quoted source text.
+	ExprLitChar
 )
 
 // ExprIdentData holds identifier expression details.
@@ -431,6 +433,7 @@ type ExprStructField struct {
 // ExprStructData holds struct literal expression details.
 type ExprStructData struct {
 	Type             TypeID
+	Base             ExprID // `..base` spread; NoExprID when absent
 	Fields           []ExprStructField
 	FieldCommas      []source.Span
 	HasTrailingComma bool
@@ -504,10 +507,25 @@ type ExprTernaryData struct {
 	FalseExpr ExprID
 }
 
+// ComparePatternKind classifies the pattern of a compare arm.
+type ComparePatternKind uint8
+
+const (
+	// ComparePatternExpr is a general pattern: binding, tag, tuple, `nothing`, ...
+	ComparePatternExpr ComparePatternKind = iota
+	// ComparePatternLiteral is a literal pattern such as `1`, `-1`, `"s"` or `true`.
+	ComparePatternLiteral
+	// ComparePatternRange is a range pattern `lo..hi` or `lo..=hi` with literal bounds.
+	ComparePatternRange
+	// ComparePatternOr is an or-pattern `p1 | p2 | ...`; the arm matches if any alternative does.
+	ComparePatternOr
+)
+
 // ExprCompareArm represents a single arm in a compare expression.
 type ExprCompareArm struct {
 	Pattern     ExprID
 	PatternSpan source.Span
+	PatternKind ComparePatternKind
 	Guard       ExprID
 	Result      ExprID
 	IsFinally   bool
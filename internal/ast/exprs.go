@@ -263,9 +263,10 @@ func (e *Exprs) Ternary(id ExprID) (*ExprTernaryData, bool) {
 }
 
 // NewStruct creates a new struct literal expression.
-func (e *Exprs) NewStruct(span source.Span, typ TypeID, fields []ExprStructField, commas []source.Span, trailing, positional bool) ExprID {
+func (e *Exprs) NewStruct(span source.Span, typ TypeID, base ExprID, fields []ExprStructField, commas []source.Span, trailing, positional bool) ExprID {
 	payload := e.Structs.Allocate(ExprStructData{
 		Type:             typ,
+		Base:             base,
 		Fields:           append([]ExprStructField(nil), fields...),
 		FieldCommas:      append([]source.Span(nil), commas...),
 		HasTrailingComma: trailing,
@@ -510,6 +511,17 @@ func (e *Exprs) Compare(id ExprID) (*ExprCompareData, bool) {
 	return e.Compares.Get(uint32(expr.Payload)), true
 }
 
+// OrPatternAlternatives разворачивает цепочку `a | b | c` паттерна compare-арма
+// в список альтернатив слева направо. Группы не раскрываются: `(1 | 2)` остаётся
+// одним значением-паттерном.
+func (e *Exprs) OrPatternAlternatives(id ExprID) []ExprID {
+	bin, ok := e.Binary(id)
+	if !ok || bin == nil || bin.Op != ExprBinaryBitOr {
+		return []ExprID{id}
+	}
+	return append(e.OrPatternAlternatives(bin.Left), e.OrPatternAlternatives(bin.Right)...)
+}
+
 // NewSelect creates a new select expression.
 func (e *Exprs) NewSelect(span source.Span, arms []ExprSelectArm) ExprID {
 	payload := e.Selects.Allocate(ExprSelectData{
@@ -60,6 +60,7 @@ type Stmts struct {
 	ClassicFors *Arena[ForClassicStmt]
 	ForIns      *Arena[ForInStmt]
 	Drops       *Arena[DropStmt]
+	Jumps       *Arena[LoopJumpStmt]
 }
 
 // NewStmts creates and returns a new Stmts populated with internal arenas.
@@ -84,6 +85,7 @@ func NewStmts(capHint uint) *Stmts {
 		ClassicFors: NewArena[ForClassicStmt](capHint),
 		ForIns:      NewArena[ForInStmt](capHint),
 		Drops:       NewArena[DropStmt](capHint),
+		Jumps:       NewArena[LoopJumpStmt](capHint),
 	}
 }
 
@@ -158,25 +160,41 @@ type IfStmt struct {
 
 // WhileStmt represents a 'while' loop statement.
 type WhileStmt struct {
-	Cond ExprID
-	Body StmtID
+	Cond      ExprID
+	Body      StmtID
+	Label     source.StringID // `outer: while ...`; source.NoStringID, если метки нет
+	LabelSpan source.Span
 }
 
 // ForClassicStmt represents a C-style 'for' loop.
 type ForClassicStmt struct {
-	Init StmtID
-	Cond ExprID
-	Post ExprID
-	Body StmtID
+	Init      StmtID
+	Cond      ExprID
+	Post      ExprID
+	Body      StmtID
+	Label     source.StringID
+	LabelSpan source.Span
 }
 
 // ForInStmt represents a 'for ... in' loop.
 type ForInStmt struct {
+	// Index — имя счётчика в форме `for (i, x) in ...`; source.NoStringID, если его нет.
+	Index       source.StringID
+	IndexSpan   source.Span
 	Pattern     source.StringID
 	PatternSpan source.Span
 	Type        TypeID
 	Iterable    ExprID
 	Body        StmtID
+	Label       source.StringID
+	LabelSpan   source.Span
+}
+
+// LoopJumpStmt holds the target of a labeled `break label;` / `continue label;`.
+// Unlabeled break/continue carry no payload.
+type LoopJumpStmt struct {
+	Label     source.StringID
+	LabelSpan source.Span
 }
 
 // NewBlock creates a new block statement.
@@ -333,6 +351,76 @@ func (s *Stmts) NewContinue(span source.Span) StmtID {
 	return s.New(StmtContinue, span, NoPayloadID)
 }
 
+// NewLabeledBreak creates `break label;`.
+func (s *Stmts) NewLabeledBreak(span source.Span, label source.StringID, labelSpan source.Span) StmtID {
+	payload := PayloadID(s.Jumps.Allocate(LoopJumpStmt{Label: label, LabelSpan: labelSpan}))
+	return s.New(StmtBreak, span, payload)
+}
+
+// NewLabeledContinue creates `continue label;`.
+func (s *Stmts) NewLabeledContinue(span source.Span, label source.StringID, labelSpan source.Span) StmtID {
+	payload := PayloadID(s.Jumps.Allocate(LoopJumpStmt{Label: label, LabelSpan: labelSpan}))
+	return s.New(StmtContinue, span, payload)
+}
+
+// Jump returns the label target of a break/continue, or nil when it is unlabeled.
+func (s *Stmts) Jump(id StmtID) *LoopJumpStmt {
+	stmt := s.Get(id)
+	if stmt == nil || (stmt.Kind != StmtBreak && stmt.Kind != StmtContinue) || !stmt.Payload.IsValid() {
+		return nil
+	}
+	return s.Jumps.Get(uint32(stmt.Payload))
+}
+
+// SetLoopLabel attaches a label to a while/for loop. It reports false for other statements.
+func (s *Stmts) SetLoopLabel(id StmtID, label source.StringID, labelSpan source.Span) bool {
+	stmt := s.Get(id)
+	if stmt == nil {
+		return false
+	}
+	switch stmt.Kind {
+	case StmtWhile:
+		if data := s.While(id); data != nil {
+			data.Label, data.LabelSpan = label, labelSpan
+			return true
+		}
+	case StmtForClassic:
+		if data := s.ForClassic(id); data != nil {
+			data.Label, data.LabelSpan = label, labelSpan
+			return true
+		}
+	case StmtForIn:
+		if data := s.ForIn(id); data != nil {
+			data.Label, data.LabelSpan = label, labelSpan
+			return true
+		}
+	}
+	return false
+}
+
+// LoopLabel returns the label of a while/for loop; source.NoStringID when absent.
+func (s *Stmts) LoopLabel(id StmtID) source.StringID {
+	stmt := s.Get(id)
+	if stmt == nil {
+		return source.NoStringID
+	}
+	switch stmt.Kind {
+	case StmtWhile:
+		if data := s.While(id); data != nil {
+			return data.Label
+		}
+	case StmtForClassic:
+		if data := s.ForClassic(id); data != nil {
+			return data.Label
+		}
+	case StmtForIn:
+		if data := s.ForIn(id); data != nil {
+			return data.Label
+		}
+	}
+	return source.NoStringID
+}
+
 // NewIf creates a new if statement.
 func (s *Stmts) NewIf(span source.Span, cond ExprID, thenStmt, elseStmt StmtID) StmtID {
 	payload := PayloadID(s.Ifs.Allocate(IfStmt{
@@ -402,6 +490,16 @@ func (s *Stmts) NewForIn(span source.Span, pattern source.StringID, patternSpan
 	return s.New(StmtForIn, span, payload)
 }
 
+// NewForInIndexed creates a for-in loop that also binds the element index: `for (i, x) in xs`.
+func (s *Stmts) NewForInIndexed(span source.Span, index source.StringID, indexSpan source.Span, pattern source.StringID, patternSpan source.Span, typ TypeID, iterable ExprID, body StmtID) StmtID {
+	stmtID := s.NewForIn(span, pattern, patternSpan, typ, iterable, body)
+	if data := s.ForIn(stmtID); data != nil {
+		data.Index = index
+		data.IndexSpan = indexSpan
+	}
+	return stmtID
+}
+
 // ForIn returns the for-in loop statement data for the given StmtID.
 func (s *Stmts) ForIn(id StmtID) *ForInStmt {
 	stmt := s.Get(id)
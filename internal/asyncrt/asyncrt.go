@@ -57,6 +57,8 @@ const (
 	TaskKindSleep
 	// TaskKindTimeout indicates a timeout task.
 	TaskKindTimeout
+	// TaskKindParallel indicates one element step of a parallel map/reduce.
+	TaskKindParallel
 )
 
 // TaskResultKind describes how a task completed.
@@ -636,10 +638,7 @@ func (e *Executor) drainChannelPayloads() []any {
 		if ch == nil {
 			continue
 		}
-		payloads = append(payloads, ch.buf...)
-		clear(ch.buf)
-		ch.buf = nil
-		ch.head = 0
+		payloads = ch.bufDrain(payloads)
 
 		for i := range ch.sendq {
 			waiter := &ch.sendq[i]
@@ -17,8 +17,11 @@ type Channel struct {
 	cap    uint64
 	closed bool
 
-	buf  []any
-	head int
+	// buf is a ring buffer of buffered values: count items starting at head.
+	// It grows on demand up to cap slots.
+	buf   []any
+	head  int
+	count int
 
 	recvq      []TaskID
 	sendq      []chanWaiter
@@ -282,49 +285,82 @@ func (ch *Channel) bufLen() int {
 	if ch == nil {
 		return 0
 	}
-	return len(ch.buf) - ch.head
+	return ch.count
 }
 
 func (ch *Channel) bufLenU64() uint64 {
-	if ch == nil {
-		return 0
-	}
-	n := ch.bufLen()
-	if n <= 0 {
+	if ch == nil || ch.count <= 0 {
 		return 0
 	}
-	u, err := safecast.Conv[uint64](n)
+	u, err := safecast.Conv[uint64](ch.count)
 	if err != nil {
 		return 0
 	}
 	return u
 }
 
+// minChanRing is the initial ring size for buffered channels; the ring doubles
+// from here up to the channel capacity.
+const minChanRing = 8
+
 func (ch *Channel) bufPush(value any) {
 	if ch == nil {
 		return
 	}
-	ch.buf = append(ch.buf, value)
+	if ch.count == len(ch.buf) {
+		ch.bufGrow()
+	}
+	ch.buf[(ch.head+ch.count)%len(ch.buf)] = value
+	ch.count++
+}
+
+func (ch *Channel) bufGrow() {
+	size := len(ch.buf) * 2
+	if size < minChanRing {
+		size = minChanRing
+	}
+	if limit, err := safecast.Conv[int](ch.cap); err == nil && limit > 0 && size > limit {
+		size = limit
+	}
+	if size <= ch.count {
+		size = ch.count + 1
+	}
+	next := make([]any, size)
+	for i := range ch.count {
+		next[i] = ch.buf[(ch.head+i)%len(ch.buf)]
+	}
+	ch.buf = next
+	ch.head = 0
 }
 
 func (ch *Channel) bufPop() (any, bool) {
-	if ch == nil || ch.bufLen() == 0 {
+	if ch == nil || ch.count == 0 {
 		return nil, false
 	}
 	val := ch.buf[ch.head]
 	ch.buf[ch.head] = nil
-	ch.head++
-	if ch.head >= len(ch.buf) {
-		ch.buf = nil
-		ch.head = 0
-	} else if ch.head > 128 && ch.head*2 >= len(ch.buf) {
-		remaining := append([]any(nil), ch.buf[ch.head:]...)
-		ch.buf = remaining
+	ch.head = (ch.head + 1) % len(ch.buf)
+	ch.count--
+	if ch.count == 0 {
 		ch.head = 0
 	}
 	return val, true
 }
 
+// bufDrain appends the buffered values to out in FIFO order and releases the ring.
+func (ch *Channel) bufDrain(out []any) []any {
+	if ch == nil {
+		return out
+	}
+	for i := range ch.count {
+		out = append(out, ch.buf[(ch.head+i)%len(ch.buf)])
+	}
+	ch.buf = nil
+	ch.head = 0
+	ch.count = 0
+	return out
+}
+
 func (ch *Channel) popRecvWaiter(e *Executor) (TaskID, bool) {
 	for len(ch.recvq) > 0 {
 		taskID := ch.recvq[0]
@@ -0,0 +1,64 @@
+package asyncrt
+
+import "testing"
+
+func TestChanCapacityTwoBuffersBeforeParking(t *testing.T) {
+	exec := NewExecutor(Config{Deterministic: true})
+	id := exec.ChanNew(2)
+	sender := exec.Spawn(1, nil)
+	exec.SetCurrent(sender)
+
+	if !exec.ChanSendOrPark(id, 1) || !exec.ChanSendOrPark(id, 2) {
+		t.Fatal("expected the first two sends to fill the buffer without parking")
+	}
+	if exec.ChanTrySend(id, 3) {
+		t.Fatal("expected try_send on a full buffer to fail")
+	}
+	if exec.ChanSendOrPark(id, 3) {
+		t.Fatal("expected the third send to park")
+	}
+
+	for want := 1; want <= 3; want++ {
+		got, ok := exec.ChanTryRecv(id)
+		if !ok || got != want {
+			t.Fatalf("recv %d: got %v ok=%v", want, got, ok)
+		}
+	}
+	if _, ok := exec.ChanTryRecv(id); ok {
+		t.Fatal("expected the channel to be empty")
+	}
+	if task := exec.tasks[sender]; task.ResumeKind != ResumeChanSendAck {
+		t.Fatalf("expected the parked sender to be acked, got resume kind %v", task.ResumeKind)
+	}
+}
+
+func TestChanRingWrapsAndDrainsAfterClose(t *testing.T) {
+	exec := NewExecutor(Config{Deterministic: true})
+	id := exec.ChanNew(2)
+
+	next := 0
+	for round := 0; round < 5; round++ {
+		for exec.ChanTrySend(id, next) {
+			next++
+		}
+		got, ok := exec.ChanTryRecv(id)
+		if !ok || got != next-2 {
+			t.Fatalf("round %d: got %v ok=%v, want %d", round, got, ok, next-2)
+		}
+	}
+
+	exec.ChanClose(id)
+	if exec.ChanTrySend(id, -1) {
+		t.Fatal("expected send on a closed channel to fail")
+	}
+	got, ok := exec.ChanRecvOrPark(id)
+	if !ok || got != next-1 {
+		t.Fatalf("expected buffered value %d after close, got %v ok=%v", next-1, got, ok)
+	}
+	if _, ok := exec.ChanRecvOrPark(id); ok {
+		t.Fatal("expected closed empty channel to report no value")
+	}
+	if !exec.ChanCanRecv(id) {
+		t.Fatal("expected closed channel to be ready for recv")
+	}
+}
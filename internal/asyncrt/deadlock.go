@@ -0,0 +1,28 @@
+package asyncrt
+
+import "sort"
+
+// StuckTask describes an unfinished task left behind when the executor has
+// nothing ready to run and no timers or network waiters that could wake it.
+type StuckTask struct {
+	ID  TaskID
+	Key WakerKey // invalid when the task is not parked on a key
+}
+
+// StuckTasks lists unfinished tasks in id order together with the keys they
+// are parked on. It is meant to be called after NextReady reports no work:
+// at that point every listed task is waiting on something no one will signal.
+func (e *Executor) StuckTasks() []StuckTask {
+	if e == nil {
+		return nil
+	}
+	stuck := make([]StuckTask, 0, len(e.tasks))
+	for id, task := range e.tasks {
+		if task == nil || task.Status == TaskDone {
+			continue
+		}
+		stuck = append(stuck, StuckTask{ID: id, Key: e.parked[id]})
+	}
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].ID < stuck[j].ID })
+	return stuck
+}
@@ -0,0 +1,37 @@
+package asyncrt
+
+import "testing"
+
+func TestStuckTasksListsParkedTasksInOrder(t *testing.T) {
+	exec := NewExecutor(Config{Deterministic: true})
+	first := exec.Spawn(1, nil)
+	second := exec.Spawn(1, nil)
+	done := exec.Spawn(1, nil)
+	exec.MarkDone(done, TaskResultSuccess, nil)
+
+	for _, id := range []TaskID{first, second} {
+		if got, ok := exec.NextReady(); !ok || got != id {
+			t.Fatalf("expected task %d to be ready, got %d ok=%v", id, got, ok)
+		}
+		exec.SetCurrent(id)
+	}
+	exec.SetCurrent(first)
+	exec.ParkCurrent(JoinKey(second))
+	exec.SetCurrent(second)
+	exec.ParkCurrent(ChannelRecvKey(7))
+	exec.SetCurrent(0)
+
+	if _, ok := exec.NextReady(); ok {
+		t.Fatal("expected no ready tasks")
+	}
+	stuck := exec.StuckTasks()
+	if len(stuck) != 2 {
+		t.Fatalf("expected 2 stuck tasks, got %+v", stuck)
+	}
+	if stuck[0].ID != first || stuck[0].Key.String() != "join(task 2)" {
+		t.Fatalf("unexpected first stuck task %d on %s", stuck[0].ID, stuck[0].Key)
+	}
+	if stuck[1].ID != second || stuck[1].Key.String() != "chan_recv(#7)" {
+		t.Fatalf("unexpected second stuck task %d on %s", stuck[1].ID, stuck[1].Key)
+	}
+}
@@ -0,0 +1,6 @@
+package asyncrt
+
+// SpawnParallel registers a parallel map/reduce step task and enqueues it.
+func (e *Executor) SpawnParallel(state any) TaskID {
+	return e.spawnBuiltin(TaskKindParallel, state, false)
+}
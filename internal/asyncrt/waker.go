@@ -1,5 +1,7 @@
 package asyncrt
 
+import "fmt"
+
 // WakerKind identifies a wait queue category.
 type WakerKind uint8
 
@@ -85,6 +87,30 @@ func NetWriteKey(fd int) WakerKey {
 	return WakerKey{Kind: WakerNetWrite, A: uint64(fd)}
 }
 
+// String renders the key for diagnostics, e.g. "join(task 3)" or "chan_recv(#1)".
+func (k WakerKey) String() string {
+	switch k.Kind {
+	case WakerJoin:
+		return fmt.Sprintf("join(task %d)", k.A)
+	case WakerChannelRecv:
+		return fmt.Sprintf("chan_recv(#%d)", k.A)
+	case WakerChannelSend:
+		return fmt.Sprintf("chan_send(#%d)", k.A)
+	case WakerTimer:
+		return fmt.Sprintf("timer(#%d)", k.A)
+	case WakerSelect:
+		return fmt.Sprintf("select(#%d)", k.A)
+	case WakerNetAccept:
+		return fmt.Sprintf("net_accept(fd %d)", k.A)
+	case WakerNetRead:
+		return fmt.Sprintf("net_read(fd %d)", k.A)
+	case WakerNetWrite:
+		return fmt.Sprintf("net_write(fd %d)", k.A)
+	default:
+		return "none"
+	}
+}
+
 // Waiter represents a task waiting on a key (optionally as part of a select).
 type Waiter struct {
 	TaskID   TaskID
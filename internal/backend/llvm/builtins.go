@@ -1,6 +1,8 @@
 // Package llvm implements the LLVM IR backend.
 package llvm
 
+import "fmt"
+
 type builtinDecl struct {
 	name   string
 	ret    string
@@ -8,17 +10,22 @@ type builtinDecl struct {
 }
 
 func runtimeDecls() []builtinDecl {
-	return []builtinDecl{
+	decls := []builtinDecl{
 		{name: "rt_alloc", ret: "ptr", params: []string{"i64", "i64"}},
 		{name: "rt_free", ret: "void", params: []string{"ptr", "i64", "i64"}},
+		{name: "rt_release", ret: "void", params: []string{"ptr", "i64", "i64", "i64"}},
 		{name: "rt_realloc", ret: "ptr", params: []string{"ptr", "i64", "i64", "i64"}},
 		{name: "llvm.trap", ret: "void", params: nil},
+		{name: "llvm.lifetime.start.p0", ret: "void", params: []string{"i64", "ptr"}},
+		{name: "llvm.lifetime.end.p0", ret: "void", params: []string{"i64", "ptr"}},
 		{name: "rt_memcpy", ret: "void", params: []string{"ptr", "ptr", "i64"}},
 		{name: "rt_memmove", ret: "void", params: []string{"ptr", "ptr", "i64"}},
 		{name: "rt_array_is_view", ret: "i1", params: []string{"ptr"}},
 		{name: "rt_array_slice", ret: "ptr", params: []string{"ptr", "ptr", "i64"}},
 		{name: "rt_array_slice_fixed", ret: "ptr", params: []string{"ptr", "ptr", "i64", "i64"}},
 		{name: "rt_array_sync_views", ret: "void", params: []string{"ptr"}},
+		{name: "rt_clone_elems", ret: "void", params: []string{"ptr", "ptr", "i64", "i64", "ptr"}},
+		{name: "rt_array_clone", ret: "ptr", params: []string{"ptr", "i64", "i64", "ptr"}},
 		{name: "rt_array_append_raw_bytes", ret: "void", params: []string{"ptr", "ptr", "i64"}},
 		{name: "rt_byte_array_append_range", ret: "void", params: []string{"ptr", "ptr", "i64", "i64"}},
 		{name: "rt_byte_array_drop_prefix", ret: "void", params: []string{"ptr", "i64"}},
@@ -67,10 +74,14 @@ func runtimeDecls() []builtinDecl {
 		{name: "rt_net_wait_accept", ret: "i1", params: []string{"ptr"}},
 		{name: "rt_net_wait_readable", ret: "i1", params: []string{"ptr"}},
 		{name: "rt_net_wait_writable", ret: "i1", params: []string{"ptr"}},
+		{name: "rt_init", ret: "void", params: []string{"i32", "ptr"}},
 		{name: "rt_exit", ret: "void", params: []string{"i64"}},
+		{name: "rt_finish", ret: "i32", params: []string{"i64"}},
 		{name: "rt_panic", ret: "void", params: []string{"ptr", "i64"}},
 		{name: "rt_panic_numeric", ret: "void", params: []string{"ptr", "i64"}},
 		{name: "rt_panic_bounds", ret: "void", params: []string{"i64", "i64", "i64"}},
+		{name: "rt_panic_div_zero", ret: "void", params: nil},
+		{name: "rt_panic_overflow", ret: "void", params: nil},
 		{name: "rt_monotonic_now", ret: "i64", params: nil},
 		{name: "rt_worker_count", ret: "i64", params: nil},
 		{name: "rt_heap_stats", ret: "ptr", params: nil},
@@ -82,6 +93,7 @@ func runtimeDecls() []builtinDecl {
 		{name: "rt_string_len_bytes", ret: "i64", params: []string{"ptr"}},
 		{name: "rt_string_index", ret: "i32", params: []string{"ptr", "i64"}},
 		{name: "rt_string_slice", ret: "ptr", params: []string{"ptr", "ptr"}},
+		{name: "rt_string_clone", ret: "ptr", params: []string{"ptr"}},
 		{name: "rt_string_concat", ret: "ptr", params: []string{"ptr", "ptr"}},
 		{name: "rt_string_repeat", ret: "ptr", params: []string{"ptr", "i64"}},
 		{name: "rt_string_eq", ret: "i1", params: []string{"ptr", "ptr"}},
@@ -157,6 +169,13 @@ func runtimeDecls() []builtinDecl {
 		{name: "rt_task_cancel", ret: "void", params: []string{"ptr"}},
 		{name: "rt_task_clone", ret: "ptr", params: []string{"ptr"}},
 		{name: "rt_blocking_submit", ret: "ptr", params: []string{"i64", "ptr", "i64", "i64"}},
+		{name: "rt_parallel_map", ret: "ptr", params: []string{"ptr", "i64", "i64", "i64", "ptr", "ptr"}},
+		{name: "rt_parallel_reduce", ret: "void", params: []string{"ptr", "i64", "ptr", "ptr", "ptr"}},
+		{name: "rt_debug_quote", ret: "ptr", params: []string{"ptr"}},
+		{name: "rt_debug_array", ret: "ptr", params: []string{"ptr", "i64", "i64", "ptr"}},
+		{name: "rt_debug_struct", ret: "ptr", params: []string{"ptr", "i64", "ptr", "ptr"}},
+		{name: "rt_debug_tuple", ret: "ptr", params: []string{"i64", "ptr"}},
+		{name: "rt_debug_tag", ret: "ptr", params: []string{"ptr", "i64", "ptr"}},
 		{name: "rt_timeout_poll", ret: "i8", params: []string{"ptr", "i64", "ptr"}},
 		{name: "rt_select_poll_tasks", ret: "i64", params: []string{"i64", "ptr", "i64"}},
 		{name: "rt_select_poll", ret: "i64", params: []string{"i64", "ptr", "ptr", "ptr", "ptr", "i64"}},
@@ -192,6 +211,29 @@ func runtimeDecls() []builtinDecl {
 		{name: "rt_range_int_to_end", ret: "ptr", params: []string{"ptr", "i1"}},
 		{name: "rt_range_int_full", ret: "ptr", params: []string{"i1"}},
 	}
+	return append(decls, overflowIntrinsicDecls()...)
+}
+
+// overflowIntrinsicDecls declares llvm.{s,u}{add,sub,mul}.with.overflow for every fixed integer width.
+func overflowIntrinsicDecls() []builtinDecl {
+	decls := make([]builtinDecl, 0, 24)
+	for _, bits := range []int{8, 16, 32, 64} {
+		ty := fmt.Sprintf("i%d", bits)
+		for _, sign := range []string{"s", "u"} {
+			for _, op := range []string{"add", "sub", "mul"} {
+				decls = append(decls, builtinDecl{
+					name:   overflowIntrinsic(sign+op, ty),
+					ret:    fmt.Sprintf("{%s, i1}", ty),
+					params: []string{ty, ty},
+				})
+			}
+		}
+	}
+	return decls
+}
+
+func overflowIntrinsic(op, ty string) string {
+	return fmt.Sprintf("llvm.%s.with.overflow.%s", op, ty)
 }
 
 func runtimeSigMap() map[string]funcSig {
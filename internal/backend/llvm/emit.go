@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"surge/internal/layout"
 	"surge/internal/mir"
 	"surge/internal/symbols"
 	"surge/internal/types"
@@ -30,17 +31,32 @@ type stringConst struct {
 
 // Emitter generates LLVM IR from MIR.
 type Emitter struct {
-	mod          *mir.Module
-	types        *types.Interner
-	syms         *symbols.Table
-	buf          strings.Builder
-	stringConsts map[string]*stringConst
-	fnRefs       map[mir.FuncID]struct{}
-	funcNames    map[mir.FuncID]string
-	funcSigs     map[mir.FuncID]funcSig
-	globalNames  map[mir.GlobalID]string
-	runtimeSigs  map[string]funcSig
-	paramCounts  map[mir.FuncID]int
+	mod                 *mir.Module
+	types               *types.Interner
+	syms                *symbols.Table
+	buf                 strings.Builder
+	stringConsts        map[string]*stringConst
+	stringConstsByBytes map[string]*stringConst
+	fnRefs              map[mir.FuncID]struct{}
+	funcNames           map[mir.FuncID]string
+	funcSigs            map[mir.FuncID]funcSig
+	globalNames         map[mir.GlobalID]string
+	runtimeSigs         map[string]funcSig
+	paramCounts         map[mir.FuncID]int
+	debugFmts           map[types.TypeID]string
+	cloneFns            map[types.TypeID]string
+	opts                EmitOptions
+}
+
+// EmitOptions tunes LLVM IR generation.
+type EmitOptions struct {
+	// NoRuntimeChecks elides division-by-zero guards on integer division and modulo
+	// and overflow checks on fixed-width integer add, sub and mul.
+	NoRuntimeChecks bool
+	// TargetTriple overrides the module's target triple; empty uses the layout
+	// target of the module. It must have a layout (see layout.TargetForTriple)
+	// matching the one the module was lowered with.
+	TargetTriple string
 }
 
 type funcEmitter struct {
@@ -52,6 +68,7 @@ type funcEmitter struct {
 	addrOfTargets   map[mir.LocalID]addrOfTarget
 	paramLocals     []mir.LocalID
 	blockTerminated bool
+	startExitCode   string // __surge_start: exit code of the current block
 }
 
 const (
@@ -64,21 +81,34 @@ const (
 
 // EmitModule converts a MIR module into an LLVM IR string.
 func EmitModule(mod *mir.Module, typesIn *types.Interner, symTable *symbols.Table) (string, error) {
+	return EmitModuleWithOptions(mod, typesIn, symTable, EmitOptions{})
+}
+
+// EmitModuleWithOptions converts a MIR module into an LLVM IR string using opts.
+func EmitModuleWithOptions(mod *mir.Module, typesIn *types.Interner, symTable *symbols.Table, opts EmitOptions) (string, error) {
 	e := &Emitter{
-		mod:          mod,
-		types:        typesIn,
-		syms:         symTable,
-		stringConsts: make(map[string]*stringConst),
-		fnRefs:       make(map[mir.FuncID]struct{}),
-		funcNames:    make(map[mir.FuncID]string),
-		funcSigs:     make(map[mir.FuncID]funcSig),
-		globalNames:  make(map[mir.GlobalID]string),
-		runtimeSigs:  runtimeSigMap(),
+		mod:                 mod,
+		types:               typesIn,
+		syms:                symTable,
+		stringConsts:        make(map[string]*stringConst),
+		stringConstsByBytes: make(map[string]*stringConst),
+		fnRefs:              make(map[mir.FuncID]struct{}),
+		funcNames:           make(map[mir.FuncID]string),
+		funcSigs:            make(map[mir.FuncID]funcSig),
+		globalNames:         make(map[mir.GlobalID]string),
+		runtimeSigs:         runtimeSigMap(),
+		debugFmts:           make(map[types.TypeID]string),
+		cloneFns:            make(map[types.TypeID]string),
+		opts:                opts,
 	}
 	if mod == nil {
 		return "", nil
 	}
+	if err := e.checkTargetTriple(); err != nil {
+		return "", err
+	}
 	e.collectStringConsts()
+	e.collectDebugFormats()
 	e.ensureStringConst("parse error")
 	e.ensureStringConst("failed to parse \\\"")
 	e.ensureStringConst("\\\" as int: invalid numeric format: \\\"")
@@ -139,6 +169,7 @@ func EmitModule(mod *mir.Module, typesIn *types.Interner, symTable *symbols.Tabl
 	if err := e.collectParamCounts(); err != nil {
 		return "", err
 	}
+	e.mergeIdenticalBlocks()
 	if err := e.prepareFunctions(); err != nil {
 		return "", err
 	}
@@ -151,17 +182,56 @@ func EmitModule(mod *mir.Module, typesIn *types.Interner, symTable *symbols.Tabl
 	if err := e.emitFunctions(); err != nil {
 		return "", err
 	}
+	e.emitMainWrapper()
 	if err := e.emitPollDispatch(); err != nil {
 		return "", err
 	}
 	if err := e.emitBlockingDispatch(); err != nil {
 		return "", err
 	}
+	if err := e.emitParallelThunks(); err != nil {
+		return "", err
+	}
+	if err := e.emitDebugFormatters(); err != nil {
+		return "", err
+	}
+	if err := e.emitCloneHelpers(); err != nil {
+		return "", err
+	}
 	return e.buf.String(), nil
 }
 
+// layoutTarget returns the target the module's type layout was computed for.
+func (e *Emitter) layoutTarget() layout.Target {
+	if e.mod != nil && e.mod.Meta != nil && e.mod.Meta.Layout != nil {
+		return e.mod.Meta.Layout.Target
+	}
+	return layout.X86_64LinuxGNU()
+}
+
+// checkTargetTriple rejects an overriding triple without a layout or with one
+// that differs from the layout the module was lowered with.
+func (e *Emitter) checkTargetTriple() error {
+	if e.opts.TargetTriple == "" {
+		return nil
+	}
+	target, err := layout.TargetForTriple(e.opts.TargetTriple)
+	if err != nil {
+		return err
+	}
+	lowered := e.layoutTarget()
+	if target.PtrSize != lowered.PtrSize || target.PtrAlign != lowered.PtrAlign {
+		return fmt.Errorf("target triple %q does not match the layout of %q the module was lowered with", target.Triple, lowered.Triple)
+	}
+	return nil
+}
+
 func (e *Emitter) emitPreamble() {
-	e.buf.WriteString("target triple = \"x86_64-linux-gnu\"\n\n")
+	triple := e.opts.TargetTriple
+	if triple == "" {
+		triple = e.layoutTarget().Triple
+	}
+	fmt.Fprintf(&e.buf, "target triple = %q\n\n", triple)
 }
 
 func (e *Emitter) emitRuntimeDecls() {
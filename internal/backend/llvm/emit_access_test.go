@@ -0,0 +1,42 @@
+package llvm
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestEmitFieldAndIndexReadsAsCallArguments(t *testing.T) {
+	sourceCode := `type Point = { x: int32, y: int32 }
+
+fn take(v: int32) -> int32 {
+    return v;
+}
+
+@entrypoint
+fn main() -> int32 {
+    let p: Point = { x = 3, y = 4 };
+    let xs: int32[3] = [5, 6, 7];
+    return take(p.y) + take(xs[2]);
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	// p.y: RValueField — указатель на поле по смещению 4 и load с типом поля.
+	fieldRead := regexp.MustCompile(`(%t\d+) = getelementptr inbounds i8, ptr %t\d+, i64 4\n  %t\d+ = load i32, ptr (%t\d+)`)
+	if m := fieldRead.FindStringSubmatch(ir); m == nil || m[1] != m[2] {
+		t.Fatalf("expected p.y to load i32 from field offset 4:\n%s", ir)
+	}
+	// xs[2]: RValueIndex — проверка границ, затем load элемента по смещению index*4.
+	if !regexp.MustCompile(`icmp sge i64 %t\d+, 3`).MatchString(ir) {
+		t.Fatalf("expected xs[2] to be bounds-checked against length 3:\n%s", ir)
+	}
+	indexRead := regexp.MustCompile(`mul i64 %t\d+, 4\n  (%t\d+) = getelementptr inbounds i8, ptr %t\d+, i64 %t\d+\n  %t\d+ = load i32, ptr (%t\d+)`)
+	if m := indexRead.FindStringSubmatch(ir); m == nil || m[1] != m[2] {
+		t.Fatalf("expected xs[2] to load i32 from the element slot:\n%s", ir)
+	}
+	calls := regexp.MustCompile(`call i32 @fn\.\d+\(i32 %t\d+\)`).FindAllString(ir, -1)
+	if len(calls) < 2 {
+		t.Fatalf("expected both reads to be passed to take, got %d calls:\n%s", len(calls), ir)
+	}
+}
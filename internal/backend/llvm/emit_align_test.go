@@ -0,0 +1,46 @@
+package llvm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEmitAlignOverrideReachesRtAlloc(t *testing.T) {
+	sourceCode := `@align(64)
+type Line = { a: uint8, b: uint32 }
+
+tag Full(int);
+
+@align(32)
+type Slot = Full(int) | nothing;
+
+fn make_line() -> Line { return Line { a = 1:uint8, b = 2:uint32 }; }
+
+fn make_slot() -> Slot { return Full(7); }
+
+@entrypoint
+fn main() -> int {
+    let l = make_line();
+    let s = make_slot();
+    return 0;
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"make_line": "call ptr @rt_alloc(i64 64, i64 64)",
+		"make_slot": "call ptr @rt_alloc(i64 32, i64 32)",
+	} {
+		fn := findMIRFunc(t, mirMod, name)
+		body := findLLVMFuncBody(t, ir, fmt.Sprintf("fn.%d", fn.ID))
+		if !strings.Contains(body, want) {
+			t.Fatalf("%s: expected %q:\n%s", name, want, body)
+		}
+	}
+}
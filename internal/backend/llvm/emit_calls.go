@@ -50,9 +50,6 @@ func (fe *funcEmitter) emitCall(ins *mir.Instr) error {
 	if handled, err := fe.emitTaskCancelIntrinsic(call); handled {
 		return err
 	}
-	if handled, err := fe.emitCloneIntrinsic(call); handled {
-		return err
-	}
 	if handled, err := fe.emitToIntrinsic(call); handled {
 		return err
 	}
@@ -106,7 +106,7 @@ fn main() -> int {
 	if strings.Contains(body, "rt_bigint_from_literal") || strings.Contains(body, "rt_bigint_to_i64") {
 		t.Fatalf("fixed-width literal casts should not materialize BigInt in loop_fixed:\n%s", body)
 	}
-	for _, want := range []string{"store i64 10000", "store i64 1", "add i64", "icmp slt i64"} {
+	for _, want := range []string{"store i64 10000", "store i64 1", "sadd.with.overflow.i64", "icmp slt i64"} {
 		if !strings.Contains(body, want) {
 			t.Fatalf("expected %q in loop_fixed IR:\n%s", want, body)
 		}
@@ -184,7 +184,7 @@ func findI64FunctionBodyContaining(t *testing.T, ir, needle string) string {
 
 	re := regexp.MustCompile(`(?s)define i64 @fn\.\d+\(\) \{.*?\n\}`)
 	for _, body := range re.FindAllString(ir, -1) {
-		if strings.Contains(body, needle) && strings.Contains(body, "add i64") {
+		if strings.Contains(body, needle) && strings.Contains(body, "add.with.overflow.i64") {
 			return body
 		}
 	}
@@ -206,6 +206,11 @@ func emitLLVMFromSource(t *testing.T, sourceCode string) string {
 
 func lowerMIRFromSource(t *testing.T, sourceCode string) (*mir.Module, *driver.DiagnoseResult) {
 	t.Helper()
+	return lowerMIRFromSourceWithOptions(t, sourceCode, mir.LowerOptions{})
+}
+
+func lowerMIRFromSourceWithOptions(t *testing.T, sourceCode string, lowerOpts mir.LowerOptions) (*mir.Module, *driver.DiagnoseResult) {
+	t.Helper()
 
 	tmpFile, err := os.CreateTemp(t.TempDir(), "emit-call-*.sg")
 	if err != nil {
@@ -257,7 +262,7 @@ func lowerMIRFromSource(t *testing.T, sourceCode string) (*mir.Module, *driver.D
 	if err != nil {
 		t.Fatalf("monomorphize: %v", err)
 	}
-	mirMod, err := mir.LowerModule(mm, result.Sema)
+	mirMod, err := mir.LowerModuleWithOptions(mm, result.Sema, lowerOpts)
 	if err != nil {
 		t.Fatalf("lower to MIR: %v", err)
 	}
@@ -280,3 +285,37 @@ func lowerMIRFromSource(t *testing.T, sourceCode string) (*mir.Module, *driver.D
 
 	return mirMod, result
 }
+
+func TestEmitMutSelfArrayMethodThroughStructField(t *testing.T) {
+	sourceCode := `type Entry = { name: string, borrowers: string[] };
+
+extern<Entry> {
+    fn lend(self: &mut Entry, who: string) -> nothing {
+        self.borrowers.push(who);
+    }
+}
+
+@entrypoint
+fn main() -> int {
+    let mut shelf: Entry[] = [{ name = "a", borrowers = [] }];
+    shelf[0].lend("x");
+    shelf[0].borrowers.push("y");
+    return len(shelf[0].borrowers) to int;
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	// borrowers лежит по смещению 8; в push должен уходить указатель на само поле.
+	fieldArgRe := regexp.MustCompile(`(?s)(%t\d+) = getelementptr inbounds i8, ptr %t\d+, i64 8\n(?:[^\n]*\n){0,3}?\s+call void @fn\.\d+\(ptr (%t\d+), ptr %t\d+\)`)
+	matches := fieldArgRe.FindAllStringSubmatch(ir, -1)
+	passed := 0
+	for _, m := range matches {
+		if m[1] == m[2] {
+			passed++
+		}
+	}
+	if passed < 2 {
+		t.Fatalf("expected borrowers field pointer passed to push in lend and main, got %d:\n%s", passed, ir)
+	}
+}
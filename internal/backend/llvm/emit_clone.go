@@ -0,0 +1,317 @@
+package llvm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"surge/internal/mir"
+	"surge/internal/types"
+)
+
+// __clone and clone(&x) on non-Copy values make a deep copy with the same
+// semantics as the VM (internal/vm/clone.go). Every type whose copy needs more
+// than its bytes gets a helper with a uniform signature:
+//
+//	void @__surge_clone.<type>(ptr dst, ptr src)
+//
+// It reads the value stored at src and writes an independent copy to dst.
+// Strings go through rt_string_clone, arrays through rt_array_clone and
+// rt_clone_elems, structs and tags are copied field by field. A nested struct
+// or tag whose type defines __clone is copied by calling it with src as the
+// reference.
+
+func cloneFnName(id types.TypeID) string {
+	return fmt.Sprintf("__surge_clone.%d", id)
+}
+
+// cloneFnFor returns the helper for id, registering it for emission.
+func (e *Emitter) cloneFnFor(id types.TypeID) string {
+	id = resolveValueType(e.types, id)
+	name, ok := e.cloneFns[id]
+	if !ok {
+		name = cloneFnName(id)
+		e.cloneFns[id] = name
+	}
+	return name
+}
+
+// userCloneFunc returns the __clone method declared for id, if any.
+func (e *Emitter) userCloneFunc(id types.TypeID) (mir.FuncID, bool) {
+	if e.mod == nil || e.mod.Meta == nil || id == types.NoTypeID {
+		return mir.NoFuncID, false
+	}
+	fnID, ok := e.mod.Meta.CloneFuncs[resolveValueType(e.types, id)]
+	return fnID, ok
+}
+
+// needsDeepClone reports whether copying a value of id must do more than copy
+// its bytes.
+func (e *Emitter) needsDeepClone(id types.TypeID) bool {
+	typesIn := e.types
+	id = resolveValueType(typesIn, id)
+	if isStringLike(typesIn, id) {
+		return true
+	}
+	if _, ok := e.userCloneFunc(id); ok {
+		return true
+	}
+	if _, _, ok := arrayElemType(typesIn, id); ok {
+		return true
+	}
+	tt, ok := typesIn.Lookup(id)
+	if !ok {
+		return false
+	}
+	switch tt.Kind {
+	case types.KindStruct:
+		fe := &funcEmitter{emitter: e}
+		return !fe.isRuntimeOwnedStruct(id)
+	case types.KindUnion:
+		return e.hasTagLayout(id)
+	}
+	return false
+}
+
+func (e *Emitter) emitCloneHelpers() error {
+	emitted := make(map[types.TypeID]struct{}, len(e.cloneFns))
+	for len(emitted) < len(e.cloneFns) {
+		ids := make([]types.TypeID, 0, len(e.cloneFns))
+		for id := range e.cloneFns {
+			if _, ok := emitted[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, id := range ids {
+			emitted[id] = struct{}{}
+			fe := &funcEmitter{emitter: e}
+			fmt.Fprintf(&e.buf, "define void @%s(ptr %%dst, ptr %%src) {\n", e.cloneFns[id])
+			fmt.Fprintf(&e.buf, "entry:\n")
+			if err := fe.emitCloneBody(id); err != nil {
+				return fmt.Errorf("clone for %s: %w", types.Label(e.types, id), err)
+			}
+			fmt.Fprintf(&e.buf, "  ret void\n")
+			fmt.Fprintf(&e.buf, "}\n\n")
+		}
+	}
+	return nil
+}
+
+// emitDeepClone stores a deep copy of the value at src (of type id) into dst.
+func (fe *funcEmitter) emitDeepClone(dst, src string, id types.TypeID) {
+	if isStringLike(fe.emitter.types, id) {
+		str := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %s\n", str, src)
+		out := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_string_clone(ptr %s)\n", out, str)
+		fmt.Fprintf(&fe.emitter.buf, "  store ptr %s, ptr %s\n", out, dst)
+		return
+	}
+	fmt.Fprintf(&fe.emitter.buf, "  call void @%s(ptr %s, ptr %s)\n", fe.emitter.cloneFnFor(id), dst, src)
+}
+
+// emitCloneSource returns the address of the value a clone call reads and its
+// type. References are used as is; plain values are addressed in place.
+func (fe *funcEmitter) emitCloneSource(op *mir.Operand) (addr string, typeID types.TypeID, err error) {
+	typeID = op.Type
+	if typeID == types.NoTypeID && op.Kind != mir.OperandConst {
+		if base, baseErr := fe.placeBaseType(op.Place); baseErr == nil {
+			typeID = base
+		}
+	}
+	if isRefType(fe.emitter.types, typeID) || op.Kind == mir.OperandAddrOf || op.Kind == mir.OperandAddrOfMut {
+		if elem, ok := derefType(fe.emitter.types, typeID); ok {
+			typeID = elem
+		}
+		addr, _, err = fe.emitValueOperand(op)
+		return addr, typeID, err
+	}
+	addr, err = fe.emitOperandAddr(op)
+	return addr, typeID, err
+}
+
+func (fe *funcEmitter) emitCloneBody(id types.TypeID) error {
+	typesIn := fe.emitter.types
+	if isStringLike(typesIn, id) {
+		fe.emitDeepClone("%dst", "%src", id)
+		return nil
+	}
+	if fnID, ok := fe.emitter.userCloneFunc(id); ok {
+		sig := fe.emitter.funcSigs[fnID]
+		out := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = call %s @%s(ptr %%src)\n", out, sig.ret, fe.emitter.funcNames[fnID])
+		fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %%dst\n", sig.ret, out)
+		return nil
+	}
+	if elem, dynamic, ok := arrayElemType(typesIn, id); ok {
+		return fe.emitCloneArray(id, elem, dynamic)
+	}
+	if tt, ok := typesIn.Lookup(id); ok {
+		switch tt.Kind {
+		case types.KindStruct:
+			if !fe.isRuntimeOwnedStruct(id) {
+				return fe.emitCloneStruct(id)
+			}
+		case types.KindUnion:
+			if fe.emitter.hasTagLayout(id) {
+				return fe.emitCloneTag(id)
+			}
+		}
+	}
+	llvmTy, err := llvmValueType(typesIn, id)
+	if err != nil {
+		return err
+	}
+	val := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load %s, ptr %%src\n", val, llvmTy)
+	fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %%dst\n", llvmTy, val)
+	return nil
+}
+
+// elemCloneFn returns the helper operand for rt_clone_elems: null when the
+// elements are plain bytes.
+func (fe *funcEmitter) elemCloneFn(elem types.TypeID) string {
+	if !fe.emitter.needsDeepClone(elem) {
+		return "null"
+	}
+	return "@" + fe.emitter.cloneFnFor(elem)
+}
+
+func (fe *funcEmitter) emitCloneArray(id, elem types.TypeID, dynamic bool) error {
+	elemLLVM, err := llvmValueType(fe.emitter.types, elem)
+	if err != nil {
+		return err
+	}
+	stride, align, err := llvmElemStride(elemLLVM)
+	if err != nil {
+		return err
+	}
+	elemFn := fe.elemCloneFn(elem)
+	handle := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %%src\n", handle)
+	if dynamic {
+		out := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_array_clone(ptr %s, i64 %d, i64 %d, ptr %s)\n", out, handle, stride, align, elemFn)
+		fmt.Fprintf(&fe.emitter.buf, "  store ptr %s, ptr %%dst\n", out)
+		return nil
+	}
+	_, fixedLen, ok := arrayFixedInfo(fe.emitter.types, id)
+	if !ok {
+		return fmt.Errorf("missing fixed array length")
+	}
+	size := max(int(fixedLen)*stride, 1)
+	mem := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_alloc(i64 %d, i64 %d)\n", mem, size, align)
+	fmt.Fprintf(&fe.emitter.buf, "  call void @rt_clone_elems(ptr %s, ptr %s, i64 %d, i64 %d, ptr %s)\n", mem, handle, fixedLen, stride, elemFn)
+	fmt.Fprintf(&fe.emitter.buf, "  store ptr %s, ptr %%dst\n", mem)
+	return nil
+}
+
+func (fe *funcEmitter) emitCloneStruct(id types.TypeID) error {
+	typesIn := fe.emitter.types
+	info, ok := typesIn.StructInfo(id)
+	if !ok || info == nil {
+		return fmt.Errorf("missing struct info")
+	}
+	layoutInfo, err := fe.emitter.layoutOf(id)
+	if err != nil {
+		return err
+	}
+	if len(layoutInfo.FieldOffsets) != len(info.Fields) {
+		return fmt.Errorf("struct layout has %d fields, want %d", len(layoutInfo.FieldOffsets), len(info.Fields))
+	}
+	size := max(layoutInfo.Size, 1)
+	align := max(layoutInfo.Align, 1)
+	handle := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %%src\n", handle)
+	mem := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_alloc(i64 %d, i64 %d)\n", mem, size, align)
+	fmt.Fprintf(&fe.emitter.buf, "  call void @rt_memcpy(ptr %s, ptr %s, i64 %d)\n", mem, handle, layoutInfo.Size)
+	// Байтовая копия уже на месте; поля с кучей перезаписываем глубокой копией.
+	for i, field := range info.Fields {
+		if !fe.emitter.needsDeepClone(field.Type) {
+			continue
+		}
+		dstField := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", dstField, mem, layoutInfo.FieldOffsets[i])
+		srcField := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", srcField, handle, layoutInfo.FieldOffsets[i])
+		fe.emitDeepClone(dstField, srcField, field.Type)
+	}
+	fmt.Fprintf(&fe.emitter.buf, "  store ptr %s, ptr %%dst\n", mem)
+	return nil
+}
+
+func (fe *funcEmitter) emitCloneTag(id types.TypeID) error {
+	cases, err := fe.emitter.tagCases(id)
+	if err != nil {
+		return err
+	}
+	layoutInfo, err := fe.emitter.layoutOf(id)
+	if err != nil {
+		return err
+	}
+	if layoutInfo.TagSize != 4 {
+		return fmt.Errorf("unsupported tag size %d for type#%d", layoutInfo.TagSize, id)
+	}
+	size := max(layoutInfo.Size, 1)
+	align := max(layoutInfo.Align, 1)
+	handle := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %%src\n", handle)
+	tag := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load i32, ptr %s\n", tag, handle)
+	mem := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_alloc(i64 %d, i64 %d)\n", mem, size, align)
+	fmt.Fprintf(&fe.emitter.buf, "  store i32 %s, ptr %s\n", tag, mem)
+
+	labels := make([]string, len(cases))
+	arms := make([]string, len(cases))
+	for i := range cases {
+		labels[i] = fe.nextInlineBlock()
+		arms[i] = fmt.Sprintf("i32 %d, label %%%s", i, labels[i])
+	}
+	badLabel := fe.nextInlineBlock()
+	endLabel := fe.nextInlineBlock()
+	fmt.Fprintf(&fe.emitter.buf, "  switch i32 %s, label %%%s [ %s ]\n", tag, badLabel, strings.Join(arms, " "))
+
+	// Каждый вариант копирует только свой payload: блоки из рантайма
+	// (rt_tag_alloc) бывают меньше полного размера union.
+	for i, c := range cases {
+		fmt.Fprintf(&fe.emitter.buf, "%s:\n", labels[i])
+		if len(c.PayloadTypes) > 0 {
+			offsets, err := fe.emitter.payloadOffsets(c.PayloadTypes)
+			if err != nil {
+				return err
+			}
+			for j, payload := range c.PayloadTypes {
+				if isNothingType(fe.emitter.types, payload) {
+					continue
+				}
+				offset := layoutInfo.PayloadOffset + offsets[j]
+				dstPayload := fe.nextTemp()
+				fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", dstPayload, mem, offset)
+				srcPayload := fe.nextTemp()
+				fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", srcPayload, handle, offset)
+				if fe.emitter.needsDeepClone(payload) {
+					fe.emitDeepClone(dstPayload, srcPayload, payload)
+					continue
+				}
+				payloadLLVM, err := llvmValueType(fe.emitter.types, payload)
+				if err != nil {
+					return err
+				}
+				val := fe.nextTemp()
+				fmt.Fprintf(&fe.emitter.buf, "  %s = load %s, ptr %s\n", val, payloadLLVM, srcPayload)
+				fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", payloadLLVM, val, dstPayload)
+			}
+		}
+		fmt.Fprintf(&fe.emitter.buf, "  br label %%%s\n", endLabel)
+	}
+
+	fmt.Fprintf(&fe.emitter.buf, "%s:\n", badLabel)
+	fmt.Fprintf(&fe.emitter.buf, "  unreachable\n")
+	fmt.Fprintf(&fe.emitter.buf, "%s:\n", endLabel)
+	fmt.Fprintf(&fe.emitter.buf, "  store ptr %s, ptr %%dst\n", mem)
+	return nil
+}
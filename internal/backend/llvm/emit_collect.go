@@ -38,6 +38,13 @@ func (e *Emitter) collectStringConsts() {
 					for k := range ins.Blocking.State.Fields {
 						e.collectOperand(&ins.Blocking.State.Fields[k].Value)
 					}
+				case mir.InstrParallel:
+					e.fnRefs[ins.Parallel.FuncID] = struct{}{}
+					for k := range ins.Parallel.State.Fields {
+						e.collectOperand(&ins.Parallel.State.Fields[k].Value)
+					}
+					e.collectOperand(&ins.Parallel.Iterable)
+					e.collectOperand(&ins.Parallel.Init)
 				case mir.InstrTimeout:
 					e.collectOperand(&ins.Timeout.Task)
 					e.collectOperand(&ins.Timeout.Ms)
@@ -72,6 +79,9 @@ func (e *Emitter) collectRValue(rv *mir.RValue) {
 	case mir.RValueUse:
 		e.collectOperand(&rv.Use)
 	case mir.RValueStructLit:
+		if rv.StructLit.HasBase {
+			e.collectOperand(&rv.StructLit.Base)
+		}
 		for i := range rv.StructLit.Fields {
 			e.collectOperand(&rv.StructLit.Fields[i].Value)
 		}
@@ -175,17 +185,24 @@ func (e *Emitter) ensureStringConst(raw string) {
 		return
 	}
 	bytes := decodeStringLiteral(raw)
+	// Differently spelled literals with the same bytes share one global.
+	if sc, ok := e.stringConstsByBytes[string(bytes)]; ok {
+		e.stringConsts[raw] = sc
+		return
+	}
 	arrayLen := len(bytes)
 	dataLen := len(bytes)
 	if arrayLen == 0 {
 		arrayLen = 1
 	}
-	e.stringConsts[raw] = &stringConst{
+	sc := &stringConst{
 		raw:      raw,
 		bytes:    bytes,
 		dataLen:  dataLen,
 		arrayLen: arrayLen,
 	}
+	e.stringConsts[raw] = sc
+	e.stringConstsByBytes[string(bytes)] = sc
 }
 
 func (e *Emitter) emitStringConsts() {
@@ -197,9 +214,14 @@ func (e *Emitter) emitStringConsts() {
 		raws = append(raws, raw)
 	}
 	sort.Strings(raws)
-	for idx, raw := range raws {
+	idx := 0
+	for _, raw := range raws {
 		sc := e.stringConsts[raw]
+		if sc.globalName != "" {
+			continue
+		}
 		name := fmt.Sprintf(".str.%d", idx)
+		idx++
 		sc.globalName = name
 		lit := formatLLVMBytes(sc.bytes, sc.arrayLen)
 		fmt.Fprintf(&e.buf, "@%s = private unnamed_addr constant [%d x i8] %s\n", name, sc.arrayLen, lit)
@@ -0,0 +1,402 @@
+package llvm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"surge/internal/mir"
+	"surge/internal/types"
+)
+
+// `to string` on arrays, structs, tuples and tags without their own __to uses the debug
+// format shared with the VM (internal/vm/intrinsic_to_debug.go). Every type
+// reachable from such a cast gets a formatter with a uniform signature:
+//
+//	ptr @__surge_debug_fmt.<type>(ptr value)
+//
+// It takes the address of one value and returns a fresh string. Formatters
+// compose through the runtime helpers:
+//
+//	ptr @rt_debug_array(ptr data, i64 len, i64 stride, ptr fmt)
+//	ptr @rt_debug_struct(ptr name, i64 count, ptr names, ptr values)
+//	ptr @rt_debug_tuple(i64 count, ptr values)
+//	ptr @rt_debug_tag(ptr name, i64 count, ptr values)
+//	ptr @rt_debug_quote(ptr s)
+
+func debugFmtName(id types.TypeID) string {
+	return fmt.Sprintf("__surge_debug_fmt.%d", id)
+}
+
+// collectDebugFormats registers formatters for every aggregate-to-string cast
+// in the module, along with the string constants they print.
+func (e *Emitter) collectDebugFormats() {
+	if e.mod == nil || e.types == nil {
+		return
+	}
+	for _, f := range e.mod.Funcs {
+		if f == nil {
+			continue
+		}
+		for i := range f.Blocks {
+			bb := &f.Blocks[i]
+			for j := range bb.Instrs {
+				ins := &bb.Instrs[j]
+				if ins.Kind != mir.InstrAssign || ins.Assign.Src.Kind != mir.RValueCast {
+					continue
+				}
+				c := &ins.Assign.Src.Cast
+				src := c.Value.Type
+				if src == types.NoTypeID && c.Value.Kind != mir.OperandConst && c.Value.Place.Kind == mir.PlaceLocal && len(c.Value.Place.Proj) == 0 {
+					if int(c.Value.Place.Local) < len(f.Locals) {
+						src = f.Locals[c.Value.Place.Local].Type
+					}
+				}
+				if !isStringLike(e.types, c.TargetTy) || src == types.NoTypeID || isStringLike(e.types, src) {
+					continue
+				}
+				found := make(map[types.TypeID]struct{})
+				if !e.addDebugFormat(src, found) {
+					continue
+				}
+				for id := range found {
+					e.debugFmts[id] = debugFmtName(id)
+				}
+			}
+		}
+	}
+}
+
+// addDebugFormat walks the types a formatter for id depends on. It reports
+// false when some nested type has no debug rendering.
+func (e *Emitter) addDebugFormat(id types.TypeID, found map[types.TypeID]struct{}) bool {
+	typesIn := e.types
+	id = resolveValueType(typesIn, id)
+	if _, ok := e.debugFmts[id]; ok {
+		return true
+	}
+	if _, ok := found[id]; ok {
+		return true
+	}
+	found[id] = struct{}{}
+	if isStringLike(typesIn, id) {
+		return true
+	}
+	if isNothingType(typesIn, id) {
+		e.ensureStringConst("nothing")
+		return true
+	}
+	if elem, _, ok := arrayElemType(typesIn, id); ok {
+		return e.addDebugFormat(elem, found)
+	}
+	tt, ok := typesIn.Lookup(id)
+	if !ok {
+		return false
+	}
+	switch tt.Kind {
+	case types.KindBool, types.KindInt, types.KindUint, types.KindFloat:
+		return true
+	case types.KindStruct:
+		fe := &funcEmitter{emitter: e}
+		info, ok := typesIn.StructInfo(id)
+		if !ok || info == nil || fe.isRuntimeOwnedStruct(id) {
+			return false
+		}
+		e.ensureStringConst(types.Label(typesIn, id))
+		for _, field := range info.Fields {
+			e.ensureStringConst(typesIn.Strings.MustLookup(field.Name))
+			if !e.addDebugFormat(field.Type, found) {
+				return false
+			}
+		}
+		return true
+	case types.KindTuple:
+		info, ok := typesIn.TupleInfo(id)
+		if !ok || info == nil || len(info.Elems) == 0 {
+			return false
+		}
+		for _, elem := range info.Elems {
+			if !e.addDebugFormat(elem, found) {
+				return false
+			}
+		}
+		return true
+	case types.KindUnion:
+		cases, err := e.tagCases(id)
+		if err != nil {
+			return false
+		}
+		for _, c := range cases {
+			e.ensureStringConst(c.TagName)
+			for _, payload := range c.PayloadTypes {
+				if !e.addDebugFormat(payload, found) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (e *Emitter) emitDebugFormatters() error {
+	if len(e.debugFmts) == 0 {
+		return nil
+	}
+	ids := make([]types.TypeID, 0, len(e.debugFmts))
+	for id := range e.debugFmts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		fe := &funcEmitter{emitter: e}
+		fmt.Fprintf(&e.buf, "define ptr @%s(ptr %%value) {\n", e.debugFmts[id])
+		fmt.Fprintf(&e.buf, "entry:\n")
+		out, err := fe.emitDebugFormatBody(id)
+		if err != nil {
+			return fmt.Errorf("debug format for %s: %w", types.Label(e.types, id), err)
+		}
+		fmt.Fprintf(&e.buf, "  ret ptr %s\n", out)
+		fmt.Fprintf(&e.buf, "}\n\n")
+	}
+	return nil
+}
+
+// emitDebugFormatCast renders `value to string` for an aggregate through its
+// formatter.
+func (fe *funcEmitter) emitDebugFormatCast(op *mir.Operand) (val, ty string, err error) {
+	srcVal, srcLLVM, srcType, err := fe.emitToSource(op)
+	if err != nil {
+		return "", "", err
+	}
+	name, ok := fe.emitter.debugFmts[resolveValueType(fe.emitter.types, srcType)]
+	if !ok {
+		return "", "", fmt.Errorf("%s to string has no debug format", types.Label(fe.emitter.types, srcType))
+	}
+	slot := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = alloca %s\n", slot, srcLLVM)
+	fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", srcLLVM, srcVal, slot)
+	out := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @%s(ptr %s)\n", out, name, slot)
+	return out, "ptr", nil
+}
+
+func (fe *funcEmitter) emitDebugFormatBody(id types.TypeID) (string, error) {
+	typesIn := fe.emitter.types
+	if isStringLike(typesIn, id) {
+		str := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %%value\n", str)
+		out := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_debug_quote(ptr %s)\n", out, str)
+		return out, nil
+	}
+	if isNothingType(typesIn, id) {
+		out, _, err := fe.emitStringConst("nothing")
+		return out, err
+	}
+	if elem, dynamic, ok := arrayElemType(typesIn, id); ok {
+		return fe.emitDebugFormatArray(id, elem, dynamic)
+	}
+	tt, ok := typesIn.Lookup(id)
+	if !ok {
+		return "", fmt.Errorf("unknown type id %d", id)
+	}
+	switch tt.Kind {
+	case types.KindStruct:
+		return fe.emitDebugFormatStruct(id)
+	case types.KindTuple:
+		return fe.emitDebugFormatTuple(id)
+	case types.KindUnion:
+		return fe.emitDebugFormatTag(id)
+	}
+	llvmTy, err := llvmValueType(typesIn, id)
+	if err != nil {
+		return "", err
+	}
+	val := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load %s, ptr %%value\n", val, llvmTy)
+	out, _, err := fe.emitToString(val, llvmTy, id)
+	return out, err
+}
+
+func (fe *funcEmitter) emitDebugFormatArray(id, elem types.TypeID, dynamic bool) (string, error) {
+	elemLLVM, err := llvmValueType(fe.emitter.types, elem)
+	if err != nil {
+		return "", err
+	}
+	stride, _, err := llvmElemStride(elemLLVM)
+	if err != nil {
+		return "", err
+	}
+	handle := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %%value\n", handle)
+	data, length := handle, ""
+	if dynamic {
+		lenPtr := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", lenPtr, handle, arrayLenOffset)
+		length = fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = load i64, ptr %s\n", length, lenPtr)
+		dataPtr := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", dataPtr, handle, arrayDataOffset)
+		data = fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %s\n", data, dataPtr)
+	} else {
+		_, fixedLen, ok := arrayFixedInfo(fe.emitter.types, id)
+		if !ok {
+			return "", fmt.Errorf("missing fixed array length")
+		}
+		length = fmt.Sprintf("%d", fixedLen)
+	}
+	out := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_debug_array(ptr %s, i64 %s, i64 %d, ptr @%s)\n",
+		out, data, length, stride, debugFmtName(resolveValueType(fe.emitter.types, elem)))
+	return out, nil
+}
+
+func (fe *funcEmitter) emitDebugFormatStruct(id types.TypeID) (string, error) {
+	typesIn := fe.emitter.types
+	info, ok := typesIn.StructInfo(id)
+	if !ok || info == nil {
+		return "", fmt.Errorf("missing struct info")
+	}
+	name, _, err := fe.emitStringConst(types.Label(typesIn, id))
+	if err != nil {
+		return "", err
+	}
+	count := len(info.Fields)
+	if count == 0 {
+		out := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_debug_struct(ptr %s, i64 0, ptr null, ptr null)\n", out, name)
+		return out, nil
+	}
+	layoutInfo, err := fe.emitter.layoutOf(id)
+	if err != nil {
+		return "", err
+	}
+	if len(layoutInfo.FieldOffsets) != count {
+		return "", fmt.Errorf("struct layout has %d fields, want %d", len(layoutInfo.FieldOffsets), count)
+	}
+	handle := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %%value\n", handle)
+	names := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = alloca [%d x ptr]\n", names, count)
+	values := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = alloca [%d x ptr]\n", values, count)
+	for i, field := range info.Fields {
+		fieldPtr := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", fieldPtr, handle, layoutInfo.FieldOffsets[i])
+		fieldStr := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @%s(ptr %s)\n", fieldStr, debugFmtName(resolveValueType(typesIn, field.Type)), fieldPtr)
+		fieldName, _, err := fe.emitStringConst(typesIn.Strings.MustLookup(field.Name))
+		if err != nil {
+			return "", err
+		}
+		fe.emitDebugStoreSlot(names, count, i, fieldName)
+		fe.emitDebugStoreSlot(values, count, i, fieldStr)
+	}
+	out := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_debug_struct(ptr %s, i64 %d, ptr %s, ptr %s)\n", out, name, count, names, values)
+	return out, nil
+}
+
+func (fe *funcEmitter) emitDebugFormatTuple(id types.TypeID) (string, error) {
+	typesIn := fe.emitter.types
+	info, ok := typesIn.TupleInfo(id)
+	if !ok || info == nil || len(info.Elems) == 0 {
+		return "", fmt.Errorf("missing tuple info")
+	}
+	count := len(info.Elems)
+	layoutInfo, err := fe.emitter.layoutOf(id)
+	if err != nil {
+		return "", err
+	}
+	if len(layoutInfo.FieldOffsets) != count {
+		return "", fmt.Errorf("tuple layout has %d elements, want %d", len(layoutInfo.FieldOffsets), count)
+	}
+	handle := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %%value\n", handle)
+	values := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = alloca [%d x ptr]\n", values, count)
+	for i, elem := range info.Elems {
+		elemPtr := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", elemPtr, handle, layoutInfo.FieldOffsets[i])
+		elemStr := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @%s(ptr %s)\n", elemStr, debugFmtName(resolveValueType(typesIn, elem)), elemPtr)
+		fe.emitDebugStoreSlot(values, count, i, elemStr)
+	}
+	out := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_debug_tuple(i64 %d, ptr %s)\n", out, count, values)
+	return out, nil
+}
+
+func (fe *funcEmitter) emitDebugFormatTag(id types.TypeID) (string, error) {
+	cases, err := fe.emitter.tagCases(id)
+	if err != nil {
+		return "", err
+	}
+	layoutInfo, err := fe.emitter.layoutOf(id)
+	if err != nil {
+		return "", err
+	}
+	if layoutInfo.TagSize != 4 {
+		return "", fmt.Errorf("unsupported tag size %d for type#%d", layoutInfo.TagSize, id)
+	}
+	handle := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %%value\n", handle)
+	tag := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load i32, ptr %s\n", tag, handle)
+	result := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = alloca ptr\n", result)
+
+	labels := make([]string, len(cases))
+	arms := make([]string, len(cases))
+	for i := range cases {
+		labels[i] = fe.nextInlineBlock()
+		arms[i] = fmt.Sprintf("i32 %d, label %%%s", i, labels[i])
+	}
+	badLabel := fe.nextInlineBlock()
+	endLabel := fe.nextInlineBlock()
+	fmt.Fprintf(&fe.emitter.buf, "  switch i32 %s, label %%%s [ %s ]\n", tag, badLabel, strings.Join(arms, " "))
+
+	for i, c := range cases {
+		fmt.Fprintf(&fe.emitter.buf, "%s:\n", labels[i])
+		name, _, err := fe.emitStringConst(c.TagName)
+		if err != nil {
+			return "", err
+		}
+		count := len(c.PayloadTypes)
+		values := "null"
+		if count > 0 {
+			offsets, err := fe.emitter.payloadOffsets(c.PayloadTypes)
+			if err != nil {
+				return "", err
+			}
+			values = fe.nextTemp()
+			fmt.Fprintf(&fe.emitter.buf, "  %s = alloca [%d x ptr]\n", values, count)
+			for j, payload := range c.PayloadTypes {
+				payloadPtr := fe.nextTemp()
+				fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", payloadPtr, handle, layoutInfo.PayloadOffset+offsets[j])
+				payloadStr := fe.nextTemp()
+				fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @%s(ptr %s)\n", payloadStr, debugFmtName(resolveValueType(fe.emitter.types, payload)), payloadPtr)
+				fe.emitDebugStoreSlot(values, count, j, payloadStr)
+			}
+		}
+		out := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_debug_tag(ptr %s, i64 %d, ptr %s)\n", out, name, count, values)
+		fmt.Fprintf(&fe.emitter.buf, "  store ptr %s, ptr %s\n", out, result)
+		fmt.Fprintf(&fe.emitter.buf, "  br label %%%s\n", endLabel)
+	}
+
+	fmt.Fprintf(&fe.emitter.buf, "%s:\n", badLabel)
+	fmt.Fprintf(&fe.emitter.buf, "  unreachable\n")
+	fmt.Fprintf(&fe.emitter.buf, "%s:\n", endLabel)
+	out := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %s\n", out, result)
+	return out, nil
+}
+
+func (fe *funcEmitter) emitDebugStoreSlot(arr string, count, idx int, val string) {
+	slot := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds [%d x ptr], ptr %s, i64 0, i64 %d\n", slot, count, arr, idx)
+	fmt.Fprintf(&fe.emitter.buf, "  store ptr %s, ptr %s\n", val, slot)
+}
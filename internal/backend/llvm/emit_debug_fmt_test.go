@@ -0,0 +1,59 @@
+package llvm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEmitStructToStringFormatsFieldsInDeclarationOrder(t *testing.T) {
+	sourceCode := `type Person = {
+    name: string,
+    age: int32,
+    ok: bool,
+}
+
+@entrypoint
+fn main() -> int {
+    let p = Person { name: "ann", age: 30:int32, ok: true };
+    let s: string = p to string;
+    return len(s) to int;
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+
+	mainFn := findMIRFunc(t, mirMod, "main")
+	mainBody := findLLVMFuncBody(t, ir, fmt.Sprintf("fn.%d", mainFn.ID))
+	callFmt := regexp.MustCompile(`call ptr @(__surge_debug_fmt\.\d+)\(ptr %\w+\)`)
+	top := callFmt.FindStringSubmatch(mainBody)
+	if top == nil {
+		t.Fatalf("expected main to call the Person formatter:\n%s", mainBody)
+	}
+
+	structBody := findLLVMFuncBody(t, ir, top[1])
+	fieldCalls := callFmt.FindAllStringSubmatchIndex(structBody, -1)
+	if len(fieldCalls) != 3 {
+		t.Fatalf("expected one formatter call per field, got %d:\n%s", len(fieldCalls), structBody)
+	}
+	wantField := []string{"@rt_debug_quote", "@rt_string_from_int", "select i1"}
+	for i, loc := range fieldCalls {
+		callee := structBody[loc[2]:loc[3]]
+		fieldBody := findLLVMFuncBody(t, ir, callee)
+		if !strings.Contains(fieldBody, wantField[i]) {
+			t.Fatalf("field %d formatter %s should contain %q:\n%s", i, callee, wantField[i], fieldBody)
+		}
+	}
+	joined := strings.Index(structBody, "call ptr @rt_debug_struct(")
+	if joined < 0 || !strings.Contains(structBody[joined:], ", i64 3, ") {
+		t.Fatalf("expected rt_debug_struct over 3 fields:\n%s", structBody)
+	}
+	if joined < fieldCalls[len(fieldCalls)-1][1] {
+		t.Fatalf("rt_debug_struct must run after every field is formatted:\n%s", structBody)
+	}
+}
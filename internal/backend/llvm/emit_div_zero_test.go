@@ -0,0 +1,53 @@
+package llvm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const divZeroSource = `fn quot(a: int32, b: int32) -> int32 {
+    return a / b;
+}
+
+fn rem(a: uint32, b: uint32) -> uint32 {
+    return a % b;
+}
+
+@entrypoint
+fn main() -> int {
+    let q: int32 = quot(7:int32, 2:int32);
+    let r: uint32 = rem(7:uint32, 2:uint32);
+    return (q to int) + (r to int);
+}
+`
+
+func TestEmitIntegerDivisionGuardsZeroDivisor(t *testing.T) {
+	ir := emitLLVMFromSource(t, divZeroSource)
+
+	guard := regexp.MustCompile(`(?s)%(t\d+) = icmp eq i32 %t\d+, 0\n  br i1 %t\d+, label %(\S+), label %(\S+)\n(\S+):\n  call void @rt_panic_div_zero\(\)\n  unreachable\n(\S+):\n  %t\d+ = sdiv i32`)
+	m := guard.FindStringSubmatch(ir)
+	if m == nil {
+		t.Fatalf("expected zero-divisor guard before sdiv:\n%s", ir)
+	}
+	if m[2] != m[4] || m[3] != m[5] {
+		t.Fatalf("guard branches to %s/%s but blocks are %s/%s", m[2], m[3], m[4], m[5])
+	}
+	if !regexp.MustCompile(`call void @rt_panic_div_zero\(\)\n  unreachable\n\S+:\n  %t\d+ = urem i32`).MatchString(ir) {
+		t.Fatalf("expected zero-divisor guard before urem:\n%s", ir)
+	}
+}
+
+func TestEmitIntegerDivisionWithoutRuntimeChecks(t *testing.T) {
+	mirMod, result := lowerMIRFromSource(t, divZeroSource)
+	ir, err := EmitModuleWithOptions(mirMod, result.Sema.TypeInterner, result.Symbols.Table, EmitOptions{NoRuntimeChecks: true})
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+	if !strings.Contains(ir, "sdiv i32") {
+		t.Fatalf("expected sdiv in IR:\n%s", ir)
+	}
+	if strings.Contains(ir, "call void @rt_panic_div_zero()") {
+		t.Fatalf("division guard must be elided with NoRuntimeChecks:\n%s", ir)
+	}
+}
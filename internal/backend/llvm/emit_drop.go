@@ -0,0 +1,140 @@
+package llvm
+
+import (
+	"fmt"
+	"strings"
+
+	"surge/internal/mir"
+	"surge/internal/types"
+)
+
+// Release kinds understood by rt_release (see runtime/native/rt.h).
+const (
+	releaseBlock  = 0
+	releaseString = 1
+	releaseArray  = 2
+	releaseTask   = 3
+)
+
+// dropRelease describes how a dropped value gives its heap block back.
+type dropRelease struct {
+	kind  int
+	size  int
+	align int
+}
+
+// emitInstrDrop releases the heap block owned by a dropped place via rt_release.
+// Copy values, references and runtime-owned handles need no release and emit
+// nothing. Only the top-level block is freed; handles nested inside it are not
+// followed.
+func (fe *funcEmitter) emitInstrDrop(ins *mir.Instr) error {
+	if ins == nil {
+		return nil
+	}
+	place := ins.Drop.Place
+	if len(place.Proj) != 0 {
+		// A projected place may alias storage the parent still owns.
+		return nil
+	}
+	typeID, err := fe.placeBaseType(place)
+	if err != nil {
+		return err
+	}
+	if place.Kind == mir.PlaceLocal && fe.f.Locals[place.Local].Flags&(mir.LocalFlagRef|mir.LocalFlagRefMut|mir.LocalFlagPtr) != 0 {
+		return nil
+	}
+	rel, ok, err := fe.dropReleaseFor(typeID)
+	if err != nil || !ok {
+		return err
+	}
+	ptr, ty, err := fe.emitPlacePtr(place)
+	if err != nil {
+		return err
+	}
+	if ty != "ptr" {
+		return fmt.Errorf("drop expects heap handle for %s, got %s", types.Label(fe.emitter.types, typeID), ty)
+	}
+	val := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load ptr, ptr %s\n", val, ptr)
+	fmt.Fprintf(&fe.emitter.buf, "  call void @rt_release(ptr %s, i64 %d, i64 %d, i64 %d)\n", val, rel.kind, rel.size, rel.align)
+	return nil
+}
+
+// dropReleaseFor classifies a dropped type. ok is false when dropping the value
+// must not touch the heap.
+func (fe *funcEmitter) dropReleaseFor(typeID types.TypeID) (rel dropRelease, ok bool, err error) {
+	typesIn := fe.emitter.types
+	if typesIn == nil || typeID == types.NoTypeID {
+		return dropRelease{}, false, nil
+	}
+	id := resolveAliasAndOwn(typesIn, typeID)
+	if typesIn.IsCopy(id) {
+		return dropRelease{}, false, nil
+	}
+	if isTaskType(typesIn, id) {
+		return dropRelease{kind: releaseTask}, true, nil
+	}
+	tt, found := typesIn.Lookup(id)
+	if !found {
+		return dropRelease{}, false, nil
+	}
+	if elem, dynamic, isArray := arrayElemType(typesIn, id); isArray && dynamic {
+		elemLLVM, elemErr := llvmValueType(typesIn, elem)
+		if elemErr != nil {
+			return dropRelease{}, false, elemErr
+		}
+		stride, align, strideErr := llvmElemStride(elemLLVM)
+		if strideErr != nil {
+			return dropRelease{}, false, strideErr
+		}
+		return dropRelease{kind: releaseArray, size: stride, align: align}, true, nil
+	}
+	switch tt.Kind {
+	case types.KindString:
+		return dropRelease{kind: releaseString}, true, nil
+	case types.KindStruct:
+		if fe.isRuntimeOwnedStruct(id) {
+			return dropRelease{}, false, nil
+		}
+	case types.KindTuple, types.KindUnion, types.KindArray:
+	default:
+		return dropRelease{}, false, nil
+	}
+	layoutInfo, err := fe.emitter.layoutOf(id)
+	if err != nil {
+		return dropRelease{}, false, err
+	}
+	size := layoutInfo.Size
+	align := layoutInfo.Align
+	if size <= 0 {
+		size = 1
+	}
+	if align <= 0 {
+		align = 1
+	}
+	return dropRelease{kind: releaseBlock, size: size, align: align}, true, nil
+}
+
+// isRuntimeOwnedStruct reports whether values of a struct type are handles into
+// runtime state (maps, channels, files, ranges, ...) rather than blocks
+// allocated for a struct literal. Such structs keep an `__opaque`/`__state`
+// field or share storage with another value.
+func (fe *funcEmitter) isRuntimeOwnedStruct(id types.TypeID) bool {
+	typesIn := fe.emitter.types
+	if _, _, ok := typesIn.MapInfo(id); ok {
+		return true
+	}
+	if isChannelType(typesIn, id) || isBytesViewType(typesIn, id) || isRangeType(typesIn, id) {
+		return true
+	}
+	info, ok := typesIn.StructInfo(id)
+	if !ok || info == nil || typesIn.Strings == nil {
+		return false
+	}
+	for _, field := range info.Fields {
+		if name, ok := typesIn.Strings.Lookup(field.Name); ok && strings.HasPrefix(name, "__") {
+			return true
+		}
+	}
+	return false
+}
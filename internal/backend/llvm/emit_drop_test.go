@@ -0,0 +1,42 @@
+package llvm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestEmitDropReleasesStringButNotInt(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let s: string = "abc";
+    let n: int = 1;
+    let xs: int[] = [1, 2];
+    @drop s;
+    @drop n;
+    @drop xs;
+    return 0;
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+
+	mainFn := findMIRFunc(t, mirMod, "main")
+	body := findLLVMFuncBody(t, ir, fmt.Sprintf("fn.%d", mainFn.ID))
+
+	release := regexp.MustCompile(`call void @rt_release\(ptr %\w+, i64 (\d+), i64 (\d+), i64 (\d+)\)`)
+	calls := release.FindAllStringSubmatch(body, -1)
+	if len(calls) != 2 {
+		t.Fatalf("expected rt_release for the string and the array only, got %d:\n%s", len(calls), body)
+	}
+	if calls[0][1] != "1" {
+		t.Fatalf("string drop must use the string release kind, got %q", calls[0][0])
+	}
+	if calls[1][1] != "2" || calls[1][2] != "8" || calls[1][3] != "8" {
+		t.Fatalf("array drop must pass the element stride, got %q", calls[1][0])
+	}
+}
@@ -45,6 +45,7 @@ func (e *Emitter) emitFunction(f *mir.Func) error {
 		fe.localAlloca[localID] = fmt.Sprintf("l%d", i)
 	}
 	fe.addrOfTargets = fe.collectAddrOfTargets()
+	lifetimes := fe.planLifetimes()
 
 	fmt.Fprint(&e.buf, "entry:\n")
 	if err := fe.emitAllocas(); err != nil {
@@ -62,13 +63,17 @@ func (e *Emitter) emitFunction(f *mir.Func) error {
 		}
 		fmt.Fprintf(&e.buf, "bb%d:\n", bb.ID)
 		fe.blockTerminated = false
+		fe.startExitCode = ""
 		for i := range bb.Instrs {
+			at := lifetimePoint{block: bb.ID, instr: i}
+			fe.emitLifetimeMarkers("llvm.lifetime.start.p0", lifetimes.start[at])
 			if err := fe.emitInstr(&bb.Instrs[i]); err != nil {
 				return fmt.Errorf("llvm emit %s bb%d instr[%d] (%s): %w", f.Name, bb.ID, i, bb.Instrs[i].Kind, err)
 			}
 			if fe.blockTerminated {
 				break
 			}
+			fe.emitLifetimeMarkers("llvm.lifetime.end.p0", lifetimes.end[at])
 		}
 		if fe.blockTerminated {
 			continue
@@ -67,7 +67,7 @@ func (e *Emitter) reachableFuncs() map[mir.FuncID]struct{} {
 		}
 	}
 	for id, f := range e.mod.Funcs {
-		if f != nil && f.Name == "__surge_start" {
+		if isSurgeStart(f) {
 			roots = append(roots, id)
 		}
 	}
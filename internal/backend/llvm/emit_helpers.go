@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"surge/internal/mir"
+	"surge/internal/token"
 	"surge/internal/types"
 )
 
@@ -40,36 +41,7 @@ func formatLLVMBytes(data []byte, arrayLen int) string {
 }
 
 func decodeStringLiteral(raw string) []byte {
-	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
-		raw = raw[1 : len(raw)-1]
-	}
-	out := make([]byte, 0, len(raw))
-	for i := 0; i < len(raw); i++ {
-		ch := raw[i]
-		if ch != '\\' {
-			out = append(out, ch)
-			continue
-		}
-		if i+1 >= len(raw) {
-			break
-		}
-		i++
-		switch raw[i] {
-		case '\\':
-			out = append(out, '\\')
-		case '"':
-			out = append(out, '"')
-		case 'n':
-			out = append(out, '\n')
-		case 't':
-			out = append(out, '\t')
-		case 'r':
-			out = append(out, '\r')
-		default:
-			out = append(out, raw[i])
-		}
-	}
-	return out
+	return []byte(token.UnquoteString(raw))
 }
 
 func (fe *funcEmitter) operandIsRef(op *mir.Operand, opType types.TypeID) bool {
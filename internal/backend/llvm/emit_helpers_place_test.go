@@ -1,6 +1,7 @@
 package llvm
 
 import (
+	"strings"
 	"testing"
 
 	"surge/internal/mir"
@@ -120,3 +121,46 @@ func findMIRLocal(t *testing.T, fn *mir.Func, name string) mir.LocalID {
 	t.Fatalf("missing MIR local %q", name)
 	return mir.NoLocalID
 }
+
+func TestEmitPlacePtrRejectsDerefOfNonReference(t *testing.T) {
+	sourceCode := `type Entry = { name: string, borrowers: string[] };
+
+@entrypoint
+fn main() -> int {
+    let mut shelf: Entry[] = [{ name = "a", borrowers = [] }];
+    shelf[0].borrowers.push("y");
+    return 0;
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	mainFn := findMIRFunc(t, mirMod, "main")
+
+	// Поле borrowers хранит сам массив: deref такого места — ошибка MIR, а не no-op.
+	patched := false
+	for bi := range mainFn.Blocks {
+		for ii := range mainFn.Blocks[bi].Instrs {
+			ins := &mainFn.Blocks[bi].Instrs[ii]
+			if ins.Kind != mir.InstrCall || len(ins.Call.Args) == 0 {
+				continue
+			}
+			recv := &ins.Call.Args[0]
+			proj := recv.Place.Proj
+			if recv.Kind != mir.OperandAddrOfMut || len(proj) == 0 || proj[len(proj)-1].Kind != mir.PlaceProjField {
+				continue
+			}
+			recv.Place.Proj = append(append([]mir.PlaceProj(nil), proj...), mir.PlaceProj{Kind: mir.PlaceProjDeref})
+			patched = true
+		}
+	}
+	if !patched {
+		t.Fatalf("no &mut field receiver found in main")
+	}
+
+	if err := mir.Verify(mirMod, result.Sema.TypeInterner); err == nil || !strings.Contains(err.Error(), "deref projection on Array<string>") {
+		t.Fatalf("expected mir.Verify to reject the deref, got %v", err)
+	}
+	if _, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table); err == nil || !strings.Contains(err.Error(), "deref") {
+		t.Fatalf("expected the emitter to reject the deref, got %v", err)
+	}
+}
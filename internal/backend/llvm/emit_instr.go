@@ -22,6 +22,8 @@ func (fe *funcEmitter) emitInstr(ins *mir.Instr) error {
 		return fe.emitInstrSpawn(ins)
 	case mir.InstrBlocking:
 		return fe.emitInstrBlocking(ins)
+	case mir.InstrParallel:
+		return fe.emitInstrParallel(ins)
 	case mir.InstrPoll:
 		return fe.emitInstrPoll(ins)
 	case mir.InstrJoinAll:
@@ -36,7 +38,9 @@ func (fe *funcEmitter) emitInstr(ins *mir.Instr) error {
 		return fe.emitInstrTimeout(ins)
 	case mir.InstrSelect:
 		return fe.emitInstrSelect(ins)
-	case mir.InstrDrop, mir.InstrEndBorrow, mir.InstrNop:
+	case mir.InstrDrop:
+		return fe.emitInstrDrop(ins)
+	case mir.InstrEndBorrow, mir.InstrNop:
 		return nil
 	default:
 		return fmt.Errorf("unsupported instruction kind %v", ins.Kind)
@@ -116,12 +120,42 @@ func (fe *funcEmitter) emitAssign(ins *mir.Instr) error {
 		return err
 	}
 	if dstTy != ty {
-		ty = dstTy
+		srcType := types.NoTypeID
+		if ins.Assign.Src.Kind == mir.RValueUse {
+			srcType = operandValueType(fe.emitter.types, &ins.Assign.Src.Use)
+		}
+		dstType, dstErr := fe.placeBaseType(ins.Assign.Dst)
+		if dstErr != nil {
+			dstType = types.NoTypeID
+		}
+		val, ty, err = fe.reconcileStoreValue(val, ty, dstTy, srcType, dstType)
+		if err != nil {
+			return err
+		}
 	}
 	fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", ty, val, ptr)
 	return nil
 }
 
+// reconcileStoreValue adapts a value to the LLVM type of its destination slot.
+// Numeric values that types.Interner.AssignableTo allows to widen are converted;
+// otherwise the destination type is reused as is.
+func (fe *funcEmitter) reconcileStoreValue(val, ty, dstTy string, srcType, dstType types.TypeID) (outVal, outTy string, err error) {
+	if ty == dstTy {
+		return val, ty, nil
+	}
+	if fe.emitter.types.AssignableTo(srcType, dstType) {
+		casted, castTy, err := fe.coerceNumericValue(val, ty, srcType, dstType)
+		if err != nil {
+			return "", "", err
+		}
+		if castTy == dstTy {
+			return casted, castTy, nil
+		}
+	}
+	return val, dstTy, nil
+}
+
 func (fe *funcEmitter) emitRValue(rv *mir.RValue) (val, ty string, err error) {
 	if rv == nil {
 		return "", "", fmt.Errorf("nil rvalue")
@@ -149,6 +183,10 @@ func (fe *funcEmitter) emitRValue(rv *mir.RValue) (val, ty string, err error) {
 		return fe.emitIterInit(&rv.IterInit)
 	case mir.RValueIterNext:
 		return fe.emitIterNext(&rv.IterNext)
+	case mir.RValueTypeTest:
+		return fe.emitTypeTest(&rv.TypeTest)
+	case mir.RValueHeirTest:
+		return fe.emitHeirTest(&rv.HeirTest)
 	case mir.RValueArrayLit, mir.RValueTupleLit:
 		return "", "", fmt.Errorf("literal rvalue must be handled in assignment")
 	default:
@@ -2,6 +2,7 @@ package llvm
 
 import (
 	"fmt"
+	"strconv"
 
 	"surge/internal/ast"
 	"surge/internal/mir"
@@ -108,12 +109,14 @@ func (fe *funcEmitter) emitBinary(op *mir.BinaryOp) (val, ty string, err error)
 			case ast.ExprBinaryMul:
 				opcode = "mul"
 			case ast.ExprBinaryDiv:
+				fe.emitDivZeroGuard(rightVal, rightTy)
 				if info.signed {
 					opcode = "sdiv"
 				} else {
 					opcode = "udiv"
 				}
 			case ast.ExprBinaryMod:
+				fe.emitDivZeroGuard(rightVal, rightTy)
 				if info.signed {
 					opcode = "srem"
 				} else {
@@ -134,9 +137,7 @@ func (fe *funcEmitter) emitBinary(op *mir.BinaryOp) (val, ty string, err error)
 					opcode = "lshr"
 				}
 			}
-			tmp := fe.nextTemp()
-			fmt.Fprintf(&fe.emitter.buf, "  %s = %s %s %s, %s\n", tmp, opcode, leftTy, leftVal, rightVal)
-			return tmp, leftTy, nil
+			return fe.emitIntArith(opcode, info, leftTy, leftVal, rightVal), leftTy, nil
 		}
 		if _, floatOK := floatInfo(fe.emitter.types, op.Left.Type); floatOK {
 			opcode := ""
@@ -164,6 +165,56 @@ func (fe *funcEmitter) emitBinary(op *mir.BinaryOp) (val, ty string, err error)
 	}
 }
 
+// emitDivZeroGuard branches to rt_panic_div_zero when an integer divisor is zero,
+// matching the VM's division-by-zero panic. Non-zero constant divisors need no guard.
+func (fe *funcEmitter) emitDivZeroGuard(divisor, ty string) {
+	if fe.emitter.opts.NoRuntimeChecks {
+		return
+	}
+	if n, err := strconv.ParseInt(divisor, 10, 64); err == nil && n != 0 {
+		return
+	}
+	isZero := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = icmp eq %s %s, 0\n", isZero, ty, divisor)
+	fail := fe.nextInlineBlock()
+	cont := fe.nextInlineBlock()
+	fmt.Fprintf(&fe.emitter.buf, "  br i1 %s, label %%%s, label %%%s\n", isZero, fail, cont)
+	fmt.Fprintf(&fe.emitter.buf, "%s:\n", fail)
+	fmt.Fprintf(&fe.emitter.buf, "  call void @rt_panic_div_zero()\n")
+	fmt.Fprintf(&fe.emitter.buf, "  unreachable\n")
+	fmt.Fprintf(&fe.emitter.buf, "%s:\n", cont)
+}
+
+// emitIntArith emits an integer binary opcode. add/sub/mul go through the
+// llvm.*.with.overflow intrinsics and branch to rt_panic_overflow when the
+// result does not fit, matching the VM's integer overflow panic.
+func (fe *funcEmitter) emitIntArith(opcode string, info intMeta, ty, left, right string) string {
+	checked := opcode == "add" || opcode == "sub" || opcode == "mul"
+	if !checked || fe.emitter.opts.NoRuntimeChecks || info.bits <= 1 {
+		tmp := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = %s %s %s, %s\n", tmp, opcode, ty, left, right)
+		return tmp
+	}
+	sign := "u"
+	if info.signed {
+		sign = "s"
+	}
+	pair := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call {%s, i1} @%s(%s %s, %s %s)\n", pair, ty, overflowIntrinsic(sign+opcode, ty), ty, left, ty, right)
+	tmp := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = extractvalue {%s, i1} %s, 0\n", tmp, ty, pair)
+	overflow := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = extractvalue {%s, i1} %s, 1\n", overflow, ty, pair)
+	fail := fe.nextInlineBlock()
+	cont := fe.nextInlineBlock()
+	fmt.Fprintf(&fe.emitter.buf, "  br i1 %s, label %%%s, label %%%s\n", overflow, fail, cont)
+	fmt.Fprintf(&fe.emitter.buf, "%s:\n", fail)
+	fmt.Fprintf(&fe.emitter.buf, "  call void @rt_panic_overflow()\n")
+	fmt.Fprintf(&fe.emitter.buf, "  unreachable\n")
+	fmt.Fprintf(&fe.emitter.buf, "%s:\n", cont)
+	return tmp
+}
+
 func (fe *funcEmitter) emitCompare(op *mir.BinaryOp, leftVal, rightVal, leftTy string) (val, ty string, err error) {
 	if isBigIntType(fe.emitter.types, op.Left.Type) {
 		return fe.emitBigCompare("rt_bigint_cmp", op.Op, leftVal, rightVal)
@@ -18,6 +18,10 @@ func (fe *funcEmitter) emitCast(c *mir.CastOp) (val, ty string, err error) {
 	if constVal, constTy, ok, constErr := fe.emitConstIntegerCast(c); ok || constErr != nil {
 		return constVal, constTy, constErr
 	}
+	if isStringLike(fe.emitter.types, c.TargetTy) && !isStringLike(fe.emitter.types, c.Value.Type) {
+		// Aggregates without __to(string) use the debug format.
+		return fe.emitDebugFormatCast(&c.Value)
+	}
 	val, srcTy, err := fe.emitOperand(&c.Value)
 	if err != nil {
 		return "", "", err
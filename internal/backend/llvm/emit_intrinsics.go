@@ -310,42 +310,3 @@ func (fe *funcEmitter) emitLayoutIntrinsic(call *mir.CallInstr) (bool, error) {
 	}
 	return true, nil
 }
-
-func (fe *funcEmitter) emitCloneIntrinsic(call *mir.CallInstr) (bool, error) {
-	if call == nil || call.Callee.Kind != mir.CalleeSym {
-		return false, nil
-	}
-	name := call.Callee.Name
-	if name == "" {
-		name = fe.symbolName(call.Callee.Sym)
-	}
-	name = stripGenericSuffix(name)
-	if name != "__clone" {
-		return false, nil
-	}
-	if len(call.Args) != 1 {
-		return true, fmt.Errorf("__clone requires 1 argument")
-	}
-	if !call.HasDst {
-		return true, nil
-	}
-	argVal, _, srcType, err := fe.emitToSource(&call.Args[0])
-	if err != nil {
-		return true, err
-	}
-	if !isStringLike(fe.emitter.types, srcType) {
-		if _, ok := fe.emitter.resolveFuncIDForCall(fe.f, call); ok {
-			return false, nil
-		}
-		return true, fmt.Errorf("__clone unsupported for type")
-	}
-	ptr, dstTy, err := fe.emitPlacePtr(call.Dst)
-	if err != nil {
-		return true, err
-	}
-	if dstTy != "ptr" {
-		dstTy = "ptr"
-	}
-	fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", dstTy, argVal, ptr)
-	return true, nil
-}
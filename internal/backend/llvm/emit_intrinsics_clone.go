@@ -53,7 +53,7 @@ func (fe *funcEmitter) emitCloneValueIntrinsic(call *mir.CallInstr) (bool, error
 	}
 	if fe.emitter != nil && fe.emitter.types != nil && dstType != types.NoTypeID {
 		if !fe.emitter.types.IsCopy(resolveAliasAndOwn(fe.emitter.types, dstType)) {
-			return true, fmt.Errorf("clone requires a Copy type")
+			return true, fe.emitCloneDeepValue(call, dstType)
 		}
 	}
 	val, valTy, err := fe.emitValueOperand(&call.Args[0])
@@ -72,3 +72,27 @@ func (fe *funcEmitter) emitCloneValueIntrinsic(call *mir.CallInstr) (bool, error
 	fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", dstTy, val, ptr)
 	return true, nil
 }
+
+// emitCloneDeepValue lowers clone(&x) for a non-Copy x to a deep copy.
+func (fe *funcEmitter) emitCloneDeepValue(call *mir.CallInstr, dstType types.TypeID) error {
+	src, _, err := fe.emitCloneSource(&call.Args[0])
+	if err != nil {
+		return err
+	}
+	ptr, _, err := fe.emitPlacePtr(call.Dst)
+	if err != nil {
+		return err
+	}
+	if !fe.emitter.needsDeepClone(dstType) {
+		llvmTy, tyErr := llvmValueType(fe.emitter.types, dstType)
+		if tyErr != nil {
+			return tyErr
+		}
+		val := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = load %s, ptr %s\n", val, llvmTy, src)
+		fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", llvmTy, val, ptr)
+		return nil
+	}
+	fe.emitDeepClone(ptr, src, dstType)
+	return nil
+}
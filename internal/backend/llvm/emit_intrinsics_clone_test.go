@@ -2,6 +2,7 @@ package llvm
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -69,3 +70,52 @@ fn main() -> int {
 		t.Fatalf("Error clone leaked as an unresolved external __clone call:\n%s", ir)
 	}
 }
+
+func TestEmitStringMagicCloneCallsRuntime(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let s: string = "client";
+    let c: string = s.__clone();
+    print(c);
+    return 0;
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	if !regexp.MustCompile(`load ptr, ptr %l\d+\n  %t\d+ = call ptr @rt_string_clone\(ptr %t\d+\)`).MatchString(ir) {
+		t.Fatalf("string __clone did not call rt_string_clone on the loaded handle:\n%s", ir)
+	}
+}
+
+func TestEmitCloneStructDispatchesNestedUserClone(t *testing.T) {
+	sourceCode := `type Counted = { n: int };
+
+extern<Counted> {
+    pub fn __clone(self: &Counted) -> Counted {
+        return { n = self.n + 100 };
+    }
+}
+
+type Holder = { name: string, tags: string[], c: Counted };
+
+@entrypoint
+fn main() -> int {
+    let h: Holder = { name = "a", tags = ["x"], c = { n = 1 } };
+    let copy: Holder = clone(&h);
+    return copy.c.n;
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	if !strings.Contains(ir, "call ptr @rt_array_clone(") {
+		t.Fatalf("array field was not deep-copied:\n%s", ir)
+	}
+	if !strings.Contains(ir, "call ptr @rt_string_clone(") {
+		t.Fatalf("string field was not deep-copied:\n%s", ir)
+	}
+	if !regexp.MustCompile(`define void @__surge_clone\.\d+\(ptr %dst, ptr %src\) \{\nentry:\n  %t\d+ = call ptr @fn\.\d+\(ptr %src\)`).MatchString(ir) {
+		t.Fatalf("nested struct with __clone was not copied through the user function:\n%s", ir)
+	}
+}
@@ -43,6 +43,9 @@ func (fe *funcEmitter) emitDefaultIntrinsic(call *mir.CallInstr) (bool, error) {
 	if targetType == types.NoTypeID {
 		return true, fmt.Errorf("default requires type arguments")
 	}
+	if fe.emitter != nil && fe.emitter.types != nil && fe.emitter.types.ZeroValueKind(targetType) == types.ZeroNone {
+		return true, fmt.Errorf("default is not defined for %s", types.Label(fe.emitter.types, targetType))
+	}
 	val, valTy, err := fe.emitDefaultValue(targetType)
 	if err != nil {
 		return true, err
@@ -119,6 +122,9 @@ func (fe *funcEmitter) emitDefaultValue(typeID types.TypeID) (val, ty string, er
 		}
 		return fe.emitDefaultArrayFixed(typeID, tt.Elem, tt.Count)
 	case types.KindStruct:
+		if _, _, ok := fe.emitter.types.MapInfo(typeID); ok {
+			return fe.emitDefaultMap(typeID)
+		}
 		if _, ok := fe.emitter.types.ArrayInfo(typeID); ok {
 			return fe.emitDefaultArrayDynamic()
 		}
@@ -239,6 +245,20 @@ func (fe *funcEmitter) emitDefaultTuple(typeID types.TypeID) (val, ty string, er
 	return mem, "ptr", nil
 }
 
+func (fe *funcEmitter) emitDefaultMap(typeID types.TypeID) (val, ty string, err error) {
+	keyType, err := fe.mapKeyTypeFromType(typeID)
+	if err != nil {
+		return "", "", err
+	}
+	keyKind, err := fe.mapKeyKindForType(keyType)
+	if err != nil {
+		return "", "", err
+	}
+	tmp := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_map_new(i64 %d)\n", tmp, keyKind)
+	return tmp, "ptr", nil
+}
+
 func (fe *funcEmitter) emitDefaultArrayDynamic() (val, ty string, err error) {
 	headPtr := fe.nextTemp()
 	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_alloc(i64 %d, i64 %d)\n", headPtr, arrayHeaderSize, arrayHeaderAlign)
@@ -440,6 +440,10 @@ func (fe *funcEmitter) emitRtExit(call *mir.CallInstr) error {
 	if err != nil {
 		return err
 	}
+	if isSurgeStart(fe.f) {
+		fe.emitSurgeStartExit(code64)
+		return nil
+	}
 	fmt.Fprintf(&fe.emitter.buf, "  call void @rt_exit(i64 %s)\n", code64)
 	return nil
 }
@@ -0,0 +1,60 @@
+package llvm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var arrayIterAlloc = fmt.Sprintf("call ptr @rt_alloc(i64 %d, i64 %d)", arrayIterSize, arrayIterAlign)
+
+func TestEmitForOverArrayLiteralUsesArrayIterator(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let mut total: int = 0;
+    for x in [1, 2, 3] {
+        total = total + x;
+    }
+    return total;
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	// iter_init: {data, index, length} на куче, длина литерала известна статически.
+	if !strings.Contains(ir, arrayIterAlloc) {
+		t.Fatalf("expected iter_init to allocate the array iterator:\n%s", ir)
+	}
+	if !strings.Contains(ir, "store i64 3, ptr") {
+		t.Fatalf("expected iter_init to record the literal length 3:\n%s", ir)
+	}
+	// iter_next: сравнение индекса с длиной даёт флаг завершения, затем Some/nothing.
+	if !strings.Contains(ir, "icmp sge i64") {
+		t.Fatalf("expected iter_next to compare index with length:\n%s", ir)
+	}
+	if !strings.Contains(ir, "add i64") {
+		t.Fatalf("expected iter_next to advance the index:\n%s", ir)
+	}
+}
+
+func TestEmitForOverDynamicArrayLoadsRuntimeLength(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let xs: int[] = [4, 5];
+    let mut total: int = 0;
+    for x in xs {
+        total = total + x;
+    }
+    return total;
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	if !strings.Contains(ir, arrayIterAlloc) {
+		t.Fatalf("expected iter_init to allocate the array iterator:\n%s", ir)
+	}
+	if !strings.Contains(ir, "icmp sge i64") {
+		t.Fatalf("expected iter_next to compare index with length:\n%s", ir)
+	}
+}
@@ -0,0 +1,129 @@
+package llvm
+
+import (
+	"fmt"
+
+	"surge/internal/mir"
+)
+
+type lifetimePoint struct {
+	block mir.BlockID
+	instr int
+}
+
+type lifetimeMarker struct {
+	local mir.LocalID
+	size  int
+}
+
+// lifetimePlan maps instruction positions to the lifetime markers emitted around them.
+type lifetimePlan struct {
+	start map[lifetimePoint][]lifetimeMarker
+	end   map[lifetimePoint][]lifetimeMarker
+}
+
+type localLiveRange struct {
+	block     mir.BlockID
+	first     int
+	last      int
+	seen      bool
+	rejected  bool
+	firstDefs bool
+}
+
+// planLifetimes finds locals whose whole live range sits inside one block:
+// every mention (including the MIR Drop/EndBorrow that closes it) is in that
+// block, the first mention fully overwrites the local and its address never
+// escapes. Such allocas are bracketed with llvm.lifetime.start/end so LLVM can
+// reuse their stack slots. Async state machines are left alone: their locals
+// are reloaded across suspension points.
+func (fe *funcEmitter) planLifetimes() lifetimePlan {
+	plan := lifetimePlan{}
+	f := fe.f
+	if f == nil || f.IsAsync || isPollFunc(f) || isBlockingFunc(f) {
+		return plan
+	}
+	ranges := make([]localLiveRange, len(f.Locals))
+	for _, id := range fe.paramLocals {
+		if int(id) >= 0 && int(id) < len(ranges) {
+			ranges[id].rejected = true
+		}
+	}
+	if f.ScopeLocal != mir.NoLocalID && int(f.ScopeLocal) < len(ranges) {
+		ranges[f.ScopeLocal].rejected = true
+	}
+	for id := range fe.addrOfTargets {
+		if int(id) >= 0 && int(id) < len(ranges) {
+			ranges[id].rejected = true
+		}
+	}
+
+	for bi := range f.Blocks {
+		bb := &f.Blocks[bi]
+		for ii := range bb.Instrs {
+			reads := make(map[mir.LocalID]bool)
+			mir.VisitInstrLocals(&bb.Instrs[ii], func(id mir.LocalID, access mir.LocalAccess) {
+				if int(id) < 0 || int(id) >= len(ranges) {
+					return
+				}
+				r := &ranges[id]
+				switch access {
+				case mir.LocalAddr:
+					r.rejected = true
+				case mir.LocalRead:
+					reads[id] = true
+				}
+				if r.seen && r.block != bb.ID {
+					r.rejected = true
+				}
+				if !r.seen {
+					r.seen = true
+					r.block = bb.ID
+					r.first = ii
+					r.firstDefs = true
+				}
+				r.last = ii
+			})
+			for id := range reads {
+				if ranges[id].first == ii {
+					ranges[id].firstDefs = false
+				}
+			}
+		}
+		mir.VisitTermLocals(&bb.Term, func(id mir.LocalID, _ mir.LocalAccess) {
+			if int(id) >= 0 && int(id) < len(ranges) {
+				ranges[id].rejected = true
+			}
+		})
+	}
+
+	for i, r := range ranges {
+		if !r.seen || r.rejected || !r.firstDefs {
+			continue
+		}
+		llvmTy, err := llvmLocalValueType(fe.emitter.types, f.Locals[i])
+		if err != nil {
+			continue
+		}
+		size, _, err := llvmTypeSizeAlign(llvmTy)
+		if err != nil || size <= 0 {
+			continue
+		}
+		marker := lifetimeMarker{local: mir.LocalID(i), size: size} //nolint:gosec // bounded by locals length
+		if plan.start == nil {
+			plan.start = make(map[lifetimePoint][]lifetimeMarker)
+			plan.end = make(map[lifetimePoint][]lifetimeMarker)
+		}
+		startAt := lifetimePoint{block: r.block, instr: r.first}
+		endAt := lifetimePoint{block: r.block, instr: r.last}
+		plan.start[startAt] = append(plan.start[startAt], marker)
+		plan.end[endAt] = append(plan.end[endAt], marker)
+	}
+	return plan
+}
+
+func (fe *funcEmitter) emitLifetimeMarkers(intrinsic string, markers []lifetimeMarker) {
+	for _, m := range markers {
+		fmt.Fprintf(&fe.emitter.buf, "  call void @%s(i64 %d, ptr %%%s)\n", intrinsic, m.size, fe.localAlloca[m.local])
+	}
+}
@@ -0,0 +1,60 @@
+package llvm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEmitLifetimeMarkersForBlockLocal(t *testing.T) {
+	sourceCode := `fn scale(a: int, b: int) -> int {
+    let sum = a + b;
+    return sum * 2;
+}
+
+@entrypoint
+fn main() -> int {
+    return scale(1, 2);
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	if !strings.Contains(ir, "declare void @llvm.lifetime.start.p0(i64, ptr)") {
+		t.Fatalf("expected lifetime intrinsic declarations:\n%s", ir)
+	}
+	start := regexp.MustCompile(`call void @llvm\.lifetime\.start\.p0\(i64 8, ptr (%l\d+)\)`).FindAllStringSubmatchIndex(ir, -1)
+	if len(start) == 0 {
+		t.Fatalf("expected lifetime.start for a block-local alloca:\n%s", ir)
+	}
+	for _, m := range start {
+		local := ir[m[2]:m[3]]
+		rest := ir[m[1]:]
+		store := strings.Index(rest, "store ptr %")
+		end := strings.Index(rest, "call void @llvm.lifetime.end.p0(i64 8, ptr "+local+")")
+		if end < 0 {
+			t.Fatalf("lifetime.start for %s has no matching lifetime.end:\n%s", local, ir)
+		}
+		if store < 0 || store > end {
+			t.Fatalf("expected %s to be written inside its lifetime:\n%s", local, ir)
+		}
+	}
+}
+
+func TestEmitLifetimeMarkersSkipParams(t *testing.T) {
+	sourceCode := `fn pick(a: int) -> int {
+    return a;
+}
+
+@entrypoint
+fn main() -> int {
+    return pick(1);
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	if regexp.MustCompile(`lifetime\.(start|end)\.p0\(i64 \d+, ptr %l0\)`).MatchString(ir) {
+		t.Fatalf("parameter alloca must stay live for the whole function:\n%s", ir)
+	}
+}
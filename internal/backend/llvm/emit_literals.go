@@ -22,6 +22,14 @@ func (fe *funcEmitter) emitStructLit(lit *mir.StructLit) (val, ty string, err er
 	}
 	mem := fe.nextTemp()
 	fmt.Fprintf(&fe.emitter.buf, "  %s = call ptr @rt_alloc(i64 %d, i64 %d)\n", mem, size, align)
+	basePtr := ""
+	if lit.HasBase {
+		basePtr, _, err = fe.emitValueOperand(&lit.Base)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	assigned := make([]bool, len(layoutInfo.FieldOffsets))
 	for i := range lit.Fields {
 		field := &lit.Fields[i]
 		fieldIdx, fieldType, err := fe.structFieldInfo(lit.TypeID, mir.PlaceProj{Kind: mir.PlaceProjField, FieldName: field.Name, FieldIdx: -1})
@@ -31,6 +39,7 @@ func (fe *funcEmitter) emitStructLit(lit *mir.StructLit) (val, ty string, err er
 		if fieldIdx < 0 || fieldIdx >= len(layoutInfo.FieldOffsets) {
 			return "", "", fmt.Errorf("field index %d out of range", fieldIdx)
 		}
+		assigned[fieldIdx] = true
 		op := field.Value
 		if op.Type == types.NoTypeID {
 			op.Type = fieldType
@@ -68,9 +77,45 @@ func (fe *funcEmitter) emitStructLit(lit *mir.StructLit) (val, ty string, err er
 		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", bytePtr, mem, off)
 		fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", valTy, val, bytePtr)
 	}
+	if basePtr != "" {
+		if err := fe.emitStructSpreadFields(lit.TypeID, basePtr, mem, layoutInfo.FieldOffsets, assigned, lit.Base.Kind == mir.OperandMove); err != nil {
+			return "", "", err
+		}
+	}
 	return mem, "ptr", nil
 }
 
+// emitStructSpreadFields copies fields not listed in a `..base` literal from base.
+// Fields owning heap data are deep-copied unless base is moved into the literal,
+// so writes through the new value never reach base.
+func (fe *funcEmitter) emitStructSpreadFields(typeID types.TypeID, basePtr, mem string, offsets []int, assigned []bool, baseMoved bool) error {
+	for idx, done := range assigned {
+		if done {
+			continue
+		}
+		_, fieldType, err := fe.structFieldInfo(typeID, mir.PlaceProj{Kind: mir.PlaceProjField, FieldIdx: idx})
+		if err != nil {
+			return err
+		}
+		fieldLLVM, err := llvmValueType(fe.emitter.types, fieldType)
+		if err != nil {
+			return err
+		}
+		srcPtr := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", srcPtr, basePtr, offsets[idx])
+		dstPtr := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = getelementptr inbounds i8, ptr %s, i64 %d\n", dstPtr, mem, offsets[idx])
+		if !baseMoved && fe.emitter.needsDeepClone(fieldType) {
+			fe.emitDeepClone(dstPtr, srcPtr, fieldType)
+			continue
+		}
+		val := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf, "  %s = load %s, ptr %s\n", val, fieldLLVM, srcPtr)
+		fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", fieldLLVM, val, dstPtr)
+	}
+	return nil
+}
+
 func (fe *funcEmitter) emitTupleLit(lit *mir.TupleLit, dstType types.TypeID) (val, ty string, err error) {
 	if lit == nil {
 		return "", "", fmt.Errorf("nil tuple literal")
@@ -0,0 +1,42 @@
+package llvm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEmitStructSpreadDeepCopiesHeapFields(t *testing.T) {
+	sourceCode := `type Config = { name: string, tags: int[], level: int };
+
+@entrypoint
+fn main() -> int {
+    let base: Config = { name = "base", tags = [1, 2], level = 1 };
+    let mut derived: Config = { ..base, level = 2 };
+    derived.tags[0] = 9;
+    return base.tags[0];
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	mainFn := findMIRFunc(t, mirMod, "main")
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+	start := strings.Index(ir, fmt.Sprintf(" @fn.%d(", mainFn.ID))
+	if start < 0 {
+		t.Fatalf("missing main in IR:\n%s", ir)
+	}
+	body := ir[start:]
+	body = body[:strings.Index(body, "\n}\n")]
+
+	// base не перемещается в литерал: строка и массив копируются, а не делятся.
+	if !strings.Contains(body, "call ptr @rt_string_clone(") {
+		t.Fatalf("expected the spread name field to be cloned:\n%s", body)
+	}
+	if !regexp.MustCompile(`call void @__surge_clone\.\d+\(ptr %t\d+, ptr %t\d+\)`).MatchString(body) {
+		t.Fatalf("expected the spread tags field to be deep-copied:\n%s", body)
+	}
+}
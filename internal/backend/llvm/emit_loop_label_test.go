@@ -0,0 +1,56 @@
+package llvm
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestEmitLabeledBreakBranchesToOuterLoopExit(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int32 {
+    let mut i: int32 = 0;
+    let mut hits: int32 = 0;
+    outer: while i < 3 {
+        let mut j: int32 = 0;
+        while j < 4 {
+            if j == 2 {
+                break outer;
+            }
+            hits = hits + 1;
+            j = j + 1;
+        }
+        i = i + 1;
+    }
+    return hits;
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	// Заголовки циклов: br cond, body, exit — сначала внешний, затем внутренний.
+	header := regexp.MustCompile(`icmp slt i32 %t\d+, (\d+)\n(?:  .*\n)*?  br i1 %t\d+, label %(\w+), label %(\w+)`)
+	exits := map[string]string{}
+	for _, m := range header.FindAllStringSubmatch(ir, -1) {
+		exits[m[1]] = m[3]
+	}
+	outerExit, innerExit := exits["3"], exits["4"]
+	if outerExit == "" || innerExit == "" || outerExit == innerExit {
+		t.Fatalf("expected distinct exit blocks for the outer and inner loops, got %v:\n%s", exits, ir)
+	}
+
+	// break outer внутри внутреннего цикла обязан выйти за тело внешнего цикла.
+	breakBr := regexp.MustCompile(`icmp eq i32 %t\d+, 2\n(?:  .*\n)*?  br i1 %t\d+, label %(\w+), label %\w+`)
+	m := breakBr.FindStringSubmatch(ir)
+	if m == nil {
+		t.Fatalf("expected a conditional branch for `if j == 2`:\n%s", ir)
+	}
+	target := m[1]
+	if target != outerExit {
+		// Пустой блок break может остаться отдельным: тогда он сам ведёт к выходу.
+		jump := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(target) + `:\n  br label %(\w+)`)
+		jm := jump.FindStringSubmatch(ir)
+		if jm == nil || jm[1] != outerExit {
+			t.Fatalf("expected break outer to branch to %s (outer exit), not %s:\n%s", outerExit, innerExit, ir)
+		}
+	}
+}
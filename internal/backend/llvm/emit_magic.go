@@ -5,6 +5,7 @@ import (
 
 	"surge/internal/ast"
 	"surge/internal/mir"
+	"surge/internal/types"
 )
 
 func (fe *funcEmitter) emitMagicIntrinsic(call *mir.CallInstr) (bool, error) {
@@ -29,11 +30,40 @@ func (fe *funcEmitter) emitMagicIntrinsic(call *mir.CallInstr) (bool, error) {
 			return false, nil
 		}
 		return true, fe.emitMagicUnaryIntrinsic(call, name)
+	case "__clone":
+		return fe.emitMagicClone(call)
 	default:
 		return false, nil
 	}
 }
 
+// emitMagicClone lowers __clone without a user implementation to a deep copy
+// of the receiver; a user __clone stays an ordinary call.
+func (fe *funcEmitter) emitMagicClone(call *mir.CallInstr) (bool, error) {
+	if len(call.Args) != 1 {
+		return true, fmt.Errorf("__clone requires 1 argument")
+	}
+	if _, ok := fe.emitter.resolveFuncIDForCall(fe.f, call); ok {
+		return false, nil
+	}
+	if !call.HasDst {
+		return true, nil
+	}
+	src, srcType, err := fe.emitCloneSource(&call.Args[0])
+	if err != nil {
+		return true, err
+	}
+	if !fe.emitter.needsDeepClone(srcType) {
+		return true, fmt.Errorf("__clone unsupported for type %s", types.Label(fe.emitter.types, srcType))
+	}
+	ptr, _, err := fe.emitPlacePtr(call.Dst)
+	if err != nil {
+		return true, err
+	}
+	fe.emitDeepClone(ptr, src, srcType)
+	return true, nil
+}
+
 func (fe *funcEmitter) canEmitMagicBinary(call *mir.CallInstr) bool {
 	if call == nil || len(call.Args) != 2 {
 		return false
@@ -203,12 +233,14 @@ func (fe *funcEmitter) emitMagicBinaryIntrinsic(call *mir.CallInstr, name string
 			case "__mul":
 				opcode = "mul"
 			case "__div":
+				fe.emitDivZeroGuard(rightVal, rightTy)
 				if info.signed {
 					opcode = "sdiv"
 				} else {
 					opcode = "udiv"
 				}
 			case "__mod":
+				fe.emitDivZeroGuard(rightVal, rightTy)
 				if info.signed {
 					opcode = "srem"
 				} else {
@@ -229,7 +261,7 @@ func (fe *funcEmitter) emitMagicBinaryIntrinsic(call *mir.CallInstr, name string
 					opcode = "lshr"
 				}
 			}
-			fmt.Fprintf(&fe.emitter.buf, "  %s = %s %s %s, %s\n", tmp, opcode, leftTy, leftVal, rightVal)
+			tmp = fe.emitIntArith(opcode, info, leftTy, leftVal, rightVal)
 			break
 		}
 		if !floatOK {
@@ -0,0 +1,63 @@
+package llvm
+
+import (
+	"fmt"
+
+	"surge/internal/mir"
+)
+
+const surgeStartName = "__surge_start"
+
+// isSurgeStart reports whether f is the synthetic entry function built by MIR.
+// In native code it returns the exit code instead of calling rt_exit, so the
+// generated main can hand it back to the C runtime.
+func isSurgeStart(f *mir.Func) bool {
+	return f != nil && f.Name == surgeStartName
+}
+
+// emitMainWrapper emits the process entry point:
+//
+//	int main(int argc, char** argv) {
+//	    rt_init(argc, argv);
+//	    return rt_finish(__surge_start());
+//	}
+//
+// Modules without an entrypoint still get a main that exits with 0.
+func (e *Emitter) emitMainWrapper() {
+	start := ""
+	if e.mod != nil {
+		for id, f := range e.mod.Funcs {
+			if isSurgeStart(f) {
+				start = e.funcNames[id]
+				break
+			}
+		}
+	}
+	e.buf.WriteString("define i32 @main(i32 %argc, ptr %argv) {\n")
+	e.buf.WriteString("entry:\n")
+	e.buf.WriteString("  call void @rt_init(i32 %argc, ptr %argv)\n")
+	code := "0"
+	if start != "" {
+		fmt.Fprintf(&e.buf, "  %%code = call i64 @%s()\n", start)
+		code = "%code"
+	}
+	fmt.Fprintf(&e.buf, "  %%status = call i32 @rt_finish(i64 %s)\n", code)
+	e.buf.WriteString("  ret i32 %status\n")
+	e.buf.WriteString("}\n\n")
+}
+
+// emitSurgeStartExit records code as the value __surge_start returns in place
+// of the rt_exit call MIR places right before its return.
+func (fe *funcEmitter) emitSurgeStartExit(code64 string) {
+	fe.startExitCode = code64
+}
+
+// emitSurgeStartReturn returns the recorded exit code, or 0 on paths that
+// end without one.
+func (fe *funcEmitter) emitSurgeStartReturn() {
+	code := fe.startExitCode
+	if code == "" {
+		code = "0"
+	}
+	fmt.Fprintf(&fe.emitter.buf, "  ret i64 %s\n", code)
+}
@@ -0,0 +1,73 @@
+package llvm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEmitMainReturnsEntrypointValue(t *testing.T) {
+	sourceCode := `fn answer() -> int {
+    return 42;
+}
+
+@entrypoint
+fn main() -> int {
+    return answer();
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	wrapper := "define i32 @main(i32 %argc, ptr %argv) {\n" +
+		"entry:\n" +
+		"  call void @rt_init(i32 %argc, ptr %argv)\n" +
+		"  %code = call i64 @__surge_start()\n" +
+		"  %status = call i32 @rt_finish(i64 %code)\n" +
+		"  ret i32 %status\n" +
+		"}\n"
+	if !strings.Contains(ir, wrapper) {
+		t.Fatalf("expected main to return the __surge_start exit code:\n%s", ir)
+	}
+
+	start := extractFunctionIR(t, ir, "define i64 @__surge_start()")
+	if strings.Contains(start, "@rt_exit(") {
+		t.Fatalf("__surge_start must return its exit code instead of calling rt_exit:\n%s", start)
+	}
+	// Код возврата — значение, загруженное из результата entrypoint, а не константа.
+	if !regexp.MustCompile(`%t\d+ = call ptr @fn\.\d+\(\)\n  store ptr %t\d+, ptr %l0\n`).MatchString(start) {
+		t.Fatalf("expected __surge_start to store the entrypoint result:\n%s", start)
+	}
+	if !regexp.MustCompile(`%t\d+ = load i64, ptr %t\d+\n(?:.*\n)*  ret i64 %t\d+\n`).MatchString(start) {
+		t.Fatalf("expected __surge_start to return a computed exit code:\n%s", start)
+	}
+}
+
+func TestEmitMainWithoutEntrypoint(t *testing.T) {
+	sourceCode := `fn helper() -> int {
+    return 1;
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	if strings.Contains(ir, "@__surge_start") {
+		t.Fatalf("unexpected __surge_start without an entrypoint:\n%s", ir)
+	}
+	if !strings.Contains(ir, "  %status = call i32 @rt_finish(i64 0)\n  ret i32 %status\n") {
+		t.Fatalf("expected main to exit with 0 without an entrypoint:\n%s", ir)
+	}
+}
+
+func extractFunctionIR(t *testing.T, ir, header string) string {
+	t.Helper()
+	start := strings.Index(ir, header)
+	if start < 0 {
+		t.Fatalf("missing %q in IR:\n%s", header, ir)
+	}
+	end := strings.Index(ir[start:], "\n}\n")
+	if end < 0 {
+		t.Fatalf("unterminated function %q in IR:\n%s", header, ir)
+	}
+	return ir[start : start+end+3]
+}
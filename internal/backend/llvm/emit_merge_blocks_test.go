@@ -0,0 +1,43 @@
+package llvm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEmitIdenticalCompareArmsShareOneBlock(t *testing.T) {
+	sourceCode := `fn pick(x: int32) -> int32 {
+    return compare x {
+        1 => 7;
+        2 => 7;
+        finally => 0;
+    };
+}
+
+@entrypoint
+fn main() -> int32 {
+    return pick(2);
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	fn := regexp.MustCompile(`(?s)define i32 @fn\.\d+\(i32 %p0\) \{\n.*?\n\}`).FindString(ir)
+	if fn == "" {
+		t.Fatalf("expected pick in the emitted IR:\n%s", ir)
+	}
+	if n := strings.Count(fn, "store i32 7, ptr"); n != 1 {
+		t.Fatalf("expected the two identical arm bodies to be merged into one block, found %d copies:\n%s", n, fn)
+	}
+
+	// Обе проверки `x == 1` и `x == 2` должны вести в один и тот же блок арма.
+	arm := regexp.MustCompile(`icmp eq i32 %t\d+, ([12])\n(?:  .*\n)*?  br i1 %t\d+, label %(\w+), label %\w+`)
+	targets := map[string]string{}
+	for _, m := range arm.FindAllStringSubmatch(fn, -1) {
+		targets[m[1]] = m[2]
+	}
+	if targets["1"] == "" || targets["1"] != targets["2"] {
+		t.Fatalf("expected both arms to branch to one shared block, got %v:\n%s", targets, fn)
+	}
+}
@@ -0,0 +1,65 @@
+package llvm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const overflowSource = `fn sum(a: int32, b: int32) -> int32 {
+    return a + b;
+}
+
+fn diff(a: uint8, b: uint8) -> uint8 {
+    return a - b;
+}
+
+fn prod(a: int64, b: int64) -> int64 {
+    return a * b;
+}
+
+@entrypoint
+fn main() -> int {
+    let s: int32 = sum(2147483647:int32, 1:int32);
+    let d: uint8 = diff(1:uint8, 2:uint8);
+    let p: int64 = prod(2:int64, 3:int64);
+    return (s to int) + (d to int) + (p to int);
+}
+`
+
+func TestEmitIntegerArithmeticChecksOverflow(t *testing.T) {
+	ir := emitLLVMFromSource(t, overflowSource)
+
+	check := regexp.MustCompile(`(?s)%(t\d+) = call \{i32, i1\} @llvm\.sadd\.with\.overflow\.i32\(i32 %t\d+, i32 %t\d+\)\n  %t\d+ = extractvalue \{i32, i1\} %(t\d+), 0\n  %(t\d+) = extractvalue \{i32, i1\} %(t\d+), 1\n  br i1 %(t\d+), label %(\S+), label %\S+\n(\S+):\n  call void @rt_panic_overflow\(\)\n  unreachable\n`)
+	m := check.FindStringSubmatch(ir)
+	if m == nil {
+		t.Fatalf("expected checked sadd before overflow panic:\n%s", ir)
+	}
+	if m[1] != m[2] || m[1] != m[4] || m[3] != m[5] || m[6] != m[7] {
+		t.Fatalf("overflow check is not wired to the panic block: %v", m[1:])
+	}
+	for _, want := range []string{
+		"call {i8, i1} @llvm.usub.with.overflow.i8(",
+		"call {i64, i1} @llvm.smul.with.overflow.i64(",
+		"declare {i32, i1} @llvm.sadd.with.overflow.i32(i32, i32)",
+		"declare void @rt_panic_overflow()",
+	} {
+		if !strings.Contains(ir, want) {
+			t.Fatalf("expected %q in IR:\n%s", want, ir)
+		}
+	}
+}
+
+func TestEmitIntegerArithmeticWithoutRuntimeChecks(t *testing.T) {
+	mirMod, result := lowerMIRFromSource(t, overflowSource)
+	ir, err := EmitModuleWithOptions(mirMod, result.Sema.TypeInterner, result.Symbols.Table, EmitOptions{NoRuntimeChecks: true})
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+	if !strings.Contains(ir, "add i32") || !strings.Contains(ir, "mul i64") {
+		t.Fatalf("expected plain add/mul in IR:\n%s", ir)
+	}
+	if strings.Contains(ir, "call void @rt_panic_overflow()") {
+		t.Fatalf("overflow checks must be elided with NoRuntimeChecks:\n%s", ir)
+	}
+}
@@ -0,0 +1,45 @@
+package llvm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestEmitPackedStructFieldOffsets(t *testing.T) {
+	sourceCode := `type Plain = { a: uint8, b: uint32 }
+
+@packed
+type Packed = { a: uint8, b: uint32 }
+
+fn plain_b(p: Plain) -> uint32 { return p.b; }
+
+fn packed_b(p: Packed) -> uint32 { return p.b; }
+
+@entrypoint
+fn main() -> int {
+    let x = plain_b(Plain { a = 1:uint8, b = 2:uint32 });
+    let y = packed_b(Packed { a = 1:uint8, b = 2:uint32 });
+    return (x + y) to int;
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+
+	gep := regexp.MustCompile(`getelementptr inbounds i8, ptr %\w+, i64 (\d+)\n\s+%\w+ = load i32`)
+	for name, want := range map[string]string{"plain_b": "4", "packed_b": "1"} {
+		fn := findMIRFunc(t, mirMod, name)
+		body := findLLVMFuncBody(t, ir, fmt.Sprintf("fn.%d", fn.ID))
+		m := gep.FindStringSubmatch(body)
+		if m == nil {
+			t.Fatalf("%s: expected field load through getelementptr:\n%s", name, body)
+		}
+		if m[1] != want {
+			t.Fatalf("%s: expected field b at offset %s, got %s", name, want, m[1])
+		}
+	}
+}
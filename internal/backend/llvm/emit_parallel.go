@@ -0,0 +1,164 @@
+package llvm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"surge/internal/ast"
+	"surge/internal/mir"
+)
+
+// Parallel map/reduce run on the runtime fork-join helpers:
+//
+//	ptr  @rt_parallel_map(ptr arr, i64 in_stride, i64 out_stride, i64 out_align, ptr thunk, ptr state)
+//	void @rt_parallel_reduce(ptr arr, i64 in_stride, ptr acc, ptr thunk, ptr state)
+//
+// The element function is passed through a per-function thunk with a uniform
+// signature: void(ptr state, ptr elem, ptr out) for map and
+// void(ptr state, ptr acc, ptr elem) for reduce.
+
+func isParallelFunc(f *mir.Func) bool {
+	if f == nil || f.Name == "" {
+		return false
+	}
+	return strings.HasPrefix(f.Name, "__parallel_map$") || strings.HasPrefix(f.Name, "__parallel_reduce$")
+}
+
+func parallelThunkName(fnName string) string {
+	return fnName + ".parallel"
+}
+
+func (e *Emitter) emitParallelThunks() error {
+	if e == nil || e.mod == nil {
+		return nil
+	}
+	reachable := e.reachableFuncs()
+	ids := make([]mir.FuncID, 0)
+	for id, f := range e.mod.Funcs {
+		if _, ok := reachable[id]; ok && isParallelFunc(f) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		f := e.mod.Funcs[id]
+		name := e.funcNames[id]
+		sig, ok := e.funcSigs[id]
+		if !ok {
+			return fmt.Errorf("missing parallel function signature for %s", f.Name)
+		}
+		if sig.ret == "void" {
+			return fmt.Errorf("parallel function %s must return a value", f.Name)
+		}
+		switch len(sig.params) {
+		case 2:
+			fmt.Fprintf(&e.buf, "define void @%s(ptr %%state, ptr %%elem, ptr %%out) {\n", parallelThunkName(name))
+			fmt.Fprintf(&e.buf, "entry:\n")
+			fmt.Fprintf(&e.buf, "  %%x = load %s, ptr %%elem\n", sig.params[1])
+			fmt.Fprintf(&e.buf, "  %%r = call %s @%s(ptr %%state, %s %%x)\n", sig.ret, name, sig.params[1])
+			fmt.Fprintf(&e.buf, "  store %s %%r, ptr %%out\n", sig.ret)
+		case 3:
+			fmt.Fprintf(&e.buf, "define void @%s(ptr %%state, ptr %%acc, ptr %%elem) {\n", parallelThunkName(name))
+			fmt.Fprintf(&e.buf, "entry:\n")
+			fmt.Fprintf(&e.buf, "  %%a = load %s, ptr %%acc\n", sig.params[1])
+			fmt.Fprintf(&e.buf, "  %%x = load %s, ptr %%elem\n", sig.params[2])
+			fmt.Fprintf(&e.buf, "  %%r = call %s @%s(ptr %%state, %s %%a, %s %%x)\n", sig.ret, name, sig.params[1], sig.params[2])
+			fmt.Fprintf(&e.buf, "  store %s %%r, ptr %%acc\n", sig.ret)
+		default:
+			return fmt.Errorf("parallel function %s has %d parameters", f.Name, len(sig.params))
+		}
+		fmt.Fprintf(&e.buf, "  ret void\n")
+		fmt.Fprintf(&e.buf, "}\n\n")
+	}
+	return nil
+}
+
+func (fe *funcEmitter) emitInstrParallel(ins *mir.Instr) error {
+	if ins == nil {
+		return nil
+	}
+	par := &ins.Parallel
+	f := fe.emitter.mod.Funcs[par.FuncID]
+	sig, ok := fe.emitter.funcSigs[par.FuncID]
+	if f == nil || !ok || len(sig.params) < 2 {
+		return fmt.Errorf("parallel: missing function %d", par.FuncID)
+	}
+	thunk := parallelThunkName(fe.emitter.funcNames[par.FuncID])
+
+	stateVal, stateTy, err := fe.emitStructLit(&par.State)
+	if err != nil {
+		return err
+	}
+	if stateTy != "ptr" {
+		return fmt.Errorf("parallel expects state pointer, got %s", stateTy)
+	}
+	arr, arrTy, err := fe.emitValueOperand(&par.Iterable)
+	if err != nil {
+		return err
+	}
+	if arrTy != "ptr" {
+		return fmt.Errorf("parallel expects array handle, got %s", arrTy)
+	}
+	elemTy := sig.params[len(sig.params)-1]
+	inStride, _, err := llvmElemStride(elemTy)
+	if err != nil {
+		return err
+	}
+
+	ptr, _, err := fe.emitPlacePtr(par.Dst)
+	if err != nil {
+		return err
+	}
+
+	if par.Kind != ast.ExprParallelReduce {
+		outStride, outAlign, strideErr := llvmElemStride(sig.ret)
+		if strideErr != nil {
+			return strideErr
+		}
+		tmp := fe.nextTemp()
+		fmt.Fprintf(&fe.emitter.buf,
+			"  %s = call ptr @rt_parallel_map(ptr %s, i64 %d, i64 %d, i64 %d, ptr @%s, ptr %s)\n",
+			tmp, arr, inStride, outStride, outAlign, thunk, stateVal)
+		fmt.Fprintf(&fe.emitter.buf, "  store ptr %s, ptr %s\n", tmp, ptr)
+		return nil
+	}
+
+	accTy := sig.params[1]
+	initVal, initTy, err := fe.emitValueOperand(&par.Init)
+	if err != nil {
+		return err
+	}
+	if initTy != accTy {
+		initVal, initTy, err = fe.coerceNumericValue(initVal, initTy, operandValueType(fe.emitter.types, &par.Init), f.Result)
+		if err != nil {
+			return err
+		}
+		if initTy != accTy {
+			return fmt.Errorf("parallel reduce init type mismatch: expected %s, got %s", accTy, initTy)
+		}
+	}
+	accPtr := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = alloca %s\n", accPtr, accTy)
+	fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", accTy, initVal, accPtr)
+	fmt.Fprintf(&fe.emitter.buf,
+		"  call void @rt_parallel_reduce(ptr %s, i64 %d, ptr %s, ptr @%s, ptr %s)\n",
+		arr, inStride, accPtr, thunk, stateVal)
+	result := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = load %s, ptr %s\n", result, accTy, accPtr)
+	fmt.Fprintf(&fe.emitter.buf, "  store %s %s, ptr %s\n", accTy, result, ptr)
+	return nil
+}
+
+// llvmElemStride returns the array stride and alignment of an element stored as ty.
+func llvmElemStride(ty string) (stride, align int, err error) {
+	size, align, err := llvmTypeSizeAlign(ty)
+	if err != nil {
+		return 0, 0, err
+	}
+	if align <= 0 {
+		align = 1
+	}
+	return roundUpInt(size, align), align, nil
+}
@@ -0,0 +1,65 @@
+package llvm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"surge/internal/mir"
+)
+
+func TestEmitParallelMapForkJoin(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let xs: int[] = [1, 2, 3];
+    let k = 10;
+    let ys = parallel map xs with (x) => x * k;
+    let total = parallel reduce ys with 0, (acc, y) => acc + y;
+    print(total to string);
+    return 0;
+}
+`
+
+	mirMod, result := lowerMIRFromSourceWithOptions(t, sourceCode, mir.LowerOptions{ParallelRuntime: true})
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+
+	mapCall := regexp.MustCompile(`call ptr @rt_parallel_map\(ptr %\w+, i64 8, i64 8, i64 8, ptr @(fn\.\d+)\.parallel, ptr %\w+\)`)
+	m := mapCall.FindStringSubmatch(ir)
+	if m == nil {
+		t.Fatalf("expected rt_parallel_map fork-join call:\n%s", ir)
+	}
+	mapThunk := "define void @" + m[1] + ".parallel(ptr %state, ptr %elem, ptr %out)"
+	if !strings.Contains(ir, mapThunk) {
+		t.Fatalf("missing map thunk %q:\n%s", mapThunk, ir)
+	}
+	if !strings.Contains(ir, "call ptr @"+m[1]+"(ptr %state, ptr %x)") {
+		t.Fatalf("map thunk must call the element function:\n%s", ir)
+	}
+
+	reduceCall := regexp.MustCompile(`call void @rt_parallel_reduce\(ptr %\w+, i64 8, ptr (%\w+), ptr @(fn\.\d+)\.parallel, ptr %\w+\)`)
+	r := reduceCall.FindStringSubmatch(ir)
+	if r == nil {
+		t.Fatalf("expected rt_parallel_reduce call:\n%s", ir)
+	}
+	if !strings.Contains(ir, "define void @"+r[2]+".parallel(ptr %state, ptr %acc, ptr %elem)") {
+		t.Fatalf("missing reduce thunk for %s:\n%s", r[2], ir)
+	}
+}
+
+func TestEmitParallelWithoutRuntimeUsesLoop(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let xs: int[] = [1, 2, 3];
+    let ys = parallel map xs with (x) => x + 1;
+    return ys[0];
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+	if strings.Contains(ir, "call ptr @rt_parallel_map") {
+		t.Fatalf("sequential lowering must not call the parallel runtime:\n%s", ir)
+	}
+}
@@ -22,6 +22,18 @@ func (e *Emitter) prepareGlobals() error {
 	return nil
 }
 
+// mergeIdenticalBlocks сливает одинаковые блоки внутри каждой функции до emit:
+// цепочки compare/if часто порождают арм-блоки с одинаковым телом, и без слияния
+// каждый из них попадает в IR отдельной копией.
+func (e *Emitter) mergeIdenticalBlocks() {
+	if e.mod == nil {
+		return
+	}
+	for _, f := range e.mod.Funcs {
+		mir.MergeIdenticalBlocks(f)
+	}
+}
+
 func (e *Emitter) prepareFunctions() error {
 	if e.mod == nil {
 		return nil
@@ -34,7 +46,7 @@ func (e *Emitter) prepareFunctions() error {
 	}
 	for _, f := range funcs {
 		name := fmt.Sprintf("fn.%d", f.ID)
-		if f.Name == "__surge_start" {
+		if isSurgeStart(f) {
 			name = f.Name
 		}
 		e.funcNames[f.ID] = name
@@ -66,6 +78,9 @@ func (e *Emitter) prepareFunctions() error {
 			}
 			ret = inferred
 		}
+		if isSurgeStart(f) {
+			ret = "i64"
+		}
 		e.funcSigs[f.ID] = funcSig{ret: ret, params: params, paramTypes: paramTypes}
 	}
 	return nil
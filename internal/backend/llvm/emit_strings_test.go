@@ -0,0 +1,37 @@
+package llvm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEmitStringConstsDedupByBytes(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let a: string = "a\\b";
+    let b: string = r"a\b";
+    let c: string = "a\tb";
+    return (len(a) + len(b) + len(c)) to int;
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+
+	backslash := regexp.MustCompile(`(?m)^@(\.str\.\d+) = private unnamed_addr constant \[3 x i8\] c"\\61\\5C\\62"$`)
+	globals := backslash.FindAllStringSubmatch(ir, -1)
+	if len(globals) != 1 {
+		t.Fatalf("expected one global for \"a\\\\b\" and r\"a\\b\", got %d:\n%s", len(globals), ir)
+	}
+	name := globals[0][1]
+	if uses := strings.Count(ir, "ptr @"+name+","); uses != 2 {
+		t.Fatalf("expected both literals to reference @%s, got %d uses", name, uses)
+	}
+	if !strings.Contains(ir, `c"\61\09\62"`) {
+		t.Fatalf("expected a separate global for \"a\\tb\"")
+	}
+}
@@ -0,0 +1,31 @@
+package llvm
+
+import (
+	"strings"
+	"testing"
+)
+
+const targetSource = `@entrypoint
+fn main() -> int {
+    return 0;
+}
+`
+
+func TestEmitTargetTripleOverride(t *testing.T) {
+	mirMod, result := lowerMIRFromSource(t, targetSource)
+	ir, err := EmitModuleWithOptions(mirMod, result.Sema.TypeInterner, result.Symbols.Table, EmitOptions{TargetTriple: "aarch64-unknown-linux-gnu"})
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+	if !strings.HasPrefix(ir, "target triple = \"aarch64-unknown-linux-gnu\"\n") {
+		t.Fatalf("unexpected preamble:\n%s", ir[:min(len(ir), 200)])
+	}
+}
+
+func TestEmitRejectsTargetWithoutLayout(t *testing.T) {
+	mirMod, result := lowerMIRFromSource(t, targetSource)
+	_, err := EmitModuleWithOptions(mirMod, result.Sema.TypeInterner, result.Symbols.Table, EmitOptions{TargetTriple: "i686-linux-gnu"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported target triple") {
+		t.Fatalf("expected an unsupported target error, got %v", err)
+	}
+}
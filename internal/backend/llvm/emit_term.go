@@ -41,6 +41,10 @@ func (fe *funcEmitter) emitTerminator(term *mir.Terminator) error {
 			fmt.Fprintf(&fe.emitter.buf, "  ret %s %s\n", ty, val)
 			return nil
 		}
+		if isSurgeStart(fe.f) {
+			fe.emitSurgeStartReturn()
+			return nil
+		}
 		fmt.Fprintf(&fe.emitter.buf, "  ret void\n")
 		return nil
 	case mir.TermAsyncYield:
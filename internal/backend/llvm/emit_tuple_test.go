@@ -0,0 +1,43 @@
+package llvm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEmitTupleLiteralStoresElementsAtOffsets(t *testing.T) {
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let t: (int, bool) = (1, true);
+    let unit: () = ();
+    if t.1 {
+        return t.0;
+    }
+    return 0;
+}
+`
+
+	ir := emitLLVMFromSource(t, sourceCode)
+
+	// (int, bool): int — указатель на bigint (8 байт), bool лежит следом по смещению 8.
+	if !strings.Contains(ir, "call ptr @rt_alloc(i64 16, i64 8)") {
+		t.Fatalf("expected tuple literal to allocate its layout:\n%s", ir)
+	}
+	storeBool := regexp.MustCompile(`(%t\d+) = getelementptr inbounds i8, ptr %t\d+, i64 8\n  store i1 1, ptr (%t\d+)`)
+	if m := storeBool.FindStringSubmatch(ir); m == nil || m[1] != m[2] {
+		t.Fatalf("expected tuple literal to store true at offset 8:\n%s", ir)
+	}
+	loadBool := regexp.MustCompile(`(%t\d+) = getelementptr inbounds i8, ptr %t\d+, i64 8\n  %t\d+ = load i1, ptr (%t\d+)`)
+	if m := loadBool.FindStringSubmatch(ir); m == nil || m[1] != m[2] {
+		t.Fatalf("expected t.1 to load i1 from offset 8:\n%s", ir)
+	}
+	loadInt := regexp.MustCompile(`(%t\d+) = getelementptr inbounds i8, ptr %t\d+, i64 0\n  %t\d+ = load ptr, ptr (%t\d+)`)
+	if m := loadInt.FindStringSubmatch(ir); m == nil || m[1] != m[2] {
+		t.Fatalf("expected t.0 to load the int from offset 0:\n%s", ir)
+	}
+	// Пустой кортеж — unit: одно байтовое значение без выделения памяти.
+	if !strings.Contains(ir, "store i8 0, ptr") {
+		t.Fatalf("expected empty tuple to lower to a unit value:\n%s", ir)
+	}
+}
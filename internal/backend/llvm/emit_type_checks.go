@@ -0,0 +1,228 @@
+package llvm
+
+import (
+	"fmt"
+
+	"surge/internal/mir"
+	"surge/internal/source"
+	"surge/internal/types"
+)
+
+// `is` and `heir` follow internal/vm/type_checks.go: the left side is the type
+// of the value, ignoring `own`. After monomorphization that type is static
+// except for a union holding its plain (non-tag) member, which is stored as the
+// member value itself; its discriminant then lies outside the union's tag cases.
+
+func (fe *funcEmitter) emitTypeTest(tt *mir.TypeTest) (val, ty string, err error) {
+	if tt == nil {
+		return "", "", fmt.Errorf("nil type test")
+	}
+	typesIn := fe.emitter.types
+	target := stripOwnType(typesIn, tt.TargetTy)
+	left := stripOwnType(typesIn, fe.typeTestOperandType(&tt.Value))
+	if left == types.NoTypeID || target == types.NoTypeID {
+		return "0", "i1", nil
+	}
+	if member, ok := fe.unionPlainMember(left); ok && member == target {
+		return fe.emitUnionHoldsPlainMember(&tt.Value, left)
+	}
+	return boolConstI1(left == target), "i1", nil
+}
+
+func (fe *funcEmitter) emitHeirTest(ht *mir.HeirTest) (val, ty string, err error) {
+	if ht == nil {
+		return "", "", fmt.Errorf("nil heir test")
+	}
+	typesIn := fe.emitter.types
+	left := stripOwnType(typesIn, fe.typeTestOperandType(&ht.Value))
+	asUnion := typeHeir(typesIn, left, ht.TargetTy)
+	member, ok := fe.unionPlainMember(left)
+	if !ok {
+		return boolConstI1(asUnion), "i1", nil
+	}
+	asMember := typeHeir(typesIn, member, ht.TargetTy)
+	if asMember == asUnion {
+		return boolConstI1(asUnion), "i1", nil
+	}
+	holds, _, err := fe.emitUnionHoldsPlainMember(&ht.Value, left)
+	if err != nil {
+		return "", "", err
+	}
+	if asMember {
+		return holds, "i1", nil
+	}
+	inv := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = xor i1 %s, true\n", inv, holds)
+	return inv, "i1", nil
+}
+
+func (fe *funcEmitter) typeTestOperandType(op *mir.Operand) types.TypeID {
+	typeID := op.Type
+	if typeID == types.NoTypeID && op.Kind != mir.OperandConst {
+		if baseType, err := fe.placeBaseType(op.Place); err == nil {
+			typeID = baseType
+		}
+	}
+	return typeID
+}
+
+// unionPlainMember returns the only plain member type of a union with tag
+// cases; with none or several of them the value's type is decided statically.
+func (fe *funcEmitter) unionPlainMember(id types.TypeID) (types.TypeID, bool) {
+	typesIn := fe.emitter.types
+	id = resolveAliasAndOwn(typesIn, id)
+	if typesIn == nil || !isUnionType(typesIn, id) {
+		return types.NoTypeID, false
+	}
+	info, ok := typesIn.UnionInfo(id)
+	if !ok || info == nil {
+		return types.NoTypeID, false
+	}
+	member := types.NoTypeID
+	for _, m := range info.Members {
+		if m.Kind != types.UnionMemberType {
+			continue
+		}
+		if isUnionType(typesIn, m.Type) || member != types.NoTypeID {
+			return types.NoTypeID, false
+		}
+		member = stripOwnType(typesIn, m.Type)
+	}
+	return member, member != types.NoTypeID
+}
+
+// emitUnionHoldsPlainMember emits `discriminant >= len(tag cases)`.
+func (fe *funcEmitter) emitUnionHoldsPlainMember(op *mir.Operand, unionType types.TypeID) (val, ty string, err error) {
+	cases, err := fe.emitter.tagCases(unionType)
+	if err != nil {
+		return "", "", err
+	}
+	tagVal, err := fe.emitTagDiscriminant(op)
+	if err != nil {
+		return "", "", err
+	}
+	tmp := fe.nextTemp()
+	fmt.Fprintf(&fe.emitter.buf, "  %s = icmp uge i32 %s, %d\n", tmp, tagVal, len(cases))
+	return tmp, "i1", nil
+}
+
+func boolConstI1(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func stripOwnType(typesIn *types.Interner, id types.TypeID) types.TypeID {
+	if typesIn == nil {
+		return id
+	}
+	for range 32 {
+		tt, ok := typesIn.Lookup(id)
+		if !ok || tt.Kind != types.KindOwn {
+			return id
+		}
+		id = tt.Elem
+	}
+	return id
+}
+
+// typeHeir walks aliases and struct bases from left, accepting right itself or
+// a union that lists one of the visited types.
+func typeHeir(typesIn *types.Interner, left, right types.TypeID) bool {
+	if typesIn == nil {
+		return false
+	}
+	left = stripOwnType(typesIn, left)
+	right = stripOwnType(typesIn, right)
+	if left == types.NoTypeID || right == types.NoTypeID {
+		return false
+	}
+	rightIsUnion := false
+	if tt, ok := typesIn.Lookup(right); ok && tt.Kind == types.KindUnion {
+		rightIsUnion = true
+	}
+	seen := map[types.TypeID]struct{}{left: {}}
+	queue := []types.TypeID{left}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == right {
+			return true
+		}
+		if rightIsUnion && unionContainsType(typesIn, right, cur) {
+			return true
+		}
+		tt, ok := typesIn.Lookup(cur)
+		if !ok {
+			continue
+		}
+		next := types.NoTypeID
+		if tt.Kind == types.KindAlias {
+			if target, ok := typesIn.AliasTarget(cur); ok {
+				next = target
+			}
+		}
+		for _, t := range []types.TypeID{next, structBaseType(typesIn, cur)} {
+			t = stripOwnType(typesIn, t)
+			if t == types.NoTypeID {
+				continue
+			}
+			if _, exists := seen[t]; !exists {
+				seen[t] = struct{}{}
+				queue = append(queue, t)
+			}
+		}
+	}
+	return false
+}
+
+func structBaseType(typesIn *types.Interner, id types.TypeID) types.TypeID {
+	if base, ok := typesIn.StructBase(id); ok {
+		return base
+	}
+	return types.NoTypeID
+}
+
+func unionContainsType(typesIn *types.Interner, unionType, candidate types.TypeID) bool {
+	info, ok := typesIn.UnionInfo(unionType)
+	if !ok || info == nil {
+		return false
+	}
+	candidate = stripOwnType(typesIn, candidate)
+	for _, member := range info.Members {
+		switch member.Kind {
+		case types.UnionMemberNothing:
+			if candidate == typesIn.Builtins().Nothing {
+				return true
+			}
+		case types.UnionMemberType:
+			if stripOwnType(typesIn, member.Type) == candidate {
+				return true
+			}
+		case types.UnionMemberTag:
+			if tagTypeMatches(typesIn, candidate, member.TagName, member.TagArgs) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func tagTypeMatches(typesIn *types.Interner, candidate types.TypeID, tagName source.StringID, tagArgs []types.TypeID) bool {
+	if candidate == types.NoTypeID || tagName == source.NoStringID {
+		return false
+	}
+	info, ok := typesIn.UnionInfo(candidate)
+	if !ok || info == nil || info.Name != tagName || len(info.TypeArgs) != len(tagArgs) {
+		return false
+	}
+	for i := range info.TypeArgs {
+		a := stripOwnType(typesIn, info.TypeArgs[i])
+		b := stripOwnType(typesIn, tagArgs[i])
+		if a == types.NoTypeID || a != b {
+			return false
+		}
+	}
+	return true
+}
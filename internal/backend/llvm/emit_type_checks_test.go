@@ -0,0 +1,77 @@
+package llvm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEmitTypeTestChecksUnionPlainMemberAtRuntime(t *testing.T) {
+	sourceCode := `type Child = { v: int }
+tag Leaf(int);
+type Tree = Leaf(int) | Child;
+
+fn is_child(t: Tree) -> bool {
+    return t is Child;
+}
+
+fn not_child(t: Tree) -> bool {
+    return !(t heir Child);
+}
+
+@entrypoint
+fn main() -> int {
+    if is_child(Child { v: 1 }) && not_child(Leaf(2)) {
+        return 0;
+    }
+    return 1;
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+
+	for _, name := range []string{"is_child", "not_child"} {
+		fn := findMIRFunc(t, mirMod, name)
+		body := findLLVMFuncBody(t, ir, fmt.Sprintf("fn.%d", fn.ID))
+		if !strings.Contains(body, "icmp uge i32") {
+			t.Fatalf("%s should compare the union discriminant against its tag cases:\n%s", name, body)
+		}
+	}
+}
+
+func TestEmitTypeTestFoldsStaticTypes(t *testing.T) {
+	sourceCode := `type Base = { b: int }
+type Kid = Base : { k: int }
+
+fn check(k: Kid) -> bool {
+    return (k heir Base) && (k is Kid) && !(k is Base);
+}
+
+@entrypoint
+fn main() -> int {
+    if check(Kid { b: 1, k: 2 }) {
+        return 0;
+    }
+    return 1;
+}
+`
+
+	mirMod, result := lowerMIRFromSource(t, sourceCode)
+	ir, err := EmitModule(mirMod, result.Sema.TypeInterner, result.Symbols.Table)
+	if err != nil {
+		t.Fatalf("emit LLVM IR: %v", err)
+	}
+
+	fn := findMIRFunc(t, mirMod, "check")
+	body := findLLVMFuncBody(t, ir, fmt.Sprintf("fn.%d", fn.ID))
+	if strings.Contains(body, "icmp") {
+		t.Fatalf("type checks on a struct should fold to constants:\n%s", body)
+	}
+	if !strings.Contains(body, "i1 1") || !strings.Contains(body, "i1 0") {
+		t.Fatalf("expected folded true and false results:\n%s", body)
+	}
+}
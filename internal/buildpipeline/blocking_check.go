@@ -71,6 +71,7 @@ func collectBlockingSpans(mod *hir.Module) []source.Span {
 			}
 		case hir.ExprStructLit:
 			if data, ok := e.Data.(hir.StructLitData); ok {
+				scanExpr(data.Base)
 				for _, field := range data.Fields {
 					scanExpr(field.Value)
 				}
@@ -162,6 +163,13 @@ func collectBlockingSpans(mod *hir.Module) []source.Span {
 			if data, ok := e.Data.(hir.BlockingData); ok {
 				scanBlock(data.Body)
 			}
+		case hir.ExprParallel:
+			if data, ok := e.Data.(hir.ParallelData); ok {
+				scanExpr(data.Iterable)
+				scanExpr(data.Init)
+				scanExpr(data.Body)
+				scanBlock(data.Seq)
+			}
 		case hir.ExprCast:
 			if data, ok := e.Data.(hir.CastData); ok {
 				scanExpr(data.Value)
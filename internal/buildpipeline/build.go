@@ -16,23 +16,39 @@ import (
 
 	"surge/internal/backend/llvm"
 	"surge/internal/driver"
+	"surge/internal/layout"
 	"surge/internal/mir"
 	runtimeembed "surge/runtime"
 )
 
+// EmitKind selects the artefact an LLVM build produces.
+type EmitKind string
+
+const (
+	// EmitBin links the program with the native runtime into an executable.
+	EmitBin EmitKind = "bin"
+	// EmitObj compiles the program into an object file without the runtime.
+	EmitObj EmitKind = "obj"
+	// EmitLL writes the LLVM IR text and needs no toolchain.
+	EmitLL EmitKind = "ll"
+)
+
 // BuildRequest configures output generation for a compilation.
 type BuildRequest struct {
 	CompileRequest
-	OutputName    string
-	OutputRoot    string
-	Profile       string
-	Backend       Backend
-	EmitMIR       bool
-	EmitLLVM      bool
-	KeepTmp       bool
-	PrintCommands bool
-	ManifestRoot  string
-	ManifestFound bool
+	OutputName      string
+	OutputRoot      string
+	Profile         string
+	Backend         Backend
+	EmitMIR         bool
+	EmitLLVM        bool
+	KeepTmp         bool
+	PrintCommands   bool
+	ManifestRoot    string
+	ManifestFound   bool
+	NoRuntimeChecks bool
+	Emit            EmitKind // LLVM backend only; empty means EmitBin
+	TargetTriple    string   // LLVM backend only; empty means x86_64-linux-gnu, see layout.TargetForTriple
 }
 
 // BuildResult captures build artefacts and timings.
@@ -59,6 +75,19 @@ func Build(ctx context.Context, req *BuildRequest) (BuildResult, error) {
 	if req.Profile == "" {
 		req.Profile = "debug"
 	}
+	if req.Emit == "" {
+		req.Emit = EmitBin
+	}
+	if req.Emit != EmitBin && req.Emit != EmitObj && req.Emit != EmitLL {
+		return result, fmt.Errorf("unsupported emit kind: %s (supported: bin, obj, ll)", req.Emit)
+	}
+	if req.Backend == BackendLLVM {
+		target, err := layout.TargetForTriple(req.TargetTriple)
+		if err != nil {
+			return result, err
+		}
+		req.CompileRequest.Target = target
+	}
 
 	req.CompileRequest.Backend = req.Backend
 	compileRes, err := Compile(ctx, &req.CompileRequest)
@@ -85,6 +114,9 @@ func Build(ctx context.Context, req *BuildRequest) (BuildResult, error) {
 	}
 	outputDir := filepath.Join(outputRoot, "target", req.Profile)
 	outputPath := filepath.Join(outputDir, req.OutputName)
+	if req.Backend == BackendLLVM {
+		outputPath += emitExtension(req.Emit)
+	}
 	tmpDir := filepath.Join(outputDir, ".tmp", req.OutputName)
 	result.OutputPath = outputPath
 	result.TmpDir = tmpDir
@@ -128,12 +160,15 @@ func Build(ctx context.Context, req *BuildRequest) (BuildResult, error) {
 		result.Timings.Set(StageBuild, time.Since(buildStart))
 
 	case BackendLLVM:
-		if err := ensureClangAvailable(); err != nil {
+		if err := ensureToolchain(req.Emit); err != nil {
 			emitStage(req.Progress, req.Files, StageBuild, StatusError, err, 0)
 			return result, err
 		}
 		llPath := filepath.Join(tmpDir, "out.ll")
-		llvmIR, err := llvm.EmitModule(compileRes.MIR, compileRes.Diagnose.Sema.TypeInterner, compileRes.Diagnose.Symbols.Table)
+		llvmIR, err := llvm.EmitModuleWithOptions(compileRes.MIR, compileRes.Diagnose.Sema.TypeInterner, compileRes.Diagnose.Symbols.Table, llvm.EmitOptions{
+			NoRuntimeChecks: req.NoRuntimeChecks,
+			TargetTriple:    req.TargetTriple,
+		})
 		if err != nil {
 			err = fmt.Errorf("LLVM emit failed: %w", err)
 			emitStage(req.Progress, req.Files, StageBuild, StatusError, err, 0)
@@ -145,10 +180,18 @@ func Build(ctx context.Context, req *BuildRequest) (BuildResult, error) {
 			return result, err
 		}
 		result.Timings.Set(StageBuild, time.Since(buildStart))
+		if req.Emit == EmitLL {
+			if err := os.WriteFile(outputPath, []byte(llvmIR), 0o600); err != nil {
+				err = fmt.Errorf("failed to write build output %q: %w", outputPath, err)
+				emitStage(req.Progress, req.Files, StageBuild, StatusError, err, 0)
+				return result, err
+			}
+			break
+		}
 
 		linkStart := time.Now()
 		emitStage(req.Progress, req.Files, StageLink, StatusWorking, nil, 0)
-		if err := buildLLVMOutput(tmpDir, outputPath, req.PrintCommands); err != nil {
+		if err := buildLLVMOutput(tmpDir, outputPath, req.Emit, req.TargetTriple, req.PrintCommands); err != nil {
 			emitStage(req.Progress, req.Files, StageLink, StatusError, err, 0)
 			return result, err
 		}
@@ -203,19 +246,54 @@ func buildVMWrapperScript(manifestFound bool, manifestRoot, targetPath, baseDir
 	return fmt.Sprintf("#!/bin/sh\nset -e\ncd %q\nexec surge run --backend=vm %q -- \"$@\"\n", baseDir, absPath)
 }
 
-func ensureClangAvailable() error {
-	if _, err := exec.LookPath("clang"); err != nil {
-		return fmt.Errorf("clang not found; install with: sudo apt-get update && sudo apt-get install -y clang llvm lld")
+// emitExtension returns the suffix of an LLVM build output of kind emit.
+func emitExtension(emit EmitKind) string {
+	switch emit {
+	case EmitObj:
+		return ".o"
+	case EmitLL:
+		return ".ll"
+	default:
+		return ""
+	}
+}
+
+// ensureToolchain checks that the tools the emit kind needs are on PATH: an
+// object file needs clang or llc, an executable needs clang and ar.
+func ensureToolchain(emit EmitKind) error {
+	switch emit {
+	case EmitLL:
+		return nil
+	case EmitObj:
+		if _, err := exec.LookPath("clang"); err == nil {
+			return nil
+		}
+		if _, err := exec.LookPath("llc"); err == nil {
+			return nil
+		}
+		return fmt.Errorf("neither clang nor llc found; install with: sudo apt-get update && sudo apt-get install -y clang llvm lld")
+	default:
+		if _, err := exec.LookPath("clang"); err != nil {
+			return fmt.Errorf("clang not found; install with: sudo apt-get update && sudo apt-get install -y clang llvm lld")
+		}
+		if _, err := exec.LookPath("ar"); err != nil {
+			return fmt.Errorf("ar not found; install with: sudo apt-get update && sudo apt-get install -y clang llvm lld")
+		}
+		return nil
 	}
-	return nil
 }
 
-func buildLLVMOutput(tmpDir, outputPath string, printCommands bool) error {
+func buildLLVMOutput(tmpDir, outputPath string, emit EmitKind, triple string, printCommands bool) error {
+	llPath := filepath.Join(tmpDir, "out.ll")
+	if emit == EmitObj {
+		return compileLLVMIR(printCommands, llPath, outputPath, triple)
+	}
+
 	runtimeDir, runtimeSources, err := extractNativeRuntime(tmpDir)
 	if err != nil {
 		return err
 	}
-	runtimeObjs, err := compileRuntime(runtimeDir, runtimeSources, printCommands)
+	runtimeObjs, err := compileRuntime(runtimeDir, runtimeSources, triple, printCommands)
 	if err != nil {
 		return err
 	}
@@ -224,11 +302,10 @@ func buildLLVMOutput(tmpDir, outputPath string, printCommands bool) error {
 		return err
 	}
 	objPath := filepath.Join(tmpDir, "out.o")
-	llPath := filepath.Join(tmpDir, "out.ll")
-	if err := compileLLVMIR(printCommands, llPath, objPath); err != nil {
+	if err := compileLLVMIR(printCommands, llPath, objPath, triple); err != nil {
 		return err
 	}
-	args := []string{objPath, libPath, "-o", outputPath}
+	args := append(clangTargetArgs(triple), objPath, libPath, "-o", outputPath)
 	if runtime.GOOS != "windows" {
 		args = append(args, "-pthread")
 	}
@@ -238,17 +315,31 @@ func buildLLVMOutput(tmpDir, outputPath string, printCommands bool) error {
 	return nil
 }
 
-func compileLLVMIR(printCommands bool, llPath, objPath string) error {
-	if err := runCommand(printCommands, "clang", "-c", "-x", "ir", llPath, "-o", objPath); err == nil {
+// clangTargetArgs returns the clang flags that select triple; none for the
+// default target.
+func clangTargetArgs(triple string) []string {
+	if triple == "" {
 		return nil
 	}
+	return []string{"-target", triple}
+}
+
+func compileLLVMIR(printCommands bool, llPath, objPath, triple string) error {
+	if _, err := exec.LookPath("clang"); err == nil {
+		args := append(clangTargetArgs(triple), "-c", "-x", "ir", llPath, "-o", objPath)
+		if err := runCommand(printCommands, "clang", args...); err == nil {
+			return nil
+		}
+	}
 	// Fallback to llc
 	// clangErr := err // not used, but could be useful for debugging
 	llcPath, llcErr := exec.LookPath("llc")
 	if llcErr != nil {
 		return fmt.Errorf("clang failed and llc not found: %w", llcErr)
 	}
-	triple := hostTripleFromClang()
+	if triple == "" {
+		triple = hostTripleFromClang()
+	}
 	args := []string{"-filetype=obj", llPath, "-o", objPath}
 	if triple != "" {
 		args = append([]string{"-mtriple=" + triple}, args...)
@@ -334,12 +425,12 @@ func runtimeFileAllowed(entryPath string) bool {
 	return true
 }
 
-func compileRuntime(runtimeDir string, sources []string, printCommands bool) ([]string, error) {
+func compileRuntime(runtimeDir string, sources []string, triple string, printCommands bool) ([]string, error) {
 	objs := make([]string, 0, len(sources))
 	for _, src := range sources {
 		base := strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))
 		obj := filepath.Join(runtimeDir, base+".o")
-		args := []string{"-c", "-std=c11"}
+		args := append(clangTargetArgs(triple), "-c", "-std=c11")
 		if runtime.GOOS != "windows" {
 			args = append(args, "-pthread")
 		}
@@ -8,10 +8,12 @@ import (
 
 	"surge/internal/diag"
 	"surge/internal/driver"
+	"surge/internal/layout"
 	"surge/internal/mir"
 	"surge/internal/mono"
 	"surge/internal/observ"
 	"surge/internal/project"
+	"surge/internal/symbols"
 )
 
 // DirInfo describes a directory run target.
@@ -31,6 +33,11 @@ type CompileRequest struct {
 	Progress              ProgressSink
 	Files                 []string
 	Backend               Backend
+	VerifyMIR             bool                // run mir.Verify before handing MIR to a backend
+	Optimize              bool                // run MIR optimizations such as constant folding
+	Cfg                   symbols.CfgSettings // settings for @cfg items; nil describes the host
+	Source                []byte              // in-memory content of TargetPath; nil reads it from disk
+	Target                layout.Target       // layout target for MIR; the zero value means x86_64-linux-gnu
 }
 
 // CompileResult captures compilation artefacts and stage timings.
@@ -70,6 +77,8 @@ func Compile(ctx context.Context, req *CompileRequest) (CompileResult, error) {
 		RootKind:           req.RootKind,
 		EnableTimings:      true,
 		PhaseObserver:      phaseProgress.OnPhase,
+		Cfg:                req.Cfg,
+		Source:             req.Source,
 	}
 
 	diagRes, err := driver.DiagnoseWithOptions(ctx, req.TargetPath, &opts)
@@ -165,7 +174,10 @@ func Compile(ctx context.Context, req *CompileRequest) (CompileResult, error) {
 		return result, err
 	}
 
-	mirMod, err := mir.LowerModule(mm, diagRes.Sema)
+	mirMod, err := mir.LowerModuleWithOptions(mm, diagRes.Sema, mir.LowerOptions{
+		ParallelRuntime: true,
+		Target:          req.Target,
+	})
 	if err != nil {
 		err = fmt.Errorf("MIR lowering failed: %w", err)
 		emitStage(req.Progress, req.Files, StageLower, StatusError, err, 0)
@@ -177,6 +189,15 @@ func Compile(ctx context.Context, req *CompileRequest) (CompileResult, error) {
 		mir.RecognizeSwitchTag(f)
 		mir.SimplifyCFG(f)
 	}
+	if req.Optimize {
+		if err := foldConstants(mirMod, diagRes); err != nil {
+			emitStage(req.Progress, req.Files, StageLower, StatusError, err, 0)
+			return result, err
+		}
+		for _, f := range mirMod.Funcs {
+			mir.EliminateDeadBlocks(f)
+		}
+	}
 
 	if err := mir.LowerAsyncStateMachine(mirMod, diagRes.Sema, diagRes.Symbols.Table); err != nil {
 		err = fmt.Errorf("async lowering failed: %w", err)
@@ -192,6 +213,13 @@ func Compile(ctx context.Context, req *CompileRequest) (CompileResult, error) {
 		emitStage(req.Progress, req.Files, StageLower, StatusError, err, 0)
 		return result, err
 	}
+	if req.VerifyMIR {
+		if err := mir.Verify(mirMod, diagRes.Sema.TypeInterner); err != nil {
+			err = fmt.Errorf("MIR verification failed:\n%w", err)
+			emitStage(req.Progress, req.Files, StageLower, StatusError, err, 0)
+			return result, err
+		}
+	}
 
 	result.MIR = mirMod
 	result.Timings.Set(StageLower, time.Since(lowerStart))
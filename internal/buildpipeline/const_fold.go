@@ -0,0 +1,49 @@
+package buildpipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"surge/internal/diag"
+	"surge/internal/driver"
+	"surge/internal/mir"
+	"surge/internal/source"
+)
+
+// foldConstants runs mir.FoldConstants and turns constant overflows into
+// diagnostics. The returned error lists them with their source locations.
+func foldConstants(mirMod *mir.Module, diagRes *driver.DiagnoseResult) error {
+	if mirMod == nil || diagRes == nil || diagRes.Sema == nil {
+		return nil
+	}
+	errs := mir.FoldConstants(mirMod, diagRes.Sema.TypeInterner)
+	if len(errs) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(errs))
+	for _, ferr := range errs {
+		if diagRes.Bag != nil {
+			diagRes.Bag.Add(&diag.Diagnostic{
+				Severity: diag.SevError,
+				Code:     diag.SemaConstOverflow,
+				Message:  ferr.Msg,
+				Primary:  ferr.Span,
+			})
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", spanLocation(diagRes.FileSet, ferr.Span), ferr.Error()))
+	}
+	return fmt.Errorf("constant folding reported errors:\n%s", strings.Join(lines, "\n"))
+}
+
+// spanLocation renders span as path:line:col, or "<no-span>" when unknown.
+func spanLocation(files *source.FileSet, span source.Span) string {
+	if files == nil || span == (source.Span{}) {
+		return "<no-span>"
+	}
+	file := files.Get(span.File)
+	if file == nil {
+		return "<no-span>"
+	}
+	start, _ := files.Resolve(span)
+	return fmt.Sprintf("%s:%d:%d", file.Path, start.Line, start.Col)
+}
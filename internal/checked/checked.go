@@ -0,0 +1,123 @@
+// Package checked содержит целочисленную арифметику с проверкой переполнения
+// для типов фиксированной ширины.
+//
+// Её используют и VM, и свёртка констант в MIR, поэтому константное
+// выражение получает ровно тот же результат и ту же ошибку, что и
+// исполнение программы.
+package checked
+
+import (
+	"math"
+	"math/bits"
+
+	"surge/internal/types"
+)
+
+// AddInt64 returns (a+b, ok). ok is false on signed overflow.
+func AddInt64(a, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// SubInt64 returns (a-b, ok). ok is false on signed overflow.
+func SubInt64(a, b int64) (int64, bool) {
+	if (b > 0 && a < math.MinInt64+b) || (b < 0 && a > math.MaxInt64+b) {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// MulInt64 returns (a*b, ok). ok is false on signed overflow.
+func MulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	if (a == math.MinInt64 && b == -1) || (b == math.MinInt64 && a == -1) {
+		return 0, false
+	}
+	res := a * b
+	if res/b != a {
+		return 0, false
+	}
+	return res, true
+}
+
+// AddUint64 returns (a+b, ok). ok is false on unsigned overflow.
+func AddUint64(a, b uint64) (uint64, bool) {
+	sum, carry := bits.Add64(a, b, 0)
+	return sum, carry == 0
+}
+
+// SubUint64 returns (a-b, ok). ok is false when b > a.
+func SubUint64(a, b uint64) (uint64, bool) {
+	if a < b {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// MulUint64 returns (a*b, ok). ok is false on unsigned overflow.
+func MulUint64(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi == 0
+}
+
+// IntRange returns the bounds of a fixed-width signed integer.
+func IntRange(width types.Width) (minVal, maxVal int64, ok bool) {
+	switch width {
+	case types.Width8:
+		return math.MinInt8, math.MaxInt8, true
+	case types.Width16:
+		return math.MinInt16, math.MaxInt16, true
+	case types.Width32:
+		return math.MinInt32, math.MaxInt32, true
+	case types.Width64:
+		return math.MinInt64, math.MaxInt64, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// UintMax returns the upper bound of a fixed-width unsigned integer.
+func UintMax(width types.Width) (uint64, bool) {
+	switch width {
+	case types.Width8:
+		return math.MaxUint8, true
+	case types.Width16:
+		return math.MaxUint16, true
+	case types.Width32:
+		return math.MaxUint32, true
+	case types.Width64:
+		return math.MaxUint64, true
+	default:
+		return 0, false
+	}
+}
+
+// FitsSigned reports whether value is representable at width.
+// WidthAny and unknown widths accept every value.
+func FitsSigned(value int64, width types.Width) bool {
+	if width == types.WidthAny {
+		return true
+	}
+	minVal, maxVal, ok := IntRange(width)
+	if !ok {
+		return true
+	}
+	return value >= minVal && value <= maxVal
+}
+
+// FitsUnsigned reports whether value is representable at width.
+// WidthAny and unknown widths accept every value.
+func FitsUnsigned(value uint64, width types.Width) bool {
+	if width == types.WidthAny {
+		return true
+	}
+	maxVal, ok := UintMax(width)
+	if !ok {
+		return true
+	}
+	return value <= maxVal
+}
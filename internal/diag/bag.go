@@ -3,14 +3,19 @@ package diag
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"fortio.org/safecast"
+
+	"surge/internal/source"
 )
 
 // Bag holds a collection of diagnostics.
 type Bag struct {
 	items   []*Diagnostic
 	maximum uint16
+	// suppressions собирает `surge:ignore` директивы, найденные лексером
+	suppressions *Suppressions
 }
 
 // NewBag creates a Bag with a capacity limit.
@@ -86,6 +91,17 @@ func (b *Bag) Merge(other *Bag) {
 		b.maximum = newTotalUint16
 	}
 	b.items = append(b.items, other.items...)
+	if other.suppressions.Len() > 0 {
+		b.Suppressions().AddAll(other.suppressions)
+	}
+}
+
+// Suppressions returns the inline suppression index filled while lexing the bag's files.
+func (b *Bag) Suppressions() *Suppressions {
+	if b.suppressions == nil {
+		b.suppressions = NewSuppressions()
+	}
+	return b.suppressions
 }
 
 // Sort сортирует диагностики по: file, start, end, severity (desc), code (asc)
@@ -129,6 +145,59 @@ func (b *Bag) Dedup() {
 	b.items = newitems
 }
 
+// DedupByLocation collapses diagnostics that share Code and Primary span
+// (file, start, end) and whose messages differ only trivially: one message
+// contains the other, e.g. the resolver's "unresolved symbol" and a later
+// pass's "unresolved symbol 'foo'". Distinct findings at one location, such as
+// two different missing fields, are kept. The surviving instance is the most
+// informative one (see moreInformative) and takes the position of the first
+// occurrence, so the output order stays deterministic.
+func (b *Bag) DedupByLocation() {
+	type locationKey struct {
+		code Code
+		span source.Span
+	}
+	index := make(map[locationKey][]int, len(b.items))
+	newitems := make([]*Diagnostic, 0, len(b.items))
+	for _, d := range b.items {
+		key := locationKey{code: d.Code, span: d.Primary}
+		merged := false
+		for _, at := range index[key] {
+			kept := newitems[at]
+			if !strings.Contains(kept.Message, d.Message) && !strings.Contains(d.Message, kept.Message) {
+				continue
+			}
+			if moreInformative(d, kept) {
+				newitems[at] = d
+			}
+			merged = true
+			break
+		}
+		if merged {
+			continue
+		}
+		index[key] = append(index[key], len(newitems))
+		newitems = append(newitems, d)
+	}
+	b.items = newitems
+}
+
+// moreInformative сравнивает две диагностики с одинаковым ключом: сначала
+// severity, затем наличие fix, затем число заметок, затем длина сообщения.
+// При полном равенстве побеждает уже сохранённая (a не вытесняет b).
+func moreInformative(a, b *Diagnostic) bool {
+	if a.Severity != b.Severity {
+		return a.Severity > b.Severity
+	}
+	if (len(a.Fixes) > 0) != (len(b.Fixes) > 0) {
+		return len(a.Fixes) > 0
+	}
+	if len(a.Notes) != len(b.Notes) {
+		return len(a.Notes) > len(b.Notes)
+	}
+	return len(a.Message) > len(b.Message)
+}
+
 // Filter удаляет диагностики, которые не проходят проверку predicate
 func (b *Bag) Filter(predicate func(*Diagnostic) bool) {
 	newitems := make([]*Diagnostic, 0, len(b.items))
@@ -0,0 +1,89 @@
+package diag
+
+import (
+	"testing"
+
+	"surge/internal/source"
+)
+
+func TestBagDedupByLocationKeepsRicherInstance(t *testing.T) {
+	span := source.Span{File: 1, Start: 10, End: 14}
+	plain := &Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Message: "unresolved symbol", Primary: span}
+	other := &Diagnostic{Severity: SevError, Code: SynUnexpectedToken, Message: "unexpected token", Primary: source.Span{File: 1, Start: 2, End: 3}}
+	rich := &Diagnostic{
+		Severity: SevError,
+		Code:     SemaUnresolvedSymbol,
+		Message:  "unresolved symbol 'foo'",
+		Primary:  span,
+		Notes:    []Note{{Span: span, Msg: "did you mean 'for'?"}},
+		Fixes:    []*Fix{{Title: "rename to 'for'", Edits: []TextEdit{{Span: span, NewText: "for"}}}},
+	}
+
+	bag := NewBag(8)
+	bag.Add(plain)
+	bag.Add(other)
+	bag.Add(rich)
+	bag.DedupByLocation()
+
+	items := bag.Items()
+	if len(items) != 2 {
+		t.Fatalf("expected 2 diagnostics after dedup, got %d", len(items))
+	}
+	if items[0] != rich {
+		t.Fatalf("expected the richer duplicate in the first slot, got %+v", items[0])
+	}
+	if items[1] != other {
+		t.Fatalf("expected the unrelated diagnostic to survive, got %+v", items[1])
+	}
+}
+
+func TestBagDedupByLocationPrefersSeverity(t *testing.T) {
+	span := source.Span{File: 1, Start: 0, End: 5}
+	warning := &Diagnostic{
+		Severity: SevWarning,
+		Code:     SemaUnresolvedSymbol,
+		Message:  "maybe unresolved",
+		Primary:  span,
+		Notes:    []Note{{Span: span, Msg: "first note"}, {Span: span, Msg: "second note"}},
+	}
+	errDiag := &Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Message: "unresolved", Primary: span}
+	twin := &Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Message: "unresolved", Primary: span}
+
+	bag := NewBag(8)
+	bag.Add(warning)
+	bag.Add(errDiag)
+	bag.Add(twin)
+	bag.DedupByLocation()
+
+	items := bag.Items()
+	if len(items) != 1 {
+		t.Fatalf("expected 1 diagnostic after dedup, got %d", len(items))
+	}
+	// При равенстве остаётся первый из равных.
+	if items[0] != errDiag {
+		t.Fatalf("expected the first error instance to win, got %+v", items[0])
+	}
+}
+
+func TestBagDedupByLocationKeepsDistinctMessages(t *testing.T) {
+	span := source.Span{File: 1, Start: 0, End: 5}
+	bag := NewBag(8)
+	bag.Add(&Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Message: "Foo is missing required field name", Primary: span})
+	bag.Add(&Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Message: "Foo is missing required field value", Primary: span})
+	bag.DedupByLocation()
+	if bag.Len() != 2 {
+		t.Fatalf("expected distinct findings at one span to survive, got %d", bag.Len())
+	}
+}
+
+func TestBagDedupByLocationKeepsDistinctSpans(t *testing.T) {
+	bag := NewBag(8)
+	bag.Add(&Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Primary: source.Span{File: 1, Start: 0, End: 5}})
+	bag.Add(&Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Primary: source.Span{File: 1, Start: 0, End: 6}})
+	bag.Add(&Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Primary: source.Span{File: 2, Start: 0, End: 5}})
+	bag.Add(&Diagnostic{Severity: SevError, Code: SynUnexpectedToken, Primary: source.Span{File: 1, Start: 0, End: 5}})
+	bag.DedupByLocation()
+	if bag.Len() != 4 {
+		t.Fatalf("expected no diagnostics to collapse, got %d", bag.Len())
+	}
+}
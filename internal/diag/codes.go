@@ -27,6 +27,12 @@ const (
 	LexBadNumber Code = 1004
 	// LexTokenTooLong represents a token too long error.
 	LexTokenTooLong Code = 1005
+	// LexUnusedSuppression represents a `surge:ignore` comment that silenced nothing.
+	LexUnusedSuppression Code = 1006
+	// LexUnterminatedChar represents an unterminated character literal error.
+	LexUnterminatedChar Code = 1007
+	// LexBadCharLiteral represents an empty, multi-character, or badly escaped character literal.
+	LexBadCharLiteral Code = 1008
 
 	// Парсерные (зарезервируем)
 
@@ -63,6 +69,7 @@ const (
 	SynFatArrowOutsideParallel Code = 2028
 	SynPragmaPosition          Code = 2029
 	SynFnNotAllowed            Code = 2030
+	SynExpectComma             Code = 2031
 
 	// import errors & warnings
 
@@ -74,6 +81,8 @@ const (
 	SynExpectItemAfterDbl Code = 2104
 	SynExpectIdentAfterAs Code = 2105
 	SynEmptyImportGroup   Code = 2106
+	// SynStatementAtFileScope reports a statement or expression outside any function.
+	SynStatementAtFileScope Code = 2107
 
 	// type errors & warnings
 
@@ -286,6 +295,24 @@ const (
 	SemaBlockingBorrowCapture          Code = 3133 // blocking capture cannot borrow
 	SemaRetOutsideBlock                Code = 3134 // ret used outside block expression / async payload
 	SemaImplicitBlockValue             Code = 3135 // legacy implicit block value should use ret
+	SemaCfgInvalid                     Code = 3136 // Malformed @cfg predicate
+	SemaParallelArgs                   Code = 3137 // parallel map/reduce argument list has the wrong shape
+	SemaConstOverflow                  Code = 3138 // constant expression overflows its type
+	SemaParallelCaptureMutation        Code = 3139 // parallel body mutates a captured variable
+	SemaAsyncCaptureMutation           Code = 3140 // async block mutates a captured variable
+	SemaAmbiguousOverloadSet           Code = 3141 // two overloads accept the same call
+	SemaModuleNotFound                 Code = 3142 // relative import points at no module file
+	SemaIncompatibleOperands           Code = 3143 // operand types are not assignable to each other
+	SemaUnknownLoopLabel               Code = 3144 // break/continue names no enclosing loop label
+	SemaDuplicateLoopLabel             Code = 3145 // nested loop reuses an enclosing loop's label
+	SemaConstantCondition              Code = 3146 // if/loop condition folds to a constant
+	SemaInvalidOrPattern               Code = 3147 // or-pattern alternative binds or destructures
+	SemaAssignInCondition              Code = 3148 // `=` used as an if/while condition, likely meant `==`
+	SemaUnusedLocal                    Code = 3149 // local let binding is never read
+	SemaUnusedImport                   Code = 3150 // imported name is never referenced
+	SemaUnknownDirective               Code = 3151 // directive line calls an unknown namespace or function
+	SemaDirectiveArity                 Code = 3152 // directive call passes the wrong number of arguments
+	SemaBorrowEscapesFn                Code = 3153 // returned reference points into a local of the function
 
 	// Ошибки I/O
 
@@ -336,6 +363,9 @@ const (
 	AlnGoDefer         Code = 8004
 	AlnTSInterface     Code = 8005
 	AlnRustImplicitRet Code = 8006
+	AlnRustBox         Code = 8007
+	AlnGoShortVarDecl  Code = 8008
+	AlnGoFunc          Code = 8009
 	AlnPythonNoneType  Code = 8010
 	AlnPythonNoneAlias Code = 8050
 )
@@ -349,6 +379,9 @@ var ( // todo расширить описания и использовать к
 		LexUnterminatedBlockComment:        "Unterminated block comment",
 		LexBadNumber:                       "Bad number",
 		LexTokenTooLong:                    "Token too long",
+		LexUnusedSuppression:               "Unused suppression comment",
+		LexUnterminatedChar:                "Unterminated character literal",
+		LexBadCharLiteral:                  "Invalid character literal",
 		SynInfo:                            "Syntax information",
 		SynUnexpectedToken:                 "Unexpected token",
 		SynUnclosedDelimiter:               "Unclosed delimiter",
@@ -382,11 +415,13 @@ var ( // todo расширить описания и использовать к
 		SynFatArrowOutsideParallel:         "Fat arrow is only allowed in parallel expressions, compare arms, or select/race arms",
 		SynPragmaPosition:                  "Pragma must appear at the top of the file",
 		SynFnNotAllowed:                    "Function declaration is not allowed here",
+		SynExpectComma:                     "Expected comma",
 		SynExpectIdentifier:                "Expect identifier",
 		SynExpectModuleSeg:                 "Expect module segment",
 		SynExpectItemAfterDbl:              "Expect item after double colon",
 		SynExpectIdentAfterAs:              "Expect identifier after as",
 		SynEmptyImportGroup:                "Empty import group",
+		SynStatementAtFileScope:            "Statement at file scope",
 		SynInfoTypeExpr:                    "Type expression information",
 		SynExpectRightBracket:              "Expect right bracket",
 		SynExpectType:                      "Expect type",
@@ -531,6 +566,24 @@ var ( // todo расширить описания и использовать к
 		SemaTrivialRecursion:               "obvious infinite recursion cycle",
 		SemaLocalTaskNotSendable:           "local task handle is not sendable",
 		SemaImplicitBlockValue:             "legacy implicit block value should use 'ret'",
+		SemaCfgInvalid:                     "malformed @cfg predicate",
+		SemaParallelArgs:                   "invalid parallel argument list",
+		SemaConstOverflow:                  "constant expression overflows its type",
+		SemaParallelCaptureMutation:        "parallel body cannot mutate captured variables",
+		SemaAsyncCaptureMutation:           "async block cannot mutate captured variables",
+		SemaAmbiguousOverloadSet:           "overload set is ambiguous",
+		SemaModuleNotFound:                 "imported module not found",
+		SemaIncompatibleOperands:           "incompatible operand types",
+		SemaUnknownLoopLabel:               "unknown loop label",
+		SemaDuplicateLoopLabel:             "duplicate loop label",
+		SemaConstantCondition:              "constant condition",
+		SemaInvalidOrPattern:               "invalid or-pattern alternative",
+		SemaAssignInCondition:              "assignment used as condition",
+		SemaUnusedLocal:                    "unused local variable",
+		SemaUnusedImport:                   "unused import",
+		SemaUnknownDirective:               "unknown directive",
+		SemaDirectiveArity:                 "wrong number of directive arguments",
+		SemaBorrowEscapesFn:                "reference to local escapes function",
 		IOLoadFileError:                    "I/O load file error",
 		ProjInfo:                           "Project information",
 		ProjDuplicateModule:                "Duplicate module definition",
@@ -559,6 +612,9 @@ var ( // todo расширить описания и использовать к
 		AlnRustAttribute:                   "alien hint: rust attribute syntax",
 		AlnRustMacroCall:                   "alien hint: rust macro call",
 		AlnRustImplicitRet:                 "alien hint: rust implicit return",
+		AlnRustBox:                         "alien hint: rust Box",
+		AlnGoShortVarDecl:                  "alien hint: go short variable declaration",
+		AlnGoFunc:                          "alien hint: go func keyword",
 		AlnGoDefer:                         "alien hint: go defer",
 		AlnTSInterface:                     "alien hint: typescript interface",
 		AlnPythonNoneType:                  "alien hint: python None type",
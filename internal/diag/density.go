@@ -0,0 +1,76 @@
+package diag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"surge/internal/source"
+)
+
+// FileDensity holds diagnostic counts for a single file.
+type FileDensity struct {
+	Path     string
+	Errors   int
+	Warnings int
+}
+
+// DensityReport groups diagnostics by the file of their primary span and ranks
+// the files for triage: more errors first, then more warnings, then by path.
+// Notes and info diagnostics are not counted; files without errors or warnings
+// are omitted.
+func DensityReport(diags []*Diagnostic, fs *source.FileSet) []FileDensity {
+	if fs == nil || len(diags) == 0 {
+		return nil
+	}
+	byPath := make(map[string]*FileDensity)
+	for _, d := range diags {
+		if d == nil || (d.Severity != SevError && d.Severity != SevWarning) {
+			continue
+		}
+		loc, ok := resolveSpan(fs, d.Primary)
+		if !ok {
+			continue
+		}
+		entry := byPath[loc.Path]
+		if entry == nil {
+			entry = &FileDensity{Path: loc.Path}
+			byPath[loc.Path] = entry
+		}
+		if d.Severity == SevError {
+			entry.Errors++
+		} else {
+			entry.Warnings++
+		}
+	}
+
+	report := make([]FileDensity, 0, len(byPath))
+	for _, entry := range byPath {
+		report = append(report, *entry)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		ri, rj := report[i], report[j]
+		if ri.Errors != rj.Errors {
+			return ri.Errors > rj.Errors
+		}
+		if ri.Warnings != rj.Warnings {
+			return ri.Warnings > rj.Warnings
+		}
+		return ri.Path < rj.Path
+	})
+	return report
+}
+
+// FormatDensityReport renders a density report as a table, one file per line.
+// It returns an empty string for an empty report.
+func FormatDensityReport(report []FileDensity) string {
+	if len(report) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("errors warnings  file")
+	for _, entry := range report {
+		fmt.Fprintf(&b, "\n%6d %8d  %s", entry.Errors, entry.Warnings, entry.Path)
+	}
+	return b.String()
+}
@@ -0,0 +1,53 @@
+package diag
+
+import (
+	"testing"
+
+	"surge/internal/source"
+)
+
+func TestDensityReportRanksByErrorsThenWarnings(t *testing.T) {
+	fs := source.NewFileSet()
+	fs.SetBaseDir("/workspace")
+
+	quiet := fs.Add("/workspace/quiet.sg", []byte("x\n"), 0)
+	noisy := fs.Add("/workspace/noisy.sg", []byte("x\n"), 0)
+	warned := fs.Add("/workspace/warned.sg", []byte("x\n"), 0)
+	infoOnly := fs.Add("/workspace/info.sg", []byte("x\n"), 0)
+
+	at := func(file source.FileID, sev Severity) *Diagnostic {
+		return &Diagnostic{Severity: sev, Code: SemaError, Message: "m", Primary: source.Span{File: file}}
+	}
+	diags := []*Diagnostic{
+		at(quiet, SevError),
+		at(noisy, SevError),
+		at(warned, SevWarning),
+		at(noisy, SevError),
+		at(warned, SevError),
+		at(infoOnly, SevInfo),
+		at(warned, SevWarning),
+	}
+
+	want := []FileDensity{
+		{Path: "noisy.sg", Errors: 2},
+		{Path: "warned.sg", Errors: 1, Warnings: 2},
+		{Path: "quiet.sg", Errors: 1},
+	}
+	got := DensityReport(diags, fs)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d files, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("entry %d: want %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	expected := "errors warnings  file\n" +
+		"     2        0  noisy.sg\n" +
+		"     1        2  warned.sg\n" +
+		"     1        0  quiet.sg"
+	if out := FormatDensityReport(got); out != expected {
+		t.Fatalf("unexpected report:\nwant:\n%s\n\ngot:\n%s", expected, out)
+	}
+}
@@ -3,6 +3,8 @@ package diag
 import "surge/internal/source"
 
 // Note provides auxiliary context for a diagnostic message.
+// Span.File may differ from the diagnostic's primary file (e.g. a declaration
+// in another module); renderers resolve each note through the FileSet.
 type Note struct {
 	Span source.Span
 	Msg  string
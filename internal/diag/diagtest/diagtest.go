@@ -0,0 +1,84 @@
+// Package diagtest provides assertions over diag.Bag for package tests.
+package diagtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"surge/internal/diag"
+)
+
+// Summary renders the bag as "[CODE] message; ..." for failure messages.
+func Summary(bag *diag.Bag) string {
+	if bag == nil {
+		return "<nil bag>"
+	}
+	items := bag.Items()
+	if len(items) == 0 {
+		return "<none>"
+	}
+	lines := make([]string, len(items))
+	for i, d := range items {
+		lines[i] = fmt.Sprintf("[%s] %s", d.Code.ID(), d.Message)
+	}
+	return strings.Join(lines, "; ")
+}
+
+// HasCode reports whether the bag holds a diagnostic with the given code.
+func HasCode(bag *diag.Bag, code diag.Code) bool {
+	return find(bag, func(d *diag.Diagnostic) bool { return d.Code == code }) != nil
+}
+
+// AssertHasCode fails the test unless the bag holds a diagnostic with code.
+func AssertHasCode(t testing.TB, bag *diag.Bag, code diag.Code) {
+	t.Helper()
+	if !HasCode(bag, code) {
+		t.Fatalf("expected %s diagnostic, got %s", code.ID(), Summary(bag))
+	}
+}
+
+// AssertNoDiagnostics fails the test if the bag holds any diagnostic,
+// warnings and infos included. A nil bag counts as empty.
+func AssertNoDiagnostics(t testing.TB, bag *diag.Bag) {
+	t.Helper()
+	if bag != nil && bag.Len() != 0 {
+		t.Fatalf("expected no diagnostics, got %d: %s", bag.Len(), Summary(bag))
+	}
+}
+
+// AssertSpanAt fails the test unless the bag holds a diagnostic with code
+// whose primary span covers exactly [start, end).
+func AssertSpanAt(t testing.TB, bag *diag.Bag, code diag.Code, start, end uint32) {
+	t.Helper()
+	match := find(bag, func(d *diag.Diagnostic) bool {
+		return d.Code == code && d.Primary.Start == start && d.Primary.End == end
+	})
+	if match != nil {
+		return
+	}
+	var spans []string
+	if bag != nil {
+		for _, d := range bag.Items() {
+			if d.Code == code {
+				spans = append(spans, fmt.Sprintf("[%d,%d)", d.Primary.Start, d.Primary.End))
+			}
+		}
+	}
+	if len(spans) == 0 {
+		t.Fatalf("expected %s diagnostic at [%d,%d), got %s", code.ID(), start, end, Summary(bag))
+	}
+	t.Fatalf("expected %s diagnostic at [%d,%d), found it at %s", code.ID(), start, end, strings.Join(spans, ", "))
+}
+
+func find(bag *diag.Bag, pred func(*diag.Diagnostic) bool) *diag.Diagnostic {
+	if bag == nil {
+		return nil
+	}
+	for _, d := range bag.Items() {
+		if d != nil && pred(d) {
+			return d
+		}
+	}
+	return nil
+}
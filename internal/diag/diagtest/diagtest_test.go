@@ -0,0 +1,113 @@
+package diagtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+// recorder captures Fatalf instead of stopping the enclosing test.
+type recorder struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failed = true
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+func newBag(items ...*diag.Diagnostic) *diag.Bag {
+	bag := diag.NewBag(16)
+	for _, d := range items {
+		bag.Add(d)
+	}
+	return bag
+}
+
+func diagAt(code diag.Code, start, end uint32) *diag.Diagnostic {
+	return &diag.Diagnostic{
+		Severity: diag.SevError,
+		Code:     code,
+		Message:  "boom",
+		Primary:  source.Span{Start: start, End: end},
+	}
+}
+
+func TestAssertHasCode(t *testing.T) {
+	bag := newBag(diagAt(diag.SynUnexpectedToken, 1, 2))
+
+	r := &recorder{}
+	AssertHasCode(r, bag, diag.SynUnexpectedToken)
+	if r.failed {
+		t.Fatalf("unexpected failure: %s", r.msg)
+	}
+
+	r = &recorder{}
+	AssertHasCode(r, bag, diag.SynExpectSemicolon)
+	if !r.failed {
+		t.Fatal("expected failure for a missing code")
+	}
+	if !strings.Contains(r.msg, diag.SynUnexpectedToken.ID()) {
+		t.Fatalf("failure should list present diagnostics, got %q", r.msg)
+	}
+}
+
+func TestAssertNoDiagnostics(t *testing.T) {
+	r := &recorder{}
+	AssertNoDiagnostics(r, nil)
+	AssertNoDiagnostics(r, newBag())
+	if r.failed {
+		t.Fatalf("unexpected failure: %s", r.msg)
+	}
+
+	warn := diagAt(diag.SynUnexpectedToken, 0, 1)
+	warn.Severity = diag.SevWarning
+	AssertNoDiagnostics(r, newBag(warn))
+	if !r.failed {
+		t.Fatal("expected failure: warnings count as diagnostics")
+	}
+}
+
+func TestAssertSpanAt(t *testing.T) {
+	bag := newBag(diagAt(diag.SynUnexpectedToken, 4, 9), diagAt(diag.SynExpectSemicolon, 10, 11))
+
+	r := &recorder{}
+	AssertSpanAt(r, bag, diag.SynUnexpectedToken, 4, 9)
+	if r.failed {
+		t.Fatalf("unexpected failure: %s", r.msg)
+	}
+
+	r = &recorder{}
+	AssertSpanAt(r, bag, diag.SynUnexpectedToken, 4, 8)
+	if !r.failed || !strings.Contains(r.msg, "[4,9)") {
+		t.Fatalf("expected failure naming the actual span, got failed=%v %q", r.failed, r.msg)
+	}
+
+	// Span of another code must not satisfy the assertion.
+	r = &recorder{}
+	AssertSpanAt(r, bag, diag.SynUnexpectedToken, 10, 11)
+	if !r.failed {
+		t.Fatal("expected failure: span belongs to a different code")
+	}
+}
+
+func TestSummary(t *testing.T) {
+	if got := Summary(nil); got != "<nil bag>" {
+		t.Fatalf("nil bag summary = %q", got)
+	}
+	if got := Summary(newBag()); got != "<none>" {
+		t.Fatalf("empty bag summary = %q", got)
+	}
+	got := Summary(newBag(diagAt(diag.SynUnexpectedToken, 0, 1)))
+	want := "[" + diag.SynUnexpectedToken.ID() + "] boom"
+	if got != want {
+		t.Fatalf("summary = %q, want %q", got, want)
+	}
+}
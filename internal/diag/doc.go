@@ -60,6 +60,14 @@
 // directly. For convenience, diag.BagReporter aggregates diagnostics into a Bag,
 // which supports sorting, deduplication, filtering, and transformation.
 //
+// # Suppressions
+//
+// Source files may silence diagnostics inline with `// surge:ignore CODE`
+// (applies to its own line when trailing code, otherwise to the next line) or
+// `// surge:ignore-file CODE` (applies to the whole file). The driver collects
+// these while lexing (lexer.Options.Suppressions → Bag.Suppressions) and calls Bag.Suppress;
+// directives that silence nothing surface as LEX1006 warnings.
+//
 // # Consumers
 //
 //   - internal/diagfmt: renders Diagnostics into pretty/json/sarif formats.
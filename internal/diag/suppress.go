@@ -0,0 +1,187 @@
+package diag
+
+import (
+	"sort"
+	"strings"
+
+	"surge/internal/source"
+)
+
+const (
+	suppressLinePrefix = "surge:ignore"
+	suppressFilePrefix = "surge:ignore-file"
+)
+
+// Suppression is a single inline `// surge:ignore` directive.
+// Empty Codes means the directive silences every diagnostic in its scope.
+type Suppression struct {
+	Span      source.Span // span of the comment itself
+	Line      uint32      // 1-based target line; 0 for file-scoped suppressions
+	FileScope bool
+	Codes     []string
+	used      bool
+}
+
+// Used reports whether the suppression silenced at least one diagnostic.
+func (s *Suppression) Used() bool {
+	return s != nil && s.used
+}
+
+func (s *Suppression) matches(code Code) bool {
+	if len(s.Codes) == 0 {
+		return true
+	}
+	id := code.ID()
+	for _, c := range s.Codes {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSuppressionComment recognises `// surge:ignore CODE[, CODE...]` and
+// `// surge:ignore-file CODE[, CODE...]` comments.
+func ParseSuppressionComment(text string) (fileScope bool, codes []string, ok bool) {
+	body, found := strings.CutPrefix(text, "//")
+	if !found {
+		return false, nil, false
+	}
+	body = strings.TrimSpace(body)
+	switch {
+	case strings.HasPrefix(body, suppressFilePrefix):
+		fileScope = true
+		body = body[len(suppressFilePrefix):]
+	case strings.HasPrefix(body, suppressLinePrefix):
+		body = body[len(suppressLinePrefix):]
+	default:
+		return false, nil, false
+	}
+	// reject things like "surge:ignored" that merely share the prefix
+	if body != "" && body[0] != ' ' && body[0] != '\t' {
+		return false, nil, false
+	}
+	for _, field := range strings.FieldsFunc(body, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	}) {
+		codes = append(codes, strings.ToUpper(field))
+	}
+	return fileScope, codes, true
+}
+
+// Suppressions indexes inline suppression directives by file and line.
+type Suppressions struct {
+	lines map[source.FileID]map[uint32][]*Suppression
+	files map[source.FileID][]*Suppression
+	all   []*Suppression
+	spans map[source.Span]struct{}
+}
+
+// NewSuppressions creates an empty suppression index.
+func NewSuppressions() *Suppressions {
+	return &Suppressions{
+		lines: make(map[source.FileID]map[uint32][]*Suppression),
+		files: make(map[source.FileID][]*Suppression),
+		spans: make(map[source.Span]struct{}),
+	}
+}
+
+// Add registers a suppression directive. A comment lexed twice is registered once.
+func (s *Suppressions) Add(sup *Suppression) {
+	if s == nil || sup == nil {
+		return
+	}
+	if sup.Span.End > sup.Span.Start {
+		if _, dup := s.spans[sup.Span]; dup {
+			return
+		}
+		s.spans[sup.Span] = struct{}{}
+	}
+	s.all = append(s.all, sup)
+	file := sup.Span.File
+	if sup.FileScope {
+		s.files[file] = append(s.files[file], sup)
+		return
+	}
+	byLine := s.lines[file]
+	if byLine == nil {
+		byLine = make(map[uint32][]*Suppression)
+		s.lines[file] = byLine
+	}
+	byLine[sup.Line] = append(byLine[sup.Line], sup)
+}
+
+// AddAll registers every directive of other.
+func (s *Suppressions) AddAll(other *Suppressions) {
+	if other == nil {
+		return
+	}
+	for _, sup := range other.all {
+		s.Add(sup)
+	}
+}
+
+// Len returns the number of registered suppressions.
+func (s *Suppressions) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.all)
+}
+
+// Match reports whether a diagnostic is silenced and marks matching suppressions as used.
+func (s *Suppressions) Match(fs *source.FileSet, d *Diagnostic) bool {
+	if s == nil || d == nil || len(s.all) == 0 {
+		return false
+	}
+	file := d.Primary.File
+	matched := false
+	for _, sup := range s.files[file] {
+		if sup.matches(d.Code) {
+			sup.used = true
+			matched = true
+		}
+	}
+	byLine := s.lines[file]
+	if len(byLine) == 0 || fs == nil || !fs.HasFile(file) {
+		return matched
+	}
+	start, _ := fs.Resolve(d.Primary)
+	for _, sup := range byLine[start.Line] {
+		if sup.matches(d.Code) {
+			sup.used = true
+			matched = true
+		}
+	}
+	return matched
+}
+
+// Unused returns suppressions that did not silence anything, ordered by position.
+func (s *Suppressions) Unused() []*Suppression {
+	if s == nil {
+		return nil
+	}
+	var out []*Suppression
+	for _, sup := range s.all {
+		if !sup.used {
+			out = append(out, sup)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Span.File != out[j].Span.File {
+			return out[i].Span.File < out[j].Span.File
+		}
+		return out[i].Span.Start < out[j].Span.Start
+	})
+	return out
+}
+
+// Suppress drops diagnostics silenced by the given suppressions.
+func (b *Bag) Suppress(fs *source.FileSet, s *Suppressions) {
+	if s.Len() == 0 {
+		return
+	}
+	b.Filter(func(d *Diagnostic) bool {
+		return !s.Match(fs, d)
+	})
+}
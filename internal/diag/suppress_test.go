@@ -0,0 +1,72 @@
+package diag
+
+import (
+	"testing"
+
+	"surge/internal/source"
+)
+
+func TestParseSuppressionComment(t *testing.T) {
+	cases := []struct {
+		text      string
+		ok        bool
+		fileScope bool
+		codes     []string
+	}{
+		{text: "// surge:ignore SEM3005", ok: true, codes: []string{"SEM3005"}},
+		{text: "//surge:ignore sem3005, SYN2001", ok: true, codes: []string{"SEM3005", "SYN2001"}},
+		{text: "// surge:ignore-file SEM3004", ok: true, fileScope: true, codes: []string{"SEM3004"}},
+		{text: "// surge:ignore", ok: true},
+		{text: "// surge:ignored SEM3005"},
+		{text: "// plain comment"},
+	}
+	for _, tc := range cases {
+		fileScope, codes, ok := ParseSuppressionComment(tc.text)
+		if ok != tc.ok || fileScope != tc.fileScope || len(codes) != len(tc.codes) {
+			t.Fatalf("%q: got (%v, %v, %v)", tc.text, fileScope, codes, ok)
+		}
+		for i := range codes {
+			if codes[i] != tc.codes[i] {
+				t.Fatalf("%q: code %d = %q, want %q", tc.text, i, codes[i], tc.codes[i])
+			}
+		}
+	}
+}
+
+func TestBagSuppress(t *testing.T) {
+	fs := source.NewFileSet()
+	file := fs.Add("a.sg", []byte("one\ntwo\nthree\n"), 0)
+	other := fs.Add("b.sg", []byte("x\n"), 0)
+
+	bag := NewBag(10)
+	bag.Add(&Diagnostic{Severity: SevError, Code: SemaUnresolvedSymbol, Primary: source.Span{File: file, Start: 4, End: 7}})
+	bag.Add(&Diagnostic{Severity: SevError, Code: SemaTypeMismatch, Primary: source.Span{File: file, Start: 4, End: 7}})
+	bag.Add(&Diagnostic{Severity: SevWarning, Code: SemaShadowSymbol, Primary: source.Span{File: file, Start: 8, End: 13}})
+	bag.Add(&Diagnostic{Severity: SevWarning, Code: SemaShadowSymbol, Primary: source.Span{File: other, Start: 0, End: 1}})
+
+	sups := NewSuppressions()
+	line := &Suppression{Span: source.Span{File: file}, Line: 2, Codes: []string{"SEM3005"}}
+	whole := &Suppression{Span: source.Span{File: file}, FileScope: true, Codes: []string{"SEM3004"}}
+	unused := &Suppression{Span: source.Span{File: file}, Line: 1, Codes: []string{"SEM3005"}}
+	sups.Add(line)
+	sups.Add(whole)
+	sups.Add(unused)
+
+	bag.Suppress(fs, sups)
+
+	if bag.Len() != 2 {
+		t.Fatalf("expected 2 diagnostics after suppression, got %d: %+v", bag.Len(), bag.Items())
+	}
+	if bag.Items()[0].Code != SemaTypeMismatch {
+		t.Fatalf("unmatched code on a suppressed line must still report, got %v", bag.Items()[0].Code)
+	}
+	if bag.Items()[1].Primary.File != other {
+		t.Fatalf("file-scoped suppression leaked into another file")
+	}
+	if !line.Used() || !whole.Used() || unused.Used() {
+		t.Fatalf("unexpected usage flags: line=%v file=%v unused=%v", line.Used(), whole.Used(), unused.Used())
+	}
+	if got := sups.Unused(); len(got) != 1 || got[0] != unused {
+		t.Fatalf("expected exactly the line-1 suppression to be unused, got %+v", got)
+	}
+}
@@ -1,7 +1,6 @@
 package diagfmt
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -10,16 +9,6 @@ import (
 	"surge/internal/source"
 )
 
-// ASTNodeOutput represents a node in the JSON AST output.
-type ASTNodeOutput struct {
-	Type     string          `json:"type"`
-	Kind     string          `json:"kind,omitempty"`
-	Span     source.Span     `json:"span"`
-	Text     string          `json:"text,omitempty"`
-	Children []ASTNodeOutput `json:"children,omitempty"`
-	Fields   map[string]any  `json:"fields,omitempty"`
-}
-
 // FormatASTPretty writes a pretty-printed version of the AST to w.
 func FormatASTPretty(w io.Writer, builder *ast.Builder, fileID ast.FileID, fs *source.FileSet) error {
 	file := builder.Files.Get(fileID)
@@ -79,45 +68,3 @@ func FormatASTTree(w io.Writer, builder *ast.Builder, fileID ast.FileID, fs *sou
 	}
 	return nil
 }
-
-// BuildASTJSON формирует JSON-представление AST для заданного файла.
-func BuildASTJSON(builder *ast.Builder, fileID ast.FileID) (ASTNodeOutput, error) {
-	file := builder.Files.Get(fileID)
-	if file == nil {
-		return ASTNodeOutput{}, fmt.Errorf("file not found")
-	}
-
-	children := make([]ASTNodeOutput, 0, len(file.Items))
-	for _, itemID := range file.Items {
-		itemNode, err := formatItemJSON(builder, itemID)
-		if err != nil {
-			return ASTNodeOutput{}, err
-		}
-		children = append(children, itemNode)
-	}
-
-	output := ASTNodeOutput{
-		Type:     "File",
-		Span:     file.Span,
-		Children: children,
-	}
-
-	return output, nil
-}
-
-// FormatASTJSON writes the JSON representation of the AST for the specified file to w.
-//
-// FormatASTJSON constructs a JSON-serializable AST for fileID and encodes it to w
-// using two-space indentation.
-//
-// It returns any error produced while building the AST or while encoding to the writer.
-func FormatASTJSON(w io.Writer, builder *ast.Builder, fileID ast.FileID) error {
-	output, err := BuildASTJSON(builder, fileID)
-	if err != nil {
-		return err
-	}
-
-	encoder := json.NewEncoder(w)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
-}
@@ -0,0 +1,793 @@
+package diagfmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"surge/internal/ast"
+	"surge/internal/source"
+)
+
+// ASTNodeOutput is one node of the structured AST dump produced by
+// `surge parse --format=json|sexpr`. Node names the arena the node lives in
+// ("item", "stmt", "expr", ...) and ID is its index there, so ids are stable
+// for a given source text. Nodes without an arena of their own (call
+// arguments, compare arms) have no ID. Role tells how the node hangs off its
+// parent ("body", "left", "arg", ...). Type expressions are not walked: they
+// appear as rendered strings in Fields.
+type ASTNodeOutput struct {
+	Node     string           `json:"node"`
+	ID       uint32           `json:"id,omitempty"`
+	Kind     string           `json:"kind,omitempty"`
+	Role     string           `json:"role,omitempty"`
+	Span     ASTSpanOutput    `json:"span"`
+	Fields   map[string]any   `json:"fields,omitempty"`
+	Children []*ASTNodeOutput `json:"children,omitempty"`
+}
+
+// ASTSpanOutput is the byte range of a node within its file.
+type ASTSpanOutput struct {
+	Start uint32 `json:"start"`
+	End   uint32 `json:"end"`
+}
+
+// BuildASTJSON walks the arenas of builder starting from fileID and returns
+// the file node of the structured dump.
+func BuildASTJSON(builder *ast.Builder, fileID ast.FileID) (*ASTNodeOutput, error) {
+	if builder == nil {
+		return nil, fmt.Errorf("builder is nil")
+	}
+	file := builder.Files.Get(fileID)
+	if file == nil {
+		return nil, fmt.Errorf("file not found")
+	}
+	d := astDumper{b: builder}
+	root := &ASTNodeOutput{Node: "file", Span: dumpSpan(file.Span)}
+	for _, itemID := range file.Items {
+		root.Children = append(root.Children, d.item(itemID))
+	}
+	return root, nil
+}
+
+// FormatASTJSON writes the structured AST dump of fileID to w as indented JSON.
+func FormatASTJSON(w io.Writer, builder *ast.Builder, fileID ast.FileID) error {
+	root, err := BuildASTJSON(builder, fileID)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(root)
+}
+
+// FormatASTSExpr writes the structured AST dump of fileID to w as an
+// S-expression, one node per line:
+//
+//	(item#1 Fn @0..42 name="add"
+//	  :body (stmt#3 Block @25..42
+//	    ...))
+func FormatASTSExpr(w io.Writer, builder *ast.Builder, fileID ast.FileID) error {
+	root, err := BuildASTJSON(builder, fileID)
+	if err != nil {
+		return err
+	}
+	var sb strings.Builder
+	writeSExpr(&sb, root, 0)
+	sb.WriteByte('\n')
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func writeSExpr(sb *strings.Builder, n *ASTNodeOutput, depth int) {
+	sb.WriteByte('(')
+	sb.WriteString(n.Node)
+	if n.ID != 0 {
+		sb.WriteByte('#')
+		sb.WriteString(strconv.FormatUint(uint64(n.ID), 10))
+	}
+	if n.Kind != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(n.Kind)
+	}
+	fmt.Fprintf(sb, " @%d..%d", n.Span.Start, n.Span.End)
+	keys := make([]string, 0, len(n.Fields))
+	for key := range n.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		sb.WriteByte(' ')
+		sb.WriteString(key)
+		sb.WriteByte('=')
+		writeSExprValue(sb, n.Fields[key])
+	}
+	for _, child := range n.Children {
+		sb.WriteByte('\n')
+		sb.WriteString(strings.Repeat("  ", depth+1))
+		if child.Role != "" {
+			sb.WriteByte(':')
+			sb.WriteString(child.Role)
+			sb.WriteByte(' ')
+		}
+		writeSExpr(sb, child, depth+1)
+	}
+	sb.WriteByte(')')
+}
+
+func writeSExprValue(sb *strings.Builder, v any) {
+	switch val := v.(type) {
+	case string:
+		sb.WriteString(strconv.Quote(val))
+	case []string:
+		sb.WriteByte('(')
+		for i, s := range val {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(strconv.Quote(s))
+		}
+		sb.WriteByte(')')
+	default:
+		fmt.Fprint(sb, val)
+	}
+}
+
+func dumpSpan(sp source.Span) ASTSpanOutput {
+	return ASTSpanOutput{Start: sp.Start, End: sp.End}
+}
+
+// astDumper converts arena nodes into ASTNodeOutput trees.
+type astDumper struct {
+	b *ast.Builder
+}
+
+func (d *astDumper) str(id source.StringID) string {
+	return lookupStringOr(d.b, id, "_")
+}
+
+func (d *astDumper) attrs(fields map[string]any, start ast.AttrID, count uint32) {
+	if count == 0 {
+		return
+	}
+	attrs := d.b.Items.CollectAttrs(start, count)
+	if len(attrs) == 0 {
+		return
+	}
+	out := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		out = append(out, formatAttrInline(d.b, attr))
+	}
+	fields["attrs"] = out
+}
+
+func (d *astDumper) generics(fields map[string]any, names []source.StringID) {
+	if len(names) == 0 {
+		return
+	}
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, d.str(name))
+	}
+	fields["generics"] = out
+}
+
+func (d *astDumper) typeField(fields map[string]any, key string, id ast.TypeID) {
+	if id.IsValid() {
+		fields[key] = formatTypeExprInline(d.b, id)
+	}
+}
+
+func withRole(n *ASTNodeOutput, role string) *ASTNodeOutput {
+	if n != nil {
+		n.Role = role
+	}
+	return n
+}
+
+func appendChild(n, child *ASTNodeOutput) {
+	if child != nil {
+		n.Children = append(n.Children, child)
+	}
+}
+
+func (d *astDumper) item(id ast.ItemID) *ASTNodeOutput {
+	item := d.b.Items.Get(id)
+	if item == nil {
+		return &ASTNodeOutput{Node: "item", ID: uint32(id), Kind: "<invalid>"}
+	}
+	n := &ASTNodeOutput{Node: "item", ID: uint32(id), Kind: formatItemKind(item.Kind), Span: dumpSpan(item.Span)}
+	fields := make(map[string]any)
+	switch item.Kind {
+	case ast.ItemFn:
+		if fn, ok := d.b.Items.Fn(id); ok {
+			d.fn(n, fields, fn)
+		}
+	case ast.ItemLet:
+		if let, ok := d.b.Items.Let(id); ok {
+			fields["name"] = d.str(let.Name)
+			fields["visibility"] = let.Visibility.String()
+			if let.IsMut {
+				fields["mut"] = true
+			}
+			d.typeField(fields, "type", let.Type)
+			d.attrs(fields, let.AttrStart, let.AttrCount)
+			appendChild(n, withRole(d.expr(let.Value), "value"))
+		}
+	case ast.ItemConst:
+		if c, ok := d.b.Items.Const(id); ok {
+			fields["name"] = d.str(c.Name)
+			fields["visibility"] = c.Visibility.String()
+			d.typeField(fields, "type", c.Type)
+			d.attrs(fields, c.AttrStart, c.AttrCount)
+			appendChild(n, withRole(d.expr(c.Value), "value"))
+		}
+	case ast.ItemImport:
+		if imp, ok := d.b.Items.Import(id); ok {
+			module := make([]string, 0, len(imp.Module))
+			for _, seg := range imp.Module {
+				module = append(module, d.str(seg))
+			}
+			fields["module"] = module
+			if imp.ModuleAlias != source.NoStringID {
+				fields["alias"] = d.str(imp.ModuleAlias)
+			}
+			if imp.HasOne {
+				fields["one"] = formatImportOne(imp.One, d.b)
+				if imp.One.Alias != source.NoStringID {
+					fields["oneAlias"] = d.str(imp.One.Alias)
+				}
+			}
+			if len(imp.Group) > 0 {
+				group := make([]string, 0, len(imp.Group))
+				for _, pair := range imp.Group {
+					entry := d.str(pair.Name)
+					if pair.Alias != source.NoStringID {
+						entry += " as " + d.str(pair.Alias)
+					}
+					group = append(group, entry)
+				}
+				fields["group"] = group
+			}
+			if imp.ImportAll {
+				fields["all"] = true
+			}
+		}
+	case ast.ItemType:
+		if typ, ok := d.b.Items.Type(id); ok {
+			d.typeDecl(n, fields, typ)
+		}
+	case ast.ItemTag:
+		if tag, ok := d.b.Items.Tag(id); ok {
+			fields["name"] = d.str(tag.Name)
+			fields["visibility"] = tag.Visibility.String()
+			d.generics(fields, tag.Generics)
+			if len(tag.Payload) > 0 {
+				payload := make([]string, 0, len(tag.Payload))
+				for _, p := range tag.Payload {
+					payload = append(payload, formatTypeExprInline(d.b, p))
+				}
+				fields["payload"] = payload
+			}
+			d.attrs(fields, tag.AttrStart, tag.AttrCount)
+		}
+	case ast.ItemContract:
+		if contract, ok := d.b.Items.Contract(id); ok {
+			d.contract(n, fields, contract)
+		}
+	case ast.ItemExtern:
+		if ext, ok := d.b.Items.Extern(id); ok {
+			d.extern(n, fields, ext)
+		}
+	}
+	if len(fields) > 0 {
+		n.Fields = fields
+	}
+	return n
+}
+
+func (d *astDumper) fn(n *ASTNodeOutput, fields map[string]any, fn *ast.FnItem) {
+	fields["name"] = d.str(fn.Name)
+	d.typeField(fields, "returnType", fn.ReturnType)
+	d.generics(fields, fn.Generics)
+	d.attrs(fields, fn.AttrStart, fn.AttrCount)
+	if fn.Flags&ast.FnModifierPublic != 0 {
+		fields["public"] = true
+	}
+	if fn.Flags&ast.FnModifierAsync != 0 {
+		fields["async"] = true
+	}
+	for _, paramID := range d.b.Items.GetFnParamIDs(fn) {
+		param := d.b.Items.FnParam(paramID)
+		if param == nil {
+			continue
+		}
+		p := &ASTNodeOutput{Node: "param", ID: uint32(paramID), Span: dumpSpan(param.Span), Role: "param"}
+		pf := map[string]any{"name": d.str(param.Name)}
+		d.typeField(pf, "type", param.Type)
+		if param.Variadic {
+			pf["variadic"] = true
+		}
+		d.attrs(pf, param.AttrStart, param.AttrCount)
+		p.Fields = pf
+		appendChild(p, withRole(d.expr(param.Default), "default"))
+		n.Children = append(n.Children, p)
+	}
+	appendChild(n, withRole(d.stmt(fn.Body), "body"))
+}
+
+func (d *astDumper) typeDecl(n *ASTNodeOutput, fields map[string]any, typ *ast.TypeItem) {
+	fields["name"] = d.str(typ.Name)
+	fields["decl"] = formatTypeDeclKind(typ.Kind)
+	fields["visibility"] = typ.Visibility.String()
+	d.generics(fields, typ.Generics)
+	d.attrs(fields, typ.AttrStart, typ.AttrCount)
+	switch typ.Kind {
+	case ast.TypeDeclAlias:
+		if alias := d.b.Items.TypeAlias(typ); alias != nil {
+			d.typeField(fields, "target", alias.Target)
+		}
+	case ast.TypeDeclStruct:
+		decl := d.b.Items.TypeStruct(typ)
+		if decl == nil {
+			return
+		}
+		d.typeField(fields, "base", decl.Base)
+		for idx := range decl.FieldsCount {
+			fieldID := ast.TypeFieldID(uint32(decl.FieldsStart) + idx)
+			field := d.b.Items.StructField(fieldID)
+			if field == nil {
+				continue
+			}
+			f := &ASTNodeOutput{Node: "field", ID: uint32(fieldID), Span: dumpSpan(field.Span), Role: "field"}
+			ff := map[string]any{"name": d.str(field.Name)}
+			d.typeField(ff, "type", field.Type)
+			d.attrs(ff, field.AttrStart, field.AttrCount)
+			f.Fields = ff
+			appendChild(f, withRole(d.expr(field.Default), "default"))
+			n.Children = append(n.Children, f)
+		}
+	case ast.TypeDeclUnion:
+		decl := d.b.Items.TypeUnion(typ)
+		if decl == nil {
+			return
+		}
+		members := make([]string, 0, decl.MembersCount)
+		for idx := range decl.MembersCount {
+			member := d.b.Items.UnionMember(ast.TypeUnionMemberID(uint32(decl.MembersStart) + idx))
+			if member == nil {
+				continue
+			}
+			// formatUnionMemberInline пишет "Member[i]: ..." — отрезаем префикс.
+			text := formatUnionMemberInline(d.b, member, 0)
+			members = append(members, strings.TrimPrefix(text, "Member[0]: "))
+		}
+		fields["members"] = members
+	case ast.TypeDeclEnum:
+		decl := d.b.Items.TypeEnum(typ)
+		if decl == nil {
+			return
+		}
+		d.typeField(fields, "base", decl.BaseType)
+		for idx := range decl.VariantsCount {
+			variantID := ast.EnumVariantID(uint32(decl.VariantsStart) + idx)
+			variant := d.b.Items.EnumVariant(variantID)
+			if variant == nil {
+				continue
+			}
+			v := &ASTNodeOutput{Node: "variant", ID: uint32(variantID), Span: dumpSpan(variant.Span), Role: "variant"}
+			v.Fields = map[string]any{"name": d.str(variant.Name)}
+			appendChild(v, withRole(d.expr(variant.Value), "value"))
+			n.Children = append(n.Children, v)
+		}
+	}
+}
+
+func (d *astDumper) contract(n *ASTNodeOutput, fields map[string]any, contract *ast.ContractDecl) {
+	fields["name"] = d.str(contract.Name)
+	fields["visibility"] = contract.Visibility.String()
+	d.generics(fields, contract.Generics)
+	d.attrs(fields, contract.AttrStart, contract.AttrCount)
+	for _, cid := range d.b.Items.GetContractItemIDs(contract) {
+		member := d.b.Items.ContractItem(cid)
+		if member == nil {
+			continue
+		}
+		m := &ASTNodeOutput{Node: "member", ID: uint32(cid), Kind: formatContractItemKind(member.Kind), Span: dumpSpan(member.Span), Role: "member"}
+		mf := make(map[string]any)
+		switch member.Kind {
+		case ast.ContractItemField:
+			if field := d.b.Items.ContractField(ast.ContractFieldID(member.Payload)); field != nil {
+				mf["name"] = d.str(field.Name)
+				d.typeField(mf, "type", field.Type)
+				d.attrs(mf, field.AttrStart, field.AttrCount)
+			}
+		case ast.ContractItemFn:
+			if fn := d.b.Items.ContractFn(ast.ContractFnID(member.Payload)); fn != nil {
+				mf["name"] = d.str(fn.Name)
+				mf["params"] = formatContractFnParamsInline(d.b, fn)
+				d.typeField(mf, "returnType", fn.ReturnType)
+				d.generics(mf, fn.Generics)
+				d.attrs(mf, fn.AttrStart, fn.AttrCount)
+				appendChild(m, withRole(d.stmt(fn.Body), "body"))
+			}
+		}
+		if len(mf) > 0 {
+			m.Fields = mf
+		}
+		n.Children = append(n.Children, m)
+	}
+}
+
+func (d *astDumper) extern(n *ASTNodeOutput, fields map[string]any, ext *ast.ExternBlock) {
+	d.typeField(fields, "target", ext.Target)
+	d.attrs(fields, ext.AttrStart, ext.AttrCount)
+	for idx := range ext.MembersCount {
+		memberID := ast.ExternMemberID(uint32(ext.MembersStart) + idx)
+		member := d.b.Items.ExternMember(memberID)
+		if member == nil {
+			continue
+		}
+		m := &ASTNodeOutput{Node: "member", ID: uint32(memberID), Span: dumpSpan(member.Span), Role: "member"}
+		mf := make(map[string]any)
+		switch member.Kind {
+		case ast.ExternMemberFn:
+			m.Kind = "Fn"
+			if fn := d.b.Items.FnByPayload(member.Fn); fn != nil {
+				d.fn(m, mf, fn)
+			}
+		case ast.ExternMemberField:
+			m.Kind = "Field"
+			if field := d.b.Items.ExternField(member.Field); field != nil {
+				mf["name"] = d.str(field.Name)
+				d.typeField(mf, "type", field.Type)
+				d.attrs(mf, field.AttrStart, field.AttrCount)
+			}
+		}
+		if len(mf) > 0 {
+			m.Fields = mf
+		}
+		n.Children = append(n.Children, m)
+	}
+}
+
+func (d *astDumper) stmt(id ast.StmtID) *ASTNodeOutput {
+	if !id.IsValid() {
+		return nil
+	}
+	stmt := d.b.Stmts.Get(id)
+	if stmt == nil {
+		return &ASTNodeOutput{Node: "stmt", ID: uint32(id), Kind: "<invalid>"}
+	}
+	n := &ASTNodeOutput{Node: "stmt", ID: uint32(id), Kind: formatStmtKind(stmt.Kind), Span: dumpSpan(stmt.Span)}
+	fields := make(map[string]any)
+	label := func(l source.StringID) {
+		if l != source.NoStringID {
+			fields["label"] = d.str(l)
+		}
+	}
+	switch stmt.Kind {
+	case ast.StmtBlock:
+		if block := d.b.Stmts.Block(id); block != nil {
+			d.stmts(n, block.Stmts)
+		}
+	case ast.StmtLet:
+		if let := d.b.Stmts.Let(id); let != nil {
+			if let.Name != source.NoStringID {
+				fields["name"] = d.str(let.Name)
+			}
+			if let.IsMut {
+				fields["mut"] = true
+			}
+			d.typeField(fields, "type", let.Type)
+			appendChild(n, withRole(d.expr(let.Pattern), "pattern"))
+			appendChild(n, withRole(d.expr(let.Value), "value"))
+		}
+	case ast.StmtConst:
+		if c := d.b.Stmts.Const(id); c != nil {
+			fields["name"] = d.str(c.Name)
+			d.typeField(fields, "type", c.Type)
+			appendChild(n, withRole(d.expr(c.Value), "value"))
+		}
+	case ast.StmtExpr:
+		if s := d.b.Stmts.Expr(id); s != nil {
+			appendChild(n, withRole(d.expr(s.Expr), "expr"))
+		}
+	case ast.StmtDrop:
+		if s := d.b.Stmts.Drop(id); s != nil {
+			appendChild(n, withRole(d.expr(s.Expr), "expr"))
+		}
+	case ast.StmtSignal:
+		if s := d.b.Stmts.Signal(id); s != nil {
+			fields["name"] = d.str(s.Name)
+			appendChild(n, withRole(d.expr(s.Value), "value"))
+		}
+	case ast.StmtReturn:
+		if s := d.b.Stmts.Return(id); s != nil {
+			appendChild(n, withRole(d.expr(s.Expr), "expr"))
+		}
+	case ast.StmtRet:
+		if s := d.b.Stmts.Ret(id); s != nil {
+			appendChild(n, withRole(d.expr(s.Expr), "expr"))
+		}
+	case ast.StmtBreak, ast.StmtContinue:
+		if jump := d.b.Stmts.Jump(id); jump != nil {
+			label(jump.Label)
+		}
+	case ast.StmtIf:
+		if s := d.b.Stmts.If(id); s != nil {
+			appendChild(n, withRole(d.expr(s.Cond), "cond"))
+			appendChild(n, withRole(d.stmt(s.Then), "then"))
+			appendChild(n, withRole(d.stmt(s.Else), "else"))
+		}
+	case ast.StmtWhile:
+		if s := d.b.Stmts.While(id); s != nil {
+			label(s.Label)
+			appendChild(n, withRole(d.expr(s.Cond), "cond"))
+			appendChild(n, withRole(d.stmt(s.Body), "body"))
+		}
+	case ast.StmtForClassic:
+		if s := d.b.Stmts.ForClassic(id); s != nil {
+			label(s.Label)
+			appendChild(n, withRole(d.stmt(s.Init), "init"))
+			appendChild(n, withRole(d.expr(s.Cond), "cond"))
+			appendChild(n, withRole(d.expr(s.Post), "post"))
+			appendChild(n, withRole(d.stmt(s.Body), "body"))
+		}
+	case ast.StmtForIn:
+		if s := d.b.Stmts.ForIn(id); s != nil {
+			label(s.Label)
+			fields["pattern"] = d.str(s.Pattern)
+			if s.Index != source.NoStringID {
+				fields["index"] = d.str(s.Index)
+			}
+			d.typeField(fields, "type", s.Type)
+			appendChild(n, withRole(d.expr(s.Iterable), "iterable"))
+			appendChild(n, withRole(d.stmt(s.Body), "body"))
+		}
+	}
+	if len(fields) > 0 {
+		n.Fields = fields
+	}
+	return n
+}
+
+func (d *astDumper) stmts(n *ASTNodeOutput, ids []ast.StmtID) {
+	for _, id := range ids {
+		appendChild(n, d.stmt(id))
+	}
+}
+
+func (d *astDumper) exprs(n *ASTNodeOutput, ids []ast.ExprID, role string) {
+	for _, id := range ids {
+		appendChild(n, withRole(d.expr(id), role))
+	}
+}
+
+func (d *astDumper) expr(id ast.ExprID) *ASTNodeOutput {
+	if !id.IsValid() {
+		return nil
+	}
+	expr := d.b.Exprs.Get(id)
+	if expr == nil {
+		return &ASTNodeOutput{Node: "expr", ID: uint32(id), Kind: "<invalid>"}
+	}
+	n := &ASTNodeOutput{Node: "expr", ID: uint32(id), Kind: formatExprKind(expr.Kind), Span: dumpSpan(expr.Span)}
+	fields := make(map[string]any)
+	switch expr.Kind {
+	case ast.ExprIdent:
+		if data, ok := d.b.Exprs.Ident(id); ok {
+			fields["name"] = d.str(data.Name)
+		}
+	case ast.ExprLit:
+		if data, ok := d.b.Exprs.Literal(id); ok {
+			fields["lit"] = formatLitKind(data.Kind)
+			switch {
+			case data.Kind == ast.ExprLitTrue:
+				fields["value"] = "true"
+			case data.Kind == ast.ExprLitFalse:
+				fields["value"] = "false"
+			case data.Value != source.NoStringID:
+				fields["value"] = d.str(data.Value)
+			}
+		}
+	case ast.ExprBinary:
+		if data, ok := d.b.Exprs.Binary(id); ok {
+			fields["op"] = formatBinaryOpString(data.Op)
+			appendChild(n, withRole(d.expr(data.Left), "left"))
+			appendChild(n, withRole(d.expr(data.Right), "right"))
+		}
+	case ast.ExprUnary:
+		if data, ok := d.b.Exprs.Unary(id); ok {
+			fields["op"] = data.Op.String()
+			appendChild(n, withRole(d.expr(data.Operand), "operand"))
+		}
+	case ast.ExprCast:
+		if data, ok := d.b.Exprs.Cast(id); ok {
+			d.typeField(fields, "type", data.Type)
+			appendChild(n, withRole(d.expr(data.Value), "value"))
+		}
+	case ast.ExprCall:
+		if data, ok := d.b.Exprs.Call(id); ok {
+			if len(data.TypeArgs) > 0 {
+				typeArgs := make([]string, 0, len(data.TypeArgs))
+				for _, t := range data.TypeArgs {
+					typeArgs = append(typeArgs, formatTypeExprInline(d.b, t))
+				}
+				fields["typeArgs"] = typeArgs
+			}
+			appendChild(n, withRole(d.expr(data.Target), "target"))
+			for _, arg := range data.Args {
+				child := withRole(d.expr(arg.Value), "arg")
+				if child != nil && arg.Name != source.NoStringID {
+					child.Role = "arg:" + d.str(arg.Name)
+				}
+				appendChild(n, child)
+			}
+		}
+	case ast.ExprIndex:
+		if data, ok := d.b.Exprs.Index(id); ok {
+			appendChild(n, withRole(d.expr(data.Target), "target"))
+			appendChild(n, withRole(d.expr(data.Index), "index"))
+		}
+	case ast.ExprMember:
+		if data, ok := d.b.Exprs.Member(id); ok {
+			fields["field"] = d.str(data.Field)
+			appendChild(n, withRole(d.expr(data.Target), "target"))
+		}
+	case ast.ExprTupleIndex:
+		if data, ok := d.b.Exprs.TupleIndex(id); ok {
+			fields["index"] = data.Index
+			appendChild(n, withRole(d.expr(data.Target), "target"))
+		}
+	case ast.ExprGroup:
+		if data, ok := d.b.Exprs.Group(id); ok {
+			appendChild(n, withRole(d.expr(data.Inner), "inner"))
+		}
+	case ast.ExprTuple:
+		if data, ok := d.b.Exprs.Tuple(id); ok {
+			d.exprs(n, data.Elements, "elem")
+		}
+	case ast.ExprArray:
+		if data, ok := d.b.Exprs.Array(id); ok {
+			d.exprs(n, data.Elements, "elem")
+		}
+	case ast.ExprMap:
+		if data, ok := d.b.Exprs.Map(id); ok {
+			for _, entry := range data.Entries {
+				appendChild(n, withRole(d.expr(entry.Key), "key"))
+				appendChild(n, withRole(d.expr(entry.Value), "value"))
+			}
+		}
+	case ast.ExprRangeLit:
+		if data, ok := d.b.Exprs.RangeLit(id); ok && data != nil {
+			if data.Inclusive {
+				fields["inclusive"] = true
+			}
+			appendChild(n, withRole(d.expr(data.Start), "start"))
+			appendChild(n, withRole(d.expr(data.End), "end"))
+		}
+	case ast.ExprTernary:
+		if data, ok := d.b.Exprs.Ternary(id); ok {
+			appendChild(n, withRole(d.expr(data.Cond), "cond"))
+			appendChild(n, withRole(d.expr(data.TrueExpr), "then"))
+			appendChild(n, withRole(d.expr(data.FalseExpr), "else"))
+		}
+	case ast.ExprAwait:
+		if data, ok := d.b.Exprs.Await(id); ok {
+			appendChild(n, withRole(d.expr(data.Value), "value"))
+		}
+	case ast.ExprTask:
+		if data, ok := d.b.Exprs.Task(id); ok {
+			appendChild(n, withRole(d.expr(data.Value), "value"))
+		}
+	case ast.ExprSpawn:
+		if data, ok := d.b.Exprs.Spawn(id); ok {
+			d.attrs(fields, data.AttrStart, data.AttrCount)
+			appendChild(n, withRole(d.expr(data.Value), "value"))
+		}
+	case ast.ExprSpread:
+		if data, ok := d.b.Exprs.Spread(id); ok {
+			appendChild(n, withRole(d.expr(data.Value), "value"))
+		}
+	case ast.ExprParallel:
+		if data, ok := d.b.Exprs.Parallel(id); ok {
+			if data.Kind == ast.ExprParallelReduce {
+				fields["op"] = "reduce"
+			} else {
+				fields["op"] = "map"
+			}
+			appendChild(n, withRole(d.expr(data.Iterable), "iterable"))
+			appendChild(n, withRole(d.expr(data.Init), "init"))
+			d.exprs(n, data.Args, "arg")
+			appendChild(n, withRole(d.expr(data.Body), "body"))
+		}
+	case ast.ExprCompare:
+		if data, ok := d.b.Exprs.Compare(id); ok {
+			appendChild(n, withRole(d.expr(data.Value), "value"))
+			for _, arm := range data.Arms {
+				a := &ASTNodeOutput{Node: "arm", Span: dumpSpan(arm.PatternSpan), Role: "arm"}
+				if arm.IsFinally {
+					a.Fields = map[string]any{"finally": true}
+				}
+				appendChild(a, withRole(d.expr(arm.Pattern), "pattern"))
+				appendChild(a, withRole(d.expr(arm.Guard), "guard"))
+				appendChild(a, withRole(d.expr(arm.Result), "result"))
+				n.Children = append(n.Children, a)
+			}
+		}
+	case ast.ExprSelect, ast.ExprRace:
+		data, ok := d.b.Exprs.Select(id)
+		if expr.Kind == ast.ExprRace {
+			data, ok = d.b.Exprs.Race(id)
+		}
+		if ok && data != nil {
+			for _, arm := range data.Arms {
+				a := &ASTNodeOutput{Node: "arm", Span: dumpSpan(arm.Span), Role: "arm"}
+				if arm.IsDefault {
+					a.Fields = map[string]any{"default": true}
+				}
+				appendChild(a, withRole(d.expr(arm.Await), "await"))
+				appendChild(a, withRole(d.expr(arm.Result), "result"))
+				n.Children = append(n.Children, a)
+			}
+		}
+	case ast.ExprStruct:
+		if data, ok := d.b.Exprs.Struct(id); ok {
+			d.typeField(fields, "type", data.Type)
+			if data.Positional {
+				fields["positional"] = true
+			}
+			for _, field := range data.Fields {
+				child := withRole(d.expr(field.Value), "field")
+				if child != nil && field.Name != source.NoStringID {
+					child.Role = "field:" + d.str(field.Name)
+				}
+				appendChild(n, child)
+			}
+			appendChild(n, withRole(d.expr(data.Base), "base"))
+		}
+	case ast.ExprAsync:
+		if data, ok := d.b.Exprs.Async(id); ok && data != nil {
+			d.attrs(fields, data.AttrStart, data.AttrCount)
+			appendChild(n, withRole(d.stmt(data.Body), "body"))
+		}
+	case ast.ExprBlocking:
+		if data, ok := d.b.Exprs.Blocking(id); ok && data != nil {
+			appendChild(n, withRole(d.stmt(data.Body), "body"))
+		}
+	case ast.ExprBlock:
+		if data, ok := d.b.Exprs.Block(id); ok && data != nil {
+			d.stmts(n, data.Stmts)
+		}
+	}
+	if len(fields) > 0 {
+		n.Fields = fields
+	}
+	return n
+}
+
+// formatLitKind returns the lower-case name of a literal kind.
+func formatLitKind(kind ast.ExprLitKind) string {
+	switch kind {
+	case ast.ExprLitInt:
+		return "int"
+	case ast.ExprLitUint:
+		return "uint"
+	case ast.ExprLitFloat:
+		return "float"
+	case ast.ExprLitString:
+		return "string"
+	case ast.ExprLitTrue, ast.ExprLitFalse:
+		return "bool"
+	case ast.ExprLitNothing:
+		return "nothing"
+	case ast.ExprLitChar:
+		return "char"
+	default:
+		return fmt.Sprintf("LitKind(%d)", kind)
+	}
+}
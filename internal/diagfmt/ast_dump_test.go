@@ -0,0 +1,83 @@
+package diagfmt
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/lexer"
+	"surge/internal/parser"
+	"surge/internal/source"
+)
+
+func parseForDump(t *testing.T, name string, src []byte) (*ast.Builder, ast.FileID) {
+	t.Helper()
+	fs := source.NewFileSetWithBase("")
+	fileID := fs.AddVirtual(name, src)
+	bag := diag.NewBag(64)
+	lx := lexer.New(fs.Get(fileID), lexer.Options{Reporter: (&lexer.ReporterAdapter{Bag: bag}).Reporter()})
+	builder := ast.NewBuilder(ast.Hints{}, nil)
+	result := parser.ParseFile(context.Background(), fs, lx, builder, parser.Options{
+		Reporter:  &diag.BagReporter{Bag: bag},
+		MaxErrors: 64,
+	})
+	if bag.HasErrors() {
+		t.Fatalf("parse %s: %d error(s), first: %s", name, bag.Len(), bag.Items()[0].Message)
+	}
+	return builder, result.File
+}
+
+// TestASTDumpGolden сверяет JSON- и S-expression-дампы AST с testdata/golden/ast_dump.
+func TestASTDumpGolden(t *testing.T) {
+	dir := filepath.Join("..", "..", "testdata", "golden", "ast_dump")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read ast_dump golden dir: %v", err)
+	}
+
+	formats := []struct {
+		ext    string
+		render func(io.Writer, *ast.Builder, ast.FileID) error
+	}{
+		{ext: ".ast.json", render: FormatASTJSON},
+		{ext: ".ast.sexpr", render: FormatASTSExpr},
+	}
+	for _, ent := range entries {
+		if ent.IsDir() || !strings.HasSuffix(ent.Name(), ".sg") {
+			continue
+		}
+		name := strings.TrimSuffix(ent.Name(), ".sg")
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join(dir, ent.Name()))
+			if err != nil {
+				t.Fatalf("read %s: %v", ent.Name(), err)
+			}
+			builder, fileID := parseForDump(t, ent.Name(), src)
+			for _, f := range formats {
+				want, err := os.ReadFile(filepath.Join(dir, name+f.ext))
+				if err != nil {
+					t.Fatalf("read %s%s: %v", name, f.ext, err)
+				}
+				var first, second bytes.Buffer
+				if err := f.render(&first, builder, fileID); err != nil {
+					t.Fatalf("render %s: %v", f.ext, err)
+				}
+				if err := f.render(&second, builder, fileID); err != nil {
+					t.Fatalf("render %s: %v", f.ext, err)
+				}
+				if first.String() != second.String() {
+					t.Fatalf("%s output is not deterministic", f.ext)
+				}
+				if first.String() != string(want) {
+					t.Fatalf("%s mismatch:\nwant:\n%s\ngot:\n%s", f.ext, want, first.String())
+				}
+			}
+		})
+	}
+}
@@ -468,6 +468,8 @@ func formatExprKind(kind ast.ExprKind) string {
 		return "Group"
 	case ast.ExprTuple:
 		return "Tuple"
+	case ast.ExprArray:
+		return "Array"
 	case ast.ExprMap:
 		return "Map"
 	case ast.ExprIndex:
@@ -490,10 +492,18 @@ func formatExprKind(kind ast.ExprKind) string {
 		return "Spread"
 	case ast.ExprCompare:
 		return "Compare"
+	case ast.ExprSelect:
+		return "Select"
+	case ast.ExprRace:
+		return "Race"
+	case ast.ExprStruct:
+		return "Struct"
 	case ast.ExprAsync:
 		return "Async"
 	case ast.ExprBlocking:
 		return "Blocking"
+	case ast.ExprBlock:
+		return "Block"
 	case ast.ExprRangeLit:
 		return "RangeLit"
 	default:
@@ -92,11 +92,12 @@ func buildStmtTreeNode(builder *ast.Builder, stmtID ast.StmtID, fs *source.FileS
 			label: fmt.Sprintf("Expr: %s", value),
 		})
 
-	case ast.StmtBreak:
-		node.children = append(node.children, &treeNode{label: "(no additional data)"})
-
-	case ast.StmtContinue:
-		node.children = append(node.children, &treeNode{label: "(no additional data)"})
+	case ast.StmtBreak, ast.StmtContinue:
+		if jump := builder.Stmts.Jump(stmtID); jump != nil {
+			node.children = append(node.children, &treeNode{label: fmt.Sprintf("Label: %s", lookupStringOr(builder, jump.Label, "<anon>"))})
+		} else {
+			node.children = append(node.children, &treeNode{label: "(no additional data)"})
+		}
 
 	case ast.StmtIf:
 		ifStmt := builder.Stmts.If(stmtID)
@@ -130,6 +131,9 @@ func buildStmtTreeNode(builder *ast.Builder, stmtID ast.StmtID, fs *source.FileS
 		if whileStmt == nil {
 			return node
 		}
+		if whileStmt.Label != source.NoStringID {
+			node.children = append(node.children, &treeNode{label: fmt.Sprintf("Label: %s", lookupStringOr(builder, whileStmt.Label, "<anon>"))})
+		}
 		node.children = append(node.children,
 			&treeNode{label: fmt.Sprintf("Cond: %s", formatExprSummary(builder, whileStmt.Cond))},
 		)
@@ -146,6 +150,9 @@ func buildStmtTreeNode(builder *ast.Builder, stmtID ast.StmtID, fs *source.FileS
 		if forStmt == nil {
 			return node
 		}
+		if forStmt.Label != source.NoStringID {
+			node.children = append(node.children, &treeNode{label: fmt.Sprintf("Label: %s", lookupStringOr(builder, forStmt.Label, "<anon>"))})
+		}
 		if forStmt.Init.IsValid() {
 			initNode := buildStmtTreeNode(builder, forStmt.Init, fs, 0)
 			if initNode != nil {
@@ -170,6 +177,12 @@ func buildStmtTreeNode(builder *ast.Builder, stmtID ast.StmtID, fs *source.FileS
 		if forIn == nil {
 			return node
 		}
+		if forIn.Label != source.NoStringID {
+			node.children = append(node.children, &treeNode{label: fmt.Sprintf("Label: %s", lookupStringOr(builder, forIn.Label, "<anon>"))})
+		}
+		if forIn.Index != source.NoStringID {
+			node.children = append(node.children, &treeNode{label: fmt.Sprintf("Index: %s", lookupStringOr(builder, forIn.Index, "<anon>"))})
+		}
 		pattern := lookupStringOr(builder, forIn.Pattern, "<anon>")
 		node.children = append(node.children, &treeNode{label: fmt.Sprintf("Pattern: %s", pattern)})
 		if forIn.Type.IsValid() {
@@ -188,255 +201,6 @@ func buildStmtTreeNode(builder *ast.Builder, stmtID ast.StmtID, fs *source.FileS
 	return node
 }
 
-// formatStmtJSON converts the statement identified by stmtID into an ASTNodeOutput suitable for JSON-like serialization.
-// It returns the constructed ASTNodeOutput and an error if the builder or its statements arena is nil or if the statement is not found.
-func formatStmtJSON(builder *ast.Builder, stmtID ast.StmtID) (ASTNodeOutput, error) {
-	if builder == nil || builder.Stmts == nil {
-		return ASTNodeOutput{}, fmt.Errorf("statements arena is nil")
-	}
-
-	stmt := builder.Stmts.Get(stmtID)
-	if stmt == nil {
-		return ASTNodeOutput{}, fmt.Errorf("statement %d not found", stmtID)
-	}
-
-	output := ASTNodeOutput{
-		Type: "Stmt",
-		Kind: formatStmtKind(stmt.Kind),
-		Span: stmt.Span,
-	}
-
-	switch stmt.Kind {
-	case ast.StmtBlock:
-		block := builder.Stmts.Block(stmtID)
-		if block != nil {
-			for _, childID := range block.Stmts {
-				childNode, err := formatStmtJSON(builder, childID)
-				if err != nil {
-					return ASTNodeOutput{}, err
-				}
-				output.Children = append(output.Children, childNode)
-			}
-		}
-
-	case ast.StmtLet:
-		letStmt := builder.Stmts.Let(stmtID)
-		if letStmt != nil {
-			fields := map[string]any{
-				"name":  lookupStringOr(builder, letStmt.Name, "<anon>"),
-				"isMut": letStmt.IsMut,
-				"type":  formatTypeExprInline(builder, letStmt.Type),
-				"value": formatExprInline(builder, letStmt.Value),
-				"valueID": func() any {
-					if letStmt.Value.IsValid() {
-						return uint32(letStmt.Value)
-					}
-					return nil
-				}(),
-			}
-			output.Fields = cleanupNilFields(fields)
-		}
-
-	case ast.StmtExpr:
-		exprStmt := builder.Stmts.Expr(stmtID)
-		if exprStmt != nil {
-			output.Fields = cleanupNilFields(map[string]any{
-				"expr": formatExprInline(builder, exprStmt.Expr),
-				"exprID": func() any {
-					if exprStmt.Expr.IsValid() {
-						return uint32(exprStmt.Expr)
-					}
-					return nil
-				}(),
-			})
-		}
-
-	case ast.StmtSignal:
-		signalStmt := builder.Stmts.Signal(stmtID)
-		if signalStmt != nil {
-			output.Fields = cleanupNilFields(map[string]any{
-				"name":  lookupStringOr(builder, signalStmt.Name, "<anon>"),
-				"value": formatExprInline(builder, signalStmt.Value),
-				"valueID": func() any {
-					if signalStmt.Value.IsValid() {
-						return uint32(signalStmt.Value)
-					}
-					return nil
-				}(),
-			})
-		}
-
-	case ast.StmtReturn:
-		retStmt := builder.Stmts.Return(stmtID)
-		if retStmt != nil {
-			fields := map[string]any{
-				"expr": formatExprInline(builder, retStmt.Expr),
-				"exprID": func() any {
-					if retStmt.Expr.IsValid() {
-						return uint32(retStmt.Expr)
-					}
-					return nil
-				}(),
-			}
-			output.Fields = cleanupNilFields(fields)
-		}
-
-	case ast.StmtRet:
-		retStmt := builder.Stmts.Ret(stmtID)
-		if retStmt != nil {
-			fields := map[string]any{
-				"expr": formatExprInline(builder, retStmt.Expr),
-				"exprID": func() any {
-					if retStmt.Expr.IsValid() {
-						return uint32(retStmt.Expr)
-					}
-					return nil
-				}(),
-			}
-			output.Fields = cleanupNilFields(fields)
-		}
-
-	case ast.StmtBreak, ast.StmtContinue:
-		// no extra fields
-
-	case ast.StmtIf:
-		ifStmt := builder.Stmts.If(stmtID)
-		if ifStmt != nil {
-			output.Fields = cleanupNilFields(map[string]any{
-				"cond": formatExprInline(builder, ifStmt.Cond),
-				"condID": func() any {
-					if ifStmt.Cond.IsValid() {
-						return uint32(ifStmt.Cond)
-					}
-					return nil
-				}(),
-			})
-			if ifStmt.Then.IsValid() {
-				thenNode, err := formatStmtJSON(builder, ifStmt.Then)
-				if err != nil {
-					return ASTNodeOutput{}, err
-				}
-				if thenNode.Fields == nil {
-					thenNode.Fields = map[string]any{}
-				}
-				thenNode.Fields["role"] = "then"
-				output.Children = append(output.Children, thenNode)
-			}
-			if ifStmt.Else.IsValid() {
-				elseNode, err := formatStmtJSON(builder, ifStmt.Else)
-				if err != nil {
-					return ASTNodeOutput{}, err
-				}
-				if elseNode.Fields == nil {
-					elseNode.Fields = map[string]any{}
-				}
-				elseNode.Fields["role"] = "else"
-				output.Children = append(output.Children, elseNode)
-			}
-		}
-
-	case ast.StmtWhile:
-		whileStmt := builder.Stmts.While(stmtID)
-		if whileStmt != nil {
-			output.Fields = cleanupNilFields(map[string]any{
-				"cond": formatExprInline(builder, whileStmt.Cond),
-				"condID": func() any {
-					if whileStmt.Cond.IsValid() {
-						return uint32(whileStmt.Cond)
-					}
-					return nil
-				}(),
-			})
-			if whileStmt.Body.IsValid() {
-				bodyNode, err := formatStmtJSON(builder, whileStmt.Body)
-				if err != nil {
-					return ASTNodeOutput{}, err
-				}
-				if bodyNode.Fields == nil {
-					bodyNode.Fields = map[string]any{}
-				}
-				bodyNode.Fields["role"] = "body"
-				output.Children = append(output.Children, bodyNode)
-			}
-		}
-
-	case ast.StmtForClassic:
-		forStmt := builder.Stmts.ForClassic(stmtID)
-		if forStmt != nil {
-			output.Fields = cleanupNilFields(map[string]any{
-				"cond": formatExprInline(builder, forStmt.Cond),
-				"condID": func() any {
-					if forStmt.Cond.IsValid() {
-						return uint32(forStmt.Cond)
-					}
-					return nil
-				}(),
-				"post": formatExprInline(builder, forStmt.Post),
-				"postID": func() any {
-					if forStmt.Post.IsValid() {
-						return uint32(forStmt.Post)
-					}
-					return nil
-				}(),
-			})
-			if forStmt.Init.IsValid() {
-				initNode, err := formatStmtJSON(builder, forStmt.Init)
-				if err != nil {
-					return ASTNodeOutput{}, err
-				}
-				if initNode.Fields == nil {
-					initNode.Fields = map[string]any{}
-				}
-				initNode.Fields["role"] = "init"
-				output.Children = append(output.Children, initNode)
-			}
-			if forStmt.Body.IsValid() {
-				bodyNode, err := formatStmtJSON(builder, forStmt.Body)
-				if err != nil {
-					return ASTNodeOutput{}, err
-				}
-				if bodyNode.Fields == nil {
-					bodyNode.Fields = map[string]any{}
-				}
-				bodyNode.Fields["role"] = "body"
-				output.Children = append(output.Children, bodyNode)
-			}
-		}
-
-	case ast.StmtForIn:
-		forIn := builder.Stmts.ForIn(stmtID)
-		if forIn != nil {
-			fields := map[string]any{
-				"pattern":  lookupStringOr(builder, forIn.Pattern, "<anon>"),
-				"iterable": formatExprInline(builder, forIn.Iterable),
-				"iterableID": func() any {
-					if forIn.Iterable.IsValid() {
-						return uint32(forIn.Iterable)
-					}
-					return nil
-				}(),
-			}
-			if forIn.Type.IsValid() {
-				fields["type"] = formatTypeExprInline(builder, forIn.Type)
-			}
-			output.Fields = cleanupNilFields(fields)
-			if forIn.Body.IsValid() {
-				bodyNode, err := formatStmtJSON(builder, forIn.Body)
-				if err != nil {
-					return ASTNodeOutput{}, err
-				}
-				if bodyNode.Fields == nil {
-					bodyNode.Fields = map[string]any{}
-				}
-				bodyNode.Fields["role"] = "body"
-				output.Children = append(output.Children, bodyNode)
-			}
-		}
-	}
-
-	return output, nil
-}
-
 // lookupStringOr resolves the interned string for the given StringID, falling back to the provided fallback or "<anon>" when unavailable.
 // If builder or its StringsInterner is nil, or id is source.NoStringID, the fallback is returned when non-empty; otherwise "<anon>" is returned.
 func lookupStringOr(builder *ast.Builder, id source.StringID, fallback string) string {
@@ -487,17 +251,3 @@ func formatFnParamsInline(builder *ast.Builder, fn *ast.FnItem) string {
 	}
 	return fmt.Sprintf("(%s)", strings.Join(parts, ", "))
 }
-
-// cleanupNilFields removes any key/value pairs from fields whose value is nil and returns the resulting map.
-// The input map is modified in-place; if all entries are removed the function returns nil.
-func cleanupNilFields(fields map[string]any) map[string]any {
-	for key, value := range fields {
-		if value == nil {
-			delete(fields, key)
-		}
-	}
-	if len(fields) == 0 {
-		return nil
-	}
-	return fields
-}
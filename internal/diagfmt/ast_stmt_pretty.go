@@ -159,7 +159,11 @@ func formatStmtPretty(w io.Writer, builder *ast.Builder, stmtID ast.StmtID, fs *
 		fmt.Fprintf(w, "%s└─ Expr: %s\n", prefix, value) //nolint:errcheck
 
 	case ast.StmtBreak, ast.StmtContinue:
-		fmt.Fprintf(w, "%s└─ (no additional data)\n", prefix) //nolint:errcheck
+		if jump := builder.Stmts.Jump(stmtID); jump != nil {
+			fmt.Fprintf(w, "%s└─ Label: %s\n", prefix, lookupStringOr(builder, jump.Label, "<anon>")) //nolint:errcheck
+		} else {
+			fmt.Fprintf(w, "%s└─ (no additional data)\n", prefix) //nolint:errcheck
+		}
 
 	case ast.StmtIf:
 		ifStmt := builder.Stmts.If(stmtID)
@@ -218,6 +222,9 @@ func formatStmtPretty(w io.Writer, builder *ast.Builder, stmtID ast.StmtID, fs *
 		if whileStmt == nil {
 			return nil
 		}
+		if whileStmt.Label != source.NoStringID {
+			fmt.Fprintf(w, "%s├─ Label: %s\n", prefix, lookupStringOr(builder, whileStmt.Label, "<anon>")) //nolint:errcheck
+		}
 		fmt.Fprintf(w, "%s├─ Cond: %s\n", prefix, formatExprSummary(builder, whileStmt.Cond)) //nolint:errcheck
 		fmt.Fprintf(w, "%s└─ Body:\n", prefix)                                                //nolint:errcheck
 		if err := formatStmtPretty(w, builder, whileStmt.Body, fs, prefix+"   "); err != nil {
@@ -235,6 +242,9 @@ func formatStmtPretty(w io.Writer, builder *ast.Builder, stmtID ast.StmtID, fs *
 			expr  ast.ExprID
 			stmt  ast.StmtID
 		}
+		if forStmt.Label != source.NoStringID {
+			fmt.Fprintf(w, "%s├─ Label: %s\n", prefix, lookupStringOr(builder, forStmt.Label, "<anon>")) //nolint:errcheck
+		}
 		var entries []entry
 		if forStmt.Init.IsValid() {
 			entries = append(entries, entry{label: "Init", kind: "stmt", stmt: forStmt.Init})
@@ -271,6 +281,12 @@ func formatStmtPretty(w io.Writer, builder *ast.Builder, stmtID ast.StmtID, fs *
 		if forIn == nil {
 			return nil
 		}
+		if forIn.Label != source.NoStringID {
+			fmt.Fprintf(w, "%s├─ Label: %s\n", prefix, lookupStringOr(builder, forIn.Label, "<anon>")) //nolint:errcheck
+		}
+		if forIn.Index != source.NoStringID {
+			fmt.Fprintf(w, "%s├─ Index: %s\n", prefix, lookupStringOr(builder, forIn.Index, "<anon>")) //nolint:errcheck
+		}
 		patternName := lookupStringOr(builder, forIn.Pattern, "<anon>")
 		fmt.Fprintf(w, "%s├─ Pattern: %s\n", prefix, patternName) //nolint:errcheck
 		if forIn.Type.IsValid() {
@@ -0,0 +1,26 @@
+package diagfmt
+
+import (
+	"fmt"
+	"net/url"
+
+	"surge/internal/source"
+)
+
+// hyperlink оборачивает text в OSC 8 escape-последовательность, указывающую на file://path#line:col.
+// Терминалы без поддержки OSC 8 просто игнорируют обёртку.
+func hyperlink(f *source.File, pos source.LineCol, text string) string {
+	if f == nil || f.Flags&source.FileVirtual != 0 {
+		return text
+	}
+	absPath, err := source.AbsolutePath(f.Path)
+	if err != nil {
+		return text
+	}
+	target := url.URL{
+		Scheme:   "file",
+		Path:     absPath,
+		Fragment: fmt.Sprintf("%d:%d", pos.Line, pos.Col),
+	}
+	return "\x1b]8;;" + target.String() + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
@@ -3,8 +3,11 @@ package diagfmt
 import (
 	"encoding/json"
 	"io"
+	"math"
 	"sort"
 
+	"fortio.org/safecast"
+
 	"surge/internal/diag"
 	"surge/internal/source"
 )
@@ -24,6 +27,7 @@ type LocationJSON struct {
 type NoteJSON struct {
 	Message  string       `json:"message"`
 	Location LocationJSON `json:"location"`
+	Snippet  *SnippetJSON `json:"snippet,omitempty"`
 }
 
 // FixEditJSON представляет одно редактирование для JSON
@@ -46,12 +50,28 @@ type FixJSON struct {
 	Edits         []FixEditJSON `json:"edits,omitempty"`
 }
 
+// SnippetJSON embeds the source lines under a span, so a consumer can render
+// the diagnostic without opening the file. Columns are 1-based byte columns,
+// like LocationJSON.
+type SnippetJSON struct {
+	StartLine     uint32   `json:"start_line"`      // line number of Lines[0]
+	LineStartByte uint32   `json:"line_start_byte"` // byte offset of Lines[0] in the file
+	Lines         []string `json:"lines"`
+	CaretCol      uint32   `json:"caret_col"`     // span start on Lines[0]
+	CaretEndCol   uint32   `json:"caret_end_col"` // span end on Lines[0], exclusive; end of line for multi-line spans
+	Truncated     bool     `json:"truncated,omitempty"`
+}
+
+// snippetMaxLines ограничивает число строк сниппета для многострочных span'ов.
+const snippetMaxLines = 8
+
 // DiagnosticJSON представляет диагностику в JSON формате
 type DiagnosticJSON struct {
 	Severity string       `json:"severity"`
 	Code     string       `json:"code"`
 	Message  string       `json:"message"`
 	Location LocationJSON `json:"location"`
+	Snippet  *SnippetJSON `json:"snippet,omitempty"`
 	Notes    []NoteJSON   `json:"notes,omitempty"`
 	Fixes    []FixJSON    `json:"fixes,omitempty"`
 }
@@ -100,6 +120,54 @@ func makeLocation(span source.Span, fs *source.FileSet, pathMode PathMode, inclu
 	return loc
 }
 
+// makeSnippet вырезает строки, покрытые span, из файла в FileSet.
+func makeSnippet(span source.Span, fs *source.FileSet) *SnippetJSON {
+	if !fs.HasFile(span.File) {
+		return nil
+	}
+	f := fs.Get(span.File)
+	startPos, endPos := fs.Resolve(span)
+	endLine := max(endPos.Line, startPos.Line)
+	if endLine > startPos.Line && endPos.Col == 1 {
+		// span заканчивается на переводе строки: следующая строка не покрыта
+		endLine--
+	}
+	snippet := &SnippetJSON{
+		StartLine: startPos.Line,
+		CaretCol:  startPos.Col,
+	}
+	if startPos.Line >= 2 && int(startPos.Line-2) < len(f.LineIdx) {
+		snippet.LineStartByte = f.LineIdx[startPos.Line-2] + 1
+	}
+	if endLine-startPos.Line+1 > snippetMaxLines {
+		endLine = startPos.Line + snippetMaxLines - 1
+		snippet.Truncated = true
+	}
+	for line := startPos.Line; line <= endLine; line++ {
+		snippet.Lines = append(snippet.Lines, f.GetLine(line))
+	}
+	lineEnd := snippetLineEnd(len(snippet.Lines[0]))
+	switch {
+	case endPos.Line == startPos.Line:
+		snippet.CaretEndCol = min(max(endPos.Col, startPos.Col), lineEnd)
+	default:
+		snippet.CaretEndCol = lineEnd
+	}
+	snippet.CaretCol = min(snippet.CaretCol, lineEnd)
+	return snippet
+}
+
+// snippetLineEnd возвращает колонку сразу за концом строки длиной n байт.
+// Колонки хранятся в uint32, поэтому для строк длиннее значение усекается
+// до math.MaxUint32.
+func snippetLineEnd(n int) uint32 {
+	lineLen, err := safecast.Conv[uint32](n)
+	if err != nil || lineLen == math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return lineLen + 1
+}
+
 // BuildDiagnosticsOutput формирует структуру JSON-вывода без сериализации.
 func BuildDiagnosticsOutput(bag *diag.Bag, fs *source.FileSet, opts JSONOpts, semantics *SemanticsInput) (DiagnosticsOutput, error) {
 	diagnostics := make([]DiagnosticJSON, 0, bag.Len())
@@ -119,6 +187,9 @@ func BuildDiagnosticsOutput(bag *diag.Bag, fs *source.FileSet, opts JSONOpts, se
 			Message:  d.Message,
 			Location: makeLocation(d.Primary, fs, opts.PathMode, opts.IncludePositions),
 		}
+		if opts.IncludeSnippets {
+			diagJSON.Snippet = makeSnippet(d.Primary, fs)
+		}
 
 		includeNotes := opts.IncludeNotes || d.Code == diag.ObsTimings
 		if includeNotes && len(d.Notes) > 0 {
@@ -128,6 +199,9 @@ func BuildDiagnosticsOutput(bag *diag.Bag, fs *source.FileSet, opts JSONOpts, se
 					Message:  note.Msg,
 					Location: makeLocation(note.Span, fs, opts.PathMode, opts.IncludePositions),
 				}
+				if opts.IncludeSnippets {
+					diagJSON.Notes[j].Snippet = makeSnippet(note.Span, fs)
+				}
 			}
 		}
 
@@ -3,6 +3,8 @@ package diagfmt
 import (
 	"bytes"
 	"encoding/json"
+	"math"
+	"strconv"
 	"testing"
 
 	"fortio.org/safecast"
@@ -404,3 +406,98 @@ func TestJSONFixPreview(t *testing.T) {
 		t.Errorf("Unexpected after line: %q", editJSON.AfterLines[0])
 	}
 }
+
+// TestJSONSnippets проверяет, что сниппет совпадает со строкой primary span.
+func TestJSONSnippets(t *testing.T) {
+	fs := source.NewFileSet()
+	content := []byte("fn main() {\n\tlet value = missing;\n}\n")
+	fileID := fs.AddVirtual("snippet.sg", content)
+
+	start := uint32(bytes.Index(content, []byte("missing"))) //nolint:gosec // small test input
+	bag := diag.NewBag(10)
+	d := diag.New(
+		diag.SevError,
+		diag.SemaUnresolvedSymbol,
+		source.Span{File: fileID, Start: start, End: start + uint32(len("missing"))},
+		"unresolved symbol",
+	)
+	d.Notes = append(d.Notes, diag.Note{
+		Span: source.Span{File: fileID, Start: 0, End: uint32(len(content))}, //nolint:gosec // small test input
+		Msg:  "in this function",
+	})
+	bag.Add(d)
+
+	opts := JSONOpts{
+		IncludePositions: true,
+		PathMode:         PathModeBasename,
+		IncludeNotes:     true,
+		IncludeSnippets:  true,
+	}
+	var first, second bytes.Buffer
+	if err := JSON(&first, bag, fs, opts, nil); err != nil {
+		t.Fatalf("JSON() error: %v", err)
+	}
+	if err := JSON(&second, bag, fs, opts, nil); err != nil {
+		t.Fatalf("JSON() error: %v", err)
+	}
+	if first.String() != second.String() {
+		t.Fatalf("snippet output is not deterministic:\n%s\n---\n%s", first.String(), second.String())
+	}
+
+	var output DiagnosticsOutput
+	if err := json.Unmarshal(first.Bytes(), &output); err != nil {
+		t.Fatalf("Invalid JSON output: %v\nOutput: %s", err, first.String())
+	}
+	diagJSON := output.Diagnostics[0]
+	snippet := diagJSON.Snippet
+	if snippet == nil {
+		t.Fatalf("expected a snippet, got none:\n%s", first.String())
+	}
+	loc := diagJSON.Location
+	if snippet.StartLine != loc.StartLine || len(snippet.Lines) != 1 {
+		t.Fatalf("snippet lines %d+%d do not match span line %d", snippet.StartLine, len(snippet.Lines), loc.StartLine)
+	}
+	if want := fs.Get(fileID).GetLine(loc.StartLine); snippet.Lines[0] != want {
+		t.Errorf("snippet line %q, want %q", snippet.Lines[0], want)
+	}
+	if snippet.CaretCol != loc.StartCol || snippet.CaretEndCol != loc.EndCol {
+		t.Errorf("caret %d..%d, want %d..%d", snippet.CaretCol, snippet.CaretEndCol, loc.StartCol, loc.EndCol)
+	}
+	if got := snippet.Lines[0][snippet.CaretCol-1 : snippet.CaretEndCol-1]; got != "missing" {
+		t.Errorf("caret covers %q, want %q", got, "missing")
+	}
+	if snippet.LineStartByte+snippet.CaretCol-1 != loc.StartByte {
+		t.Errorf("line_start_byte %d + caret_col %d does not point at start_byte %d", snippet.LineStartByte, snippet.CaretCol, loc.StartByte)
+	}
+
+	note := diagJSON.Notes[0].Snippet
+	if note == nil || note.StartLine != 1 || len(note.Lines) != 3 || note.Lines[2] != "}" {
+		t.Fatalf("unexpected multi-line note snippet: %+v", note)
+	}
+	if note.CaretEndCol != uint32(len(note.Lines[0]))+1 { //nolint:gosec // small test input
+		t.Errorf("multi-line caret should run to the end of the first line, got %d", note.CaretEndCol)
+	}
+}
+
+// TestSnippetLineEndClamps проверяет, что слишком длинная строка не роняет
+// вывод сниппета, а упирается в максимальную колонку.
+func TestSnippetLineEndClamps(t *testing.T) {
+	type lineCase struct {
+		n    int
+		want uint32
+	}
+	cases := []lineCase{{0, 1}, {7, 8}}
+	if strconv.IntSize == 64 {
+		maxCol := uint64(math.MaxUint32)
+		cases = append(cases,
+			lineCase{int(maxCol) - 1, math.MaxUint32}, //nolint:gosec // int is 64-bit here
+			lineCase{int(maxCol), math.MaxUint32},     //nolint:gosec // int is 64-bit here
+			lineCase{math.MaxInt, math.MaxUint32},
+		)
+	}
+	for _, tc := range cases {
+		if got := snippetLineEnd(tc.n); got != tc.want {
+			t.Errorf("snippetLineEnd(%d) = %d, want %d", tc.n, got, tc.want)
+		}
+	}
+}
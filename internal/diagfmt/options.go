@@ -17,12 +17,14 @@ const (
 // PrettyOpts configures pretty-printing of diagnostics.
 type PrettyOpts struct {
 	Color       bool
+	Theme       Theme // палитра при Color=true; пустая строка — ThemeDark
 	Context     int8
 	PathMode    PathMode
-	Width       uint8 // максимальная ширина строки, 0 - не ограничено
+	Width       uint16 // ширина вывода в колонках: переносит сообщения и обрезает строки исходника; 0 - не ограничено
 	ShowNotes   bool
 	ShowFixes   bool
 	ShowPreview bool
+	Hyperlinks  bool // оборачивать локации в OSC 8 ссылки (file://path#line:col)
 }
 
 // JSONOpts configures JSON output of diagnostics.
@@ -34,6 +36,7 @@ type JSONOpts struct {
 	IncludeFixes     bool
 	IncludePreviews  bool
 	IncludeSemantics bool
+	IncludeSnippets  bool // встроить строки исходника под каждым span (SnippetJSON)
 }
 
 // SarifRunMeta provides metadata for SARIF output.
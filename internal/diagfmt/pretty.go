@@ -48,20 +48,18 @@ func visualWidthUpTo(s string, byteCol uint32, tabWidth int) int {
 // Для каждого diag печатает:
 // <path>:<line>:<col>: <SEV> <CODE>: <Message>
 // затем контекст строки с подчёркиванием ^~~~ по Span, затем Notes с аналогичным форматом.
-// Цвет включается опцией.
+// Цвет включается опцией, палитра выбирается через opts.Theme.
 func Pretty(w io.Writer, bag *diag.Bag, fs *source.FileSet, opts PrettyOpts) {
-	// Настройка цветов
+	// Настройка цветов по теме (пустая тема — dark)
+	pal := opts.Theme.palette()
 	var (
-		errorColor     = color.New(color.FgRed, color.Bold)
-		warningColor   = color.New(color.FgYellow, color.Bold)
-		infoColor      = color.New(color.FgCyan, color.Bold)
-		pathColor      = color.New(color.FgWhite, color.Bold)
-		codeColor      = color.New(color.FgMagenta)
-		lineNumColor   = color.New(color.FgBlue)
-		underlineColor = color.New(color.FgRed, color.Bold)
-		previewLabel   = color.New(color.FgCyan, color.Bold)
-		beforeColor    = color.New(color.FgRed)
-		afterColor     = color.New(color.FgGreen)
+		pathColor    = pal.path
+		codeColor    = pal.code
+		lineNumColor = pal.lineNum
+		infoColor    = pal.note
+		previewLabel = pal.previewLabel
+		beforeColor  = pal.before
+		afterColor   = pal.after
 	)
 
 	// Отключаем цвета если нужно
@@ -92,7 +90,16 @@ func Pretty(w io.Writer, bag *diag.Bag, fs *source.FileSet, opts PrettyOpts) {
 		}
 	}
 
+	formatLocation := func(f *source.File, displayPath string, pos source.LineCol) string {
+		loc := fmt.Sprintf("%s:%d:%d", pathColor.Sprint(displayPath), pos.Line, pos.Col)
+		if !opts.Hyperlinks {
+			return loc
+		}
+		return hyperlink(f, pos, loc)
+	}
+
 	fixLabelColor := infoColor
+	width := int(opts.Width)
 
 	for idx, d := range bag.Items() {
 		if idx > 0 {
@@ -106,27 +113,24 @@ func Pretty(w io.Writer, bag *diag.Bag, fs *source.FileSet, opts PrettyOpts) {
 		displayPath := formatPath(f)
 
 		// Заголовок: file.sg:23:7: ERROR LEX1002: message
-		sevStr := d.Severity.String()
-		var sevColored string
-		switch d.Severity {
-		case diag.SevError:
-			sevColored = errorColor.Sprint(sevStr)
-		case diag.SevWarning:
-			sevColored = warningColor.Sprint(sevStr)
-		case diag.SevInfo:
-			sevColored = infoColor.Sprint(sevStr)
-		default:
-			sevColored = sevStr
-		}
-
-		fmt.Fprintf(w, "%s:%d:%d: %s %s: %s\n", //nolint:errcheck
-			pathColor.Sprint(displayPath),
-			lineColStart.Line,
-			lineColStart.Col,
+		sevColored := pal.severityText(d.Severity)
+
+		// Ширина префикса считается по тексту без ANSI-кодов и OSC 8 ссылок.
+		headerWidth := runewidth.StringWidth(fmt.Sprintf("%s:%d:%d: %s %s: ",
+			displayPath, lineColStart.Line, lineColStart.Col, d.Severity.String(), d.Code.ID()))
+		msgLines := wrappedLines(headerWidth, d.Message, width, "    ")
+		header := fmt.Sprintf("%s: %s %s:",
+			formatLocation(f, displayPath, lineColStart),
 			sevColored,
 			codeColor.Sprint(d.Code.ID()),
-			d.Message,
 		)
+		if len(msgLines) == 1 || msgLines[0] != "" {
+			header += " " + msgLines[0]
+		}
+		fmt.Fprintln(w, header) //nolint:errcheck
+		for _, line := range msgLines[1:] {
+			fmt.Fprintln(w, line) //nolint:errcheck
+		}
 
 		// Вывод контекста с подчеркиванием
 		totalLines, err := safecast.Conv[uint32](len(f.LineIdx))
@@ -159,6 +163,15 @@ func Pretty(w io.Writer, bag *diag.Bag, fs *source.FileSet, opts PrettyOpts) {
 		// Вычисляем ширину номеров строк для всего блока (для единообразия)
 		lineNumWidth := max(len(fmt.Sprintf("%d", endLine)), 3)
 
+		// При ограниченной ширине каждая строка контекста обрезается вокруг
+		// визуальных колонок основного span, чтобы он оставался на экране.
+		primaryText := f.GetLine(lineColStart.Line)
+		focusStart := visualWidthUpTo(primaryText, lineColStart.Col, tabWidth)
+		focusEnd := focusStart
+		if lineColEnd.Line == lineColStart.Line {
+			focusEnd = max(visualWidthUpTo(primaryText, lineColEnd.Col, tabWidth), focusStart)
+		}
+
 		for lineNum := startLine; lineNum <= endLine; lineNum++ {
 			lineText := f.GetLine(lineNum)
 
@@ -172,7 +185,13 @@ func Pretty(w io.Writer, bag *diag.Bag, fs *source.FileSet, opts PrettyOpts) {
 			if err != nil {
 				panic(fmt.Errorf("write gutter: %w", err))
 			}
-			_, err = io.WriteString(w, lineText)
+			var window sourceWindow
+			shownText := lineText
+			if width > 0 {
+				window = clipSourceLine(lineText, width-gutterLen, focusStart, focusEnd, tabWidth)
+				shownText = window.text
+			}
+			_, err = io.WriteString(w, shownText)
 			if err != nil {
 				panic(fmt.Errorf("write line text: %w", err))
 			}
@@ -199,6 +218,11 @@ func Pretty(w io.Writer, bag *diag.Bag, fs *source.FileSet, opts PrettyOpts) {
 				// Вычисляем визуальные позиции с учётом табуляций и Unicode
 				visualStart := visualWidthUpTo(lineText, startCol, tabWidth)
 				visualEnd := visualWidthUpTo(lineText, endCol, tabWidth)
+				if width > 0 {
+					// Каретка выравнивается по видимому фрагменту строки.
+					visualStart = window.column(visualStart)
+					visualEnd = window.column(visualEnd)
+				}
 
 				// Строим строку подчеркивания
 				var underline strings.Builder
@@ -227,7 +251,7 @@ func Pretty(w io.Writer, bag *diag.Bag, fs *source.FileSet, opts PrettyOpts) {
 					}
 				}
 
-				fmt.Fprintln(w, underlineColor.Sprint(underline.String())) //nolint:errcheck
+				fmt.Fprintln(w, pal.underlineText(d.Severity, underline.String())) //nolint:errcheck
 			}
 		}
 
@@ -243,18 +267,29 @@ func Pretty(w io.Writer, bag *diag.Bag, fs *source.FileSet, opts PrettyOpts) {
 					continue
 				}
 
+				if !fs.HasFile(note.Span.File) {
+					// Файл заметки неизвестен этому FileSet — выводим только текст.
+					fmt.Fprintf(w, "  %s: %s\n", infoColor.Sprint("note"), note.Msg) //nolint:errcheck
+					continue
+				}
 				nf := fs.Get(note.Span.File)
 				notePath := formatPath(nf)
-				noteStart, _ := fs.Resolve(note.Span)
-				fmt.Fprintf( //nolint:errcheck
-					w,
-					"  %s: %s:%d:%d: %s\n",
-					infoColor.Sprint("note"),
-					pathColor.Sprint(notePath),
-					noteStart.Line,
-					noteStart.Col,
-					note.Msg,
-				)
+				noteStart, noteEnd := fs.Resolve(note.Span)
+				noteWidth := runewidth.StringWidth(fmt.Sprintf("  note: %s:%d:%d: ", notePath, noteStart.Line, noteStart.Col))
+				noteLines := wrappedLines(noteWidth, note.Msg, width, "      ")
+				noteHeader := fmt.Sprintf("  %s: %s:", infoColor.Sprint("note"), formatLocation(nf, notePath, noteStart))
+				if len(noteLines) == 1 || noteLines[0] != "" {
+					noteHeader += " " + noteLines[0]
+				}
+				fmt.Fprintln(w, noteHeader) //nolint:errcheck
+				for _, line := range noteLines[1:] {
+					fmt.Fprintln(w, line) //nolint:errcheck
+				}
+				// Контекст основного файла уже выведен выше; для заметки из
+				// другого файла показываем её строку, иначе место не видно.
+				if note.Span.File != d.Primary.File {
+					writeNoteSnippet(w, nf, noteStart, noteEnd, width, pal)
+				}
 			}
 		}
 
@@ -442,3 +477,41 @@ func printTimingNote(w io.Writer, payload string, infoColor *color.Color) bool {
 	}
 	return true
 }
+
+// writeNoteSnippet печатает строку заметки из другого файла с подчёркиванием.
+// Многострочный span подчёркивается до конца первой строки.
+func writeNoteSnippet(w io.Writer, f *source.File, start, end source.LineCol, width int, pal palette) {
+	const tabWidth = 8
+	lineText := f.GetLine(start.Line)
+	lineNumStr := fmt.Sprintf("%*d", max(len(fmt.Sprintf("%d", start.Line)), 3), start.Line)
+	// Отступ под "  note: " + номер строки + " | "
+	gutterLen := 4 + len(lineNumStr) + 3
+
+	endCol := end.Col
+	if end.Line != start.Line {
+		lenLineText, err := safecast.Conv[uint32](len(lineText))
+		if err != nil {
+			panic(fmt.Errorf("len line text overflow: %w", err))
+		}
+		endCol = lenLineText + 1
+	}
+	visualStart := visualWidthUpTo(lineText, start.Col, tabWidth)
+	visualEnd := max(visualWidthUpTo(lineText, endCol, tabWidth), visualStart)
+
+	shown := lineText
+	if width > 0 {
+		window := clipSourceLine(lineText, width-gutterLen, visualStart, visualEnd, tabWidth)
+		shown = window.text
+		visualStart = window.column(visualStart)
+		visualEnd = window.column(visualEnd)
+	}
+	fmt.Fprintf(w, "    %s | %s\n", pal.lineNum.Sprint(lineNumStr), shown) //nolint:errcheck
+
+	var underline strings.Builder
+	underline.WriteString(strings.Repeat(" ", gutterLen+visualStart))
+	if spanLen := visualEnd - visualStart; spanLen > 1 {
+		underline.WriteString(strings.Repeat("~", spanLen-1))
+	}
+	underline.WriteByte('^')
+	fmt.Fprintln(w, pal.note.Sprint(underline.String())) //nolint:errcheck
+}
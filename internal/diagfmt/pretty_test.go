@@ -262,3 +262,36 @@ func TestPrettyFixPreview(t *testing.T) {
 		t.Fatalf("expected after line in preview, got:\n%s", output)
 	}
 }
+
+// TestPrettyHyperlinks проверяет, что OSC 8 ссылки выводятся только при включённой опции
+func TestPrettyHyperlinks(t *testing.T) {
+	fs := source.NewFileSet()
+	fileID := fs.Add("/home/user/project/src/main.sg", []byte("let x = 1\nlet y = 2\n"), 0)
+
+	bag := diag.NewBag(10)
+	bag.Add(diag.New(diag.SevError, diag.SemaError, source.Span{File: fileID, Start: 14, End: 15}, "boom"))
+
+	render := func(enabled bool) string {
+		var buf bytes.Buffer
+		Pretty(&buf, bag, fs, PrettyOpts{
+			Context:    1,
+			PathMode:   PathModeAbsolute,
+			Hyperlinks: enabled,
+		})
+		return buf.String()
+	}
+
+	withLinks := render(true)
+	wantLink := "\x1b]8;;file:///home/user/project/src/main.sg#2:5\x1b\\/home/user/project/src/main.sg:2:5\x1b]8;;\x1b\\"
+	if !strings.Contains(withLinks, wantLink) {
+		t.Fatalf("expected OSC 8 hyperlink %q in output, got:\n%q", wantLink, withLinks)
+	}
+
+	withoutLinks := render(false)
+	if strings.Contains(withoutLinks, "\x1b]8;;") {
+		t.Fatalf("unexpected OSC 8 sequence when hyperlinks are disabled:\n%q", withoutLinks)
+	}
+	if !strings.Contains(withoutLinks, "/home/user/project/src/main.sg:2:5: ERROR") {
+		t.Fatalf("expected plain location in output, got:\n%s", withoutLinks)
+	}
+}
@@ -0,0 +1,132 @@
+package diagfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+// crossFileBag строит диагностику в b.sg с заметкой, указывающей в a.sg.
+func crossFileBag(t *testing.T) (*source.FileSet, *diag.Bag) {
+	t.Helper()
+	fs := source.NewFileSet()
+	fileA := fs.AddVirtual("mod/a.sg", []byte("pragma module;\n\npub fn helper() -> int { return 1; }\n"))
+	fileB := fs.AddVirtual("mod/b.sg", []byte("pragma module;\npub fn helper() -> int { return 2; }\n"))
+
+	bag := diag.NewBag(4)
+	d := diag.New(diag.SevError, diag.SemaDuplicateSymbol,
+		source.Span{File: fileB, Start: 22, End: 28}, "duplicate declaration of 'helper'")
+	d = d.WithNote(source.Span{File: fileA, Start: 23, End: 29}, "previous declaration here")
+	bag.Add(d)
+	return fs, bag
+}
+
+func TestPrettyNoteInAnotherFile(t *testing.T) {
+	fs, bag := crossFileBag(t)
+	var buf bytes.Buffer
+	Pretty(&buf, bag, fs, PrettyOpts{Context: 1, PathMode: PathModeAuto, ShowNotes: true})
+	out := buf.String()
+
+	want := "  note: mod/a.sg:3:8: previous declaration here\n" +
+		"      3 | pub fn helper() -> int { return 1; }\n" +
+		"                 ~~~~~^\n"
+	if !strings.Contains(out, want) {
+		t.Fatalf("expected cross-file note snippet:\n%s\ngot:\n%s", want, out)
+	}
+	if !strings.HasPrefix(out, "mod/b.sg:2:8: ERROR SEM") {
+		t.Fatalf("primary location should stay in b.sg, got:\n%s", out)
+	}
+}
+
+func TestPrettyNoteSameFileHasNoSnippet(t *testing.T) {
+	fs := source.NewFileSet()
+	file := fs.AddVirtual("one.sg", []byte("let a = 1;\nlet a = 2;\n"))
+	bag := diag.NewBag(4)
+	d := diag.New(diag.SevError, diag.SemaDuplicateSymbol, source.Span{File: file, Start: 15, End: 16}, "duplicate declaration of 'a'")
+	bag.Add(d.WithNote(source.Span{File: file, Start: 4, End: 5}, "previous declaration here"))
+
+	var buf bytes.Buffer
+	Pretty(&buf, bag, fs, PrettyOpts{Context: 1, PathMode: PathModeAuto, ShowNotes: true})
+	out := buf.String()
+	if !strings.HasSuffix(out, "  note: one.sg:1:5: previous declaration here\n") {
+		t.Fatalf("same-file note should be a single line, got:\n%s", out)
+	}
+}
+
+func TestPrettyNoteUnknownFile(t *testing.T) {
+	fs, bag := crossFileBag(t)
+	bag.Items()[0].Notes[0].Span.File = 42
+
+	var buf bytes.Buffer
+	Pretty(&buf, bag, fs, PrettyOpts{Context: 1, PathMode: PathModeAuto, ShowNotes: true})
+	if !strings.Contains(buf.String(), "  note: previous declaration here\n") {
+		t.Fatalf("note with unknown file should print message only, got:\n%s", buf.String())
+	}
+}
+
+func TestJSONNoteInAnotherFile(t *testing.T) {
+	fs, bag := crossFileBag(t)
+	var buf bytes.Buffer
+	if err := JSON(&buf, bag, fs, JSONOpts{IncludePositions: true, PathMode: PathModeAuto, IncludeNotes: true}, nil); err != nil {
+		t.Fatalf("JSON() error: %v", err)
+	}
+	var output DiagnosticsOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	d := output.Diagnostics[0]
+	if d.Location.File != "mod/b.sg" || len(d.Notes) != 1 {
+		t.Fatalf("unexpected diagnostic: %+v", d)
+	}
+	note := d.Notes[0].Location
+	if note.File != "mod/a.sg" || note.StartLine != 3 || note.StartCol != 8 {
+		t.Fatalf("note should resolve in a.sg at 3:8, got %+v", note)
+	}
+}
+
+func TestSarifRelatedLocationInAnotherFile(t *testing.T) {
+	fs, bag := crossFileBag(t)
+	var buf bytes.Buffer
+	meta := SarifRunMeta{ToolName: "surge", ToolVersion: "test", InvocationArgs: []string{"diag", "mod"}}
+	if err := Sarif(&buf, bag, fs, meta); err != nil {
+		t.Fatalf("Sarif() error: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF output: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: %+v", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "surge" || len(run.Tool.Driver.Rules) != 1 || len(run.Invocations) != 1 {
+		t.Fatalf("unexpected SARIF run metadata: %+v", run)
+	}
+	if len(run.Results) != 1 {
+		t.Fatalf("expected one result, got %d", len(run.Results))
+	}
+	res := run.Results[0]
+	if res.Level != "error" || res.RuleID != diag.SemaDuplicateSymbol.ID() {
+		t.Fatalf("unexpected result header: %+v", res)
+	}
+	if len(res.Locations) != 1 || res.Locations[0].PhysicalLocation.ArtifactLocation.URI != "mod/b.sg" {
+		t.Fatalf("primary location should be b.sg, got %+v", res.Locations)
+	}
+	if len(res.RelatedLocations) != 1 {
+		t.Fatalf("expected one related location, got %+v", res.RelatedLocations)
+	}
+	rel := res.RelatedLocations[0]
+	if rel.PhysicalLocation == nil || rel.PhysicalLocation.ArtifactLocation.URI != "mod/a.sg" {
+		t.Fatalf("related location should point into a.sg, got %+v", rel.PhysicalLocation)
+	}
+	if r := rel.PhysicalLocation.Region; r.StartLine != 3 || r.StartColumn != 8 || r.EndColumn != 14 {
+		t.Fatalf("unexpected related region: %+v", r)
+	}
+	if rel.Message == nil || rel.Message.Text != "previous declaration here" {
+		t.Fatalf("unexpected related message: %+v", rel.Message)
+	}
+}
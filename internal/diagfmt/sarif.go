@@ -1,16 +1,164 @@
 package diagfmt
 
 import (
+	"encoding/json"
 	"io"
+	"path/filepath"
+
 	"surge/internal/diag"
 	"surge/internal/source"
 )
 
-// Sarif форматирует диагностики в SARIF формат (v2.1.0)
-func Sarif(w io.Writer, bag *diag.Bag, fs *source.FileSet, meta SarifRunMeta) {
-	// TODO: реализовать SARIF форматирование
-	_ = w
-	_ = bag
-	_ = fs
-	_ = meta
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations,omitempty"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifInvocation struct {
+	Arguments           []string `json:"arguments,omitempty"`
+	ExecutionSuccessful bool     `json:"executionSuccessful"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID           string          `json:"ruleId"`
+	Level            string          `json:"level"`
+	Message          sarifMessage    `json:"message"`
+	Locations        []sarifLocation `json:"locations,omitempty"`
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               int                    `json:"id,omitempty"`
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	Message          *sarifMessage          `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   uint32 `json:"startLine"`
+	StartColumn uint32 `json:"startColumn"`
+	EndLine     uint32 `json:"endLine"`
+	EndColumn   uint32 `json:"endColumn"`
+}
+
+// Sarif форматирует диагностики в SARIF формат (v2.1.0).
+// Заметки (Notes) становятся relatedLocations; файл каждой заметки
+// берётся из её Span, поэтому она может указывать в другой модуль.
+func Sarif(w io.Writer, bag *diag.Bag, fs *source.FileSet, meta SarifRunMeta) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:    meta.ToolName,
+			Version: meta.ToolVersion,
+		}},
+		Results: []sarifResult{},
+	}
+	if len(meta.InvocationArgs) > 0 {
+		run.Invocations = []sarifInvocation{{
+			Arguments:           meta.InvocationArgs,
+			ExecutionSuccessful: !bag.HasErrors(),
+		}}
+	}
+
+	seenRules := make(map[diag.Code]struct{})
+	for _, d := range bag.Items() {
+		if _, ok := seenRules[d.Code]; !ok {
+			seenRules[d.Code] = struct{}{}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               d.Code.ID(),
+				ShortDescription: sarifMessage{Text: d.Code.Title()},
+			})
+		}
+		res := sarifResult{
+			RuleID:  d.Code.ID(),
+			Level:   sarifLevel(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+		}
+		if loc := sarifPhysical(d.Primary, fs); loc != nil {
+			res.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		for i, note := range d.Notes {
+			res.RelatedLocations = append(res.RelatedLocations, sarifLocation{
+				ID:               i + 1,
+				PhysicalLocation: sarifPhysical(note.Span, fs),
+				Message:          &sarifMessage{Text: note.Msg},
+			})
+		}
+		run.Results = append(run.Results, res)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifPhysical переводит span в SARIF-локацию; nil, если файл неизвестен fs.
+func sarifPhysical(span source.Span, fs *source.FileSet) *sarifPhysicalLocation {
+	if fs == nil || !fs.HasFile(span.File) {
+		return nil
+	}
+	f := fs.Get(span.File)
+	start, end := fs.Resolve(span)
+	return &sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(f.Path)},
+		Region: sarifRegion{
+			StartLine:   start.Line,
+			StartColumn: start.Col,
+			EndLine:     end.Line,
+			EndColumn:   end.Col,
+		},
+	}
+}
+
+func sarifLevel(sev diag.Severity) string {
+	switch sev {
+	case diag.SevError:
+		return "error"
+	case diag.SevWarning:
+		return "warning"
+	default:
+		return "note"
+	}
 }
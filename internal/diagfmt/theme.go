@@ -0,0 +1,155 @@
+package diagfmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"surge/internal/diag"
+)
+
+// Theme names a color palette for Pretty output.
+type Theme string
+
+const (
+	// ThemeDark is the default palette tuned for dark terminal backgrounds.
+	ThemeDark Theme = "dark"
+	// ThemeLight avoids white and yellow text that vanish on light backgrounds.
+	ThemeLight Theme = "light"
+	// ThemeMono uses only bold/faint/underline attributes, no hues.
+	ThemeMono Theme = "mono"
+	// ThemeHighContrast uses bright bold colors and underlines spans.
+	ThemeHighContrast Theme = "high-contrast"
+)
+
+// Themes lists the supported palettes in the order they are documented.
+func Themes() []Theme {
+	return []Theme{ThemeDark, ThemeLight, ThemeMono, ThemeHighContrast}
+}
+
+// ParseTheme validates a --color-theme value; an empty name selects ThemeDark.
+func ParseTheme(name string) (Theme, error) {
+	name = strings.TrimSpace(strings.ToLower(name))
+	if name == "" {
+		return ThemeDark, nil
+	}
+	for _, t := range Themes() {
+		if string(t) == name {
+			return t, nil
+		}
+	}
+	names := make([]string, 0, len(Themes()))
+	for _, t := range Themes() {
+		names = append(names, string(t))
+	}
+	return "", fmt.Errorf("unknown color theme %q (expected %s)", name, strings.Join(names, "|"))
+}
+
+// palette maps every styled element of Pretty output to an ANSI style.
+type palette struct {
+	severity     map[diag.Severity]*color.Color
+	underline    map[diag.Severity]*color.Color
+	path         *color.Color
+	code         *color.Color
+	lineNum      *color.Color
+	note         *color.Color
+	previewLabel *color.Color
+	before       *color.Color
+	after        *color.Color
+}
+
+func bySeverity(errC, warnC, infoC *color.Color) map[diag.Severity]*color.Color {
+	return map[diag.Severity]*color.Color{
+		diag.SevError:   errC,
+		diag.SevWarning: warnC,
+		diag.SevInfo:    infoC,
+	}
+}
+
+// palette returns the styles for t; unknown themes fall back to ThemeDark.
+func (t Theme) palette() palette {
+	switch t {
+	case ThemeLight:
+		errC := color.New(color.FgRed, color.Bold)
+		warnC := color.New(color.FgMagenta, color.Bold)
+		infoC := color.New(color.FgBlue, color.Bold)
+		return palette{
+			severity:     bySeverity(errC, warnC, infoC),
+			underline:    bySeverity(errC, warnC, infoC),
+			path:         color.New(color.Bold),
+			code:         color.New(color.FgBlue),
+			lineNum:      color.New(color.FgHiBlack),
+			note:         infoC,
+			previewLabel: infoC,
+			before:       color.New(color.FgRed),
+			after:        color.New(color.FgGreen),
+		}
+	case ThemeMono:
+		errC := color.New(color.Bold, color.Underline)
+		warnC := color.New(color.Bold)
+		infoC := color.New(color.Italic)
+		return palette{
+			severity:     bySeverity(errC, warnC, infoC),
+			underline:    bySeverity(color.New(color.Bold), color.New(color.Bold), color.New(color.Bold)),
+			path:         color.New(color.Bold),
+			code:         color.New(color.Faint),
+			lineNum:      color.New(color.Faint),
+			note:         infoC,
+			previewLabel: color.New(color.Bold),
+			before:       color.New(color.Faint),
+			after:        color.New(color.Bold),
+		}
+	case ThemeHighContrast:
+		errC := color.New(color.FgHiRed, color.Bold)
+		warnC := color.New(color.FgHiYellow, color.Bold)
+		infoC := color.New(color.FgHiCyan, color.Bold)
+		return palette{
+			severity: bySeverity(errC, warnC, infoC),
+			underline: bySeverity(
+				color.New(color.FgHiRed, color.Bold, color.Underline),
+				color.New(color.FgHiYellow, color.Bold, color.Underline),
+				color.New(color.FgHiCyan, color.Bold, color.Underline),
+			),
+			path:         color.New(color.FgHiWhite, color.Bold),
+			code:         color.New(color.FgHiMagenta, color.Bold),
+			lineNum:      color.New(color.FgHiBlue, color.Bold),
+			note:         infoC,
+			previewLabel: infoC,
+			before:       color.New(color.FgHiRed, color.Bold),
+			after:        color.New(color.FgHiGreen, color.Bold),
+		}
+	default:
+		// Исторические цвета: подчёркивание всегда красное, независимо от severity.
+		errC := color.New(color.FgRed, color.Bold)
+		warnC := color.New(color.FgYellow, color.Bold)
+		infoC := color.New(color.FgCyan, color.Bold)
+		underline := color.New(color.FgRed, color.Bold)
+		return palette{
+			severity:     bySeverity(errC, warnC, infoC),
+			underline:    bySeverity(underline, underline, underline),
+			path:         color.New(color.FgWhite, color.Bold),
+			code:         color.New(color.FgMagenta),
+			lineNum:      color.New(color.FgBlue),
+			note:         infoC,
+			previewLabel: color.New(color.FgCyan, color.Bold),
+			before:       color.New(color.FgRed),
+			after:        color.New(color.FgGreen),
+		}
+	}
+}
+
+// severityText colors the severity label; unknown severities stay plain.
+func (p palette) severityText(sev diag.Severity) string {
+	if c := p.severity[sev]; c != nil {
+		return c.Sprint(sev.String())
+	}
+	return sev.String()
+}
+
+func (p palette) underlineText(sev diag.Severity, s string) string {
+	if c := p.underline[sev]; c != nil {
+		return c.Sprint(s)
+	}
+	return p.underline[diag.SevError].Sprint(s)
+}
@@ -0,0 +1,113 @@
+package diagfmt
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func themeFixture() (*diag.Bag, *source.FileSet) {
+	fs := source.NewFileSet()
+	fileID := fs.Add("main.sg", []byte("let x = 1\nlet y = 2\n"), 0)
+
+	bag := diag.NewBag(10)
+	bag.Add(diag.New(diag.SevError, diag.SemaError, source.Span{File: fileID, Start: 4, End: 5}, "boom"))
+	bag.Add(diag.New(diag.SevWarning, diag.SemaError, source.Span{File: fileID, Start: 14, End: 15}, "careful"))
+	return bag, fs
+}
+
+func renderTheme(theme Theme, colored bool) string {
+	bag, fs := themeFixture()
+	var buf bytes.Buffer
+	Pretty(&buf, bag, fs, PrettyOpts{Color: colored, Theme: theme, Context: 1, PathMode: PathModeRelative})
+	return buf.String()
+}
+
+const plainThemeSnapshot = "main.sg:1:5: ERROR SEM3001: boom\n" +
+	"  1 | let x = 1\n" +
+	"          ^\n" +
+	"  2 | let y = 2\n" +
+	"...\n" +
+	"\n" +
+	"main.sg:2:5: WARNING SEM3001: careful\n" +
+	"  1 | let x = 1\n" +
+	"  2 | let y = 2\n" +
+	"          ^\n" +
+	"  3 | \n"
+
+func TestPrettyThemeSnapshots(t *testing.T) {
+	// Для каждой темы: заголовок ошибки, подчёркивание ошибки, заголовок и подчёркивание предупреждения.
+	want := map[Theme][]string{
+		ThemeDark: {
+			"\x1b[37;1mmain.sg\x1b[0;22m:1:5: \x1b[31;1mERROR\x1b[0;22m \x1b[35mSEM3001\x1b[0m: boom",
+			"\x1b[31;1m          ^\x1b[0;22m",
+			"\x1b[37;1mmain.sg\x1b[0;22m:2:5: \x1b[33;1mWARNING\x1b[0;22m \x1b[35mSEM3001\x1b[0m: careful",
+			"\x1b[31;1m          ^\x1b[0;22m",
+		},
+		ThemeLight: {
+			"\x1b[1mmain.sg\x1b[22m:1:5: \x1b[31;1mERROR\x1b[0;22m \x1b[34mSEM3001\x1b[0m: boom",
+			"\x1b[31;1m          ^\x1b[0;22m",
+			"\x1b[1mmain.sg\x1b[22m:2:5: \x1b[35;1mWARNING\x1b[0;22m \x1b[34mSEM3001\x1b[0m: careful",
+			"\x1b[35;1m          ^\x1b[0;22m",
+		},
+		ThemeMono: {
+			"\x1b[1mmain.sg\x1b[22m:1:5: \x1b[1;4mERROR\x1b[22;24m \x1b[2mSEM3001\x1b[22m: boom",
+			"\x1b[1m          ^\x1b[22m",
+			"\x1b[1mmain.sg\x1b[22m:2:5: \x1b[1mWARNING\x1b[22m \x1b[2mSEM3001\x1b[22m: careful",
+			"\x1b[1m          ^\x1b[22m",
+		},
+		ThemeHighContrast: {
+			"\x1b[97;1mmain.sg\x1b[0;22m:1:5: \x1b[91;1mERROR\x1b[0;22m \x1b[95;1mSEM3001\x1b[0;22m: boom",
+			"\x1b[91;1;4m          ^\x1b[0;22;24m",
+			"\x1b[97;1mmain.sg\x1b[0;22m:2:5: \x1b[93;1mWARNING\x1b[0;22m \x1b[95;1mSEM3001\x1b[0;22m: careful",
+			"\x1b[93;1;4m          ^\x1b[0;22;24m",
+		},
+	}
+
+	for _, theme := range Themes() {
+		t.Run(string(theme), func(t *testing.T) {
+			kept := renderTheme(theme, true)
+			lines := strings.Split(kept, "\n")
+			got := []string{lines[0], lines[2], lines[6], lines[9]}
+			for i := range got {
+				if got[i] != want[theme][i] {
+					t.Fatalf("line %d mismatch:\nwant %q\ngot  %q", i, want[theme][i], got[i])
+				}
+			}
+
+			if stripped := ansiEscape.ReplaceAllString(kept, ""); stripped != plainThemeSnapshot {
+				t.Fatalf("stripped output differs from plain snapshot:\n%q", stripped)
+			}
+			if plain := renderTheme(theme, false); plain != plainThemeSnapshot {
+				t.Fatalf("color off output depends on theme %s:\n%q", theme, plain)
+			}
+		})
+	}
+}
+
+func TestPrettyEmptyThemeIsDark(t *testing.T) {
+	if got, want := renderTheme("", true), renderTheme(ThemeDark, true); got != want {
+		t.Fatalf("empty theme differs from dark:\n%q\n%q", got, want)
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	for _, theme := range Themes() {
+		got, err := ParseTheme(string(theme))
+		if err != nil || got != theme {
+			t.Fatalf("ParseTheme(%q) = %q, %v", theme, got, err)
+		}
+	}
+	if got, err := ParseTheme(""); err != nil || got != ThemeDark {
+		t.Fatalf("ParseTheme(\"\") = %q, %v; want dark", got, err)
+	}
+	if _, err := ParseTheme("solarized"); err == nil {
+		t.Fatal("expected error for unknown theme")
+	}
+}
@@ -12,10 +12,18 @@ import (
 
 // TokenOutput represents a token in the JSON output.
 type TokenOutput struct {
-	Kind    string      `json:"kind"`
-	Text    string      `json:"text,omitempty"`
-	Span    source.Span `json:"span"`
-	Leading []string    `json:"leading,omitempty"`
+	Kind     string          `json:"kind"`
+	Text     string          `json:"text,omitempty"`
+	Span     TokenSpanOutput `json:"span"`
+	Leading  []string        `json:"leading,omitempty"`
+	Trailing []string        `json:"trailing,omitempty"`
+}
+
+// TokenSpanOutput is a byte range in the token's file; Text equals
+// source[Start:End].
+type TokenSpanOutput struct {
+	Start uint32 `json:"start"`
+	End   uint32 `json:"end"`
 }
 
 // FormatTokensPretty выводит токены в человекочитаемом формате
@@ -65,30 +73,28 @@ func FormatTokensPretty(w io.Writer, tokens []token.Token, fs *source.FileSet) e
 }
 
 // TokenOutputsJSON готовит токены к сериализации в JSON формате.
+// Лексер прикрепляет все trivia к следующему токену как leading; здесь
+// хвост строки (пробелы и комментарии до перевода строки включительно)
+// переносится в trailing предыдущего токена, как это принято в редакторах.
+// Директивы (///) остаются leading trivia следующего токена.
 func TokenOutputsJSON(tokens []token.Token) []TokenOutput {
 	output := make([]TokenOutput, 0, len(tokens))
-	for _, tok := range tokens {
-		leading := make([]string, 0, len(tok.Leading))
-		for _, trivia := range tok.Leading {
-			leading = append(leading, trivia.Kind.String())
+	for i, tok := range tokens {
+		leading := tok.Leading
+		if i > 0 {
+			split := trailingTriviaLen(leading)
+			if split > 0 {
+				output[i-1].Trailing = triviaKinds(leading[:split])
+			}
+			leading = leading[split:]
 		}
 
-		tokenOut := TokenOutput{
+		output = append(output, TokenOutput{
 			Kind:    tok.Kind.String(),
 			Text:    tok.Text,
-			Span:    tok.Span,
-			Leading: leading,
-		}
-
-		if len(leading) == 0 {
-			tokenOut.Leading = nil // Убираем пустые массивы из JSON
-		}
-
-		if tok.Text == "" {
-			tokenOut.Text = "" // Для consistency
-		}
-
-		output = append(output, tokenOut)
+			Span:    TokenSpanOutput{Start: tok.Span.Start, End: tok.Span.End},
+			Leading: triviaKinds(leading),
+		})
 
 		if tok.Kind == token.EOF {
 			break
@@ -98,6 +104,34 @@ func TokenOutputsJSON(tokens []token.Token) []TokenOutput {
 	return output
 }
 
+// trailingTriviaLen возвращает длину префикса trivia, который завершает строку
+// предыдущего токена: пробелы и комментарии, затем перевод строки.
+// Если перевода строки нет, всё остаётся leading следующего токена.
+func trailingTriviaLen(trivia []token.Trivia) int {
+	for i, tr := range trivia {
+		switch tr.Kind {
+		case token.TriviaSpace, token.TriviaLineComment, token.TriviaBlockComment:
+			continue
+		case token.TriviaNewline:
+			return i + 1
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+func triviaKinds(trivia []token.Trivia) []string {
+	if len(trivia) == 0 {
+		return nil // Убираем пустые массивы из JSON
+	}
+	kinds := make([]string, 0, len(trivia))
+	for _, tr := range trivia {
+		kinds = append(kinds, tr.Kind.String())
+	}
+	return kinds
+}
+
 // FormatTokensJSON выводит токены в JSON формате
 func FormatTokensJSON(w io.Writer, tokens []token.Token) error {
 	output := TokenOutputsJSON(tokens)
@@ -0,0 +1,81 @@
+package diagfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"surge/internal/lexer"
+	"surge/internal/source"
+	"surge/internal/token"
+)
+
+func lexAll(t *testing.T, src string) []token.Token {
+	t.Helper()
+	fs := source.NewFileSet()
+	fileID := fs.AddVirtual("tokens.sg", []byte(src))
+	lx := lexer.New(fs.Get(fileID), lexer.Options{})
+	var tokens []token.Token
+	for {
+		tok := lx.Next()
+		tokens = append(tokens, tok)
+		if tok.Kind == token.EOF {
+			return tokens
+		}
+	}
+}
+
+// TestFormatTokensJSON декодирует JSON и сверяет span известного токена с исходником.
+func TestFormatTokensJSON(t *testing.T) {
+	src := "fn add(a: int) -> int { // sum\n    return a;\n}\n\n/// test:\n/// Add:\n///   test.eq(add(1), 1);\nfn main() {}\n"
+
+	var buf bytes.Buffer
+	if err := FormatTokensJSON(&buf, lexAll(t, src)); err != nil {
+		t.Fatalf("FormatTokensJSON() error: %v", err)
+	}
+	var out []TokenOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(out) == 0 || out[len(out)-1].Kind != "EOF" {
+		t.Fatalf("expected tokens ending with EOF, got %+v", out)
+	}
+
+	for _, tok := range out {
+		if got := src[tok.Span.Start:tok.Span.End]; got != tok.Text {
+			t.Errorf("%s: source[%d:%d] = %q, text %q", tok.Kind, tok.Span.Start, tok.Span.End, got, tok.Text)
+		}
+		if strings.HasPrefix(tok.Text, "//") {
+			t.Errorf("comment or directive leaked into the token stream: %+v", tok)
+		}
+	}
+
+	var ret, brace, mainFn *TokenOutput
+	for i := range out {
+		switch {
+		case out[i].Kind == "KwReturn":
+			ret = &out[i]
+		case out[i].Kind == "LBrace" && brace == nil:
+			brace = &out[i]
+		case out[i].Kind == "KwFn" && out[i].Span.Start > 0:
+			mainFn = &out[i]
+		}
+	}
+	if ret == nil || brace == nil || mainFn == nil {
+		t.Fatalf("expected return, '{' and second fn tokens, got %+v", out)
+	}
+	wantStart := uint32(strings.Index(src, "return")) //nolint:gosec // small test input
+	if ret.Span.Start != wantStart || ret.Span.End != wantStart+uint32(len("return")) || ret.Text != "return" {
+		t.Fatalf("unexpected return token: %+v (want start %d)", *ret, wantStart)
+	}
+	if strings.Join(ret.Leading, ",") != "Space" {
+		t.Errorf("return leading = %v, want [Space]", ret.Leading)
+	}
+	if strings.Join(brace.Trailing, ",") != "Space,LineComment,Newline" {
+		t.Errorf("'{' trailing = %v, want [Space LineComment Newline]", brace.Trailing)
+	}
+	if len(mainFn.Leading) == 0 || mainFn.Leading[0] != "DocLine" {
+		t.Errorf("directive lines should lead the next fn as trivia, got %v", mainFn.Leading)
+	}
+}
@@ -0,0 +1,197 @@
+package diagfmt
+
+import (
+	"os"
+	"strings"
+
+	"fortio.org/safecast"
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// ellipsis помечает обрезанную часть строки исходника.
+const ellipsis = "…"
+
+// minSourceBudget — минимальная ширина видимого фрагмента строки исходника,
+// даже если gutter почти не оставляет места.
+const minSourceBudget = 8
+
+// minMessageBudget — если после префикса заголовка остаётся меньше колонок,
+// сообщение целиком переносится на следующие строки.
+const minMessageBudget = 20
+
+// TerminalWidth возвращает ширину терминала f в колонках или 0, если f не терминал.
+func TerminalWidth(f *os.File) int {
+	fd, err := safecast.Conv[int](f.Fd())
+	if err != nil || !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil || width <= 0 {
+		return 0
+	}
+	return width
+}
+
+// wrapText переносит текст по словам: первая строка не шире first колонок,
+// остальные — не шире rest. Слова длиннее строки режутся по ширине символов.
+func wrapText(s string, first, rest int) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	var (
+		lines []string
+		cur   strings.Builder
+		curW  int
+	)
+	limit := first
+	flush := func() {
+		lines = append(lines, cur.String())
+		cur.Reset()
+		curW = 0
+		limit = rest
+	}
+	for _, word := range words {
+		ww := runewidth.StringWidth(word)
+		if curW > 0 && curW+1+ww <= limit {
+			cur.WriteByte(' ')
+			cur.WriteString(word)
+			curW += 1 + ww
+			continue
+		}
+		if curW > 0 {
+			flush()
+		}
+		for ww > limit {
+			head := runewidth.Truncate(word, max(limit, 1), "")
+			if head == "" {
+				// Символ шире лимита: выводим его отдельной строкой.
+				r := []rune(word)
+				head = string(r[0])
+			}
+			cur.WriteString(head)
+			flush()
+			word = word[len(head):]
+			ww = runewidth.StringWidth(word)
+		}
+		cur.WriteString(word)
+		curW = ww
+	}
+	if curW > 0 || len(lines) == 0 {
+		lines = append(lines, cur.String())
+	}
+	return lines
+}
+
+// wrappedLines раскладывает message после префикса шириной prefixWidth так,
+// чтобы строки не превышали width. Продолжения начинаются с indent.
+// Первый элемент дописывается к префиксу; пустой — сообщение начинается со следующей строки.
+func wrappedLines(prefixWidth int, message string, width int, indent string) []string {
+	if width <= 0 || prefixWidth+runewidth.StringWidth(message) <= width {
+		return []string{message}
+	}
+	rest := max(width-len(indent), 1)
+	first := width - prefixWidth
+	if first < minMessageBudget {
+		lines := wrapText(message, rest, rest)
+		for i := range lines {
+			lines[i] = indent + lines[i]
+		}
+		return append([]string{""}, lines...)
+	}
+	lines := wrapText(message, first, rest)
+	for i := 1; i < len(lines); i++ {
+		lines[i] = indent + lines[i]
+	}
+	return lines
+}
+
+// sourceWindow — видимый фрагмент строки исходника, обрезанный по ширине вывода.
+// Колонки визуальные: табы раскрыты, широкие символы занимают 2 колонки.
+type sourceWindow struct {
+	text string // фрагмент с многоточиями, табы заменены пробелами
+	lo   int    // первая видимая колонка исходной строки
+	hi   int    // колонка сразу за последней видимой
+	left int    // ширина левого многоточия
+}
+
+// column переводит визуальную колонку исходной строки в колонку внутри text.
+func (w sourceWindow) column(col int) int {
+	col = min(max(col, w.lo), w.hi)
+	return col - w.lo + w.left
+}
+
+type sourceCell struct {
+	text  string
+	col   int
+	width int
+}
+
+// clipSourceLine раскрывает табы и, если строка шире budget, вырезает окно
+// вокруг визуального диапазона [start, end), заменяя скрытые края на "…".
+func clipSourceLine(line string, budget, start, end, tabWidth int) sourceWindow {
+	budget = max(budget, minSourceBudget)
+	var cells []sourceCell
+	pos := 0
+	for _, r := range line {
+		if r == '\t' {
+			next := (pos + tabWidth) / tabWidth * tabWidth
+			for ; pos < next; pos++ {
+				cells = append(cells, sourceCell{text: " ", col: pos, width: 1})
+			}
+			continue
+		}
+		rw := runewidth.RuneWidth(r)
+		cells = append(cells, sourceCell{text: string(r), col: pos, width: rw})
+		pos += rw
+	}
+	total := pos
+
+	lo, hi, left := 0, total, 0
+	if total > budget {
+		if end > budget-1 {
+			inner := budget - 2
+			margin := 0
+			if span := end - start; span < inner {
+				margin = (inner - span) / 2
+			}
+			lo = max(start-margin, 0)
+		}
+		if lo == 0 {
+			hi = budget - 1
+		} else {
+			left = len([]rune(ellipsis))
+			hi = lo + budget - 2
+		}
+		if hi >= total {
+			hi = total
+			lo = total - (budget - 1)
+			left = len([]rune(ellipsis))
+		}
+	}
+
+	var b strings.Builder
+	if left > 0 {
+		b.WriteString(ellipsis)
+	}
+	cursor := lo
+	for _, c := range cells {
+		if c.col < lo || c.col+c.width > hi {
+			continue
+		}
+		// Широкий символ на границе окна не помещается — закрываем дыру пробелами.
+		for ; cursor < c.col; cursor++ {
+			b.WriteByte(' ')
+		}
+		b.WriteString(c.text)
+		cursor = c.col + c.width
+	}
+	if hi < total {
+		for ; cursor < hi; cursor++ {
+			b.WriteByte(' ')
+		}
+		b.WriteString(ellipsis)
+	}
+	return sourceWindow{text: b.String(), lo: lo, hi: hi, left: left}
+}
@@ -0,0 +1,110 @@
+package diagfmt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+const wrapFixtureLine = "\tlet x: int = \"日本語日本語日本語日本語日本語日本語日本語日本語日本語日本語日本語日本語\"; return missing_function_name(1);"
+
+func renderWidth(t *testing.T, width uint16) string {
+	t.Helper()
+	content := "fn main() -> int {\n" + wrapFixtureLine + "\n}\n"
+	fs := source.NewFileSet()
+	fileID := fs.Add("main.sg", []byte(content), 0)
+
+	start := strings.Index(content, "missing_function_name")
+	span := source.Span{File: fileID, Start: mustUint32(t, start), End: mustUint32(t, start+len("missing_function_name"))}
+	bag := diag.NewBag(10)
+	bag.Add(diag.New(diag.SevError, diag.SemaError, span,
+		"cannot resolve 'missing_function_name' because no declaration with this name is visible in the current scope"))
+
+	var buf bytes.Buffer
+	Pretty(&buf, bag, fs, PrettyOpts{Context: 1, PathMode: PathModeRelative, Width: width})
+	return buf.String()
+}
+
+// checkCaretAligned проверяет, что подчёркивание начинается под идентификатором
+// в видимом фрагменте строки и что все строки укладываются в width.
+func checkCaretAligned(t *testing.T, out string, width int) {
+	t.Helper()
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	for _, line := range lines {
+		if w := runewidth.StringWidth(line); w > width {
+			t.Fatalf("line wider than %d (%d): %q\n%s", width, w, line, out)
+		}
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "  2 | ") {
+			continue
+		}
+		if !strings.Contains(line, ellipsis) {
+			t.Fatalf("expected clipped source line, got %q", line)
+		}
+		idx := strings.Index(line, "missing_")
+		if idx < 0 {
+			t.Fatalf("identifier scrolled out of view: %q", line)
+		}
+		wantCol := runewidth.StringWidth(line[:idx])
+		underline := lines[i+1]
+		gotCol := len(underline) - len(strings.TrimLeft(underline, " "))
+		if gotCol != wantCol {
+			t.Fatalf("caret at column %d, want %d:\n%s\n%s", gotCol, wantCol, line, underline)
+		}
+		return
+	}
+	t.Fatalf("source line not found:\n%s", out)
+}
+
+func TestPrettyWidth40(t *testing.T) {
+	out := renderWidth(t, 40)
+	checkCaretAligned(t, out, 40)
+
+	lines := strings.Split(out, "\n")
+	if lines[0] != "main.sg:2:134: ERROR SEM3001:" {
+		t.Fatalf("expected message moved off the narrow header line, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "    cannot resolve") {
+		t.Fatalf("expected indented continuation, got %q", lines[1])
+	}
+}
+
+func TestPrettyWidth120(t *testing.T) {
+	out := renderWidth(t, 120)
+	checkCaretAligned(t, out, 120)
+
+	lines := strings.Split(out, "\n")
+	if !strings.HasPrefix(lines[0], "main.sg:2:134: ERROR SEM3001: cannot resolve 'missing_function_name' because") {
+		t.Fatalf("expected message to start on the header line, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "    ") {
+		t.Fatalf("expected wrapped continuation, got %q", lines[1])
+	}
+}
+
+func TestPrettyWidthZeroKeepsLines(t *testing.T) {
+	out := renderWidth(t, 0)
+	if strings.Contains(out, ellipsis) {
+		t.Fatalf("unexpected clipping without width:\n%s", out)
+	}
+	if !strings.Contains(out, "  2 | "+wrapFixtureLine+"\n") {
+		t.Fatalf("source line changed without width:\n%s", out)
+	}
+}
+
+func TestClipSourceLineWideRuneBoundary(t *testing.T) {
+	// Окно начинается посреди широкого символа: вместо половины символа — пробел.
+	win := clipSourceLine("日本語日本語abcdef", 8, 13, 15, 8)
+	if win.text != "… abcde…" {
+		t.Fatalf("unexpected window %q (lo=%d)", win.text, win.lo)
+	}
+	if got, want := win.column(13), runewidth.StringWidth(win.text[:strings.Index(win.text, "b")]); got != want {
+		t.Fatalf("column(13) = %d, want %d", got, want)
+	}
+}
@@ -23,6 +23,7 @@ var keywordSignals = map[string][]keywordSignal{
 	"dyn":         {{Dialect: Rust, Score: 4, Reason: "rust keyword `dyn`"}},
 	"ref":         {{Dialect: Rust, Score: 1, Reason: "rust keyword `ref`"}},
 	"struct":      {{Dialect: Rust, Score: 2, Reason: "rust keyword `struct`"}},
+	"Box":         {{Dialect: Rust, Score: 3, Reason: "rust type `Box`"}},
 	// "enum" is a Surge keyword too; treat it as low-signal.
 	"enum": {{Dialect: Rust, Score: 1, Reason: "rust keyword `enum`"}},
 
@@ -0,0 +1,169 @@
+package directive
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"surge/internal/ast"
+	"surge/internal/source"
+	"surge/internal/symbols"
+)
+
+// HarnessEntry is the entrypoint of a generated test harness. It takes the
+// index of the scenario to run as its only argv argument and returns the
+// scenario's exit code.
+const HarnessEntry = "__surge_directive_main"
+
+// Exit codes of a harness scenario. A failed or skipped call reports its line
+// index on top of the base, so the runner can point at the directive line.
+const (
+	harnessFailBase = 1000
+	harnessSkipBase = 2000
+	harnessMaxLines = harnessSkipBase - harnessFailBase
+)
+
+// Harness is a generated program that executes the directive scenarios of
+// one source file. Source is the original file with its @entrypoint
+// attributes blanked out (byte offsets stay the same) followed by one
+// function per scenario and the HarnessEntry dispatcher.
+type Harness struct {
+	Source    string
+	Scenarios []Scenario
+	file      string
+	src       []byte
+}
+
+// BuildHarness generates the harness for scenarios collected from fileID.
+// Lines of the `test` namespace are expanded into checks (eq, ne, assert,
+// assert_msg, fail, skip); lines of other namespaces are emitted as calls.
+func BuildHarness(builder *ast.Builder, fileID ast.FileID, src []byte, scenarios []Scenario) (*Harness, error) {
+	out := append([]byte(nil), src...)
+	for _, span := range entrypointAttrSpans(builder, fileID) {
+		blankSpan(out, span)
+	}
+	h := &Harness{Scenarios: scenarios, src: src}
+	if len(scenarios) > 0 {
+		h.file = filepath.Base(scenarios[0].SourceFile)
+	}
+
+	var b strings.Builder
+	b.Write(out)
+	if len(out) > 0 && out[len(out)-1] != '\n' {
+		b.WriteByte('\n')
+	}
+	b.WriteString("\n// Generated by `surge test`.\n")
+	for i := range scenarios {
+		if err := writeScenarioFunc(&b, &scenarios[i]); err != nil {
+			return nil, err
+		}
+	}
+	fmt.Fprintf(&b, "\n@entrypoint(\"argv\")\nfn %s(index: int) -> int {\n", HarnessEntry)
+	for i := range scenarios {
+		fmt.Fprintf(&b, "    if index == %d {\n        return %s();\n    }\n", i, scenarios[i].FunctionName)
+	}
+	b.WriteString("    return 1;\n}\n")
+	h.Source = b.String()
+	return h, nil
+}
+
+// Outcome decodes the exit code of the scenario with the given index.
+func (h *Harness) Outcome(index, exitCode int) Outcome {
+	if exitCode == 0 {
+		return Outcome{Status: StatusPassed}
+	}
+	if index < 0 || index >= len(h.Scenarios) {
+		return Outcome{Status: StatusFailed, Message: fmt.Sprintf("unknown scenario #%d", index)}
+	}
+	lines := h.Scenarios[index].Lines
+	switch {
+	case exitCode >= harnessFailBase && exitCode-harnessFailBase < len(lines):
+		return Outcome{Status: StatusFailed, Message: h.lineLabel(lines[exitCode-harnessFailBase])}
+	case exitCode >= harnessSkipBase && exitCode-harnessSkipBase < len(lines):
+		return Outcome{Status: StatusSkipped, Message: h.lineLabel(lines[exitCode-harnessSkipBase])}
+	}
+	return Outcome{Status: StatusFailed, Message: fmt.Sprintf("exited with code %d", exitCode)}
+}
+
+// lineLabel formats a directive line as `file.sg:LINE: text`.
+func (h *Harness) lineLabel(line Line) string {
+	start := min(int(line.Span.Start), len(h.src))
+	lineNo := bytes.Count(h.src[:start], []byte{'\n'}) + 1
+	return fmt.Sprintf("%s:%d: %s", h.file, lineNo, line.Text)
+}
+
+func writeScenarioFunc(b *strings.Builder, s *Scenario) error {
+	if len(s.Lines) > harnessMaxLines {
+		return fmt.Errorf("directive block %s has %d lines, at most %d are supported", formatLocation(s), len(s.Lines), harnessMaxLines)
+	}
+	fmt.Fprintf(b, "\nfn %s() -> int {\n", s.FunctionName)
+	for i, line := range s.Lines {
+		call, ok := symbols.ParseDirectiveCall(line.Text)
+		if !ok {
+			return fmt.Errorf("%s: malformed directive call %q", formatLocation(s), line.Text)
+		}
+		if call.Namespace != s.Namespace || s.Namespace != "test" {
+			fmt.Fprintf(b, "    %s;\n", strings.TrimSuffix(strings.TrimSpace(line.Text), ";"))
+			continue
+		}
+		fail := fmt.Sprintf("return %d;", harnessFailBase+i)
+		switch {
+		case call.Name == "eq" && len(call.Args) == 2:
+			fmt.Fprintf(b, "    if !((%s) == (%s)) {\n        %s\n    }\n", call.Args[0], call.Args[1], fail)
+		case call.Name == "ne" && len(call.Args) == 2:
+			// `if (` разбирается как условие в скобках, поэтому условие начинается с `!`.
+			fmt.Fprintf(b, "    if !((%s) != (%s)) {\n        %s\n    }\n", call.Args[0], call.Args[1], fail)
+		case (call.Name == "assert" && len(call.Args) == 1) || (call.Name == "assert_msg" && len(call.Args) == 2):
+			fmt.Fprintf(b, "    if !(%s) {\n        %s\n    }\n", call.Args[0], fail)
+		case call.Name == "fail":
+			fmt.Fprintf(b, "    %s\n", fail)
+		case call.Name == "skip":
+			fmt.Fprintf(b, "    return %d;\n", harnessSkipBase+i)
+		default:
+			fmt.Fprintf(b, "    %s;\n", strings.TrimSuffix(strings.TrimSpace(line.Text), ";"))
+		}
+		if call.Name == "fail" || call.Name == "skip" {
+			// Остаток блока недостижим: не генерируем код после return.
+			b.WriteString("}\n")
+			return nil
+		}
+	}
+	b.WriteString("    return 0;\n}\n")
+	return nil
+}
+
+// entrypointAttrSpans returns the spans of the @entrypoint attributes of the
+// file's functions. The harness replaces them with its own entrypoint.
+func entrypointAttrSpans(builder *ast.Builder, fileID ast.FileID) []source.Span {
+	if builder == nil {
+		return nil
+	}
+	file := builder.Files.Get(fileID)
+	if file == nil {
+		return nil
+	}
+	var spans []source.Span
+	for _, itemID := range file.Items {
+		fn, ok := builder.Items.Fn(itemID)
+		if !ok || fn == nil {
+			continue
+		}
+		for _, attr := range builder.Items.CollectAttrs(fn.AttrStart, fn.AttrCount) {
+			if name, ok := builder.StringsInterner.Lookup(attr.Name); ok && name == "entrypoint" {
+				spans = append(spans, attr.Span)
+			}
+		}
+	}
+	return spans
+}
+
+// blankSpan replaces the bytes of span with spaces, keeping line breaks.
+func blankSpan(buf []byte, span source.Span) {
+	end := min(int(span.End), len(buf))
+	for i := int(span.Start); i < end; i++ {
+		if buf[i] != '\n' {
+			buf[i] = ' '
+		}
+	}
+}
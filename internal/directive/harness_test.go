@@ -0,0 +1,89 @@
+package directive
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/source"
+)
+
+func TestBuildHarness_ExpandsTestCalls(t *testing.T) {
+	src := []byte("fn add(a: int, b: int) -> int { return a + b; }\n")
+	scenario := Scenario{
+		Namespace:    "test",
+		SourceFile:   "/tmp/calc.sg",
+		FunctionName: "__directive_test_0__",
+		Lines: []Line{
+			{Text: "test.eq(add(1, 2), 3)"},
+			{Text: "test.ne(add(1, 1), 3)"},
+			{Text: "test.assert_msg(add(0, 0) == 0, \"zero, really\")"},
+		},
+	}
+	h, err := BuildHarness(nil, 0, src, []Scenario{scenario})
+	if err != nil {
+		t.Fatalf("BuildHarness: %v", err)
+	}
+	for _, want := range []string{
+		"if !((add(1, 2)) == (3)) {\n        return 1000;",
+		"if !((add(1, 1)) != (3)) {\n        return 1001;",
+		"if !(add(0, 0) == 0) {\n        return 1002;",
+		"@entrypoint(\"argv\")\nfn " + HarnessEntry + "(index: int) -> int",
+		"return __directive_test_0__();",
+	} {
+		if !strings.Contains(h.Source, want) {
+			t.Errorf("expected %q in harness:\n%s", want, h.Source)
+		}
+	}
+	if !strings.HasPrefix(h.Source, string(src)) {
+		t.Errorf("harness must start with the original source:\n%s", h.Source)
+	}
+}
+
+func TestBuildHarness_SkipEndsScenario(t *testing.T) {
+	scenario := Scenario{
+		Namespace:    "test",
+		FunctionName: "__directive_test_0__",
+		Lines: []Line{
+			{Text: "test.skip(\"later\")"},
+			{Text: "test.eq(1, 2)"},
+		},
+	}
+	h, err := BuildHarness(nil, 0, nil, []Scenario{scenario})
+	if err != nil {
+		t.Fatalf("BuildHarness: %v", err)
+	}
+	if !strings.Contains(h.Source, "fn __directive_test_0__() -> int {\n    return 2000;\n}\n") {
+		t.Errorf("skip must end the scenario:\n%s", h.Source)
+	}
+}
+
+func TestHarness_Outcome(t *testing.T) {
+	src := []byte("import x;\n/// test:\n/// test.eq(1, 2)\n/// test.skip(\"no\")\n")
+	line1 := strings.Index(string(src), "test.eq")
+	line2 := strings.Index(string(src), "test.skip")
+	h := &Harness{
+		Scenarios: []Scenario{{
+			Namespace: "test",
+			Lines: []Line{
+				{Text: "test.eq(1, 2)", Span: source.Span{Start: uint32(line1), End: uint32(line1 + 13)}},
+				{Text: "test.skip(\"no\")", Span: source.Span{Start: uint32(line2), End: uint32(line2 + 15)}},
+			},
+		}},
+		file: "a.sg",
+		src:  src,
+	}
+	tests := []struct {
+		exitCode int
+		want     Outcome
+	}{
+		{0, Outcome{Status: StatusPassed}},
+		{1000, Outcome{Status: StatusFailed, Message: "a.sg:3: test.eq(1, 2)"}},
+		{2001, Outcome{Status: StatusSkipped, Message: "a.sg:4: test.skip(\"no\")"}},
+		{1, Outcome{Status: StatusFailed, Message: "exited with code 1"}},
+	}
+	for _, tc := range tests {
+		if got := h.Outcome(0, tc.exitCode); got != tc.want {
+			t.Errorf("Outcome(0, %d) = %+v, want %+v", tc.exitCode, got, tc.want)
+		}
+	}
+}
@@ -89,12 +89,21 @@ func (r *Registry) CollectFromFile(builder *ast.Builder, fileID ast.FileID, modu
 		idx := namespaceIndex[namespace]
 		namespaceIndex[namespace]++
 
+		lines := make([]Line, 0, len(block.Lines))
+		for _, line := range block.Lines {
+			lines = append(lines, Line{
+				Text: builder.StringsInterner.MustLookup(line.Text),
+				Span: line.Span,
+			})
+		}
+
 		r.Add(&Scenario{
 			Namespace:  namespace,
 			Index:      idx,
 			ModulePath: modulePath,
 			SourceFile: sourceFile,
 			Span:       block.Span,
+			Lines:      lines,
 		})
 	}
 }
@@ -13,6 +13,28 @@ type RunnerConfig struct {
 
 	// Output is where to write execution status.
 	Output io.Writer
+
+	// Exec runs one scenario. When nil, scenarios are only listed as SKIPPED.
+	Exec func(s *Scenario) Outcome
+}
+
+// Status is the result of one executed scenario.
+type Status uint8
+
+const (
+	// StatusPassed means every directive call of the scenario succeeded.
+	StatusPassed Status = iota
+	// StatusFailed means a directive call failed or the scenario crashed.
+	StatusFailed
+	// StatusSkipped means the scenario was not executed to the end.
+	StatusSkipped
+)
+
+// Outcome describes how a scenario finished. Message explains a failure or
+// a skip and is empty for a passed scenario.
+type Outcome struct {
+	Status  Status
+	Message string
 }
 
 // RunResult contains the outcome of running directives.
@@ -23,8 +45,7 @@ type RunResult struct {
 	Failed  int
 }
 
-// Runner executes directive scenarios.
-// Stage 1: This is a stub that only prints scenario names.
+// Runner executes directive scenarios through RunnerConfig.Exec.
 type Runner struct {
 	config   RunnerConfig
 	registry *Registry
@@ -38,8 +59,8 @@ func NewRunner(registry *Registry, config RunnerConfig) *Runner {
 	}
 }
 
-// Run executes (or stubs) all matching scenarios.
-// Stage 1 implementation: prints names and reports SKIPPED.
+// Run executes all matching scenarios and prints a line per scenario and a
+// summary. Without an Exec hook every scenario is reported as SKIPPED.
 func (r *Runner) Run() RunResult {
 	scenarios := r.registry.FilterByNamespace(r.config.Filter)
 
@@ -52,12 +73,24 @@ func (r *Runner) Run() RunResult {
 	for i := range scenarios {
 		s := &scenarios[i]
 		location := formatLocation(s)
-		_, printErr = fmt.Fprintf(r.config.Output, "Running test: %s (%s) ... SKIPPED (execution not implemented)\n",
-			location, s.Namespace)
+		outcome := Outcome{Status: StatusSkipped, Message: "execution not implemented"}
+		if r.config.Exec != nil {
+			outcome = r.config.Exec(s)
+		}
+		switch outcome.Status {
+		case StatusPassed:
+			_, printErr = fmt.Fprintf(r.config.Output, "Running test: %s (%s) ... PASS\n", location, s.Namespace)
+			result.Passed++
+		case StatusFailed:
+			_, printErr = fmt.Fprintf(r.config.Output, "Running test: %s (%s) ... FAIL\n    %s\n", location, s.Namespace, outcome.Message)
+			result.Failed++
+		default:
+			_, printErr = fmt.Fprintf(r.config.Output, "Running test: %s (%s) ... SKIPPED (%s)\n", location, s.Namespace, outcome.Message)
+			result.Skipped++
+		}
 		if printErr != nil {
 			panic(printErr)
 		}
-		result.Skipped++
 	}
 
 	// Print summary
@@ -120,3 +120,43 @@ func TestRunner_Run_NoFilter(t *testing.T) {
 		t.Errorf("expected bench in output: %s", output)
 	}
 }
+
+func TestRunner_Run_Exec(t *testing.T) {
+	r := NewRegistry()
+	r.Add(&Scenario{Namespace: "test", Index: 0, SourceFile: "a.sg"})
+	r.Add(&Scenario{Namespace: "test", Index: 1, SourceFile: "a.sg"})
+	r.Add(&Scenario{Namespace: "test", Index: 2, SourceFile: "a.sg"})
+
+	var buf bytes.Buffer
+	runner := NewRunner(r, RunnerConfig{
+		Filter: []string{"test"},
+		Output: &buf,
+		Exec: func(s *Scenario) Outcome {
+			switch s.Index {
+			case 0:
+				return Outcome{Status: StatusPassed}
+			case 1:
+				return Outcome{Status: StatusFailed, Message: "a.sg:3: test.eq(1, 2)"}
+			default:
+				return Outcome{Status: StatusSkipped, Message: "later"}
+			}
+		},
+	})
+
+	result := runner.Run()
+
+	if result.Passed != 1 || result.Failed != 1 || result.Skipped != 1 {
+		t.Errorf("expected 1 passed, 1 failed, 1 skipped, got %+v", result)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"a.sg#0 (test) ... PASS",
+		"a.sg#1 (test) ... FAIL\n    a.sg:3: test.eq(1, 2)",
+		"a.sg#2 (test) ... SKIPPED (later)",
+		"3 total, 1 skipped, 1 passed, 1 failed",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+}
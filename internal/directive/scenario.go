@@ -25,11 +25,20 @@ type Scenario struct {
 	// Span is the source location of the directive block.
 	Span source.Span
 
+	// Lines are the directive calls of the block, in source order.
+	Lines []Line
+
 	// FunctionName is the generated function name for execution.
 	// Format: __directive_<namespace>_<index>__
 	FunctionName string
 }
 
+// Line is one directive call as written in the source, e.g. `test.eq(add(1, 2), 3)`.
+type Line struct {
+	Text string
+	Span source.Span
+}
+
 // GenerateFunctionName creates the canonical function name for this scenario.
 func (s *Scenario) GenerateFunctionName() string {
 	return fmt.Sprintf("__directive_%s_%d__", s.Namespace, s.Index)
@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"surge/internal/symbols"
+)
+
+func TestDiagnoseCfgSettingsSelectItems(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.sg")
+	src := `@cfg(fast)
+fn speed() -> int { return 9; }
+
+@cfg(os = "plan9")
+fn speed() -> int { return 1; }
+
+@entrypoint
+fn main() -> int {
+    return speed();
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	run := func(cfg symbols.CfgSettings) int {
+		res, err := DiagnoseWithOptions(context.Background(), path, &DiagnoseOptions{
+			Stage:          DiagnoseStageAll,
+			MaxDiagnostics: 16,
+			BaseDir:        dir,
+			Cfg:            cfg,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(res.Bag.Items())
+	}
+	if n := run(nil); n == 0 {
+		t.Fatalf("expected 'speed' to be unresolved with host settings")
+	}
+	if n := run(symbols.CfgSettings{"fast": "true"}); n != 0 {
+		t.Fatalf("expected a clean run with fast enabled, got %d diagnostics", n)
+	}
+	if n := run(symbols.CfgSettings{"fast": "true", "os": "plan9"}); n == 0 {
+		t.Fatalf("expected a duplicate 'speed' when both items are enabled")
+	}
+}
@@ -0,0 +1,77 @@
+package driver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+)
+
+func TestCharLiteralHasCharType(t *testing.T) {
+	src := `fn demo() -> char {
+    let c = 'a';
+    return c;
+}
+`
+	name := filepath.Join(t.TempDir(), "char.sg")
+	// BaseDir внутри репозитория, чтобы нашёлся core с операторами char
+	res, err := CompileSource(context.Background(), name, []byte(src), CompileOptions{MaxDiagnostics: 16, BaseDir: "."})
+	if err != nil {
+		t.Fatalf("CompileSource error: %v", err)
+	}
+	if res.Bag.HasErrors() {
+		for _, d := range res.Bag.Items() {
+			t.Errorf("unexpected diagnostic %s: %s", d.Code, d.Message)
+		}
+		t.FailNow()
+	}
+	if res.Sema == nil || res.Sema.TypeInterner == nil {
+		t.Fatal("missing sema result")
+	}
+
+	found := false
+	for exprID, ty := range res.Sema.ExprTypes {
+		lit, ok := res.Builder.Exprs.Literal(exprID)
+		if !ok || lit == nil || lit.Kind != ast.ExprLitChar {
+			continue
+		}
+		found = true
+		if ty != res.Sema.TypeInterner.Builtins().Char {
+			t.Fatalf("expected 'a' to have the builtin char type, got type#%d", ty)
+		}
+		if ty == res.Sema.TypeInterner.Builtins().Uint32 {
+			t.Fatalf("char must be distinct from uint32")
+		}
+	}
+	if !found {
+		t.Fatal("character literal not typed")
+	}
+}
+
+func TestCharDoesNotMixWithIntegers(t *testing.T) {
+	src := `fn demo(c: char) -> uint32 {
+    let _a: uint32 = c;
+    let _b: char = 97;
+    return c to uint32;
+}
+`
+	name := filepath.Join(t.TempDir(), "char_mix.sg")
+	res, err := CompileSource(context.Background(), name, []byte(src), CompileOptions{MaxDiagnostics: 16, BaseDir: "."})
+	if err != nil {
+		t.Fatalf("CompileSource error: %v", err)
+	}
+	lines := make(map[uint32]bool)
+	for _, d := range res.Bag.Items() {
+		if d.Code != diag.SemaTypeMismatch {
+			t.Errorf("unexpected diagnostic %s: %s", d.Code, d.Message)
+			continue
+		}
+		start, _ := res.FileSet.Resolve(d.Primary)
+		lines[start.Line] = true
+	}
+	if !lines[2] || !lines[3] || len(lines) != 2 {
+		t.Fatalf("expected type mismatches on lines 2 and 3 only, got %v", lines)
+	}
+}
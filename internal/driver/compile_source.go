@@ -0,0 +1,86 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/sema"
+	"surge/internal/source"
+	"surge/internal/symbols"
+	"surge/internal/trace"
+)
+
+// CompileOptions configures CompileSource.
+type CompileOptions struct {
+	Stage            DiagnoseStage // defaults to DiagnoseStageSema
+	MaxDiagnostics   int
+	BaseDir          string // directory used to resolve imports; defaults to the directory of name
+	IgnoreWarnings   bool
+	WarningsAsErrors bool
+	NoAlienHints     bool
+}
+
+// CompileResult holds the artefacts of CompileSource.
+type CompileResult struct {
+	FileSet *source.FileSet
+	File    *source.File
+	FileID  ast.FileID
+	Bag     *diag.Bag
+	Builder *ast.Builder
+	Table   *symbols.Table // resolved symbol table (nil before the sema stage)
+	Symbols *symbols.Result
+	Sema    *sema.Result
+}
+
+// CompileSource runs the diagnostics pipeline on an in-memory source buffer.
+// It is the programmatic counterpart of `surge diag` for embedders (LSP, playground):
+// src is registered as a virtual file called name, so it is never read from disk;
+// imports are still resolved relative to BaseDir.
+// The tracer carried by ctx (see trace.WithTracer) receives the usual pass spans.
+func CompileSource(ctx context.Context, name string, src []byte, opts CompileOptions) (*CompileResult, error) {
+	if name == "" {
+		return nil, errors.New("compile source: empty name")
+	}
+	tracer := trace.FromContext(ctx)
+	span := trace.Begin(tracer, trace.ScopeDriver, "compile_source", 0)
+	defer span.End("")
+
+	stage := opts.Stage
+	if stage == "" {
+		stage = DiagnoseStageSema
+	}
+	baseDir := opts.BaseDir
+	if baseDir == "" {
+		baseDir = filepath.Dir(name)
+	}
+
+	res, err := DiagnoseWithOptions(ctx, name, &DiagnoseOptions{
+		Stage:            stage,
+		MaxDiagnostics:   opts.MaxDiagnostics,
+		IgnoreWarnings:   opts.IgnoreWarnings,
+		WarningsAsErrors: opts.WarningsAsErrors,
+		NoAlienHints:     opts.NoAlienHints,
+		BaseDir:          baseDir,
+		Source:           append([]byte(nil), src...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &CompileResult{
+		FileSet: res.FileSet,
+		File:    res.File,
+		FileID:  res.FileID,
+		Bag:     res.Bag,
+		Builder: res.Builder,
+		Symbols: res.Symbols,
+		Sema:    res.Sema,
+	}
+	if res.Symbols != nil {
+		out.Table = res.Symbols.Table
+	}
+	return out, nil
+}
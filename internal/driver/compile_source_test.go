@@ -0,0 +1,49 @@
+package driver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func TestCompileSourceReportsDiagnosticsAndSymbols(t *testing.T) {
+	src := `
+        fn helper() -> int { return 1; }
+
+        fn demo() -> int {
+            return missing;
+        }
+    `
+	name := filepath.Join(t.TempDir(), "snippet.sg")
+
+	res, err := CompileSource(context.Background(), name, []byte(src), CompileOptions{MaxDiagnostics: 16})
+	if err != nil {
+		t.Fatalf("CompileSource error: %v", err)
+	}
+	if res.Builder == nil || res.Table == nil {
+		t.Fatalf("expected AST builder and symbol table, got %+v", res)
+	}
+
+	found := false
+	for _, d := range res.Bag.Items() {
+		if d.Code == diag.SemaUnresolvedSymbol {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected unresolved symbol diagnostic, got %+v", res.Bag.Items())
+	}
+
+	declared := make(map[string]bool)
+	for _, sym := range res.Table.Symbols.Data() {
+		declared[res.Builder.StringsInterner.MustLookup(sym.Name)] = true
+	}
+	if !declared["helper"] || !declared["demo"] {
+		t.Fatalf("expected helper and demo to be declared")
+	}
+	if res.File == nil || string(res.File.Content) != src {
+		t.Fatalf("expected file content to come from the in-memory source")
+	}
+}
@@ -0,0 +1,335 @@
+package driver
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"surge/internal/diag"
+	"surge/internal/parser"
+	"surge/internal/project"
+	"surge/internal/source"
+)
+
+// Increment when diagCacheEntry or the key layout changes.
+const diagCacheSchemaVersion uint16 = 2
+
+// DiagCache stores per-module diagnostics across diagnose runs. An entry is
+// keyed by the module's files, the run options and the export hashes of the
+// modules it imports (see diagCacheSession); it holds the diagnostics that
+// symbol resolution and sema reported for the module plus the module's own
+// export hash, so dependents can be keyed without re-checking it. A module
+// whose entry hits is not re-checked unless a changed dependent needs its
+// exports.
+type DiagCache struct {
+	dir string
+
+	// mem backs caches created by NewMemoryDiagCache (dir is empty then).
+	mu  sync.Mutex
+	mem map[project.Digest][]byte
+}
+
+// diagCacheEntry is the serialized result of checking one module. Spans refer
+// to Files by index, so an entry replays into any FileSet that loaded the
+// same paths.
+type diagCacheEntry struct {
+	Schema      uint16
+	Module      string
+	ExportHash  project.Digest
+	Exports     []string // canonical rendering of the module's exports
+	Files       []string
+	Diagnostics []diagCacheDiagnostic
+}
+
+type diagCacheSpan struct {
+	File  uint32 // index into diagCacheEntry.Files
+	Start uint32
+	End   uint32
+}
+
+type diagCacheDiagnostic struct {
+	Severity diag.Severity
+	Code     diag.Code
+	Message  string
+	Primary  diagCacheSpan
+	Notes    []diagCacheNote
+	Fixes    []diagCacheFix
+}
+
+type diagCacheNote struct {
+	Span diagCacheSpan
+	Msg  string
+}
+
+// diagCacheFix is a materialized diag.Fix: lazy thunks are built before storing.
+type diagCacheFix struct {
+	ID            string
+	Title         string
+	Kind          diag.FixKind
+	Applicability diag.FixApplicability
+	IsPreferred   bool
+	Edits         []diagCacheEdit
+	RequiresAll   bool
+}
+
+type diagCacheEdit struct {
+	Span    diagCacheSpan
+	NewText string
+	OldText string
+}
+
+// DefaultDiagCacheDir returns the conventional diagnostics cache directory
+// under the user cache directory ($XDG_CACHE_HOME or ~/.cache). Nothing uses
+// it implicitly: callers pass it to OpenDiagCache when caching is wanted.
+func DefaultDiagCacheDir() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(base, "surge", "diag"), nil
+}
+
+// OpenDiagCache opens the on-disk diagnostics cache rooted at dir, creating
+// it if needed.
+func OpenDiagCache(dir string) (*DiagCache, error) {
+	if dir == "" {
+		return nil, errors.New("diag cache: empty directory")
+	}
+	// #nosec G703 -- dir is the user-selected cache directory.
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &DiagCache{dir: dir}, nil
+}
+
+// NewMemoryDiagCache returns a cache that lives as long as the process, for
+// repeated runs such as watch mode when no cache directory was given.
+func NewMemoryDiagCache() *DiagCache {
+	return &DiagCache{mem: make(map[project.Digest][]byte)}
+}
+
+// Dir returns the cache root, or "" for an in-memory cache.
+func (c *DiagCache) Dir() string {
+	if c == nil {
+		return ""
+	}
+	return c.dir
+}
+
+func (c *DiagCache) pathFor(key project.Digest) string {
+	return filepath.Join(c.dir, hex.EncodeToString(key[:])+".mp")
+}
+
+// diagCacheable reports whether a run with opts produces nothing but
+// diagnostics, so replaying cached module diagnostics is equivalent to
+// checking the modules again.
+func diagCacheable(opts *DiagnoseOptions) bool {
+	return opts != nil &&
+		opts.DiagCache != nil &&
+		opts.Source == nil &&
+		opts.ReadFile == nil &&
+		!opts.EnableTimings &&
+		opts.PhaseObserver == nil &&
+		opts.DirectiveMode == parser.DirectiveModeOff &&
+		!opts.EmitHIR &&
+		!opts.EmitInstantiations &&
+		!opts.KeepArtifacts &&
+		opts.ExportsOut == nil
+}
+
+var (
+	compilerFingerprintOnce sync.Once
+	compilerFingerprintVal  string
+)
+
+// compilerFingerprint identifies the running binary by path, size and
+// modification time, so a rebuilt compiler never reads stale entries.
+func compilerFingerprint() string {
+	compilerFingerprintOnce.Do(func() {
+		exe, err := os.Executable()
+		if err != nil {
+			return
+		}
+		st, err := os.Stat(exe)
+		if err != nil {
+			return
+		}
+		compilerFingerprintVal = fmt.Sprintf("%s:%d:%d", exe, st.Size(), st.ModTime().UnixNano())
+	})
+	return compilerFingerprintVal
+}
+
+// encodeDiagCacheEntry converts the diagnostics in items into an entry.
+// Spans are stored by file path; ok is false when a span refers to a file
+// without a path on disk, since such an entry cannot be replayed.
+func encodeDiagCacheEntry(fs *source.FileSet, module string, items []*diag.Diagnostic) (*diagCacheEntry, bool) {
+	entry := &diagCacheEntry{Schema: diagCacheSchemaVersion, Module: module}
+	fileIdx := make(map[source.FileID]uint32)
+	ok := true
+	span := func(s source.Span) diagCacheSpan {
+		idx, seen := fileIdx[s.File]
+		if !seen {
+			if !fs.HasFile(s.File) || fs.Get(s.File).Flags&source.FileVirtual != 0 {
+				ok = false
+				return diagCacheSpan{}
+			}
+			idx = uint32(len(entry.Files)) //nolint:gosec // bounded by the number of loaded files
+			fileIdx[s.File] = idx
+			entry.Files = append(entry.Files, fs.Get(s.File).Path)
+		}
+		return diagCacheSpan{File: idx, Start: s.Start, End: s.End}
+	}
+
+	ctx := diag.FixBuildContext{FileSet: fs}
+	for _, d := range items {
+		item := diagCacheDiagnostic{
+			Severity: d.Severity,
+			Code:     d.Code,
+			Message:  d.Message,
+			Primary:  span(d.Primary),
+		}
+		for _, note := range d.Notes {
+			item.Notes = append(item.Notes, diagCacheNote{Span: span(note.Span), Msg: note.Msg})
+		}
+		for _, fix := range d.Fixes {
+			resolved, err := fix.Resolve(ctx)
+			if err != nil || resolved == nil {
+				continue
+			}
+			cached := diagCacheFix{
+				ID:            resolved.ID,
+				Title:         resolved.Title,
+				Kind:          resolved.Kind,
+				Applicability: resolved.Applicability,
+				IsPreferred:   resolved.IsPreferred,
+				RequiresAll:   resolved.RequiresAll,
+			}
+			for _, edit := range resolved.Edits {
+				cached.Edits = append(cached.Edits, diagCacheEdit{
+					Span:    span(edit.Span),
+					NewText: edit.NewText,
+					OldText: edit.OldText,
+				})
+			}
+			item.Fixes = append(item.Fixes, cached)
+		}
+		entry.Diagnostics = append(entry.Diagnostics, item)
+	}
+	return entry, ok
+}
+
+// decodeDiagnostics maps the entry's spans onto the files fs loaded under the
+// same paths. ok is false when one of the paths is not loaded.
+func (e *diagCacheEntry) decodeDiagnostics(fs *source.FileSet) ([]*diag.Diagnostic, bool) {
+	ids := make([]source.FileID, len(e.Files))
+	for i, path := range e.Files {
+		id, found := fs.GetLatest(path)
+		if !found {
+			return nil, false
+		}
+		ids[i] = id
+	}
+	ok := true
+	span := func(s diagCacheSpan) source.Span {
+		if int(s.File) >= len(ids) {
+			ok = false
+			return source.Span{}
+		}
+		return source.Span{File: ids[s.File], Start: s.Start, End: s.End}
+	}
+
+	out := make([]*diag.Diagnostic, 0, len(e.Diagnostics))
+	for i := range e.Diagnostics {
+		d := &e.Diagnostics[i]
+		item := &diag.Diagnostic{
+			Severity: d.Severity,
+			Code:     d.Code,
+			Message:  d.Message,
+			Primary:  span(d.Primary),
+		}
+		for _, note := range d.Notes {
+			item.Notes = append(item.Notes, diag.Note{Span: span(note.Span), Msg: note.Msg})
+		}
+		for _, fix := range d.Fixes {
+			edits := make([]diag.TextEdit, 0, len(fix.Edits))
+			for _, edit := range fix.Edits {
+				edits = append(edits, diag.TextEdit{Span: span(edit.Span), NewText: edit.NewText, OldText: edit.OldText})
+			}
+			item.Fixes = append(item.Fixes, &diag.Fix{
+				ID:            fix.ID,
+				Title:         fix.Title,
+				Kind:          fix.Kind,
+				Applicability: fix.Applicability,
+				IsPreferred:   fix.IsPreferred,
+				Edits:         edits,
+				RequiresAll:   fix.RequiresAll,
+			})
+		}
+		out = append(out, item)
+	}
+	return out, ok
+}
+
+func (c *DiagCache) read(key project.Digest) (*diagCacheEntry, bool) {
+	var data []byte
+	if c.mem != nil {
+		c.mu.Lock()
+		data = c.mem[key]
+		c.mu.Unlock()
+		if data == nil {
+			return nil, false
+		}
+	} else {
+		var err error
+		// #nosec G304 -- path is derived from the cache root and a hash
+		data, err = os.ReadFile(c.pathFor(key))
+		if err != nil {
+			return nil, false
+		}
+	}
+	var entry diagCacheEntry
+	if err := msgpack.Unmarshal(data, &entry); err != nil || entry.Schema != diagCacheSchemaVersion {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *DiagCache) write(key project.Digest, entry *diagCacheEntry) error {
+	data, err := msgpack.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if c.mem != nil {
+		c.mu.Lock()
+		c.mem[key] = data
+		c.mu.Unlock()
+		return nil
+	}
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rmErr := os.Remove(tmp.Name()); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "failed to remove temp file: %v\n", rmErr)
+		}
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// Атомарная замена
+	return os.Rename(tmp.Name(), c.pathFor(key))
+}
@@ -0,0 +1,197 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type cachedDiag struct {
+	Path    string
+	Code    string
+	Message string
+	Start   uint32
+	End     uint32
+}
+
+func collectCachedDiags(t *testing.T, res *DiagnoseResult) []cachedDiag {
+	t.Helper()
+	out := make([]cachedDiag, 0, res.Bag.Len())
+	for _, d := range res.Bag.Items() {
+		out = append(out, cachedDiag{
+			Path:    res.FileSet.Get(d.Primary.File).Path,
+			Code:    d.Code.ID(),
+			Message: d.Message,
+			Start:   d.Primary.Start,
+			End:     d.Primary.End,
+		})
+	}
+	return out
+}
+
+func writeCacheFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiagCacheReplaysUnchangedRun(t *testing.T) {
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "util", "helpers.sg")
+	writeCacheFile(t, helper, "pub fn twice(x: int) -> int {\n    return x * 2;\n}\n")
+	mainPath := filepath.Join(dir, "main.sg")
+	writeCacheFile(t, mainPath, "import util/helpers;\n\n@entrypoint\nfn main() -> int {\n    let y: int = helpers.twice(2);\n    return 0;\n}\n")
+
+	cache, err := OpenDiagCache(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatalf("OpenDiagCache: %v", err)
+	}
+	run := func() *DiagnoseResult {
+		t.Helper()
+		res, diagErr := DiagnoseWithOptions(context.Background(), mainPath, &DiagnoseOptions{
+			Stage:          DiagnoseStageAll,
+			MaxDiagnostics: 64,
+			BaseDir:        dir,
+			DiagCache:      cache,
+		})
+		if diagErr != nil {
+			t.Fatalf("diagnose: %v", diagErr)
+		}
+		return res
+	}
+
+	first := run()
+	if first.CacheHit {
+		t.Fatalf("first run must not hit an empty cache")
+	}
+	if want := []string{"main", "util/helpers"}; !reflect.DeepEqual(first.CheckedModules, want) {
+		t.Fatalf("first run checked %v, want %v", first.CheckedModules, want)
+	}
+	want := collectCachedDiags(t, first)
+	if len(want) == 0 {
+		t.Fatalf("expected diagnostics for the sample program")
+	}
+
+	second := run()
+	if !second.CacheHit || len(second.CheckedModules) != 0 {
+		t.Fatalf("expected the unchanged run to replay every module, checked %v", second.CheckedModules)
+	}
+	if got := collectCachedDiags(t, second); !reflect.DeepEqual(got, want) {
+		t.Fatalf("cached diagnostics differ:\n got %+v\nwant %+v", got, want)
+	}
+
+	// Изменение экспортов импортируемого модуля инвалидирует и его, и импортёра.
+	writeCacheFile(t, helper, "pub fn double(x: int) -> int {\n    return x * 2;\n}\n")
+	third := run()
+	if want := []string{"main", "util/helpers"}; !reflect.DeepEqual(third.CheckedModules, want) {
+		t.Fatalf("after an export change checked %v, want %v", third.CheckedModules, want)
+	}
+	if got := collectCachedDiags(t, third); reflect.DeepEqual(got, want) {
+		t.Fatalf("expected new diagnostics after the change, got %+v", got)
+	}
+}
+
+func TestDiagCacheRechecksOnlyModulesWithChangedExports(t *testing.T) {
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "util", "helpers.sg")
+	writeCacheFile(t, helper, "pub fn twice(x: int) -> int {\n    let unused: int = 1;\n    return x * 2;\n}\n")
+	other := filepath.Join(dir, "util", "other.sg")
+	writeCacheFile(t, other, "pub fn one() -> int {\n    let spare: int = 1;\n    return 1;\n}\n")
+	mainPath := filepath.Join(dir, "main.sg")
+	writeCacheFile(t, mainPath, "import util/helpers;\nimport util/other;\n\n@entrypoint\nfn main() -> int {\n    let y: int = helpers.twice(other.one());\n    return 0;\n}\n")
+
+	cache, err := OpenDiagCache(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatalf("OpenDiagCache: %v", err)
+	}
+	run := func() *DiagnoseResult {
+		t.Helper()
+		res, diagErr := DiagnoseWithOptions(context.Background(), mainPath, &DiagnoseOptions{
+			Stage:          DiagnoseStageAll,
+			MaxDiagnostics: 64,
+			BaseDir:        dir,
+			DiagCache:      cache,
+		})
+		if diagErr != nil {
+			t.Fatalf("diagnose: %v", diagErr)
+		}
+		return res
+	}
+	// Диагностики модулей-зависимостей попадают в их собственные Bag.
+	merged := func(res *DiagnoseResult) []cachedDiag {
+		t.Helper()
+		res.MergeModuleDiagnostics()
+		return collectCachedDiags(t, res)
+	}
+
+	want := merged(run())
+	hasHelperDiag := false
+	for _, d := range want {
+		hasHelperDiag = hasHelperDiag || d.Path == helper
+	}
+	if !hasHelperDiag {
+		t.Fatalf("expected a diagnostic in %s, got %+v", helper, want)
+	}
+	// Правка тела той же длины: экспорты и смещения объявлений не меняются.
+	writeCacheFile(t, helper, "pub fn twice(x: int) -> int {\n    let unused: int = 1;\n    return x * 3;\n}\n")
+	res := run()
+	if want := []string{"util/helpers"}; !reflect.DeepEqual(res.CheckedModules, want) {
+		t.Fatalf("checked %v, want only %v", res.CheckedModules, want)
+	}
+	if got := merged(res); !reflect.DeepEqual(got, want) {
+		t.Fatalf("diagnostics differ after a body edit:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestDiagCacheReplaysDirectoryRun(t *testing.T) {
+	dir := t.TempDir()
+	writeCacheFile(t, filepath.Join(dir, "a.sg"), "fn a() -> int {\n    let unused: int = 1;\n    return 1;\n}\n")
+	writeCacheFile(t, filepath.Join(dir, "b.sg"), "fn b() -> int {\n    return missing;\n}\n")
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	cache, err := OpenDiagCache(cacheDir)
+	if err != nil {
+		t.Fatalf("OpenDiagCache: %v", err)
+	}
+	run := func() []cachedDiag {
+		t.Helper()
+		fs, results, diagErr := DiagnoseDirWithOptions(context.Background(), dir, &DiagnoseOptions{
+			Stage:          DiagnoseStageAll,
+			MaxDiagnostics: 64,
+			DiagCache:      cache,
+		}, 2)
+		if diagErr != nil {
+			t.Fatalf("diagnose dir: %v", diagErr)
+		}
+		var out []cachedDiag
+		for i := range results {
+			for _, d := range results[i].Bag.Items() {
+				out = append(out, cachedDiag{
+					Path:    fs.Get(d.Primary.File).Path,
+					Code:    d.Code.ID(),
+					Message: d.Message,
+					Start:   d.Primary.Start,
+					End:     d.Primary.End,
+				})
+			}
+		}
+		return out
+	}
+
+	want := run()
+	if len(want) == 0 {
+		t.Fatalf("expected diagnostics for the sample files")
+	}
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected one cache entry per file, got %d (%v)", len(entries), err)
+	}
+	if got := run(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("cached diagnostics differ:\n got %+v\nwant %+v", got, want)
+	}
+}
@@ -47,6 +47,8 @@ type DiagnoseResult struct {
 	DirectiveRegistry *directive.Registry // Collected directive scenarios
 	HIR               *hir.Module         // HIR module (if EmitHIR is enabled)
 	TimingReport      observ.Report       // Phase timing report (if enabled)
+	CacheHit          bool                // Every module's diagnostics were replayed from DiagnoseOptions.DiagCache
+	CheckedModules    []string            // Modules whose symbols and sema ran (with DiagCache; sorted)
 	rootRecord        *moduleRecord
 	moduleRecords     map[string]*moduleRecord
 }
@@ -72,8 +74,10 @@ type DiagnoseOptions struct {
 	IgnoreWarnings     bool
 	WarningsAsErrors   bool
 	NoAlienHints       bool // Disable extra alien-hint diagnostics (enabled by default)
+	DialectHints       bool // Report info hints for foreign-dialect evidence (--hints=dialect)
 	BaseDir            string
 	ReadFile           func(string) ([]byte, error)
+	Source             []byte // In-memory content for the root file (registered as virtual); nil reads it from disk
 	RootKind           project.ModuleKind
 	ModuleMapping      *project.ModuleMapping
 	EnableTimings      bool
@@ -86,6 +90,8 @@ type DiagnoseOptions struct {
 	KeepArtifacts      bool                 // Retain AST/symbol/semantic data (for analysis snapshots)
 	FullModuleGraph    bool                 // Canonical module-directory strategy is full graph resolution. In directory diagnostics, this keeps module scopes coherent and avoids cascading SEM3005-style errors; non-module files follow the initial per-file pass.
 	ExportsOut         *map[string]*symbols.ModuleExports
+	DiagCache          *DiagCache          // Replay diagnostics of unchanged modules (nil disables)
+	Cfg                symbols.CfgSettings // Build settings for @cfg items; nil describes the host
 }
 
 // Diagnose запускает диагностику файла до указанного уровня
@@ -157,7 +163,15 @@ func DiagnoseWithOptions(ctx context.Context, filePath string, opts *DiagnoseOpt
 		fs.SetReadFile(opts.ReadFile)
 	}
 	sharedTypes := types.NewInterner()
-	fileID, err := fs.Load(filePath)
+	var (
+		fileID source.FileID
+		err    error
+	)
+	if opts.Source != nil {
+		fileID = fs.AddVirtual(filePath, opts.Source)
+	} else {
+		fileID, err = fs.Load(filePath)
+	}
 	loadSpan.End("")
 	end(loadIdx, "")
 	phaseEnd("load_file")
@@ -245,8 +259,11 @@ func DiagnoseWithOptions(ctx context.Context, filePath string, opts *DiagnoseOpt
 				if moduleExports == nil {
 					moduleExports = make(map[string]*symbols.ModuleExports)
 				}
-				if exp := resolveModuleRecord(ctx, rootRec, baseDir, moduleExports, sharedTypes, opts, instRecorder); exp != nil {
-					moduleExports[rootRec.Meta.Path] = exp
+				// С кэшем диагностик корень уже разрешён (или воспроизведён) в графе.
+				if !rootRec.diagCached {
+					if exp := resolveModuleRecord(ctx, rootRec, baseDir, moduleExports, sharedTypes, opts, instRecorder); exp != nil {
+						moduleExports[rootRec.Meta.Path] = exp
+					}
 				}
 				if sym, ok := rootRec.Symbols[astFile]; ok {
 					symbolsRes = &sym
@@ -255,12 +272,13 @@ func DiagnoseWithOptions(ctx context.Context, filePath string, opts *DiagnoseOpt
 					semaRes = sem
 				}
 			}
-			if symbolsRes == nil {
+			replayed := rootRec != nil && rootRec.replayed
+			if symbolsRes == nil && !replayed {
 				symFilePath := ""
 				if file != nil {
 					symFilePath = file.Path
 				}
-				symbolsRes = diagnoseSymbols(builder, astFile, bag, modulePath, symFilePath, baseDir, moduleExports)
+				symbolsRes = diagnoseSymbols(builder, astFile, bag, modulePath, symFilePath, baseDir, moduleExports, opts.Cfg)
 				if moduleExports != nil && symbolsRes != nil {
 					if rootExports := symbols.CollectExports(builder, *symbolsRes, modulePath); rootExports != nil {
 						moduleExports[modulePath] = rootExports
@@ -275,7 +293,7 @@ func DiagnoseWithOptions(ctx context.Context, filePath string, opts *DiagnoseOpt
 			end(symbolIdx, symbolNote)
 			phaseEnd("symbols")
 
-			if semaRes == nil {
+			if semaRes == nil && !replayed {
 				phaseBegin("sema")
 				semaIdx := begin("sema")
 				semaSpan := trace.Begin(tracer, trace.ScopePass, "sema", diagSpan.ID())
@@ -287,7 +305,16 @@ func DiagnoseWithOptions(ctx context.Context, filePath string, opts *DiagnoseOpt
 		}
 	}
 
+	if opts.DialectHints {
+		emitDialectHints(builder, astFile, file, bag)
+	}
+
 	// Применяем фильтрацию и трансформацию диагностик
+	bag.DedupByLocation()
+	if file != nil {
+		fullPipeline := opts.Stage == DiagnoseStageSema || opts.Stage == DiagnoseStageAll
+		applySuppressions(fs, bag, file.ID, fullPipeline)
+	}
 	if opts.IgnoreWarnings {
 		bag.Filter(func(d *diag.Diagnostic) bool {
 			return d.Severity != diag.SevWarning && d.Severity != diag.SevInfo
@@ -338,6 +365,12 @@ func DiagnoseWithOptions(ctx context.Context, filePath string, opts *DiagnoseOpt
 	if opts.ExportsOut != nil {
 		*opts.ExportsOut = moduleExports
 	}
+	var checked []string
+	cacheHit := false
+	if diagCacheable(opts) && moduleRecords != nil {
+		checked = checkedModules(moduleRecords)
+		cacheHit = len(checked) == 0
+	}
 
 	return &DiagnoseResult{
 		FileSet:           fs,
@@ -351,12 +384,14 @@ func DiagnoseWithOptions(ctx context.Context, filePath string, opts *DiagnoseOpt
 		DirectiveRegistry: directiveRegistry,
 		HIR:               hirModule,
 		TimingReport:      timingReport,
+		CacheHit:          cacheHit,
+		CheckedModules:    checked,
 		rootRecord:        rootRec,
 		moduleRecords:     moduleRecords,
 	}, nil
 }
 
-func diagnoseSymbols(builder *ast.Builder, fileID ast.FileID, bag *diag.Bag, modulePath, filePath, baseDir string, exports map[string]*symbols.ModuleExports) *symbols.Result {
+func diagnoseSymbols(builder *ast.Builder, fileID ast.FileID, bag *diag.Bag, modulePath, filePath, baseDir string, exports map[string]*symbols.ModuleExports, cfg symbols.CfgSettings) *symbols.Result {
 	if builder == nil || fileID == ast.NoFileID {
 		return nil
 	}
@@ -367,6 +402,7 @@ func diagnoseSymbols(builder *ast.Builder, fileID ast.FileID, bag *diag.Bag, mod
 		FilePath:      filePath,
 		BaseDir:       baseDir,
 		ModuleExports: exports,
+		Cfg:           cfg,
 	})
 	return &res
 }
@@ -408,7 +444,8 @@ func diagnoseSemaWithTypes(ctx context.Context, builder *ast.Builder, fileID ast
 func diagnoseTokenize(file *source.File, bag *diag.Bag) {
 	reporterAdapter := &lexer.ReporterAdapter{Bag: bag}
 	opts := lexer.Options{
-		Reporter: reporterAdapter.Reporter(),
+		Reporter:     reporterAdapter.Reporter(),
+		Suppressions: bag.Suppressions(),
 	}
 	lx := lexer.New(file, opts)
 
@@ -466,6 +503,11 @@ type moduleRecord struct {
 	Symbols            map[ast.FileID]symbols.Result
 	Exports            *symbols.ModuleExports
 	checkedEntrypoints bool
+	// builtinRoot is set for a stdlib module whose resolution was deferred to
+	// the diag cache session; it is resolved against this root.
+	builtinRoot string
+	diagCached  bool // resolved or replayed by the diag cache session
+	replayed    bool // diagnostics came from the diag cache; symbols and sema did not run
 }
 
 func runModuleGraph(
@@ -624,7 +666,7 @@ func runModuleGraph(
 
 	metas := make([]*project.ModuleMeta, 0, len(paths))
 	nodes := make([]*dag.ModuleNode, 0, len(paths))
-	overrides := missingModuleOverrides(records, opts.ModuleMapping)
+	overrides := missingModuleOverrides(records, opts.ModuleMapping, baseDir)
 	for _, p := range paths {
 		rec := records[p]
 		reporter := diag.Reporter(&diag.BagReporter{Bag: rec.Bag})
@@ -658,7 +700,7 @@ func runModuleGraph(
 	}
 	dag.ReportBrokenDeps(idx, slots)
 
-	exports = collectModuleExports(ctx, records, idx, topo, baseDir, meta.Path, typeInterner, opts)
+	exports = collectModuleExports(ctx, records, idx, topo, baseDir, meta.Path, typeInterner, opts, newDiagCacheSession(fs, opts))
 	for alias, target := range aliasExports {
 		if exp, ok := exports[normalizeExportsKey(target)]; ok {
 			exports[normalizeExportsKey(alias)] = exp
@@ -25,6 +25,7 @@ type DiagnoseOptions struct {
 	IgnoreWarnings     bool
 	WarningsAsErrors   bool
 	NoAlienHints       bool
+	DialectHints       bool
 	RootKind           project.ModuleKind
 	EnableTimings      bool
 	EnableDiskCache    bool
@@ -37,6 +38,14 @@ type DiagnoseOptions struct {
 	Result             *WorkspaceResult
 	// KeepArtifacts retains AST/symbol/semantic data for analysis snapshots.
 	KeepArtifacts bool
+	// Source, when non-nil, is diagnosed as the content of ProjectRoot (e.g. "<stdin>")
+	// instead of reading it from disk.
+	Source []byte
+	// DiagCache, when set, replays diagnostics of unchanged modules;
+	// Result.ModuleExports is not filled then.
+	DiagCache *driver.DiagCache
+	// Cfg holds build settings for @cfg items; nil describes the host.
+	Cfg symbols.CfgSettings
 }
 
 // FileOverlay stores in-memory file contents keyed by absolute path or file URI.
@@ -103,6 +112,7 @@ func DiagnoseWorkspace(ctx context.Context, opts *DiagnoseOptions, overlay FileO
 		IgnoreWarnings:     opts.IgnoreWarnings,
 		WarningsAsErrors:   opts.WarningsAsErrors,
 		NoAlienHints:       opts.NoAlienHints,
+		DialectHints:       opts.DialectHints,
 		BaseDir:            opts.BaseDir,
 		ModuleMapping:      nil,
 		ReadFile:           readFile,
@@ -115,12 +125,18 @@ func DiagnoseWorkspace(ctx context.Context, opts *DiagnoseOptions, overlay FileO
 		EmitInstantiations: opts.EmitInstantiations,
 		KeepArtifacts:      opts.KeepArtifacts,
 		FullModuleGraph:    opts.FullModuleGraph,
+		Source:             opts.Source,
+		DiagCache:          opts.DiagCache,
+		Cfg:                opts.Cfg,
 	}
 	var moduleExports map[string]*symbols.ModuleExports
-	driverOpts.ExportsOut = &moduleExports
+	// Модули из кэша не дают экспортов, поэтому с кэшем они не собираются.
+	if opts.DiagCache == nil {
+		driverOpts.ExportsOut = &moduleExports
+	}
 
 	isOverlayFile := err != nil && overlayHasPath(overlayMap, opts.ProjectRoot, rootDir)
-	if err != nil && !isOverlayFile {
+	if err != nil && !isOverlayFile && opts.Source == nil {
 		return nil, err
 	}
 
@@ -176,6 +192,7 @@ func DiagnoseFiles(ctx context.Context, opts *DiagnoseOptions, files []string, o
 		IgnoreWarnings:     opts.IgnoreWarnings,
 		WarningsAsErrors:   opts.WarningsAsErrors,
 		NoAlienHints:       opts.NoAlienHints,
+		DialectHints:       opts.DialectHints,
 		BaseDir:            baseDir,
 		ReadFile:           readFile,
 		RootKind:           opts.RootKind,
@@ -187,9 +204,13 @@ func DiagnoseFiles(ctx context.Context, opts *DiagnoseOptions, files []string, o
 		EmitInstantiations: opts.EmitInstantiations,
 		KeepArtifacts:      opts.KeepArtifacts,
 		FullModuleGraph:    opts.FullModuleGraph,
+		DiagCache:          opts.DiagCache,
+		Cfg:                opts.Cfg,
 	}
 	var moduleExports map[string]*symbols.ModuleExports
-	driverOpts.ExportsOut = &moduleExports
+	if opts.DiagCache == nil {
+		driverOpts.ExportsOut = &moduleExports
+	}
 
 	fs, results, diagErr := driver.DiagnoseFilesWithOptions(ctx, baseDir, files, &driverOpts, opts.Jobs)
 	if diagErr != nil {
@@ -201,6 +201,7 @@ func resolveModuleRecord(
 			ModuleScope:   moduleScope,
 			NoStd:         noStd,
 			DeclareOnly:   true,
+			Cfg:           opts.Cfg,
 		})
 	}
 
@@ -221,6 +222,7 @@ func resolveModuleRecord(
 			ModuleScope:   moduleScope,
 			NoStd:         noStd,
 			ReuseDecls:    true,
+			Cfg:           opts.Cfg,
 		})
 		res.ModuleFiles = moduleFiles
 		if rec.Symbols == nil {
@@ -259,7 +261,11 @@ func collectModuleExports(
 	rootPath string,
 	typeInterner *types.Interner,
 	opts *DiagnoseOptions,
+	session *diagCacheSession,
 ) map[string]*symbols.ModuleExports {
+	if session != nil {
+		return session.collectModuleExports(ctx, records, idx, topo, baseDir, typeInterner, opts)
+	}
 	exports := collectedExports(records)
 	if exports == nil {
 		exports = make(map[string]*symbols.ModuleExports, len(records))
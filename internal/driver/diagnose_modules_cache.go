@@ -0,0 +1,460 @@
+package driver
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"os"
+	"sort"
+	"strings"
+
+	"surge/internal/diag"
+	"surge/internal/project"
+	"surge/internal/project/dag"
+	"surge/internal/source"
+	"surge/internal/symbols"
+	"surge/internal/types"
+)
+
+// diagCacheSession applies a DiagCache to one diagnose run.
+//
+// Модули обходятся в топологическом порядке (core первым). Ключ модуля —
+// хеш опций прогона, его файлов, уже накопленных в его Bag диагностик и
+// export-хешей импортов. При попадании модуль не проверяется: его export-хеш
+// берётся из записи, а диагностики воспроизводятся в конце. При промахе
+// модуль (и, транзитивно, его зависимости — им нужны настоящие экспорты)
+// проходит symbols + sema, а результат сохраняется.
+type diagCacheSession struct {
+	cache   *DiagCache
+	fs      *source.FileSet
+	options []byte // rendered run options, prefix of every key
+
+	exportHashes map[string]project.Digest
+}
+
+// diagCacheModule is the per-run state of one module.
+type diagCacheModule struct {
+	rec      *moduleRecord
+	deps     []string // imported modules, normalized, in key order
+	missing  []string // imports without a module record
+	keyed    bool     // every dep has an export hash, so the module has a key
+	key      project.Digest
+	replay   []*diag.Diagnostic
+	resolved bool
+	captured []*diag.Diagnostic
+	complete bool // the bag had room for everything resolve reported
+}
+
+// newDiagCacheSession returns nil unless opts allow caching.
+func newDiagCacheSession(fs *source.FileSet, opts *DiagnoseOptions) *diagCacheSession {
+	if fs == nil || !diagCacheable(opts) {
+		return nil
+	}
+	var b strings.Builder
+	wd, _ := os.Getwd() //nolint:errcheck // an empty wd only makes the key stricter
+	fmt.Fprintf(&b, "schema=%d\nbinary=%s\n", diagCacheSchemaVersion, compilerFingerprint())
+	fmt.Fprintf(&b, "stage=%s\nnoalien=%t\n", opts.Stage, opts.NoAlienHints)
+	fmt.Fprintf(&b, "wd=%s\nbase=%s\nstdlib=%s\n", wd, fs.BaseDir(), detectStdlibRootFrom(fs.BaseDir()))
+	if opts.ModuleMapping != nil {
+		// fmt печатает map с отсортированными ключами, так что вывод детерминирован.
+		fmt.Fprintf(&b, "mapping=%+v\n", *opts.ModuleMapping)
+	}
+	if opts.Cfg != nil {
+		fmt.Fprintf(&b, "cfg=%v\n", opts.Cfg)
+	}
+	return &diagCacheSession{
+		cache:        opts.DiagCache,
+		fs:           fs,
+		options:      []byte(b.String()),
+		exportHashes: make(map[string]project.Digest),
+	}
+}
+
+// collectModuleExports is collectModuleExports for a cached run. Unlike the
+// uncached walk it also handles rootPath, so callers must skip resolving
+// records marked diagCached.
+func (s *diagCacheSession) collectModuleExports(
+	ctx context.Context,
+	records map[string]*moduleRecord,
+	idx dag.ModuleIndex,
+	topo *dag.Topo,
+	baseDir string,
+	typeInterner *types.Interner,
+	opts *DiagnoseOptions,
+) map[string]*symbols.ModuleExports {
+	exports := collectedExports(records)
+	if exports == nil {
+		exports = make(map[string]*symbols.ModuleExports, len(records))
+	}
+
+	mods := make(map[string]*diagCacheModule, len(records))
+	position := make(map[string]int, len(records))
+	var order, core []string
+	add := func(path string, rec *moduleRecord) {
+		if rec == nil || rec.Meta == nil {
+			return
+		}
+		if _, seen := mods[path]; seen {
+			return
+		}
+		if rec.Bag == nil {
+			rec.Bag = diag.NewBag(opts.MaxDiagnostics)
+		}
+		mods[path] = &diagCacheModule{rec: rec}
+		position[path] = len(order)
+		order = append(order, path)
+	}
+	corePaths := make([]string, 0, 4)
+	for _, rec := range records {
+		if rec != nil && rec.Meta != nil && isCoreModulePath(normalizeExportsKey(rec.Meta.Path)) {
+			corePaths = append(corePaths, normalizeExportsKey(rec.Meta.Path))
+		}
+	}
+	sort.Strings(corePaths)
+	for _, path := range corePaths {
+		if _, seen := mods[path]; !seen {
+			core = append(core, path)
+		}
+		add(path, recordFor(records, path))
+	}
+	if topo != nil {
+		for i := len(topo.Order) - 1; i >= 0; i-- {
+			path := idx.IDToName[int(topo.Order[i])]
+			add(normalizeExportsKey(path), recordFor(records, path))
+		}
+	}
+
+	// Records outside the order (cycles) are left to the caller; whatever
+	// they import must still be resolved for them.
+	unordered := make(map[string]struct{})
+	for path, rec := range records {
+		if _, ok := mods[normalizeExportsKey(path)]; !ok && rec != nil {
+			unordered[normalizeExportsKey(path)] = struct{}{}
+		}
+	}
+	for i, path := range order {
+		m := mods[path]
+		seen := make(map[string]struct{})
+		implicit := core
+		if isCoreModulePath(path) {
+			implicit = nil
+			for _, c := range core {
+				if c == path {
+					break
+				}
+				implicit = append(implicit, c)
+			}
+		}
+		for _, dep := range implicit {
+			seen[dep] = struct{}{}
+			m.deps = append(m.deps, dep)
+		}
+		m.keyed = true
+		for _, imp := range m.rec.Meta.Imports {
+			dep := normalizeExportsKey(imp.Path)
+			if _, dup := seen[dep]; dup || dep == path {
+				continue
+			}
+			seen[dep] = struct{}{}
+			switch pos, inOrder := position[dep]; {
+			case inOrder && pos < i:
+				m.deps = append(m.deps, dep)
+			case inOrder:
+				// Зависимость ещё не обработана: порядок нарушен циклом.
+				m.deps = append(m.deps, dep)
+				m.keyed = false
+			default:
+				if _, cyclic := unordered[dep]; cyclic {
+					m.keyed = false
+				} else {
+					m.missing = append(m.missing, dep)
+				}
+			}
+		}
+		sort.Strings(m.missing)
+	}
+
+	var ensure func(path string)
+	ensure = func(path string) {
+		m := mods[path]
+		if m == nil || m.resolved {
+			return
+		}
+		m.resolved = true
+		m.replay = nil
+		for _, dep := range m.deps {
+			ensure(dep)
+		}
+		rec := m.rec
+		mark := rec.Bag.Len()
+		resolveBase := baseDir
+		if rec.builtinRoot != "" {
+			resolveBase = rec.builtinRoot
+		}
+		if exp := resolveModuleRecord(ctx, rec, resolveBase, exports, typeInterner, opts, nil); exp != nil {
+			exports[path] = exp
+		}
+		if rec.builtinRoot != "" {
+			for i := range rec.Symbols {
+				res := rec.Symbols[i]
+				markSymbolsBuiltin(&res)
+				rec.Symbols[i] = res
+			}
+		}
+		m.captured = append([]*diag.Diagnostic(nil), rec.Bag.Items()[mark:]...)
+		m.complete = rec.Bag.Len() < int(rec.Bag.Cap())
+	}
+
+	for _, path := range order {
+		m := mods[path]
+		if m.keyed {
+			if m.key, m.keyed = s.moduleKey(path, m); m.keyed {
+				if entry, hit := s.cache.read(m.key); hit && entry.Module == path {
+					if diags, ok := entry.decodeDiagnostics(s.fs); ok {
+						m.replay = diags
+						s.exportHashes[path] = entry.ExportHash
+						continue
+					}
+				}
+			}
+		}
+		ensure(path)
+		if !m.keyed {
+			continue
+		}
+		lines := renderModuleExports(s.fs, typeInterner, exports[path])
+		exportHash := s.exportHash(path, m, lines)
+		s.exportHashes[path] = exportHash
+		if !m.complete {
+			continue
+		}
+		if entry, ok := encodeDiagCacheEntry(s.fs, path, m.captured); ok {
+			entry.ExportHash = exportHash
+			entry.Exports = lines
+			_ = s.cache.write(m.key, entry) //nolint:errcheck // cache is best-effort
+		}
+	}
+	for path := range unordered {
+		rec := recordFor(records, path)
+		if rec == nil || rec.Meta == nil {
+			continue
+		}
+		for _, dep := range core {
+			ensure(dep)
+		}
+		for _, imp := range rec.Meta.Imports {
+			ensure(normalizeExportsKey(imp.Path))
+		}
+	}
+
+	for _, path := range order {
+		m := mods[path]
+		m.rec.diagCached = true
+		if m.resolved {
+			continue
+		}
+		for _, d := range m.replay {
+			m.rec.Bag.Add(d)
+		}
+		m.rec.replayed = true
+	}
+	return exports
+}
+
+// checkFile runs check, which reports into bag the symbol and sema
+// diagnostics of a standalone file, or replays what it reported in an earlier
+// run. It returns true when the diagnostics were replayed.
+func (s *diagCacheSession) checkFile(modulePath string, file *source.File, bag *diag.Bag, check func()) bool {
+	h := sha256.New()
+	_, _ = h.Write(s.options)
+	fmt.Fprintf(h, "file=%s\nmodule=%s\ncontent=%x\n", file.Path, modulePath, file.Hash)
+	s.writeDiagnostics(h, bag.Items())
+	key := digestOf(h.Sum(nil))
+	if entry, hit := s.cache.read(key); hit {
+		if diags, ok := entry.decodeDiagnostics(s.fs); ok {
+			for _, d := range diags {
+				bag.Add(d)
+			}
+			return true
+		}
+	}
+	mark := bag.Len()
+	check()
+	if bag.Len() >= int(bag.Cap()) {
+		return false
+	}
+	if entry, ok := encodeDiagCacheEntry(s.fs, modulePath, bag.Items()[mark:]); ok {
+		_ = s.cache.write(key, entry) //nolint:errcheck // cache is best-effort
+	}
+	return false
+}
+
+// moduleKey hashes everything that shapes what checking the module reports.
+// ok is false when a dependency has no export hash.
+func (s *diagCacheSession) moduleKey(path string, m *diagCacheModule) (project.Digest, bool) {
+	h := sha256.New()
+	_, _ = h.Write(s.options)
+	rec := m.rec
+	fmt.Fprintf(h, "module=%s\nkind=%d\nnostd=%t\nbuiltin=%s\n", path, rec.Meta.Kind, rec.Meta.NoStd, rec.builtinRoot)
+	for _, f := range rec.Files {
+		if f == nil {
+			continue
+		}
+		fmt.Fprintf(h, "file=%s %x\n", f.Path, f.Hash)
+	}
+	// Диагностики лексера, парсера и графа модулей уже в Bag и влияют на sema
+	// (alien hints смотрят на ошибки файла).
+	s.writeDiagnostics(h, rec.Bag.Items())
+	for _, dep := range m.deps {
+		exportHash, ok := s.exportHashes[dep]
+		if !ok {
+			return project.Digest{}, false
+		}
+		fmt.Fprintf(h, "import=%s %x\n", dep, exportHash)
+	}
+	for _, dep := range m.missing {
+		fmt.Fprintf(h, "missing=%s\n", dep)
+	}
+	return digestOf(h.Sum(nil)), true
+}
+
+// exportHash identifies what dependents can observe of a module: its
+// rendered exports, its declarations with the bodies of non-generic
+// functions left out, and the export hashes of its own imports, since its
+// signatures may name their types. An edit inside such a body keeps the
+// hash, so dependents stay cached.
+func (s *diagCacheSession) exportHash(path string, m *diagCacheModule, lines []string) project.Digest {
+	h := sha256.New()
+	fmt.Fprintf(h, "exports=%s\n", path)
+	for _, line := range lines {
+		fmt.Fprintf(h, "%s\n", line)
+	}
+	writeModuleInterface(h, m.rec)
+	for _, dep := range m.deps {
+		fmt.Fprintf(h, "import=%s %x\n", dep, s.exportHashes[dep])
+	}
+	return digestOf(h.Sum(nil))
+}
+
+func (s *diagCacheSession) writeDiagnostics(h hash.Hash, items []*diag.Diagnostic) {
+	for _, d := range items {
+		fmt.Fprintf(h, "diag=%d %s %s %q\n", d.Severity, d.Code.ID(), spanLabel(s.fs, d.Primary), d.Message)
+	}
+}
+
+// writeModuleInterface hashes the module's source with the bodies of
+// non-generic top-level functions cut out. Offsets of what is kept are hashed
+// too, because dependents' diagnostics may point into the module.
+func writeModuleInterface(h hash.Hash, rec *moduleRecord) {
+	if rec.Builder == nil {
+		return
+	}
+	for i, fileID := range rec.FileIDs {
+		if i >= len(rec.Files) || rec.Files[i] == nil {
+			continue
+		}
+		file := rec.Files[i]
+		fmt.Fprintf(h, "interface=%s\n", file.Path)
+		node := rec.Builder.Files.Get(fileID)
+		if node == nil {
+			_, _ = h.Write(file.Content)
+			continue
+		}
+		var bodies []source.Span
+		for _, itemID := range node.Items {
+			fn, ok := rec.Builder.Items.Fn(itemID)
+			if !ok || fn == nil || len(fn.Generics) > 0 || fn.TypeParamsCount > 0 || !fn.Body.IsValid() {
+				continue
+			}
+			if body := rec.Builder.Stmts.Get(fn.Body); body != nil {
+				bodies = append(bodies, body.Span)
+			}
+		}
+		sort.Slice(bodies, func(a, b int) bool { return bodies[a].Start < bodies[b].Start })
+		pos := uint32(0)
+		size := uint32(len(file.Content)) //nolint:gosec // source files are far below 4GiB
+		for _, body := range bodies {
+			if body.Start < pos || body.End > size || body.Start > body.End {
+				continue
+			}
+			fmt.Fprintf(h, "@%d:", pos)
+			_, _ = h.Write(file.Content[pos:body.Start])
+			pos = body.End
+		}
+		fmt.Fprintf(h, "@%d:", pos)
+		_, _ = h.Write(file.Content[pos:])
+	}
+}
+
+// renderModuleExports renders exports without type or string IDs, which
+// depend on the order modules were checked in.
+func renderModuleExports(fs *source.FileSet, typesIn *types.Interner, exp *symbols.ModuleExports) []string {
+	if exp == nil {
+		return nil
+	}
+	lines := make([]string, 0, len(exp.Symbols)+1)
+	lines = append(lines, fmt.Sprintf("pragma=%d", exp.PragmaFlags))
+	for name, set := range exp.Symbols {
+		for i := range set {
+			sym := &set[i]
+			var b strings.Builder
+			fmt.Fprintf(&b, "%s %s flags=%d at=%s", name, sym.Kind, sym.Flags, spanLabel(fs, sym.Span))
+			if sig := sym.Signature; sig != nil {
+				fmt.Fprintf(&b, " fn%v->%s variadic=%v defaults=%v allow_to=%v self=%t body=%t",
+					sig.Params, sig.Result, sig.Variadic, sig.Defaults, sig.AllowTo, sig.HasSelf, sig.HasBody)
+			}
+			if sym.ReceiverKey != "" {
+				fmt.Fprintf(&b, " recv=%s", sym.ReceiverKey)
+			}
+			if len(sym.TypeParamNames) > 0 {
+				fmt.Fprintf(&b, " params=%v", sym.TypeParamNames)
+			}
+			if len(sym.TypeAttrNames) > 0 {
+				fmt.Fprintf(&b, " attrs=%v", sym.TypeAttrNames)
+			}
+			if sym.Type != types.NoTypeID {
+				fmt.Fprintf(&b, " type=%s", types.Label(typesIn, sym.Type))
+			}
+			if sym.Contract != nil {
+				fmt.Fprintf(&b, " contract=%d/%d", len(sym.Contract.Fields), len(sym.Contract.Methods))
+			}
+			lines = append(lines, b.String())
+		}
+	}
+	sort.Strings(lines[1:])
+	return lines
+}
+
+func spanLabel(fs *source.FileSet, span source.Span) string {
+	path := "?"
+	if fs != nil && fs.HasFile(span.File) {
+		path = fs.Get(span.File).Path
+	}
+	return fmt.Sprintf("%s:%d-%d", path, span.Start, span.End)
+}
+
+func recordFor(records map[string]*moduleRecord, path string) *moduleRecord {
+	if rec := records[path]; rec != nil {
+		return rec
+	}
+	return records[normalizeExportsKey(path)]
+}
+
+func digestOf(sum []byte) project.Digest {
+	var out project.Digest
+	copy(out[:], sum)
+	return out
+}
+
+// checkedModules lists the modules whose symbols and sema ran in this run.
+func checkedModules(records map[string]*moduleRecord) []string {
+	var out []string
+	for path, rec := range records {
+		if rec != nil && rec.Sema != nil {
+			out = append(out, path)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -131,6 +131,11 @@ func loadStdModule(
 		FileIDs:  fileIDs,
 		Files:    files,
 	}
+	if diagCacheable(opts) {
+		// Сессия кэша диагностик разрешит модуль сама, если он кому-то нужен.
+		rec.builtinRoot = stdlibRoot
+		return rec, nil
+	}
 	exports := resolveModuleRecord(ctx, rec, stdlibRoot, moduleExports, typeInterner, opts, nil)
 	if exports != nil {
 		rec.Exports = exports
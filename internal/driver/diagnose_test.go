@@ -371,6 +371,52 @@ fn main() -> int {
 	}
 }
 
+func TestDiagnoseReportsUseAfterMoveIntoFreeTrySend(t *testing.T) {
+	stdlibRoot := detectStdlibRootFrom(".")
+	if stdlibRoot == "" {
+		t.Fatal("failed to locate stdlib root for test")
+	}
+	t.Setenv("SURGE_STDLIB", stdlibRoot)
+
+	src := `
+@entrypoint
+fn main() -> int {
+    let ch = make_channel::<string>(1:uint);
+    let msg: string = "hello";
+    try_send(&ch, own msg);
+    print(msg);
+    return 0;
+}
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "try_send_move.sg")
+	if writeErr := os.WriteFile(path, []byte(src), 0o600); writeErr != nil {
+		t.Fatalf("write file: %v", writeErr)
+	}
+
+	opts := DiagnoseOptions{
+		Stage:          DiagnoseStageAll,
+		MaxDiagnostics: 8,
+	}
+
+	res, err := DiagnoseWithOptions(context.Background(), path, &opts)
+	if err != nil {
+		t.Fatalf("DiagnoseWithOptions error: %v", err)
+	}
+
+	found := false
+	for _, d := range res.Bag.Items() {
+		if d.Code == diag.SemaUseAfterMove && strings.Contains(d.Message, "msg") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("expected use-after-move of the value passed to try_send, got %+v", res.Bag.Items())
+	}
+}
+
 func TestDiagnoseAllowsRetInBlockExpression(t *testing.T) {
 	src := `
 fn main() -> int {
@@ -0,0 +1,294 @@
+package driver
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/dialect"
+	"surge/internal/fix"
+	"surge/internal/source"
+)
+
+// HintDialect enables dialect hints (`surge diag --hints=dialect`).
+const HintDialect = "dialect"
+
+// dialectHintMinScore is the score the dominant dialect needs before hints are
+// shown; the runner-up must trail it by dialectHintMargin.
+const (
+	dialectHintMinScore = 4
+	dialectHintMargin   = 2
+)
+
+// dialectHintRule turns one kind of dialect evidence into an info diagnostic.
+type dialectHintRule struct {
+	code    diag.Code
+	dialect dialect.Kind
+	match   func(dialect.Hint) bool
+	message string
+	// fix builds a mechanical rewrite for the hint; nil when there is none.
+	fix func(src []byte, h dialect.Hint) *diag.Fix
+}
+
+var dialectHintRules = []dialectHintRule{
+	{
+		code:    diag.AlnRustBox,
+		dialect: dialect.Rust,
+		match:   reasonContains("rust type `Box`"),
+		message: "this looks like Rust; Surge uses `own T` instead of `Box<T>`",
+		fix:     fixRustBox,
+	},
+	{
+		code:    diag.AlnRustMacroCall,
+		dialect: dialect.Rust,
+		match:   reasonContains("rust macro call"),
+		message: "this looks like Rust; Surge has no `!` macros, call the function directly",
+		fix:     fixRustMacroCall,
+	},
+	{
+		code:    diag.AlnRustAttribute,
+		dialect: dialect.Rust,
+		match:   reasonContains("`#[...]`"),
+		message: "this looks like Rust; Surge attributes are written as `@name`",
+		fix:     fixRustAttribute,
+	},
+	{
+		code:    diag.AlnRustImplTrait,
+		dialect: dialect.Rust,
+		match:   reasonContains("rust keyword `impl`", "rust keyword `trait`"),
+		message: "this looks like Rust; Surge uses `contract` and `extern<T>` blocks instead of `trait` and `impl`",
+	},
+	{
+		code:    diag.AlnGoShortVarDecl,
+		dialect: dialect.Go,
+		match:   reasonContains("`:=`"),
+		message: "this looks like Go; Surge declares variables with `let x = ...`",
+		fix:     fixGoShortVarDecl,
+	},
+	{
+		code:    diag.AlnGoFunc,
+		dialect: dialect.Go,
+		match:   reasonContains("go keyword `func`"),
+		message: "this looks like Go; Surge declares functions with `fn`",
+		fix:     replaceHintText(diag.AlnGoFunc, "func", "fn"),
+	},
+	{
+		code:    diag.AlnGoDefer,
+		dialect: dialect.Go,
+		match:   reasonContains("go keyword `defer`"),
+		message: "this looks like Go; Surge has no `defer`, use explicit cleanup or a `@raii` type",
+	},
+	{
+		code:    diag.AlnTSInterface,
+		dialect: dialect.TypeScript,
+		match:   reasonContains("typescript keyword `interface`"),
+		message: "this looks like TypeScript; Surge uses `contract` for interfaces",
+	},
+	{
+		code:    diag.AlnPythonNoneType,
+		dialect: dialect.Python,
+		match:   reasonContains("python `None`"),
+		message: "this looks like Python; Surge spells the absent value `nothing`",
+		fix:     replaceHintText(diag.AlnPythonNoneType, "None", "nothing"),
+	},
+}
+
+// emitDialectHints reports info diagnostics for the foreign-dialect evidence the
+// lexer collected while parsing. It only reads the evidence, so parsing and
+// semantic results are unaffected. Hints already reported at the same span by
+// sema alien hints are skipped.
+func emitDialectHints(builder *ast.Builder, fileID ast.FileID, file *source.File, bag *diag.Bag) {
+	if builder == nil || file == nil || bag == nil {
+		return
+	}
+	astFile := builder.Files.Get(fileID)
+	if astFile == nil || astFile.DialectEvidence == nil {
+		return
+	}
+	class := (dialect.Classifier{}).Classify(astFile.DialectEvidence)
+	if class.Kind == dialect.Unknown || class.Score < dialectHintMinScore {
+		return
+	}
+	if class.RunnerUpScore > 0 && class.Score < class.RunnerUpScore+dialectHintMargin {
+		return
+	}
+
+	seen := make(map[string]struct{})
+	for _, d := range bag.Items() {
+		seen[dialectHintKey(d.Code, d.Primary)] = struct{}{}
+	}
+	reporter := &diag.BagReporter{Bag: bag}
+	for _, h := range astFile.DialectEvidence.Hints() {
+		if h.Dialect != class.Kind || h.Span.File != file.ID {
+			continue
+		}
+		for i := range dialectHintRules {
+			rule := &dialectHintRules[i]
+			if rule.dialect != h.Dialect || !rule.match(h) {
+				continue
+			}
+			key := dialectHintKey(rule.code, h.Span)
+			if _, dup := seen[key]; dup {
+				break
+			}
+			seen[key] = struct{}{}
+			b := diag.ReportInfo(reporter, rule.code, h.Span, rule.message)
+			if b == nil {
+				break
+			}
+			if rule.fix != nil {
+				if f := rule.fix(file.Content, h); f != nil {
+					b.WithFixSuggestion(f)
+				}
+			}
+			b.Emit()
+			break
+		}
+	}
+}
+
+func dialectHintKey(code diag.Code, span source.Span) string {
+	return fmt.Sprintf("%s:%s", code.String(), span.String())
+}
+
+func reasonContains(parts ...string) func(dialect.Hint) bool {
+	return func(h dialect.Hint) bool {
+		for _, part := range parts {
+			if strings.Contains(h.Reason, part) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func hintText(src []byte, sp source.Span) string {
+	if int(sp.End) > len(src) || sp.Start > sp.End {
+		return ""
+	}
+	return string(src[sp.Start:sp.End])
+}
+
+func dialectFix(title string, code diag.Code, spans []source.Span, newTexts, expects []string) *diag.Fix {
+	return fix.ReplaceSpans(
+		title,
+		spans,
+		newTexts,
+		expects,
+		fix.WithID(fix.MakeFixID(code, spans[0])),
+		fix.WithApplicability(diag.FixApplicabilitySafeWithHeuristics),
+	)
+}
+
+// replaceHintText rewrites the hinted word when the source spells it exactly.
+func replaceHintText(code diag.Code, from, to string) func([]byte, dialect.Hint) *diag.Fix {
+	return func(src []byte, h dialect.Hint) *diag.Fix {
+		if hintText(src, h.Span) != from {
+			return nil
+		}
+		title := fmt.Sprintf("replace `%s` with `%s`", from, to)
+		return dialectFix(title, code, []source.Span{h.Span}, []string{to}, []string{from})
+	}
+}
+
+// fixRustBox rewrites `Box<T>` into `own T`.
+func fixRustBox(src []byte, h dialect.Hint) *diag.Fix {
+	if hintText(src, h.Span) != "Box" || int(h.Span.End) >= len(src) || src[h.Span.End] != '<' {
+		return nil
+	}
+	closeAt := matchingClose(src, int(h.Span.End), '<', '>')
+	if closeAt < 0 {
+		return nil
+	}
+	open := source.Span{File: h.Span.File, Start: h.Span.Start, End: h.Span.End + 1}
+	closing := source.Span{File: h.Span.File, Start: uint32(closeAt), End: uint32(closeAt + 1)} //nolint:gosec // closeAt < len(src)
+	return dialectFix("replace `Box<T>` with `own T`", diag.AlnRustBox,
+		[]source.Span{open, closing}, []string{"own ", ""}, []string{"Box<", ">"})
+}
+
+// fixRustMacroCall drops the `!` of `name!(...)`; `println!` becomes `print`.
+func fixRustMacroCall(src []byte, h dialect.Hint) *diag.Fix {
+	text := hintText(src, h.Span)
+	name, ok := strings.CutSuffix(text, "!")
+	if !ok || name == "" {
+		return nil
+	}
+	if name == "println" {
+		name = "print"
+	}
+	return dialectFix(fmt.Sprintf("call `%s` as a function", name), diag.AlnRustMacroCall,
+		[]source.Span{h.Span}, []string{name}, []string{text})
+}
+
+// fixRustAttribute rewrites `#[attr]` into `@attr` when the brackets close on
+// the same line.
+func fixRustAttribute(src []byte, h dialect.Hint) *diag.Fix {
+	if hintText(src, h.Span) != "#[" {
+		return nil
+	}
+	closeAt := matchingClose(src, int(h.Span.End)-1, '[', ']')
+	if closeAt < 0 {
+		return nil
+	}
+	closing := source.Span{File: h.Span.File, Start: uint32(closeAt), End: uint32(closeAt + 1)} //nolint:gosec // closeAt < len(src)
+	return dialectFix("rewrite as a Surge attribute", diag.AlnRustAttribute,
+		[]source.Span{h.Span, closing}, []string{"@", ""}, []string{"#[", "]"})
+}
+
+// fixGoShortVarDecl rewrites `x := value` into `let x = value` when a single
+// identifier stands before `:=`.
+func fixGoShortVarDecl(src []byte, h dialect.Hint) *diag.Fix {
+	if hintText(src, h.Span) != ":=" {
+		return nil
+	}
+	end := int(h.Span.Start)
+	for end > 0 && (src[end-1] == ' ' || src[end-1] == '\t') {
+		end--
+	}
+	start := end
+	for start > 0 && isIdentByte(src[start-1]) {
+		start--
+	}
+	if start == end || !isIdentStart(src[start]) {
+		return nil
+	}
+	lineStart := bytes.LastIndexByte(src[:start], '\n') + 1
+	if len(bytes.TrimSpace(src[lineStart:start])) != 0 {
+		return nil
+	}
+	sp := source.Span{File: h.Span.File, Start: uint32(start), End: h.Span.End} //nolint:gosec // start < len(src)
+	old := string(src[start:h.Span.End])
+	name := string(src[start:end])
+	return dialectFix("declare with `let`", diag.AlnGoShortVarDecl,
+		[]source.Span{sp}, []string{"let " + name + " ="}, []string{old})
+}
+
+// matchingClose returns the offset of the bracket closing the one at openAt,
+// searching only up to the end of the line.
+func matchingClose(src []byte, openAt int, open, closing byte) int {
+	depth := 0
+	for i := openAt; i < len(src); i++ {
+		switch src[i] {
+		case '\n':
+			return -1
+		case open:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentByte(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
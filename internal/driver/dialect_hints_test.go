@@ -0,0 +1,120 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func writeDialectFixture(t *testing.T, name, src string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name+".sg")
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	return path
+}
+
+func diagnoseDialectFixture(t *testing.T, path string, hints bool) *DiagnoseResult {
+	t.Helper()
+	opts := DiagnoseOptions{
+		Stage:          DiagnoseStageAll,
+		MaxDiagnostics: 50,
+		DialectHints:   hints,
+	}
+	res, err := DiagnoseWithOptions(context.Background(), path, &opts)
+	if err != nil {
+		t.Fatalf("DiagnoseWithOptions error: %v", err)
+	}
+	if res.Bag == nil {
+		t.Fatalf("missing diagnostic bag: %+v", res)
+	}
+	return res
+}
+
+func findDiagnostic(items []*diag.Diagnostic, code diag.Code) *diag.Diagnostic {
+	for _, d := range items {
+		if d != nil && d.Code == code {
+			return d
+		}
+	}
+	return nil
+}
+
+func TestDialectHintsRustSnippet(t *testing.T) {
+	src := `fn make(x: Box<int>) -> int {
+    println!("hi");
+    return 1;
+}
+`
+	res := diagnoseDialectFixture(t, writeDialectFixture(t, "rust", src), true)
+	items := res.Bag.Items()
+
+	box := findDiagnostic(items, diag.AlnRustBox)
+	if box == nil {
+		t.Fatalf("expected %s, got %+v", diag.AlnRustBox.ID(), items)
+	}
+	if box.Severity != diag.SevInfo {
+		t.Fatalf("expected info severity, got %v", box.Severity)
+	}
+	if len(box.Fixes) != 1 || len(box.Fixes[0].Edits) != 2 {
+		t.Fatalf("expected a two-edit Box fix, got %+v", box.Fixes)
+	}
+	if got := box.Fixes[0].Edits[0].NewText; got != "own " {
+		t.Fatalf("unexpected Box fix text %q", got)
+	}
+
+	if findDiagnostic(items, diag.AlnRustMacroCall) == nil {
+		t.Fatalf("expected %s, got %+v", diag.AlnRustMacroCall.ID(), items)
+	}
+}
+
+func TestDialectHintsGoSnippet(t *testing.T) {
+	src := `func main() {
+    x := 1;
+}
+`
+	res := diagnoseDialectFixture(t, writeDialectFixture(t, "gogo", src), true)
+	items := res.Bag.Items()
+
+	fn := findDiagnostic(items, diag.AlnGoFunc)
+	if fn == nil {
+		t.Fatalf("expected %s, got %+v", diag.AlnGoFunc.ID(), items)
+	}
+	if len(fn.Fixes) != 1 || fn.Fixes[0].Edits[0].NewText != "fn" {
+		t.Fatalf("expected `func` -> `fn` fix, got %+v", fn.Fixes)
+	}
+
+	decl := findDiagnostic(items, diag.AlnGoShortVarDecl)
+	if decl == nil {
+		t.Fatalf("expected %s, got %+v", diag.AlnGoShortVarDecl.ID(), items)
+	}
+	if len(decl.Fixes) != 1 || decl.Fixes[0].Edits[0].NewText != "let x =" {
+		t.Fatalf("expected `let x =` fix, got %+v", decl.Fixes)
+	}
+}
+
+func TestDialectHintsDisabledByDefault(t *testing.T) {
+	src := `func main() {
+    x := 1;
+}
+`
+	path := writeDialectFixture(t, "gogo", src)
+	withHints := diagnoseDialectFixture(t, path, true)
+	without := diagnoseDialectFixture(t, path, false)
+	for _, code := range []diag.Code{diag.AlnGoFunc, diag.AlnGoShortVarDecl, diag.AlnRustBox} {
+		if findDiagnostic(without.Bag.Items(), code) != nil {
+			t.Fatalf("unexpected %s without --hints=dialect", code.ID())
+		}
+	}
+
+	// Подсказки не должны влиять на разбор: остальные диагностики совпадают.
+	base := diag.FormatGoldenDiagnostics(filterOutAlienHintDiagnostics(withHints.Bag.Items()), withHints.FileSet, false)
+	plain := diag.FormatGoldenDiagnostics(filterOutAlienHintDiagnostics(without.Bag.Items()), without.FileSet, false)
+	if base != plain {
+		t.Fatalf("dialect hints changed base diagnostics:\n--- with hints ---\n%s\n--- without ---\n%s", base, plain)
+	}
+}
@@ -39,6 +39,14 @@ type FormatResult struct {
 // would update the file contents. When opts.Stdout is true, formatted content is returned
 // in the results without touching files on disk.
 func FormatPaths(ctx context.Context, paths []string, opts FormatOptions) ([]FormatResult, error) {
+	return rewritePaths(ctx, paths, opts.Check, opts.Stdout, func(path string) ([]byte, bool, error) {
+		return formatSingleFile(path, opts)
+	})
+}
+
+// rewritePaths applies rewrite to every collected source file and either reports,
+// returns or writes back the result depending on check and stdout.
+func rewritePaths(ctx context.Context, paths []string, check, stdout bool, rewrite func(path string) ([]byte, bool, error)) ([]FormatResult, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -58,20 +66,20 @@ func FormatPaths(ctx context.Context, paths []string, opts FormatOptions) ([]For
 		}
 
 		result := FormatResult{Path: path}
-		formatted, changed, err := formatSingleFile(path, opts)
+		formatted, changed, err := rewrite(path)
 		if err != nil {
 			result.Err = err
 			results = append(results, result)
 			continue
 		}
 
-		if opts.Check {
+		if check {
 			result.Changed = changed
 			results = append(results, result)
 			continue
 		}
 
-		if opts.Stdout {
+		if stdout {
 			result.Formatted = formatted
 			result.Changed = changed
 			results = append(results, result)
@@ -102,11 +110,26 @@ func formatSingleFile(path string, opts FormatOptions) (formatted []byte, change
 		return nil, false, err
 	}
 
+	sf, builder, fileID, err := parseForFormat(path, data, opts.MaxDiagnostics)
+	if err != nil {
+		return nil, false, err
+	}
+
+	formatted, err = format.FormatFile(sf, builder, fileID, opts.Options)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed = !bytesEqual(sf.Content, formatted)
+	return formatted, changed, nil
+}
+
+// parseForFormat parses a single file in isolation for source-to-source passes.
+func parseForFormat(path string, data []byte, maxDiag int) (*source.File, *ast.Builder, ast.FileID, error) {
 	fileSet := source.NewFileSet()
 	fileID := fileSet.Add(path, data, 0)
 	sf := fileSet.Get(fileID)
 
-	maxDiag := opts.MaxDiagnostics
 	if maxDiag <= 0 {
 		maxDiag = 256
 	}
@@ -120,16 +143,9 @@ func formatSingleFile(path string, opts FormatOptions) (formatted []byte, change
 	}
 	parseRes := parser.ParseFile(context.Background(), fileSet, lx, builder, parser.Options{Reporter: &diag.BagReporter{Bag: bag}, MaxErrors: maxErrors})
 	if bag.HasErrors() {
-		return nil, false, errors.New("format: parse errors present")
-	}
-
-	formatted, err = format.FormatFile(sf, builder, parseRes.File, opts.Options)
-	if err != nil {
-		return nil, false, err
+		return nil, nil, ast.NoFileID, errors.New("format: parse errors present")
 	}
-
-	changed = !bytesEqual(sf.Content, formatted)
-	return formatted, changed, nil
+	return sf, builder, parseRes.File, nil
 }
 
 func collectSourceFiles(ctx context.Context, paths []string) ([]string, error) {
@@ -532,6 +532,7 @@ func remapExpr(expr *hir.Expr, mapping map[symbols.SymbolID]symbols.SymbolID, st
 		if !ok {
 			return
 		}
+		remapExpr(data.Base, mapping, state)
 		for i := range data.Fields {
 			remapExpr(data.Fields[i].Value, mapping, state)
 		}
@@ -640,6 +641,9 @@ func remapExpr(expr *hir.Expr, mapping map[symbols.SymbolID]symbols.SymbolID, st
 			return
 		}
 		remapBlock(data.Body, mapping, state)
+		for i := range data.Captures {
+			data.Captures[i].SymbolID = remapSymbol(data.Captures[i].SymbolID, mapping)
+		}
 		expr.Data = data
 	case hir.ExprBlocking:
 		data, ok := expr.Data.(hir.BlockingData)
@@ -651,6 +655,22 @@ func remapExpr(expr *hir.Expr, mapping map[symbols.SymbolID]symbols.SymbolID, st
 			data.Captures[i].SymbolID = remapSymbol(data.Captures[i].SymbolID, mapping)
 		}
 		expr.Data = data
+	case hir.ExprParallel:
+		data, ok := expr.Data.(hir.ParallelData)
+		if !ok {
+			return
+		}
+		for i := range data.Args {
+			data.Args[i].SymbolID = remapSymbol(data.Args[i].SymbolID, mapping)
+		}
+		remapExpr(data.Iterable, mapping, state)
+		remapExpr(data.Init, mapping, state)
+		remapExpr(data.Body, mapping, state)
+		remapBlock(data.Seq, mapping, state)
+		for i := range data.Captures {
+			data.Captures[i].SymbolID = remapSymbol(data.Captures[i].SymbolID, mapping)
+		}
+		expr.Data = data
 	case hir.ExprCast:
 		data, ok := expr.Data.(hir.CastData)
 		if !ok {
@@ -135,6 +135,56 @@ fn use_game() -> int {
 	}
 }
 
+func TestDiagnoseReportsMissingRelativeImportWithPath(t *testing.T) {
+	stdlibRoot := detectStdlibRootFrom(".")
+	if stdlibRoot == "" {
+		t.Skip("stdlib root not found")
+	}
+	t.Setenv("SURGE_STDLIB", stdlibRoot)
+
+	root, err := os.MkdirTemp(".", "relative-import-")
+	if err != nil {
+		t.Fatalf("mkdir temp project: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(root)
+	})
+
+	writeTestFile(t, filepath.Join(root, "sub", "present.sg"), `
+pub fn value() -> int {
+    return 1;
+}
+`)
+	mainPath := filepath.Join(root, "main.sg")
+	writeTestFile(t, mainPath, `
+import ./sub/present;
+import ./sub/absent;
+
+fn run() -> int {
+    return present.value();
+}
+`)
+	resetExplicitModuleDirCacheForTest()
+
+	opts := DiagnoseOptions{Stage: DiagnoseStageSema, MaxDiagnostics: 32}
+	res, err := DiagnoseWithOptions(t.Context(), mainPath, &opts)
+	if err != nil {
+		t.Fatalf("DiagnoseWithOptions error: %v", err)
+	}
+	if hasDiagCode(res.Bag, diag.ProjMissingModule) {
+		t.Fatalf("unexpected PRJ5002 for relative import, got %v", bagMessages(res.Bag))
+	}
+	var found []string
+	for _, d := range res.Bag.Items() {
+		if d.Code == diag.SemaModuleNotFound {
+			found = append(found, d.Message)
+		}
+	}
+	if len(found) != 1 || !strings.Contains(found[0], filepath.Join("sub", "absent.sg")) {
+		t.Fatalf("expected one SemaModuleNotFound for sub/absent, got %v", bagMessages(res.Bag))
+	}
+}
+
 func writeTestFile(t *testing.T, path, src string) {
 	t.Helper()
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
@@ -7,6 +7,7 @@ import (
 	"surge/internal/diag"
 	"surge/internal/project"
 	"surge/internal/source"
+	"surge/internal/symbols"
 )
 
 func ensureModuleMapping(opts *DiagnoseOptions, startDir string) error {
@@ -74,37 +75,51 @@ func logicalPathForDir(path, baseDir string, mapping *project.ModuleMapping) str
 	return logicalPathForFile(path, baseDir, mapping)
 }
 
+// missingOverride replaces the code and message of a missing-module diagnostic.
+type missingOverride struct {
+	code diag.Code
+	msg  string
+}
+
 type overrideReporter struct {
 	base      diag.Reporter
-	overrides map[source.Span]string
+	overrides map[source.Span]missingOverride
 }
 
-// Report implements diag.Reporter, overriding missing-module messages when needed.
+// Report implements diag.Reporter, overriding missing-module diagnostics when needed.
 func (r *overrideReporter) Report(code diag.Code, sev diag.Severity, span source.Span, msg string, notes []diag.Note, fixes []*diag.Fix) {
 	if code == diag.ProjMissingModule {
 		if override, ok := r.overrides[span]; ok {
-			msg = override
+			code, msg = override.code, override.msg
 		}
 	}
 	r.base.Report(code, sev, span, msg, notes, fixes)
 }
 
-func missingModuleOverrides(records map[string]*moduleRecord, mapping *project.ModuleMapping) map[source.Span]string {
-	if mapping == nil || len(mapping.Missing) == 0 {
-		return nil
-	}
-	overrides := make(map[source.Span]string)
+// missingModuleOverrides prepares replacements for missing-module diagnostics:
+// imports of aliases the module mapping could not locate get the mapping's
+// message, and relative imports are reported as SemaModuleNotFound with the
+// file path that was tried.
+func missingModuleOverrides(records map[string]*moduleRecord, mapping *project.ModuleMapping, baseDir string) map[source.Span]missingOverride {
+	overrides := make(map[source.Span]missingOverride)
 	for _, rec := range records {
 		if rec == nil || rec.Meta == nil {
 			continue
 		}
 		for _, imp := range rec.Meta.Imports {
-			alias, _ := splitModulePath(imp.Path)
-			if alias == "" {
-				continue
+			if mapping != nil && len(mapping.Missing) > 0 {
+				if alias, _ := splitModulePath(imp.Path); alias != "" {
+					if msg, ok := mapping.Missing[alias]; ok {
+						overrides[imp.Span] = missingOverride{code: diag.ProjMissingModule, msg: msg}
+						continue
+					}
+				}
 			}
-			if msg, ok := mapping.Missing[alias]; ok {
-				overrides[imp.Span] = msg
+			if imp.IsRelative && imp.Path != "" {
+				overrides[imp.Span] = missingOverride{
+					code: diag.SemaModuleNotFound,
+					msg:  symbols.ModuleNotFoundMessage(baseDir, imp.Path),
+				}
 			}
 		}
 	}
@@ -114,7 +129,7 @@ func missingModuleOverrides(records map[string]*moduleRecord, mapping *project.M
 	return overrides
 }
 
-func wrapMissingModuleReporter(reporter diag.Reporter, overrides map[source.Span]string) diag.Reporter {
+func wrapMissingModuleReporter(reporter diag.Reporter, overrides map[source.Span]missingOverride) diag.Reporter {
 	if reporter == nil || len(overrides) == 0 {
 		return reporter
 	}
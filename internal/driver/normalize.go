@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"context"
+	"os"
+
+	"surge/internal/ast"
+	"surge/internal/format"
+	"surge/internal/source"
+)
+
+// NormalizeOptions selects the source-to-source passes run by NormalizePaths.
+type NormalizeOptions struct {
+	Check          bool
+	MaxDiagnostics int
+	Stdout         bool
+	// Imports sorts and groups top-level imports.
+	Imports bool
+	// Commas normalizes spacing around commas in parameter and argument lists.
+	Commas bool
+}
+
+// NormalizePaths applies the selected normalization passes to files or
+// directories. Unlike FormatPaths it only touches the constructs handled by the
+// enabled passes and leaves the rest of the file as written. Check and Stdout
+// behave as in FormatPaths.
+func NormalizePaths(ctx context.Context, paths []string, opts NormalizeOptions) ([]FormatResult, error) {
+	return rewritePaths(ctx, paths, opts.Check, opts.Stdout, func(path string) ([]byte, bool, error) {
+		return normalizeSingleFile(path, opts)
+	})
+}
+
+func normalizeSingleFile(path string, opts NormalizeOptions) (normalized []byte, changed bool, err error) {
+	// #nosec G304 -- path comes from user-provided formatting arguments
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	normalized = data
+	// Каждый проход работает по спанам своего AST, поэтому после изменения
+	// файл разбирается заново.
+	if opts.Imports {
+		if normalized, err = normalizePass(path, normalized, opts.MaxDiagnostics, format.SortImports); err != nil {
+			return nil, false, err
+		}
+	}
+	if opts.Commas {
+		if normalized, err = normalizePass(path, normalized, opts.MaxDiagnostics, format.NormalizeCommas); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return normalized, !bytesEqual(data, normalized), nil
+}
+
+func normalizePass(path string, data []byte, maxDiag int, pass func(*source.File, *ast.Builder, ast.FileID) []byte) ([]byte, error) {
+	sf, builder, fileID, err := parseForFormat(path, data, maxDiag)
+	if err != nil {
+		return nil, err
+	}
+	return pass(sf, builder, fileID), nil
+}
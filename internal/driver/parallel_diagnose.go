@@ -102,6 +102,9 @@ func DiagnoseFilesWithOptions(ctx context.Context, baseDir string, files []strin
 		}
 	}
 
+	// Кэш диагностик (--cache-dir): воспроизводит symbols/sema неизменённых файлов.
+	diagSession := newDiagCacheSession(fileSet, opts)
+
 	// Metrics for parallel processing (Phase 6)
 	var metrics parallelMetrics
 
@@ -239,19 +242,29 @@ func DiagnoseFilesWithOptions(ctx context.Context, baseDir string, files []strin
 					end(parseIdx, parseNote)
 					if opts.Stage == DiagnoseStageSema || opts.Stage == DiagnoseStageAll {
 						if !opts.FullModuleGraph {
-							symbolIdx := begin("symbols")
-							symbolsRes = diagnoseSymbols(builder, astFile, bag, modulePath, file.Path, fileSet.BaseDir(), nil)
-							symbolNote := ""
-							if timer != nil && symbolsRes != nil && symbolsRes.Table != nil {
-								symbolNote = fmt.Sprintf("symbols=%d", symbolsRes.Table.Symbols.Len())
+							check := func() {
+								symbolIdx := begin("symbols")
+								symbolsRes = diagnoseSymbols(builder, astFile, bag, modulePath, file.Path, fileSet.BaseDir(), nil, opts.Cfg)
+								symbolNote := ""
+								if timer != nil && symbolsRes != nil && symbolsRes.Table != nil {
+									symbolNote = fmt.Sprintf("symbols=%d", symbolsRes.Table.Symbols.Len())
+								}
+								end(symbolIdx, symbolNote)
+
+								semaIdx := begin("sema")
+								semaRes = diagnoseSema(ctx, builder, astFile, bag, nil, symbolsRes, !opts.NoAlienHints, nil)
+								end(semaIdx, "")
+							}
+							if diagSession != nil {
+								diagSession.checkFile(modulePath, file, bag, check)
+							} else {
+								check()
 							}
-							end(symbolIdx, symbolNote)
-
-							semaIdx := begin("sema")
-							semaRes = diagnoseSema(ctx, builder, astFile, bag, nil, symbolsRes, !opts.NoAlienHints, nil)
-							end(semaIdx, "")
 						}
 					}
+					if opts.DialectHints {
+						emitDialectHints(builder, astFile, file, bag)
+					}
 				}
 
 				results[i] = DiagnoseDirResult{
@@ -302,6 +315,7 @@ func DiagnoseFilesWithOptions(ctx context.Context, baseDir string, files []strin
 		}
 		collectIdx := beginGraph("collect_modules")
 		entries := make([]*entry, 0, len(results))
+		leaves := make(map[string]*entry)
 		var independentCount, stdlibOnlyCount, dependentCount int
 		// Собираем метаданные: либо из кэша, либо из свежего парсинга, либо fallback.
 		for i := range results {
@@ -376,16 +390,20 @@ func DiagnoseFilesWithOptions(ctx context.Context, baseDir string, files []strin
 
 			// Only add files with project dependencies to the module graph
 			// Independent and stdlib-only files don't need dependency analysis
+			// unless another file imports them (see below).
+			e := &entry{
+				meta: meta,
+				node: dag.ModuleNode{
+					Meta:     meta,
+					Reporter: reporter,
+					Broken:   broken,
+					FirstErr: firstErr,
+				},
+			}
 			if fileClass == FileDependent {
-				entries = append(entries, &entry{
-					meta: meta,
-					node: dag.ModuleNode{
-						Meta:     meta,
-						Reporter: reporter,
-						Broken:   broken,
-						FirstErr: firstErr,
-					},
-				})
+				entries = append(entries, e)
+			} else if meta != nil {
+				leaves[meta.Path] = e
 			}
 
 			// Положим в in-memory cache (обновление/вставка).
@@ -400,6 +418,19 @@ func DiagnoseFilesWithOptions(ctx context.Context, baseDir string, files []strin
 				_ = dcache.Put(meta.ContentHash, payload) //nolint:errcheck // Cache is best-effort, errors are acceptable
 			}
 		}
+		// Импортируемые модули без собственных зависимостей тоже нужны графу,
+		// иначе импорт на них выглядит как ссылка на отсутствующий модуль.
+		for i := 0; i < len(entries); i++ {
+			if entries[i].meta == nil {
+				continue
+			}
+			for _, imp := range entries[i].meta.Imports {
+				if leaf, ok := leaves[imp.Path]; ok {
+					entries = append(entries, leaf)
+					delete(leaves, imp.Path)
+				}
+			}
+		}
 		collectNote := ""
 		if opts.EnableTimings {
 			collectNote = fmt.Sprintf("total=%d graph=%d (indep=%d stdlib=%d)",
@@ -409,17 +440,14 @@ func DiagnoseFilesWithOptions(ctx context.Context, baseDir string, files []strin
 		endGraph(collectIdx, collectNote)
 		var graphErr error
 		if len(entries) > 0 {
-			var overrides map[source.Span]string
-			if opts.ModuleMapping != nil {
-				records := make(map[string]*moduleRecord, len(entries))
-				for _, e := range entries {
-					if e.meta == nil {
-						continue
-					}
-					records[e.meta.Path] = &moduleRecord{Meta: e.meta}
+			records := make(map[string]*moduleRecord, len(entries))
+			for _, e := range entries {
+				if e.meta == nil {
+					continue
 				}
-				overrides = missingModuleOverrides(records, opts.ModuleMapping)
+				records[e.meta.Path] = &moduleRecord{Meta: e.meta}
 			}
+			overrides := missingModuleOverrides(records, opts.ModuleMapping, baseDir)
 			sort.Slice(entries, func(i, j int) bool {
 				return entries[i].meta.Path < entries[j].meta.Path
 			})
@@ -520,6 +548,7 @@ func DiagnoseFilesWithOptions(ctx context.Context, baseDir string, files []strin
 		}
 	}
 
+	fullPipeline := opts.Stage == DiagnoseStageSema || opts.Stage == DiagnoseStageAll
 	for i := range results {
 		bag := results[i].Bag
 		if bag == nil {
@@ -529,6 +558,8 @@ func DiagnoseFilesWithOptions(ctx context.Context, baseDir string, files []strin
 			results[i].Builder = nil
 			results[i].ASTFile = 0
 		}
+		bag.DedupByLocation()
+		applySuppressions(fileSet, bag, results[i].FileID, fullPipeline)
 		if opts.IgnoreWarnings {
 			bag.Filter(func(d *diag.Diagnostic) bool {
 				return d.Severity != diag.SevWarning && d.Severity != diag.SevInfo
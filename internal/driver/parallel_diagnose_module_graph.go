@@ -258,7 +258,7 @@ func resolveDirModuleGraph(ctx context.Context, fileSet *source.FileSet, results
 	sort.Strings(paths)
 	metas := make([]*project.ModuleMeta, 0, len(paths))
 	nodes := make([]*dag.ModuleNode, 0, len(paths))
-	overrides := missingModuleOverrides(records, opts.ModuleMapping)
+	overrides := missingModuleOverrides(records, opts.ModuleMapping, baseDir)
 	for _, p := range paths {
 		rec := records[p]
 		if rec == nil || rec.Meta == nil {
@@ -294,7 +294,7 @@ func resolveDirModuleGraph(ctx context.Context, fileSet *source.FileSet, results
 	}
 	dag.ReportBrokenDeps(idx, slots)
 
-	exports := collectModuleExports(ctx, records, idx, topo, baseDir, "", typeInterner, opts)
+	exports := collectModuleExports(ctx, records, idx, topo, baseDir, "", typeInterner, opts, newDiagCacheSession(fileSet, opts))
 	for alias, target := range aliasExports {
 		if exp, ok := exports[normalizeExportsKey(target)]; ok {
 			exports[normalizeExportsKey(alias)] = exp
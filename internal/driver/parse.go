@@ -28,14 +28,22 @@ func Parse(filePath string, maxDiagnostics int) (*ParseResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	file := fs.Get(fileID)
+	return parseFile(fs, fs.Get(fileID), maxDiagnostics)
+}
+
+// ParseSource parses in-memory content (e.g. stdin) registered as a virtual file.
+func ParseSource(name string, content []byte, maxDiagnostics int) (*ParseResult, error) {
+	fs := source.NewFileSet()
+	fileID := fs.AddVirtual(name, content)
+	return parseFile(fs, fs.Get(fileID), maxDiagnostics)
+}
 
+func parseFile(fs *source.FileSet, file *source.File, maxDiagnostics int) (*ParseResult, error) {
 	bag := diag.NewBag(maxDiagnostics)
 	lx := lexer.New(file, lexer.Options{})
 	builder := ast.NewBuilder(ast.Hints{}, nil)
 
-	var maxErrors uint
-	maxErrors, err = safecast.Conv[uint](maxDiagnostics)
+	maxErrors, err := safecast.Conv[uint](maxDiagnostics)
 	if err != nil {
 		return nil, err
 	}
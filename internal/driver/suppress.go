@@ -0,0 +1,41 @@
+package driver
+
+import (
+	"fmt"
+	"strings"
+
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+// applySuppressions drops diagnostics silenced by the `surge:ignore` comments that
+// diagnoseTokenize collected into the bag. Unused suppressions in reportFile are
+// reported as warnings when reportUnused is set.
+func applySuppressions(fs *source.FileSet, bag *diag.Bag, reportFile source.FileID, reportUnused bool) {
+	if fs == nil || bag == nil {
+		return
+	}
+	sups := bag.Suppressions()
+	if sups.Len() == 0 {
+		return
+	}
+	bag.Suppress(fs, sups)
+	if !reportUnused {
+		return
+	}
+	for _, sup := range sups.Unused() {
+		if sup.Span.File != reportFile {
+			continue
+		}
+		msg := "suppression comment does not silence any diagnostic"
+		if len(sup.Codes) > 0 {
+			msg = fmt.Sprintf("suppression of %s does not silence any diagnostic", strings.Join(sup.Codes, ", "))
+		}
+		bag.Add(&diag.Diagnostic{
+			Severity: diag.SevWarning,
+			Code:     diag.LexUnusedSuppression,
+			Message:  msg,
+			Primary:  sup.Span,
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func diagnoseSource(t *testing.T, src string) *diag.Bag {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suppress.sg")
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	opts := DiagnoseOptions{
+		Stage:          DiagnoseStageAll,
+		MaxDiagnostics: 32,
+	}
+	res, err := DiagnoseWithOptions(context.Background(), path, &opts)
+	if err != nil {
+		t.Fatalf("DiagnoseWithOptions error: %v", err)
+	}
+	return res.Bag
+}
+
+func countCode(bag *diag.Bag, code diag.Code) int {
+	n := 0
+	for _, d := range bag.Items() {
+		if d.Code == code {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSuppressionLineScoped(t *testing.T) {
+	src := `fn demo() -> int {
+    return missing; // surge:ignore SEM3005
+}
+
+fn other() -> int {
+    // surge:ignore SEM3005
+    return absent;
+}
+`
+	bag := diagnoseSource(t, src)
+	if n := countCode(bag, diag.SemaUnresolvedSymbol); n != 0 {
+		t.Fatalf("expected unresolved symbols to be suppressed, got %+v", bag.Items())
+	}
+	if n := countCode(bag, diag.LexUnusedSuppression); n != 0 {
+		t.Fatalf("unexpected unused suppression warnings: %+v", bag.Items())
+	}
+}
+
+func TestSuppressionFileScoped(t *testing.T) {
+	src := `// surge:ignore-file SEM3005
+fn demo() -> int {
+    return missing;
+}
+
+fn other() -> int {
+    return absent;
+}
+`
+	bag := diagnoseSource(t, src)
+	if n := countCode(bag, diag.SemaUnresolvedSymbol); n != 0 {
+		t.Fatalf("expected unresolved symbols to be suppressed file-wide, got %+v", bag.Items())
+	}
+}
+
+func TestSuppressionUnmatchedCodeStillReports(t *testing.T) {
+	src := `fn demo() -> int {
+    return missing; // surge:ignore SEM3015
+}
+`
+	bag := diagnoseSource(t, src)
+	if n := countCode(bag, diag.SemaUnresolvedSymbol); n != 1 {
+		t.Fatalf("expected unresolved symbol to still be reported, got %+v", bag.Items())
+	}
+	if n := countCode(bag, diag.LexUnusedSuppression); n != 1 {
+		t.Fatalf("expected unused suppression warning, got %+v", bag.Items())
+	}
+}
@@ -23,8 +23,17 @@ func Tokenize(path string, maxDiagnostics int) (*TokenizeResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	file := fs.Get(fileID)
+	return tokenizeFile(fs, fs.Get(fileID), maxDiagnostics), nil
+}
 
+// TokenizeSource tokenizes in-memory content (e.g. stdin) registered as a virtual file.
+func TokenizeSource(name string, content []byte, maxDiagnostics int) *TokenizeResult {
+	fs := source.NewFileSet()
+	fileID := fs.AddVirtual(name, content)
+	return tokenizeFile(fs, fs.Get(fileID), maxDiagnostics)
+}
+
+func tokenizeFile(fs *source.FileSet, file *source.File, maxDiagnostics int) *TokenizeResult {
 	// Создаём диагностический пакет
 	bag := diag.NewBag(maxDiagnostics)
 
@@ -50,5 +59,5 @@ func Tokenize(path string, maxDiagnostics int) (*TokenizeResult, error) {
 		File:    file,
 		Tokens:  tokens,
 		Bag:     bag,
-	}, nil
+	}
 }
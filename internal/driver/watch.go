@@ -0,0 +1,138 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"surge/internal/source"
+)
+
+const (
+	defaultWatchInterval = 300 * time.Millisecond
+	defaultWatchDebounce = 100 * time.Millisecond
+)
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	Interval time.Duration // How often watched paths are polled (default 300ms)
+	Debounce time.Duration // Quiet period required after a change before re-running (default 100ms)
+}
+
+// WatchRun performs one diagnostics pass and returns the paths to watch until
+// the next pass.
+type WatchRun func(ctx context.Context) []string
+
+// Watch calls run, then polls the returned paths and calls run again whenever
+// one of them changes, until ctx is cancelled. A burst of writes triggers a
+// single re-run: Watch waits until the paths stop changing for
+// opts.Debounce first.
+func Watch(ctx context.Context, opts WatchOptions, run WatchRun) {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultWatchInterval
+	}
+	if opts.Debounce <= 0 {
+		opts.Debounce = defaultWatchDebounce
+	}
+	paths := run(ctx)
+	snap := snapshotPaths(paths)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		cur := snapshotPaths(paths)
+		if cur.equal(snap) {
+			continue
+		}
+		// Редактор может писать файл в несколько приёмов: ждём, пока всё стихнет.
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(opts.Debounce):
+			}
+			next := snapshotPaths(paths)
+			if next.equal(cur) {
+				break
+			}
+			cur = next
+		}
+		paths = run(ctx)
+		snap = snapshotPaths(paths)
+	}
+}
+
+// WatchPaths returns the paths a diagnostics run over fs depends on: every
+// file loaded from disk and the directories holding them, so adding or
+// removing a module file is noticed too. extra paths (e.g. the CLI argument,
+// which may fail to load) are included as-is.
+func WatchPaths(fs *source.FileSet, extra ...string) []string {
+	seen := make(map[string]struct{})
+	add := func(path string) {
+		if path != "" {
+			seen[path] = struct{}{}
+		}
+	}
+	for _, path := range extra {
+		add(path)
+	}
+	if fs != nil {
+		for id := source.FileID(0); fs.HasFile(id); id++ {
+			f := fs.Get(id)
+			if f.Flags&source.FileVirtual != 0 {
+				continue
+			}
+			add(f.Path)
+			add(filepath.Dir(f.Path))
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for path := range seen {
+		out = append(out, path)
+	}
+	sort.Strings(out)
+	return out
+}
+
+type watchStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+type watchSnapshot map[string]watchStamp
+
+// snapshotPaths records size and modification time of each path; for
+// directories the modification time changes when entries are added or removed.
+func snapshotPaths(paths []string) watchSnapshot {
+	snap := make(watchSnapshot, len(paths))
+	for _, path := range paths {
+		st, err := os.Stat(path)
+		if err != nil {
+			snap[path] = watchStamp{}
+			continue
+		}
+		snap[path] = watchStamp{exists: true, size: st.Size(), modTime: st.ModTime()}
+	}
+	return snap
+}
+
+func (s watchSnapshot) equal(other watchSnapshot) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for path, stamp := range s {
+		o, ok := other[path]
+		if !ok || o.exists != stamp.exists || o.size != stamp.size || !o.modTime.Equal(stamp.modTime) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"surge/internal/diag"
+)
+
+func TestWatchRerendersOnFileChange(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "main.sg")
+	if err := os.WriteFile(mainPath, []byte("@entrypoint\nfn main() -> int {\n    let y: int = 1;\n    return 0;\n}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	renders := make(chan string, 4)
+	run := func(ctx context.Context) []string {
+		res, err := DiagnoseWithOptions(ctx, mainPath, &DiagnoseOptions{
+			Stage:          DiagnoseStageAll,
+			MaxDiagnostics: 64,
+			BaseDir:        dir,
+		})
+		if err != nil {
+			renders <- "error: " + err.Error()
+			return WatchPaths(nil, mainPath)
+		}
+		renders <- diag.FormatShortDiagnostics(res.Bag.Items(), res.FileSet, false)
+		return WatchPaths(res.FileSet, mainPath)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Watch(ctx, WatchOptions{Interval: 10 * time.Millisecond, Debounce: 30 * time.Millisecond}, run)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	next := func() string {
+		t.Helper()
+		select {
+		case out := <-renders:
+			return out
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for a diagnostics render")
+			return ""
+		}
+	}
+
+	first := next()
+	if !strings.Contains(first, "unused variable 'y'") {
+		t.Fatalf("unexpected first render:\n%s", first)
+	}
+
+	if err := os.WriteFile(mainPath, []byte("@entrypoint\nfn main() -> int {\n    return missing;\n}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	second := next()
+	if second == first || strings.Contains(second, "unused variable") || !strings.Contains(second, "missing") {
+		t.Fatalf("expected a fresh render after the change, got:\n%s", second)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Watch did not return after cancellation")
+	}
+	select {
+	case out := <-renders:
+		t.Fatalf("unexpected extra render:\n%s", out)
+	default:
+	}
+}
+
+func TestWatchRechecksOnlyEditedModules(t *testing.T) {
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "util", "helpers.sg")
+	writeCacheFile(t, helper, "pub fn twice(x: int) -> int {\n    return x * 2;\n}\n")
+	other := filepath.Join(dir, "util", "other.sg")
+	writeCacheFile(t, other, "pub fn one() -> int {\n    return 1;\n}\n")
+	mainPath := filepath.Join(dir, "main.sg")
+	writeCacheFile(t, mainPath, "import util/helpers;\nimport util/other;\n\n@entrypoint\nfn main() -> int {\n    let y: int = helpers.twice(other.one());\n    return 0;\n}\n")
+
+	// Как в CLI: без --cache-dir watch держит кэш диагностик в памяти.
+	cache := NewMemoryDiagCache()
+	checked := make(chan []string, 4)
+	run := func(ctx context.Context) []string {
+		res, err := DiagnoseWithOptions(ctx, mainPath, &DiagnoseOptions{
+			Stage:          DiagnoseStageAll,
+			MaxDiagnostics: 64,
+			BaseDir:        dir,
+			DiagCache:      cache,
+		})
+		if err != nil {
+			checked <- []string{"error: " + err.Error()}
+			return WatchPaths(nil, mainPath)
+		}
+		checked <- res.CheckedModules
+		return WatchPaths(res.FileSet, mainPath)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Watch(ctx, WatchOptions{Interval: 10 * time.Millisecond, Debounce: 30 * time.Millisecond}, run)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	next := func() []string {
+		t.Helper()
+		select {
+		case mods := <-checked:
+			return mods
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for a diagnostics run")
+			return nil
+		}
+	}
+
+	if got, want := next(), []string{"main", "util/helpers", "util/other"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("first run checked %v, want %v", got, want)
+	}
+
+	// Правка тела функции не меняет экспорты: main и util/other не перепроверяются.
+	writeCacheFile(t, helper, "pub fn twice(x: int) -> int {\n    return x + x;\n}\n")
+	if got, want := next(), []string{"util/helpers"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after editing util/helpers checked %v, want %v", got, want)
+	}
+}
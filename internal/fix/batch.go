@@ -0,0 +1,191 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+// ErrEditConflict is returned when two edits selected for a single pass overlap.
+var ErrEditConflict = errors.New("overlapping edits")
+
+// ErrGuardMismatch is returned when an edit's OldText guard does not match the buffer.
+var ErrGuardMismatch = errors.New("existing text does not match expected content")
+
+// EditConflict describes a pair of overlapping edits that cannot be applied together.
+type EditConflict struct {
+	FirstFix  string
+	SecondFix string
+	First     diag.TextEdit
+	Second    diag.TextEdit
+}
+
+func (e *EditConflict) Error() string {
+	return fmt.Sprintf("fix %q [%d,%d) overlaps fix %q [%d,%d)",
+		e.FirstFix, e.First.Span.Start, e.First.Span.End,
+		e.SecondFix, e.Second.Span.Start, e.Second.Span.End)
+}
+
+func (e *EditConflict) Unwrap() error { return ErrEditConflict }
+
+type batchEdit struct {
+	edit  diag.TextEdit
+	fixID string
+	order int
+}
+
+// ApplyFixes applies the edits of all given fixes in a single pass against the
+// original file contents. Edits are validated up front: overlapping spans yield
+// an *EditConflict and failed OldText guards yield ErrGuardMismatch. Nothing is
+// returned unless every edit applies, so callers either get all new buffers or none.
+func ApplyFixes(fs *source.FileSet, fixes []*diag.Fix) (map[source.FileID][]byte, error) {
+	if fs == nil {
+		return nil, fmt.Errorf("fix: FileSet is nil")
+	}
+	byFile := make(map[source.FileID][]batchEdit)
+	order := 0
+	for _, f := range fixes {
+		if f == nil {
+			continue
+		}
+		for _, edit := range f.Edits {
+			byFile[edit.Span.File] = append(byFile[edit.Span.File], batchEdit{
+				edit:  copyEdit(edit),
+				fixID: f.ID,
+				order: order,
+			})
+			order++
+		}
+	}
+
+	out := make(map[source.FileID][]byte, len(byFile))
+	for fileID, edits := range byFile {
+		file := fs.Get(fileID)
+		if file == nil {
+			return nil, fmt.Errorf("fix: unknown file id %d", fileID)
+		}
+		buf, err := applyBatchEdits(file.Content, edits)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.FormatPath("auto", fs.BaseDir()), err)
+		}
+		out[fileID] = buf
+	}
+	return out, nil
+}
+
+// ApplyEdits applies non-overlapping edits to src in one pass and returns the new buffer.
+// All spans refer to offsets in the original src.
+func ApplyEdits(src []byte, edits []diag.TextEdit) ([]byte, error) {
+	batch := make([]batchEdit, len(edits))
+	for i, e := range edits {
+		batch[i] = batchEdit{edit: copyEdit(e), order: i}
+	}
+	return applyBatchEdits(src, batch)
+}
+
+func applyBatchEdits(src []byte, edits []batchEdit) ([]byte, error) {
+	edits = dedupBatchEdits(edits)
+
+	// по убыванию позиции: правка в конце не сдвигает координаты правок перед ней;
+	// вставки в одной точке идут в обратном порядке, чтобы текст лёг в исходном.
+	sort.SliceStable(edits, func(i, j int) bool {
+		a, b := edits[i].edit.Span, edits[j].edit.Span
+		if a.Start != b.Start {
+			return a.Start > b.Start
+		}
+		if a.End != b.End {
+			return a.End > b.End
+		}
+		return edits[i].order > edits[j].order
+	})
+
+	for i := range edits {
+		e := edits[i].edit
+		if int(e.Span.End) > len(src) || e.Span.Start > e.Span.End {
+			return nil, fmt.Errorf("edit span [%d,%d) out of range", e.Span.Start, e.Span.End)
+		}
+		if e.OldText != "" && string(src[e.Span.Start:e.Span.End]) != e.OldText {
+			return nil, fmt.Errorf("fix %q at [%d,%d): %w", edits[i].fixID, e.Span.Start, e.Span.End, ErrGuardMismatch)
+		}
+		// после сортировки ближайший сосед — единственный кандидат на пересечение
+		if i > 0 && spansConflict(edits[i-1].edit, e) {
+			return nil, &EditConflict{
+				FirstFix:  edits[i].fixID,
+				SecondFix: edits[i-1].fixID,
+				First:     e,
+				Second:    edits[i-1].edit,
+			}
+		}
+	}
+
+	working := append([]byte(nil), src...)
+	for _, be := range edits {
+		e := be.edit
+		suffix := append([]byte(nil), working[e.Span.End:]...)
+		working = append(append(working[:e.Span.Start], e.NewText...), suffix...)
+	}
+	return working, nil
+}
+
+// dedupBatchEdits drops byte-identical edits so the same fix attached to
+// several diagnostics is not reported as a conflict with itself.
+func dedupBatchEdits(edits []batchEdit) []batchEdit {
+	type key struct {
+		start, end uint32
+		newText    string
+	}
+	seen := make(map[key]struct{}, len(edits))
+	out := edits[:0:0]
+	for _, be := range edits {
+		k := key{start: be.edit.Span.Start, end: be.edit.Span.End, newText: be.edit.NewText}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, be)
+	}
+	return out
+}
+
+// applyBatch applies all selected candidates atomically and writes the touched files.
+func applyBatch(fs *source.FileSet, selected []candidate, dryRun bool) ([]AppliedFix, []FileChange, []FilePreview, error) {
+	fixes := make([]*diag.Fix, 0, len(selected))
+	for _, cand := range selected {
+		for _, edit := range cand.fix.Edits {
+			if file := fs.Get(edit.Span.File); file != nil && file.Flags&source.FileVirtual != 0 {
+				return nil, nil, nil, fmt.Errorf("fix %q: target file is virtual", cand.fix.ID)
+			}
+		}
+		fixes = append(fixes, cand.fix)
+	}
+
+	buffers, err := ApplyFixes(fs, fixes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	applied := make([]AppliedFix, 0, len(selected))
+	editCount := make(map[source.FileID]int)
+	dirty := make(map[source.FileID]bool, len(buffers))
+	for _, cand := range selected {
+		for _, edit := range cand.fix.Edits {
+			editCount[edit.Span.File]++
+			dirty[edit.Span.File] = true
+		}
+		applied = append(applied, AppliedFix{
+			ID:            cand.fix.ID,
+			Title:         cand.fix.Title,
+			Code:          cand.diag.Code,
+			Message:       cand.diag.Message,
+			Applicability: cand.fix.Applicability,
+			PrimaryPath:   formatFilePath(fs, cand.diag.Primary.File),
+			EditCount:     len(cand.fix.Edits),
+		})
+	}
+
+	changes, previews, err := commitBuffers(fs, buffers, dirty, editCount, dryRun)
+	return applied, changes, previews, err
+}
@@ -0,0 +1,225 @@
+package fix
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+// TestApplyEdits_Disjoint проверяет, что непересекающиеся правки применяются за один проход
+func TestApplyEdits_Disjoint(t *testing.T) {
+	src := []byte("let a = 1\nlet b = 2\n")
+	edits := []diag.TextEdit{
+		{Span: source.Span{Start: 9, End: 9}, NewText: ";"},
+		{Span: source.Span{Start: 19, End: 19}, NewText: ";"},
+		{Span: source.Span{Start: 4, End: 5}, NewText: "alpha", OldText: "a"},
+	}
+	got, err := ApplyEdits(src, edits)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "let alpha = 1;\nlet b = 2;\n"
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestApplyEdits_GuardMismatch проверяет, что OldText guard отклоняет весь пакет
+func TestApplyEdits_GuardMismatch(t *testing.T) {
+	src := []byte("let a = 1")
+	_, err := ApplyEdits(src, []diag.TextEdit{
+		{Span: source.Span{Start: 4, End: 5}, NewText: "b", OldText: "x"},
+	})
+	if !errors.Is(err, ErrGuardMismatch) {
+		t.Fatalf("expected ErrGuardMismatch, got %v", err)
+	}
+}
+
+// TestApplyModeBatch_DisjointFixes проверяет пакетное применение двух независимых фиксов
+func TestApplyModeBatch_DisjointFixes(t *testing.T) {
+	content := []byte("let a = 1\nlet b = 2\n")
+	path, cleanup := createTestFile(t, "batch.sg", content)
+	defer cleanup()
+
+	fs := source.NewFileSet()
+	fileID := fs.Add(path, content, 0)
+
+	diags := []*diag.Diagnostic{
+		batchDiag(fileID, "fix-a", 9, 9, ";"),
+		batchDiag(fileID, "fix-b", 19, 19, ";"),
+	}
+
+	result, err := Apply(fs, diags, ApplyOptions{Mode: ApplyModeBatch})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Applied) != 2 {
+		t.Fatalf("expected 2 applied fixes, got %d", len(result.Applied))
+	}
+	got, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("read file: %v", readErr)
+	}
+	if string(got) != "let a = 1;\nlet b = 2;\n" {
+		t.Fatalf("unexpected file content: %q", got)
+	}
+}
+
+// TestApplyModeBatch_OverlapRejected проверяет, что пересекающиеся фиксы дают конфликт и файл не меняется
+func TestApplyModeBatch_OverlapRejected(t *testing.T) {
+	content := []byte("let value = 1\n")
+	path, cleanup := createTestFile(t, "overlap.sg", content)
+	defer cleanup()
+
+	fs := source.NewFileSet()
+	fileID := fs.Add(path, content, 0)
+
+	diags := []*diag.Diagnostic{
+		batchDiag(fileID, "rename", 4, 9, "v"),
+		batchDiag(fileID, "retype", 6, 13, "x = 2"),
+	}
+
+	result, err := Apply(fs, diags, ApplyOptions{Mode: ApplyModeBatch})
+	var conflict *EditConflict
+	if !errors.As(err, &conflict) || !errors.Is(err, ErrEditConflict) {
+		t.Fatalf("expected EditConflict, got %v", err)
+	}
+	if len(result.Applied) != 0 || len(result.FileChanges) != 0 {
+		t.Fatalf("expected nothing applied, got %+v", result)
+	}
+	got, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("read file: %v", readErr)
+	}
+	if string(got) != string(content) {
+		t.Fatalf("file must stay untouched on conflict, got %q", got)
+	}
+}
+
+func batchDiag(fileID source.FileID, id string, start, end uint32, newText string) *diag.Diagnostic {
+	span := source.Span{File: fileID, Start: start, End: end}
+	return &diag.Diagnostic{
+		Severity: diag.SevError,
+		Code:     diag.SynUnexpectedToken,
+		Message:  id,
+		Primary:  span,
+		Fixes: []*diag.Fix{{
+			ID:            id,
+			Title:         id,
+			Applicability: diag.FixApplicabilityAlwaysSafe,
+			Edits:         []diag.TextEdit{{Span: span, NewText: newText}},
+		}},
+	}
+}
+
+// TestApplyModeBatch_WriteFailureLeavesFilesUntouched проверяет, что сбой записи посреди пакета не меняет ни одного файла
+func TestApplyModeBatch_WriteFailureLeavesFilesUntouched(t *testing.T) {
+	contentA := []byte("let a = 1\n")
+	contentB := []byte("let b = 2\n")
+	pathA, cleanupA := createTestFile(t, "a.sg", contentA)
+	defer cleanupA()
+	pathB, cleanupB := createTestFile(t, "b.sg", contentB)
+	defer cleanupB()
+
+	fs := source.NewFileSet()
+	fileA := fs.Add(pathA, contentA, 0)
+	fileB := fs.Add(pathB, contentB, 0)
+
+	// первая запись проходит, вторая падает — какой файл первый, зависит от обхода map
+	origStage := stageFileWrite
+	defer func() { stageFileWrite = origStage }()
+	var stagedTmp []string
+	writes := 0
+	stageFileWrite = func(path string, buf []byte) (string, error) {
+		writes++
+		if writes == 2 {
+			return "", errors.New("disk full")
+		}
+		tmp, err := origStage(path, buf)
+		stagedTmp = append(stagedTmp, tmp)
+		return tmp, err
+	}
+
+	diags := []*diag.Diagnostic{
+		batchDiag(fileA, "fix-a", 9, 9, ";"),
+		batchDiag(fileB, "fix-b", 9, 9, ";"),
+	}
+	if _, err := Apply(fs, diags, ApplyOptions{Mode: ApplyModeBatch}); err == nil {
+		t.Fatalf("expected write error")
+	}
+	if writes != 2 {
+		t.Fatalf("expected the failure on the second write, got %d writes", writes)
+	}
+	for path, want := range map[string][]byte{pathA: contentA, pathB: contentB} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("%s must stay untouched, got %q", path, got)
+		}
+	}
+	for _, tmp := range stagedTmp {
+		if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+			t.Fatalf("staged temp file %s must be removed, stat err = %v", tmp, err)
+		}
+	}
+}
+
+// TestApplyModeBatch_RenameFailureRollsBack проверяет, что сбой подмены второго файла откатывает уже подменённый первый
+func TestApplyModeBatch_RenameFailureRollsBack(t *testing.T) {
+	contentA := []byte("let a = 1\n")
+	contentB := []byte("let b = 2\n")
+	pathA, cleanupA := createTestFile(t, "a.sg", contentA)
+	defer cleanupA()
+	pathB, cleanupB := createTestFile(t, "b.sg", contentB)
+	defer cleanupB()
+
+	fs := source.NewFileSet()
+	fileA := fs.Add(pathA, contentA, 0)
+	fileB := fs.Add(pathB, contentB, 0)
+
+	origReplace := replaceFile
+	defer func() { replaceFile = origReplace }()
+	renames := 0
+	replaceFile = func(oldPath, newPath string) error {
+		renames++
+		if renames == 2 {
+			return errors.New("device busy")
+		}
+		return origReplace(oldPath, newPath)
+	}
+
+	diags := []*diag.Diagnostic{
+		batchDiag(fileA, "fix-a", 9, 9, ";"),
+		batchDiag(fileB, "fix-b", 9, 9, ";"),
+	}
+	if _, err := Apply(fs, diags, ApplyOptions{Mode: ApplyModeBatch}); err == nil {
+		t.Fatalf("expected rename error")
+	}
+	if renames != 2 {
+		t.Fatalf("expected the failure on the second rename, got %d renames", renames)
+	}
+	for path, want := range map[string][]byte{pathA: contentA, pathB: contentB} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("%s must be rolled back, got %q", path, got)
+		}
+	}
+	for _, path := range []string{pathA, pathB} {
+		leftovers, err := filepath.Glob(filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".fix-*"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(leftovers) != 0 {
+			t.Fatalf("staged temp files must be removed, found %v", leftovers)
+		}
+	}
+}
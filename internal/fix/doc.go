@@ -28,6 +28,9 @@
 //   - cumulativeDelta: когда применяют несколько правок в одном файле, предыдущие
 //     изменения сдвигают координаты последующих. cumulativeDelta пересчитывает
 //     смещение для каждого edit.
+//   - Пакетный режим (ApplyModeBatch / ApplyFixes): все правки проверяются
+//     против исходного буфера заранее и применяются за один проход в порядке
+//     убывания позиций; пересечение даёт EditConflict, и ни один файл не меняется.
 //   - Конфликты: перед применением очередного фикса движок проверяет пересечение
 //     с уже применёнными правками (spansConflict). Если правка конфликтует или
 //     не проходит guard/expect проверки, новая правка пропускается с reason.
@@ -9,7 +9,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"surge/internal/diag"
 	"surge/internal/source"
@@ -28,12 +30,17 @@ const (
 	ApplyModeAll
 	// ApplyModeID applies fixes by ID.
 	ApplyModeID
+	// ApplyModeBatch applies every safe fix atomically in one pass,
+	// failing on overlapping edits instead of skipping them.
+	ApplyModeBatch
 )
 
 // ApplyOptions configures how fixes are selected.
 type ApplyOptions struct {
 	Mode     ApplyMode
 	TargetID string
+	OnlyCode string // ограничить фиксы диагностиками с этим кодом (например, "SYN2106")
+	DryRun   bool   // не писать файлы, а вернуть Previews
 }
 
 // AppliedFix records a successfully applied fix.
@@ -60,11 +67,19 @@ type FileChange struct {
 	EditCount int
 }
 
+// FilePreview holds the original and rewritten content of a file in dry-run mode.
+type FilePreview struct {
+	Path   string
+	Before []byte
+	After  []byte
+}
+
 // ApplyResult aggregates applied fixes, skipped ones, and file changes.
 type ApplyResult struct {
 	Applied     []AppliedFix
 	Skipped     []SkippedFix
 	FileChanges []FileChange
+	Previews    []FilePreview // заполняется только при DryRun
 }
 
 type candidate struct {
@@ -87,6 +102,9 @@ func Apply(fs *source.FileSet, diagnostics []*diag.Diagnostic, opts ApplyOptions
 	ctx := diag.FixBuildContext{FileSet: fs}
 	candidates, buildSkips := gatherCandidates(ctx, diagnostics)
 	result.Skipped = append(result.Skipped, buildSkips...)
+	if opts.OnlyCode != "" {
+		candidates = filterByCode(candidates, opts.OnlyCode)
+	}
 
 	if len(candidates) == 0 {
 		return result, ErrNoFixes
@@ -101,10 +119,19 @@ func Apply(fs *source.FileSet, diagnostics []*diag.Diagnostic, opts ApplyOptions
 		return result, ErrNoFixes
 	}
 
-	applied, skippedDuringApply, changes, err := applyCandidates(fs, selected)
+	if opts.Mode == ApplyModeBatch {
+		applied, changes, previews, err := applyBatch(fs, selected, opts.DryRun)
+		result.Applied = append(result.Applied, applied...)
+		result.FileChanges = append(result.FileChanges, changes...)
+		result.Previews = append(result.Previews, previews...)
+		return result, err
+	}
+
+	applied, skippedDuringApply, changes, previews, err := applyCandidates(fs, selected, opts.DryRun)
 	result.Applied = append(result.Applied, applied...)
 	result.Skipped = append(result.Skipped, skippedDuringApply...)
 	result.FileChanges = append(result.FileChanges, changes...)
+	result.Previews = append(result.Previews, previews...)
 
 	if err != nil {
 		return result, err
@@ -237,6 +264,32 @@ func selectCandidates(candidates []candidate, opts ApplyOptions) ([]candidate, [
 			})
 		}
 		return selected, skipped
+	case ApplyModeBatch:
+		// одна правка на диагностику: альтернативные фиксы всегда пересекаются
+		selected := make([]candidate, 0, len(candidates))
+		skipped := make([]SkippedFix, 0)
+		taken := make(map[*diag.Diagnostic]bool)
+		for _, cand := range candidates {
+			if cand.fix.Applicability != diag.FixApplicabilityAlwaysSafe {
+				skipped = append(skipped, SkippedFix{
+					ID:     cand.fix.ID,
+					Title:  cand.fix.Title,
+					Reason: fmt.Sprintf("applicability is %s", cand.fix.Applicability.String()),
+				})
+				continue
+			}
+			if taken[cand.diag] {
+				skipped = append(skipped, SkippedFix{
+					ID:     cand.fix.ID,
+					Title:  cand.fix.Title,
+					Reason: "another fix for the same diagnostic was selected",
+				})
+				continue
+			}
+			taken[cand.diag] = true
+			selected = append(selected, cand)
+		}
+		return selected, skipped
 	case ApplyModeOnce:
 		var selected []candidate
 		var fallback *candidate
@@ -269,7 +322,19 @@ func selectCandidates(candidates []candidate, opts ApplyOptions) ([]candidate, [
 	}
 }
 
-func applyCandidates(fs *source.FileSet, selected []candidate) ([]AppliedFix, []SkippedFix, []FileChange, error) {
+// filterByCode keeps only candidates attached to diagnostics with the given code ID.
+func filterByCode(candidates []candidate, code string) []candidate {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	out := candidates[:0]
+	for _, cand := range candidates {
+		if cand.diag.Code.ID() == code {
+			out = append(out, cand)
+		}
+	}
+	return out
+}
+
+func applyCandidates(fs *source.FileSet, selected []candidate, dryRun bool) ([]AppliedFix, []SkippedFix, []FileChange, []FilePreview, error) {
 	buffers := make(map[source.FileID][]byte)
 	appliedEdits := make(map[source.FileID][]diag.TextEdit)
 	fileEditCount := make(map[source.FileID]int)
@@ -372,34 +437,136 @@ func applyCandidates(fs *source.FileSet, selected []candidate) ([]AppliedFix, []
 	}
 
 	if len(applied) == 0 {
-		return applied, skipped, nil, nil
+		return applied, skipped, nil, nil, nil
 	}
 
+	fileChanges, previews, err := commitBuffers(fs, buffers, dirtyFiles, fileEditCount, dryRun)
+	return applied, skipped, fileChanges, previews, err
+}
+
+// commitBuffers writes rewritten buffers to disk, or collects them as previews in dry-run mode.
+// Every buffer is staged into a temp file first; originals are replaced only after all
+// writes succeed. If replacing one of the originals fails, files already replaced are
+// restored from their backups, so a failure leaves the whole batch untouched.
+func commitBuffers(fs *source.FileSet, buffers map[source.FileID][]byte, dirtyFiles map[source.FileID]bool, fileEditCount map[source.FileID]int, dryRun bool) ([]FileChange, []FilePreview, error) {
+	baseDir := fs.BaseDir()
 	fileChanges := make([]FileChange, 0, len(dirtyFiles))
+	var previews []FilePreview
+	var staged []stagedWrite
 	for fileID := range dirtyFiles {
 		buf := buffers[fileID]
 		file := fs.Get(fileID)
+		relPath := file.FormatPath("relative", baseDir)
 
-		mode := os.FileMode(0o644)
-		if info, err := os.Stat(file.Path); err == nil {
-			mode = info.Mode()
-		}
-
-		if err := os.WriteFile(file.Path, buf, mode); err != nil {
-			return applied, skipped, fileChanges, fmt.Errorf("write %s: %w", file.Path, err)
+		if dryRun {
+			previews = append(previews, FilePreview{
+				Path:   relPath,
+				Before: file.Content,
+				After:  buf,
+			})
+		} else {
+			backup, err := os.ReadFile(file.Path)
+			if err != nil {
+				discardStaged(staged)
+				return nil, nil, fmt.Errorf("read %s: %w", file.Path, err)
+			}
+			tmp, err := stageFileWrite(file.Path, buf)
+			if err != nil {
+				discardStaged(staged)
+				return nil, nil, fmt.Errorf("write %s: %w", file.Path, err)
+			}
+			staged = append(staged, stagedWrite{tmp: tmp, path: file.Path, backup: backup})
 		}
 
 		fileChanges = append(fileChanges, FileChange{
-			Path:      file.FormatPath("relative", baseDir),
+			Path:      relPath,
 			EditCount: fileEditCount[fileID],
 		})
 	}
 
+	// все файлы записаны во временные копии — только теперь подменяем оригиналы
+	for i, sw := range staged {
+		if err := replaceFile(sw.tmp, sw.path); err != nil {
+			discardStaged(staged[i:])
+			err = fmt.Errorf("write %s: %w", sw.path, err)
+			if rbErr := restoreBackups(staged[:i]); rbErr != nil {
+				err = errors.Join(err, rbErr)
+			}
+			return nil, nil, err
+		}
+	}
+
 	sort.SliceStable(fileChanges, func(i, j int) bool {
 		return fileChanges[i].Path < fileChanges[j].Path
 	})
+	sort.SliceStable(previews, func(i, j int) bool {
+		return previews[i].Path < previews[j].Path
+	})
+
+	return fileChanges, previews, nil
+}
+
+// stagedWrite is a new file content already written next to its target,
+// together with the original content to restore on rollback.
+type stagedWrite struct {
+	tmp    string
+	path   string
+	backup []byte
+}
+
+// replaceFile moves a staged file over its target. Tests replace it to
+// simulate a failure in the middle of a batch.
+var replaceFile = os.Rename
+
+// restoreBackups puts the original content back into files that were already
+// replaced by a batch that later failed.
+func restoreBackups(done []stagedWrite) error {
+	var errs []error
+	for _, sw := range done {
+		tmp, err := stageFileWrite(sw.path, sw.backup)
+		if err == nil {
+			err = os.Rename(tmp, sw.path)
+			if err != nil {
+				_ = os.Remove(tmp)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("restore %s: %w", sw.path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
 
-	return applied, skipped, fileChanges, nil
+// stageFileWrite writes buf to a temp file in the directory of path, keeping
+// the original file mode. Tests replace it to simulate a failing write.
+var stageFileWrite = func(path string, buf []byte) (string, error) {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".fix-*")
+	if err != nil {
+		return "", err
+	}
+	name := tmp.Name()
+	_, err = tmp.Write(buf)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(name, mode)
+	}
+	if err != nil {
+		_ = os.Remove(name)
+		return "", err
+	}
+	return name, nil
+}
+
+func discardStaged(staged []stagedWrite) {
+	for _, sw := range staged {
+		_ = os.Remove(sw.tmp)
+	}
 }
 
 func conflictsWithExisting(existing, edits []diag.TextEdit) bool {
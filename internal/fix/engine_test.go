@@ -540,3 +540,85 @@ func TestThunk_MaterializationPreservesRequiresAll(t *testing.T) {
 		t.Errorf("expected ID 'parent-fix', got %q", resolved.ID)
 	}
 }
+
+// TestApplyDryRun_DoesNotModifyFile проверяет, что dry-run не трогает файл и отдаёт diff
+func TestApplyDryRun_DoesNotModifyFile(t *testing.T) {
+	content := []byte("import foo::{};\nlet x = 1\n")
+	path, cleanup := createTestFile(t, "dry.sg", content)
+	defer cleanup()
+
+	fs := source.NewFileSet()
+	fileID := fs.Add(path, content, 0)
+
+	d := &diag.Diagnostic{
+		Severity: diag.SevWarning,
+		Code:     diag.SynEmptyImportGroup,
+		Message:  "empty import group",
+		Primary:  source.Span{File: fileID, Start: 10, End: 14},
+		Fixes: []*diag.Fix{{
+			ID:            "drop-group",
+			Title:         "remove empty group",
+			Applicability: diag.FixApplicabilityAlwaysSafe,
+			Edits: []diag.TextEdit{
+				{Span: source.Span{File: fileID, Start: 10, End: 14}, NewText: "", OldText: "::{}"},
+			},
+		}},
+	}
+
+	result, err := Apply(fs, []*diag.Diagnostic{d}, ApplyOptions{Mode: ApplyModeAll, DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Previews) != 1 {
+		t.Fatalf("expected 1 preview, got %d", len(result.Previews))
+	}
+
+	got, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("read file: %v", readErr)
+	}
+	if string(got) != string(content) {
+		t.Fatalf("dry-run modified the file: %q", got)
+	}
+
+	preview := result.Previews[0]
+	diff := UnifiedDiff(preview.Path, preview.Before, preview.After, 3)
+	for _, want := range []string{"@@ -1,2 +1,2 @@", "-import foo::{};\n", "+import foo;\n", " let x = 1\n"} {
+		if !strings.Contains(diff, want) {
+			t.Fatalf("expected diff to contain %q, got:\n%s", want, diff)
+		}
+	}
+}
+
+// TestApplyOnlyCode проверяет фильтр --only по коду диагностики
+func TestApplyOnlyCode(t *testing.T) {
+	content := []byte("let a = 1\n")
+	path, cleanup := createTestFile(t, "only.sg", content)
+	defer cleanup()
+
+	fs := source.NewFileSet()
+	fileID := fs.Add(path, content, 0)
+
+	d := &diag.Diagnostic{
+		Severity: diag.SevError,
+		Code:     diag.SynExpectSemicolon,
+		Message:  "missing semicolon",
+		Primary:  source.Span{File: fileID, Start: 9, End: 9},
+		Fixes: []*diag.Fix{{
+			ID:            "semi",
+			Applicability: diag.FixApplicabilityAlwaysSafe,
+			Edits:         []diag.TextEdit{{Span: source.Span{File: fileID, Start: 9, End: 9}, NewText: ";"}},
+		}},
+	}
+
+	if _, err := Apply(fs, []*diag.Diagnostic{d}, ApplyOptions{Mode: ApplyModeAll, DryRun: true, OnlyCode: "SEM3005"}); !errors.Is(err, ErrNoFixes) {
+		t.Fatalf("expected ErrNoFixes for non-matching code, got %v", err)
+	}
+	result, err := Apply(fs, []*diag.Diagnostic{d}, ApplyOptions{Mode: ApplyModeAll, DryRun: true, OnlyCode: "syn2012"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Applied) != 1 {
+		t.Fatalf("expected matching fix to be selected, got %+v", result)
+	}
+}
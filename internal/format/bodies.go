@@ -0,0 +1,80 @@
+package format
+
+import (
+	"surge/internal/ast"
+	"surge/internal/lexer"
+	"surge/internal/source"
+	"surge/internal/token"
+)
+
+// printedBody records where a reprinted function body landed in the output.
+type printedBody struct {
+	id    ast.StmtID
+	src   source.Span
+	start int
+	end   int
+}
+
+// printBody prints a function body block and records its output range so
+// FormatFile can verify it afterwards.
+func (p *printer) printBody(id ast.StmtID) {
+	stmt := p.builder.Stmts.Get(id)
+	if stmt == nil {
+		return
+	}
+	start := len(p.writer.buf)
+	p.printBlock(id)
+	p.bodies = append(p.bodies, printedBody{
+		id:    id,
+		src:   stmt.Span,
+		start: start,
+		end:   len(p.writer.buf),
+	})
+}
+
+// changedBodies returns the bodies whose token stream differs from the source.
+// Reprinting only rewrites whitespace, so any difference means the printer
+// misrendered a construct and the body must be copied verbatim instead.
+func (p *printer) changedBodies(out []byte) []ast.StmtID {
+	var changed []ast.StmtID
+	content := p.writer.sf.Content
+	for _, body := range p.bodies {
+		if p.verbatim[body.id] {
+			continue
+		}
+		srcStart := clampToContent(int(body.src.Start), len(content))
+		srcEnd := clampToContent(int(body.src.End), len(content))
+		if !sameTokens(content[srcStart:srcEnd], out[body.start:body.end]) {
+			changed = append(changed, body.id)
+		}
+	}
+	return changed
+}
+
+func sameTokens(a, b []byte) bool {
+	ta := lexFragment(a)
+	tb := lexFragment(b)
+	if len(ta) != len(tb) {
+		return false
+	}
+	for i := range ta {
+		if ta[i].Kind != tb[i].Kind || ta[i].Text != tb[i].Text {
+			return false
+		}
+	}
+	return true
+}
+
+func lexFragment(content []byte) []token.Token {
+	fs := source.NewFileSetWithBase("")
+	fid := fs.AddVirtual("fragment.sg", content)
+	lx := lexer.New(fs.Get(fid), lexer.Options{})
+	var toks []token.Token
+	for {
+		tok := lx.Next()
+		if tok.Kind == token.EOF {
+			return toks
+		}
+		toks = append(toks, token.Token{Kind: tok.Kind, Text: tok.Text})
+	}
+}
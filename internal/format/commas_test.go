@@ -57,7 +57,7 @@ func TestFormatFileBasic(t *testing.T) {
 	}
 
 	got := string(formatted)
-	want := "import std/math::{sin as s, cos};\n" +
+	want := "import std/math::{cos, sin as s};\n" +
 		"type Vec2 = { x: int, y: int, };\n" +
 		"type Shape = Circle(Point, int,) | nothing;\n" +
 		"type Wrapper<T,> = Vec2;\n" +
@@ -0,0 +1,62 @@
+package format
+
+import (
+	"sort"
+
+	"surge/internal/lexer"
+	"surge/internal/source"
+	"surge/internal/token"
+)
+
+// collectComments lexes sf and returns the spans of comment, doc and directive
+// trivia in source order. Trivia after the last token is not attached to any
+// token and is left out; it never falls inside a function body.
+func collectComments(sf *source.File) []source.Span {
+	lx := lexer.New(sf, lexer.Options{})
+	var spans []source.Span
+	for {
+		tok := lx.Next()
+		for _, tr := range tok.Leading {
+			switch tr.Kind {
+			case token.TriviaSpace, token.TriviaNewline:
+			default:
+				spans = append(spans, tr.Span)
+			}
+		}
+		if tok.Kind == token.EOF {
+			return spans
+		}
+	}
+}
+
+// newlinesBetween counts line breaks in the source between two offsets.
+func (p *printer) newlinesBetween(start, end int) int {
+	content := p.writer.sf.Content
+	start = clampToContent(start, len(content))
+	end = clampToContent(end, len(content))
+	count := 0
+	for _, b := range content[start:end] {
+		if b == '\n' {
+			count++
+		}
+	}
+	return count
+}
+
+// commentsBetween returns the comment spans lying entirely within [start, end).
+func (p *printer) commentsBetween(start, end int) []source.Span {
+	comments := p.writer.comments
+	first := sort.Search(len(comments), func(i int) bool {
+		return int(comments[i].Start) >= start
+	})
+	var out []source.Span
+	for _, sp := range comments[first:] {
+		if int(sp.Start) >= end {
+			break
+		}
+		if int(sp.End) <= end {
+			out = append(out, sp)
+		}
+	}
+	return out
+}
@@ -1,7 +1,14 @@
-// Package format contains lightweight formatting passes that operate on the AST
-// to produce stable textual output without rebuilding full pretty-printers.
+// Package format pretty-prints Surge source files from the AST.
 //
-// Назначение: ранние форматтеры (normalize-команды) поверх уже разобранного AST.
-// Не делает: полноценного pretty-print всего файла, генерации кода или IO.
-// Зависимости: internal/ast, internal/source.
+// Назначение: `surge fmt` — переписывает файл целиком: заголовки элементов,
+// тела функций (включая методы extern-блоков), операторы и выражения получают
+// каноничные пробелы и отступы. Комментарии и директивы переносятся из trivia по
+// спанам исходника; многострочные выражения сохраняют раскладку и лишь сдвигаются
+// по отступу. Тело, которое не удалось напечатать с тем же потоком токенов,
+// копируется как есть, поэтому форматирование идемпотентно и не меняет смысл.
+// Подряд идущие импорты сортируются по пути модуля (сначала абсолютные, затем
+// относительные), члены `::{...}` — по имени; дубликаты оставляются резолверу.
+// Отдельные проходы SortImports и NormalizeCommas доступны через `surge normalize`.
+// Не делает: перенос длинных строк, генерации кода или IO.
+// Зависимости: internal/ast, internal/lexer, internal/source.
 package format
@@ -0,0 +1,58 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestFormatGolden formats the unformatted inputs in testdata/golden/fmt and
+// checks the result against the stored .fmt output and against itself.
+func TestFormatGolden(t *testing.T) {
+	dir := filepath.Join("..", "..", "testdata", "golden", "fmt")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read fmt golden dir: %v", err)
+	}
+
+	for _, ent := range entries {
+		if ent.IsDir() || !strings.HasSuffix(ent.Name(), ".sg") {
+			continue
+		}
+		name := strings.TrimSuffix(ent.Name(), ".sg")
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join(dir, name+".sg"))
+			if err != nil {
+				t.Fatalf("read %s.sg: %v", name, err)
+			}
+			want, err := os.ReadFile(filepath.Join(dir, name+".fmt"))
+			if err != nil {
+				t.Fatalf("read %s.fmt: %v", name, err)
+			}
+
+			got := formatSource(t, src)
+			if string(got) != string(want) {
+				t.Fatalf("format mismatch:\nwant:\n%s\ngot:\n%s", want, got)
+			}
+			if string(src) == string(got) {
+				t.Fatalf("%s.sg is already formatted; golden inputs must exercise the formatter", name)
+			}
+
+			again := formatSource(t, got)
+			if string(again) != string(got) {
+				t.Fatalf("formatting is not idempotent:\nfirst:\n%s\nsecond:\n%s", got, again)
+			}
+		})
+	}
+}
+
+func formatSource(t *testing.T, src []byte) []byte {
+	t.Helper()
+	sf, builder, fileID := parseSource(t, src)
+	out, err := FormatFile(sf, builder, fileID, Options{})
+	if err != nil {
+		t.Fatalf("FormatFile failed: %v", err)
+	}
+	return out
+}
@@ -0,0 +1,290 @@
+package format
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+// importEntry is one import line of a run together with the comment lines
+// written directly above it.
+type importEntry struct {
+	path     string
+	relative bool
+	text     []byte
+}
+
+// importRun is the byte range covered by a run of consecutive imports.
+type importRun struct {
+	start   int
+	end     int
+	entries []importEntry
+}
+
+// SortImports returns a copy of the file content with every run of
+// consecutive top-level imports sorted:
+//   - absolute imports first, then relative ones (`./`, `../`) after a
+//     blank line;
+//   - by module path within a group, stable for equal paths;
+//   - `::{...}` members by name, aliases stay attached.
+//
+// Comments directly above an import (except the first of a run) and trailing
+// line comments move with it.
+// Runs broken by pragmas or detached comments are left as written; duplicates
+// stay adjacent and are reported by the resolver.
+func SortImports(sf *source.File, b *ast.Builder, fileID ast.FileID) []byte {
+	if sf == nil {
+		return nil
+	}
+
+	content := append([]byte(nil), sf.Content...)
+	if b == nil || !fileID.IsValid() || b.Files == nil || b.Items == nil {
+		return content
+	}
+	file := b.Files.Get(fileID)
+	if file == nil {
+		return content
+	}
+
+	var out []byte
+	cursor := 0
+	for _, ids := range consecutiveImports(sf, b, file) {
+		run, ok := splitImportRun(sf, b, ids, cursor)
+		if !ok {
+			continue
+		}
+		sorted := renderImportRun(run.entries)
+		if bytes.Equal(sorted, content[run.start:run.end]) {
+			continue
+		}
+		out = append(out, content[cursor:run.start]...)
+		out = append(out, sorted...)
+		cursor = run.end
+	}
+	if cursor == 0 {
+		return content
+	}
+	return append(out, content[cursor:]...)
+}
+
+// consecutiveImports groups imports that follow each other in the item list.
+func consecutiveImports(sf *source.File, b *ast.Builder, file *ast.File) [][]ast.ItemID {
+	var runs [][]ast.ItemID
+	var current []ast.ItemID
+	for _, itemID := range file.Items {
+		item := b.Items.Get(itemID)
+		if item != nil && item.Kind == ast.ItemImport && item.Span.File == sf.ID {
+			current = append(current, itemID)
+			continue
+		}
+		if len(current) > 0 {
+			runs = append(runs, current)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		runs = append(runs, current)
+	}
+	return runs
+}
+
+// splitImportRun maps a run of imports onto whole source lines. floor is the
+// offset before which nothing may be moved.
+func splitImportRun(sf *source.File, b *ast.Builder, ids []ast.ItemID, floor int) (importRun, bool) {
+	content := sf.Content
+	run := importRun{start: -1}
+	prevEnd := floor
+	for _, itemID := range ids {
+		item := b.Items.Get(itemID)
+		imp, ok := b.Items.Import(itemID)
+		if item == nil || !ok || imp == nil {
+			return importRun{}, false
+		}
+		start := clampToContent(int(item.Span.Start), len(content))
+		end := clampToContent(int(item.Span.End), len(content))
+		lineStart := bytes.LastIndexByte(content[:start], '\n') + 1
+		if lineStart < prevEnd || len(bytes.TrimSpace(content[lineStart:start])) != 0 {
+			return importRun{}, false
+		}
+		lineEnd := end + lineLength(content[end:])
+		trailing := bytes.TrimSpace(content[end:lineEnd])
+		if len(trailing) != 0 && !bytes.HasPrefix(trailing, []byte("//")) {
+			return importRun{}, false
+		}
+
+		// Lines between the previous import and this one may only be blank or
+		// line comments; the comments must sit directly above this import.
+		// Comments above the first import usually describe the whole file or
+		// section and stay where they are.
+		leadStart := lineStart
+		if run.start >= 0 && !gapHoldsOnlyAttachedComments(content[prevEnd:lineStart]) {
+			return importRun{}, false
+		}
+		for run.start >= 0 && leadStart > prevEnd {
+			prevLineStart := bytes.LastIndexByte(content[:leadStart-1], '\n') + 1
+			if prevLineStart < prevEnd {
+				break
+			}
+			line := bytes.TrimSpace(content[prevLineStart : leadStart-1])
+			if !bytes.HasPrefix(line, []byte("//")) {
+				break
+			}
+			leadStart = prevLineStart
+		}
+		if run.start < 0 {
+			run.start = leadStart
+		}
+
+		var text []byte
+		for _, line := range bytes.SplitAfter(content[leadStart:lineStart], []byte("\n")) {
+			if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+				text = append(text, trimmed...)
+				text = append(text, '\n')
+			}
+		}
+		text = append(text, importText(b.StringsInterner, imp, true)...)
+		if len(trailing) != 0 {
+			text = append(text, ' ')
+			text = append(text, trailing...)
+		}
+		path := modulePathText(b.StringsInterner, imp.Module)
+		run.entries = append(run.entries, importEntry{
+			path:     path,
+			relative: strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../"),
+			text:     text,
+		})
+		run.end = lineEnd
+		prevEnd = lineEnd
+	}
+	return run, run.start >= 0
+}
+
+// gapHoldsOnlyAttachedComments reports whether the lines between two imports
+// are blank lines followed by line comments, with no comment detached from the
+// next import by a blank line.
+func gapHoldsOnlyAttachedComments(gap []byte) bool {
+	// The gap runs from the end of one import line to the start of the next.
+	gap = bytes.TrimSuffix(bytes.TrimPrefix(gap, []byte("\n")), []byte("\n"))
+	seenComment := false
+	for _, line := range bytes.Split(gap, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		switch {
+		case len(trimmed) == 0:
+			if seenComment {
+				return false
+			}
+		case bytes.HasPrefix(trimmed, []byte("//")):
+			seenComment = true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func renderImportRun(entries []importEntry) []byte {
+	sorted := append([]importEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].relative != sorted[j].relative {
+			return !sorted[i].relative
+		}
+		return sorted[i].path < sorted[j].path
+	})
+	var out []byte
+	for i, entry := range sorted {
+		if i > 0 {
+			out = append(out, '\n')
+			if entry.relative && !sorted[i-1].relative {
+				out = append(out, '\n')
+			}
+		}
+		out = append(out, entry.text...)
+	}
+	return out
+}
+
+// importText renders an import item; sortGroup orders `::{...}` members by name.
+func importText(strs *source.Interner, imp *ast.ImportItem, sortGroup bool) string {
+	lookup := func(id source.StringID) string {
+		if id == source.NoStringID || strs == nil {
+			return ""
+		}
+		return strs.MustLookup(id)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("import ")
+	sb.WriteString(modulePathText(strs, imp.Module))
+	if imp.ModuleAlias != source.NoStringID {
+		sb.WriteString(" as ")
+		sb.WriteString(lookup(imp.ModuleAlias))
+	}
+
+	switch {
+	case imp.ImportAll:
+		sb.WriteString("::*")
+	case imp.HasOne:
+		sb.WriteString("::")
+		sb.WriteString(lookup(imp.One.Name))
+		if imp.One.Alias != source.NoStringID {
+			sb.WriteString(" as ")
+			sb.WriteString(lookup(imp.One.Alias))
+		}
+	case len(imp.Group) > 0:
+		group := imp.Group
+		if sortGroup {
+			group = append([]ast.ImportPair(nil), group...)
+			sort.SliceStable(group, func(i, j int) bool {
+				return lookup(group[i].Name) < lookup(group[j].Name)
+			})
+		}
+		sb.WriteString("::{")
+		for i, pair := range group {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(lookup(pair.Name))
+			if pair.Alias != source.NoStringID {
+				sb.WriteString(" as ")
+				sb.WriteString(lookup(pair.Alias))
+			}
+		}
+		sb.WriteString("}")
+	}
+	sb.WriteString(";")
+	return sb.String()
+}
+
+func modulePathText(strs *source.Interner, parts []source.StringID) string {
+	segs := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part == source.NoStringID || strs == nil {
+			segs = append(segs, "")
+			continue
+		}
+		segs = append(segs, strs.MustLookup(part))
+	}
+	return strings.Join(segs, "/")
+}
+
+func lineLength(buf []byte) int {
+	if idx := bytes.IndexByte(buf, '\n'); idx >= 0 {
+		return idx
+	}
+	return len(buf)
+}
+
+// sortFormattedImports reparses formatted output and applies SortImports to it.
+// Output that does not reparse cleanly is returned unchanged.
+func sortFormattedImports(sf *source.File, formatted []byte) []byte {
+	bag := diag.NewBag(1)
+	rebuilt, builder, fileID := parseOnce(sf.Path, formatted, bag)
+	if bag.HasErrors() || builder.Files.Get(fileID) == nil {
+		return formatted
+	}
+	return SortImports(rebuilt, builder, fileID)
+}
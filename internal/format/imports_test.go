@@ -0,0 +1,85 @@
+package format
+
+import "testing"
+
+func sortImportsSource(t *testing.T, src string) string {
+	t.Helper()
+	sf, builder, fileID := parseSource(t, []byte(src))
+	return string(SortImports(sf, builder, fileID))
+}
+
+func TestSortImportsMixedRelativeAndAbsolute(t *testing.T) {
+	src := "import ./util;\n" +
+		"import std/strings;\n" +
+		"import ../shared/log as log;\n" +
+		"import core/option;\n" +
+		"\n" +
+		"fn main() {}\n"
+	want := "import core/option;\n" +
+		"import std/strings;\n" +
+		"\n" +
+		"import ../shared/log as log;\n" +
+		"import ./util;\n" +
+		"\n" +
+		"fn main() {}\n"
+	if got := sortImportsSource(t, src); got != want {
+		t.Fatalf("SortImports mismatch:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestSortImportsGroupMembers(t *testing.T) {
+	src := "import std/math::{sin as s, cos, abs};\n" +
+		"import std/io::{write, read as r};\n"
+	want := "import std/io::{read as r, write};\n" +
+		"import std/math::{abs, cos, sin as s};\n"
+	if got := sortImportsSource(t, src); got != want {
+		t.Fatalf("SortImports mismatch:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestSortImportsKeepsAttachedComments(t *testing.T) {
+	src := "// header\n" +
+		"import std/strings; // trailing\n" +
+		"// математика\n" +
+		"import std/math;\n"
+	want := "// header\n" +
+		"// математика\n" +
+		"import std/math;\n" +
+		"import std/strings; // trailing\n"
+	if got := sortImportsSource(t, src); got != want {
+		t.Fatalf("SortImports mismatch:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestSortImportsKeepsDuplicates(t *testing.T) {
+	src := "import std/math;\n" +
+		"import std/io;\n" +
+		"import std/math;\n"
+	want := "import std/io;\n" +
+		"import std/math;\n" +
+		"import std/math;\n"
+	if got := sortImportsSource(t, src); got != want {
+		t.Fatalf("SortImports mismatch:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestSortImportsLeavesDetachedCommentsAlone(t *testing.T) {
+	src := "import std/math;\n" +
+		"// section\n" +
+		"\n" +
+		"import std/io;\n"
+	if got := sortImportsSource(t, src); got != src {
+		t.Fatalf("SortImports changed a run with a detached comment:\n%s", got)
+	}
+}
+
+func TestFormatFileSortsImports(t *testing.T) {
+	src := "import ./b;\nimport std/math::{sin, cos};\n"
+	want := "import std/math::{cos, sin};\n\nimport ./b;\n"
+	if got := string(formatSource(t, []byte(src))); got != want {
+		t.Fatalf("FormatFile mismatch:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+	if again := string(formatSource(t, []byte(want))); again != want {
+		t.Fatalf("FormatFile is not idempotent on sorted imports:\n%s", again)
+	}
+}
@@ -7,7 +7,13 @@ import (
 
 func (p *printer) printCallExpr(id ast.ExprID, expr *ast.Expr) {
 	call, ok := p.builder.Exprs.Call(id)
-	if !ok || call == nil {
+	if !ok || call == nil || len(call.TypeArgs) > 0 {
+		// printCallExpr has no printer for `::<T>` type arguments yet.
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	// Desugared calls such as f-strings share the span of their target.
+	if target := p.builder.Exprs.Get(call.Target); target == nil || target.Span.End >= expr.Span.End {
 		p.writer.CopySpan(expr.Span)
 		return
 	}
@@ -0,0 +1,187 @@
+package format
+
+import (
+	"bytes"
+
+	"surge/internal/ast"
+)
+
+func (p *printer) printBinaryExpr(id ast.ExprID, expr *ast.Expr) {
+	bin, ok := p.builder.Exprs.Binary(id)
+	if !ok || bin == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	p.printExpr(bin.Left)
+	switch bin.Op {
+	case ast.ExprBinaryRange, ast.ExprBinaryRangeInclusive:
+		p.writer.WriteString(bin.Op.String())
+	default:
+		p.writer.Space()
+		p.writer.WriteString(bin.Op.String())
+		p.writer.Space()
+	}
+	p.printExpr(bin.Right)
+}
+
+func (p *printer) printUnaryExpr(id ast.ExprID, expr *ast.Expr) {
+	un, ok := p.builder.Exprs.Unary(id)
+	if !ok || un == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	operand := p.builder.Exprs.Get(un.Operand)
+	if operand == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	switch un.Op {
+	case ast.ExprUnaryRefMut, ast.ExprUnaryOwn, ast.ExprUnaryAwait:
+		p.writer.WriteString(un.Op.String())
+		p.writer.Space()
+	default:
+		// `- -x` and `--x` lex differently, so stacked sign operators keep their spelling.
+		if operand.Kind == ast.ExprUnary || operand.Kind == ast.ExprLit {
+			p.writer.CopySpan(expr.Span)
+			return
+		}
+		p.writer.WriteString(un.Op.String())
+	}
+	p.printExpr(un.Operand)
+}
+
+func (p *printer) printGroupExpr(id ast.ExprID, expr *ast.Expr) {
+	group, ok := p.builder.Exprs.Group(id)
+	if !ok || group == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	if err := p.writer.WriteByte('('); err != nil {
+		panic(err)
+	}
+	p.printExpr(group.Inner)
+	if err := p.writer.WriteByte(')'); err != nil {
+		panic(err)
+	}
+}
+
+func (p *printer) printCastExpr(id ast.ExprID, expr *ast.Expr) {
+	cast, ok := p.builder.Exprs.Cast(id)
+	if !ok || cast == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	value := p.builder.Exprs.Get(cast.Value)
+	if value == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	var typeStart int
+	switch {
+	case cast.Type.IsValid():
+		typ := p.builder.Types.Get(cast.Type)
+		if typ == nil {
+			p.writer.CopySpan(expr.Span)
+			return
+		}
+		typeStart = int(typ.Span.Start)
+	case cast.RawType.IsValid():
+		raw := p.builder.Exprs.Get(cast.RawType)
+		if raw == nil {
+			p.writer.CopySpan(expr.Span)
+			return
+		}
+		typeStart = int(raw.Span.Start)
+	default:
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+
+	p.printExpr(cast.Value)
+	switch p.sourceBetween(int(value.Span.End), typeStart) {
+	case "to":
+		p.writer.WriteString(" to ")
+	case ":":
+		// Literal suffix casts are written tight, as in `0:uint`.
+		p.writer.WriteString(":")
+	default:
+		p.writer.CopyRange(int(value.Span.End), typeStart)
+	}
+	if cast.Type.IsValid() {
+		p.printTypeID(cast.Type)
+	} else {
+		p.printExpr(cast.RawType)
+	}
+}
+
+func (p *printer) printIndexExpr(id ast.ExprID, expr *ast.Expr) {
+	index, ok := p.builder.Exprs.Index(id)
+	if !ok || index == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	p.printExpr(index.Target)
+	if err := p.writer.WriteByte('['); err != nil {
+		panic(err)
+	}
+	p.printExpr(index.Index)
+	if err := p.writer.WriteByte(']'); err != nil {
+		panic(err)
+	}
+}
+
+func (p *printer) printMemberExpr(id ast.ExprID, expr *ast.Expr) {
+	member, ok := p.builder.Exprs.Member(id)
+	if !ok || member == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	target := p.builder.Exprs.Get(member.Target)
+	if target == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	field := p.string(member.Field)
+	fieldStart := int(expr.Span.End) - len(field)
+	sep := p.sourceBetween(int(target.Span.End), fieldStart)
+	if sep != "." && sep != "::" {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	p.printExpr(member.Target)
+	p.writer.WriteString(sep)
+	p.writer.WriteString(field)
+}
+
+func (p *printer) printTupleIndexExpr(id ast.ExprID, expr *ast.Expr) {
+	ti, ok := p.builder.Exprs.TupleIndex(id)
+	if !ok || ti == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	target := p.builder.Exprs.Get(ti.Target)
+	if target == nil {
+		p.writer.CopySpan(expr.Span)
+		return
+	}
+	p.printExpr(ti.Target)
+	// `.N` is a single token, so the index is copied as written.
+	p.writer.WriteString(p.sourceBetween(int(target.Span.End), int(expr.Span.End)))
+}
+
+// sourceBetween returns the source text in [start, end) without surrounding whitespace.
+func (p *printer) sourceBetween(start, end int) string {
+	content := p.writer.sf.Content
+	start = clampToContent(start, len(content))
+	end = clampToContent(end, len(content))
+	if start >= end {
+		return ""
+	}
+	return string(bytes.TrimSpace(content[start:end]))
+}
+
+// spansLines reports whether the expression was written over several lines;
+// such expressions keep their layout.
+func (p *printer) spansLines(expr *ast.Expr) bool {
+	return p.newlinesBetween(int(expr.Span.Start), int(expr.Span.End)) > 0
+}
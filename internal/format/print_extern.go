@@ -0,0 +1,32 @@
+package format
+
+import "surge/internal/ast"
+
+// printExternItem keeps the extern block layout as written and reprints the
+// bodies of its methods at the indentation of the line they start on.
+func (p *printer) printExternItem(item *ast.Item, ext *ast.ExternBlock) {
+	contentLen := len(p.writer.sf.Content)
+	cursor := clampToContent(int(item.Span.Start), contentLen)
+	end := clampToContent(int(item.Span.End), contentLen)
+	for idx := range ext.MembersCount {
+		member := p.builder.Items.ExternMember(ext.MembersStart + ast.ExternMemberID(idx))
+		if member == nil || member.Kind != ast.ExternMemberFn {
+			continue
+		}
+		fn := p.builder.Items.FnByPayload(member.Fn)
+		if fn == nil || !fn.Body.IsValid() {
+			continue
+		}
+		body := p.builder.Stmts.Get(fn.Body)
+		if body == nil || int(body.Span.Start) < cursor || int(body.Span.End) > end {
+			continue
+		}
+		p.writer.CopyRange(cursor, int(body.Span.Start))
+		saved := p.writer.indentLevel
+		p.writer.indentLevel = p.bodyIndent(body.Span)
+		p.printBody(fn.Body)
+		p.writer.indentLevel = saved
+		cursor = int(body.Span.End)
+	}
+	p.writer.CopyRange(cursor, end)
+}
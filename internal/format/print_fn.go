@@ -18,7 +18,7 @@ func (p *printer) printFnItem(item *ast.Item, fn *ast.FnItem) {
 	p.writer.Space()
 	p.writer.WriteString(p.string(fn.Name))
 	if len(fn.Generics) > 0 {
-		p.printGenerics(fn.Generics, fn.GenericsTrailingComma)
+		p.printGenerics(fn.Generics, fn.TypeParamsStart, fn.TypeParamsCount, fn.GenericsTrailingComma)
 	}
 
 	var err error
@@ -50,7 +50,7 @@ func (p *printer) printFnItem(item *ast.Item, fn *ast.FnItem) {
 		if len(p.writer.buf) > 0 && p.writer.buf[len(p.writer.buf)-1] != '\n' {
 			p.writer.Space()
 		}
-		p.writer.CopySpan(stmt.Span)
+		p.printBody(fn.Body)
 		p.writer.CopyRange(int(stmt.Span.End), int(item.Span.End))
 		return
 	}
@@ -70,17 +70,25 @@ func (p *printer) printFnItem(item *ast.Item, fn *ast.FnItem) {
 	p.writer.CopyRange(int(fn.ParamsSpan.End), int(item.Span.End))
 }
 
-func (p *printer) printGenerics(names []source.StringID, trailing bool) {
+func (p *printer) printGenerics(names []source.StringID, start ast.TypeParamID, count uint32, trailing bool) {
 	if len(names) == 0 {
 		return
 	}
 	if err := p.writer.WriteByte('<'); err != nil {
 		panic(err)
 	}
+	params := p.builder.Items.GetTypeParamIDs(start, count)
 	for i, id := range names {
 		if i > 0 {
 			p.writer.WriteString(", ")
 		}
+		// Bounds and const parameters are not modelled by names alone.
+		if len(params) == len(names) {
+			if param := p.builder.Items.TypeParam(params[i]); param != nil && spanValid(param.Span) {
+				p.writeTypeParam(param.Span)
+				continue
+			}
+		}
 		p.writer.WriteString(p.string(id))
 	}
 	if trailing && len(names) > 0 {
@@ -91,6 +99,30 @@ func (p *printer) printGenerics(names []source.StringID, trailing bool) {
 	}
 }
 
+// writeTypeParam copies a type parameter as written. A bound ending in a nested
+// argument list closes on a `>>` token, and the parameter span then also covers
+// the list's own `>`, which is trimmed here.
+func (p *printer) writeTypeParam(sp source.Span) {
+	content := p.writer.sf.Content
+	start := clampToContent(int(sp.Start), len(content))
+	end := clampToContent(int(sp.End), len(content))
+	text := content[start:end]
+	opened, closed := 0, 0
+	for i, b := range text {
+		switch {
+		case b == '<':
+			opened++
+		case b == '>' && (i == 0 || text[i-1] != '-'):
+			closed++
+		}
+	}
+	for closed > opened && end > start && content[end-1] == '>' {
+		end--
+		closed--
+	}
+	p.writer.CopyRange(start, end)
+}
+
 func (p *printer) printFnParams(fn *ast.FnItem) {
 	paramIDs := p.builder.Items.GetFnParamIDs(fn)
 	for i, pid := range paramIDs {
@@ -33,7 +33,26 @@ func (p *printer) printExpr(id ast.ExprID) {
 		return
 	}
 
+	if p.spansLines(expr) {
+		p.copyReindented(expr.Span)
+		return
+	}
+
 	switch expr.Kind {
+	case ast.ExprBinary:
+		p.printBinaryExpr(id, expr)
+	case ast.ExprUnary:
+		p.printUnaryExpr(id, expr)
+	case ast.ExprGroup:
+		p.printGroupExpr(id, expr)
+	case ast.ExprCast:
+		p.printCastExpr(id, expr)
+	case ast.ExprIndex:
+		p.printIndexExpr(id, expr)
+	case ast.ExprMember:
+		p.printMemberExpr(id, expr)
+	case ast.ExprTupleIndex:
+		p.printTupleIndexExpr(id, expr)
 	case ast.ExprCall:
 		p.printCallExpr(id, expr)
 	case ast.ExprTuple:
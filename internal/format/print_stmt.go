@@ -0,0 +1,414 @@
+package format
+
+import (
+	"bytes"
+
+	"surge/internal/ast"
+	"surge/internal/source"
+)
+
+// printBlock re-emits a block with one statement per line at the current
+// indentation. Comments between statements are reattached from the source:
+// a comment on the same line as the previous statement stays trailing, others
+// get their own line, and at most one blank line separates entries.
+func (p *printer) printBlock(id ast.StmtID) {
+	stmt := p.builder.Stmts.Get(id)
+	if stmt == nil {
+		return
+	}
+	block := p.builder.Stmts.Block(id)
+	if block == nil || p.verbatim[id] {
+		p.writer.CopySpan(stmt.Span)
+		return
+	}
+
+	open := int(stmt.Span.Start) + 1
+	closing := int(stmt.Span.End) - 1
+	if err := p.writer.WriteByte('{'); err != nil {
+		panic(err)
+	}
+	if len(block.Stmts) == 0 && len(p.commentsBetween(open, closing)) == 0 {
+		if err := p.writer.WriteByte('}'); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	p.writer.IndentPush()
+	prev := open
+	for i, childID := range block.Stmts {
+		child := p.builder.Stmts.Get(childID)
+		if child == nil {
+			continue
+		}
+		p.printGap(prev, int(child.Span.Start), i == 0, false)
+		p.printStmt(childID)
+		prev = int(child.Span.End)
+	}
+	p.printGap(prev, closing, len(block.Stmts) == 0, true)
+	p.writer.IndentPop()
+	if err := p.writer.WriteByte('}'); err != nil {
+		panic(err)
+	}
+}
+
+// printGap emits the comments found in [start, end) and leaves the writer at
+// the start of a fresh line.
+func (p *printer) printGap(start, end int, first, last bool) {
+	cursor := start
+	lineComment := false
+	for _, sp := range p.commentsBetween(start, end) {
+		breaks := p.newlinesBetween(cursor, int(sp.Start))
+		switch {
+		case breaks == 0 && !p.writer.atLineStart:
+			p.writer.Space()
+		case breaks > 1 && !first:
+			p.writer.BlankLine()
+		default:
+			p.writer.Newline()
+		}
+		p.writer.writeIndent()
+		p.writer.CopySpan(sp)
+		lineComment = !bytes.HasPrefix(p.writer.sf.Content[sp.Start:sp.End], []byte("/*"))
+		cursor = int(sp.End)
+		first = false
+	}
+	breaks := p.newlinesBetween(cursor, end)
+	switch {
+	case last:
+		p.writer.Newline()
+	case breaks == 0 && cursor != start && !lineComment:
+		p.writer.Space()
+	case breaks > 1 && !first:
+		p.writer.BlankLine()
+	default:
+		p.writer.Newline()
+	}
+}
+
+// printStmt prints a statement in canonical form, falling back to the source
+// text when the statement has a shape the printer does not rewrite or when
+// rewriting it would drop a comment.
+func (p *printer) printStmt(id ast.StmtID) {
+	stmt := p.builder.Stmts.Get(id)
+	if stmt == nil {
+		return
+	}
+	p.writer.writeIndent()
+	mark := p.writer.mark()
+	want := p.writer.commentsIn(int(stmt.Span.Start), int(stmt.Span.End))
+	if p.printStmtBody(id, stmt) && p.writer.commentsCopied-mark.commentsCopied == want {
+		return
+	}
+	p.writer.reset(mark)
+	p.copyReindented(stmt.Span)
+}
+
+// copyReindented copies a source span whose first line is being moved to the
+// current indentation level, carrying its continuation lines along.
+func (p *printer) copyReindented(sp source.Span) {
+	delta := p.writer.indentLevel*p.opt.IndentWidth - p.sourceIndent(sp)
+	p.writer.CopyReindented(int(sp.Start), int(sp.End), delta)
+}
+
+func (p *printer) printStmtBody(id ast.StmtID, stmt *ast.Stmt) bool {
+	switch stmt.Kind {
+	case ast.StmtBlock:
+		p.printBlock(id)
+		return true
+	case ast.StmtLet:
+		let := p.builder.Stmts.Let(id)
+		if let == nil || !p.printLetBinding(let) {
+			return false
+		}
+		return p.writeSemicolon()
+	case ast.StmtConst:
+		c := p.builder.Stmts.Const(id)
+		if c == nil {
+			return false
+		}
+		p.writer.WriteString("const ")
+		p.writer.WriteString(p.string(c.Name))
+		if c.Type.IsValid() {
+			p.writer.WriteString(": ")
+			p.printTypeID(c.Type)
+		}
+		p.writer.WriteString(" = ")
+		p.printExpr(c.Value)
+		return p.writeSemicolon()
+	case ast.StmtExpr:
+		es := p.builder.Stmts.Expr(id)
+		if es == nil {
+			return false
+		}
+		expr := p.builder.Exprs.Get(es.Expr)
+		if expr == nil || expr.Span.Start != stmt.Span.Start {
+			// Statement attributes such as `@failfast async` sit outside the expression.
+			return false
+		}
+		p.printExpr(es.Expr)
+		if es.MissingSemicolon {
+			return true
+		}
+		return p.writeSemicolon()
+	case ast.StmtSignal:
+		sig := p.builder.Stmts.Signal(id)
+		if sig == nil {
+			return false
+		}
+		p.writer.WriteString("signal ")
+		p.writer.WriteString(p.string(sig.Name))
+		p.writer.WriteString(" := ")
+		p.printExpr(sig.Value)
+		return p.writeSemicolon()
+	case ast.StmtReturn:
+		ret := p.builder.Stmts.Return(id)
+		if ret == nil {
+			return false
+		}
+		return p.printKeywordExpr("return", ret.Expr)
+	case ast.StmtRet:
+		ret := p.builder.Stmts.Ret(id)
+		if ret == nil {
+			return false
+		}
+		return p.printKeywordExpr("ret", ret.Expr)
+	case ast.StmtBreak:
+		p.writer.WriteString("break")
+		p.printJumpLabel(id)
+		return p.writeSemicolon()
+	case ast.StmtContinue:
+		p.writer.WriteString("continue")
+		p.printJumpLabel(id)
+		return p.writeSemicolon()
+	case ast.StmtDrop:
+		drop := p.builder.Stmts.Drop(id)
+		if drop == nil {
+			return false
+		}
+		p.writer.WriteString("@drop ")
+		p.printExpr(drop.Expr)
+		return p.writeSemicolon()
+	case ast.StmtIf:
+		return p.printIf(id)
+	case ast.StmtWhile:
+		loop := p.builder.Stmts.While(id)
+		if loop == nil || !p.isBlock(loop.Body) {
+			return false
+		}
+		keywordStart := p.printLoopLabel(loop.Label, loop.LabelSpan, int(stmt.Span.Start))
+		p.writer.WriteString("while ")
+		p.printCond(keywordStart+len("while"), loop.Cond)
+		p.writer.Space()
+		p.printBlock(loop.Body)
+		return true
+	case ast.StmtForClassic:
+		return p.printForClassic(id)
+	case ast.StmtForIn:
+		loop := p.builder.Stmts.ForIn(id)
+		if loop == nil || !p.isBlock(loop.Body) {
+			return false
+		}
+		p.printLoopLabel(loop.Label, loop.LabelSpan, int(stmt.Span.Start))
+		p.writer.WriteString("for ")
+		if loop.Index != source.NoStringID {
+			p.writer.WriteString("(")
+			p.writer.WriteString(p.string(loop.Index))
+			p.writer.WriteString(", ")
+		}
+		p.writer.WriteString(p.string(loop.Pattern))
+		if loop.Type.IsValid() {
+			p.writer.WriteString(": ")
+			p.printTypeID(loop.Type)
+		}
+		if loop.Index != source.NoStringID {
+			p.writer.WriteString(")")
+		}
+		p.writer.WriteString(" in ")
+		p.printExpr(loop.Iterable)
+		p.writer.Space()
+		p.printBlock(loop.Body)
+		return true
+	default:
+		return false
+	}
+}
+
+// printLetBinding prints a let statement without its trailing semicolon.
+func (p *printer) printLetBinding(let *ast.LetStmt) bool {
+	if let.Pattern.IsValid() && (let.IsMut || let.Type.IsValid()) {
+		return false
+	}
+	p.writer.WriteString("let ")
+	if let.Pattern.IsValid() {
+		p.printExpr(let.Pattern)
+	} else {
+		if let.IsMut {
+			p.writer.WriteString("mut ")
+		}
+		p.writer.WriteString(p.string(let.Name))
+		if let.Type.IsValid() {
+			p.writer.WriteString(": ")
+			p.printTypeID(let.Type)
+		}
+	}
+	if let.Value.IsValid() {
+		p.writer.WriteString(" = ")
+		p.printExpr(let.Value)
+	}
+	return true
+}
+
+func (p *printer) printKeywordExpr(keyword string, expr ast.ExprID) bool {
+	p.writer.WriteString(keyword)
+	if expr.IsValid() {
+		p.writer.Space()
+		p.printExpr(expr)
+	}
+	return p.writeSemicolon()
+}
+
+func (p *printer) printIf(id ast.StmtID) bool {
+	stmt := p.builder.Stmts.Get(id)
+	ifStmt := p.builder.Stmts.If(id)
+	if stmt == nil || ifStmt == nil || !p.isBlock(ifStmt.Then) {
+		return false
+	}
+	p.writer.WriteString("if ")
+	p.printCond(int(stmt.Span.Start)+len("if"), ifStmt.Cond)
+	p.writer.Space()
+	p.printBlock(ifStmt.Then)
+	if !ifStmt.Else.IsValid() {
+		return true
+	}
+	p.writer.WriteString(" else ")
+	if p.isBlock(ifStmt.Else) {
+		p.printBlock(ifStmt.Else)
+		return true
+	}
+	elseStmt := p.builder.Stmts.Get(ifStmt.Else)
+	if elseStmt == nil || elseStmt.Kind != ast.StmtIf {
+		return false
+	}
+	return p.printIf(ifStmt.Else)
+}
+
+func (p *printer) printForClassic(id ast.StmtID) bool {
+	loop := p.builder.Stmts.ForClassic(id)
+	if loop == nil || !p.isBlock(loop.Body) {
+		return false
+	}
+	if stmt := p.builder.Stmts.Get(id); stmt != nil {
+		p.printLoopLabel(loop.Label, loop.LabelSpan, int(stmt.Span.Start))
+	}
+	p.writer.WriteString("for (")
+	if loop.Init.IsValid() {
+		init := p.builder.Stmts.Get(loop.Init)
+		if init == nil {
+			return false
+		}
+		switch init.Kind {
+		case ast.StmtLet:
+			let := p.builder.Stmts.Let(loop.Init)
+			if let == nil || !p.printLetBinding(let) {
+				return false
+			}
+		case ast.StmtExpr:
+			es := p.builder.Stmts.Expr(loop.Init)
+			if es == nil {
+				return false
+			}
+			p.printExpr(es.Expr)
+		default:
+			return false
+		}
+	}
+	p.writer.WriteString(";")
+	if loop.Cond.IsValid() {
+		p.writer.Space()
+		p.printExpr(loop.Cond)
+	}
+	p.writer.WriteString(";")
+	if loop.Post.IsValid() {
+		p.writer.Space()
+		p.printExpr(loop.Post)
+	}
+	p.writer.WriteString(") ")
+	p.printBlock(loop.Body)
+	return true
+}
+
+// printCond prints a loop or branch condition, keeping the optional parentheses
+// around it when the source has them.
+func (p *printer) printCond(keywordEnd int, cond ast.ExprID) {
+	expr := p.builder.Exprs.Get(cond)
+	if expr == nil || p.sourceBetween(keywordEnd, int(expr.Span.Start)) != "(" {
+		p.printExpr(cond)
+		return
+	}
+	if err := p.writer.WriteByte('('); err != nil {
+		panic(err)
+	}
+	p.printExpr(cond)
+	if err := p.writer.WriteByte(')'); err != nil {
+		panic(err)
+	}
+}
+
+// printLoopLabel writes `label: ` before a loop and returns the source offset of
+// the loop keyword.
+func (p *printer) printLoopLabel(label source.StringID, labelSpan source.Span, start int) int {
+	if label == source.NoStringID {
+		return start
+	}
+	p.writer.WriteString(p.string(label))
+	p.writer.WriteString(": ")
+	content := p.writer.sf.Content
+	pos := clampToContent(int(labelSpan.End), len(content))
+	for pos < len(content) && (content[pos] == ':' || content[pos] == ' ' || content[pos] == '\t' || content[pos] == '\n' || content[pos] == '\r') {
+		pos++
+	}
+	return pos
+}
+
+func (p *printer) printJumpLabel(id ast.StmtID) {
+	if jump := p.builder.Stmts.Jump(id); jump != nil {
+		p.writer.Space()
+		p.writer.WriteString(p.string(jump.Label))
+	}
+}
+
+func (p *printer) isBlock(id ast.StmtID) bool {
+	stmt := p.builder.Stmts.Get(id)
+	return stmt != nil && stmt.Kind == ast.StmtBlock
+}
+
+func (p *printer) writeSemicolon() bool {
+	return p.writer.WriteByte(';') == nil
+}
+
+// bodyIndent derives the indentation level of the source line holding pos, for
+// bodies nested in items whose headers are copied verbatim.
+func (p *printer) bodyIndent(pos source.Span) int {
+	return p.sourceIndent(pos) / p.opt.IndentWidth
+}
+
+// sourceIndent returns the leading whitespace width, in columns, of the source
+// line holding pos.
+func (p *printer) sourceIndent(pos source.Span) int {
+	content := p.writer.sf.Content
+	start := clampToContent(int(pos.Start), len(content))
+	lineStart := bytes.LastIndexByte(content[:start], '\n') + 1
+	width := 0
+	for _, b := range content[lineStart:start] {
+		switch b {
+		case ' ':
+			width++
+		case '\t':
+			width += p.opt.IndentWidth
+		default:
+			return width
+		}
+	}
+	return width
+}
@@ -20,7 +20,7 @@ func (p *printer) printTagItem(item *ast.Item, tag *ast.TagItem) {
 	p.writer.WriteString(p.string(tag.Name))
 
 	if len(tag.Generics) > 0 {
-		p.printGenerics(tag.Generics, tag.GenericsTrailingComma)
+		p.printGenerics(tag.Generics, tag.TypeParamsStart, tag.TypeParamsCount, tag.GenericsTrailingComma)
 	}
 
 	if err := p.writer.WriteByte('('); err != nil {
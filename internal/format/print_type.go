@@ -47,7 +47,7 @@ func (p *printer) printTypeAlias(item *ast.Item, typeItem *ast.TypeItem, decl *a
 	p.writer.Space()
 	p.writer.WriteString(p.string(typeItem.Name))
 	if len(typeItem.Generics) > 0 {
-		p.printGenerics(typeItem.Generics, typeItem.GenericsTrailingComma)
+		p.printGenerics(typeItem.Generics, typeItem.TypeParamsStart, typeItem.TypeParamsCount, typeItem.GenericsTrailingComma)
 	}
 
 	p.writer.WriteString(" = ")
@@ -82,7 +82,7 @@ func (p *printer) printStructDecl(item *ast.Item, typeItem *ast.TypeItem, decl *
 	p.writer.Space()
 	p.writer.WriteString(p.string(typeItem.Name))
 	if len(typeItem.Generics) > 0 {
-		p.printGenerics(typeItem.Generics, typeItem.GenericsTrailingComma)
+		p.printGenerics(typeItem.Generics, typeItem.TypeParamsStart, typeItem.TypeParamsCount, typeItem.GenericsTrailingComma)
 	}
 	p.writer.WriteString(" =")
 	p.writer.Space()
@@ -176,7 +176,7 @@ func (p *printer) printUnionDecl(item *ast.Item, typeItem *ast.TypeItem, decl *a
 	p.writer.Space()
 	p.writer.WriteString(p.string(typeItem.Name))
 	if len(typeItem.Generics) > 0 {
-		p.printGenerics(typeItem.Generics, typeItem.GenericsTrailingComma)
+		p.printGenerics(typeItem.Generics, typeItem.TypeParamsStart, typeItem.TypeParamsCount, typeItem.GenericsTrailingComma)
 	}
 	p.writer.WriteString(" = ")
 
@@ -36,6 +36,11 @@ type printer struct {
 	file    *ast.File
 	writer  *Writer
 	opt     Options
+
+	// verbatim lists blocks that are copied from the source unchanged;
+	// bodies collects reprinted function bodies for verification.
+	verbatim map[ast.StmtID]bool
+	bodies   []printedBody
 }
 
 // FormatFile formats an AST file into a byte slice.
@@ -55,15 +60,29 @@ func FormatFile(sf *source.File, b *ast.Builder, fid ast.FileID, opt Options) ([
 	}
 
 	opt = opt.withDefaults()
-	w := NewWriter(sf, opt)
-	pr := printer{
-		builder: b,
-		file:    file,
-		writer:  w,
-		opt:     opt,
-	}
-	pr.printFile()
-	return w.Bytes(), nil
+	comments := collectComments(sf)
+	verbatim := make(map[ast.StmtID]bool)
+	for {
+		w := NewWriter(sf, opt)
+		w.comments = comments
+		pr := printer{
+			builder:  b,
+			file:     file,
+			writer:   w,
+			opt:      opt,
+			verbatim: verbatim,
+		}
+		pr.printFile()
+		out := w.Bytes()
+		changed := pr.changedBodies(out)
+		if len(changed) == 0 {
+			return sortFormattedImports(sf, out), nil
+		}
+		// Each pass freezes at least one more body, so the loop terminates.
+		for _, id := range changed {
+			verbatim[id] = true
+		}
+	}
 }
 
 func (p *printer) printFile() {
@@ -117,6 +136,11 @@ func (p *printer) printItem(id ast.ItemID, item *ast.Item) {
 			p.printTagItem(item, tagItem)
 			return
 		}
+	case ast.ItemExtern:
+		if ext, ok := p.builder.Items.Extern(id); ok && ext != nil {
+			p.printExternItem(item, ext)
+			return
+		}
 	}
 	// fallback copy
 	p.writer.CopySpan(item.Span)
@@ -126,7 +150,7 @@ func (p *printer) printItem(id ast.ItemID, item *ast.Item) {
 // ensuring that top-level item kinds remain identical to the original.
 func CheckRoundTrip(sf *source.File, opt Options, maxDiag int) (ok bool, msg string) {
 	origBag := diag.NewBag(maxDiag)
-	origBuilder, origFileID := parseOnce(sf, origBag)
+	origFile, origBuilder, origFileID := parseOnce(sf.Path, sf.Content, origBag)
 	if origBuilder == nil || origBuilder.Files.Get(origFileID) == nil {
 		return false, "fmt-check: initial parse failed"
 	}
@@ -134,16 +158,13 @@ func CheckRoundTrip(sf *source.File, opt Options, maxDiag int) (ok bool, msg str
 		return false, "fmt-check: initial parse has errors"
 	}
 
-	formatted, err := FormatFile(sf, origBuilder, origFileID, opt)
+	formatted, err := FormatFile(origFile, origBuilder, origFileID, opt)
 	if err != nil {
 		return false, "fmt-check: formatter failed: " + err.Error()
 	}
 
-	fs2 := source.NewFileSetWithBase("")
-	fid := fs2.AddVirtual(sf.Path, formatted)
-	rebuiltFile := fs2.Get(fid)
 	newBag := diag.NewBag(maxDiag)
-	newBuilder, newFileID := parseOnce(rebuiltFile, newBag)
+	_, newBuilder, newFileID := parseOnce(sf.Path, formatted, newBag)
 	if newBuilder == nil || newBuilder.Files.Get(newFileID) == nil || newBag.HasErrors() {
 		return false, "fmt-check: reparse failed"
 	}
@@ -155,12 +176,15 @@ func CheckRoundTrip(sf *source.File, opt Options, maxDiag int) (ok bool, msg str
 	return true, "fmt-check: OK"
 }
 
-func parseOnce(sf *source.File, bag *diag.Bag) (*ast.Builder, ast.FileID) {
+// parseOnce parses content as a standalone virtual file.
+func parseOnce(path string, content []byte, bag *diag.Bag) (*source.File, *ast.Builder, ast.FileID) {
+	fs := source.NewFileSetWithBase("")
+	sf := fs.Get(fs.AddVirtual(path, content))
 	lx := lexer.New(sf, lexer.Options{Reporter: (&lexer.ReporterAdapter{Bag: bag}).Reporter()})
 	builder := ast.NewBuilder(ast.Hints{}, nil)
 	opts := parser.Options{Reporter: &diag.BagReporter{Bag: bag}, MaxErrors: uint(bag.Cap())}
-	res := parser.ParseFile(context.Background(), source.NewFileSet(), lx, builder, opts)
-	return builder, res.File
+	res := parser.ParseFile(context.Background(), fs, lx, builder, opts)
+	return sf, builder, res.File
 }
 
 func sameTopItemKinds(b1 *ast.Builder, f1 ast.FileID, b2 *ast.Builder, f2 ast.FileID) bool {
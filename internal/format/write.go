@@ -2,6 +2,7 @@ package format
 
 import (
 	"bytes"
+	"sort"
 
 	"surge/internal/source"
 )
@@ -14,6 +15,19 @@ type Writer struct {
 	buf         []byte
 	indentLevel int
 	atLineStart bool
+
+	// comments holds comment trivia spans in source order; commentsCopied counts
+	// how many of them were emitted, so callers can detect dropped comments.
+	comments       []source.Span
+	commentsCopied int
+}
+
+// writerMark captures the writer state for rewinding a speculative print.
+type writerMark struct {
+	bufLen         int
+	indentLevel    int
+	atLineStart    bool
+	commentsCopied int
 }
 
 // NewWriter creates a new formatting writer.
@@ -101,6 +115,34 @@ func (w *Writer) Newline() {
 	w.atLineStart = true
 }
 
+// BlankLine ends the current line and leaves exactly one empty line after it.
+func (w *Writer) BlankLine() {
+	if len(w.buf) == 0 {
+		return
+	}
+	w.Newline()
+	if len(w.buf) < 2 || w.buf[len(w.buf)-2] != '\n' {
+		w.buf = append(w.buf, '\n')
+	}
+	w.atLineStart = true
+}
+
+func (w *Writer) mark() writerMark {
+	return writerMark{
+		bufLen:         len(w.buf),
+		indentLevel:    w.indentLevel,
+		atLineStart:    w.atLineStart,
+		commentsCopied: w.commentsCopied,
+	}
+}
+
+func (w *Writer) reset(m writerMark) {
+	w.buf = w.buf[:m.bufLen]
+	w.indentLevel = m.indentLevel
+	w.atLineStart = m.atLineStart
+	w.commentsCopied = m.commentsCopied
+}
+
 // IndentPush increases the indentation level.
 func (w *Writer) IndentPush() {
 	w.indentLevel++
@@ -139,6 +181,59 @@ func (w *Writer) CopyRange(start, end int) {
 	chunk := w.sf.Content[start:end]
 	w.buf = append(w.buf, chunk...)
 	w.updateLineState(chunk[len(chunk)-1])
+	w.commentsCopied += w.commentsIn(start, end)
+}
+
+// CopyReindented copies [start, end) like CopyRange but shifts every line after
+// the first by delta columns, so multi-line fragments follow a changed
+// indentation of their first line. Lines are never shifted past their first
+// non-blank character.
+func (w *Writer) CopyReindented(start, end, delta int) {
+	if w.sf == nil {
+		return
+	}
+	start = clampToContent(start, len(w.sf.Content))
+	end = clampToContent(end, len(w.sf.Content))
+	if delta == 0 || w.opt.UseTabs || bytes.IndexByte(w.sf.Content[start:end], '\n') < 0 {
+		w.CopyRange(start, end)
+		return
+	}
+	w.commentsCopied += w.commentsIn(start, end)
+	lines := bytes.SplitAfter(w.sf.Content[start:end], []byte("\n"))
+	for i, line := range lines {
+		if i > 0 && len(bytes.TrimSpace(line)) > 0 {
+			if delta > 0 {
+				w.buf = append(w.buf, bytes.Repeat([]byte(" "), delta)...)
+			} else {
+				trim := 0
+				for trim < -delta && trim < len(line) && line[trim] == ' ' {
+					trim++
+				}
+				line = line[trim:]
+			}
+		}
+		w.buf = append(w.buf, line...)
+	}
+	if len(w.buf) > 0 {
+		w.updateLineState(w.buf[len(w.buf)-1])
+	}
+}
+
+// commentsIn counts comment spans lying entirely within [start, end).
+func (w *Writer) commentsIn(start, end int) int {
+	first := sort.Search(len(w.comments), func(i int) bool {
+		return int(w.comments[i].Start) >= start
+	})
+	count := 0
+	for _, sp := range w.comments[first:] {
+		if int(sp.Start) >= end {
+			break
+		}
+		if int(sp.End) <= end {
+			count++
+		}
+	}
+	return count
 }
 
 // TrimmedCopySpan copies a span from the source file to the output, trimming leading/trailing whitespace.
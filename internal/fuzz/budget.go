@@ -0,0 +1,65 @@
+package fuzztests
+
+import (
+	"context"
+	"fmt"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/lexer"
+	"surge/internal/parser"
+	"surge/internal/source"
+)
+
+// ASTNodeBudget is the number of AST nodes (items, statements, expressions
+// and type expressions) one fuzz input may produce. Inputs are capped at
+// maxFuzzInput bytes and ordinary sources stay under one node per byte, so
+// exceeding the budget means the parser grows its arenas faster than its
+// input.
+const ASTNodeBudget = 1 << 17
+
+// parsedInput is the result of parsing one fuzz input.
+type parsedInput struct {
+	builder *ast.Builder
+	file    ast.FileID
+	bag     *diag.Bag
+}
+
+// parseInput прогоняет input через лексер и парсер с бюджетом ASTNodeBudget.
+func parseInput(ctx context.Context, input []byte) (parsedInput, error) {
+	return parseInputWithBudget(ctx, input, ASTNodeBudget)
+}
+
+// parseInputWithBudget parses input and stops the parser as soon as the
+// ast.Builder arenas hold more than budget nodes, so a runaway input is cut
+// off mid-file instead of being parsed to the end.
+func parseInputWithBudget(ctx context.Context, input []byte, budget uint64) (parsedInput, error) {
+	fs := source.NewFileSet()
+	fileID := fs.AddVirtual("fuzz.sg", input)
+	file := fs.Get(fileID)
+
+	bag := diag.NewBag(128)
+	reporter := diag.BagReporter{Bag: bag}
+	lx := lexer.New(file, lexer.Options{Reporter: reporter})
+
+	builder := ast.NewBuilder(ast.Hints{}, nil)
+	opts := parser.Options{
+		Reporter:  reporter,
+		MaxErrors: 128,
+		MaxNodes:  budget,
+	}
+
+	res := parser.ParseFile(ctx, fs, lx, builder, opts)
+	parsed := parsedInput{builder: builder, file: res.File, bag: bag}
+	if !res.NodeBudgetExceeded {
+		return parsed, nil
+	}
+	return parsed, astBudgetError(builder, len(input), budget)
+}
+
+// astBudgetError describes an input that made the parser exceed budget nodes.
+func astBudgetError(builder *ast.Builder, inputLen int, budget uint64) error {
+	return fmt.Errorf("AST allocation budget exceeded: %d nodes for %d input bytes (budget %d; items=%d stmts=%d exprs=%d types=%d)",
+		builder.NodeCount(), inputLen, budget,
+		builder.Items.Arena.Len(), builder.Stmts.Arena.Len(), builder.Exprs.Arena.Len(), builder.Types.Arena.Len())
+}
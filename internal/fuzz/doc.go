@@ -1,13 +1,20 @@
 // Package fuzztests houses Go fuzz harnesses that exercise the early Surge
-// compilation pipeline (source -> lexer -> parser). Its goal is to smoke test
+// compilation pipeline (source -> lexer -> parser -> symbols). Its goal is to smoke test
 // robustness and guard against panics or allocator explosions on arbitrary
-// inputs.
+// inputs. Allocator explosions are caught by ASTNodeBudget: the parser stops
+// as soon as its ast.Builder arenas outgrow the budget and the fuzz case fails
+// with the node counts, so the input lands in the corpus as a reproducible
+// failure.
+//
+// FuzzResolverNoPanic continues into symbols.ResolveFile for inputs that parse
+// without diagnostics. It skips inputs the parser rejects or crashes on, so its
+// failures are resolver bugs only.
 //
 // Назначение: запускать fuzz-обработчики, которые загружают байты в FileSet и
-// прогоняют их через лексер/парсер.
+// прогоняют их через лексер/парсер, а чисто разобранные — через резолвер.
 //
 // Не делает: генерацию корпусов, запись файлов, выполнение CLI.
 //
 // Зависимости: internal/source, internal/lexer, internal/parser, internal/diag,
-// internal/ast.
+// internal/ast, internal/symbols.
 package fuzztests
@@ -2,14 +2,9 @@ package fuzztests
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
-
-	"surge/internal/ast"
-	"surge/internal/diag"
-	"surge/internal/lexer"
-	"surge/internal/parser"
-	"surge/internal/source"
 )
 
 // parseTimeout is the maximum time allowed for parsing a single input.
@@ -18,28 +13,16 @@ const parseTimeout = 5 * time.Second
 
 func FuzzParserBuildsAST(f *testing.F) {
 	addCorpusSeeds(f)
-	f.Fuzz(func(_ *testing.T, input []byte) {
+	f.Fuzz(func(t *testing.T, input []byte) {
 		if len(input) > maxFuzzInput {
 			input = append([]byte(nil), input[:maxFuzzInput]...)
 		} else {
 			input = append([]byte(nil), input...)
 		}
 
-		fs := source.NewFileSet()
-		fileID := fs.AddVirtual("fuzz.sg", input)
-		file := fs.Get(fileID)
-
-		bag := diag.NewBag(128)
-		reporter := diag.BagReporter{Bag: bag}
-		lx := lexer.New(file, lexer.Options{Reporter: reporter})
-
-		builder := ast.NewBuilder(ast.Hints{}, nil)
-		opts := parser.Options{
-			Reporter:  reporter,
-			MaxErrors: 128,
+		if _, err := parseInput(context.Background(), input); err != nil {
+			t.Fatalf("%v\ninput (%d bytes): %q", err, len(input), truncateForLog(input, 200))
 		}
-
-		_ = parser.ParseFile(context.Background(), fs, lx, builder, opts)
 	})
 }
 
@@ -71,30 +54,18 @@ func FuzzParserNoHang(f *testing.F) {
 
 		// Run parser in a goroutine
 		done := make(chan struct{})
+		var budgetErr error
 		go func() {
 			defer close(done)
-
-			fs := source.NewFileSet()
-			fileID := fs.AddVirtual("fuzz.sg", input)
-			file := fs.Get(fileID)
-
-			bag := diag.NewBag(128)
-			reporter := diag.BagReporter{Bag: bag}
-			lx := lexer.New(file, lexer.Options{Reporter: reporter})
-
-			builder := ast.NewBuilder(ast.Hints{}, nil)
-			opts := parser.Options{
-				Reporter:  reporter,
-				MaxErrors: 128,
-			}
-
-			_ = parser.ParseFile(ctx, fs, lx, builder, opts)
+			_, budgetErr = parseInput(ctx, input)
 		}()
 
 		// Wait for completion or timeout
 		select {
 		case <-done:
-			// Parser completed successfully
+			if budgetErr != nil {
+				t.Fatalf("%v\ninput (%d bytes): %q", budgetErr, len(input), truncateForLog(input, 200))
+			}
 		case <-ctx.Done():
 			t.Fatalf("parser hang detected: parsing took longer than %v\ninput (%d bytes): %q",
 				parseTimeout, len(input), truncateForLog(input, 200))
@@ -109,3 +80,30 @@ func truncateForLog(input []byte, maxLen int) []byte {
 	}
 	return append(input[:maxLen], []byte("...")...)
 }
+
+func TestParseInputTripsASTBudget(t *testing.T) {
+	const budget = 1 << 10
+	// Каждое "+1" даёт два узла по мере чтения, так что вход заметно больше
+	// бюджета, но укладывается в maxFuzzInput.
+	input := []byte("fn f() { let x = 1" + strings.Repeat("+1", 8*budget) + "; }")
+	if len(input) > maxFuzzInput {
+		t.Fatalf("input of %d bytes exceeds maxFuzzInput", len(input))
+	}
+
+	parsed, err := parseInputWithBudget(context.Background(), input, budget)
+	if err == nil {
+		t.Fatalf("expected %d additions to exceed the budget of %d nodes", 8*budget, budget)
+	}
+	if !strings.Contains(err.Error(), "AST allocation budget exceeded") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Парсер останавливается посреди файла, а не разбирает его до конца.
+	if got := parsed.builder.NodeCount(); got > 2*budget {
+		t.Fatalf("parser kept allocating after the budget: %d nodes", got)
+	}
+
+	small := []byte("fn f() { let x = 1" + strings.Repeat("+1", budget/4) + "; }")
+	if _, err := parseInputWithBudget(context.Background(), small, budget); err != nil {
+		t.Fatalf("unexpected budget error for a short chain: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package fuzztests
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"surge/internal/diag"
+	"surge/internal/symbols"
+)
+
+// maxResolveDiagnostics bounds the diagnostics one fuzz input may report
+// during symbol resolution.
+const maxResolveDiagnostics = 128
+
+// resolveInput runs symbols.ResolveFile with validation over a cleanly parsed
+// input. A Go panic in the resolver is returned as an error, so the fuzz
+// target reports it as a resolver failure rather than crashing the runner.
+func resolveInput(parsed parsedInput) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("resolver panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	bag := diag.NewBag(maxResolveDiagnostics)
+	symbols.ResolveFile(parsed.builder, parsed.file, &symbols.ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+		NoStd:    true,
+	})
+	return nil
+}
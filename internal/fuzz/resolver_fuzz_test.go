@@ -0,0 +1,64 @@
+package fuzztests
+
+import (
+	"context"
+	"testing"
+)
+
+// FuzzResolverNoPanic feeds inputs that parse without errors to the symbol
+// resolver. Inputs the parser rejects, or that crash it, are skipped: those
+// belong to the parser fuzz targets, so a failure here is always a resolver bug.
+func FuzzResolverNoPanic(f *testing.F) {
+	addCorpusSeeds(f)
+	for _, seed := range resolverSeeds() {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		if len(input) > maxFuzzInput {
+			input = append([]byte(nil), input[:maxFuzzInput]...)
+		} else {
+			input = append([]byte(nil), input...)
+		}
+
+		parsed, ok := parseCleanly(input)
+		if !ok {
+			t.Skip("input does not parse cleanly")
+		}
+		if err := resolveInput(parsed); err != nil {
+			t.Fatalf("%v\ninput (%d bytes): %q", err, len(input), truncateForLog(input, 200))
+		}
+	})
+}
+
+// parseCleanly parses input and reports whether it produced an AST without
+// diagnostics. A parser panic or budget overrun counts as unclean input.
+func parseCleanly(input []byte) (parsed parsedInput, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	parsed, err := parseInput(context.Background(), input)
+	if err != nil || parsed.bag.HasErrors() {
+		return parsed, false
+	}
+	return parsed, true
+}
+
+func TestResolverSeedsParseCleanly(t *testing.T) {
+	for i, seed := range resolverSeeds() {
+		parsed, ok := parseCleanly(seed)
+		if !ok {
+			if parsed.bag != nil {
+				t.Errorf("resolver seed #%d does not parse cleanly: %+v", i, parsed.bag.Items())
+			} else {
+				t.Errorf("resolver seed #%d does not parse cleanly", i)
+			}
+			continue
+		}
+		if err := resolveInput(parsed); err != nil {
+			t.Errorf("resolver seed #%d: %v", i, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package fuzztests
 
 import (
 	"bytes"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -91,3 +92,44 @@ func clampSeed(src []byte) []byte {
 	}
 	return append([]byte(nil), src[:maxSeedBytes]...)
 }
+
+// resolverSeeds returns syntactically valid snippets that stress the symbol
+// resolver: deep shadowing, large overload sets, name clashes across scopes.
+func resolverSeeds() [][]byte {
+	seeds := []string{
+		"fn main() -> int { let x = 1; { let x = x + 1; { let x = x * 2; return x; } } }\n",
+		"fn f(x: int) -> int { return x; }\nfn f(x: string) -> string { return x; }\nfn main() { f(1); f(\"a\"); }\n",
+		"type T = { t: int };\nfn T() -> int { return 0; }\nfn main() { let T = 1; }\n",
+		"fn main() { let f = 1; f(); main(); undefined_name; }\n",
+		"fn g<T>(x: T) -> T { return x; }\nfn g<T, U>(x: T, y: U) -> T { return x; }\nfn main() { g(1); g(1, 2); }\n",
+		"tag Some<T>(T);\nfn main() { let Some = 1; let x = Some(2); }\n",
+		"fn main() { for i in 0..10 { let i = i; for i in 0..i { let i = i; } } }\n",
+		"let a = b;\nlet b = a;\nfn main() { }\n",
+		"fn main() { let x: int = 1; let x: string = \"x\"; let x = x; }\n",
+		"@cfg(os = \"linux\")\nfn p() -> int { return 1; }\n@cfg(os = \"windows\")\nfn p() -> int { return 2; }\nfn main() { p(); }\n",
+	}
+
+	var deep bytes.Buffer
+	deep.WriteString("fn main() -> int {\n    let x = 0;\n")
+	for range 200 {
+		deep.WriteString("    {\n    let x = x + 1;\n")
+	}
+	for range 200 {
+		deep.WriteString("    }\n")
+	}
+	deep.WriteString("    return x;\n}\n")
+	seeds = append(seeds, deep.String())
+
+	var overloads bytes.Buffer
+	for i := range 256 {
+		fmt.Fprintf(&overloads, "fn over(a: int, b: int%s) -> int { return a; }\n", bytes.Repeat([]byte(", c: int"), i%8))
+		fmt.Fprintf(&overloads, "fn f%d() -> int { return over(1, 2); }\n", i)
+	}
+	seeds = append(seeds, overloads.String())
+
+	out := make([][]byte, 0, len(seeds))
+	for _, seed := range seeds {
+		out = append(out, clampSeed([]byte(seed)))
+	}
+	return out
+}
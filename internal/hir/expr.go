@@ -41,6 +41,8 @@ const (
 	ExprSelect
 	// ExprRace represents race expression over awaitables.
 	ExprRace
+	// ExprParallel represents parallel map/reduce; NormalizeModule attaches a for-in loop fallback.
+	ExprParallel
 	// ExprTagTest checks whether a union value matches a tag or the `nothing` variant.
 	ExprTagTest
 	// ExprTagPayload extracts a payload component from a tagged union value.
@@ -98,6 +100,8 @@ func (k ExprKind) String() string {
 		return "Select"
 	case ExprRace:
 		return "Race"
+	case ExprParallel:
+		return "Parallel"
 	case ExprTagTest:
 		return "TagTest"
 	case ExprTagPayload:
@@ -225,6 +229,7 @@ func (IndexData) exprData() {}
 type StructLitData struct {
 	TypeName string
 	TypeID   types.TypeID
+	Base     *Expr // `..base` spread: fields not listed in Fields are taken from it
 	Fields   []StructFieldInit
 }
 
@@ -266,11 +271,13 @@ func (TupleLitData) exprData() {}
 
 // CompareArm represents one arm in a compare expression.
 type CompareArm struct {
-	Pattern   *Expr       // Pattern to match against
-	Guard     *Expr       // Optional guard condition (nil if none)
-	Result    *Expr       // Result expression
-	IsFinally bool        // true if this is a 'finally' clause
-	Span      source.Span // Source location
+	Pattern      *Expr                  // Pattern to match against
+	PatternKind  ast.ComparePatternKind // Literal/range patterns are matched by value
+	Alternatives []*Expr                // Or-pattern alternatives (PatternKind == ComparePatternOr)
+	Guard        *Expr                  // Optional guard condition (nil if none)
+	Result       *Expr                  // Result expression
+	IsFinally    bool                   // true if this is a 'finally' clause
+	Span         source.Span            // Source location
 }
 
 // CompareData holds data for ExprCompare.
@@ -296,6 +303,33 @@ type SelectData struct {
 
 func (SelectData) exprData() {}
 
+// ParallelArg is a binding introduced by a parallel argument list.
+type ParallelArg struct {
+	Name     string
+	SymbolID symbols.SymbolID
+	Type     types.TypeID
+}
+
+// ParallelData holds data for ExprParallel.
+// Map binds Args[0] to each element; reduce binds Args[0] to the
+// accumulator and Args[1] to each element.
+type ParallelData struct {
+	Kind     ast.ExprParallelKind
+	Iterable *Expr
+	Init     *Expr // nil for map
+	Args     []ParallelArg
+	Body     *Expr
+	Captures []BlockingCapture
+	// Seq is the sequential for-in desugaring built by NormalizeModule. It owns
+	// its own copy of the operands, so backends lower either Seq or the fields above.
+	Seq *Block
+
+	seq  *ParallelData // independent copy of the operands consumed by normalization
+	push *Expr         // rt_array_push callee resolved by the lowerer (map fallback only)
+}
+
+func (ParallelData) exprData() {}
+
 // TagTestData holds data for ExprTagTest.
 type TagTestData struct {
 	Value   *Expr
@@ -361,11 +395,12 @@ func (SpawnData) exprData() {}
 type AsyncData struct {
 	Body     *Block
 	Failfast bool
+	Captures []BlockingCapture // enclosing-function bindings passed to the task by value
 }
 
 func (AsyncData) exprData() {}
 
-// BlockingCapture describes a captured symbol in a blocking block.
+// BlockingCapture describes a captured symbol in an async or blocking block or a parallel body.
 type BlockingCapture struct {
 	SymbolID symbols.SymbolID
 	Name     string
@@ -120,7 +120,7 @@ func (l *lowerer) lowerFile(fileID ast.FileID) {
 
 	for _, itemID := range file.Items {
 		item := l.builder.Items.Arena.Get(uint32(itemID))
-		if item == nil {
+		if item == nil || !l.symRes.ItemEnabled(itemID) {
 			continue
 		}
 
@@ -7,6 +7,7 @@ import (
 	"surge/internal/sema"
 	"surge/internal/source"
 	"surge/internal/symbols"
+	"surge/internal/token"
 	"surge/internal/types"
 )
 
@@ -118,7 +119,7 @@ func (l *lowerer) lowerExprCore(exprID ast.ExprID) *Expr {
 		return l.lowerSpawnExpr(expr, ty)
 
 	case ast.ExprAsync:
-		return l.lowerAsyncExpr(expr, ty)
+		return l.lowerAsyncExpr(exprID, expr, ty)
 	case ast.ExprBlocking:
 		return l.lowerBlockingExpr(exprID, expr, ty)
 
@@ -140,8 +141,7 @@ func (l *lowerer) lowerExprCore(exprID ast.ExprID) *Expr {
 		return nil
 
 	case ast.ExprParallel:
-		// Parallel is reserved for v2+
-		return nil
+		return l.lowerParallelExpr(exprID, expr, ty)
 
 	default:
 		return nil
@@ -242,6 +242,12 @@ func (l *lowerer) lowerLiteralExpr(expr *ast.Expr, ty types.TypeID) *Expr {
 		data.BoolValue = false
 	case ast.ExprLitNothing:
 		data.Kind = LiteralNothing
+	case ast.ExprLitChar:
+		// символ — беззнаковое целое с кодом Unicode-скаляра
+		r, _ := token.CharLiteralValue(rawValue)
+		data.Kind = LiteralInt
+		data.IntValue = int64(r)
+		data.Text = strconv.FormatInt(data.IntValue, 10)
 	}
 
 	return &Expr{
@@ -549,6 +555,7 @@ func (l *lowerer) lowerStructExpr(expr *ast.Expr, ty types.TypeID) *Expr {
 		Span: expr.Span,
 		Data: StructLitData{
 			TypeID: ty,
+			Base:   l.lowerExpr(structData.Base),
 			Fields: fields,
 		},
 	}
@@ -583,11 +590,19 @@ func (l *lowerer) lowerCompareExpr(expr *ast.Expr, ty types.TypeID) *Expr {
 	arms := make([]CompareArm, len(cmpData.Arms))
 	for i, arm := range cmpData.Arms {
 		arms[i] = CompareArm{
-			Pattern:   l.lowerExpr(arm.Pattern),
-			Guard:     l.lowerExpr(arm.Guard),
-			Result:    l.lowerExpr(arm.Result),
-			IsFinally: arm.IsFinally,
-			Span:      arm.PatternSpan,
+			Pattern:     l.lowerExpr(arm.Pattern),
+			PatternKind: arm.PatternKind,
+			Guard:       l.lowerExpr(arm.Guard),
+			Result:      l.lowerExpr(arm.Result),
+			IsFinally:   arm.IsFinally,
+			Span:        arm.PatternSpan,
+		}
+		if arm.PatternKind == ast.ComparePatternOr {
+			// Альтернативы берутся из AST: группы при lowering разворачиваются,
+			// и `(1 | 2)` уже нельзя было бы отличить от `1 | 2`.
+			for _, alt := range l.builder.Exprs.OrPatternAlternatives(arm.Pattern) {
+				arms[i].Alternatives = append(arms[i].Alternatives, l.lowerExpr(alt))
+			}
 		}
 	}
 
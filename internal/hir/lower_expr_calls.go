@@ -127,7 +127,7 @@ func (l *lowerer) lowerCallExpr(exprID ast.ExprID, expr *ast.Expr, ty types.Type
 			if !l.isModuleExpr(member.Target) {
 				recv := l.lowerExpr(member.Target)
 				if recv != nil && recv.Type != types.NoTypeID {
-					recv = l.applySelfBorrow(symID, recv)
+					recv = l.applyReceiverAdjust(exprID, symID, recv)
 					args = append([]*Expr{recv}, args...)
 				}
 			}
@@ -89,7 +89,7 @@ func (l *lowerer) lowerSpawnExpr(expr *ast.Expr, ty types.TypeID) *Expr {
 }
 
 // lowerAsyncExpr lowers an async block expression.
-func (l *lowerer) lowerAsyncExpr(expr *ast.Expr, ty types.TypeID) *Expr {
+func (l *lowerer) lowerAsyncExpr(exprID ast.ExprID, expr *ast.Expr, ty types.TypeID) *Expr {
 	asyncData := l.builder.Exprs.Asyncs.Get(uint32(expr.Payload))
 	if asyncData == nil {
 		return nil
@@ -112,11 +112,18 @@ func (l *lowerer) lowerAsyncExpr(expr *ast.Expr, ty types.TypeID) *Expr {
 		}
 	}
 
+	var captures []BlockingCapture
+	if l.semaRes != nil && l.semaRes.AsyncCaptures != nil {
+		if caps, ok := l.semaRes.AsyncCaptures[exprID]; ok {
+			captures = l.blockingCaptureInfo(caps)
+		}
+	}
+
 	return &Expr{
 		Kind: ExprAsync,
 		Type: ty,
 		Span: expr.Span,
-		Data: AsyncData{Body: body, Failfast: failfast},
+		Data: AsyncData{Body: body, Failfast: failfast, Captures: captures},
 	}
 }
 
@@ -212,3 +219,57 @@ func (l *lowerer) blockingCaptureInfo(captures []symbols.SymbolID) []BlockingCap
 	}
 	return out
 }
+
+// lowerParallelExpr lowers parallel map/reduce; NormalizeModule attaches a for-in loop fallback.
+func (l *lowerer) lowerParallelExpr(exprID ast.ExprID, expr *ast.Expr, ty types.TypeID) *Expr {
+	parData, ok := l.builder.Exprs.Parallel(exprID)
+	if !ok || parData == nil {
+		return nil
+	}
+	data := l.lowerParallelData(parData)
+	// Operands are lowered twice: the loop fallback must not share nodes with
+	// the parallel form, since later passes rewrite expressions in place.
+	seq := l.lowerParallelData(parData)
+	if seq.Kind == ast.ExprParallelMap {
+		seq.push, _ = l.intrinsicCallee("rt_array_push", expr.Span)
+	}
+	data.seq = &seq
+	if l.semaRes != nil && l.semaRes.ParallelCaptures != nil {
+		if caps, ok := l.semaRes.ParallelCaptures[exprID]; ok {
+			data.Captures = l.blockingCaptureInfo(caps)
+		}
+	}
+	return &Expr{
+		Kind: ExprParallel,
+		Type: ty,
+		Span: expr.Span,
+		Data: data,
+	}
+}
+
+func (l *lowerer) lowerParallelData(parData *ast.ExprParallelData) ParallelData {
+	args := make([]ParallelArg, 0, len(parData.Args))
+	for _, argID := range parData.Args {
+		arg := ParallelArg{}
+		if ident, ok := l.builder.Exprs.Ident(argID); ok && ident != nil {
+			arg.Name = l.lookupString(ident.Name)
+		}
+		if l.symRes != nil {
+			arg.SymbolID = l.symRes.ExprSymbols[argID]
+		}
+		if arg.SymbolID.IsValid() && l.semaRes != nil && l.semaRes.BindingTypes != nil {
+			arg.Type = l.semaRes.BindingTypes[arg.SymbolID]
+		}
+		args = append(args, arg)
+	}
+	data := ParallelData{
+		Kind:     parData.Kind,
+		Iterable: l.lowerIterableExpr(parData.Iterable),
+		Args:     args,
+		Body:     l.lowerExpr(parData.Body),
+	}
+	if parData.Init.IsValid() {
+		data.Init = l.lowerExpr(parData.Init)
+	}
+	return data
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"surge/internal/ast"
+	"surge/internal/sema"
 	"surge/internal/source"
 	"surge/internal/symbols"
 	"surge/internal/types"
@@ -43,6 +44,78 @@ func (l *lowerer) boolLiteralExpr(span source.Span, value bool) *Expr {
 	}
 }
 
+// applyReceiverAdjust materializes the receiver adjustment recorded by sema for a method call.
+// Calls without a record fall back to borrowing by the self parameter.
+func (l *lowerer) applyReceiverAdjust(callID ast.ExprID, symID symbols.SymbolID, recv *Expr) *Expr {
+	if l.semaRes != nil && l.semaRes.ReceiverAdjusts != nil {
+		switch l.semaRes.ReceiverAdjusts[callID] {
+		case sema.ReceiverDeref:
+			return l.applyDeref(recv)
+		case sema.ReceiverBorrow:
+			return l.applyBorrow(recv, false)
+		case sema.ReceiverReborrow:
+			if ref := l.borrowElementPlace(recv, false); ref != nil {
+				return ref
+			}
+			// На ссылке applyBorrow строит явный реборроу &*recv.
+			return l.applyBorrow(recv, false)
+		case sema.ReceiverBorrowMut:
+			if _, _, recvMut := l.referenceInfo(recv.Type); !recvMut {
+				if ref := l.borrowElementPlace(recv, true); ref != nil {
+					return ref
+				}
+			}
+			return l.applyBorrow(recv, true)
+		}
+	}
+	return l.applySelfBorrow(symID, recv)
+}
+
+// borrowElementPlace borrows xs[i] or s.f directly. Such expressions are typed
+// as references but denote element places, so the borrow is taken of the place
+// itself, without a deref. Other receivers yield nil.
+func (l *lowerer) borrowElementPlace(recv *Expr, mut bool) *Expr {
+	if recv == nil || (recv.Kind != ExprIndex && recv.Kind != ExprFieldAccess) {
+		return nil
+	}
+	elem, ok, _ := l.referenceInfo(recv.Type)
+	if !ok || l.fieldDeclaredAsReference(recv) {
+		return nil
+	}
+	op := ast.ExprUnaryRef
+	if mut {
+		op = ast.ExprUnaryRefMut
+	}
+	return &Expr{
+		Kind: ExprUnaryOp,
+		Type: l.referenceType(elem, mut),
+		Span: recv.Span,
+		Data: UnaryOpData{Op: op, Operand: recv},
+	}
+}
+
+// fieldDeclaredAsReference reports whether recv reads a struct field whose
+// declared type is itself a reference: its value is the reference, not a place.
+func (l *lowerer) fieldDeclaredAsReference(recv *Expr) bool {
+	data, ok := recv.Data.(FieldAccessData)
+	if !ok || data.Object == nil || l.semaRes == nil || l.semaRes.TypeInterner == nil {
+		return false
+	}
+	in := l.semaRes.TypeInterner
+	obj := data.Object.Type
+	if elem, isRef, _ := l.referenceInfo(obj); isRef {
+		obj = elem
+	}
+	for _, field := range in.StructFields(resolveAliasHIR(in, obj)) {
+		if l.lookupString(field.Name) != data.FieldName {
+			continue
+		}
+		_, isRef, _ := l.referenceInfo(resolveAliasHIR(in, field.Type))
+		return isRef
+	}
+	return false
+}
+
 // applySelfBorrow applies a borrow operation for method receivers.
 func (l *lowerer) applySelfBorrow(symID symbols.SymbolID, recv *Expr) *Expr {
 	if recv == nil || !symID.IsValid() {
@@ -356,6 +356,23 @@ func spanWithin(span, container source.Span) bool {
 	return span.Start >= container.Start && span.End <= container.End
 }
 
+func (l *lowerer) scopeForStmt(stmtID ast.StmtID) symbols.ScopeID {
+	if !stmtID.IsValid() || l.symRes == nil || l.symRes.Table == nil || l.symRes.Table.Scopes == nil {
+		return symbols.NoScopeID
+	}
+	scopeMax, err := safecast.Conv[uint32](l.symRes.Table.Scopes.Len())
+	if err != nil {
+		return symbols.NoScopeID
+	}
+	for id := symbols.ScopeID(scopeMax); id != 0; id-- {
+		scope := l.symRes.Table.Scopes.Get(id)
+		if scope != nil && scope.Owner.Kind == symbols.ScopeOwnerStmt && scope.Owner.Stmt == stmtID && scope.Owner.ASTFile == l.module.SourceAST {
+			return id
+		}
+	}
+	return symbols.NoScopeID
+}
+
 func (l *lowerer) scopeForExtern(memberID ast.ExternMemberID) symbols.ScopeID {
 	if !memberID.IsValid() || l.symRes == nil || l.symRes.Table == nil || l.symRes.Table.Scopes == nil {
 		return symbols.NoScopeID
@@ -476,6 +493,8 @@ func (l *lowerer) typeFromResultKey(key symbols.TypeKey) types.TypeID {
 		return builtins.Uint32
 	case "uint64":
 		return builtins.Uint64
+	case "char":
+		return builtins.Char
 	case "float":
 		return builtins.Float
 	case "float16":
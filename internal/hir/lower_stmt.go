@@ -2,6 +2,8 @@ package hir
 
 import (
 	"surge/internal/ast"
+	"surge/internal/source"
+	"surge/internal/symbols"
 	"surge/internal/types"
 )
 
@@ -85,17 +87,25 @@ func (l *lowerer) lowerStmt(stmtID ast.StmtID) *Stmt {
 		}
 
 	case ast.StmtBreak:
+		var data BreakData
+		if jump := l.builder.Stmts.Jump(stmtID); jump != nil {
+			data.Label = l.lookupString(jump.Label)
+		}
 		return &Stmt{
 			Kind: StmtBreak,
 			Span: stmt.Span,
-			Data: BreakData{},
+			Data: data,
 		}
 
 	case ast.StmtContinue:
+		var data ContinueData
+		if jump := l.builder.Stmts.Jump(stmtID); jump != nil {
+			data.Label = l.lookupString(jump.Label)
+		}
 		return &Stmt{
 			Kind: StmtContinue,
 			Span: stmt.Span,
-			Data: ContinueData{},
+			Data: data,
 		}
 
 	case ast.StmtIf:
@@ -276,7 +286,8 @@ func (l *lowerer) lowerWhileStmt(stmtID ast.StmtID, stmt *ast.Stmt) *Stmt {
 	}
 
 	data := WhileData{
-		Cond: l.lowerExpr(whileStmt.Cond),
+		Cond:  l.lowerExpr(whileStmt.Cond),
+		Label: l.lookupString(whileStmt.Label),
 	}
 
 	if whileStmt.Body.IsValid() {
@@ -298,7 +309,8 @@ func (l *lowerer) lowerForClassicStmt(stmtID ast.StmtID, stmt *ast.Stmt) *Stmt {
 	}
 
 	data := ForData{
-		Kind: ForClassic,
+		Kind:  ForClassic,
+		Label: l.lookupString(forStmt.Label),
 	}
 
 	if forStmt.Init.IsValid() {
@@ -331,9 +343,17 @@ func (l *lowerer) lowerForInStmt(stmtID ast.StmtID, stmt *ast.Stmt) *Stmt {
 	data := ForData{
 		Kind:    ForIn,
 		VarName: l.lookupString(forStmt.Pattern),
+		Label:   l.lookupString(forStmt.Label),
 	}
 
 	data.VarSym = l.symbolForStmt(stmtID)
+	if forStmt.Index != source.NoStringID {
+		// Индекс объявлен в той же области, что и элемент; ищем оба по имени.
+		scope := l.scopeForStmt(stmtID)
+		data.IndexName = l.lookupString(forStmt.Index)
+		data.IndexSym = l.symbolInScope(scope, forStmt.Index, symbols.SymbolLet)
+		data.VarSym = l.symbolInScope(scope, forStmt.Pattern, symbols.SymbolLet)
+	}
 
 	if forStmt.Type.IsValid() {
 		data.VarType = l.lookupTypeFromAST(forStmt.Type)
@@ -344,18 +364,7 @@ func (l *lowerer) lowerForInStmt(stmtID ast.StmtID, stmt *ast.Stmt) *Stmt {
 		}
 	}
 	if forStmt.Iterable.IsValid() {
-		data.Iterable = l.lowerExpr(forStmt.Iterable)
-		if data.Iterable != nil && l.semaRes != nil && l.semaRes.RangeSymbols != nil {
-			if symID, ok := l.semaRes.RangeSymbols[forStmt.Iterable]; ok && symID.IsValid() {
-				rangeType := data.Iterable.Type
-				if l.semaRes.RangeTypes != nil {
-					if ty := l.semaRes.RangeTypes[forStmt.Iterable]; ty != types.NoTypeID {
-						rangeType = ty
-					}
-				}
-				data.Iterable = l.magicCallExpr(data.Iterable.Span, rangeType, symID, []*Expr{data.Iterable})
-			}
-		}
+		data.Iterable = l.lowerIterableExpr(forStmt.Iterable)
 	}
 	if forStmt.Body.IsValid() {
 		data.Body = l.lowerBlockOrWrap(forStmt.Body)
@@ -368,6 +377,26 @@ func (l *lowerer) lowerForInStmt(stmtID ast.StmtID, stmt *ast.Stmt) *Stmt {
 	}
 }
 
+// lowerIterableExpr lowers the iterable of a for-in loop, calling its
+// __range method when sema resolved one.
+func (l *lowerer) lowerIterableExpr(exprID ast.ExprID) *Expr {
+	iterable := l.lowerExpr(exprID)
+	if iterable == nil || l.semaRes == nil || l.semaRes.RangeSymbols == nil {
+		return iterable
+	}
+	symID, ok := l.semaRes.RangeSymbols[exprID]
+	if !ok || !symID.IsValid() {
+		return iterable
+	}
+	rangeType := iterable.Type
+	if l.semaRes.RangeTypes != nil {
+		if ty := l.semaRes.RangeTypes[exprID]; ty != types.NoTypeID {
+			rangeType = ty
+		}
+	}
+	return l.magicCallExpr(iterable.Span, rangeType, symID, []*Expr{iterable})
+}
+
 // lowerBlockOrWrap ensures a statement is wrapped in a block if needed.
 func (l *lowerer) lowerBlockOrWrap(stmtID ast.StmtID) *Block {
 	stmt := l.builder.Stmts.Get(stmtID)
@@ -211,6 +211,51 @@ fn main() -> int {
 	}
 }
 
+func TestLowerReceiverReborrow(t *testing.T) {
+	src := `
+type Grid = { cells: int[] };
+
+extern<Grid> {
+    fn first(self: &Grid) -> int { return self.cells[0]; }
+}
+
+fn through_ref(g: &mut Grid) -> int {
+    return g.first();
+}
+`
+	module, _, err := parseAndLower(t, src)
+	if err != nil {
+		t.Fatalf("failed to lower: %v", err)
+	}
+	var fn *hir.Func
+	for _, f := range module.Funcs {
+		if f.Name == "through_ref" {
+			fn = f
+		}
+	}
+	if fn == nil || fn.Body == nil || len(fn.Body.Stmts) == 0 {
+		t.Fatalf("expected through_ref with a body, got %+v", fn)
+	}
+	ret, ok := fn.Body.Stmts[len(fn.Body.Stmts)-1].Data.(hir.ReturnData)
+	if !ok || ret.Value == nil || ret.Value.Kind != hir.ExprCall {
+		t.Fatalf("expected return of a call, got %+v", fn.Body.Stmts)
+	}
+	call := ret.Value.Data.(hir.CallData)
+	if len(call.Args) == 0 {
+		t.Fatalf("expected a receiver argument")
+	}
+	// &mut Grid передаётся в self: &Grid как явный реборроу &*g.
+	recv := call.Args[0]
+	ref, ok := recv.Data.(hir.UnaryOpData)
+	if recv.Kind != hir.ExprUnaryOp || !ok || ref.Op != ast.ExprUnaryRef {
+		t.Fatalf("expected &*g receiver, got %s", recv.Kind)
+	}
+	deref, ok := ref.Operand.Data.(hir.UnaryOpData)
+	if ref.Operand.Kind != hir.ExprUnaryOp || !ok || deref.Op != ast.ExprUnaryDeref {
+		t.Fatalf("expected &*g receiver, got &%s", ref.Operand.Kind)
+	}
+}
+
 func parseAndLower(t *testing.T, src string) (*hir.Module, *types.Interner, error) {
 	t.Helper()
 
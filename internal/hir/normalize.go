@@ -14,6 +14,7 @@ import (
 //
 // Current goals (v1):
 //   - remove ExprCompare
+//   - desugar ExprParallel into a for-in loop (kept as ParallelData.Seq)
 //   - remove StmtFor (classic + for-in)
 //
 // This is a best-effort pass meant for analysis/debug and later MIR lowering; it must not
@@ -267,6 +268,11 @@ func normalizeExpr(ctx *normCtx, e *Expr) error {
 
 	case ExprStructLit:
 		data := e.Data.(StructLitData)
+		if data.Base != nil {
+			if err := normalizeExpr(ctx, data.Base); err != nil {
+				return err
+			}
+		}
 		for i := range data.Fields {
 			if data.Fields[i].Value != nil {
 				if err := normalizeExpr(ctx, data.Fields[i].Value); err != nil {
@@ -317,6 +323,9 @@ func normalizeExpr(ctx *normCtx, e *Expr) error {
 	case ExprCompare:
 		return normalizeCompareExpr(ctx, e)
 
+	case ExprParallel:
+		return normalizeParallelExpr(ctx, e)
+
 	case ExprSelect, ExprRace:
 		data := e.Data.(SelectData)
 		for i := range data.Arms {
@@ -470,6 +479,13 @@ func (ctx *normCtx) boolType() types.TypeID {
 	return types.NoTypeID
 }
 
+func (ctx *normCtx) intType() types.TypeID {
+	if ctx != nil && ctx.mod != nil && ctx.mod.TypeInterner != nil {
+		return ctx.mod.TypeInterner.Builtins().Int
+	}
+	return types.NoTypeID
+}
+
 func (ctx *normCtx) inferOwnership(ty types.TypeID) Ownership {
 	if ctx == nil || ctx.mod == nil || ctx.mod.TypeInterner == nil || ty == types.NoTypeID {
 		return OwnershipNone
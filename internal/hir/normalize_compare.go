@@ -111,13 +111,25 @@ func lowerCompareArm(ctx *normCtx, subject *Expr, subjectTy types.TypeID, arm Co
 	}
 
 	// `finally` is a wildcard default arm.
-	if arm.IsFinally || isWildcardPattern(arm.Pattern) {
+	if arm.IsFinally || isWildcardPattern(arm.Pattern) || orPatternHasWildcard(arm) {
 		if arm.Guard == nil {
 			return []Stmt{mkReturn(span, arm.Result)}
 		}
 		return []Stmt{mkIf(span, arm.Guard, &Block{Stmts: []Stmt{mkReturn(span, arm.Result)}, Span: span})}
 	}
 
+	if arm.PatternKind == ast.ComparePatternOr {
+		if cond := orPatternCond(ctx, span, subject, subjectTy, arm.Alternatives); cond != nil {
+			return []Stmt{mkMatchIf(span, cond, nil, arm.Guard, arm.Result)}
+		}
+	}
+
+	if arm.PatternKind == ast.ComparePatternRange {
+		if cond := rangePatternCond(ctx, span, subject, subjectTy, arm.Pattern); cond != nil {
+			return []Stmt{mkMatchIf(span, cond, nil, arm.Guard, arm.Result)}
+		}
+	}
+
 	if isNothingPattern(arm.Pattern) {
 		return []Stmt{mkMatchIf(span, &Expr{Kind: ExprTagTest, Type: ctx.boolType(), Span: span, Data: TagTestData{Value: subject, TagName: "nothing"}}, nil, arm.Guard, arm.Result)}
 	}
@@ -162,6 +174,83 @@ func lowerCompareArm(ctx *normCtx, subject *Expr, subjectTy types.TypeID, arm Co
 	return []Stmt{mkMatchIf(span, cond, nil, arm.Guard, arm.Result)}
 }
 
+// orPatternCond lowers the alternatives of `p1 | p2 | ...` to `c1 || c2 || ...`.
+func orPatternCond(ctx *normCtx, span source.Span, subject *Expr, subjectTy types.TypeID, alts []*Expr) *Expr {
+	var cond *Expr
+	for _, alt := range alts {
+		altCond := orAlternativeCond(ctx, span, subject, subjectTy, alt)
+		if altCond == nil {
+			continue
+		}
+		if cond == nil {
+			cond = altCond
+			continue
+		}
+		cond = ctx.binary(ast.ExprBinaryLogicalOr, cond, altCond, ctx.boolType(), span)
+	}
+	return cond
+}
+
+// orPatternHasWildcard reports an or-pattern with a `_` alternative: such an arm matches anything.
+func orPatternHasWildcard(arm CompareArm) bool {
+	if arm.PatternKind != ast.ComparePatternOr {
+		return false
+	}
+	for _, alt := range arm.Alternatives {
+		if isWildcardPattern(alt) {
+			return true
+		}
+	}
+	return false
+}
+
+// orAlternativeCond строит условие для одной альтернативы. Резолвер гарантирует,
+// что альтернатива ничего не связывает, поэтому у тегов полезная нагрузка — только `_`.
+func orAlternativeCond(ctx *normCtx, span source.Span, subject *Expr, subjectTy types.TypeID, alt *Expr) *Expr {
+	if alt == nil {
+		return nil
+	}
+	if cond := rangePatternCond(ctx, span, subject, subjectTy, alt); cond != nil {
+		return cond
+	}
+	if isNothingPattern(alt) {
+		return &Expr{Kind: ExprTagTest, Type: ctx.boolType(), Span: span, Data: TagTestData{Value: subject, TagName: "nothing"}}
+	}
+	if tagName, _, ok := tagPattern(ctx, alt); ok {
+		return &Expr{Kind: ExprTagTest, Type: ctx.boolType(), Span: span, Data: TagTestData{Value: subject, TagName: tagName}}
+	}
+	return ctx.binary(ast.ExprBinaryEq, subject, alt, ctx.boolType(), span)
+}
+
+// rangePatternCond lowers `lo..hi` to `lo <= subject && subject < hi`
+// (`<=` for the inclusive form `lo..=hi`).
+func rangePatternCond(ctx *normCtx, span source.Span, subject *Expr, subjectTy types.TypeID, p *Expr) *Expr {
+	if p == nil || p.Kind != ExprBinaryOp {
+		return nil
+	}
+	data := p.Data.(BinaryOpData)
+	upperOp := ast.ExprBinaryLess
+	switch data.Op {
+	case ast.ExprBinaryRange:
+	case ast.ExprBinaryRangeInclusive:
+		upperOp = ast.ExprBinaryLessEq
+	default:
+		return nil
+	}
+	value := subject
+	if derefType := derefReferenceType(ctx, subjectTy); derefType != types.NoTypeID {
+		value = &Expr{
+			Kind: ExprUnaryOp,
+			Type: derefType,
+			Span: span,
+			Data: UnaryOpData{Op: ast.ExprUnaryDeref, Operand: subject},
+		}
+	}
+	lower := ctx.binary(ast.ExprBinaryLessEq, data.Left, value, ctx.boolType(), span)
+	upper := ctx.binary(upperOp, value, data.Right, ctx.boolType(), span)
+	return ctx.binary(ast.ExprBinaryLogicalAnd, lower, upper, ctx.boolType(), span)
+}
+
 func isWildcardPattern(p *Expr) bool {
 	if p == nil || p.Kind != ExprVarRef {
 		return false
@@ -254,7 +343,7 @@ func compareExhaustive(ctx *normCtx, subjectTy types.TypeID, arms []CompareArm)
 		if arm.Guard != nil {
 			continue
 		}
-		if arm.IsFinally || isWildcardPattern(arm.Pattern) {
+		if arm.IsFinally || isWildcardPattern(arm.Pattern) || orPatternHasWildcard(arm) {
 			return true
 		}
 		if _, _, ok := bindingPattern(ctx, arm.Pattern); ok {
@@ -292,18 +381,24 @@ func compareExhaustive(ctx *normCtx, subjectTy types.TypeID, arms []CompareArm)
 		if arm.Guard != nil {
 			continue
 		}
-		if isNothingPattern(arm.Pattern) {
-			coveredNothing = true
-			continue
-		}
-		tagName, payloadPats, ok := tagPattern(ctx, arm.Pattern)
-		if !ok {
-			continue
+		patterns := []*Expr{arm.Pattern}
+		if arm.PatternKind == ast.ComparePatternOr {
+			patterns = arm.Alternatives
 		}
-		if !payloadPatternsCoverAll(ctx, payloadPats) {
-			continue
+		for _, pattern := range patterns {
+			if isNothingPattern(pattern) {
+				coveredNothing = true
+				continue
+			}
+			tagName, payloadPats, ok := tagPattern(ctx, pattern)
+			if !ok {
+				continue
+			}
+			if !payloadPatternsCoverAll(ctx, payloadPats) {
+				continue
+			}
+			coveredTags[ctx.mod.Symbols.Table.Strings.Intern(tagName)] = struct{}{}
 		}
-		coveredTags[ctx.mod.Symbols.Table.Strings.Intern(tagName)] = struct{}{}
 	}
 
 	if needNothing && !coveredNothing {
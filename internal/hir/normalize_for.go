@@ -70,7 +70,7 @@ func normalizeForClassic(ctx *normCtx, span source.Span, data ForData) ([]Stmt,
 	}
 
 	if postStmt != nil && data.Body != nil {
-		rewriteContinues(data.Body, []Stmt{*postStmt}, 0)
+		rewriteContinues(data.Body, []Stmt{*postStmt}, data.Label, 0)
 		data.Body.Stmts = append(data.Body.Stmts, *postStmt)
 	}
 
@@ -78,8 +78,9 @@ func normalizeForClassic(ctx *normCtx, span source.Span, data ForData) ([]Stmt,
 		Kind: StmtWhile,
 		Span: span,
 		Data: WhileData{
-			Cond: cond,
-			Body: data.Body,
+			Cond:  cond,
+			Body:  data.Body,
+			Label: data.Label,
 		},
 	}
 
@@ -103,20 +104,97 @@ func normalizeForIn(ctx *normCtx, span source.Span, data ForData) ([]Stmt, error
 		return nil, err
 	}
 
+	var counterLet *Stmt
+	if data.IndexName != "" && data.IndexName != "_" && data.IndexSym.IsValid() {
+		counterLet = bindForIndex(ctx, span, &data)
+	}
+
+	var loop []Stmt
+	var err error
 	if isNumericRangeFor(ctx, data.Iterable, data.VarType) {
-		return normalizeNumericRangeFor(ctx, span, data)
+		loop, err = normalizeNumericRangeFor(ctx, span, data)
+	} else {
+		loop, err = normalizeIterFor(ctx, span, data)
+	}
+	if err != nil || counterLet == nil {
+		return loop, err
+	}
+	outer := &Block{Span: span}
+	outer.Stmts = append(outer.Stmts, *counterLet)
+	outer.Stmts = append(outer.Stmts, loop...)
+	return []Stmt{{Kind: StmtBlock, Span: span, Data: BlockStmtData{Block: outer}}}, nil
+}
+
+// bindForIndex rewrites `for (i, x) in xs { body }` so that the body starts with
+// `let i: int = __idx; __idx = __idx + 1;` and returns the `let mut __idx: int = 0`
+// that must precede the loop. Счётчик увеличивается в начале тела, поэтому
+// continue не требует переписывания.
+func bindForIndex(ctx *normCtx, span source.Span, data *ForData) *Stmt {
+	intTy := ctx.intType()
+	counterSym, counterName := ctx.newTemp("idx")
+	counterLet := &Stmt{
+		Kind: StmtLet,
+		Span: span,
+		Data: LetData{
+			Name:      counterName,
+			SymbolID:  counterSym,
+			Type:      intTy,
+			Value:     ctx.intLit(0, intTy, span),
+			IsMut:     true,
+			IsConst:   false,
+			Ownership: ctx.inferOwnership(intTy),
+		},
+	}
+	indexLet := Stmt{
+		Kind: StmtLet,
+		Span: span,
+		Data: LetData{
+			Name:      data.IndexName,
+			SymbolID:  data.IndexSym,
+			Type:      intTy,
+			Value:     ctx.varRef(counterName, counterSym, intTy, span),
+			IsMut:     false,
+			IsConst:   false,
+			Ownership: ctx.inferOwnership(intTy),
+		},
 	}
-	return normalizeIterFor(ctx, span, data)
+	incr := Stmt{
+		Kind: StmtAssign,
+		Span: span,
+		Data: AssignData{
+			Target: ctx.varRef(counterName, counterSym, intTy, span),
+			Value: ctx.binary(
+				ast.ExprBinaryAdd,
+				ctx.varRef(counterName, counterSym, intTy, span),
+				ctx.intLit(1, intTy, span),
+				intTy,
+				span,
+			),
+		},
+	}
+	if data.Body == nil {
+		data.Body = &Block{Span: span}
+	}
+	prefix := []Stmt{indexLet, incr}
+	data.Body.Stmts = append(prefix, data.Body.Stmts...)
+	return counterLet
 }
 
 func isNumericRangeFor(ctx *normCtx, iterable *Expr, elemTy types.TypeID) bool {
-	if ctx == nil || iterable == nil || iterable.Kind != ExprBinaryOp || elemTy == types.NoTypeID {
+	if ctx == nil || iterable == nil || iterable.Kind != ExprBinaryOp {
 		return false
 	}
 	bin := iterable.Data.(BinaryOpData)
 	if bin.Op != ast.ExprBinaryRange && bin.Op != ast.ExprBinaryRangeInclusive {
 		return false
 	}
+	if elemTy == types.NoTypeID && bin.Left != nil {
+		// `for _ in a..b` не создаёт привязку, тип элемента берётся из границы.
+		elemTy = bin.Left.Type
+	}
+	if elemTy == types.NoTypeID {
+		return false
+	}
 	if ctx.mod == nil || ctx.mod.TypeInterner == nil {
 		return false
 	}
@@ -219,15 +297,16 @@ func normalizeNumericRangeFor(ctx *normCtx, span source.Span, data ForData) ([]S
 	if data.Body == nil {
 		data.Body = &Block{Span: span}
 	}
-	rewriteContinues(data.Body, []Stmt{incr}, 0)
+	rewriteContinues(data.Body, []Stmt{incr}, data.Label, 0)
 	data.Body.Stmts = append(data.Body.Stmts, incr)
 
 	whileStmt := Stmt{
 		Kind: StmtWhile,
 		Span: span,
 		Data: WhileData{
-			Cond: cond,
-			Body: data.Body,
+			Cond:  cond,
+			Body:  data.Body,
+			Label: data.Label,
 		},
 	}
 
@@ -322,8 +401,9 @@ func normalizeIterFor(ctx *normCtx, span source.Span, data ForData) ([]Stmt, err
 		Kind: StmtWhile,
 		Span: span,
 		Data: WhileData{
-			Cond: ctx.boolLit(true, span),
-			Body: data.Body,
+			Cond:  ctx.boolLit(true, span),
+			Body:  data.Body,
+			Label: data.Label,
 		},
 	}
 
@@ -484,7 +564,10 @@ func substTypeParamIndex(typesIn *types.Interner, id types.TypeID, args []types.
 	}
 }
 
-func rewriteContinues(b *Block, inject []Stmt, depth int) {
+// rewriteContinues prepends inject to every continue that targets the loop
+// being desugared: unlabeled ones at depth 0 and, when the loop is labeled,
+// `continue label` from any nested loop.
+func rewriteContinues(b *Block, inject []Stmt, label string, depth int) {
 	if b == nil {
 		return
 	}
@@ -493,7 +576,9 @@ func rewriteContinues(b *Block, inject []Stmt, depth int) {
 		s := b.Stmts[i]
 		switch s.Kind {
 		case StmtContinue:
-			if depth == 0 && len(inject) > 0 {
+			target, _ := s.Data.(ContinueData)
+			own := (target.Label == "" && depth == 0) || (label != "" && target.Label == label)
+			if own && len(inject) > 0 {
 				blk := &Block{Span: s.Span}
 				blk.Stmts = append(blk.Stmts, inject...)
 				blk.Stmts = append(blk.Stmts, s)
@@ -503,23 +588,23 @@ func rewriteContinues(b *Block, inject []Stmt, depth int) {
 			}
 		case StmtIf:
 			data := s.Data.(IfStmtData)
-			rewriteContinues(data.Then, inject, depth)
-			rewriteContinues(data.Else, inject, depth)
+			rewriteContinues(data.Then, inject, label, depth)
+			rewriteContinues(data.Else, inject, label, depth)
 			s.Data = data
 			out = append(out, s)
 		case StmtWhile:
 			data := s.Data.(WhileData)
-			rewriteContinues(data.Body, inject, depth+1)
+			rewriteContinues(data.Body, inject, label, depth+1)
 			s.Data = data
 			out = append(out, s)
 		case StmtFor:
 			data := s.Data.(ForData)
-			rewriteContinues(data.Body, inject, depth+1)
+			rewriteContinues(data.Body, inject, label, depth+1)
 			s.Data = data
 			out = append(out, s)
 		case StmtBlock:
 			data := s.Data.(BlockStmtData)
-			rewriteContinues(data.Block, inject, depth)
+			rewriteContinues(data.Block, inject, label, depth)
 			s.Data = data
 			out = append(out, s)
 		default:
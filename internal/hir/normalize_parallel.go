@@ -0,0 +1,164 @@
+//nolint:errcheck // HIR nodes are checked by construction; Kind implies the Data payload type.
+package hir
+
+import (
+	"fmt"
+
+	"surge/internal/ast"
+	"surge/internal/types"
+)
+
+// normalizeParallelExpr desugars parallel map/reduce into a sequential block:
+//
+//	parallel map xs with (x) => body
+//	  => { let mut out: U[] = []; for x in xs { rt_array_push(&mut out, body); } return out; }
+//	     (rt_array_push is the core intrinsic symbol resolved by the lowerer)
+//
+//	parallel reduce xs with init, (acc, x) => body
+//	  => { let mut acc = init; for x in xs { acc = body; } return acc; }
+//
+// Elements are visited in iteration order, so map results keep the order of xs.
+// When the lowerer kept a second copy of the operands, the block is stored in
+// ParallelData.Seq and the expression stays ExprParallel so a backend with a
+// parallel runtime can still fork the body; otherwise e becomes the block.
+func normalizeParallelExpr(ctx *normCtx, e *Expr) error {
+	if ctx == nil || e == nil {
+		return nil
+	}
+	data, ok := e.Data.(ParallelData)
+	if !ok {
+		return fmt.Errorf("hir: normalize parallel: unexpected payload %T", e.Data)
+	}
+	if data.seq == nil {
+		block, err := buildParallelSeq(ctx, e, data)
+		if err != nil {
+			return err
+		}
+		e.Kind = ExprBlock
+		e.Data = BlockExprData{Block: block}
+		return nil
+	}
+
+	block, err := buildParallelSeq(ctx, e, *data.seq)
+	if err != nil {
+		return err
+	}
+	for _, part := range []*Expr{data.Iterable, data.Init, data.Body} {
+		if part == nil {
+			continue
+		}
+		if err := normalizeExpr(ctx, part); err != nil {
+			return err
+		}
+	}
+	data.Seq = block
+	data.seq = nil
+	e.Data = data
+	return nil
+}
+
+func buildParallelSeq(ctx *normCtx, e *Expr, data ParallelData) (*Block, error) {
+
+	block := &Block{Span: e.Span}
+	loop := ForData{Kind: ForIn, Iterable: data.Iterable, Body: &Block{Span: e.Span}}
+
+	switch data.Kind {
+	case ast.ExprParallelMap:
+		if len(data.Args) != 1 {
+			return nil, fmt.Errorf("hir: normalize parallel map: expected 1 argument, got %d", len(data.Args))
+		}
+		elem := data.Args[0]
+		loop.VarName, loop.VarSym, loop.VarType = elem.Name, elem.SymbolID, elem.Type
+
+		outSym, outName := ctx.newTemp("par_out")
+		block.Stmts = append(block.Stmts, Stmt{
+			Kind: StmtLet,
+			Span: e.Span,
+			Data: LetData{
+				Name:      outName,
+				SymbolID:  outSym,
+				Type:      e.Type,
+				Value:     &Expr{Kind: ExprArrayLit, Type: e.Type, Span: e.Span, Data: ArrayLitData{}},
+				IsMut:     true,
+				Ownership: ctx.inferOwnership(e.Type),
+			},
+		})
+		outRef := &Expr{
+			Kind: ExprUnaryOp,
+			Type: ctx.refType(e.Type, true),
+			Span: e.Span,
+			Data: UnaryOpData{Op: ast.ExprUnaryRefMut, Operand: ctx.varRef(outName, outSym, e.Type, e.Span)},
+		}
+		if data.push == nil {
+			return nil, fmt.Errorf("hir: normalize parallel map: rt_array_push callee is not resolved")
+		}
+		callee, _ := data.push.Data.(VarRefData)
+		push := &Expr{
+			Kind: ExprCall,
+			Type: ctx.nothingType(),
+			Span: e.Span,
+			Data: CallData{
+				Callee:   data.push,
+				Args:     []*Expr{outRef, data.Body},
+				SymbolID: callee.SymbolID,
+			},
+		}
+		loop.Body.Stmts = append(loop.Body.Stmts, Stmt{Kind: StmtExpr, Span: e.Span, Data: ExprStmtData{Expr: push}})
+		block.Stmts = append(block.Stmts,
+			Stmt{Kind: StmtFor, Span: e.Span, Data: loop},
+			mkReturn(e.Span, ctx.varRef(outName, outSym, e.Type, e.Span)),
+		)
+
+	case ast.ExprParallelReduce:
+		if len(data.Args) != 2 {
+			return nil, fmt.Errorf("hir: normalize parallel reduce: expected 2 arguments, got %d", len(data.Args))
+		}
+		acc, elem := data.Args[0], data.Args[1]
+		loop.VarName, loop.VarSym, loop.VarType = elem.Name, elem.SymbolID, elem.Type
+
+		// The accumulator binding itself carries the running value.
+		block.Stmts = append(block.Stmts, Stmt{
+			Kind: StmtLet,
+			Span: e.Span,
+			Data: LetData{
+				Name:      acc.Name,
+				SymbolID:  acc.SymbolID,
+				Type:      e.Type,
+				Value:     data.Init,
+				IsMut:     true,
+				Ownership: ctx.inferOwnership(e.Type),
+			},
+		})
+		loop.Body.Stmts = append(loop.Body.Stmts, Stmt{
+			Kind: StmtAssign,
+			Span: e.Span,
+			Data: AssignData{Target: ctx.varRef(acc.Name, acc.SymbolID, e.Type, e.Span), Value: data.Body},
+		})
+		block.Stmts = append(block.Stmts,
+			Stmt{Kind: StmtFor, Span: e.Span, Data: loop},
+			mkReturn(e.Span, ctx.varRef(acc.Name, acc.SymbolID, e.Type, e.Span)),
+		)
+
+	default:
+		return nil, fmt.Errorf("hir: normalize parallel: unknown kind %d", data.Kind)
+	}
+
+	if err := normalizeBlock(ctx, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (ctx *normCtx) nothingType() types.TypeID {
+	if ctx != nil && ctx.mod != nil && ctx.mod.TypeInterner != nil {
+		return ctx.mod.TypeInterner.Builtins().Nothing
+	}
+	return types.NoTypeID
+}
+
+func (ctx *normCtx) refType(elem types.TypeID, mutable bool) types.TypeID {
+	if ctx == nil || ctx.mod == nil || ctx.mod.TypeInterner == nil || elem == types.NoTypeID {
+		return types.NoTypeID
+	}
+	return ctx.mod.TypeInterner.Intern(types.MakeReference(elem, mutable))
+}
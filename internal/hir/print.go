@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 
+	"surge/internal/ast"
 	"surge/internal/types"
 )
 
@@ -249,10 +250,18 @@ func (p *Printer) printStmt(s *Stmt) {
 		p.printf("\n")
 
 	case StmtBreak:
-		p.printf("break\n")
+		if data, ok := s.Data.(BreakData); ok && data.Label != "" {
+			p.printf("break %s\n", data.Label)
+		} else {
+			p.printf("break\n")
+		}
 
 	case StmtContinue:
-		p.printf("continue\n")
+		if data, ok := s.Data.(ContinueData); ok && data.Label != "" {
+			p.printf("continue %s\n", data.Label)
+		} else {
+			p.printf("continue\n")
+		}
 
 	case StmtIf:
 		data := s.Data.(IfStmtData)
@@ -276,6 +285,9 @@ func (p *Printer) printStmt(s *Stmt) {
 
 	case StmtWhile:
 		data := s.Data.(WhileData)
+		if data.Label != "" {
+			p.printf("%s: ", data.Label)
+		}
 		p.printf("while ")
 		p.printExpr(data.Cond)
 		p.printf(" {\n")
@@ -287,6 +299,9 @@ func (p *Printer) printStmt(s *Stmt) {
 
 	case StmtFor:
 		data := s.Data.(ForData)
+		if data.Label != "" {
+			p.printf("%s: ", data.Label)
+		}
 		if data.Kind == ForClassic {
 			p.printf("for ")
 			if data.Init != nil {
@@ -301,7 +316,11 @@ func (p *Printer) printStmt(s *Stmt) {
 				p.printExpr(data.Post)
 			}
 		} else {
-			p.printf("for %s: %s in ", data.VarName, p.typeStr(data.VarType))
+			if data.IndexName != "" {
+				p.printf("for (%s, %s: %s) in ", data.IndexName, data.VarName, p.typeStr(data.VarType))
+			} else {
+				p.printf("for %s: %s in ", data.VarName, p.typeStr(data.VarType))
+			}
 			p.printExpr(data.Iterable)
 		}
 		p.printf(" {\n")
@@ -447,6 +466,13 @@ func (p *Printer) printExprWithType(e *Expr, showType bool) {
 	case ExprStructLit:
 		data := e.Data.(StructLitData)
 		p.printf("%s { ", data.TypeName)
+		if data.Base != nil {
+			p.printf("..")
+			p.printExpr(data.Base)
+			if len(data.Fields) > 0 {
+				p.printf(", ")
+			}
+		}
 		for i, f := range data.Fields {
 			if i > 0 {
 				p.printf(", ")
@@ -512,6 +538,29 @@ func (p *Printer) printExprWithType(e *Expr, showType bool) {
 		p.printIndent()
 		p.printf("}")
 
+	case ExprParallel:
+		data := e.Data.(ParallelData)
+		if data.Kind == ast.ExprParallelReduce {
+			p.printf("parallel reduce ")
+		} else {
+			p.printf("parallel map ")
+		}
+		p.printExpr(data.Iterable)
+		p.printf(" with ")
+		if data.Init != nil {
+			p.printExpr(data.Init)
+			p.printf(", ")
+		}
+		p.printf("(")
+		for i, arg := range data.Args {
+			if i > 0 {
+				p.printf(", ")
+			}
+			p.printf("%s", arg.Name)
+		}
+		p.printf(") => ")
+		p.printExpr(data.Body)
+
 	case ExprSelect, ExprRace:
 		data := e.Data.(SelectData)
 		kindLabel := "select"
@@ -669,6 +718,9 @@ func (p *Printer) formatType(t types.Type, id types.TypeID) string {
 	case types.KindInt:
 		return p.formatIntType(t.Width, true)
 	case types.KindUint:
+		if t.IsChar() {
+			return "char"
+		}
 		return p.formatIntType(t.Width, false)
 	case types.KindFloat:
 		return p.formatFloatType(t.Width)
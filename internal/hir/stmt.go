@@ -128,14 +128,14 @@ func (RetData) stmtData() {}
 
 // BreakData holds data for StmtBreak.
 type BreakData struct {
-	// Label could be added here for labeled breaks
+	Label string // Target loop label; empty for the innermost loop
 }
 
 func (BreakData) stmtData() {}
 
 // ContinueData holds data for StmtContinue.
 type ContinueData struct {
-	// Label could be added here for labeled continues
+	Label string // Target loop label; empty for the innermost loop
 }
 
 func (ContinueData) stmtData() {}
@@ -151,8 +151,9 @@ func (IfStmtData) stmtData() {}
 
 // WhileData holds data for StmtWhile.
 type WhileData struct {
-	Cond *Expr
-	Body *Block
+	Cond  *Expr
+	Body  *Block
+	Label string // Loop label for labeled break/continue; empty if none
 }
 
 func (WhileData) stmtData() {}
@@ -182,8 +183,13 @@ type ForData struct {
 	VarType  types.TypeID     // Loop variable type
 	Iterable *Expr            // Expression to iterate over
 
+	// For enumerated for-in `for (i, x) in ...`:
+	IndexName string           // Index variable name; empty if none
+	IndexSym  symbols.SymbolID // Index variable symbol
+
 	// Common:
-	Body *Block
+	Body  *Block
+	Label string // Loop label for labeled break/continue; empty if none
 }
 
 func (ForData) stmtData() {}
@@ -0,0 +1,45 @@
+package layout_test
+
+import (
+	"testing"
+
+	"surge/internal/layout"
+)
+
+func TestLayoutEngine_AlignOverrideOnStructAndUnion(t *testing.T) {
+	sourceCode := `@align(64)
+type Line = { a: uint8, b: uint32 }
+
+tag Full(int);
+
+@align(32)
+type Slot = Full(int) | nothing;
+
+@entrypoint
+fn main() -> int { return 0; }
+`
+	res := diagnoseSemaFromSource(t, sourceCode, false)
+	le := layout.New(layout.X86_64LinuxGNU(), res.Sema.TypeInterner)
+
+	line, err := le.LayoutOf(resolveTypeSymbol(t, res, "Line"))
+	if err != nil {
+		t.Fatalf("Line: layout error: %v", err)
+	}
+	if line.Align != 64 || line.Size != 64 {
+		t.Fatalf("Line: expected size=64 align=64, got size=%d align=%d", line.Size, line.Align)
+	}
+	if len(line.FieldOffsets) != 2 || line.FieldOffsets[1] != 4 {
+		t.Fatalf("Line: @align must not move fields, got offsets %v", line.FieldOffsets)
+	}
+
+	slot, err := le.LayoutOf(resolveTypeSymbol(t, res, "Slot"))
+	if err != nil {
+		t.Fatalf("Slot: layout error: %v", err)
+	}
+	if slot.Align != 32 || slot.Size != 32 {
+		t.Fatalf("Slot: expected size=32 align=32, got size=%d align=%d", slot.Size, slot.Align)
+	}
+	if slot.PayloadOffset != 8 {
+		t.Fatalf("Slot: expected payload offset 8, got %d", slot.PayloadOffset)
+	}
+}
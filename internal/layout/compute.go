@@ -362,6 +362,9 @@ func (e *LayoutEngine) tagUnionLayout(id types.TypeID, state *layoutState) (Type
 	}
 	payloadOffset := roundUp(tagSize, payloadAlign)
 	overallAlign := maxInt(tagAlign, payloadAlign)
+	if attrs, ok := e.Types.TypeLayoutAttrs(id); ok && attrs.AlignOverride != nil {
+		overallAlign = maxInt(overallAlign, *attrs.AlignOverride)
+	}
 	size := roundUp(payloadOffset+maxPayloadSize, overallAlign)
 	return TypeLayout{
 		Size:          size,
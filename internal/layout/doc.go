@@ -22,14 +22,15 @@
 // Атрибуты layout'а:
 //
 //   - @packed: упакованная структура без выравнивания (align=1, поля идут последовательно)
-//   - @align(N): явное указание выравнивания для типа или поля
+//   - @align(N): явное указание выравнивания для типа (структуры или tagged union) или поля
 //
 // Особенности:
 //
 //   - Кэширование: результаты вычисления layout'ов кэшируются для повышения производительности
 //   - Обнаружение рекурсии: выявляет рекурсивные типы с бесконечным размером (LayoutErrRecursiveUnsized)
 //   - Канонизация типов: разрешает алиасы и own-типы перед вычислением layout'а
-//   - Поддержка платформ: в текущей версии поддерживается только x86_64-linux-gnu
+//   - Поддержка платформ: 64-битные x86_64 и aarch64 с моделью данных x86_64-linux-gnu;
+//     TargetForTriple выводит Target из LLVM triple и отклоняет остальные архитектуры
 //
 // Использование:
 //
@@ -0,0 +1,47 @@
+package layout_test
+
+import (
+	"testing"
+
+	"surge/internal/layout"
+)
+
+func TestLayoutEngine_PackedStructSkipsPadding(t *testing.T) {
+	sourceCode := `type Plain = { a: uint8, b: uint32 }
+
+@packed
+type Packed = { a: uint8, b: uint32 }
+
+@entrypoint
+fn main() -> int { return 0; }
+`
+	res := diagnoseSemaFromSource(t, sourceCode, false)
+	le := layout.New(layout.X86_64LinuxGNU(), res.Sema.TypeInterner)
+
+	cases := []struct {
+		name    string
+		size    int
+		align   int
+		offsets []int
+	}{
+		{name: "Plain", size: 8, align: 4, offsets: []int{0, 4}},
+		{name: "Packed", size: 5, align: 1, offsets: []int{0, 1}},
+	}
+	for _, tc := range cases {
+		got, err := le.LayoutOf(resolveTypeSymbol(t, res, tc.name))
+		if err != nil {
+			t.Fatalf("%s: layout error: %v", tc.name, err)
+		}
+		if got.Size != tc.size || got.Align != tc.align {
+			t.Fatalf("%s: expected size=%d align=%d, got size=%d align=%d", tc.name, tc.size, tc.align, got.Size, got.Align)
+		}
+		if len(got.FieldOffsets) != len(tc.offsets) {
+			t.Fatalf("%s: expected %d field offsets, got %v", tc.name, len(tc.offsets), got.FieldOffsets)
+		}
+		for i, off := range tc.offsets {
+			if got.FieldOffsets[i] != off {
+				t.Fatalf("%s: field %d expected offset %d, got %d", tc.name, i, off, got.FieldOffsets[i])
+			}
+		}
+	}
+}
@@ -190,3 +190,70 @@ func bagHasCode(bag *diag.Bag, code diag.Code) bool {
 	}
 	return false
 }
+
+func TestLayoutEngine_RecursiveDirectFieldReportsCycle(t *testing.T) {
+	sourceCode := `type Node = { value: int, next: Node }
+
+@entrypoint
+fn main() -> int { return 0; }
+`
+	res := diagnoseSemaFromSource(t, sourceCode, true)
+	msg := diagMessageWithCode(res.Bag, diag.SemaRecursiveUnsized)
+	if msg == "" {
+		t.Fatalf("expected %v diagnostic, got %+v", diag.SemaRecursiveUnsized, res.Bag.Items())
+	}
+	if !strings.Contains(msg, "Node -> Node") {
+		t.Fatalf("expected cycle Node -> Node in message, got %q", msg)
+	}
+}
+
+func TestLayoutEngine_MutuallyRecursiveStructsReportCycle(t *testing.T) {
+	sourceCode := `type A = { b: B }
+type B = { a: A }
+
+@entrypoint
+fn main() -> int { return 0; }
+`
+	res := diagnoseSemaFromSource(t, sourceCode, true)
+	msg := diagMessageWithCode(res.Bag, diag.SemaRecursiveUnsized)
+	if msg == "" {
+		t.Fatalf("expected %v diagnostic, got %+v", diag.SemaRecursiveUnsized, res.Bag.Items())
+	}
+	if !strings.Contains(msg, "A -> B -> A") && !strings.Contains(msg, "B -> A -> B") {
+		t.Fatalf("expected A/B cycle in message, got %q", msg)
+	}
+}
+
+func TestLayoutEngine_RecursiveIndirectionIsSized(t *testing.T) {
+	cases := map[string]string{
+		"mut_ref": `type Node = { next: &mut Node }`,
+		"array":   `type Node = { children: Node[] }`,
+	}
+	for name, decl := range cases {
+		t.Run(name, func(t *testing.T) {
+			sourceCode := decl + `
+
+@entrypoint
+fn main() -> int { return 0; }
+`
+			res := diagnoseSemaFromSource(t, sourceCode, false)
+			nodeType := resolveTypeSymbol(t, res, "Node")
+			le := layout.New(layout.X86_64LinuxGNU(), res.Sema.TypeInterner)
+			if _, err := le.LayoutOf(nodeType); err != nil {
+				t.Fatalf("unexpected layout error: %v", err)
+			}
+		})
+	}
+}
+
+func diagMessageWithCode(bag *diag.Bag, code diag.Code) string {
+	if bag == nil {
+		return ""
+	}
+	for _, d := range bag.Items() {
+		if d.Code == code {
+			return d.Message
+		}
+	}
+	return ""
+}
@@ -1,8 +1,13 @@
 package layout
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Target describes the ABI target triple and its pointer properties.
 //
-// Step B scope: only x86_64-linux-gnu is implemented.
+// Only 64-bit targets with the x86_64-linux-gnu data model are implemented.
 type Target struct {
 	Triple   string // e.g. "x86_64-linux-gnu"
 	PtrSize  int    // bytes
@@ -17,3 +22,21 @@ func X86_64LinuxGNU() Target {
 		PtrAlign: 8,
 	}
 }
+
+// TargetForTriple derives the target from an LLVM triple such as
+// "aarch64-unknown-linux-gnu"; an empty triple means X86_64LinuxGNU.
+// Architectures without a layout here are rejected instead of silently
+// getting the x86_64 one.
+func TargetForTriple(triple string) (Target, error) {
+	triple = strings.TrimSpace(triple)
+	if triple == "" {
+		return X86_64LinuxGNU(), nil
+	}
+	arch, _, _ := strings.Cut(strings.ToLower(triple), "-")
+	switch arch {
+	case "x86_64", "amd64", "aarch64", "arm64":
+		// Те же 8-байтовые указатели и естественное выравнивание скаляров, что у x86_64.
+		return Target{Triple: triple, PtrSize: 8, PtrAlign: 8}, nil
+	}
+	return Target{}, fmt.Errorf("unsupported target triple %q: no type layout for architecture %q (supported: x86_64, aarch64)", triple, arch)
+}
@@ -0,0 +1,30 @@
+package layout_test
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/layout"
+)
+
+func TestTargetForTriple(t *testing.T) {
+	target, err := layout.TargetForTriple("")
+	if err != nil || target != layout.X86_64LinuxGNU() {
+		t.Fatalf("empty triple: got %+v, %v", target, err)
+	}
+	for _, triple := range []string{"x86_64-linux-gnu", "x86_64-unknown-linux-gnu", "aarch64-unknown-linux-gnu", "arm64-apple-darwin"} {
+		target, err := layout.TargetForTriple(triple)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", triple, err)
+		}
+		if target.Triple != triple || target.PtrSize != 8 || target.PtrAlign != 8 {
+			t.Fatalf("%s: unexpected target %+v", triple, target)
+		}
+	}
+	for _, triple := range []string{"i686-linux-gnu", "armv7-unknown-linux-gnueabihf", "wasm32-unknown-unknown", "bogus"} {
+		_, err := layout.TargetForTriple(triple)
+		if err == nil || !strings.Contains(err.Error(), "unsupported target triple") {
+			t.Fatalf("%s: expected an unsupported target error, got %v", triple, err)
+		}
+	}
+}
@@ -18,7 +18,7 @@ type Lexer struct {
 	file    *source.File
 	cursor  Cursor
 	opts    Options
-	look    *token.Token   // 1 элементный буфер для токена
+	look    []token.Token  // стек возвращённых токенов (последний — следующий)
 	hold    []token.Trivia // накопленные leading trivia
 	last    token.Token
 	hasLast bool
@@ -30,7 +30,6 @@ func New(file *source.File, opts Options) *Lexer {
 		file:   file,
 		cursor: NewCursor(file),
 		opts:   opts,
-		look:   nil,
 		hold:   nil,
 	}
 }
@@ -44,19 +43,29 @@ func (lx *Lexer) SetRange(start, end uint32) {
 	if end != 0 {
 		lx.cursor.Limit = end
 	}
-	lx.look = nil
+	lx.look = lx.look[:0]
 	lx.hold = nil
 	lx.last = token.Token{}
 	lx.hasLast = false
 }
 
+// Stop moves the lexer to the end of its range: every following token is EOF.
+func (lx *Lexer) Stop() {
+	if lx == nil {
+		return
+	}
+	lx.cursor.Off = lx.cursor.limit()
+	lx.look = lx.look[:0]
+	lx.hold = nil
+}
+
 // Next возвращает следующий **значимый** токен с уже собранным Leading.
 // После EOF всегда возвращает EOF.
 func (lx *Lexer) Next() token.Token {
-	// 1) Если есть look — вернуть его и очистить
-	if lx.look != nil {
-		tok := *lx.look
-		lx.look = nil
+	// 1) Если есть look — вернуть верхний токен
+	if n := len(lx.look); n > 0 {
+		tok := lx.look[n-1]
+		lx.look = lx.look[:n-1]
 		lx.last = tok
 		lx.hasLast = true
 		return tok
@@ -86,6 +95,16 @@ func (lx *Lexer) Next() token.Token {
 		}
 		tok = lx.scanIdentOrKeyword()
 
+	case ch == 'r':
+		// r"..." / r#"..."# → scanRawString(), иначе обычный идентификатор
+		if b0, b1, ok := lx.cursor.Peek2(); ok && b0 == 'r' && (b1 == '"' || b1 == '#') {
+			if raw, isRaw := lx.scanRawString(); isRaw {
+				tok = raw
+				break
+			}
+		}
+		tok = lx.scanIdentOrKeyword()
+
 	case isIdentStartByte(ch):
 		// ASCII буква → scanIdentOrKeyword()
 		tok = lx.scanIdentOrKeyword()
@@ -106,6 +125,10 @@ func (lx *Lexer) Next() token.Token {
 		// " → scanString()
 		tok = lx.scanString()
 
+	case ch == '\'':
+		// ' → scanChar()
+		tok = lx.scanChar()
+
 	default:
 		// иначе → scanOperatorOrPunct() (включая @, скобки, запятые и т.д.)
 		tok = lx.scanOperatorOrPunct()
@@ -129,14 +152,19 @@ func (lx *Lexer) Next() token.Token {
 
 // Peek возвращает следующий токен, не потребляя его.
 func (lx *Lexer) Peek() token.Token {
+	if n := len(lx.look); n > 0 {
+		return lx.look[n-1]
+	}
 	t := lx.Next()
-	lx.look = &t
+	lx.look = append(lx.look, t)
 	return t
 }
 
 // Push injects a token back into the lookahead buffer.
+// Pushed tokens are returned in LIFO order, so a parser can un-read
+// a consumed token even while another one is already peeked.
 func (lx *Lexer) Push(tok token.Token) {
-	lx.look = &tok
+	lx.look = append(lx.look, tok)
 }
 
 // EmptySpan returns a zero-length span at the current cursor position.
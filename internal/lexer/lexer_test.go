@@ -431,6 +431,61 @@ func TestNumbers_InvalidExponent(t *testing.T) {
 	}
 }
 
+func TestNumbers_DigitSeparators(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  token.Kind
+	}{
+		{"1_000_000", token.IntLit},
+		{"0xFF_FF", token.IntLit},
+		{"0b1010_0101", token.IntLit},
+		{"3.14_15", token.FloatLit},
+		{"1_000.000_1e1_0", token.FloatLit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			lx, reporter := makeTestLexer(tt.input)
+			tok := lx.Next()
+			if tok.Kind != tt.kind || tok.Text != tt.input {
+				t.Errorf("Expected %v %q, got %v %q", tt.kind, tt.input, tok.Kind, tok.Text)
+			}
+			if reporter.HasErrors() {
+				t.Errorf("Unexpected errors for %q: %v", tt.input, reporter.ErrorMessages())
+			}
+		})
+	}
+}
+
+func TestNumbers_MalformedDigitSeparators(t *testing.T) {
+	tests := []string{
+		"1__0",
+		"1_",
+		"0x_FF",
+		"1_.5",
+		"1_e5",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			lx, reporter := makeTestLexer(input)
+			tok := lx.Next()
+			// текст токена сохраняется как есть
+			if tok.Text != input {
+				t.Errorf("Expected text %q, got %q", input, tok.Text)
+			}
+			if !reporter.HasErrors() {
+				t.Errorf("Expected error for %q", input)
+			}
+		})
+	}
+}
+
+func TestNumbers_LeadingUnderscoreIsIdent(t *testing.T) {
+	// "_5" — идентификатор, а не число
+	expectSingleToken(t, "_5", token.Ident, "_5")
+}
+
 func TestNumbers_DotFollowedByLetter(t *testing.T) {
 	// ".e10" — это Dot + Ident, а не число
 	expectTokens(t, ".e10", []token.Kind{
@@ -529,6 +584,127 @@ func TestString_NewlineInString(t *testing.T) {
 	}
 }
 
+func TestRawString_Backslashes(t *testing.T) {
+	tests := []struct {
+		input string
+		body  string
+	}{
+		{`r""`, ``},
+		{`r"C:\path\to"`, `C:\path\to`},
+		{`r"\d+\.\d+"`, `\d+\.\d+`},
+		{`r"trailing\"`, `trailing\`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expectSingleToken(t, tt.input, token.StringLit, tt.input)
+			body, ok := token.RawStringBody(tt.input)
+			if !ok || body != tt.body {
+				t.Fatalf("RawStringBody(%s) = %q, %v; want %q", tt.input, body, ok, tt.body)
+			}
+		})
+	}
+}
+
+func TestRawString_HashGuards(t *testing.T) {
+	tests := []struct {
+		input string
+		body  string
+	}{
+		{`r#"say "hi""#`, `say "hi"`},
+		{`r##"a "# inside"##`, `a "# inside`},
+		{`r##"ends with "#"##`, `ends with "#`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expectSingleToken(t, tt.input, token.StringLit, tt.input)
+			body, ok := token.RawStringBody(tt.input)
+			if !ok || body != tt.body {
+				t.Fatalf("RawStringBody(%s) = %q, %v; want %q", tt.input, body, ok, tt.body)
+			}
+		})
+	}
+}
+
+func TestRawString_IdentifierR(t *testing.T) {
+	expectTokens(t, `r + raw`, []token.Kind{token.Ident, token.Plus, token.Ident})
+	expectTokens(t, `r "x"`, []token.Kind{token.Ident, token.StringLit})
+}
+
+func TestRawString_Unterminated(t *testing.T) {
+	for _, input := range []string{`r"open`, `r#"closed by plain quote"`, "r\"a\nb\""} {
+		t.Run(input, func(t *testing.T) {
+			lx, reporter := makeTestLexer(input)
+			tok := lx.Next()
+			if tok.Kind != token.Invalid {
+				t.Errorf("Expected Invalid for unterminated raw string, got %v", tok.Kind)
+			}
+			if !reporter.HasErrors() {
+				t.Error("Expected error report for unterminated raw string")
+			}
+		})
+	}
+}
+
+func TestChar_Escapes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  rune
+	}{
+		{`'a'`, 'a'},
+		{`'\n'`, '\n'},
+		{`'\t'`, '\t'},
+		{`'\0'`, 0},
+		{`'\\'`, '\\'},
+		{`'\''`, '\''},
+		{`'"'`, '"'},
+		{`'\u{1F600}'`, 0x1F600},
+		{`'Ж'`, 'Ж'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			lx, reporter := makeTestLexer(tt.input)
+			tok := lx.Next()
+			if tok.Kind != token.CharLit || tok.Text != tt.input {
+				t.Fatalf("Expected CharLit %q, got %v %q (errors: %v)", tt.input, tok.Kind, tok.Text, reporter.ErrorMessages())
+			}
+			got, err := token.CharLiteralValue(tok.Text)
+			if err != nil || got != tt.want {
+				t.Errorf("Expected code point %U, got %U (err %v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestChar_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		code  diag.Code
+	}{
+		{`'ab'`, diag.LexBadCharLiteral},
+		{`''`, diag.LexBadCharLiteral},
+		{`'\q'`, diag.LexBadCharLiteral},
+		{`'\u{110000}'`, diag.LexBadCharLiteral},
+		{`'a`, diag.LexUnterminatedChar},
+		{"'a\n'", diag.LexUnterminatedChar},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			lx, reporter := makeTestLexer(tt.input)
+			tok := lx.Next()
+			if tok.Kind != token.Invalid {
+				t.Errorf("Expected Invalid for %q, got %v", tt.input, tok.Kind)
+			}
+			if len(reporter.diagnostics) == 0 || reporter.diagnostics[0].Code != tt.code {
+				t.Errorf("Expected %v for %q, got %+v", tt.code, tt.input, reporter.diagnostics)
+			}
+		})
+	}
+}
+
 func TestFString_Simple(t *testing.T) {
 	tests := []struct {
 		input string
@@ -9,6 +9,8 @@ import (
 type Options struct {
 	Reporter        diag.Reporter
 	DialectEvidence *dialect.Evidence
+	// Suppressions collects `// surge:ignore` directives found in line comments.
+	Suppressions *diag.Suppressions
 }
 
 // SetDialectEvidence sets the container for collecting foreign dialect signals.
@@ -1,6 +1,8 @@
 package lexer
 
 import (
+	"strings"
+
 	"surge/internal/diag"
 	"surge/internal/token"
 )
@@ -15,7 +17,7 @@ func (lx *Lexer) scanNumber() token.Token {
 	//  - 0b[01_]+, 0o[0-7_]+, 0x[0-9a-fA-F_]+
 	//  - десятичные: [0-9][0-9_]* (опц. .[0-9_]+) (опц. [eE][+-]?[0-9_]+)
 	//  - .[0-9_]+ (если вызваны после проверки isNumberAfterDot)
-	//  - Валидацию расположения '_' пока мягко: разрешаем внутри цифр; грубые ошибки репортим позже.
+	//  - '_' допустим только между двумя цифрами (см. checkDigitSeparators).
 
 	kind := token.IntLit
 
@@ -116,5 +118,42 @@ emitWithMaybeExp:
 
 emit:
 	sp := lx.cursor.SpanFrom(start)
-	return token.Token{Kind: kind, Span: sp, Text: string(lx.file.Content[sp.Start:sp.End])}
+	text := string(lx.file.Content[sp.Start:sp.End])
+	if msg := checkDigitSeparators(text); msg != "" {
+		lx.errLex(diag.LexBadNumber, sp, msg)
+	}
+	return token.Token{Kind: kind, Span: sp, Text: text}
+}
+
+// checkDigitSeparators проверяет, что каждый '_' стоит между двумя цифрами
+// (с учётом базы): `1_000`, `0xFF_FF`, `3.14_15` — ок; `1__0`, `1_`, `0x_F`, `1_.5` — ошибка.
+// Сам текст токена не меняется, '_' вырезаются при разборе значения.
+func checkDigitSeparators(text string) string {
+	if strings.IndexByte(text, '_') < 0 {
+		return ""
+	}
+	isDigit := isDec
+	body := 0
+	if len(text) > 1 && text[0] == '0' {
+		switch text[1] {
+		case 'x', 'X':
+			isDigit, body = isHex, 2
+		case 'b', 'B', 'o', 'O':
+			body = 2
+		}
+	}
+	for i := body; i < len(text); i++ {
+		if text[i] != '_' {
+			continue
+		}
+		switch {
+		case i+1 < len(text) && text[i+1] == '_':
+			return "consecutive '_' in numeric literal"
+		case i == body || !isDigit(text[i-1]):
+			return "'_' must follow a digit in numeric literal"
+		case i+1 == len(text) || !isDigit(text[i+1]):
+			return "'_' must be followed by a digit in numeric literal"
+		}
+	}
+	return ""
 }
@@ -73,3 +73,86 @@ func (lx *Lexer) scanFString() token.Token {
 	lx.errLex(diag.LexUnterminatedString, sp, "unterminated string literal")
 	return token.Token{Kind: token.Invalid, Span: sp, Text: string(lx.file.Content[sp.Start:sp.End])}
 }
+
+// scanRawString сканирует сырые строки r"..." и r#"..."# (любое число '#').
+// Escape-последовательности не интерпретируются; Text остаётся срезом исходника
+// вместе с префиксом r, по которому потребители (VM, LLVM) пропускают декодирование.
+// Если после r и '#' нет кавычки — откатывается и возвращает isRaw=false.
+func (lx *Lexer) scanRawString() (tok token.Token, isRaw bool) {
+	start := lx.cursor.Mark()
+	lx.cursor.Bump() // 'r'
+	hashes := 0
+	for !lx.cursor.EOF() && lx.cursor.Peek() == '#' {
+		lx.cursor.Bump()
+		hashes++
+	}
+	if lx.cursor.EOF() || lx.cursor.Peek() != '"' {
+		lx.cursor.Reset(start)
+		return token.Token{}, false
+	}
+	lx.cursor.Bump() // opening '"'
+	for !lx.cursor.EOF() {
+		b := lx.cursor.Peek()
+		if b == '\n' {
+			sp := lx.cursor.SpanFrom(start)
+			lx.errLex(diag.LexUnterminatedString, sp, "newline in string literal")
+			return token.Token{Kind: token.Invalid, Span: sp, Text: string(lx.file.Content[sp.Start:sp.End])}, true
+		}
+		lx.cursor.Bump()
+		if b == '"' && lx.eatHashes(hashes) {
+			sp := lx.cursor.SpanFrom(start)
+			return token.Token{Kind: token.StringLit, Span: sp, Text: string(lx.file.Content[sp.Start:sp.End])}, true
+		}
+	}
+	sp := lx.cursor.SpanFrom(start)
+	lx.errLex(diag.LexUnterminatedString, sp, "unterminated raw string literal")
+	return token.Token{Kind: token.Invalid, Span: sp, Text: string(lx.file.Content[sp.Start:sp.End])}, true
+}
+
+// eatHashes съедает ровно n символов '#', если они идут подряд; иначе курсор не двигается.
+func (lx *Lexer) eatHashes(n int) bool {
+	mark := lx.cursor.Mark()
+	for range n {
+		if lx.cursor.EOF() || lx.cursor.Peek() != '#' {
+			lx.cursor.Reset(mark)
+			return false
+		}
+		lx.cursor.Bump()
+	}
+	return true
+}
+
+// scanChar сканирует символьный литерал 'a', '\n', '\u{1F600}'.
+// Text остаётся срезом исходника; значение декодирует token.CharLiteralValue.
+// Пустой, многосимвольный литерал или плохой escape → LexBadCharLiteral,
+// отсутствие закрывающей кавычки до конца строки → LexUnterminatedChar.
+func (lx *Lexer) scanChar() token.Token {
+	start := lx.cursor.Mark()
+	lx.cursor.Bump() // opening '\''
+	for !lx.cursor.EOF() {
+		b := lx.cursor.Peek()
+		if b == '\n' {
+			break
+		}
+		lx.cursor.Bump()
+		if b == '\\' {
+			if lx.cursor.EOF() || lx.cursor.Peek() == '\n' {
+				break
+			}
+			lx.cursor.Bump()
+			continue
+		}
+		if b == '\'' {
+			sp := lx.cursor.SpanFrom(start)
+			text := string(lx.file.Content[sp.Start:sp.End])
+			if _, err := token.CharLiteralValue(text); err != nil {
+				lx.errLex(diag.LexBadCharLiteral, sp, err.Error())
+				return token.Token{Kind: token.Invalid, Span: sp, Text: text}
+			}
+			return token.Token{Kind: token.CharLit, Span: sp, Text: text}
+		}
+	}
+	sp := lx.cursor.SpanFrom(start)
+	lx.errLex(diag.LexUnterminatedChar, sp, "unterminated character literal")
+	return token.Token{Kind: token.Invalid, Span: sp, Text: string(lx.file.Content[sp.Start:sp.End])}
+}
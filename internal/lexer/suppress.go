@@ -0,0 +1,55 @@
+package lexer
+
+import (
+	"fmt"
+	"sort"
+
+	"fortio.org/safecast"
+
+	"surge/internal/diag"
+	"surge/internal/source"
+)
+
+// recordSuppression registers a `surge:ignore` line comment in opts.Suppressions.
+// A directive alone on its line applies to the next line; a trailing one applies to its own line.
+func (lx *Lexer) recordSuppression(sp source.Span, text string) {
+	fileScope, codes, ok := diag.ParseSuppressionComment(text)
+	if !ok {
+		return
+	}
+	sup := &diag.Suppression{
+		Span:      sp,
+		FileScope: fileScope,
+		Codes:     codes,
+	}
+	if !fileScope {
+		line := lineOf(lx.file, sp.Start)
+		if commentStartsLine(lx.file, line, sp.Start) {
+			line++
+		}
+		sup.Line = line
+	}
+	lx.opts.Suppressions.Add(sup)
+}
+
+func lineOf(file *source.File, off uint32) uint32 {
+	newlines := sort.Search(len(file.LineIdx), func(k int) bool { return file.LineIdx[k] >= off })
+	line, err := safecast.Conv[uint32](newlines + 1)
+	if err != nil {
+		panic(fmt.Errorf("line number overflow: %w", err))
+	}
+	return line
+}
+
+func commentStartsLine(file *source.File, line, off uint32) bool {
+	lineStart := uint32(0)
+	if line >= 2 && int(line-2) < len(file.LineIdx) {
+		lineStart = file.LineIdx[line-2] + 1
+	}
+	for i := lineStart; i < off && int(i) < len(file.Content); i++ {
+		if c := file.Content[i]; c != ' ' && c != '\t' {
+			return false
+		}
+	}
+	return true
+}
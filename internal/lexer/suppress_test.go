@@ -0,0 +1,44 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"surge/internal/diag"
+	"surge/internal/lexer"
+	"surge/internal/source"
+)
+
+func TestLexerCollectsSuppressions(t *testing.T) {
+	src := "// surge:ignore-file LEX1001\n" +
+		"let a = 1; // surge:ignore SEM3005\n" +
+		"// surge:ignore\n" +
+		"let b = 2;\n" +
+		"/// surge:ignore SEM3005\n" +
+		"// surge:ignored SEM3005\n"
+	fs := source.NewFileSet()
+	file := fs.Get(fs.AddVirtual("test.sg", []byte(src)))
+
+	sups := diag.NewSuppressions()
+	lx := lexer.New(file, lexer.Options{Suppressions: sups})
+	collectAllTokens(lx)
+
+	if sups.Len() != 3 {
+		t.Fatalf("expected 3 suppressions, got %d", sups.Len())
+	}
+	got := sups.Unused()
+	if !got[0].FileScope || len(got[0].Codes) != 1 || got[0].Codes[0] != "LEX1001" {
+		t.Fatalf("file-scoped directive = %+v", got[0])
+	}
+	if got[1].Line != 2 || got[1].Codes[0] != "SEM3005" {
+		t.Fatalf("trailing directive must target its own line, got %+v", got[1])
+	}
+	if got[2].Line != 4 || len(got[2].Codes) != 0 {
+		t.Fatalf("standalone directive must target the next line, got %+v", got[2])
+	}
+
+	// Re-lexing the same file into the same index does not duplicate directives.
+	collectAllTokens(lexer.New(file, lexer.Options{Suppressions: sups}))
+	if sups.Len() != 3 {
+		t.Fatalf("expected re-lexing to keep 3 suppressions, got %d", sups.Len())
+	}
+}
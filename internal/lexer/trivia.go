@@ -82,11 +82,15 @@ func (lx *Lexer) scanCommentOrDocLineIntoHold() bool {
 			lx.cursor.Bump()
 		}
 		sp := lx.cursor.SpanFrom(start)
+		text := string(lx.file.Content[sp.Start:sp.End])
 		lx.hold = append(lx.hold, token.Trivia{
 			Kind: kind,
 			Span: sp,
-			Text: string(lx.file.Content[sp.Start:sp.End]),
+			Text: text,
 		})
+		if kind == token.TriviaLineComment && lx.opts.Suppressions != nil {
+			lx.recordSuppression(sp, text)
+		}
 		return true
 
 	case '*': // "/* ... */" (with nesting)
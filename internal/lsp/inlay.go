@@ -426,7 +426,7 @@ func isObviousLiteral(af *diagnose.AnalysisFile, exprID ast.ExprID, typeID types
 		return false
 	}
 	switch lit.Kind {
-	case ast.ExprLitInt, ast.ExprLitUint, ast.ExprLitFloat, ast.ExprLitString, ast.ExprLitTrue, ast.ExprLitFalse:
+	case ast.ExprLitInt, ast.ExprLitUint, ast.ExprLitFloat, ast.ExprLitString, ast.ExprLitChar, ast.ExprLitTrue, ast.ExprLitFalse:
 	default:
 		return false
 	}
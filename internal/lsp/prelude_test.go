@@ -17,8 +17,8 @@ func TestPreludeOptionAvailable(t *testing.T) {
 	src := strings.Join([]string{
 		"@entrypoint",
 		"fn main() -> int {",
-		"    let foo = Some(1);",
-		"    let bar: Option<int> = nothing;",
+		"    let _foo = Some(1);",
+		"    let _bar: Option<int> = nothing;",
 		"    return 0;",
 		"}",
 		"",
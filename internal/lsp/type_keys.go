@@ -89,6 +89,9 @@ func typeKeyForType(interner *types.Interner, id types.TypeID) symbols.TypeKey {
 			return symbols.TypeKey("int")
 		}
 	case types.KindUint:
+		if tt.IsChar() {
+			return symbols.TypeKey("char")
+		}
 		switch tt.Width {
 		case types.Width8:
 			return symbols.TypeKey("uint8")
@@ -236,6 +239,9 @@ func familyKeyForType(interner *types.Interner, id types.TypeID) symbols.TypeKey
 	case types.KindInt:
 		return symbols.TypeKey("int")
 	case types.KindUint:
+		if tt.IsChar() {
+			return ""
+		}
 		return symbols.TypeKey("uint")
 	case types.KindFloat:
 		return symbols.TypeKey("float")
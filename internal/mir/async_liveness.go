@@ -100,6 +100,14 @@ func computeBlockUseDef(bb *Block) (use, def localSet) {
 			}
 			addUsesFromPlaceWrite(ins.Blocking.Dst, addUse)
 			addDefFromPlace(ins.Blocking.Dst, addDef)
+		case InstrParallel:
+			for i := range ins.Parallel.State.Fields {
+				addUsesFromOperand(&ins.Parallel.State.Fields[i].Value, addUse, addDef)
+			}
+			addUsesFromOperand(&ins.Parallel.Iterable, addUse, addDef)
+			addUsesFromOperand(&ins.Parallel.Init, addUse, addDef)
+			addUsesFromPlaceWrite(ins.Parallel.Dst, addUse)
+			addDefFromPlace(ins.Parallel.Dst, addDef)
 		case InstrPoll:
 			addUsesFromOperand(&ins.Poll.Task, addUse, addDef)
 			addUsesFromPlaceWrite(ins.Poll.Dst, addUse)
@@ -194,6 +202,9 @@ func addUsesFromRValue(rv *RValue, addUse, addDef func(LocalID)) {
 	case RValueCast:
 		addUsesFromOperand(&rv.Cast.Value, addUse, addDef)
 	case RValueStructLit:
+		if rv.StructLit.HasBase {
+			addUsesFromOperand(&rv.StructLit.Base, addUse, addDef)
+		}
 		for i := range rv.StructLit.Fields {
 			addUsesFromOperand(&rv.StructLit.Fields[i].Value, addUse, addDef)
 		}
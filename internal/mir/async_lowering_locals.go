@@ -88,6 +88,10 @@ func localsAssignedInBlock(f *Func, bbID BlockID) localSet {
 			if len(ins.Blocking.Dst.Proj) == 0 && ins.Blocking.Dst.Kind == PlaceLocal {
 				set.add(ins.Blocking.Dst.Local)
 			}
+		case InstrParallel:
+			if len(ins.Parallel.Dst.Proj) == 0 && ins.Parallel.Dst.Kind == PlaceLocal {
+				set.add(ins.Parallel.Dst.Local)
+			}
 		case InstrJoinAll:
 			if len(ins.JoinAll.Dst.Proj) == 0 && ins.JoinAll.Dst.Kind == PlaceLocal {
 				set.add(ins.JoinAll.Dst.Local)
@@ -246,6 +250,13 @@ func collectLocalsInInstr(ins *Instr, set localSet) {
 			collectLocalsFromOperand(&ins.Blocking.State.Fields[i].Value, set)
 		}
 		collectLocalsFromPlace(ins.Blocking.Dst, set)
+	case InstrParallel:
+		for i := range ins.Parallel.State.Fields {
+			collectLocalsFromOperand(&ins.Parallel.State.Fields[i].Value, set)
+		}
+		collectLocalsFromOperand(&ins.Parallel.Iterable, set)
+		collectLocalsFromOperand(&ins.Parallel.Init, set)
+		collectLocalsFromPlace(ins.Parallel.Dst, set)
 	case InstrPoll:
 		collectLocalsFromOperand(&ins.Poll.Task, set)
 		collectLocalsFromPlace(ins.Poll.Dst, set)
@@ -299,6 +310,9 @@ func collectLocalsFromRValue(rv *RValue, set localSet) {
 	case RValueCast:
 		collectLocalsFromOperand(&rv.Cast.Value, set)
 	case RValueStructLit:
+		if rv.StructLit.HasBase {
+			collectLocalsFromOperand(&rv.StructLit.Base, set)
+		}
 		for i := range rv.StructLit.Fields {
 			collectLocalsFromOperand(&rv.StructLit.Fields[i].Value, set)
 		}
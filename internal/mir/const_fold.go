@@ -0,0 +1,522 @@
+package mir
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"surge/internal/ast"
+	"surge/internal/checked"
+	"surge/internal/numlit"
+	"surge/internal/source"
+	"surge/internal/types"
+)
+
+// ConstFoldError describes a constant expression that would fail at runtime,
+// such as `127:int8 + 1`. Span is the span of the instruction's destination
+// local, the same span the VM reports for it, or the function span when the
+// instruction has no destination.
+type ConstFoldError struct {
+	Func FuncID
+	Name string
+	Span source.Span
+	Msg  string
+}
+
+func (e *ConstFoldError) Error() string {
+	return fmt.Sprintf("fn %s: %s", e.Name, e.Msg)
+}
+
+// FoldConstants replaces unary, binary and cast operations whose operands are
+// all integer or bool constants with a single constant of the same type.
+// Transformations, repeated per block until nothing changes:
+// 1. Fold `RValueUnaryOp`/`RValueBinaryOp`/`RValueCast` over constant operands
+// 2. Fold calls to builtin operator intrinsics (`__add`, `__neg`, `__to`, ...),
+// which is how arithmetic on primitives reaches MIR
+// 3. Propagate a local assigned a constant into later operands of the same block,
+// so `2 + 3 * 4` folds through its temporaries
+//
+// Arithmetic goes through the same checked helpers as the VM. Overflow of a
+// fixed-width type is returned as an error and the instruction is left as is;
+// `int`/`uint` results that do not fit in 64 bits are simply not folded.
+// Functions are visited in id order so errors come out deterministically.
+func FoldConstants(m *Module, typesIn *types.Interner) []*ConstFoldError {
+	if m == nil || typesIn == nil {
+		return nil
+	}
+	ids := make([]FuncID, 0, len(m.Funcs))
+	for id := range m.Funcs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var errs []*ConstFoldError
+	for _, id := range ids {
+		f := m.Funcs[id]
+		if f == nil || len(f.Blocks) == 0 {
+			continue
+		}
+		cf := &constFolder{
+			m:        m,
+			f:        f,
+			types:    typesIn,
+			addrOf:   addressedLocals(f),
+			reported: make(map[[2]int]bool),
+		}
+		for bi := range f.Blocks {
+			for cf.foldBlock(bi) {
+			}
+		}
+		errs = append(errs, cf.errs...)
+	}
+	return errs
+}
+
+// magicBinaryOps maps builtin operator intrinsics to the operator they implement.
+var magicBinaryOps = map[string]ast.ExprBinaryOp{
+	"__add": ast.ExprBinaryAdd,
+	"__sub": ast.ExprBinarySub,
+	"__mul": ast.ExprBinaryMul,
+	"__div": ast.ExprBinaryDiv,
+	"__mod": ast.ExprBinaryMod,
+	"__eq":  ast.ExprBinaryEq,
+	"__ne":  ast.ExprBinaryNotEq,
+	"__lt":  ast.ExprBinaryLess,
+	"__le":  ast.ExprBinaryLessEq,
+	"__gt":  ast.ExprBinaryGreater,
+	"__ge":  ast.ExprBinaryGreaterEq,
+}
+
+var magicUnaryOps = map[string]ast.ExprUnaryOp{
+	"__pos": ast.ExprUnaryPlus,
+	"__neg": ast.ExprUnaryMinus,
+	"__not": ast.ExprUnaryNot,
+}
+
+type constFolder struct {
+	m        *Module
+	f        *Func
+	types    *types.Interner
+	addrOf   map[LocalID]bool // locals that may change behind a reference
+	reported map[[2]int]bool  // (block, instr) pairs already reported
+	errs     []*ConstFoldError
+}
+
+// addressedLocals collects locals whose address is taken anywhere in f.
+// Their value cannot be tracked by looking at direct assignments alone.
+func addressedLocals(f *Func) map[LocalID]bool {
+	out := make(map[LocalID]bool)
+	for bi := range f.Blocks {
+		bb := &f.Blocks[bi]
+		for ii := range bb.Instrs {
+			VisitInstrLocals(&bb.Instrs[ii], func(id LocalID, access LocalAccess) {
+				if access == LocalAddr {
+					out[id] = true
+				}
+			})
+		}
+	}
+	return out
+}
+
+// foldBlock makes one pass over a block and reports whether anything changed.
+func (cf *constFolder) foldBlock(bi int) bool {
+	bb := &cf.f.Blocks[bi]
+	known := make(map[LocalID]Const)
+	changed := false
+	for ii := range bb.Instrs {
+		ins := &bb.Instrs[ii]
+		switch ins.Kind {
+		case InstrAssign:
+			if ins.Assign.Src.Kind == RValueUse {
+				break
+			}
+			if c, ok := cf.foldRValue(&ins.Assign.Src, known, bi, ii); ok {
+				ins.Assign.Src = constRValue(c)
+				changed = true
+			}
+		case InstrCall:
+			if rv, ok := cf.magicCallRValue(&ins.Call); ok {
+				if c, ok := cf.foldRValue(&rv, known, bi, ii); ok {
+					*ins = Instr{Kind: InstrAssign, Assign: AssignInstr{Dst: ins.Call.Dst, Src: constRValue(c)}}
+					changed = true
+				}
+			}
+		}
+		VisitInstrLocals(ins, func(id LocalID, access LocalAccess) {
+			if access != LocalRead {
+				delete(known, id)
+			}
+		})
+		if ins.Kind != InstrAssign {
+			continue
+		}
+		dst := ins.Assign.Dst
+		src := ins.Assign.Src
+		if dst.Kind == PlaceLocal && len(dst.Proj) == 0 && !cf.addrOf[dst.Local] &&
+			src.Kind == RValueUse && src.Use.Kind == OperandConst {
+			known[dst.Local] = src.Use.Const
+		}
+	}
+	return changed
+}
+
+func constRValue(c Const) RValue {
+	return RValue{Kind: RValueUse, Use: Operand{Kind: OperandConst, Type: c.Type, Const: c}}
+}
+
+// magicCallRValue rewrites a call to a builtin operator intrinsic into the
+// equivalent rvalue. Calls that resolve to a function with a MIR body are
+// user overloads and are left alone, matching the VM's dispatch.
+func (cf *constFolder) magicCallRValue(call *CallInstr) (RValue, bool) {
+	if !call.HasDst || call.Callee.Kind != CalleeSym {
+		return RValue{}, false
+	}
+	if _, ok := cf.m.FuncBySym[call.Callee.Sym]; ok && call.Callee.Sym.IsValid() {
+		return RValue{}, false
+	}
+	name := call.Callee.Name
+	if op, ok := magicBinaryOps[name]; ok && len(call.Args) == 2 {
+		return RValue{Kind: RValueBinaryOp, Binary: BinaryOp{Op: op, Left: call.Args[0], Right: call.Args[1]}}, true
+	}
+	if op, ok := magicUnaryOps[name]; ok && len(call.Args) == 1 {
+		return RValue{Kind: RValueUnaryOp, Unary: UnaryOp{Op: op, Operand: call.Args[0]}}, true
+	}
+	if name == "__to" && len(call.Args) == 1 && call.Dst.Kind == PlaceLocal && len(call.Dst.Proj) == 0 &&
+		int(call.Dst.Local) < len(cf.f.Locals) {
+		target := cf.f.Locals[call.Dst.Local].Type
+		return RValue{Kind: RValueCast, Cast: CastOp{Value: call.Args[0], TargetTy: target}}, true
+	}
+	return RValue{}, false
+}
+
+func (cf *constFolder) errorf(bi, ii int, format string, args ...any) {
+	key := [2]int{bi, ii}
+	if cf.reported[key] {
+		return
+	}
+	cf.reported[key] = true
+	cf.errs = append(cf.errs, &ConstFoldError{
+		Func: cf.f.ID,
+		Name: cf.f.Name,
+		Span: cf.instrSpan(&cf.f.Blocks[bi].Instrs[ii]),
+		Msg:  fmt.Sprintf(format, args...),
+	})
+}
+
+// instrSpan returns the span of the local an assignment or call writes to.
+func (cf *constFolder) instrSpan(ins *Instr) source.Span {
+	var dst *Place
+	switch ins.Kind {
+	case InstrAssign:
+		dst = &ins.Assign.Dst
+	case InstrCall:
+		if ins.Call.HasDst {
+			dst = &ins.Call.Dst
+		}
+	}
+	if dst != nil && int(dst.Local) < len(cf.f.Locals) && cf.f.Locals[dst.Local].Span != (source.Span{}) {
+		return cf.f.Locals[dst.Local].Span
+	}
+	return cf.f.Span
+}
+
+// operandConst returns the constant value of op, looking through locals
+// whose last assignment in the block was a constant.
+func operandConst(op *Operand, known map[LocalID]Const) (Const, bool) {
+	switch op.Kind {
+	case OperandConst:
+		c := op.Const
+		if c.Type == types.NoTypeID {
+			c.Type = op.Type
+		}
+		return c, true
+	case OperandCopy:
+		if op.Place.Kind != PlaceLocal || len(op.Place.Proj) != 0 {
+			return Const{}, false
+		}
+		c, ok := known[op.Place.Local]
+		return c, ok
+	default:
+		return Const{}, false
+	}
+}
+
+// intConst is an integer constant together with its numeric kind and width.
+type intConst struct {
+	ty       types.TypeID
+	unsigned bool
+	width    types.Width
+	i        int64
+	u        uint64
+}
+
+func (cf *constFolder) intValue(c Const) (intConst, bool) {
+	tt, ok := cf.types.Lookup(resolveAlias(cf.types, c.Type))
+	if !ok || (tt.Kind != types.KindInt && tt.Kind != types.KindUint) {
+		return intConst{}, false
+	}
+	v := intConst{ty: c.Type, unsigned: tt.Kind == types.KindUint, width: tt.Width}
+	switch c.Kind {
+	case ConstInt:
+		if c.Text != "" {
+			v.i, ok = numlit.ParseInt64(c.Text)
+		} else {
+			v.i, ok = c.IntValue, true
+		}
+		if ok && v.unsigned {
+			if v.i < 0 {
+				return intConst{}, false
+			}
+			v.u = uint64(v.i)
+		}
+	case ConstUint:
+		if c.Text != "" {
+			v.u, ok = numlit.ParseUint64(c.Text)
+		} else {
+			v.u, ok = c.UintValue, true
+		}
+		if ok && !v.unsigned {
+			if v.u > math.MaxInt64 {
+				return intConst{}, false
+			}
+			v.i = int64(v.u)
+		}
+	default:
+		return intConst{}, false
+	}
+	return v, ok
+}
+
+func (v intConst) label(typesIn *types.Interner) string {
+	if v.unsigned {
+		return fmt.Sprintf("%d:%s", v.u, types.Label(typesIn, v.ty))
+	}
+	return fmt.Sprintf("%d:%s", v.i, types.Label(typesIn, v.ty))
+}
+
+func (cf *constFolder) makeInt(ty types.TypeID, unsigned bool, i int64, u uint64) Const {
+	if unsigned {
+		return Const{Kind: ConstUint, Type: ty, Text: strconv.FormatUint(u, 10), UintValue: u}
+	}
+	return Const{Kind: ConstInt, Type: ty, Text: strconv.FormatInt(i, 10), IntValue: i}
+}
+
+func (cf *constFolder) makeBool(b bool) Const {
+	return Const{Kind: ConstBool, Type: cf.types.Builtins().Bool, BoolValue: b}
+}
+
+func (cf *constFolder) foldRValue(rv *RValue, known map[LocalID]Const, bi, ii int) (Const, bool) {
+	switch rv.Kind {
+	case RValueUnaryOp:
+		operand, ok := operandConst(&rv.Unary.Operand, known)
+		if !ok {
+			return Const{}, false
+		}
+		return cf.foldUnary(rv.Unary.Op, operand, bi, ii)
+	case RValueBinaryOp:
+		left, ok := operandConst(&rv.Binary.Left, known)
+		if !ok {
+			return Const{}, false
+		}
+		right, ok := operandConst(&rv.Binary.Right, known)
+		if !ok {
+			return Const{}, false
+		}
+		return cf.foldBinary(rv.Binary.Op, left, right, bi, ii)
+	case RValueCast:
+		value, ok := operandConst(&rv.Cast.Value, known)
+		if !ok {
+			return Const{}, false
+		}
+		return cf.foldCast(value, rv.Cast.TargetTy, bi, ii)
+	default:
+		return Const{}, false
+	}
+}
+
+func (cf *constFolder) foldUnary(op ast.ExprUnaryOp, c Const, bi, ii int) (Const, bool) {
+	if c.Kind == ConstBool {
+		if op == ast.ExprUnaryNot {
+			return Const{Kind: ConstBool, Type: c.Type, BoolValue: !c.BoolValue}, true
+		}
+		return Const{}, false
+	}
+	v, ok := cf.intValue(c)
+	if !ok {
+		return Const{}, false
+	}
+	switch op {
+	case ast.ExprUnaryPlus:
+		return cf.makeInt(v.ty, v.unsigned, v.i, v.u), true
+	case ast.ExprUnaryMinus:
+		if v.unsigned {
+			return Const{}, false
+		}
+		res, ok := checked.SubInt64(0, v.i)
+		if ok && checked.FitsSigned(res, v.width) {
+			return cf.makeInt(v.ty, false, res, 0), true
+		}
+		if v.width != types.WidthAny {
+			cf.errorf(bi, ii, "constant expression -(%s) overflows %s", v.label(cf.types), types.Label(cf.types, v.ty))
+		}
+	}
+	return Const{}, false
+}
+
+func (cf *constFolder) foldBinary(op ast.ExprBinaryOp, left, right Const, bi, ii int) (Const, bool) {
+	if left.Kind == ConstBool && right.Kind == ConstBool {
+		switch op {
+		case ast.ExprBinaryLogicalAnd:
+			return cf.makeBool(left.BoolValue && right.BoolValue), true
+		case ast.ExprBinaryLogicalOr:
+			return cf.makeBool(left.BoolValue || right.BoolValue), true
+		case ast.ExprBinaryEq:
+			return cf.makeBool(left.BoolValue == right.BoolValue), true
+		case ast.ExprBinaryNotEq:
+			return cf.makeBool(left.BoolValue != right.BoolValue), true
+		}
+		return Const{}, false
+	}
+	a, ok := cf.intValue(left)
+	if !ok {
+		return Const{}, false
+	}
+	b, ok := cf.intValue(right)
+	if !ok || a.unsigned != b.unsigned || a.width != b.width {
+		return Const{}, false
+	}
+
+	if cmp, ok := compareInts(op, a, b); ok {
+		return cf.makeBool(cmp), true
+	}
+
+	var (
+		i   int64
+		u   uint64
+		ok2 bool
+	)
+	switch op {
+	case ast.ExprBinaryAdd:
+		if a.unsigned {
+			u, ok2 = checked.AddUint64(a.u, b.u)
+		} else {
+			i, ok2 = checked.AddInt64(a.i, b.i)
+		}
+	case ast.ExprBinarySub:
+		if a.unsigned {
+			u, ok2 = checked.SubUint64(a.u, b.u)
+		} else {
+			i, ok2 = checked.SubInt64(a.i, b.i)
+		}
+	case ast.ExprBinaryMul:
+		if a.unsigned {
+			u, ok2 = checked.MulUint64(a.u, b.u)
+		} else {
+			i, ok2 = checked.MulInt64(a.i, b.i)
+		}
+	case ast.ExprBinaryDiv, ast.ExprBinaryMod:
+		// Division by zero is left to the runtime, which reports it with a position.
+		if (a.unsigned && b.u == 0) || (!a.unsigned && b.i == 0) {
+			return Const{}, false
+		}
+		switch {
+		case a.unsigned && op == ast.ExprBinaryDiv:
+			u, ok2 = a.u/b.u, true
+		case a.unsigned:
+			u, ok2 = a.u%b.u, true
+		case op == ast.ExprBinaryDiv:
+			minVal := int64(math.MinInt64)
+			if lo, _, ok := checked.IntRange(a.width); ok {
+				minVal = lo
+			}
+			if a.i == minVal && b.i == -1 {
+				break
+			}
+			i, ok2 = a.i/b.i, true
+		case a.i == math.MinInt64 && b.i == -1:
+			i, ok2 = 0, true
+		default:
+			i, ok2 = a.i%b.i, true
+		}
+	default:
+		return Const{}, false
+	}
+	if ok2 && (a.unsigned && checked.FitsUnsigned(u, a.width) || !a.unsigned && checked.FitsSigned(i, a.width)) {
+		return cf.makeInt(a.ty, a.unsigned, i, u), true
+	}
+	if a.width != types.WidthAny {
+		cf.errorf(bi, ii, "constant expression %s %s %s overflows %s",
+			a.label(cf.types), op, b.label(cf.types), types.Label(cf.types, a.ty))
+	}
+	return Const{}, false
+}
+
+func compareInts(op ast.ExprBinaryOp, a, b intConst) (bool, bool) {
+	c := 0
+	switch {
+	case a.unsigned && a.u < b.u, !a.unsigned && a.i < b.i:
+		c = -1
+	case a.unsigned && a.u > b.u, !a.unsigned && a.i > b.i:
+		c = 1
+	}
+	switch op {
+	case ast.ExprBinaryEq:
+		return c == 0, true
+	case ast.ExprBinaryNotEq:
+		return c != 0, true
+	case ast.ExprBinaryLess:
+		return c < 0, true
+	case ast.ExprBinaryLessEq:
+		return c <= 0, true
+	case ast.ExprBinaryGreater:
+		return c > 0, true
+	case ast.ExprBinaryGreaterEq:
+		return c >= 0, true
+	default:
+		return false, false
+	}
+}
+
+// foldCast folds integer-to-integer casts, following the range checks the VM
+// applies in __to.
+func (cf *constFolder) foldCast(c Const, target types.TypeID, bi, ii int) (Const, bool) {
+	v, ok := cf.intValue(c)
+	if !ok || target == types.NoTypeID {
+		return Const{}, false
+	}
+	tt, ok := cf.types.Lookup(resolveAlias(cf.types, target))
+	if !ok || (tt.Kind != types.KindInt && tt.Kind != types.KindUint) {
+		return Const{}, false
+	}
+	fits := false
+	var (
+		i int64
+		u uint64
+	)
+	if tt.Kind == types.KindInt {
+		switch {
+		case !v.unsigned:
+			i, fits = v.i, true
+		case v.u <= math.MaxInt64:
+			i, fits = int64(v.u), true
+		}
+		fits = fits && checked.FitsSigned(i, tt.Width)
+	} else {
+		switch {
+		case v.unsigned:
+			u, fits = v.u, true
+		case v.i >= 0:
+			u, fits = uint64(v.i), true
+		}
+		fits = fits && checked.FitsUnsigned(u, tt.Width)
+	}
+	if fits {
+		return cf.makeInt(target, tt.Kind == types.KindUint, i, u), true
+	}
+	if tt.Width != types.WidthAny || (tt.Kind == types.KindUint && !v.unsigned && v.i < 0) {
+		cf.errorf(bi, ii, "constant %s does not fit in %s", v.label(cf.types), types.Label(cf.types, target))
+	}
+	return Const{}, false
+}
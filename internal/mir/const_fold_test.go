@@ -0,0 +1,153 @@
+package mir_test
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/ast"
+	"surge/internal/mir"
+	"surge/internal/source"
+	"surge/internal/types"
+)
+
+func constOp(ty types.TypeID, v int64) mir.Operand {
+	return mir.Operand{Kind: mir.OperandConst, Type: ty, Const: mir.Const{Kind: mir.ConstInt, Type: ty, IntValue: v}}
+}
+
+func copyOp(ty types.TypeID, local mir.LocalID) mir.Operand {
+	return mir.Operand{Kind: mir.OperandCopy, Type: ty, Place: mir.Place{Local: local}}
+}
+
+func binaryAssign(dst mir.LocalID, op ast.ExprBinaryOp, left, right mir.Operand) mir.Instr {
+	return mir.Instr{
+		Kind: mir.InstrAssign,
+		Assign: mir.AssignInstr{
+			Dst: mir.Place{Local: dst},
+			Src: mir.RValue{Kind: mir.RValueBinaryOp, Binary: mir.BinaryOp{Op: op, Left: left, Right: right}},
+		},
+	}
+}
+
+func TestFoldConstantsReplacesArithmetic(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	// L0 = 3 * 4; L1 = 2 + L0
+	f := &mir.Func{
+		Name:   "main",
+		Result: b.Nothing,
+		Locals: []mir.Local{{Name: "a", Type: b.Int}, {Name: "b", Type: b.Int}},
+		Blocks: []mir.Block{{
+			Instrs: []mir.Instr{
+				binaryAssign(0, ast.ExprBinaryMul, constOp(b.Int, 3), constOp(b.Int, 4)),
+				binaryAssign(1, ast.ExprBinaryAdd, constOp(b.Int, 2), copyOp(b.Int, 0)),
+			},
+			Term: returnTerm(),
+		}},
+	}
+	if errs := mir.FoldConstants(&mir.Module{Funcs: map[mir.FuncID]*mir.Func{0: f}}, typeInterner); len(errs) != 0 {
+		t.Fatalf("unexpected fold errors: %v", errs)
+	}
+	for i, want := range []int64{12, 14} {
+		src := f.Blocks[0].Instrs[i].Assign.Src
+		if src.Kind != mir.RValueUse || src.Use.Kind != mir.OperandConst {
+			t.Fatalf("instr %d: expected folded constant, got %+v", i, src)
+		}
+		if src.Use.Const.Kind != mir.ConstInt || src.Use.Const.IntValue != want || src.Use.Type != b.Int {
+			t.Fatalf("instr %d: expected %d:int, got %+v", i, want, src.Use.Const)
+		}
+	}
+}
+
+func TestFoldConstantsReportsOverflow(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	f := &mir.Func{
+		Name:   "main",
+		Result: b.Nothing,
+		Span:   source.Span{File: 1, Start: 0, End: 40},
+		Locals: []mir.Local{{Name: "x", Type: b.Int8, Span: source.Span{File: 1, Start: 12, End: 24}}},
+		Blocks: []mir.Block{{
+			Instrs: []mir.Instr{binaryAssign(0, ast.ExprBinaryAdd, constOp(b.Int8, 127), constOp(b.Int8, 1))},
+			Term:   returnTerm(),
+		}},
+	}
+	errs := mir.FoldConstants(&mir.Module{Funcs: map[mir.FuncID]*mir.Func{0: f}}, typeInterner)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 fold error, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "overflows int8") {
+		t.Fatalf("unexpected message: %s", errs[0].Error())
+	}
+	if errs[0].Span != f.Locals[0].Span {
+		t.Fatalf("expected the span of the folded instruction, got %v", errs[0].Span)
+	}
+	if src := f.Blocks[0].Instrs[0].Assign.Src; src.Kind != mir.RValueBinaryOp {
+		t.Fatalf("overflowing instruction must be left unfolded, got %+v", src)
+	}
+}
+
+func TestFoldConstantsFoldsBuiltinOperatorCalls(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	// L0 = __neg(5:int16); L1 = __to(L0) as int32
+	f := &mir.Func{
+		Name:   "main",
+		Result: b.Nothing,
+		Locals: []mir.Local{{Name: "a", Type: b.Int16}, {Name: "b", Type: b.Int32}},
+		Blocks: []mir.Block{{
+			Instrs: []mir.Instr{
+				{Kind: mir.InstrCall, Call: mir.CallInstr{
+					HasDst: true,
+					Dst:    mir.Place{Local: 0},
+					Callee: mir.Callee{Kind: mir.CalleeSym, Name: "__neg"},
+					Args:   []mir.Operand{constOp(b.Int16, 5)},
+				}},
+				{Kind: mir.InstrCall, Call: mir.CallInstr{
+					HasDst: true,
+					Dst:    mir.Place{Local: 1},
+					Callee: mir.Callee{Kind: mir.CalleeSym, Name: "__to"},
+					Args:   []mir.Operand{copyOp(b.Int16, 0)},
+				}},
+			},
+			Term: returnTerm(),
+		}},
+	}
+	if errs := mir.FoldConstants(&mir.Module{Funcs: map[mir.FuncID]*mir.Func{0: f}}, typeInterner); len(errs) != 0 {
+		t.Fatalf("unexpected fold errors: %v", errs)
+	}
+	ins := f.Blocks[0].Instrs[1]
+	if ins.Kind != mir.InstrAssign || ins.Assign.Src.Kind != mir.RValueUse {
+		t.Fatalf("expected call to fold into an assignment, got %+v", ins)
+	}
+	if c := ins.Assign.Src.Use.Const; c.IntValue != -5 || c.Type != b.Int32 {
+		t.Fatalf("expected -5:int32, got %+v", c)
+	}
+}
+
+func TestFoldConstantsSkipsAddressedLocals(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	f := &mir.Func{
+		Name:   "main",
+		Result: b.Nothing,
+		Locals: []mir.Local{{Name: "x", Type: b.Int}, {Name: "r", Type: b.Int}, {Name: "y", Type: b.Int}},
+		Blocks: []mir.Block{{
+			Instrs: []mir.Instr{
+				{Kind: mir.InstrAssign, Assign: mir.AssignInstr{
+					Dst: mir.Place{Local: 0},
+					Src: mir.RValue{Kind: mir.RValueUse, Use: constOp(b.Int, 1)},
+				}},
+				{Kind: mir.InstrAssign, Assign: mir.AssignInstr{
+					Dst: mir.Place{Local: 1},
+					Src: mir.RValue{Kind: mir.RValueUse, Use: mir.Operand{Kind: mir.OperandAddrOfMut, Place: mir.Place{Local: 0}}},
+				}},
+				binaryAssign(2, ast.ExprBinaryAdd, copyOp(b.Int, 0), constOp(b.Int, 1)),
+			},
+			Term: returnTerm(),
+		}},
+	}
+	mir.FoldConstants(&mir.Module{Funcs: map[mir.FuncID]*mir.Func{0: f}}, typeInterner)
+	if src := f.Blocks[0].Instrs[2].Assign.Src; src.Kind != mir.RValueBinaryOp {
+		t.Fatalf("local with taken address must not be propagated, got %+v", src)
+	}
+}
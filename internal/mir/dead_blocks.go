@@ -0,0 +1,65 @@
+package mir
+
+// EliminateDeadBlocks removes blocks that no edge from f.Entry reaches.
+// Transformations:
+// 1. Replace `if` on a constant bool with a goto to the taken branch; the
+// condition may be a constant operand or a local last assigned a constant
+// earlier in the same block, which is what FoldConstants leaves behind
+// 2. Compute reachability from the entry, following terminators and the
+// ready/pending edges of suspending instructions
+// 3. Drop unreachable blocks and renumber the rest densely, keeping their
+// relative order, so block ids stay equal to their index
+//
+// MIR has no phi nodes, so removing a predecessor never requires fixing up
+// the successor; switch_tag cases and defaults are remapped along with every
+// other edge.
+func EliminateDeadBlocks(f *Func) {
+	if f == nil || len(f.Blocks) == 0 {
+		return
+	}
+	pruneConstBranches(f)
+	compactBlocks(f, computeReachability(f))
+}
+
+// pruneConstBranches turns `if` terminators with a known condition into gotos.
+func pruneConstBranches(f *Func) {
+	addrOf := addressedLocals(f)
+	for bi := range f.Blocks {
+		bb := &f.Blocks[bi]
+		if bb.Term.Kind != TermIf {
+			continue
+		}
+		c, ok := operandConst(&bb.Term.If.Cond, blockConstLocals(bb, addrOf))
+		if !ok || c.Kind != ConstBool {
+			continue
+		}
+		target := bb.Term.If.Else
+		if c.BoolValue {
+			target = bb.Term.If.Then
+		}
+		bb.Term = Terminator{Kind: TermGoto, Goto: GotoTerm{Target: target}}
+	}
+}
+
+// blockConstLocals returns the locals that hold a constant at the end of bb.
+func blockConstLocals(bb *Block, addrOf map[LocalID]bool) map[LocalID]Const {
+	known := make(map[LocalID]Const)
+	for ii := range bb.Instrs {
+		ins := &bb.Instrs[ii]
+		VisitInstrLocals(ins, func(id LocalID, access LocalAccess) {
+			if access != LocalRead {
+				delete(known, id)
+			}
+		})
+		if ins.Kind != InstrAssign {
+			continue
+		}
+		dst := ins.Assign.Dst
+		src := ins.Assign.Src
+		if dst.Kind == PlaceLocal && len(dst.Proj) == 0 && !addrOf[dst.Local] &&
+			src.Kind == RValueUse && src.Use.Kind == OperandConst {
+			known[dst.Local] = src.Use.Const
+		}
+	}
+	return known
+}
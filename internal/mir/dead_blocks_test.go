@@ -0,0 +1,92 @@
+package mir_test
+
+import (
+	"testing"
+
+	"surge/internal/mir"
+	"surge/internal/types"
+)
+
+func gotoTerm(target mir.BlockID) mir.Terminator {
+	return mir.Terminator{Kind: mir.TermGoto, Goto: mir.GotoTerm{Target: target}}
+}
+
+func TestEliminateDeadBlocksDropsConstBranch(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	// bb0: L0 = true; if L0 then bb1 else bb2
+	// bb1: switch_tag L1 { Some -> bb3; default -> bb4 }
+	// bb2: goto bb4   (only reachable through the dead else edge)
+	// bb3, bb4: return
+	f := &mir.Func{
+		Name:   "main",
+		Result: b.Nothing,
+		Locals: []mir.Local{{Name: "c", Type: b.Bool}, {Name: "v", Type: b.Int}},
+		Blocks: []mir.Block{
+			{
+				ID: 0,
+				Instrs: []mir.Instr{{Kind: mir.InstrAssign, Assign: mir.AssignInstr{
+					Dst: mir.Place{Local: 0},
+					Src: mir.RValue{Kind: mir.RValueUse, Use: mir.Operand{
+						Kind:  mir.OperandConst,
+						Type:  b.Bool,
+						Const: mir.Const{Kind: mir.ConstBool, Type: b.Bool, BoolValue: true},
+					}},
+				}}},
+				Term: mir.Terminator{Kind: mir.TermIf, If: mir.IfTerm{Cond: copyOp(b.Bool, 0), Then: 1, Else: 2}},
+			},
+			{
+				ID: 1,
+				Term: mir.Terminator{Kind: mir.TermSwitchTag, SwitchTag: mir.SwitchTagTerm{
+					Value:   copyOp(b.Int, 1),
+					Cases:   []mir.SwitchTagCase{{TagName: "Some", Target: 3}},
+					Default: 4,
+				}},
+			},
+			{ID: 2, Term: gotoTerm(4)},
+			{ID: 3, Term: returnTerm()},
+			{ID: 4, Term: returnTerm()},
+		},
+	}
+
+	mir.EliminateDeadBlocks(f)
+
+	if len(f.Blocks) != 4 {
+		t.Fatalf("expected 4 blocks after elimination, got %d", len(f.Blocks))
+	}
+	for i := range f.Blocks {
+		if f.Blocks[i].ID != mir.BlockID(i) {
+			t.Fatalf("block %d has id %d, expected dense numbering", i, f.Blocks[i].ID)
+		}
+	}
+	if term := f.Blocks[0].Term; term.Kind != mir.TermGoto || term.Goto.Target != 1 {
+		t.Fatalf("expected constant if to become goto bb1, got %+v", term)
+	}
+	sw := f.Blocks[1].Term.SwitchTag
+	if f.Blocks[1].Term.Kind != mir.TermSwitchTag || len(sw.Cases) != 1 || sw.Cases[0].Target != 2 || sw.Default != 3 {
+		t.Fatalf("expected switch_tag remapped to bb2/bb3, got %+v", f.Blocks[1].Term)
+	}
+}
+
+func TestEliminateDeadBlocksKeepsUnknownCondition(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	// bb0: if L0 then bb1 else bb2, with L0 a parameter
+	f := &mir.Func{
+		Name:       "f",
+		Result:     b.Nothing,
+		ParamCount: 1,
+		Locals:     []mir.Local{{Name: "c", Type: b.Bool}},
+		Blocks: []mir.Block{
+			{ID: 0, Term: mir.Terminator{Kind: mir.TermIf, If: mir.IfTerm{Cond: copyOp(b.Bool, 0), Then: 1, Else: 2}}},
+			{ID: 1, Term: returnTerm()},
+			{ID: 2, Term: returnTerm()},
+		},
+	}
+
+	mir.EliminateDeadBlocks(f)
+
+	if len(f.Blocks) != 3 || f.Blocks[0].Term.Kind != mir.TermIf {
+		t.Fatalf("expected both branches to survive, got %d blocks, term %s", len(f.Blocks), f.Blocks[0].Term.Kind)
+	}
+}
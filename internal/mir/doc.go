@@ -25,6 +25,9 @@
 // Оптимизации и валидация:
 //
 //   - SimplifyCFG: упрощает граф потока управления (удаляет тривиальные goto, недостижимые блоки)
+//   - FoldConstants: сворачивает арифметику над константами (под --opt)
+//   - EliminateDeadBlocks: заменяет if по константному условию на goto и удаляет недостижимые блоки
+//   - MergeIdenticalBlocks: сливает блоки с одинаковыми инструкциями и терминатором (перед LLVM emit)
 //   - Validate: проверяет инварианты MIR модуля (терминация блоков, корректность типов и т.д.)
 //   - DumpModule: выводит человекочитаемое представление модуля для отладки
 //
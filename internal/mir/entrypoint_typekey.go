@@ -47,6 +47,9 @@ func (b *surgeStartBuilder) typeKeyForType(id types.TypeID) symbols.TypeKey {
 			return "int"
 		}
 	case types.KindUint:
+		if tt.IsChar() {
+			return "char"
+		}
 		switch tt.Width {
 		case types.Width8:
 			return "uint8"
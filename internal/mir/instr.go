@@ -24,6 +24,8 @@ const (
 	InstrSpawn
 	// InstrBlocking represents a blocking task creation instruction.
 	InstrBlocking
+	// InstrParallel represents a parallel map/reduce over an array.
+	InstrParallel
 	// InstrPoll represents a poll instruction.
 	InstrPoll
 	// InstrJoinAll represents a join all instruction.
@@ -58,6 +60,8 @@ func (k InstrKind) String() string {
 		return "Spawn"
 	case InstrBlocking:
 		return "Blocking"
+	case InstrParallel:
+		return "Parallel"
 	case InstrPoll:
 		return "Poll"
 	case InstrJoinAll:
@@ -90,6 +94,7 @@ type Instr struct {
 	Await     AwaitInstr
 	Spawn     SpawnInstr
 	Blocking  BlockingInstr
+	Parallel  ParallelInstr
 	Poll      PollInstr
 	JoinAll   JoinAllInstr
 	ChanSend  ChanSendInstr
@@ -171,6 +176,18 @@ type BlockingInstr struct {
 	State  StructLit
 }
 
+// ParallelInstr applies FuncID to every element of Iterable on the parallel runtime.
+// Map stores the array of results in Dst; reduce folds the elements into Init
+// and stores the final accumulator.
+type ParallelInstr struct {
+	Kind     ast.ExprParallelKind
+	Dst      Place
+	FuncID   FuncID
+	State    StructLit
+	Iterable Operand
+	Init     Operand // reduce only
+}
+
 // PollInstr represents a poll instruction.
 type PollInstr struct {
 	Dst     Place
@@ -443,7 +460,10 @@ type StructLitField struct {
 // StructLit represents a struct literal.
 type StructLit struct {
 	TypeID types.TypeID
-	Fields []StructLitField
+	// HasBase marks a `..base` spread: fields missing from Fields are read from Base.
+	HasBase bool
+	Base    Operand
+	Fields  []StructLitField
 }
 
 // ArrayLit represents an array literal.
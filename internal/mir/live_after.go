@@ -0,0 +1,24 @@
+package mir
+
+// LiveAfter reports the locals that may still be read after instruction instr
+// of block bb, i.e. the locals live on exit from that instruction.
+// The result is nil when f, bb or instr are out of range.
+func LiveAfter(f *Func, bb BlockID, instr int) map[LocalID]bool {
+	if f == nil || int(bb) < 0 || int(bb) >= len(f.Blocks) {
+		return nil
+	}
+	block := &f.Blocks[bb]
+	if instr < 0 || instr >= len(block.Instrs) {
+		return nil
+	}
+	info := computeLiveness(f)
+	rest := Block{ID: block.ID, Instrs: block.Instrs[instr+1:], Term: block.Term}
+	use, def := computeBlockUseDef(&rest)
+	live := unionSet(cloneSet(use), subtractSet(info[bb].out, def))
+
+	out := make(map[LocalID]bool, len(live))
+	for id := range live {
+		out[id] = true
+	}
+	return out
+}
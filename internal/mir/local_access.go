@@ -0,0 +1,213 @@
+package mir
+
+// LocalAccess classifies how an instruction or terminator mentions a local.
+type LocalAccess uint8
+
+const (
+	// LocalRead means the local is read or only partially written (through a projection).
+	LocalRead LocalAccess = iota
+	// LocalWrite means the whole local is overwritten without being read.
+	LocalWrite
+	// LocalAddr means the address of the local is taken.
+	LocalAddr
+)
+
+// VisitInstrLocals calls fn for every mention of a local in ins.
+// Sources are reported before the destination, in evaluation order.
+func VisitInstrLocals(ins *Instr, fn func(LocalID, LocalAccess)) {
+	if fn == nil {
+		return
+	}
+	visitInstrPlaces(ins, func(p Place, access LocalAccess) {
+		visitPlaceLocals(p, access, fn)
+	})
+}
+
+// VisitTermLocals calls fn for every mention of a local in term.
+func VisitTermLocals(term *Terminator, fn func(LocalID, LocalAccess)) {
+	if fn == nil {
+		return
+	}
+	visitTermPlaces(term, func(p Place, access LocalAccess) {
+		visitPlaceLocals(p, access, fn)
+	})
+}
+
+// visitInstrPlaces calls fn for every place mentioned by ins, sources first.
+func visitInstrPlaces(ins *Instr, fn func(Place, LocalAccess)) {
+	if ins == nil {
+		return
+	}
+	switch ins.Kind {
+	case InstrAssign:
+		visitRValuePlaces(&ins.Assign.Src, fn)
+		visitDstPlace(ins.Assign.Dst, fn)
+	case InstrCall:
+		if ins.Call.Callee.Kind == CalleeValue {
+			visitOperandPlace(&ins.Call.Callee.Value, fn)
+		}
+		for i := range ins.Call.Args {
+			visitOperandPlace(&ins.Call.Args[i], fn)
+		}
+		if ins.Call.HasDst {
+			visitDstPlace(ins.Call.Dst, fn)
+		}
+	case InstrDrop:
+		fn(ins.Drop.Place, LocalRead)
+	case InstrEndBorrow:
+		fn(ins.EndBorrow.Place, LocalRead)
+	case InstrAwait:
+		visitOperandPlace(&ins.Await.Task, fn)
+		visitDstPlace(ins.Await.Dst, fn)
+	case InstrSpawn:
+		visitOperandPlace(&ins.Spawn.Value, fn)
+		visitDstPlace(ins.Spawn.Dst, fn)
+	case InstrBlocking:
+		for i := range ins.Blocking.State.Fields {
+			visitOperandPlace(&ins.Blocking.State.Fields[i].Value, fn)
+		}
+		visitDstPlace(ins.Blocking.Dst, fn)
+	case InstrParallel:
+		for i := range ins.Parallel.State.Fields {
+			visitOperandPlace(&ins.Parallel.State.Fields[i].Value, fn)
+		}
+		visitOperandPlace(&ins.Parallel.Iterable, fn)
+		visitOperandPlace(&ins.Parallel.Init, fn)
+		visitDstPlace(ins.Parallel.Dst, fn)
+	case InstrPoll:
+		visitOperandPlace(&ins.Poll.Task, fn)
+		visitDstPlace(ins.Poll.Dst, fn)
+	case InstrJoinAll:
+		visitOperandPlace(&ins.JoinAll.Scope, fn)
+		visitDstPlace(ins.JoinAll.Dst, fn)
+	case InstrChanSend:
+		visitOperandPlace(&ins.ChanSend.Channel, fn)
+		visitOperandPlace(&ins.ChanSend.Value, fn)
+	case InstrChanRecv:
+		visitOperandPlace(&ins.ChanRecv.Channel, fn)
+		visitDstPlace(ins.ChanRecv.Dst, fn)
+	case InstrNetWait:
+		visitOperandPlace(&ins.NetWait.Handle, fn)
+	case InstrTimeout:
+		visitOperandPlace(&ins.Timeout.Task, fn)
+		visitOperandPlace(&ins.Timeout.Ms, fn)
+		visitDstPlace(ins.Timeout.Dst, fn)
+	case InstrSelect:
+		for i := range ins.Select.Arms {
+			arm := &ins.Select.Arms[i]
+			switch arm.Kind {
+			case SelectArmTask:
+				visitOperandPlace(&arm.Task, fn)
+			case SelectArmChanRecv:
+				visitOperandPlace(&arm.Channel, fn)
+			case SelectArmChanSend:
+				visitOperandPlace(&arm.Channel, fn)
+				visitOperandPlace(&arm.Value, fn)
+			case SelectArmTimeout:
+				visitOperandPlace(&arm.Task, fn)
+				visitOperandPlace(&arm.Ms, fn)
+			}
+		}
+		visitDstPlace(ins.Select.Dst, fn)
+	}
+}
+
+// visitTermPlaces calls fn for every place mentioned by term.
+func visitTermPlaces(term *Terminator, fn func(Place, LocalAccess)) {
+	if term == nil {
+		return
+	}
+	switch term.Kind {
+	case TermReturn:
+		if term.Return.HasValue {
+			visitOperandPlace(&term.Return.Value, fn)
+		}
+	case TermAsyncYield:
+		visitOperandPlace(&term.AsyncYield.State, fn)
+	case TermAsyncReturn:
+		visitOperandPlace(&term.AsyncReturn.State, fn)
+		if term.AsyncReturn.HasValue {
+			visitOperandPlace(&term.AsyncReturn.Value, fn)
+		}
+	case TermAsyncReturnCancelled:
+		visitOperandPlace(&term.AsyncReturnCancelled.State, fn)
+	case TermIf:
+		visitOperandPlace(&term.If.Cond, fn)
+	case TermSwitchTag:
+		visitOperandPlace(&term.SwitchTag.Value, fn)
+	}
+}
+
+func visitRValuePlaces(rv *RValue, fn func(Place, LocalAccess)) {
+	switch rv.Kind {
+	case RValueUse:
+		visitOperandPlace(&rv.Use, fn)
+	case RValueUnaryOp:
+		visitOperandPlace(&rv.Unary.Operand, fn)
+	case RValueBinaryOp:
+		visitOperandPlace(&rv.Binary.Left, fn)
+		visitOperandPlace(&rv.Binary.Right, fn)
+	case RValueCast:
+		visitOperandPlace(&rv.Cast.Value, fn)
+	case RValueStructLit:
+		if rv.StructLit.HasBase {
+			visitOperandPlace(&rv.StructLit.Base, fn)
+		}
+		for i := range rv.StructLit.Fields {
+			visitOperandPlace(&rv.StructLit.Fields[i].Value, fn)
+		}
+	case RValueArrayLit:
+		for i := range rv.ArrayLit.Elems {
+			visitOperandPlace(&rv.ArrayLit.Elems[i], fn)
+		}
+	case RValueTupleLit:
+		for i := range rv.TupleLit.Elems {
+			visitOperandPlace(&rv.TupleLit.Elems[i], fn)
+		}
+	case RValueField:
+		visitOperandPlace(&rv.Field.Object, fn)
+	case RValueIndex:
+		visitOperandPlace(&rv.Index.Object, fn)
+		visitOperandPlace(&rv.Index.Index, fn)
+	case RValueTagTest:
+		visitOperandPlace(&rv.TagTest.Value, fn)
+	case RValueTagPayload:
+		visitOperandPlace(&rv.TagPayload.Value, fn)
+	case RValueIterInit:
+		visitOperandPlace(&rv.IterInit.Iterable, fn)
+	case RValueIterNext:
+		visitOperandPlace(&rv.IterNext.Iter, fn)
+	case RValueTypeTest:
+		visitOperandPlace(&rv.TypeTest.Value, fn)
+	case RValueHeirTest:
+		visitOperandPlace(&rv.HeirTest.Value, fn)
+	}
+}
+
+func visitOperandPlace(op *Operand, fn func(Place, LocalAccess)) {
+	switch op.Kind {
+	case OperandCopy, OperandMove:
+		fn(op.Place, LocalRead)
+	case OperandAddrOf, OperandAddrOfMut:
+		fn(op.Place, LocalAddr)
+	}
+}
+
+func visitDstPlace(p Place, fn func(Place, LocalAccess)) {
+	access := LocalRead
+	if len(p.Proj) == 0 {
+		access = LocalWrite
+	}
+	fn(p, access)
+}
+
+func visitPlaceLocals(p Place, access LocalAccess, fn func(LocalID, LocalAccess)) {
+	if p.Kind == PlaceLocal && p.Local != NoLocalID {
+		fn(p.Local, access)
+	}
+	for _, proj := range p.Proj {
+		if proj.Kind == PlaceProjIndex && proj.IndexLocal != NoLocalID {
+			fn(proj.IndexLocal, LocalRead)
+		}
+	}
+}
@@ -15,8 +15,30 @@ import (
 	"surge/internal/types"
 )
 
+// LowerOptions tunes backend-specific choices made during MIR lowering.
+type LowerOptions struct {
+	// ParallelRuntime lowers parallel map/reduce over arrays to InstrParallel
+	// instead of the sequential loop fallback.
+	ParallelRuntime bool
+	// Target is the layout target recorded in Meta.Layout; the zero value
+	// means layout.X86_64LinuxGNU.
+	Target layout.Target
+}
+
+func (opts LowerOptions) layoutTarget() layout.Target {
+	if opts.Target == (layout.Target{}) {
+		return layout.X86_64LinuxGNU()
+	}
+	return opts.Target
+}
+
 // LowerModule converts a monomorphized module to MIR.
 func LowerModule(mm *mono.MonoModule, semaRes *sema.Result) (*Module, error) {
+	return LowerModuleWithOptions(mm, semaRes, LowerOptions{})
+}
+
+// LowerModuleWithOptions converts a monomorphized module to MIR using opts.
+func LowerModuleWithOptions(mm *mono.MonoModule, semaRes *sema.Result, opts LowerOptions) (*Module, error) {
 	out := &Module{
 		Funcs:     make(map[FuncID]*Func),
 		FuncBySym: make(map[symbols.SymbolID]FuncID),
@@ -103,6 +125,7 @@ func LowerModule(mm *mono.MonoModule, semaRes *sema.Result) (*Module, error) {
 			staticStringGlobals: staticStringGlobals,
 			staticStringInits:   staticStringInits,
 			nextFuncID:          &nextID,
+			opts:                opts,
 		}
 		if mm.Source != nil {
 			fl.symbols = mm.Source.Symbols
@@ -133,8 +156,9 @@ func LowerModule(mm *mono.MonoModule, semaRes *sema.Result) (*Module, error) {
 	}
 
 	out.Meta = &ModuleMeta{
-		Layout:       layout.New(layout.X86_64LinuxGNU(), typesIn),
+		Layout:       layout.New(opts.layoutTarget(), typesIn),
 		FuncTypeArgs: funcTypeArgs,
+		CloneFuncs:   buildCloneFuncs(out, mm, typesIn),
 	}
 
 	if mm.Source != nil {
@@ -155,6 +179,7 @@ func LowerModule(mm *mono.MonoModule, semaRes *sema.Result) (*Module, error) {
 }
 
 type loopCtx struct {
+	label          string
 	breakTarget    BlockID
 	continueTarget BlockID
 }
@@ -190,6 +215,7 @@ type funcLowerer struct {
 	staticStringGlobals map[string]GlobalID
 	staticStringInits   map[GlobalID]string
 	nextFuncID          *FuncID
+	opts                LowerOptions
 }
 
 func (l *funcLowerer) lowerFunc(id FuncID, fn *hir.Func) (*Func, error) {
@@ -260,19 +286,25 @@ func (l *funcLowerer) lowerFunc(id FuncID, fn *hir.Func) (*Func, error) {
 	return l.f, nil
 }
 
-func (l *funcLowerer) lowerSyntheticFunc(id FuncID, name string, body *hir.Block, result types.TypeID, span source.Span, isAsync, failfast bool) (*Func, error) {
+// lowerSyntheticFunc lowers body into a standalone function. Each capture
+// becomes a parameter bound to the captured symbol, in order.
+func (l *funcLowerer) lowerSyntheticFunc(id FuncID, name string, body *hir.Block, result types.TypeID, span source.Span, isAsync, failfast bool, captures []blockingCaptureInfo) (*Func, error) {
 	if l == nil {
 		return nil, nil
 	}
 
 	l.f = &Func{
-		ID:       id,
-		Sym:      symbols.NoSymbolID,
-		Name:     name,
-		Span:     span,
-		Result:   result,
-		IsAsync:  isAsync,
-		Failfast: failfast,
+		ID:         id,
+		Sym:        symbols.NoSymbolID,
+		Name:       name,
+		Span:       span,
+		Result:     result,
+		IsAsync:    isAsync,
+		Failfast:   failfast,
+		ParamCount: len(captures),
+	}
+	for _, cap := range captures {
+		l.ensureLocal(cap.SymbolID, cap.Name, cap.Type, span)
 	}
 
 	entry := l.newBlock()
@@ -325,6 +357,7 @@ func (l *funcLowerer) forkLowerer() *funcLowerer {
 		staticStringGlobals: l.staticStringGlobals,
 		staticStringInits:   l.staticStringInits,
 		nextFuncID:          l.nextFuncID,
+		opts:                l.opts,
 	}
 }
 
@@ -217,6 +217,9 @@ func (l *funcLowerer) lowerExpr(e *hir.Expr, consume bool) (Operand, error) {
 	case hir.ExprBlocking:
 		return l.lowerBlockingExpr(e, consume)
 
+	case hir.ExprParallel:
+		return l.lowerParallelExpr(e, consume)
+
 	case hir.ExprCompare:
 		return Operand{}, fmt.Errorf("mir: compare must be normalized before MIR lowering")
 
@@ -25,6 +25,16 @@ func (l *funcLowerer) lowerStructLitExpr(e *hir.Expr, consume bool) (Operand, er
 			}
 		}
 	}
+	lit := StructLit{TypeID: data.TypeID}
+	if data.Base != nil {
+		// base вычисляется первым и не перемещается: литерал копирует его поля
+		base, err := l.lowerExpr(data.Base, false)
+		if err != nil {
+			return Operand{}, err
+		}
+		lit.HasBase = true
+		lit.Base = base
+	}
 	fields := make([]StructLitField, 0, len(data.Fields))
 	for _, f := range data.Fields {
 		if f.Value == nil {
@@ -48,12 +58,13 @@ func (l *funcLowerer) lowerStructLitExpr(e *hir.Expr, consume bool) (Operand, er
 		}
 		fields = append(fields, StructLitField{Name: f.Name, Value: val})
 	}
+	lit.Fields = fields
 	tmp := l.newTemp(e.Type, "struct", e.Span)
 	l.emit(&Instr{
 		Kind: InstrAssign,
 		Assign: AssignInstr{
 			Dst: Place{Local: tmp},
-			Src: RValue{Kind: RValueStructLit, StructLit: StructLit{TypeID: data.TypeID, Fields: fields}},
+			Src: RValue{Kind: RValueStructLit, StructLit: lit},
 		},
 	})
 	return l.placeOperand(Place{Local: tmp}, e.Type, consume), nil
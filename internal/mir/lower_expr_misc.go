@@ -155,7 +155,24 @@ func (l *funcLowerer) lowerSpawnExpr(e *hir.Expr, consume bool) (Operand, error)
 	return l.placeOperand(Place{Local: tmp}, e.Type, consume), nil
 }
 
-// lowerAsyncExpr lowers an async block to a placeholder Task value.
+// asyncCaptureInfo resolves async block captures against the enclosing
+// function. Captures are typed by the enclosing local so generic instances
+// pass monomorphic values.
+func (l *funcLowerer) asyncCaptureInfo(captures []hir.BlockingCapture) ([]blockingCaptureInfo, error) {
+	out, err := l.blockingCaptureInfo(captures)
+	if err != nil {
+		return nil, err
+	}
+	for i := range out {
+		if local, ok := l.symToLocal[out[i].SymbolID]; ok && l.f != nil && int(local) < len(l.f.Locals) {
+			out[i].Type = l.f.Locals[local].Type
+		}
+	}
+	return out, nil
+}
+
+// lowerAsyncExpr lowers an async block to a call of a synthetic async function
+// that receives the block's captures as arguments.
 func (l *funcLowerer) lowerAsyncExpr(e *hir.Expr, consume bool) (Operand, error) {
 	data, ok := e.Data.(hir.AsyncData)
 	if !ok {
@@ -170,11 +187,15 @@ func (l *funcLowerer) lowerAsyncExpr(e *hir.Expr, consume bool) (Operand, error)
 		return Operand{}, fmt.Errorf("mir: async: failed to allocate function id")
 	}
 	name := fmt.Sprintf("__async_block$%d", asyncID)
+	captures, err := l.asyncCaptureInfo(data.Captures)
+	if err != nil {
+		return Operand{}, err
+	}
 	fl := l.forkLowerer()
 	if fl == nil {
 		return Operand{}, fmt.Errorf("mir: async: failed to fork lowerer")
 	}
-	fn, err := fl.lowerSyntheticFunc(asyncID, name, data.Body, payload, e.Span, true, data.Failfast)
+	fn, err := fl.lowerSyntheticFunc(asyncID, name, data.Body, payload, e.Span, true, data.Failfast, captures)
 	if err != nil {
 		return Operand{}, err
 	}
@@ -182,6 +203,14 @@ func (l *funcLowerer) lowerAsyncExpr(e *hir.Expr, consume bool) (Operand, error)
 		l.out.Funcs[asyncID] = fn
 	}
 
+	args := make([]Operand, 0, len(captures))
+	for _, cap := range captures {
+		arg, err := l.captureOperand(cap)
+		if err != nil {
+			return Operand{}, err
+		}
+		args = append(args, arg)
+	}
 	tmp := l.newTemp(e.Type, "async", e.Span)
 	l.emit(&Instr{
 		Kind: InstrCall,
@@ -192,6 +221,7 @@ func (l *funcLowerer) lowerAsyncExpr(e *hir.Expr, consume bool) (Operand, error)
 				Kind: CalleeValue,
 				Name: name,
 			},
+			Args: args,
 		},
 	})
 	return l.placeOperand(Place{Local: tmp}, e.Type, consume), nil
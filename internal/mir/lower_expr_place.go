@@ -61,6 +61,11 @@ func (l *funcLowerer) lowerPlace(e *hir.Expr) (Place, error) {
 		if err != nil {
 			return Place{Local: NoLocalID}, err
 		}
+		if data.Operand != nil && data.Operand.Kind == hir.ExprFieldAccess && !l.placeHoldsReference(base) {
+			// Like xs[i], s.f through a reference is typed &T but already names
+			// the field place; a deref would project from a non-reference.
+			return base, nil
+		}
 		base.Proj = append(base.Proj, PlaceProj{Kind: PlaceProjDeref})
 		return base, nil
 
@@ -153,6 +158,17 @@ func (l *funcLowerer) reborrowPlaceNeedsDeref(place Place) bool {
 	return ok && tt.Kind == types.KindReference && tt.Mutable
 }
 
+// placeHoldsReference reports whether the value stored at place can be
+// dereferenced. Places of unknown type are assumed to hold one.
+func (l *funcLowerer) placeHoldsReference(place Place) bool {
+	ty, ok := l.placeType(place)
+	if !ok {
+		return true
+	}
+	_, ok = l.derefPlaceType(ty)
+	return ok
+}
+
 func (l *funcLowerer) placeType(place Place) (types.TypeID, bool) {
 	if l == nil || l.types == nil || !place.IsValid() {
 		return types.NoTypeID, false
@@ -145,6 +145,9 @@ func buildTagLayouts(m *Module, src *hir.Module, typesIn *types.Interner) (tagLa
 			visitType(rv.Cast.TargetTy)
 		case RValueStructLit:
 			visitType(rv.StructLit.TypeID)
+			if rv.StructLit.HasBase {
+				visitOperand(&rv.StructLit.Base)
+			}
 			for i := range rv.StructLit.Fields {
 				visitOperand(&rv.StructLit.Fields[i].Value)
 			}
@@ -204,6 +207,12 @@ func buildTagLayouts(m *Module, src *hir.Module, typesIn *types.Interner) (tagLa
 					for fi := range ins.Blocking.State.Fields {
 						visitOperand(&ins.Blocking.State.Fields[fi].Value)
 					}
+				case InstrParallel:
+					for fi := range ins.Parallel.State.Fields {
+						visitOperand(&ins.Parallel.State.Fields[fi].Value)
+					}
+					visitOperand(&ins.Parallel.Iterable)
+					visitOperand(&ins.Parallel.Init)
 				case InstrPoll:
 					visitOperand(&ins.Poll.Task)
 				case InstrJoinAll:
@@ -383,6 +392,47 @@ func buildTagAliases(mm *mono.MonoModule) map[symbols.SymbolID]symbols.SymbolID
 	return out
 }
 
+// buildCloneFuncs maps receiver types to their user-defined __clone methods.
+// Only methods declared in an extern block count; a free function named
+// __clone that happens to return the same type is not a clone.
+func buildCloneFuncs(m *Module, mm *mono.MonoModule, typesIn *types.Interner) map[types.TypeID]FuncID {
+	if m == nil || mm == nil || typesIn == nil || mm.Source == nil || mm.Source.Symbols == nil || mm.Source.Symbols.Table == nil {
+		return nil
+	}
+	table := mm.Source.Symbols.Table
+	if table.Symbols == nil || table.Strings == nil {
+		return nil
+	}
+	out := make(map[types.TypeID]FuncID)
+	for id, f := range m.Funcs {
+		if f == nil || f.ParamCount != 1 || len(f.Locals) == 0 || !f.Sym.IsValid() {
+			continue
+		}
+		sym := f.Sym
+		if mf := mm.FuncBySym[sym]; mf != nil && mf.OrigSym.IsValid() {
+			sym = mf.OrigSym
+		}
+		entry := table.Symbols.Get(sym)
+		if entry == nil || entry.Kind != symbols.SymbolFunction || entry.ReceiverKey == "" {
+			continue
+		}
+		if name, ok := table.Strings.Lookup(entry.Name); !ok || name != "__clone" {
+			continue
+		}
+		recv := resolveAlias(typesIn, f.Locals[0].Type)
+		if tt, ok := typesIn.Lookup(recv); ok && tt.Kind == types.KindReference {
+			recv = resolveAlias(typesIn, tt.Elem)
+		}
+		if prev, ok := out[recv]; !ok || id < prev {
+			out[recv] = id
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
 func buildConstMap(src *hir.Module) map[symbols.SymbolID]*hir.ConstDecl {
 	if src == nil || len(src.Consts) == 0 {
 		return nil
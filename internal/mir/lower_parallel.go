@@ -0,0 +1,180 @@
+package mir
+
+import (
+	"fmt"
+
+	"surge/internal/ast"
+	"surge/internal/hir"
+	"surge/internal/source"
+	"surge/internal/symbols"
+	"surge/internal/types"
+)
+
+// lowerParallelExpr lowers parallel map/reduce. Without a parallel runtime, or
+// when the iterable is not a dynamic array, the sequential fallback built by
+// hir.NormalizeModule is lowered instead.
+func (l *funcLowerer) lowerParallelExpr(e *hir.Expr, consume bool) (Operand, error) {
+	data, ok := e.Data.(hir.ParallelData)
+	if !ok {
+		return Operand{}, fmt.Errorf("mir: parallel: unexpected payload %T", e.Data)
+	}
+	if !l.opts.ParallelRuntime || !l.parallelRuntimeSupports(&data) {
+		if data.Seq == nil {
+			return Operand{}, fmt.Errorf("mir: parallel: missing sequential lowering")
+		}
+		return l.lowerBlockExpr(e, hir.BlockExprData{Block: data.Seq}, consume)
+	}
+
+	funcID := l.allocFuncID()
+	if funcID == NoFuncID {
+		return Operand{}, fmt.Errorf("mir: parallel: failed to allocate function id")
+	}
+	name := fmt.Sprintf("__parallel_map$%d", funcID)
+	result := types.NoTypeID
+	if data.Kind == ast.ExprParallelReduce {
+		name = fmt.Sprintf("__parallel_reduce$%d", funcID)
+		result = e.Type
+	} else if elem, ok := l.dynamicArrayElem(e.Type); ok {
+		result = elem
+	} else {
+		return Operand{}, fmt.Errorf("mir: parallel map: expected array type, got %v", e.Type)
+	}
+	captures, err := l.blockingCaptureInfo(data.Captures)
+	if err != nil {
+		return Operand{}, err
+	}
+	stateType, err := buildBlockingStateStruct(l.types, name, captures)
+	if err != nil {
+		return Operand{}, err
+	}
+	fl := l.forkLowerer()
+	if fl == nil {
+		return Operand{}, fmt.Errorf("mir: parallel: failed to fork lowerer")
+	}
+	fn, err := fl.lowerParallelFunc(funcID, name, &data, result, stateType, captures, e.Span)
+	if err != nil {
+		return Operand{}, err
+	}
+	if l.out != nil {
+		l.out.Funcs[funcID] = fn
+	}
+
+	stateLit, err := l.blockingStateLiteral(stateType, captures)
+	if err != nil {
+		return Operand{}, err
+	}
+	// Workers only read captures, so the caller keeps ownership.
+	for i := range stateLit.Fields {
+		if stateLit.Fields[i].Value.Kind == OperandMove {
+			stateLit.Fields[i].Value.Kind = OperandCopy
+		}
+	}
+	iterable, err := l.lowerExpr(data.Iterable, false)
+	if err != nil {
+		return Operand{}, err
+	}
+	ins := ParallelInstr{
+		Kind:     data.Kind,
+		FuncID:   funcID,
+		State:    stateLit,
+		Iterable: iterable,
+	}
+	if data.Kind == ast.ExprParallelReduce {
+		ins.Init, err = l.lowerExpr(data.Init, true)
+		if err != nil {
+			return Operand{}, err
+		}
+	}
+	tmp := l.newTemp(e.Type, "parallel", e.Span)
+	ins.Dst = Place{Local: tmp}
+	l.emit(&Instr{Kind: InstrParallel, Parallel: ins})
+	return l.placeOperand(Place{Local: tmp}, e.Type, consume), nil
+}
+
+// parallelRuntimeSupports reports whether the runtime helpers can iterate the
+// operand directly: a dynamic array whose element type is the bound argument.
+func (l *funcLowerer) parallelRuntimeSupports(data *hir.ParallelData) bool {
+	if data == nil || data.Iterable == nil || data.Body == nil {
+		return false
+	}
+	want := 1
+	if data.Kind == ast.ExprParallelReduce {
+		want = 2
+		if data.Init == nil {
+			return false
+		}
+	}
+	if len(data.Args) != want {
+		return false
+	}
+	elem, ok := l.dynamicArrayElem(data.Iterable.Type)
+	return ok && elem == data.Args[want-1].Type
+}
+
+func (l *funcLowerer) dynamicArrayElem(id types.TypeID) (types.TypeID, bool) {
+	if l == nil || l.types == nil || id == types.NoTypeID {
+		return types.NoTypeID, false
+	}
+	id = resolveAlias(l.types, id)
+	if elem, ok := l.types.ArrayInfo(id); ok {
+		return elem, true
+	}
+	tt, ok := l.types.Lookup(id)
+	if !ok || tt.Kind != types.KindArray || tt.Count != types.ArrayDynamicLength {
+		return types.NoTypeID, false
+	}
+	return tt.Elem, true
+}
+
+// lowerParallelFunc builds the per-element function of a parallel expression:
+// fn(state, x) -> U for map and fn(state, acc, x) -> A for reduce.
+func (l *funcLowerer) lowerParallelFunc(id FuncID, name string, data *hir.ParallelData, result, stateType types.TypeID, captures []blockingCaptureInfo, span source.Span) (*Func, error) {
+	if l == nil {
+		return nil, nil
+	}
+	l.f = &Func{
+		ID:         id,
+		Sym:        symbols.NoSymbolID,
+		Name:       name,
+		Span:       span,
+		Result:     result,
+		ParamCount: 1 + len(data.Args),
+	}
+
+	stateLocal := addLocal(l.f, "__state", stateType, localFlagsFor(l.types, l.sema, stateType))
+	for _, arg := range data.Args {
+		if arg.SymbolID.IsValid() {
+			l.ensureLocal(arg.SymbolID, arg.Name, arg.Type, span)
+			continue
+		}
+		addLocal(l.f, "_", arg.Type, l.localFlags(arg.Type))
+	}
+	entry := l.newBlock()
+	l.f.Entry = entry
+	l.cur = entry
+
+	for _, cap := range captures {
+		localID := l.ensureLocal(cap.SymbolID, cap.Name, cap.Type, span)
+		l.emit(&Instr{Kind: InstrAssign, Assign: AssignInstr{
+			Dst: Place{Local: localID},
+			Src: RValue{Kind: RValueField, Field: FieldAccess{
+				Object:    Operand{Kind: OperandCopy, Place: Place{Local: stateLocal}},
+				FieldName: cap.FieldName,
+			}},
+		}})
+	}
+
+	value, err := l.lowerExpr(data.Body, true)
+	if err != nil {
+		return nil, err
+	}
+	if !l.curBlock().Terminated() {
+		l.setTerm(&Terminator{Kind: TermReturn, Return: ReturnTerm{HasValue: true, Value: value}})
+	}
+	for i := range l.f.Blocks {
+		if l.f.Blocks[i].Term.Kind == TermNone {
+			l.f.Blocks[i].Term.Kind = TermUnreachable
+		}
+	}
+	return l.f, nil
+}
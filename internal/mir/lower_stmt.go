@@ -207,18 +207,20 @@ func (l *funcLowerer) lowerStmt(st *hir.Stmt) error {
 		return nil
 
 	case hir.StmtBreak:
-		if len(l.loopStack) == 0 {
-			return fmt.Errorf("mir: break outside of a loop")
+		data, _ := st.Data.(hir.BreakData)
+		ctx, err := l.loopTarget("break", data.Label)
+		if err != nil {
+			return err
 		}
-		ctx := l.loopStack[len(l.loopStack)-1]
 		l.setTerm(&Terminator{Kind: TermGoto, Goto: GotoTerm{Target: ctx.breakTarget}})
 		return nil
 
 	case hir.StmtContinue:
-		if len(l.loopStack) == 0 {
-			return fmt.Errorf("mir: continue outside of a loop")
+		data, _ := st.Data.(hir.ContinueData)
+		ctx, err := l.loopTarget("continue", data.Label)
+		if err != nil {
+			return err
 		}
-		ctx := l.loopStack[len(l.loopStack)-1]
 		l.setTerm(&Terminator{Kind: TermGoto, Goto: GotoTerm{Target: ctx.continueTarget}})
 		return nil
 
@@ -292,7 +294,7 @@ func (l *funcLowerer) lowerStmt(st *hir.Stmt) error {
 		})
 
 		l.startBlock(bodyBB)
-		l.loopStack = append(l.loopStack, loopCtx{breakTarget: exitBB, continueTarget: headerBB})
+		l.loopStack = append(l.loopStack, loopCtx{label: data.Label, breakTarget: exitBB, continueTarget: headerBB})
 		if err := l.lowerBlock(data.Body); err != nil {
 			return err
 		}
@@ -434,3 +436,20 @@ func (l *funcLowerer) lowerLetPattern(span source.Span, data hir.LetData) error
 
 	return nil
 }
+
+// loopTarget находит цикл, к которому относится break/continue:
+// ближайший для jump без метки, иначе ближайший с совпадающей меткой.
+func (l *funcLowerer) loopTarget(kw, label string) (loopCtx, error) {
+	if len(l.loopStack) == 0 {
+		return loopCtx{}, fmt.Errorf("mir: %s outside of a loop", kw)
+	}
+	if label == "" {
+		return l.loopStack[len(l.loopStack)-1], nil
+	}
+	for i := len(l.loopStack) - 1; i >= 0; i-- {
+		if l.loopStack[i].label == label {
+			return l.loopStack[i], nil
+		}
+	}
+	return loopCtx{}, fmt.Errorf("mir: %s: unknown loop label %q", kw, label)
+}
@@ -0,0 +1,67 @@
+package mir
+
+import "reflect"
+
+// MergeIdenticalBlocks folds structurally identical blocks of a function into one.
+// Transformations:
+// 1. Find blocks with the same instructions and the same terminator (targets
+// included); the block with the lowest id becomes the canonical copy
+// 2. Redirect every edge to a duplicate, including the ready/pending edges of
+// suspending instructions, to the canonical block
+// 3. Drop the now unreachable duplicates and renumber the rest densely
+//
+// Merging two blocks can make their predecessors identical (two compare arms
+// that assign the same value and jump to the same join), so the pass repeats
+// until nothing changes. MIR has no phi nodes, so the merged block needs no
+// fixup for its new predecessors.
+func MergeIdenticalBlocks(f *Func) {
+	if f == nil || len(f.Blocks) < 2 {
+		return
+	}
+	for {
+		redirects := identicalBlockRedirects(f)
+		if len(redirects) == 0 {
+			return
+		}
+		applyRedirects(f, redirects)
+		compactBlocks(f, computeReachability(f))
+	}
+}
+
+// blockShape is a cheap bucket key: only blocks with equal shapes are compared in full.
+type blockShape struct {
+	instrs int
+	term   TermKind
+}
+
+// identicalBlockRedirects maps every duplicate block to the first block with the same body.
+func identicalBlockRedirects(f *Func) map[BlockID]BlockID {
+	buckets := make(map[blockShape][]int)
+	redirects := make(map[BlockID]BlockID)
+	for i := range f.Blocks {
+		bb := &f.Blocks[i]
+		if bb.Term.Kind == TermNone {
+			continue
+		}
+		shape := blockShape{instrs: len(bb.Instrs), term: bb.Term.Kind}
+		merged := false
+		for _, j := range buckets[shape] {
+			if sameBlockBody(&f.Blocks[j], bb) {
+				redirects[bb.ID] = f.Blocks[j].ID
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			buckets[shape] = append(buckets[shape], i)
+		}
+	}
+	return redirects
+}
+
+// sameBlockBody reports whether a and b execute the same instructions and leave
+// through the same terminator. A block that jumps to itself never equals another
+// block, because their targets differ.
+func sameBlockBody(a, b *Block) bool {
+	return reflect.DeepEqual(a.Instrs, b.Instrs) && reflect.DeepEqual(a.Term, b.Term)
+}
@@ -19,6 +19,9 @@ type ModuleMeta struct {
 	// FuncTypeArgs maps instantiated symbols to their concrete type arguments.
 	// This is used by intrinsic implementations like size_of/align_of.
 	FuncTypeArgs map[symbols.SymbolID][]types.TypeID
+
+	// CloneFuncs maps a receiver type to its user-defined __clone method.
+	CloneFuncs map[types.TypeID]FuncID
 }
 
 // TagCaseMeta holds metadata for a tag case.
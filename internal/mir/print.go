@@ -1,11 +1,13 @@
 package mir
 
 import (
+	"cmp"
 	"fmt"
 	"io"
 	"slices"
 	"strings"
 
+	"surge/internal/ast"
 	"surge/internal/types"
 )
 
@@ -86,8 +88,13 @@ func dumpFunc(w io.Writer, f *Func, typesIn *types.Interner) error {
 		}
 	}
 
+	// Print blocks in ID order so the dump stays stable when a pass reorders f.Blocks.
+	blocks := make([]*Block, 0, len(f.Blocks))
 	for i := range f.Blocks {
-		bb := &f.Blocks[i]
+		blocks = append(blocks, &f.Blocks[i])
+	}
+	slices.SortStableFunc(blocks, func(a, b *Block) int { return cmp.Compare(a.ID, b.ID) })
+	for _, bb := range blocks {
 		fmt.Fprintf(w, "  bb%d:\n", bb.ID) //nolint:errcheck
 		for j := range bb.Instrs {
 			ins := &bb.Instrs[j]
@@ -159,6 +166,14 @@ func formatInstr(typesIn *types.Interner, ins *Instr) string {
 		return fmt.Sprintf("%s = spawn %s", formatPlace(ins.Spawn.Dst), formatOperand(&ins.Spawn.Value))
 	case InstrBlocking:
 		return fmt.Sprintf("%s = blocking fn.%d", formatPlace(ins.Blocking.Dst), ins.Blocking.FuncID)
+	case InstrParallel:
+		if ins.Parallel.Kind == ast.ExprParallelReduce {
+			return fmt.Sprintf("%s = parallel reduce fn.%d(%s, %s)",
+				formatPlace(ins.Parallel.Dst), ins.Parallel.FuncID,
+				formatOperand(&ins.Parallel.Iterable), formatOperand(&ins.Parallel.Init))
+		}
+		return fmt.Sprintf("%s = parallel map fn.%d(%s)",
+			formatPlace(ins.Parallel.Dst), ins.Parallel.FuncID, formatOperand(&ins.Parallel.Iterable))
 	case InstrPoll:
 		return fmt.Sprintf("%s = poll %s ? bb%d : bb%d",
 			formatPlace(ins.Poll.Dst),
@@ -401,6 +416,12 @@ func formatRValue(typesIn *types.Interner, rv *RValue) string {
 		return fmt.Sprintf("cast %s to %s", formatOperand(&rv.Cast.Value), typeStr(typesIn, rv.Cast.TargetTy))
 	case RValueStructLit:
 		out := fmt.Sprintf("struct_lit %s {", typeStr(typesIn, rv.StructLit.TypeID))
+		if rv.StructLit.HasBase {
+			out += ".." + formatOperand(&rv.StructLit.Base)
+			if len(rv.StructLit.Fields) > 0 {
+				out += ", "
+			}
+		}
 		for i := range rv.StructLit.Fields {
 			if i > 0 {
 				out += ", "
@@ -477,6 +498,9 @@ func typeStr(typesIn *types.Interner, id types.TypeID) string {
 	case types.KindInt:
 		return formatIntType(t.Width, true)
 	case types.KindUint:
+		if t.IsChar() {
+			return "char"
+		}
 		return formatIntType(t.Width, false)
 	case types.KindFloat:
 		return formatFloatType(t.Width)
@@ -0,0 +1,86 @@
+package mir_test
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/mir"
+	"surge/internal/types"
+)
+
+// The dump format itself is covered by testdata/golden/mir/dump_small_func.mir
+// (make golden-check); this test pins the block order independently of storage order.
+func TestDumpModuleFollowsBlockIDs(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+
+	intConst := func(v int64) mir.Operand {
+		return mir.Operand{Kind: mir.OperandConst, Const: mir.Const{Kind: mir.ConstInt, IntValue: v}}
+	}
+	local := func(id mir.LocalID) mir.Place { return mir.Place{Local: id} }
+
+	f := &mir.Func{
+		ID:    1,
+		Name:  "answer",
+		Entry: 0,
+		Locals: []mir.Local{
+			{Name: "x", Type: b.Int, Flags: mir.LocalFlagCopy},
+			{Name: "s", Type: b.String, Flags: mir.LocalFlagOwn},
+			{Type: b.Int, Flags: mir.LocalFlagCopy},
+		},
+		// Blocks are deliberately out of order: the dump must follow block IDs.
+		Blocks: []mir.Block{
+			{
+				ID: 1,
+				Instrs: []mir.Instr{
+					{Kind: mir.InstrDrop, Drop: mir.DropInstr{Place: local(1)}},
+				},
+				Term: mir.Terminator{
+					Kind:   mir.TermReturn,
+					Return: mir.ReturnTerm{HasValue: true, Value: mir.Operand{Kind: mir.OperandCopy, Place: local(2)}},
+				},
+			},
+			{
+				ID: 0,
+				Instrs: []mir.Instr{
+					{
+						Kind: mir.InstrAssign,
+						Assign: mir.AssignInstr{
+							Dst: local(0),
+							Src: mir.RValue{Kind: mir.RValueUse, Use: intConst(41)},
+						},
+					},
+					{
+						Kind: mir.InstrCall,
+						Call: mir.CallInstr{
+							HasDst: true,
+							Dst:    local(2),
+							Callee: mir.Callee{Kind: mir.CalleeSym, Name: "inc"},
+							Args:   []mir.Operand{{Kind: mir.OperandCopy, Place: local(0)}},
+						},
+					},
+				},
+				Term: mir.Terminator{Kind: mir.TermGoto, Goto: mir.GotoTerm{Target: 1}},
+			},
+		},
+	}
+	dump := func(f *mir.Func) string {
+		t.Helper()
+		var out strings.Builder
+		mod := &mir.Module{Funcs: map[mir.FuncID]*mir.Func{f.ID: f}}
+		if err := mir.DumpModule(&out, mod, typeInterner, mir.DumpOptions{}); err != nil {
+			t.Fatalf("dump: %v", err)
+		}
+		return out.String()
+	}
+
+	sorted := *f
+	sorted.Blocks = []mir.Block{f.Blocks[1], f.Blocks[0]}
+	got, want := dump(f), dump(&sorted)
+	if got != want {
+		t.Fatalf("dump depends on block storage order:\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+	if strings.Index(got, "  bb0:") > strings.Index(got, "  bb1:") {
+		t.Fatalf("expected bb0 before bb1:\n%s", got)
+	}
+}
@@ -78,11 +78,8 @@ func validateBlocksTerminated(f *Func) error {
 	var errs []error
 	for i := range f.Blocks {
 		if f.Blocks[i].Term.Kind == TermNone {
-			if len(f.Blocks[i].Instrs) > 0 {
-				last := f.Blocks[i].Instrs[len(f.Blocks[i].Instrs)-1]
-				if last.Kind == InstrPoll || last.Kind == InstrJoinAll || last.Kind == InstrChanSend || last.Kind == InstrChanRecv || last.Kind == InstrNetWait || last.Kind == InstrTimeout || last.Kind == InstrSelect {
-					continue
-				}
+			if endsWithSuspend(&f.Blocks[i]) {
+				continue
 			}
 			errs = append(errs, fmt.Errorf("bb%d: unterminated block", i))
 		}
@@ -237,6 +234,9 @@ func validateLocalIDs(f *Func, globals []Global) error {
 		case RValueCast:
 			checkOperand(rv.Cast.Value, context)
 		case RValueStructLit:
+			if rv.StructLit.HasBase {
+				checkOperand(rv.StructLit.Base, context)
+			}
 			for i := range rv.StructLit.Fields {
 				checkOperand(rv.StructLit.Fields[i].Value, context)
 			}
@@ -303,6 +303,13 @@ func validateLocalIDs(f *Func, globals []Global) error {
 				for i := range ins.Blocking.State.Fields {
 					checkOperand(ins.Blocking.State.Fields[i].Value, ctx)
 				}
+			case InstrParallel:
+				checkPlace(ins.Parallel.Dst, ctx)
+				for i := range ins.Parallel.State.Fields {
+					checkOperand(ins.Parallel.State.Fields[i].Value, ctx)
+				}
+				checkOperand(ins.Parallel.Iterable, ctx)
+				checkOperand(ins.Parallel.Init, ctx)
 			case InstrPoll:
 				checkPlace(ins.Poll.Dst, ctx)
 				checkOperand(ins.Poll.Task, ctx)
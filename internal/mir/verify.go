@@ -0,0 +1,355 @@
+package mir
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"surge/internal/types"
+)
+
+// VerifyError describes one malformed construct found by Verify.
+// Block is NoBlockID for function-level problems; Instr is -1 for
+// terminators and function-level problems.
+type VerifyError struct {
+	Func  FuncID
+	Name  string
+	Block BlockID
+	Instr int
+	Msg   string
+}
+
+func (e *VerifyError) Error() string {
+	switch {
+	case e.Block == NoBlockID:
+		return fmt.Sprintf("fn %s: %s", e.Name, e.Msg)
+	case e.Instr < 0:
+		return fmt.Sprintf("fn %s bb%d terminator: %s", e.Name, e.Block, e.Msg)
+	default:
+		return fmt.Sprintf("fn %s bb%d instr %d: %s", e.Name, e.Block, e.Instr, e.Msg)
+	}
+}
+
+// VerifyErrors is the error returned by Verify.
+type VerifyErrors []*VerifyError
+
+func (es VerifyErrors) Error() string {
+	lines := make([]string, 0, len(es))
+	for _, e := range es {
+		lines = append(lines, e.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Verify is a debugging pass run before codegen. On top of the structural
+// invariants checked by Validate it looks across functions and at types:
+// every block is terminated, branch targets and local/global ids are in
+// range, calls to module functions pass a consistent number of arguments and
+// only bind a destination when the callee returns a value, and place
+// projections match the type they project from.
+// It returns nil or a VerifyErrors value ordered by function, block and instruction.
+func Verify(m *Module, typesIn *types.Interner) error {
+	if m == nil {
+		return nil
+	}
+	v := &verifier{
+		mod:      m,
+		types:    typesIn,
+		byName:   make(map[string]FuncID, len(m.Funcs)),
+		argCount: make(map[FuncID]int),
+	}
+	ids := make([]FuncID, 0, len(m.Funcs))
+	for id, f := range m.Funcs {
+		if f == nil {
+			continue
+		}
+		ids = append(ids, id)
+		if f.Name == "" {
+			continue
+		}
+		// Overloads share a name; only unique names can identify a callee.
+		if _, dup := v.byName[f.Name]; dup {
+			v.byName[f.Name] = NoFuncID
+		} else {
+			v.byName[f.Name] = id
+		}
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		v.verifyFunc(id, m.Funcs[id])
+	}
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+type verifier struct {
+	mod      *Module
+	types    *types.Interner
+	byName   map[string]FuncID
+	argCount map[FuncID]int // first argument count seen for each callee
+	errs     VerifyErrors
+
+	fid   FuncID
+	f     *Func
+	block BlockID
+	instr int
+}
+
+func (v *verifier) errorf(format string, args ...any) {
+	v.errs = append(v.errs, &VerifyError{
+		Func:  v.fid,
+		Name:  v.f.Name,
+		Block: v.block,
+		Instr: v.instr,
+		Msg:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *verifier) verifyFunc(id FuncID, f *Func) {
+	v.fid, v.f = id, f
+	v.block, v.instr = NoBlockID, -1
+	if f.Entry != NoBlockID && !v.blockExists(f.Entry) {
+		v.errorf("entry block bb%d does not exist", f.Entry)
+	}
+	if f.ParamCount > len(f.Locals) {
+		v.errorf("%d params but only %d locals", f.ParamCount, len(f.Locals))
+	}
+	for bi := range f.Blocks {
+		bb := &f.Blocks[bi]
+		v.block = BlockID(bi) //nolint:gosec // bounded by blocks length
+		if bb.ID != v.block {
+			v.instr = -1
+			v.errorf("block id bb%d does not match its index", bb.ID)
+		}
+		for ii := range bb.Instrs {
+			v.instr = ii
+			ins := &bb.Instrs[ii]
+			visitInstrTargets(ins, v.checkTarget)
+			visitInstrPlaces(ins, func(p Place, _ LocalAccess) { v.checkPlace(p) })
+			if ins.Kind == InstrCall {
+				v.checkCall(&ins.Call)
+			}
+		}
+		v.instr = -1
+		if bb.Term.Kind == TermNone && !endsWithSuspend(bb) {
+			v.errorf("unterminated block")
+		}
+		visitTermTargets(&bb.Term, v.checkTarget)
+		visitTermPlaces(&bb.Term, func(p Place, _ LocalAccess) { v.checkPlace(p) })
+	}
+}
+
+func (v *verifier) blockExists(id BlockID) bool {
+	return id >= 0 && int(id) < len(v.f.Blocks)
+}
+
+func (v *verifier) checkTarget(what string, id BlockID) {
+	if !v.blockExists(id) {
+		v.errorf("%s target bb%d does not exist", what, id)
+	}
+}
+
+// checkPlace reports out-of-range ids and projections that do not fit the
+// type they are applied to. Types are only checked when the interner is known.
+func (v *verifier) checkPlace(p Place) {
+	cur := types.NoTypeID
+	switch p.Kind {
+	case PlaceGlobal:
+		if p.Global == NoGlobalID {
+			return
+		}
+		if p.Global < 0 || int(p.Global) >= len(v.mod.Globals) {
+			v.errorf("global G%d does not exist", p.Global)
+			return
+		}
+		cur = v.mod.Globals[p.Global].Type
+	default:
+		if p.Local == NoLocalID {
+			return
+		}
+		if p.Local < 0 || int(p.Local) >= len(v.f.Locals) {
+			v.errorf("local L%d does not exist", p.Local)
+			return
+		}
+		cur = v.f.Locals[p.Local].Type
+	}
+	for _, proj := range p.Proj {
+		if proj.Kind == PlaceProjIndex && proj.IndexLocal != NoLocalID &&
+			(proj.IndexLocal < 0 || int(proj.IndexLocal) >= len(v.f.Locals)) {
+			v.errorf("index local L%d does not exist", proj.IndexLocal)
+			return
+		}
+		if v.types == nil || cur == types.NoTypeID {
+			continue
+		}
+		next, ok := v.projectType(cur, proj)
+		if !ok {
+			v.errorf("%s projection on %s in %s", projKindLabel(proj), types.Label(v.types, cur), formatPlace(p))
+			return
+		}
+		cur = next
+	}
+}
+
+func (v *verifier) projectType(cur types.TypeID, proj PlaceProj) (types.TypeID, bool) {
+	switch proj.Kind {
+	case PlaceProjDeref:
+		tt, ok := v.types.Lookup(resolveAlias(v.types, cur))
+		if !ok {
+			return types.NoTypeID, false
+		}
+		switch tt.Kind {
+		case types.KindOwn, types.KindPointer, types.KindReference:
+			return tt.Elem, true
+		}
+		return types.NoTypeID, false
+	case PlaceProjField:
+		// Backends look through references when projecting a field.
+		id := resolveAliasType(v.types, cur)
+		for tt, ok := v.types.Lookup(id); ok && (tt.Kind == types.KindReference || tt.Kind == types.KindPointer); tt, ok = v.types.Lookup(id) {
+			id = resolveAliasType(v.types, tt.Elem)
+		}
+		if info, ok := v.types.StructInfo(id); ok && info != nil {
+			if proj.FieldIdx >= 0 && proj.FieldIdx < len(info.Fields) {
+				return info.Fields[proj.FieldIdx].Type, true
+			}
+			if proj.FieldName != "" && v.types.Strings != nil {
+				for _, field := range info.Fields {
+					if name, ok := v.types.Strings.Lookup(field.Name); ok && name == proj.FieldName {
+						return field.Type, true
+					}
+				}
+			}
+			return types.NoTypeID, false
+		}
+		if info, ok := v.types.TupleInfo(id); ok && info != nil {
+			if proj.FieldIdx >= 0 && proj.FieldIdx < len(info.Elems) {
+				return info.Elems[proj.FieldIdx], true
+			}
+		}
+		return types.NoTypeID, false
+	case PlaceProjIndex:
+		id := resolveAliasType(v.types, cur)
+		if elem, ok := v.types.ArrayInfo(id); ok {
+			return elem, true
+		}
+		if elem, _, ok := v.types.ArrayFixedInfo(id); ok {
+			return elem, true
+		}
+		if tt, ok := v.types.Lookup(id); ok && tt.Kind == types.KindArray {
+			return tt.Elem, true
+		}
+		return types.NoTypeID, false
+	}
+	return types.NoTypeID, false
+}
+
+// checkCall generalizes the backend's per-callee argument count bookkeeping:
+// every call to a module function must pass the same number of arguments,
+// no more than the callee declares, and a destination is only allowed
+// when the callee returns a value.
+func (v *verifier) checkCall(call *CallInstr) {
+	if call.Callee.Kind != CalleeSym {
+		return
+	}
+	targetID := NoFuncID
+	if call.Callee.Sym.IsValid() {
+		if id, ok := v.mod.FuncBySym[call.Callee.Sym]; ok {
+			targetID = id
+		}
+	} else if call.Callee.Name != "" {
+		if id, ok := v.byName[call.Callee.Name]; ok {
+			targetID = id
+		}
+	}
+	target := v.mod.Funcs[targetID]
+	if target == nil {
+		return
+	}
+	argCount := len(call.Args)
+	if prev, ok := v.argCount[targetID]; ok && prev != argCount {
+		v.errorf("%s called with %d and %d args", target.Name, prev, argCount)
+	} else if !ok {
+		v.argCount[targetID] = argCount
+	}
+	// Fewer arguments are allowed: marker parameters such as the target of
+	// __to are dropped at call sites.
+	if target.ParamCount > 0 && argCount > target.ParamCount {
+		v.errorf("%s takes %d args, got %d", target.Name, target.ParamCount, argCount)
+	}
+	if call.HasDst && isNothingType(v.types, target.Result) {
+		v.errorf("call has destination but %s returns nothing", target.Name)
+	}
+}
+
+func projKindLabel(proj PlaceProj) string {
+	switch proj.Kind {
+	case PlaceProjDeref:
+		return "deref"
+	case PlaceProjField:
+		if proj.FieldName != "" {
+			return "field ." + proj.FieldName
+		}
+		return fmt.Sprintf("field .#%d", proj.FieldIdx)
+	case PlaceProjIndex:
+		return "index"
+	default:
+		return "unknown"
+	}
+}
+
+// endsWithSuspend reports whether bb ends in an instruction that carries its
+// own ready/pending targets and therefore needs no terminator.
+func endsWithSuspend(bb *Block) bool {
+	if len(bb.Instrs) == 0 {
+		return false
+	}
+	switch bb.Instrs[len(bb.Instrs)-1].Kind {
+	case InstrPoll, InstrJoinAll, InstrChanSend, InstrChanRecv, InstrNetWait, InstrTimeout, InstrSelect:
+		return true
+	}
+	return false
+}
+
+func visitInstrTargets(ins *Instr, fn func(string, BlockID)) {
+	switch ins.Kind {
+	case InstrPoll:
+		fn("poll ready", ins.Poll.ReadyBB)
+		fn("poll pending", ins.Poll.PendBB)
+	case InstrJoinAll:
+		fn("join_all ready", ins.JoinAll.ReadyBB)
+		fn("join_all pending", ins.JoinAll.PendBB)
+	case InstrChanSend:
+		fn("chan_send ready", ins.ChanSend.ReadyBB)
+		fn("chan_send pending", ins.ChanSend.PendBB)
+	case InstrChanRecv:
+		fn("chan_recv ready", ins.ChanRecv.ReadyBB)
+		fn("chan_recv pending", ins.ChanRecv.PendBB)
+	case InstrNetWait:
+		fn("net_wait ready", ins.NetWait.ReadyBB)
+		fn("net_wait pending", ins.NetWait.PendBB)
+	case InstrTimeout:
+		fn("timeout ready", ins.Timeout.ReadyBB)
+		fn("timeout pending", ins.Timeout.PendBB)
+	case InstrSelect:
+		fn("select ready", ins.Select.ReadyBB)
+		fn("select pending", ins.Select.PendBB)
+	}
+}
+
+func visitTermTargets(term *Terminator, fn func(string, BlockID)) {
+	switch term.Kind {
+	case TermGoto:
+		fn("goto", term.Goto.Target)
+	case TermIf:
+		fn("if then", term.If.Then)
+		fn("if else", term.If.Else)
+	case TermSwitchTag:
+		for _, c := range term.SwitchTag.Cases {
+			fn("switch_tag case "+c.TagName, c.Target)
+		}
+		fn("switch_tag default", term.SwitchTag.Default)
+	}
+}
@@ -0,0 +1,204 @@
+package mir_test
+
+import (
+	"errors"
+	"testing"
+
+	"surge/internal/mir"
+	"surge/internal/types"
+)
+
+func verifyErrors(t *testing.T, mod *mir.Module, typesIn *types.Interner) mir.VerifyErrors {
+	t.Helper()
+	err := mir.Verify(mod, typesIn)
+	if err == nil {
+		return nil
+	}
+	var errs mir.VerifyErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected VerifyErrors, got %T: %v", err, err)
+	}
+	return errs
+}
+
+func returnTerm() mir.Terminator {
+	return mir.Terminator{Kind: mir.TermReturn}
+}
+
+func TestVerifyAcceptsWellFormedModule(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	mod := &mir.Module{
+		Funcs: map[mir.FuncID]*mir.Func{
+			0: {
+				ID:     0,
+				Name:   "main",
+				Result: b.Nothing,
+				Locals: []mir.Local{{Name: "x", Type: b.Int}},
+				Blocks: []mir.Block{
+					{
+						ID: 0,
+						Instrs: []mir.Instr{{
+							Kind: mir.InstrAssign,
+							Assign: mir.AssignInstr{
+								Dst: mir.Place{Local: 0},
+								Src: mir.RValue{Kind: mir.RValueUse, Use: mir.Operand{
+									Kind:  mir.OperandConst,
+									Const: mir.Const{Kind: mir.ConstInt, IntValue: 1},
+								}},
+							},
+						}},
+						Term: mir.Terminator{Kind: mir.TermGoto, Goto: mir.GotoTerm{Target: 1}},
+					},
+					{ID: 1, Term: returnTerm()},
+				},
+			},
+		},
+	}
+	if errs := verifyErrors(t, mod, typeInterner); len(errs) != 0 {
+		t.Fatalf("unexpected verify errors: %v", errs)
+	}
+}
+
+func TestVerifyReportsStructuralErrorsWithPositions(t *testing.T) {
+	mod := &mir.Module{
+		Funcs: map[mir.FuncID]*mir.Func{
+			3: {
+				ID:   3,
+				Name: "broken",
+				Blocks: []mir.Block{
+					{
+						ID: 0,
+						Instrs: []mir.Instr{
+							{Kind: mir.InstrDrop, Drop: mir.DropInstr{Place: mir.Place{Local: 7}}},
+							{Kind: mir.InstrDrop, Drop: mir.DropInstr{Place: mir.Place{Kind: mir.PlaceGlobal, Global: 2}}},
+						},
+						Term: mir.Terminator{Kind: mir.TermGoto, Goto: mir.GotoTerm{Target: 9}},
+					},
+					{ID: 1}, // no terminator
+				},
+			},
+		},
+	}
+	errs := verifyErrors(t, mod, nil)
+	want := []mir.VerifyError{
+		{Func: 3, Name: "broken", Block: 0, Instr: 0, Msg: "local L7 does not exist"},
+		{Func: 3, Name: "broken", Block: 0, Instr: 1, Msg: "global G2 does not exist"},
+		{Func: 3, Name: "broken", Block: 0, Instr: -1, Msg: "goto target bb9 does not exist"},
+		{Func: 3, Name: "broken", Block: 1, Instr: -1, Msg: "unterminated block"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i := range want {
+		if *errs[i] != want[i] {
+			t.Errorf("error %d: got %+v, want %+v", i, *errs[i], want[i])
+		}
+	}
+	if got := errs[0].Error(); got != "fn broken bb0 instr 0: local L7 does not exist" {
+		t.Errorf("unexpected error text %q", got)
+	}
+}
+
+func TestVerifyReportsCallMismatches(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	intArg := mir.Operand{Kind: mir.OperandConst, Const: mir.Const{Kind: mir.ConstInt, IntValue: 1}}
+	call := func(args int, hasDst bool) mir.Instr {
+		return mir.Instr{Kind: mir.InstrCall, Call: mir.CallInstr{
+			HasDst: hasDst,
+			Dst:    mir.Place{Local: 0},
+			Callee: mir.Callee{Kind: mir.CalleeSym, Name: "log"},
+			Args:   make([]mir.Operand, args),
+		}}
+	}
+	first := call(1, false)
+	first.Call.Args[0] = intArg
+	mod := &mir.Module{
+		Funcs: map[mir.FuncID]*mir.Func{
+			0: {
+				ID:     0,
+				Name:   "log",
+				Result: b.Nothing,
+				Locals: []mir.Local{{Name: "v", Type: b.Int}},
+				// Parameter count is one: a second argument is an error.
+				ParamCount: 1,
+				Blocks:     []mir.Block{{ID: 0, Term: returnTerm()}},
+			},
+			1: {
+				ID:     1,
+				Name:   "main",
+				Result: b.Nothing,
+				Locals: []mir.Local{{Name: "r", Type: b.Int}},
+				Blocks: []mir.Block{{
+					ID:     0,
+					Instrs: []mir.Instr{first, call(2, false), call(1, true)},
+					Term:   returnTerm(),
+				}},
+			},
+		},
+	}
+	errs := verifyErrors(t, mod, typeInterner)
+	want := []string{
+		"fn main bb0 instr 1: log called with 1 and 2 args",
+		"fn main bb0 instr 1: log takes 1 args, got 2",
+		"fn main bb0 instr 2: call has destination but log returns nothing",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i := range want {
+		if got := errs[i].Error(); got != want[i] {
+			t.Errorf("error %d: got %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestVerifyReportsIllTypedProjections(t *testing.T) {
+	typeInterner := types.NewInterner()
+	b := typeInterner.Builtins()
+	tuple := typeInterner.RegisterTuple([]types.TypeID{b.Int, b.Bool})
+	read := func(p mir.Place) mir.Instr {
+		return mir.Instr{Kind: mir.InstrAssign, Assign: mir.AssignInstr{
+			Dst: mir.Place{Local: 0},
+			Src: mir.RValue{Kind: mir.RValueUse, Use: mir.Operand{Kind: mir.OperandCopy, Place: p}},
+		}}
+	}
+	mod := &mir.Module{
+		Funcs: map[mir.FuncID]*mir.Func{
+			0: {
+				ID:     0,
+				Name:   "main",
+				Result: b.Nothing,
+				Locals: []mir.Local{
+					{Name: "n", Type: b.Int},
+					{Name: "pair", Type: tuple},
+				},
+				Blocks: []mir.Block{{
+					ID: 0,
+					Instrs: []mir.Instr{
+						read(mir.Place{Local: 1, Proj: []mir.PlaceProj{{Kind: mir.PlaceProjField, FieldIdx: 0}}}),
+						read(mir.Place{Local: 1, Proj: []mir.PlaceProj{{Kind: mir.PlaceProjField, FieldIdx: 5}}}),
+						read(mir.Place{Local: 0, Proj: []mir.PlaceProj{{Kind: mir.PlaceProjDeref}}}),
+						read(mir.Place{Local: 0, Proj: []mir.PlaceProj{{Kind: mir.PlaceProjIndex, IndexLocal: 0}}}),
+					},
+					Term: returnTerm(),
+				}},
+			},
+		},
+	}
+	errs := verifyErrors(t, mod, typeInterner)
+	want := []string{
+		"fn main bb0 instr 1: field .#5 projection on (int, bool) in L1.#5",
+		"fn main bb0 instr 2: deref projection on int in (*L0)",
+		"fn main bb0 instr 3: index projection on int in L0[L0]",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i := range want {
+		if got := errs[i].Error(); got != want[i] {
+			t.Errorf("error %d: got %q, want %q", i, got, want[i])
+		}
+	}
+}
@@ -236,6 +236,7 @@ func cloneExpr(e *hir.Expr) *hir.Expr {
 		if !ok {
 			break
 		}
+		data.Base = cloneExpr(data.Base)
 		if len(data.Fields) > 0 {
 			fields := make([]hir.StructFieldInit, len(data.Fields))
 			copy(fields, data.Fields)
@@ -366,6 +367,7 @@ func cloneExpr(e *hir.Expr) *hir.Expr {
 			break
 		}
 		data.Body = cloneBlock(data.Body)
+		data.Captures = append([]hir.BlockingCapture(nil), data.Captures...)
 		out.Data = data
 	case hir.ExprBlocking:
 		data, ok := e.Data.(hir.BlockingData)
@@ -375,6 +377,18 @@ func cloneExpr(e *hir.Expr) *hir.Expr {
 		data.Body = cloneBlock(data.Body)
 		data.Captures = append([]hir.BlockingCapture(nil), data.Captures...)
 		out.Data = data
+	case hir.ExprParallel:
+		data, ok := e.Data.(hir.ParallelData)
+		if !ok {
+			break
+		}
+		data.Iterable = cloneExpr(data.Iterable)
+		data.Init = cloneExpr(data.Init)
+		data.Args = append([]hir.ParallelArg(nil), data.Args...)
+		data.Body = cloneExpr(data.Body)
+		data.Captures = append([]hir.BlockingCapture(nil), data.Captures...)
+		data.Seq = cloneBlock(data.Seq)
+		out.Data = data
 	case hir.ExprCast:
 		data, ok := e.Data.(hir.CastData)
 		if !ok {
@@ -47,6 +47,10 @@ func (b *monoBuilder) rewriteCloneCall(call *hir.Expr, data *hir.CallData, stack
 		return false, nil
 	}
 	cloneSym, matchType := b.cloneSymbolForType(recvType)
+	if !cloneSym.IsValid() && b.isBuiltinClonable(recvType, nil) {
+		// Остаётся вызовом интринсика clone: VM копирует значение глубоко.
+		return false, nil
+	}
 	if !cloneSym.IsValid() {
 		typeLabel := b.typeKeyForType(recvType)
 		if typeLabel == "" {
@@ -134,6 +138,90 @@ func (b *monoBuilder) findCloneSymbol(recv types.TypeID) symbols.SymbolID {
 	return fallback
 }
 
+// isBuiltinClonable mirrors sema: a type without __clone is cloned by the clone
+// intrinsic when it is a string, array, tuple, struct or union built from Copy,
+// __clone-capable or built-in clonable members. Tasks and maps are never cloned.
+func (b *monoBuilder) isBuiltinClonable(id types.TypeID, visiting map[types.TypeID]bool) bool {
+	if id == types.NoTypeID || b.types == nil {
+		return false
+	}
+	resolved := resolveAlias(b.types, id)
+	if b.types.IsCopy(resolved) || visiting[resolved] {
+		return true
+	}
+	if sym, _ := b.cloneSymbolForType(resolved); sym.IsValid() {
+		return true
+	}
+	if b.isTaskType(resolved) {
+		return false
+	}
+	if _, _, ok := b.types.MapInfo(resolved); ok {
+		return false
+	}
+	if visiting == nil {
+		visiting = make(map[types.TypeID]bool)
+	}
+	visiting[resolved] = true
+	defer delete(visiting, resolved)
+
+	if elem, ok := b.types.ArrayInfo(resolved); ok {
+		return b.isBuiltinClonable(elem, visiting)
+	}
+	if elem, _, ok := b.types.ArrayFixedInfo(resolved); ok {
+		return b.isBuiltinClonable(elem, visiting)
+	}
+	tt, ok := b.types.Lookup(resolved)
+	if !ok {
+		return false
+	}
+	switch tt.Kind {
+	case types.KindString:
+		return true
+	case types.KindOwn, types.KindArray:
+		return b.isBuiltinClonable(tt.Elem, visiting)
+	case types.KindTuple:
+		info, ok := b.types.TupleInfo(resolved)
+		if !ok || info == nil {
+			return false
+		}
+		for _, elem := range info.Elems {
+			if !b.isBuiltinClonable(elem, visiting) {
+				return false
+			}
+		}
+		return true
+	case types.KindStruct:
+		info, ok := b.types.StructInfo(resolved)
+		if !ok || info == nil {
+			return false
+		}
+		for _, field := range info.Fields {
+			if !b.isBuiltinClonable(field.Type, visiting) {
+				return false
+			}
+		}
+		return true
+	case types.KindUnion:
+		info, ok := b.types.UnionInfo(resolved)
+		if !ok || info == nil {
+			return false
+		}
+		for _, member := range info.Members {
+			if member.Kind == types.UnionMemberType && !b.isBuiltinClonable(member.Type, visiting) {
+				return false
+			}
+			for _, arg := range member.TagArgs {
+				if !b.isBuiltinClonable(arg, visiting) {
+					return false
+				}
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
 func (b *monoBuilder) isTaskType(recv types.TypeID) bool {
 	if b == nil || b.types == nil || recv == types.NoTypeID {
 		return false
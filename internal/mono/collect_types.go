@@ -162,6 +162,7 @@ func collectTypesFromExpr(e *hir.Expr, visit func(id types.TypeID)) {
 			return
 		}
 		visit(data.TypeID)
+		collectTypesFromExpr(data.Base, visit)
 		for _, f := range data.Fields {
 			collectTypesFromExpr(f.Value, visit)
 		}
@@ -263,6 +264,18 @@ func collectTypesFromExpr(e *hir.Expr, visit func(id types.TypeID)) {
 			return
 		}
 		collectTypesFromBlock(data.Body, visit)
+	case hir.ExprParallel:
+		data, ok := e.Data.(hir.ParallelData)
+		if !ok {
+			return
+		}
+		for _, arg := range data.Args {
+			visit(arg.Type)
+		}
+		collectTypesFromExpr(data.Iterable, visit)
+		collectTypesFromExpr(data.Init, visit)
+		collectTypesFromExpr(data.Body, visit)
+		collectTypesFromBlock(data.Seq, visit)
 	case hir.ExprCast:
 		data, ok := e.Data.(hir.CastData)
 		if !ok {
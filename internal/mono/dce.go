@@ -130,6 +130,7 @@ func collectCallSyms(b *hir.Block) []symbols.SymbolID {
 			if !ok {
 				return
 			}
+			walkExpr(data.Base)
 			for _, f := range data.Fields {
 				walkExpr(f.Value)
 			}
@@ -231,6 +232,15 @@ func collectCallSyms(b *hir.Block) []symbols.SymbolID {
 				return
 			}
 			walkBlock(data.Body)
+		case hir.ExprParallel:
+			data, ok := e.Data.(hir.ParallelData)
+			if !ok {
+				return
+			}
+			walkExpr(data.Iterable)
+			walkExpr(data.Init)
+			walkExpr(data.Body)
+			walkBlock(data.Seq)
 		case hir.ExprCast:
 			data, ok := e.Data.(hir.CastData)
 			if !ok {
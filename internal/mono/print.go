@@ -194,6 +194,9 @@ func formatType(typesIn *types.Interner, strs *source.Interner, id types.TypeID,
 	case types.KindInt:
 		return formatIntType(tt.Width, true)
 	case types.KindUint:
+		if tt.IsChar() {
+			return "char"
+		}
 		return formatIntType(tt.Width, false)
 	case types.KindFloat:
 		return formatFloatType(tt.Width)
@@ -247,6 +247,11 @@ func (s *Subst) ApplyExpr(e *hir.Expr) error {
 			return nil
 		}
 		data.TypeID = s.Type(data.TypeID)
+		if data.Base != nil {
+			if err := s.ApplyExpr(data.Base); err != nil {
+				return err
+			}
+		}
 		for i := range data.Fields {
 			if data.Fields[i].Value != nil {
 				if err := s.ApplyExpr(data.Fields[i].Value); err != nil {
@@ -435,6 +440,26 @@ func (s *Subst) ApplyExpr(e *hir.Expr) error {
 			return err
 		}
 		e.Data = data
+	case hir.ExprParallel:
+		data, ok := e.Data.(hir.ParallelData)
+		if !ok {
+			return nil
+		}
+		for i := range data.Args {
+			data.Args[i].Type = s.Type(data.Args[i].Type)
+		}
+		for _, part := range []*hir.Expr{data.Iterable, data.Init, data.Body} {
+			if part == nil {
+				continue
+			}
+			if err := s.ApplyExpr(part); err != nil {
+				return err
+			}
+		}
+		if err := s.ApplyBlock(data.Seq); err != nil {
+			return err
+		}
+		e.Data = data
 	case hir.ExprCast:
 		data, ok := e.Data.(hir.CastData)
 		if !ok {
@@ -217,6 +217,9 @@ func rewriteCallsInExpr(e *hir.Expr, f callRewriteFunc) error {
 		if !ok {
 			return nil
 		}
+		if err := rewriteCallsInExpr(data.Base, f); err != nil {
+			return err
+		}
 		for i := range data.Fields {
 			if err := rewriteCallsInExpr(data.Fields[i].Value, f); err != nil {
 				return err
@@ -375,6 +378,20 @@ func rewriteCallsInExpr(e *hir.Expr, f callRewriteFunc) error {
 			return err
 		}
 		e.Data = data
+	case hir.ExprParallel:
+		data, ok := e.Data.(hir.ParallelData)
+		if !ok {
+			return nil
+		}
+		for _, part := range []*hir.Expr{data.Iterable, data.Init, data.Body} {
+			if err := rewriteCallsInExpr(part, f); err != nil {
+				return err
+			}
+		}
+		if err := rewriteCallsInBlock(data.Seq, f); err != nil {
+			return err
+		}
+		e.Data = data
 	case hir.ExprCast:
 		data, ok := e.Data.(hir.CastData)
 		if !ok {
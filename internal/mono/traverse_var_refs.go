@@ -220,6 +220,9 @@ func rewriteVarRefsInExpr(e *hir.Expr, f varRefRewriteFunc) error {
 		if !ok {
 			return nil
 		}
+		if err := rewriteVarRefsInExpr(data.Base, f); err != nil {
+			return err
+		}
 		for i := range data.Fields {
 			if err := rewriteVarRefsInExpr(data.Fields[i].Value, f); err != nil {
 				return err
@@ -378,6 +381,20 @@ func rewriteVarRefsInExpr(e *hir.Expr, f varRefRewriteFunc) error {
 			return err
 		}
 		e.Data = data
+	case hir.ExprParallel:
+		data, ok := e.Data.(hir.ParallelData)
+		if !ok {
+			return nil
+		}
+		for _, part := range []*hir.Expr{data.Iterable, data.Init, data.Body} {
+			if err := rewriteVarRefsInExpr(part, f); err != nil {
+				return err
+			}
+		}
+		if err := rewriteVarRefsInBlock(data.Seq, f); err != nil {
+			return err
+		}
+		e.Data = data
 	case hir.ExprCast:
 		data, ok := e.Data.(hir.CastData)
 		if !ok {
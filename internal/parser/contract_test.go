@@ -5,6 +5,7 @@ import (
 
 	"surge/internal/ast"
 	"surge/internal/diag"
+	"surge/internal/diag/diagtest"
 )
 
 func TestParseContractWithMembers(t *testing.T) {
@@ -16,7 +17,7 @@ contract ErrorLike<E>{
 `
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -92,7 +93,7 @@ pub contract Printable{
 `
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if len(file.Items) != 1 {
@@ -118,7 +119,7 @@ contract Bad{
 		t.Fatal("expected diagnostics for missing semicolon")
 	}
 	if !hasDiagnosticCode(bag, diag.SynExpectSemicolon) {
-		t.Fatalf("expected SynExpectSemicolon, got %s", diagnosticsSummary(bag))
+		t.Fatalf("expected SynExpectSemicolon, got %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if len(file.Items) != 0 {
@@ -137,7 +138,7 @@ contract Bad{
 		t.Fatal("expected diagnostics for function body inside contract")
 	}
 	if !hasDiagnosticCode(bag, diag.SynUnexpectedToken) {
-		t.Fatalf("expected SynUnexpectedToken, got %s", diagnosticsSummary(bag))
+		t.Fatalf("expected SynUnexpectedToken, got %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if len(file.Items) != 0 {
@@ -151,7 +152,7 @@ func TestFieldKeywordOutsideContract(t *testing.T) {
 		t.Fatal("expected diagnostics for top-level field usage")
 	}
 	if !hasDiagnosticCode(bag, diag.SynUnexpectedTopLevel) {
-		t.Fatalf("expected SynUnexpectedTopLevel, got %s", diagnosticsSummary(bag))
+		t.Fatalf("expected SynUnexpectedTopLevel, got %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if len(file.Items) != 0 {
@@ -499,6 +499,9 @@ func (p *Parser) parsePrimaryExpr() (ast.ExprID, bool) {
 	case token.FStringLit:
 		return p.parseFString()
 
+	case token.CharLit:
+		return p.parseCharLiteral()
+
 	case token.KwTrue, token.KwFalse:
 		// Булевы литералы
 		return p.parseBoolLiteral()
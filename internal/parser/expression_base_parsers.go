@@ -128,6 +128,18 @@ func (p *Parser) parseStringLiteral() (ast.ExprID, bool) {
 	return p.arenas.Exprs.NewLiteral(tok.Span, ast.ExprLitString, valueID), true
 }
 
+// parseCharLiteral парсит символьные литералы; значение декодируется позже (HIR)
+func (p *Parser) parseCharLiteral() (ast.ExprID, bool) {
+	tok := p.advance()
+	if tok.Kind != token.CharLit {
+		p.err(diag.SynUnexpectedToken, "expected character literal")
+		return ast.NoExprID, false
+	}
+
+	valueID := p.arenas.StringsInterner.Intern(tok.Text)
+	return p.arenas.Exprs.NewLiteral(tok.Span, ast.ExprLitChar, valueID), true
+}
+
 // parseBoolLiteral парсит булевы литералы
 func (p *Parser) parseBoolLiteral() (ast.ExprID, bool) {
 	tok := p.advance()
@@ -179,18 +191,25 @@ func (p *Parser) parseParenExpr() (ast.ExprID, bool) {
 		return ast.NoExprID, false
 	}
 
-	// Проверяем есть ли запятая - если да, то это tuple
-	if p.at(token.Comma) {
+	// Проверяем есть ли запятая - если да, то это tuple.
+	// `(a b)` тоже считаем tuple с пропущенной запятой.
+	if p.at(token.Comma) || p.atOperandStart() {
 		var elements []ast.ExprID
 		elements = append(elements, first)
 
-		for p.at(token.Comma) {
-			commaTok := p.advance() // съедаем ','
-			commas = append(commas, commaTok.Span)
-
-			// Разрешаем завершающую запятую
-			if p.at(token.RParen) {
-				trailing = true
+		for {
+			if p.at(token.Comma) {
+				commaTok := p.advance() // съедаем ','
+				commas = append(commas, commaTok.Span)
+
+				// Разрешаем завершающую запятую
+				if p.at(token.RParen) {
+					trailing = true
+					break
+				}
+			} else if insertPos, missing := p.recoverMissingComma("tuple elements"); missing {
+				commas = append(commas, insertPos)
+			} else {
 				break
 			}
 
@@ -21,8 +21,8 @@ func (p *Parser) parseBraceExpr() (ast.ExprID, bool) {
 		return p.parseBlockExprBody(lbraceTok)
 	}
 
-	if p.at(token.RBrace) {
-		// Empty braces default to struct literal.
+	if p.at(token.RBrace) || p.at(token.DotDot) {
+		// Empty braces and `{ ..base, ... }` are struct literals.
 		return p.parseStructLiteralBody(ast.NoTypeID, source.Span{}, lbraceTok)
 	}
 
@@ -144,6 +144,7 @@ func (p *Parser) parseCompareArm() (ast.ExprCompareArm, bool) {
 			return arm, false
 		}
 		arm.Pattern = patternExpr
+		arm.PatternKind = p.classifyComparePattern(patternExpr)
 		if node := p.arenas.Exprs.Get(patternExpr); node != nil {
 			arm.PatternSpan = patternStart.Cover(node.Span)
 		} else {
@@ -212,3 +213,39 @@ func (p *Parser) parseCompareArm() (ast.ExprCompareArm, bool) {
 	arm.Result = resultExpr
 	return arm, true
 }
+
+// classifyComparePattern отделяет литеральные и диапазонные паттерны
+// (`1`, `-1`, `"s"`, `1..5`, `0..=9`) и or-паттерны (`1 | 2 | 3`)
+// от общих выражений-паттернов, чтобы проверка исчерпываемости и lowering
+// могли обрабатывать их отдельно. Диапазоны классифицируются синтаксически;
+// sema допускает их только для числовых субъектов, так что `'a'..='z'`
+// отклоняется там, а не здесь.
+func (p *Parser) classifyComparePattern(pattern ast.ExprID) ast.ComparePatternKind {
+	if p.isLiteralPattern(pattern) {
+		return ast.ComparePatternLiteral
+	}
+	if bin, ok := p.arenas.Exprs.Binary(pattern); ok && bin != nil {
+		if bin.Op == ast.ExprBinaryBitOr {
+			return ast.ComparePatternOr
+		}
+		if bin.Op == ast.ExprBinaryRange || bin.Op == ast.ExprBinaryRangeInclusive {
+			if p.isLiteralPattern(bin.Left) && p.isLiteralPattern(bin.Right) {
+				return ast.ComparePatternRange
+			}
+		}
+	}
+	return ast.ComparePatternExpr
+}
+
+// isLiteralPattern: литерал (кроме `nothing`) или числовой литерал с унарным минусом.
+func (p *Parser) isLiteralPattern(id ast.ExprID) bool {
+	if unary, ok := p.arenas.Exprs.Unary(id); ok && unary != nil {
+		if unary.Op != ast.ExprUnaryMinus {
+			return false
+		}
+		lit, ok := p.arenas.Exprs.Literal(unary.Operand)
+		return ok && lit != nil && (lit.Kind == ast.ExprLitInt || lit.Kind == ast.ExprLitFloat)
+	}
+	lit, ok := p.arenas.Exprs.Literal(id)
+	return ok && lit != nil && lit.Kind != ast.ExprLitNothing
+}
@@ -65,7 +65,13 @@ func (p *Parser) parseCallExpr(target ast.ExprID, typeArgs []ast.TypeID) (ast.Ex
 			args = append(args, ast.CallArg{Name: argName, Value: argExpr})
 
 			if !p.at(token.Comma) {
-				break
+				// `foo(a b)`: сообщаем о пропущенной запятой и парсим дальше
+				insertPos, missing := p.recoverMissingComma("function arguments")
+				if !missing {
+					break
+				}
+				commas = append(commas, insertPos)
+				continue
 			}
 			commaTok := p.advance() // съедаем ','
 			commas = append(commas, commaTok.Span)
@@ -20,8 +20,21 @@ func (p *Parser) parseStructLiteralBody(typeID ast.TypeID, typeSpan source.Span,
 	var commas []source.Span
 	trailing := false
 	positional := false
+	base := ast.NoExprID
 
 	for !p.at(token.RBrace) && !p.at(token.EOF) {
+		if p.at(token.DotDot) {
+			if !p.parseStructSpread(&base, len(fields) > 0) {
+				p.resyncStructLiteralField()
+				continue
+			}
+			if p.at(token.Comma) {
+				commas = append(commas, p.advance().Span)
+				continue
+			}
+			break
+		}
+
 		p.suspendColonCast++
 		// Parse a potential field key without consuming assignment operators, so we can
 		// recognize `field = value` (and legacy `field: value`) in struct literals.
@@ -80,7 +93,10 @@ func (p *Parser) parseStructLiteralBody(typeID ast.TypeID, typeSpan source.Span,
 	if typeSpan != (source.Span{}) {
 		span = typeSpan.Cover(span)
 	}
-	exprID := p.arenas.Exprs.NewStruct(span, typeID, fields, commas, trailing, positional)
+	if base.IsValid() && positional {
+		p.emitDiagnostic(diag.SynUnexpectedToken, diag.SevError, span, "'..base' spread requires named fields", nil)
+	}
+	exprID := p.arenas.Exprs.NewStruct(span, typeID, base, fields, commas, trailing, positional)
 	return exprID, true
 }
 
@@ -99,6 +115,19 @@ func (p *Parser) parseStructLiteralBodyWithFirst(typeID ast.TypeID, typeSpan sou
 			fieldExpr = firstExpr
 			first = false
 		} else {
+			if p.at(token.DotDot) {
+				// `..base` допустим только первым элементом литерала
+				ignored := ast.NoExprID
+				if !p.parseStructSpread(&ignored, true) {
+					p.resyncStructLiteralField()
+					continue
+				}
+				if p.at(token.Comma) {
+					commas = append(commas, p.advance().Span)
+					continue
+				}
+				break
+			}
 			p.suspendColonCast++
 			var ok bool
 			fieldExpr, ok = p.parseBinaryExpr(precNullCoalescing)
@@ -157,10 +186,29 @@ func (p *Parser) parseStructLiteralBodyWithFirst(typeID ast.TypeID, typeSpan sou
 	if typeSpan != (source.Span{}) {
 		span = typeSpan.Cover(span)
 	}
-	exprID := p.arenas.Exprs.NewStruct(span, typeID, fields, commas, trailing, positional)
+	exprID := p.arenas.Exprs.NewStruct(span, typeID, ast.NoExprID, fields, commas, trailing, positional)
 	return exprID, true
 }
 
+// parseStructSpread parses `..base` in a struct literal. The spread must be the
+// first entry; a misplaced or repeated spread is reported and its value dropped.
+func (p *Parser) parseStructSpread(base *ast.ExprID, hasFields bool) bool {
+	dotsTok := p.advance()
+	value, ok := p.parseExpr()
+	if !ok {
+		return false
+	}
+	switch {
+	case base.IsValid():
+		p.emitDiagnostic(diag.SynUnexpectedToken, diag.SevError, dotsTok.Span, "struct literal can have only one '..base' spread", nil)
+	case hasFields:
+		p.emitDiagnostic(diag.SynUnexpectedToken, diag.SevError, dotsTok.Span, "'..base' spread must come before the fields of a struct literal", nil)
+	default:
+		*base = value
+	}
+	return true
+}
+
 func (p *Parser) resyncStructLiteralField() {
 	p.resyncUntil(token.Comma, token.RBrace, token.Semicolon, token.EOF)
 	if p.at(token.Comma) || p.at(token.Semicolon) {
@@ -6,8 +6,10 @@ import (
 
 	"surge/internal/ast"
 	"surge/internal/diag"
+	"surge/internal/diag/diagtest"
 	"surge/internal/lexer"
 	"surge/internal/source"
+	"surge/internal/token"
 )
 
 func TestBasicLiterals(t *testing.T) {
@@ -290,6 +292,60 @@ func TestParseStructLiteralPositionalWithoutExplicitType(t *testing.T) {
 	}
 }
 
+func TestParseStructLiteralSpread(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		typed bool
+	}{
+		{name: "untyped", input: "let q = { ..p, x = 5 };"},
+		{name: "typed", input: "let q = Point { ..p, y = 1 };", typed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			letItem, arenas := parseExprTestInput(t, tt.input)
+			data, ok := arenas.Exprs.Struct(letItem.Value)
+			if !ok || data == nil {
+				t.Fatal("missing struct data")
+			}
+			if data.Type.IsValid() != tt.typed {
+				t.Fatalf("expected typed=%v literal", tt.typed)
+			}
+			if !data.Base.IsValid() {
+				t.Fatal("expected spread base expression")
+			}
+			if base := arenas.Exprs.Get(data.Base); base == nil || base.Kind != ast.ExprIdent {
+				t.Fatalf("expected identifier base, got %+v", base)
+			}
+			if data.Positional {
+				t.Fatal("expected named struct literal")
+			}
+			if len(data.Fields) != 1 {
+				t.Fatalf("expected 1 overridden field, got %d", len(data.Fields))
+			}
+		})
+	}
+}
+
+func TestParseStructLiteralSpreadErrors(t *testing.T) {
+	for _, input := range []string{
+		"let q = { x = 5, ..p };",
+		"let q = { ..p, ..r };",
+	} {
+		_, _, bag := parseSource(t, input)
+		found := false
+		for _, d := range bag.Items() {
+			if d.Code == diag.SynUnexpectedToken {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("%s: expected SynUnexpectedToken diagnostic, got %s", input, diagtest.Summary(bag))
+		}
+	}
+}
+
 func TestStructLiteralExpressions(t *testing.T) {
 	input := "type Pair = { x:int, y:int };\nlet x: Pair = { x: foo, y: bar, };"
 	letItem, arenas := parseExprTestInput(t, input)
@@ -424,7 +480,7 @@ fn choose(flag: bool, a: int, b: int) -> int {
 }
 `)
 	if bag.HasErrors() {
-		t.Fatalf("expected ternary with binary branches to parse, got %s", diagnosticsSummary(bag))
+		t.Fatalf("expected ternary with binary branches to parse, got %s", diagtest.Summary(bag))
 	}
 }
 
@@ -609,7 +665,7 @@ func TestNullCoalescingNotSupported(t *testing.T) {
 		}
 	}
 
-	t.Fatalf("expected FutNullCoalescingNotSupported diagnostic, got %s", diagnosticsSummary(bag))
+	t.Fatalf("expected FutNullCoalescingNotSupported diagnostic, got %s", diagtest.Summary(bag))
 }
 
 // Helper function для парсинга выражений в тестах
@@ -1199,6 +1255,9 @@ func TestNumberLiterals_ExtendedFormats(t *testing.T) {
 		{"octal_literal", "let x = 0o777;"},
 		{"float_no_leading_digit", "let x = .5;"},
 		{"float_no_trailing_digit", "let x = 5.;"},
+		{"grouped_decimal", "let x = 1_000_000;"},
+		{"grouped_hex", "let x = 0xFF_FF;"},
+		{"grouped_float", "let x = 3.14_15;"},
 	}
 
 	for _, tt := range tests {
@@ -1247,6 +1306,37 @@ func TestStringLiterals_Variants(t *testing.T) {
 	}
 }
 
+func TestRawStringLiterals_Verbatim(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		raw   string
+		body  string
+	}{
+		{"backslashes", `let x = r"C:\path\to";`, `r"C:\path\to"`, `C:\path\to`},
+		{"regex", `let x = r"\d+\n";`, `r"\d+\n"`, `\d+\n`},
+		{"embedded_quotes", `let x = r#"say "hi""#;`, `r#"say "hi""#`, `say "hi"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			letItem, arenas := parseExprTestInput(t, tt.input)
+			lit, ok := arenas.Exprs.Literal(letItem.Value)
+			if !ok || lit.Kind != ast.ExprLitString {
+				t.Fatalf("expected string literal, got %+v", lit)
+			}
+			value := arenas.StringsInterner.MustLookup(lit.Value)
+			if value != tt.raw {
+				t.Fatalf("literal value: got %q, want %q", value, tt.raw)
+			}
+			body, ok := token.RawStringBody(value)
+			if !ok || body != tt.body {
+				t.Fatalf("raw body: got %q (ok=%v), want %q", body, ok, tt.body)
+			}
+		})
+	}
+}
+
 func TestBooleanAndNothingLiterals(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -1531,7 +1621,7 @@ func TestParallelExpressionDiagnostics(t *testing.T) {
 				}
 			}
 			if !found {
-				t.Fatalf("expected diagnostic %s containing %q, got %s", tt.wantCode.ID(), tt.wantMessage, diagnosticsSummary(bag))
+				t.Fatalf("expected diagnostic %s containing %q, got %s", tt.wantCode.ID(), tt.wantMessage, diagtest.Summary(bag))
 			}
 		})
 	}
@@ -1552,5 +1642,69 @@ func TestFatArrowOutsideParallelDiagnostic(t *testing.T) {
 			return
 		}
 	}
-	t.Fatalf("fat arrow diagnostic not reported, got %s", diagnosticsSummary(bag))
+	t.Fatalf("fat arrow diagnostic not reported, got %s", diagtest.Summary(bag))
+}
+
+func TestMissingCommaRecovery(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		before string // text the comma must be inserted after
+		count  func(*ast.Builder, ast.ExprID) int
+	}{
+		{
+			name:   "call arguments",
+			input:  "let x = foo(a b, c);",
+			before: "foo(a",
+			count: func(b *ast.Builder, id ast.ExprID) int {
+				call, ok := b.Exprs.Call(id)
+				if !ok {
+					return -1
+				}
+				return len(call.Args)
+			},
+		},
+		{
+			name:   "tuple elements",
+			input:  "let x = (1 2, 3);",
+			before: "(1",
+			count: func(b *ast.Builder, id ast.ExprID) int {
+				tuple, ok := b.Exprs.Tuple(id)
+				if !ok {
+					return -1
+				}
+				return len(tuple.Elements)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder, fileID, bag := parseSource(t, tt.input)
+			if bag.Len() != 1 {
+				t.Fatalf("expected exactly one diagnostic, got %s", diagtest.Summary(bag))
+			}
+			d := bag.Items()[0]
+			if d.Code != diag.SynExpectComma {
+				t.Fatalf("expected SynExpectComma, got %s", diagtest.Summary(bag))
+			}
+			if len(d.Fixes) != 1 || len(d.Fixes[0].Edits) != 1 {
+				t.Fatalf("expected one insert-comma fix, got %+v", d.Fixes)
+			}
+			edit := d.Fixes[0].Edits[0]
+			want := uint32(strings.Index(tt.input, tt.before) + len(tt.before))
+			if edit.NewText != "," || edit.Span.Start != want || edit.Span.End != want {
+				t.Fatalf("expected ',' inserted at %d, got %+v", want, edit)
+			}
+
+			file := builder.Files.Get(fileID)
+			letItem, ok := builder.Items.Let(file.Items[0])
+			if !ok {
+				t.Fatal("expected let item")
+			}
+			if got := tt.count(builder, letItem.Value); got != 3 {
+				t.Fatalf("expected all 3 elements to parse, got %d", got)
+			}
+		})
+	}
 }
@@ -26,8 +26,11 @@ func (m *fnModifiers) extend(sp source.Span) {
 }
 
 func (p *Parser) parseFnModifiers() fnModifiers {
-	mods := fnModifiers{}
+	return p.continueFnModifiers(fnModifiers{})
+}
 
+// continueFnModifiers parses the rest of a modifier list whose start is already in mods.
+func (p *Parser) continueFnModifiers(mods fnModifiers) fnModifiers {
 	for {
 		tok := p.lx.Peek()
 		switch tok.Kind {
@@ -93,36 +96,7 @@ func (p *Parser) parseFnModifiers() fnModifiers {
 			continue
 		case token.Ident:
 			tok = p.advance()
-			msg := "unknown function modifier"
-			note := "Possible fn modifier: pub, async"
-			if tok.Text == "unsafe" {
-				msg = "'unsafe' must be specified via attribute"
-				note = "'unsafe' should be declared via attribute before the function"
-			} else if tok.Text != "" {
-				msg = "unknown function modifier '" + tok.Text + "'"
-			}
-			p.emitDiagnostic(
-				diag.SynUnexpectedModifier,
-				diag.SevError,
-				tok.Span,
-				msg,
-				func(b *diag.ReportBuilder) {
-					if b == nil {
-						return
-					}
-					fixID := fix.MakeFixID(diag.SynUnexpectedModifier, tok.Span)
-					suggestion := fix.DeleteSpan(
-						"remove the unknown function modifier",
-						tok.Span.ExtendRight(p.lx.Peek().Span),
-						"",
-						fix.WithID(fixID),
-						fix.WithKind(diag.FixKindRefactor),
-						fix.WithApplicability(diag.FixApplicabilityAlwaysSafe),
-					)
-					b.WithFixSuggestion(suggestion)
-					b.WithNote(tok.Span, note)
-				},
-			)
+			p.reportUnknownFnModifier(tok)
 			mods.extend(tok.Span)
 			continue
 		case token.EOF:
@@ -135,3 +109,37 @@ func (p *Parser) parseFnModifiers() fnModifiers {
 		}
 	}
 }
+
+// reportUnknownFnModifier reports an identifier found where a function modifier was expected.
+func (p *Parser) reportUnknownFnModifier(tok token.Token) {
+	msg := "unknown function modifier"
+	note := "Possible fn modifier: pub, async"
+	if tok.Text == "unsafe" {
+		msg = "'unsafe' must be specified via attribute"
+		note = "'unsafe' should be declared via attribute before the function"
+	} else if tok.Text != "" {
+		msg = "unknown function modifier '" + tok.Text + "'"
+	}
+	p.emitDiagnostic(
+		diag.SynUnexpectedModifier,
+		diag.SevError,
+		tok.Span,
+		msg,
+		func(b *diag.ReportBuilder) {
+			if b == nil {
+				return
+			}
+			fixID := fix.MakeFixID(diag.SynUnexpectedModifier, tok.Span)
+			suggestion := fix.DeleteSpan(
+				"remove the unknown function modifier",
+				tok.Span.ExtendRight(p.lx.Peek().Span),
+				"",
+				fix.WithID(fixID),
+				fix.WithKind(diag.FixKindRefactor),
+				fix.WithApplicability(diag.FixApplicabilityAlwaysSafe),
+			)
+			b.WithFixSuggestion(suggestion)
+			b.WithNote(tok.Span, note)
+		},
+	)
+}
@@ -732,6 +732,32 @@ func TestParseFnItem_Attributes(t *testing.T) {
 	}
 }
 
+func TestParseFnItem_CfgAttribute(t *testing.T) {
+	builder, fileID, bag := parseSource(t, "@cfg(os = \"linux\", debug) fn foo() {}")
+	if bag.HasErrors() {
+		t.Fatalf("unexpected errors: %+v", bag.Items())
+	}
+	file := builder.Files.Get(fileID)
+	fnItem, ok := builder.Items.Fn(file.Items[0])
+	if !ok {
+		t.Fatal("expected fn item")
+	}
+	attrs := builder.Items.CollectAttrs(fnItem.AttrStart, fnItem.AttrCount)
+	if len(attrs) != 1 || builder.StringsInterner.MustLookup(attrs[0].Name) != "cfg" {
+		t.Fatalf("expected a single @cfg attribute, got %+v", attrs)
+	}
+	if len(attrs[0].Args) != 2 {
+		t.Fatalf("expected 2 cfg predicates, got %d", len(attrs[0].Args))
+	}
+	bin, ok := builder.Exprs.Binary(attrs[0].Args[0])
+	if !ok || bin.Op != ast.ExprBinaryAssign {
+		t.Fatalf("expected key = value predicate, got %+v", bin)
+	}
+	if _, ok := builder.Exprs.Ident(attrs[0].Args[1]); !ok {
+		t.Fatal("expected bare identifier predicate")
+	}
+}
+
 // TestParseFnItem_ComplexSignatures tests complex function signatures
 func TestParseFnItem_ComplexSignatures(t *testing.T) {
 	tests := []struct {
@@ -4,13 +4,14 @@ import (
 	"testing"
 
 	"surge/internal/ast"
+	"surge/internal/diag/diagtest"
 )
 
 func TestParseFnTypeParamBounds(t *testing.T) {
 	src := `fn f<T: FooLike + Serializable<T>>(t: T) -> int;`
 	builder, fileID, bag := parseSource(t, src)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -61,7 +62,7 @@ func TestParseTypeDeclBounds(t *testing.T) {
 	src := `type List<T: Iterable<T>> = {};`
 	builder, fileID, bag := parseSource(t, src)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if len(file.Items) != 1 {
@@ -98,7 +99,7 @@ func TestParseMultipleTypeParamBounds(t *testing.T) {
 	src := `fn k<T: X + Y<T> + Z<T, U>, U>();`
 	builder, fileID, bag := parseSource(t, src)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -12,6 +12,7 @@ import (
 
 // advance — съедает следующий токен и обновляет lastSpan
 func (p *Parser) advance() token.Token {
+	p.checkNodeBudget()
 	tok := p.lx.Next()
 	if tok.Kind != token.EOF && tok.Kind != token.Invalid {
 		p.lastSpan = tok.Span
@@ -19,6 +20,18 @@ func (p *Parser) advance() token.Token {
 	return tok
 }
 
+// checkNodeBudget останавливает лексер, когда арены выросли сверх MaxNodes:
+// дальше парсер видит только EOF и сворачивает разбор.
+func (p *Parser) checkNodeBudget() {
+	if p.opts.MaxNodes == 0 || p.nodeBudgetExceeded {
+		return
+	}
+	if p.arenas.NodeCount() > p.opts.MaxNodes {
+		p.nodeBudgetExceeded = true
+		p.lx.Stop()
+	}
+}
+
 // getDiagnosticSpan — возвращает лучший span для диагностики
 // Если текущий токен EOF или Invalid с нулевой длиной, используем позицию после lastSpan
 func (p *Parser) getDiagnosticSpan() source.Span {
@@ -93,6 +106,55 @@ func (p *Parser) emitDiagnostic(code diag.Code, sev diag.Severity, sp source.Spa
 	}
 }
 
+// atOperandStart reports whether the next token can only begin a new operand:
+// after a complete expression it means a separator was left out.
+func (p *Parser) atOperandStart() bool {
+	switch p.lx.Peek().Kind {
+	case token.Ident, token.Underscore,
+		token.IntLit, token.UintLit, token.FloatLit,
+		token.StringLit, token.FStringLit, token.CharLit,
+		token.KwTrue, token.KwFalse, token.NothingLit:
+		return true
+	default:
+		return false
+	}
+}
+
+// recoverMissingComma handles `foo(a b)` / `(a b)`: when the next token starts
+// another element it reports a single SynExpectComma with an insert-comma fix
+// and returns the zero-width span where the comma belongs, so the caller can
+// keep parsing the remaining elements.
+func (p *Parser) recoverMissingComma(list string) (source.Span, bool) {
+	if !p.atOperandStart() {
+		return source.Span{}, false
+	}
+	insertPos := p.lastSpan.ZeroideToEnd()
+	p.emitDiagnostic(
+		diag.SynExpectComma,
+		diag.SevError,
+		p.lx.Peek().Span,
+		"expected ',' between "+list,
+		func(b *diag.ReportBuilder) {
+			if b == nil {
+				return
+			}
+			fixID := fix.MakeFixID(diag.SynExpectComma, insertPos)
+			suggestion := fix.InsertText(
+				"insert missing ','",
+				insertPos,
+				",",
+				"",
+				fix.WithID(fixID),
+				fix.WithKind(diag.FixKindQuickFix),
+				fix.WithApplicability(diag.FixApplicabilityAlwaysSafe),
+				fix.Preferred(),
+			)
+			b.WithFixSuggestion(suggestion)
+		},
+	)
+	return insertPos, true
+}
+
 // resyncUntil — consume tokens until Peek() matches any stop token or EOF.
 // Stop token остаётся на входе (не съедаем).
 func (p *Parser) resyncUntil(stop ...token.Kind) {
@@ -284,6 +346,46 @@ func (p *Parser) resyncStatement() {
 	}
 }
 
+// resyncFileScopeStatement пропускает statement, оказавшийся на верхнем уровне:
+// до ';' (съедаем), до '}' закрывающего блок statement-а (кроме `} else`) или
+// до стартера следующего item. Вложенные скобки учитываются.
+func (p *Parser) resyncFileScopeStatement() {
+	depth := 0
+	for !p.at(token.EOF) {
+		tok := p.lx.Peek()
+		switch tok.Kind {
+		case token.LBrace, token.LParen, token.LBracket:
+			depth++
+		case token.RBrace, token.RParen, token.RBracket:
+			if depth > 0 {
+				depth--
+			}
+			p.advance()
+			if depth == 0 && tok.Kind == token.RBrace && !p.at(token.KwElse) {
+				if p.at(token.Semicolon) {
+					p.advance()
+				}
+				return
+			}
+			continue
+		case token.Semicolon:
+			if depth == 0 {
+				p.advance()
+				return
+			}
+		case token.At, token.KwPragma, token.KwMacro:
+			if depth == 0 {
+				return
+			}
+		default:
+			if depth == 0 && isTopLevelStarter(tok.Kind) {
+				return
+			}
+		}
+		p.advance()
+	}
+}
+
 // FakeError — эмулирует ошибку в указанном span
 // используется для генерации диагностик для дебага
 func (p *Parser) FakeError(msg string, span source.Span) {
@@ -19,6 +19,7 @@ import (
 
 	"surge/internal/ast"
 	"surge/internal/diag"
+	"surge/internal/diag/diagtest"
 	"surge/internal/lexer"
 	"surge/internal/source"
 )
@@ -571,17 +572,12 @@ func TestParseImport_UnclosedGroupDiagnostics(t *testing.T) {
 					t.Fatalf("failed to locate 'Baz' in input")
 				}
 				wantOffset += len("Baz")
-				got := items[0].Primary.Start
 				wantStart, err := safecast.Conv[uint32](wantOffset)
 				if err != nil {
 					t.Fatalf("want offset overflow: %v", err)
 				}
-				if got != wantStart {
-					t.Errorf("unexpected primary span start: got %d, want %d", got, wantOffset)
-				}
-				if items[0].Primary.End != got {
-					t.Errorf("expected zero-length primary span, got end=%d start=%d", items[0].Primary.End, got)
-				}
+				// Пустой span сразу после "Baz".
+				diagtest.AssertSpanAt(t, bag, tt.wantCodes[0], wantStart, wantStart)
 			}
 		})
 	}
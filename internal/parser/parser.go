@@ -23,6 +23,8 @@ type Options struct {
 	CurrentErrors uint
 	Reporter      diag.Reporter
 	DirectiveMode DirectiveMode
+	// MaxNodes stops parsing once the AST builder holds more nodes; 0 disables the limit.
+	MaxNodes uint64
 }
 
 // Enough - проверить, достигли ли мы максимального количества ошибок
@@ -37,6 +39,8 @@ func (o *Options) Enough() bool {
 type Result struct {
 	File ast.FileID
 	Bag  *diag.Bag
+	// NodeBudgetExceeded is set when Options.MaxNodes cut the file short.
+	NodeBudgetExceeded bool
 }
 
 // Parser — состояние парсера на один файл
@@ -68,8 +72,10 @@ type Parser struct {
 	rangeLiteralInclusive bool
 	rangeLiteralSpan      source.Span
 	pragmaParsed          bool
+	itemResynced          bool         // parseItem уже восстановился сам, resyncTop не нужен
 	tracer                trace.Tracer // трассировщик для отладки зависаний
 	exprDepth             int          // глубина рекурсии для выражений
+	nodeBudgetExceeded    bool         // MaxNodes превышен, лексер остановлен
 }
 
 // DirectiveMode specifies how directives are handled during parsing.
@@ -117,8 +123,9 @@ func ParseFile(
 		bag = br.Bag
 	}
 	return Result{
-		File: p.file,
-		Bag:  bag,
+		File:               p.file,
+		Bag:                bag,
+		NodeBudgetExceeded: p.nodeBudgetExceeded,
 	}
 }
 
@@ -187,7 +194,13 @@ func (p *Parser) parseItems() {
 
 		itemID, ok := p.parseItem()
 		if !ok {
-			p.resyncTop()
+			// Если parseItem уже восстановился до следующего item, повторный resync
+			// съел бы его первый токен.
+			after := p.lx.Peek()
+			if !p.itemResynced && (after.Span == before.Span || !isTopLevelStarter(after.Kind)) {
+				p.resyncTop()
+			}
+			p.itemResynced = false
 		} else {
 			p.arenas.PushItem(p.file, itemID)
 			itemCount++
@@ -296,7 +309,17 @@ func (p *Parser) parseItem() (ast.ItemID, bool) {
 		p.resyncTop()
 		return ast.NoItemID, false
 	case token.KwPub, token.KwAsync, token.Ident:
-		mods := p.parseFnModifiers()
+		var mods fnModifiers
+		if p.at(token.Ident) {
+			tok := p.advance()
+			if !continuesFnModifiers(p.lx.Peek().Kind) {
+				p.reportStatementAtFileScope(tok.Span)
+				return ast.NoItemID, false
+			}
+			p.reportUnknownFnModifier(tok)
+			mods.extend(tok.Span)
+		}
+		mods = p.continueFnModifiers(mods)
 		if p.at(token.KwFn) {
 			itemID, parsed := p.parseFnItem(attrs, attrSpan, mods)
 			if parsed {
@@ -577,11 +600,63 @@ func (p *Parser) parseItem() (ast.ItemID, bool) {
 				nil,
 			)
 		}
+		if isFileScopeStatementStarter(p.lx.Peek().Kind) {
+			p.reportStatementAtFileScope(p.advance().Span)
+			return ast.NoItemID, false
+		}
 		p.report(diag.SynUnexpectedTopLevel, diag.SevError, p.lx.Peek().Span, "unexpected top-level construct")
 		return 0, false
 	}
 }
 
+// reportStatementAtFileScope reports a statement whose first token (already
+// consumed) starts at first, and skips the rest of it up to the next item.
+func (p *Parser) reportStatementAtFileScope(first source.Span) {
+	p.resyncFileScopeStatement()
+	p.itemResynced = true
+	span := first.Cover(p.lastSpan)
+	p.emitDiagnostic(
+		diag.SynStatementAtFileScope,
+		diag.SevError,
+		span,
+		"statements are not allowed at file scope",
+		func(b *diag.ReportBuilder) {
+			if b == nil {
+				return
+			}
+			b.WithNote(span, "move this code into a function, e.g. the @entrypoint function")
+		},
+	)
+}
+
+// continuesFnModifiers reports whether a token after an identifier at file
+// scope can still belong to a (misspelled) modifier list of an item.
+func continuesFnModifiers(k token.Kind) bool {
+	switch k {
+	case token.Ident, token.EOF:
+		return true
+	default:
+		return isTopLevelStarter(k)
+	}
+}
+
+// isFileScopeStatementStarter reports whether a token can only begin a
+// statement or an expression, never an item.
+func isFileScopeStatementStarter(k token.Kind) bool {
+	if isBlockStatementStarter(k) {
+		return true
+	}
+	switch k {
+	case token.KwRet, token.KwSpawn, token.KwParallel, token.KwBlocking,
+		token.KwTrue, token.KwFalse, token.NothingLit, token.IntLit, token.UintLit, token.FloatLit,
+		token.BoolLit, token.StringLit, token.FStringLit, token.CharLit,
+		token.LParen, token.LBracket, token.Minus, token.Bang, token.Star, token.Amp, token.Underscore:
+		return true
+	default:
+		return false
+	}
+}
+
 // resyncTop — восстановление после ошибки на верхнем уровне:
 // прокручиваем до ';' ИЛИ до стартового токена следующего item ИЛИ EOF.
 func (p *Parser) resyncTop() { // todo: использовать resyncUntill - надо явно знать до какого токена прокручивать
@@ -4,11 +4,13 @@ import (
 	"surge/internal/ast"
 	"surge/internal/diag"
 	"surge/internal/fix"
+	"surge/internal/source"
 	"surge/internal/token"
 )
 
 func (p *Parser) parseBreakStmt() (ast.StmtID, bool) {
 	breakTok := p.advance()
+	label, labelSpan := p.parseJumpLabel()
 
 	insertSpan := p.lastSpan.ZeroideToEnd()
 	semiTok, ok := p.expect(
@@ -38,11 +40,15 @@ func (p *Parser) parseBreakStmt() (ast.StmtID, bool) {
 	}
 
 	stmtSpan := breakTok.Span.Cover(semiTok.Span)
+	if label != source.NoStringID {
+		return p.arenas.Stmts.NewLabeledBreak(stmtSpan, label, labelSpan), true
+	}
 	return p.arenas.Stmts.NewBreak(stmtSpan), true
 }
 
 func (p *Parser) parseContinueStmt() (ast.StmtID, bool) {
 	continueTok := p.advance()
+	label, labelSpan := p.parseJumpLabel()
 
 	insertSpan := p.lastSpan.ZeroideToEnd()
 	semiTok, ok := p.expect(
@@ -72,9 +78,62 @@ func (p *Parser) parseContinueStmt() (ast.StmtID, bool) {
 	}
 
 	stmtSpan := continueTok.Span.Cover(semiTok.Span)
+	if label != source.NoStringID {
+		return p.arenas.Stmts.NewLabeledContinue(stmtSpan, label, labelSpan), true
+	}
 	return p.arenas.Stmts.NewContinue(stmtSpan), true
 }
 
+// parseJumpLabel consumes the optional target of `break label;` / `continue label;`.
+func (p *Parser) parseJumpLabel() (source.StringID, source.Span) {
+	if !p.at(token.Ident) {
+		return source.NoStringID, source.Span{}
+	}
+	tok := p.advance()
+	return p.arenas.StringsInterner.Intern(tok.Text), tok.Span
+}
+
+// atLoopLabel reports whether the statement starts with `label: while` or
+// `label: for`. Токены возвращаются в лексер, позиция парсера не меняется.
+func (p *Parser) atLoopLabel() bool {
+	saved := p.lastSpan
+	labelTok := p.advance()
+	if !p.at(token.Colon) {
+		p.lx.Push(labelTok)
+		p.lastSpan = saved
+		return false
+	}
+	colonTok := p.advance()
+	isLoop := p.atOr(token.KwWhile, token.KwFor)
+	p.lx.Push(colonTok)
+	p.lx.Push(labelTok)
+	p.lastSpan = saved
+	return isLoop
+}
+
+// parseLabeledLoop parses `label: while ...` / `label: for ...`.
+func (p *Parser) parseLabeledLoop() (ast.StmtID, bool) {
+	labelTok := p.advance()
+	p.advance() // ':'
+	label := p.arenas.StringsInterner.Intern(labelTok.Text)
+
+	var stmtID ast.StmtID
+	var ok bool
+	if p.at(token.KwWhile) {
+		stmtID, ok = p.parseWhileStmt()
+	} else {
+		stmtID, ok = p.parseForStmt()
+	}
+	if !ok {
+		return stmtID, ok
+	}
+	p.arenas.Stmts.SetLoopLabel(stmtID, label, labelTok.Span)
+	if stmt := p.arenas.Stmts.Get(stmtID); stmt != nil {
+		stmt.Span = labelTok.Span.Cover(stmt.Span)
+	}
+	return stmtID, true
+}
+
 func (p *Parser) parseIfStmt() (ast.StmtID, bool) {
 	ifTok := p.advance()
 
@@ -268,9 +327,31 @@ func (p *Parser) parseWhileStmt() (ast.StmtID, bool) {
 func (p *Parser) parseForStmt() (ast.StmtID, bool) {
 	forTok := p.advance()
 	if p.at(token.LParen) {
+		if p.atIndexedForHeader() {
+			return p.parseForIn(forTok, true)
+		}
 		return p.parseForClassic(forTok)
 	}
-	return p.parseForIn(forTok)
+	return p.parseForIn(forTok, false)
+}
+
+// atIndexedForHeader reports whether the tokens after 'for' open an
+// enumerated header `(i, x) in ...` rather than a classic `(init; cond; post)`.
+// Просмотренные токены возвращаются в лексер, позиция парсера не меняется.
+func (p *Parser) atIndexedForHeader() bool {
+	saved := p.lastSpan
+	openTok := p.advance()
+	if !p.at(token.Ident) {
+		p.lx.Push(openTok)
+		p.lastSpan = saved
+		return false
+	}
+	nameTok := p.advance()
+	indexed := p.at(token.Comma)
+	p.lx.Push(nameTok)
+	p.lx.Push(openTok)
+	p.lastSpan = saved
+	return indexed
 }
 
 func (p *Parser) parseForClassic(forTok token.Token) (ast.StmtID, bool) {
@@ -439,7 +520,23 @@ func (p *Parser) parseForInitializer() (ast.StmtID, bool) {
 	return stmtID, true
 }
 
-func (p *Parser) parseForIn(forTok token.Token) (ast.StmtID, bool) {
+func (p *Parser) parseForIn(forTok token.Token, indexed bool) (ast.StmtID, bool) {
+	indexID := source.NoStringID
+	var indexSpan source.Span
+	if indexed {
+		p.advance() // '('
+		indexTok := p.lx.Peek()
+		var ok bool
+		indexID, ok = p.parseIdent()
+		if !ok {
+			return ast.NoStmtID, false
+		}
+		indexSpan = indexTok.Span
+		if _, ok = p.expect(token.Comma, diag.SynForBadHeader, "expected ',' after for index"); !ok {
+			return ast.NoStmtID, false
+		}
+	}
+
 	nameTok := p.lx.Peek()
 	nameID, ok := p.parseIdent()
 	if !ok {
@@ -456,6 +553,11 @@ func (p *Parser) parseForIn(forTok token.Token) (ast.StmtID, bool) {
 			patternSpan = coverOptional(patternSpan, typ.Span)
 		}
 	}
+	if indexed {
+		if _, ok = p.expect(token.RParen, diag.SynForBadHeader, "expected ')' after for-in bindings"); !ok {
+			return ast.NoStmtID, false
+		}
+	}
 
 	insertSpan := p.lastSpan.ZeroideToEnd()
 	inTok, ok := p.expect(
@@ -515,5 +617,8 @@ func (p *Parser) parseForIn(forTok token.Token) (ast.StmtID, bool) {
 		stmtSpan = stmtSpan.Cover(body.Span)
 	}
 
+	if indexed {
+		return p.arenas.Stmts.NewForInIndexed(stmtSpan, indexID, indexSpan, nameID, patternSpan, typeID, iterExpr, bodyStmt), true
+	}
 	return p.arenas.Stmts.NewForIn(stmtSpan, nameID, patternSpan, typeID, iterExpr, bodyStmt), true
 }
@@ -154,6 +154,11 @@ func (p *Parser) parseSignalStmt() (ast.StmtID, bool) {
 
 func (p *Parser) parseStmt() (ast.StmtID, bool) {
 	switch p.lx.Peek().Kind {
+	case token.Ident:
+		if p.atLoopLabel() {
+			return p.parseLabeledLoop()
+		}
+		return p.parseExprStmt()
 	case token.LBrace:
 		return p.parseBlock()
 	case token.KwPub:
@@ -6,6 +6,7 @@ import (
 
 	"surge/internal/ast"
 	"surge/internal/diag"
+	"surge/internal/diag/diagtest"
 	"surge/internal/lexer"
 	"surge/internal/source"
 )
@@ -54,7 +55,7 @@ fn foo() {}
 `
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if file == nil {
@@ -95,7 +96,7 @@ pragma directive
 		}
 	}
 	if !found {
-		t.Fatalf("expected SynPragmaPosition diagnostic, got %s", diagnosticsSummary(bag))
+		t.Fatalf("expected SynPragmaPosition diagnostic, got %s", diagtest.Summary(bag))
 	}
 }
 
@@ -113,7 +114,7 @@ fn foo() -> int { return 42; }
 	}
 	builder, fileID, bag := parseSourceWithOptions(t, input, opts)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if file == nil {
@@ -146,7 +147,7 @@ fn foo() -> int { return 42; }
 `
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if file == nil {
@@ -166,7 +167,7 @@ fn foo() {
 `
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if file == nil || len(file.Items) == 0 {
@@ -204,7 +205,7 @@ fn foo() -> int { return 42; }
 	}
 	builder, fileID, bag := parseSourceWithOptions(t, input, opts)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 	file := builder.Files.Get(fileID)
 	if file == nil {
@@ -227,7 +228,7 @@ func TestParseBlockStatements_Positive(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -318,7 +319,7 @@ fn demo() {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -380,7 +381,7 @@ func TestSignalStatement(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected diagnostics: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -457,7 +458,7 @@ func TestSignalStatementDiagnostics(t *testing.T) {
 		}
 	}
 	if !found {
-		t.Fatalf("missing signal diagnostic, got %s", diagnosticsSummary(bag))
+		t.Fatalf("missing signal diagnostic, got %s", diagtest.Summary(bag))
 	}
 }
 
@@ -559,7 +560,7 @@ func TestParseReturnStatement(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			builder, fileID, bag := parseSource(t, tt.input)
 			if bag.HasErrors() {
-				t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+				t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 			}
 
 			file := builder.Files.Get(fileID)
@@ -597,7 +598,7 @@ func TestParseExpressionStatement(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			builder, fileID, bag := parseSource(t, tt.input)
 			if bag.HasErrors() {
-				t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+				t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 			}
 
 			file := builder.Files.Get(fileID)
@@ -634,7 +635,7 @@ func TestParseExpressionStatement(t *testing.T) {
 
 // 	builder, fileID, bag := parseSource(t, input)
 // 	if bag.HasErrors() {
-// 		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+// 		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 // 	}
 
 // 	file := builder.Files.Get(fileID)
@@ -680,7 +681,7 @@ func TestParseLetStatementInFunction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			builder, fileID, bag := parseSource(t, tt.input)
 			if bag.HasErrors() {
-				t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+				t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 			}
 
 			file := builder.Files.Get(fileID)
@@ -714,7 +715,7 @@ func TestParseMultipleStatementsInBlock(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -739,7 +740,7 @@ func TestParseEmptyBlock(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -813,7 +814,7 @@ func TestParseBlockWithWhitespace(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			builder, fileID, bag := parseSource(t, tt.input)
 			if bag.HasErrors() {
-				t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+				t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 			}
 
 			file := builder.Files.Get(fileID)
@@ -865,7 +866,7 @@ func TestParseComplexStatements(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			builder, fileID, bag := parseSource(t, tt.input)
 			if bag.HasErrors() {
-				t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+				t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 			}
 
 			file := builder.Files.Get(fileID)
@@ -899,7 +900,7 @@ func TestParseIfStatement(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			builder, fileID, bag := parseSource(t, tt.input)
 			if bag.HasErrors() {
-				t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+				t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 			}
 
 			file := builder.Files.Get(fileID)
@@ -957,7 +958,7 @@ func TestParseWhileStatement(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			builder, fileID, bag := parseSource(t, tt.input)
 			if bag.HasErrors() {
-				t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+				t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 			}
 
 			file := builder.Files.Get(fileID)
@@ -1002,7 +1003,7 @@ func TestParseForClassicStatement(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -1052,7 +1053,7 @@ func TestParseForInStatement(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -1091,6 +1092,115 @@ func TestParseForInStatement(t *testing.T) {
 	}
 }
 
+func TestParseForInIndexedStatement(t *testing.T) {
+	input := `
+		fn foo() {
+			for (i, item: int) in items {
+				return;
+			}
+			for (j; j < 3; j = j + 1) {
+			}
+		}
+	`
+
+	builder, fileID, bag := parseSource(t, input)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
+	}
+
+	file := builder.Files.Get(fileID)
+	fnItem, ok := builder.Items.Fn(file.Items[0])
+	if !ok {
+		t.Fatal("expected fn item")
+	}
+
+	block := builder.Stmts.Block(fnItem.Body)
+	if block == nil || len(block.Stmts) != 2 {
+		t.Fatalf("expected two statements, got %+v", block)
+	}
+
+	forIn := builder.Stmts.ForIn(block.Stmts[0])
+	if forIn == nil {
+		t.Fatal("for-in payload missing")
+	}
+	if name := lookupNameOr(builder, forIn.Index, ""); name != "i" {
+		t.Fatalf("expected index name 'i', got %q", name)
+	}
+	if name := lookupNameOr(builder, forIn.Pattern, ""); name != "item" {
+		t.Fatalf("expected pattern name 'item', got %q", name)
+	}
+	if !forIn.Type.IsValid() {
+		t.Fatal("expected explicit type annotation")
+	}
+	if builder.Exprs.Get(forIn.Iterable) == nil {
+		t.Fatal("iterable expression missing")
+	}
+
+	// A parenthesised header without a comma is still a classic for.
+	if stmt := builder.Stmts.Get(block.Stmts[1]); stmt == nil || stmt.Kind != ast.StmtForClassic {
+		t.Fatalf("expected StmtForClassic, got %+v", stmt)
+	}
+}
+
+func TestParseLabeledLoopsAndJumps(t *testing.T) {
+	input := `
+		fn foo() {
+			outer: while true {
+				inner: for x in items {
+					continue outer;
+				}
+				break outer;
+			}
+			label = 1;
+		}
+	`
+
+	builder, fileID, bag := parseSource(t, input)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
+	}
+
+	file := builder.Files.Get(fileID)
+	fnItem, ok := builder.Items.Fn(file.Items[0])
+	if !ok {
+		t.Fatal("expected fn item")
+	}
+	block := builder.Stmts.Block(fnItem.Body)
+	if block == nil || len(block.Stmts) != 2 {
+		t.Fatalf("expected two statements, got %+v", block)
+	}
+
+	whileStmt := builder.Stmts.While(block.Stmts[0])
+	if whileStmt == nil {
+		t.Fatal("expected labeled while")
+	}
+	if name := lookupNameOr(builder, whileStmt.Label, ""); name != "outer" {
+		t.Fatalf("expected label 'outer', got %q", name)
+	}
+	body := builder.Stmts.Block(whileStmt.Body)
+	if body == nil || len(body.Stmts) != 2 {
+		t.Fatalf("expected two statements in while body, got %+v", body)
+	}
+	if name := lookupNameOr(builder, builder.Stmts.LoopLabel(body.Stmts[0]), ""); name != "inner" {
+		t.Fatalf("expected label 'inner', got %q", name)
+	}
+	forIn := builder.Stmts.ForIn(body.Stmts[0])
+	inner := builder.Stmts.Block(forIn.Body)
+	cont := builder.Stmts.Jump(inner.Stmts[0])
+	if cont == nil || lookupNameOr(builder, cont.Label, "") != "outer" {
+		t.Fatalf("expected 'continue outer', got %+v", cont)
+	}
+	brk := builder.Stmts.Jump(body.Stmts[1])
+	if brk == nil || lookupNameOr(builder, brk.Label, "") != "outer" {
+		t.Fatalf("expected 'break outer', got %+v", brk)
+	}
+
+	// An identifier not followed by `: while|for` is still an expression.
+	if stmt := builder.Stmts.Get(block.Stmts[1]); stmt == nil || stmt.Kind != ast.StmtExpr {
+		t.Fatalf("expected StmtExpr, got %+v", stmt)
+	}
+}
+
 func TestParseBreakContinueStatements(t *testing.T) {
 	input := `
 		fn foo() {
@@ -1101,7 +1211,7 @@ func TestParseBreakContinueStatements(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -1137,7 +1247,7 @@ func TestParseCompareExpressionStatement(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -1209,7 +1319,7 @@ func TestParseCompareExpressionStatementNoSemicolon(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -1237,6 +1347,134 @@ func TestParseCompareExpressionStatementNoSemicolon(t *testing.T) {
 	}
 }
 
+func TestParseComparePatternKinds(t *testing.T) {
+	input := `
+		fn foo() {
+			compare value {
+				1 => 10;
+				-1 => 11;
+				"s" => 12;
+				1..5 => 13;
+				'a'..='z' => 14;
+				Some(x) if x > 0 => 15;
+				n if n > 100 => 16;
+				lo..hi => 17;
+				finally => 18;
+			};
+		}
+	`
+
+	builder, fileID, bag := parseSource(t, input)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
+	}
+
+	file := builder.Files.Get(fileID)
+	fnItem, ok := builder.Items.Fn(file.Items[0])
+	if !ok {
+		t.Fatal("expected fn item")
+	}
+	block := builder.Stmts.Block(fnItem.Body)
+	exprStmt := builder.Stmts.Expr(block.Stmts[0])
+	if exprStmt == nil {
+		t.Fatal("expression payload missing")
+	}
+	data, ok := builder.Exprs.Compare(exprStmt.Expr)
+	if !ok {
+		t.Fatal("compare payload missing")
+	}
+
+	want := []struct {
+		kind  ast.ComparePatternKind
+		guard bool
+	}{
+		{ast.ComparePatternLiteral, false},
+		{ast.ComparePatternLiteral, false},
+		{ast.ComparePatternLiteral, false},
+		{ast.ComparePatternRange, false},
+		{ast.ComparePatternRange, false},
+		{ast.ComparePatternExpr, true},
+		{ast.ComparePatternExpr, true},
+		{ast.ComparePatternExpr, false},
+	}
+	if len(data.Arms) != len(want)+1 {
+		t.Fatalf("expected %d compare arms, got %d", len(want)+1, len(data.Arms))
+	}
+	for i, w := range want {
+		arm := data.Arms[i]
+		if arm.PatternKind != w.kind {
+			t.Errorf("arm %d: expected pattern kind %d, got %d", i, w.kind, arm.PatternKind)
+		}
+		if arm.Guard.IsValid() != w.guard {
+			t.Errorf("arm %d: expected guard=%v", i, w.guard)
+		}
+	}
+
+	rangeArm := data.Arms[4]
+	bin, ok := builder.Exprs.Binary(rangeArm.Pattern)
+	if !ok || bin.Op != ast.ExprBinaryRangeInclusive {
+		t.Fatalf("expected inclusive range pattern, got %+v", builder.Exprs.Get(rangeArm.Pattern))
+	}
+	if lit, ok := builder.Exprs.Literal(bin.Left); !ok || lit.Kind != ast.ExprLitChar {
+		t.Fatalf("expected char literal range start, got %+v", builder.Exprs.Get(bin.Left))
+	}
+	if !data.Arms[len(want)].IsFinally {
+		t.Fatal("expected last arm to be finally")
+	}
+}
+
+func TestParseCompareOrPattern(t *testing.T) {
+	input := `
+		fn foo() {
+			compare value {
+				1 | 2 | 3 => 10;
+				(1 | 2) => 11;
+				finally => 12;
+			};
+		}
+	`
+
+	builder, fileID, bag := parseSource(t, input)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
+	}
+
+	file := builder.Files.Get(fileID)
+	fnItem, ok := builder.Items.Fn(file.Items[0])
+	if !ok {
+		t.Fatal("expected fn item")
+	}
+	block := builder.Stmts.Block(fnItem.Body)
+	exprStmt := builder.Stmts.Expr(block.Stmts[0])
+	if exprStmt == nil {
+		t.Fatal("expression payload missing")
+	}
+	data, ok := builder.Exprs.Compare(exprStmt.Expr)
+	if !ok || len(data.Arms) != 3 {
+		t.Fatalf("expected 3 compare arms, got %+v", data)
+	}
+
+	orArm := data.Arms[0]
+	if orArm.PatternKind != ast.ComparePatternOr {
+		t.Fatalf("expected or-pattern, got kind %d", orArm.PatternKind)
+	}
+	alts := builder.Exprs.OrPatternAlternatives(orArm.Pattern)
+	if len(alts) != 3 {
+		t.Fatalf("expected 3 alternatives, got %d", len(alts))
+	}
+	for i, want := range []string{"1", "2", "3"} {
+		lit, ok := builder.Exprs.Literal(alts[i])
+		if !ok || lit.Kind != ast.ExprLitInt || builder.StringsInterner.MustLookup(lit.Value) != want {
+			t.Errorf("alternative %d: expected int literal %s, got %+v", i, want, builder.Exprs.Get(alts[i]))
+		}
+	}
+
+	// Скобки делают `|` обычным битовым ИЛИ внутри значения-паттерна.
+	if kind := data.Arms[1].PatternKind; kind != ast.ComparePatternExpr {
+		t.Fatalf("expected grouped bitwise or to stay an expression pattern, got kind %d", kind)
+	}
+}
+
 func TestParseLetWithCompareExpression(t *testing.T) {
 	input := `
 		fn foo() {
@@ -1249,7 +1487,7 @@ func TestParseLetWithCompareExpression(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -1293,7 +1531,7 @@ func TestParseIfElseChain(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -1334,7 +1572,7 @@ func TestParseForInWithoutTypeAnnotation(t *testing.T) {
 
 	builder, fileID, bag := parseSource(t, input)
 	if bag.HasErrors() {
-		t.Fatalf("unexpected errors: %s", diagnosticsSummary(bag))
+		t.Fatalf("unexpected errors: %s", diagtest.Summary(bag))
 	}
 
 	file := builder.Files.Get(fileID)
@@ -1470,3 +1708,35 @@ func TestBlockTypeDeclResyncKeepsFollowingStatements(t *testing.T) {
 		t.Fatalf("expected second statement to be return, got %v", second.Kind)
 	}
 }
+
+func TestFileScopeStatementResyncKeepsFollowingItems(t *testing.T) {
+	inputs := map[string]string{
+		"call":  `print("stray");`,
+		"block": `if ready { let x = 1; print("x"); } else { return; }`,
+		"expr":  `1 + 2;`,
+	}
+	for name, stray := range inputs {
+		t.Run(name, func(t *testing.T) {
+			input := "fn first() {}\n\n" + stray + "\n\nfn second() {}\n"
+			builder, fileID, bag := parseSource(t, input)
+
+			if len(bag.Items()) != 1 || bag.Items()[0].Code != diag.SynStatementAtFileScope {
+				t.Fatalf("expected a single SynStatementAtFileScope, got %s", diagtest.Summary(bag))
+			}
+
+			file := builder.Files.Get(fileID)
+			if file == nil || len(file.Items) != 2 {
+				t.Fatalf("expected both functions to parse, got %+v", file)
+			}
+			for i, want := range []string{"first", "second"} {
+				fnItem, ok := builder.Items.Fn(file.Items[i])
+				if !ok {
+					t.Fatalf("item %d: expected fn item", i)
+				}
+				if got := lookupNameOr(builder, fnItem.Name, ""); got != want {
+					t.Fatalf("item %d: got %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
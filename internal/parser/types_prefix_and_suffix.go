@@ -125,20 +125,28 @@ func (p *Parser) parseTypeSuffix(baseType ast.TypeID) (ast.TypeID, bool) {
 		var lengthExpr ast.ExprID
 		hasConstLen := false
 		lengthValue := uint64(0)
+		parsedLiteral := false
 		if p.at(token.IntLit) || p.at(token.UintLit) {
 			sizeTok := p.advance()
-			value, ok := p.parseArraySizeLiteral(sizeTok)
-			if !ok {
-				// ошибка уже зарепорчена
-				p.resyncUntil(token.RBracket, token.Semicolon, token.Comma)
-				if p.at(token.RBracket) {
-					p.advance()
+			if p.at(token.RBracket) {
+				value, ok := p.parseArraySizeLiteral(sizeTok)
+				if !ok {
+					// ошибка уже зарепорчена
+					p.resyncUntil(token.RBracket, token.Semicolon, token.Comma)
+					if p.at(token.RBracket) {
+						p.advance()
+					}
+					return ast.NoTypeID, false
 				}
-				return ast.NoTypeID, false
+				hasConstLen = true
+				lengthValue = value
+				parsedLiteral = true
+			} else {
+				// `T[2 * N]`: литерал — лишь начало константного выражения
+				p.lx.Push(sizeTok)
 			}
-			hasConstLen = true
-			lengthValue = value
-		} else {
+		}
+		if !parsedLiteral {
 			var ok bool
 			lengthExpr, ok = p.parseExpr()
 			if !ok {
@@ -138,6 +138,8 @@ func TestArrayTypes(t *testing.T) {
 		{"owned_slice", "let x: own int[];", "own int[]"},
 		{"ref_to_array", "let x: &int[5];", "&int[5]"},
 		{"qualified_array", "let x: foo.bar.Baz[];", "foo.bar.Baz[]"},
+		{"sized_const_expr", "let x: int[2 * N];", "int[?]"},
+		{"sized_literal_expr", "let x: int[1 + 2][];", "int[?][]"},
 	}
 
 	for _, tt := range tests {
@@ -272,6 +274,75 @@ func TestOptionalAndErrorableTypes(t *testing.T) {
 	}
 }
 
+func TestFixedArrayTypeShape(t *testing.T) {
+	type dim struct {
+		kind     ast.TypeArrayKind
+		constLen uint64
+		hasConst bool
+		hasExpr  bool
+	}
+	tests := []struct {
+		name  string
+		input string
+		dims  []dim // от внешнего измерения к внутреннему
+	}{
+		{"fixed", "let x: int[3];", []dim{{kind: ast.ArraySized, constLen: 3, hasConst: true}}},
+		{"dynamic", "let x: int[];", []dim{{kind: ast.ArraySlice}}},
+		{"nested_fixed", "let x: int[2][3];", []dim{
+			{kind: ast.ArraySized, constLen: 3, hasConst: true},
+			{kind: ast.ArraySized, constLen: 2, hasConst: true},
+		}},
+		{"const_expr", "let x: int[N + 1];", []dim{{kind: ast.ArraySized, hasExpr: true}}},
+		{"literal_then_op", "let x: int[3 * 4];", []dim{{kind: ast.ArraySized, hasExpr: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			letItem, arenas := parseTestInput(t, tt.input)
+			cur := letItem.Type
+			for i, want := range tt.dims {
+				arr, ok := arenas.Types.Array(cur)
+				if !ok || arr == nil {
+					t.Fatalf("dim %d: expected array type", i)
+				}
+				if arr.Kind != want.kind {
+					t.Fatalf("dim %d: kind = %v, want %v", i, arr.Kind, want.kind)
+				}
+				if arr.HasConstLen != want.hasConst || arr.ConstLength != want.constLen {
+					t.Fatalf("dim %d: const len = (%v, %d), want (%v, %d)", i, arr.HasConstLen, arr.ConstLength, want.hasConst, want.constLen)
+				}
+				if arr.Length.IsValid() != want.hasExpr {
+					t.Fatalf("dim %d: length expr present = %v, want %v", i, arr.Length.IsValid(), want.hasExpr)
+				}
+				cur = arr.Elem
+			}
+			if _, ok := arenas.Types.Array(cur); ok {
+				t.Fatalf("expected element type after %d dims, got another array", len(tt.dims))
+			}
+		})
+	}
+}
+
+func TestInvalidArraySizeTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"size_out_of_range", "let x: int[99999999999999999999999];"},
+		{"unclosed_size", "let x: int[3;"},
+		{"trailing_size_token", "let x: int[3 4];"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, bag := parseLetWithBag(t, tt.input)
+			if bag.Len() == 0 {
+				t.Fatalf("expected parsing errors for %q, got none", tt.input)
+			}
+		})
+	}
+}
+
 func TestInvalidOptionalAndErrorableTypes(t *testing.T) {
 	tests := []struct {
 		name  string
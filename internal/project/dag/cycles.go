@@ -0,0 +1,135 @@
+package dag
+
+import (
+	"fmt"
+	"slices"
+
+	"fortio.org/safecast"
+)
+
+// FindCyclePaths walks the graph depth-first and returns import cycles as ordered
+// module paths: path[i] imports path[i+1], and the last module imports path[0].
+// Only the first back edge found inside each strongly connected component is
+// kept, so a tangle of modules yields one cycle instead of one per back edge.
+// Every path is rotated to start at its smallest ModuleID, which keeps the
+// output deterministic.
+func FindCyclePaths(g Graph) [][]ModuleID {
+	const (
+		white = iota
+		gray
+		black
+	)
+	nodeCount := len(g.Edges)
+	color := make([]uint8, nodeCount)
+	comp := strongComponents(g)
+	reported := make(map[int]struct{})
+	stack := make([]ModuleID, 0, nodeCount)
+	var cycles [][]ModuleID
+
+	var visit func(id ModuleID)
+	visit = func(id ModuleID) {
+		color[int(id)] = gray
+		stack = append(stack, id)
+		for _, to := range g.Edges[int(id)] {
+			if !g.Present[int(to)] {
+				continue
+			}
+			switch color[int(to)] {
+			case white:
+				visit(to)
+			case gray:
+				// все модули на стеке от to до id лежат в одной компоненте
+				if _, done := reported[comp[int(to)]]; done {
+					continue
+				}
+				reported[comp[int(to)]] = struct{}{}
+				start := slices.Index(stack, to)
+				cycles = append(cycles, rotateToMin(stack[start:]))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		color[int(id)] = black
+	}
+
+	for i := range nodeCount {
+		if !g.Present[i] || color[i] != white {
+			continue
+		}
+		mID, err := safecast.Conv[ModuleID](i)
+		if err != nil {
+			panic(fmt.Errorf("module id overflow: %w", err))
+		}
+		visit(mID)
+	}
+	slices.SortStableFunc(cycles, func(a, b []ModuleID) int { return int(a[0]) - int(b[0]) })
+	return cycles
+}
+
+// strongComponents numbers the strongly connected components of the present
+// modules (Tarjan); comp[i] is -1 for modules that are only imported.
+func strongComponents(g Graph) []int {
+	nodeCount := len(g.Edges)
+	index := make([]int, nodeCount)
+	low := make([]int, nodeCount)
+	comp := make([]int, nodeCount)
+	onStack := make([]bool, nodeCount)
+	for i := range nodeCount {
+		index[i] = -1
+		comp[i] = -1
+	}
+	stack := make([]int, 0, nodeCount)
+	next, count := 0, 0
+
+	var connect func(v int)
+	connect = func(v int) {
+		index[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, to := range g.Edges[v] {
+			w := int(to)
+			if !g.Present[w] {
+				continue
+			}
+			if index[w] < 0 {
+				connect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] != index[v] {
+			return
+		}
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			comp[w] = count
+			if w == v {
+				break
+			}
+		}
+		count++
+	}
+
+	for i := range nodeCount {
+		if g.Present[i] && index[i] < 0 {
+			connect(i)
+		}
+	}
+	return comp
+}
+
+func rotateToMin(path []ModuleID) []ModuleID {
+	minAt := 0
+	for i, id := range path {
+		if id < path[minAt] {
+			minAt = i
+		}
+	}
+	out := make([]ModuleID, 0, len(path))
+	out = append(out, path[minAt:]...)
+	out = append(out, path[:minAt]...)
+	return out
+}
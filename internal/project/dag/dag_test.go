@@ -245,8 +245,120 @@ func TestReportCycles(t *testing.T) {
 	if bagA.Len() != 1 || bagA.Items()[0].Code != diag.ProjImportCycle {
 		t.Fatalf("module a diagnostics = %v", bagA.Items())
 	}
-	if bagB.Len() != 1 || bagB.Items()[0].Code != diag.ProjImportCycle {
-		t.Fatalf("module b diagnostics = %v", bagB.Items())
+	if bagB.Len() != 0 {
+		t.Fatalf("the cycle must be reported once, module b got %v", bagB.Items())
+	}
+	d := bagA.Items()[0]
+	if d.Message != "import cycle detected: a -> b -> a" {
+		t.Fatalf("unexpected cycle message %q", d.Message)
+	}
+	if len(d.Notes) != 2 || d.Notes[0].Span != spanA || d.Notes[1].Span != spanB {
+		t.Fatalf("expected ordered notes for a->b and b->a, got %+v", d.Notes)
+	}
+	if d.Primary != spanA {
+		t.Fatalf("primary = %v, want the import in module a", d.Primary)
+	}
+	for _, slot := range slots {
+		if !slot.Broken || slot.FirstErr == nil || slot.FirstErr.Code != diag.ProjImportCycle {
+			t.Fatalf("module %s must be broken by the cycle, got %+v", slot.Meta.Path, slot)
+		}
+	}
+}
+
+func TestReportCyclesThreeModules(t *testing.T) {
+	spanApp := source.Span{File: 1, Start: 0, End: 4}
+	spanA := source.Span{File: 2, Start: 0, End: 4}
+	spanB := source.Span{File: 3, Start: 0, End: 4}
+	spanC := source.Span{File: 4, Start: 0, End: 4}
+
+	metas := []*project.ModuleMeta{
+		{Path: "app", Span: spanApp, Imports: []project.ImportMeta{{Path: "b", Span: spanApp}}},
+		{Path: "a", Span: spanA, Imports: []project.ImportMeta{{Path: "b", Span: spanA}}},
+		{Path: "b", Span: spanB, Imports: []project.ImportMeta{{Path: "c", Span: spanB}}},
+		{Path: "c", Span: spanC, Imports: []project.ImportMeta{{Path: "a", Span: spanC}}},
+	}
+	bags := make(map[string]*diag.Bag, len(metas))
+	nodes := make([]*ModuleNode, 0, len(metas))
+	for _, meta := range metas {
+		bags[meta.Path] = diag.NewBag(10)
+		nodes = append(nodes, &ModuleNode{Meta: meta, Reporter: &diag.BagReporter{Bag: bags[meta.Path]}})
+	}
+
+	idx := BuildIndex(metas)
+	graph, slots := BuildGraph(idx, nodes)
+	topo := ToposortKahn(graph)
+	if !topo.Cyclic || len(topo.CyclePaths) != 1 {
+		t.Fatalf("expected one cycle path, got %+v", topo)
+	}
+	if got := idsToNames(idx, topo.CyclePaths[0]); len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("cycle path = %v, want [a b c]", got)
+	}
+
+	ReportCycles(idx, slots, topo)
+
+	if bags["app"].Len() != 0 {
+		t.Fatalf("module app is outside the cycle: %v", bags["app"].Items())
+	}
+	for _, name := range []string{"b", "c"} {
+		if bags[name].Len() != 0 {
+			t.Fatalf("the cycle must be reported once, module %s got %v", name, bags[name].Items())
+		}
+	}
+	if bags["a"].Len() != 1 {
+		t.Fatalf("expected one cycle diagnostic on module a, got %v", bags["a"].Items())
+	}
+	d := bags["a"].Items()[0]
+	if d.Message != "import cycle detected: a -> b -> c -> a" {
+		t.Fatalf("unexpected cycle message %q", d.Message)
+	}
+	if d.Primary != spanA {
+		t.Fatalf("primary = %v, want the import in module a %v", d.Primary, spanA)
+	}
+	wantNotes := []source.Span{spanA, spanB, spanC}
+	if len(d.Notes) != len(wantNotes) {
+		t.Fatalf("expected %d notes, got %+v", len(wantNotes), d.Notes)
+	}
+	for j, want := range wantNotes {
+		if d.Notes[j].Span != want {
+			t.Fatalf("note %d span = %v, want %v", j, d.Notes[j].Span, want)
+		}
+	}
+
+	// app импортирует звено цикла и получает ошибку зависимости, звенья друг о друге — нет
+	ReportBrokenDeps(idx, slots)
+	if bags["app"].Len() != 1 || bags["app"].Items()[0].Code != diag.ProjDependencyFailed {
+		t.Fatalf("module app diagnostics = %v", bags["app"].Items())
+	}
+	if bags["b"].Len() != 0 || bags["c"].Len() != 0 || bags["a"].Len() != 1 {
+		t.Fatalf("cycle members must not report each other as broken dependencies")
+	}
+}
+
+func TestFindCyclePathsOnePerComponent(t *testing.T) {
+	// a <-> b и c <-> d связаны в одну компоненту рёбрами b -> c и d -> a
+	metas := []*project.ModuleMeta{
+		{Path: "a", Imports: []project.ImportMeta{{Path: "b"}}},
+		{Path: "b", Imports: []project.ImportMeta{{Path: "a"}, {Path: "c"}}},
+		{Path: "c", Imports: []project.ImportMeta{{Path: "d"}}},
+		{Path: "d", Imports: []project.ImportMeta{{Path: "a"}, {Path: "c"}}},
+		{Path: "x", Imports: []project.ImportMeta{{Path: "y"}}},
+		{Path: "y", Imports: []project.ImportMeta{{Path: "x"}}},
+	}
+	nodes := make([]*ModuleNode, 0, len(metas))
+	for _, meta := range metas {
+		nodes = append(nodes, &ModuleNode{Meta: meta})
+	}
+	idx := BuildIndex(metas)
+	graph, _ := BuildGraph(idx, nodes)
+	paths := FindCyclePaths(graph)
+	if len(paths) != 2 {
+		t.Fatalf("expected one cycle per component, got %d: %v", len(paths), paths)
+	}
+	if got := idsToNames(idx, paths[0]); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("first cycle = %v, want [a b]", got)
+	}
+	if got := idsToNames(idx, paths[1]); len(got) != 2 || got[0] != "x" || got[1] != "y" {
+		t.Fatalf("second cycle = %v, want [x y]", got)
 	}
 }
 
@@ -159,25 +159,67 @@ func BuildGraph(idx ModuleIndex, nodes []*ModuleNode) (Graph, []ModuleSlot) {
 	return g, slots
 }
 
-// ReportCycles emits diagnostics for any import cycles detected in the graph.
+// ReportCycles emits one diagnostic per import cycle listing the whole path,
+// with one note per import edge. It is reported on the first module of the
+// path; every module of the cycle is marked broken with it as the first error.
 func ReportCycles(idx ModuleIndex, slots []ModuleSlot, topo *Topo) {
-	if topo == nil || !topo.Cyclic || len(topo.Cycles) == 0 {
+	if topo == nil || !topo.Cyclic {
 		return
 	}
-	names := make([]string, 0, len(topo.Cycles))
-	for _, id := range topo.Cycles {
-		names = append(names, idx.IDToName[int(id)])
+	for _, path := range topo.CyclePaths {
+		if len(path) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(path)+1)
+		for _, id := range path {
+			names = append(names, idx.IDToName[int(id)])
+		}
+		names = append(names, names[0])
+
+		notes := make([]diag.Note, 0, len(path))
+		for i, id := range path {
+			from := slots[int(id)].Meta
+			to := names[i+1]
+			notes = append(notes, diag.Note{
+				Span: importSpan(from, to),
+				Msg:  fmt.Sprintf("%q imports %q", from.Path, to),
+			})
+		}
+
+		cycle := &diag.Diagnostic{
+			Severity: diag.SevError,
+			Code:     diag.ProjImportCycle,
+			Message:  fmt.Sprintf("import cycle detected: %s", strings.Join(names, " -> ")),
+			Notes:    notes,
+		}
+		// primary — импорт следующего звена у первого модуля, которому есть куда сообщить
+		for i, id := range path {
+			slot := slots[int(id)]
+			if !slot.Present || slot.Reporter == nil {
+				continue
+			}
+			cycle.Primary = notes[i].Span
+			slot.Reporter.Report(cycle.Code, cycle.Severity, cycle.Primary, cycle.Message, notes, nil)
+			break
+		}
+		for _, id := range path {
+			slot := &slots[int(id)]
+			slot.Broken = true
+			if slot.FirstErr == nil {
+				slot.FirstErr = cycle
+			}
+		}
 	}
-	summary := strings.Join(names, " -> ")
+}
 
-	for _, id := range topo.Cycles {
-		slot := slots[int(id)]
-		if !slot.Present || slot.Reporter == nil {
-			continue
+// importSpan returns the span of the import of target inside meta, falling back to the module span.
+func importSpan(meta *project.ModuleMeta, target string) source.Span {
+	for _, imp := range meta.Imports {
+		if imp.Path == target && imp.Span != (source.Span{}) {
+			return imp.Span
 		}
-		msg := fmt.Sprintf("module %q participates in an import cycle: %s", slot.Meta.Path, summary)
-		slot.Reporter.Report(diag.ProjImportCycle, diag.SevError, slot.Meta.Span, msg, nil, nil)
 	}
+	return meta.Span
 }
 
 // ReportBrokenDeps emits diagnostics for modules that depend on broken modules.
@@ -197,6 +239,10 @@ func ReportBrokenDeps(idx ModuleIndex, slots []ModuleSlot) {
 			if !depSlot.Broken {
 				continue
 			}
+			// звенья одного цикла уже покрыты его диагностикой
+			if depSlot.FirstErr != nil && depSlot.FirstErr == slotFrom.FirstErr {
+				continue
+			}
 			key := imp.Path + "|" + imp.Span.String()
 			if _, seen := emitted[key]; seen {
 				continue
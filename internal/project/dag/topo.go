@@ -13,6 +13,8 @@ type Topo struct {
 	Batches [][]ModuleID // волны независимых модулей
 	Cyclic  bool
 	Cycles  []ModuleID // узлы, оставшиеся в цикле
+	// CyclePaths — упорядоченные пути циклов (см. FindCyclePaths)
+	CyclePaths [][]ModuleID
 }
 
 // ToposortKahn performs a topological sort on the graph using Kahn's algorithm.
@@ -87,6 +89,7 @@ func ToposortKahn(g Graph) *Topo {
 			}
 		}
 		slices.Sort(topo.Cycles)
+		topo.CyclePaths = FindCyclePaths(g)
 	}
 
 	return topo
@@ -0,0 +1,80 @@
+package sema
+
+import (
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func runAsyncCaptureSema(t *testing.T, src string) *diag.Bag {
+	t.Helper()
+	builder, fileID, bag := parseSource(t, src)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(bag))
+	}
+	symRes := resolveSymbols(t, builder, fileID)
+	semaBag := diag.NewBag(16)
+	Check(t.Context(), builder, fileID, Options{
+		Reporter: &diag.BagReporter{Bag: semaBag},
+		Symbols:  symRes,
+	})
+	return semaBag
+}
+
+func TestAsyncBlockReadsCapturesByValue(t *testing.T) {
+	bag := runAsyncCaptureSema(t, `
+fn main() {
+    let mut base = 7;
+    let xs: int[] = [1, 2, 3];
+    let t = spawn async {
+        return base + xs[0];
+    };
+    base = 0;
+}
+`)
+	if hasCode(bag, diag.SemaAsyncCaptureMutation) || hasCode(bag, diag.SemaUseAfterMove) {
+		t.Fatalf("unexpected capture diagnostics: %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestAsyncBlockCannotMutateCaptures(t *testing.T) {
+	bag := runAsyncCaptureSema(t, `
+fn bump(r: &mut int) {
+    *r = *r + 1;
+}
+
+fn main() {
+    let mut hits = 0;
+    let mut seen = 0;
+    let t = spawn async {
+        hits = hits + 1;
+        bump(&mut seen);
+        return 0;
+    };
+}
+`)
+	count := 0
+	for _, d := range bag.Items() {
+		if d.Code == diag.SemaAsyncCaptureMutation {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 SemaAsyncCaptureMutation diagnostics, got %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestAsyncBlockMovesNonCopyCaptures(t *testing.T) {
+	bag := runAsyncCaptureSema(t, `
+fn main() {
+    let xs: int[] = [1, 2, 3];
+    let t = spawn async {
+        return xs[0];
+    };
+    let n = xs[1];
+}
+`)
+	if !hasCode(bag, diag.SemaUseAfterMove) {
+		t.Fatalf("expected use-after-move after capturing xs, got %s", diagnosticsSummary(bag))
+	}
+}
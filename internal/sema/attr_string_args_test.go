@@ -0,0 +1,41 @@
+package sema
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func TestAttrStringArgsAcceptRawLiterals(t *testing.T) {
+	parseBag, semaBag := runSemaOnSnippet(t, `
+@backend(r"cpu")
+fn kernel() -> nothing {
+	return nothing;
+}
+
+@deprecated(r#"use "fresh" instead"#)
+fn stale() -> nothing {
+	return nothing;
+}
+
+fn main() -> nothing {
+	kernel();
+	stale();
+	return nothing;
+}
+`)
+	if parseBag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(parseBag))
+	}
+	if d := findDiag(semaBag, diag.SemaAttrBackendUnknown); d != nil {
+		t.Fatalf("raw string backend target must be unquoted, got %q", d.Message)
+	}
+	d := findDiag(semaBag, diag.SemaDeprecatedUsage)
+	if d == nil {
+		t.Fatalf("expected %s, got %s", diag.SemaDeprecatedUsage.ID(), diagnosticsSummary(semaBag))
+	}
+	if !strings.Contains(d.Message, `use "fresh" instead`) || strings.Contains(d.Message, `r#`) {
+		t.Fatalf("expected the raw deprecation message verbatim, got %q", d.Message)
+	}
+}
@@ -1,11 +1,10 @@
 package sema
 
 import (
-	"strings"
-
 	"surge/internal/ast"
 	"surge/internal/diag"
 	"surge/internal/source"
+	"surge/internal/token"
 	"surge/internal/types"
 )
 
@@ -52,9 +51,8 @@ func (tc *typeChecker) validateFieldReferenceWithType(info AttrInfo, ownerTypeID
 		return types.NoTypeID
 	}
 
-	// Get the field name - strip quotes from string literal
-	fieldNameRaw := tc.lookupName(lit.Value)
-	fieldNameStr := strings.Trim(fieldNameRaw, "\"")
+	// Get the field name - unquote the string literal
+	fieldNameStr := token.UnquoteString(tc.lookupName(lit.Value))
 
 	// Validate that the field exists in ownerTypeID
 	if ownerTypeID == types.NoTypeID {
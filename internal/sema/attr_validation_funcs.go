@@ -2,12 +2,12 @@ package sema
 
 import (
 	"strconv"
-	"strings"
 
 	"surge/internal/ast"
 	"surge/internal/diag"
 	"surge/internal/source"
 	"surge/internal/symbols"
+	"surge/internal/token"
 	"surge/internal/types"
 )
 
@@ -82,9 +82,7 @@ func (tc *typeChecker) validateBackendParameter(info AttrInfo) bool {
 	}
 
 	// Get the string value
-	target := tc.lookupName(lit.Value)
-	// Strip quotes from string literal
-	target = strings.Trim(target, "\"")
+	target := token.UnquoteString(tc.lookupName(lit.Value))
 
 	// Known backend targets
 	knownTargets := map[string]bool{
@@ -264,8 +262,7 @@ func (tc *typeChecker) getDeprecatedMessage(attrs []AttrInfo) string {
 	if !ok || lit.Kind != ast.ExprLitString {
 		return ""
 	}
-	msg := tc.lookupName(lit.Value)
-	return strings.Trim(msg, "\"")
+	return token.UnquoteString(tc.lookupName(lit.Value))
 }
 
 // checkDeprecatedSymbol emits a warning if the symbol is deprecated
@@ -7,25 +7,56 @@ import (
 )
 
 type blockingCapture struct {
-	symID  symbols.SymbolID
-	exprID ast.ExprID
-	span   source.Span
+	symID   symbols.SymbolID
+	exprID  ast.ExprID
+	span    source.Span
+	mutated bool // assigned or borrowed as &mut inside the scanned code
 }
 
 func (tc *typeChecker) collectBlockingCaptures(stmtID ast.StmtID) []blockingCapture {
 	if tc == nil || tc.builder == nil || !stmtID.IsValid() {
 		return nil
 	}
+	return tc.collectCaptures(stmtID, ast.NoExprID)
+}
+
+// collectCaptures returns outer-scope bindings referenced from stmtID and/or
+// rootExpr, in order of first use.
+func (tc *typeChecker) collectCaptures(stmtID ast.StmtID, rootExpr ast.ExprID) []blockingCapture {
 	scopeSet := make(map[symbols.ScopeID]struct{}, len(tc.scopeStack))
 	for _, scope := range tc.scopeStack {
 		scopeSet[scope] = struct{}{}
 	}
 	seen := make(map[symbols.SymbolID]struct{})
+	mutated := make(map[symbols.SymbolID]struct{})
 	var captures []blockingCapture
 
 	var scanExpr func(ast.ExprID)
 	var scanStmt func(ast.StmtID)
 
+	markMutated := func(exprID ast.ExprID) {
+		for exprID.IsValid() {
+			expr := tc.builder.Exprs.Get(exprID)
+			if expr == nil {
+				return
+			}
+			switch expr.Kind {
+			case ast.ExprGroup:
+				data, ok := tc.builder.Exprs.Group(exprID)
+				if !ok || data == nil {
+					return
+				}
+				exprID = data.Inner
+				continue
+			case ast.ExprIdent:
+				if symID := tc.symbolForExpr(exprID); symID.IsValid() {
+					mutated[symID] = struct{}{}
+				}
+			}
+			return
+		}
+	}
+
 	scanExpr = func(exprID ast.ExprID) {
 		if !exprID.IsValid() {
 			return
@@ -64,11 +95,17 @@ func (tc *typeChecker) collectBlockingCaptures(stmtID ast.StmtID) []blockingCapt
 			return
 		case ast.ExprBinary:
 			if data, ok := tc.builder.Exprs.Binary(exprID); ok && data != nil {
+				if isAssignmentOp(data.Op) {
+					markMutated(data.Left)
+				}
 				scanExpr(data.Left)
 				scanExpr(data.Right)
 			}
 		case ast.ExprUnary:
 			if data, ok := tc.builder.Exprs.Unary(exprID); ok && data != nil {
+				if data.Op == ast.ExprUnaryRefMut {
+					markMutated(data.Operand)
+				}
 				scanExpr(data.Operand)
 			}
 		case ast.ExprGroup:
@@ -183,6 +220,7 @@ func (tc *typeChecker) collectBlockingCaptures(stmtID ast.StmtID) []blockingCapt
 			}
 		case ast.ExprStruct:
 			if data, ok := tc.builder.Exprs.Struct(exprID); ok && data != nil {
+				scanExpr(data.Base)
 				for _, field := range data.Fields {
 					scanExpr(field.Value)
 				}
@@ -267,6 +305,12 @@ func (tc *typeChecker) collectBlockingCaptures(stmtID ast.StmtID) []blockingCapt
 	}
 
 	scanStmt(stmtID)
+	scanExpr(rootExpr)
+	for i := range captures {
+		if _, ok := mutated[captures[i].symID]; ok {
+			captures[i].mutated = true
+		}
+	}
 	return captures
 }
 
@@ -0,0 +1,187 @@
+package sema
+
+import (
+	"fmt"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/source"
+	"surge/internal/symbols"
+	"surge/internal/types"
+)
+
+// borrowOrigin is a place a value points into together with the span of the
+// `&` expression that produced the reference.
+type borrowOrigin struct {
+	place Place
+	span  source.Span
+}
+
+// returnedBorrow records a function return whose value carries a borrow. The
+// origin is captured while walking (binding state is live there); whether it
+// escapes is decided in the "borrow" phase once the whole file is walked.
+type returnedBorrow struct {
+	origin borrowOrigin
+	span   source.Span
+}
+
+// noteReturnedBorrow remembers the borrows carried by a value returned from
+// the current function: a reference, or an aggregate holding one.
+func (tc *typeChecker) noteReturnedBorrow(expr ast.ExprID) {
+	if tc.borrow == nil || !expr.IsValid() || tc.result == nil {
+		return
+	}
+	if !tc.typeHoldsReference(tc.result.ExprTypes[expr], 0) {
+		return
+	}
+	span := tc.exprSpan(expr)
+	for _, origin := range tc.borrowOrigins(expr) {
+		tc.returnedBorrows = append(tc.returnedBorrows, returnedBorrow{origin: origin, span: span})
+	}
+}
+
+// rememberBindingOrigins keeps the borrows stored inside a non-reference
+// binding (e.g. a struct with a reference field) so that returning the binding
+// or one of its fields later can be traced back to the borrowed place.
+func (tc *typeChecker) rememberBindingOrigins(symID symbols.SymbolID, expr ast.ExprID) {
+	if tc.bindingOrigins == nil {
+		return
+	}
+	delete(tc.bindingOrigins, symID)
+	if tc.result == nil || !expr.IsValid() || !tc.typeHoldsReference(tc.result.ExprTypes[expr], 0) {
+		return
+	}
+	if origins := tc.borrowOrigins(expr); len(origins) > 0 {
+		tc.bindingOrigins[symID] = origins
+	}
+}
+
+// borrowOrigins returns the places the value of expr may point into.
+func (tc *typeChecker) borrowOrigins(expr ast.ExprID) []borrowOrigin {
+	expr = tc.unwrapGroupExpr(expr)
+	if tc.borrow == nil || tc.builder == nil || !expr.IsValid() {
+		return nil
+	}
+	if bid := tc.inheritedBorrowForExpr(expr); bid != NoBorrowID {
+		if info := tc.borrow.Info(bid); info != nil {
+			return []borrowOrigin{{place: info.Place, span: info.Span}}
+		}
+		return nil
+	}
+	node := tc.builder.Exprs.Get(expr)
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case ast.ExprIdent:
+		if symID := tc.symbolForExpr(expr); symID.IsValid() {
+			return tc.bindingOrigins[symID]
+		}
+	case ast.ExprStruct:
+		data, ok := tc.builder.Exprs.Struct(expr)
+		if !ok || data == nil {
+			return nil
+		}
+		origins := tc.borrowOrigins(data.Base)
+		for _, field := range data.Fields {
+			origins = append(origins, tc.borrowOrigins(field.Value)...)
+		}
+		return origins
+	case ast.ExprTuple:
+		data, ok := tc.builder.Exprs.Tuple(expr)
+		if !ok || data == nil {
+			return nil
+		}
+		var origins []borrowOrigin
+		for _, elem := range data.Elements {
+			origins = append(origins, tc.borrowOrigins(elem)...)
+		}
+		return origins
+	case ast.ExprMember:
+		// Поле наследует заимствования агрегата, только если само может их хранить.
+		data, ok := tc.builder.Exprs.Member(expr)
+		if !ok || data == nil || tc.result == nil || !tc.typeHoldsReference(tc.result.ExprTypes[expr], 0) {
+			return nil
+		}
+		return tc.borrowOrigins(data.Target)
+	}
+	return nil
+}
+
+// typeHoldsReference reports whether values of id may carry a reference:
+// references themselves and structs or tuples with such a component.
+func (tc *typeChecker) typeHoldsReference(id types.TypeID, depth int) bool {
+	if id == types.NoTypeID || tc.types == nil || depth > 8 {
+		return false
+	}
+	id = tc.resolveAlias(id)
+	tt, ok := tc.types.Lookup(id)
+	if !ok {
+		return false
+	}
+	switch tt.Kind {
+	case types.KindReference:
+		return true
+	case types.KindStruct:
+		for _, field := range tc.types.StructFields(id) {
+			if tc.typeHoldsReference(field.Type, depth+1) {
+				return true
+			}
+		}
+	case types.KindTuple:
+		if info, ok := tc.types.TupleInfo(id); ok {
+			for _, elem := range info.Elems {
+				if tc.typeHoldsReference(elem, depth+1) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// checkEscapingBorrows reports returned references that point into storage
+// owned by the returning function: a local binding or a by-value parameter.
+// Borrows through reference parameters and of module-level bindings outlive
+// the call and are fine.
+func (tc *typeChecker) checkEscapingBorrows() {
+	for _, ret := range tc.returnedBorrows {
+		sym := tc.functionLocal(ret.origin.place.Base)
+		if sym == nil {
+			continue
+		}
+		label := tc.placeLabel(ret.origin.place)
+		msg := fmt.Sprintf("cannot return a reference to local %s", label)
+		builder := diag.ReportError(tc.reporter, diag.SemaBorrowEscapesFn, ret.span, msg)
+		if builder == nil {
+			continue
+		}
+		builder.WithNote(ret.origin.span, fmt.Sprintf("%s is borrowed here", label))
+		if sym.Span != (source.Span{}) {
+			builder.WithNote(sym.Span, fmt.Sprintf("'%s' is declared here and dropped when the function returns", tc.lookupName(sym.Name)))
+		}
+		builder.Emit()
+	}
+	tc.returnedBorrows = tc.returnedBorrows[:0]
+}
+
+// functionLocal returns the symbol behind symID when it names storage that
+// dies with the enclosing function call.
+func (tc *typeChecker) functionLocal(symID symbols.SymbolID) *symbols.Symbol {
+	sym := tc.symbolFromID(symID)
+	if sym == nil || (sym.Kind != symbols.SymbolLet && sym.Kind != symbols.SymbolParam) {
+		return nil
+	}
+	// Через ссылку заимствуется чужое значение, а не сам биндинг.
+	if tc.isReferenceType(tc.bindingType(symID)) {
+		return nil
+	}
+	if tc.symbols == nil || tc.symbols.Table == nil || tc.symbols.Table.Scopes == nil {
+		return nil
+	}
+	scope := tc.symbols.Table.Scopes.Get(sym.Scope)
+	if scope == nil || (scope.Kind != symbols.ScopeFunction && scope.Kind != symbols.ScopeBlock) {
+		return nil
+	}
+	return sym
+}
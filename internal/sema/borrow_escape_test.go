@@ -0,0 +1,191 @@
+package sema
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func findDiag(bag *diag.Bag, code diag.Code) *diag.Diagnostic {
+	for _, d := range bag.Items() {
+		if d.Code == code {
+			return d
+		}
+	}
+	return nil
+}
+
+func TestBorrowPassReportsUseAfterMoveWithMoveSite(t *testing.T) {
+	parseBag, semaBag := runSemaOnSnippet(t, `
+fn consume(s: string) -> nothing {
+	return nothing;
+}
+
+fn main() -> nothing {
+	let s = "hi";
+	consume(s);
+	consume(s);
+	return nothing;
+}
+`)
+	if parseBag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(parseBag))
+	}
+	d := findDiag(semaBag, diag.SemaUseAfterMove)
+	if d == nil {
+		t.Fatalf("expected %s, got %s", diag.SemaUseAfterMove.ID(), diagnosticsSummary(semaBag))
+	}
+	if len(d.Notes) == 0 || d.Notes[0].Span == d.Primary {
+		t.Fatalf("expected a note pointing at the move site, got %+v", d.Notes)
+	}
+}
+
+func TestBorrowPassReportsDoubleMutableBorrowWithPreviousBorrow(t *testing.T) {
+	parseBag, semaBag := runSemaOnSnippet(t, `
+fn main() -> nothing {
+	let mut x: int = 1;
+	let a: &mut int = &mut x;
+	let b: &mut int = &mut x;
+	*a = 2;
+	*b = 3;
+	return nothing;
+}
+`)
+	if parseBag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(parseBag))
+	}
+	d := findDiag(semaBag, diag.SemaBorrowConflict)
+	if d == nil {
+		t.Fatalf("expected %s, got %s", diag.SemaBorrowConflict.ID(), diagnosticsSummary(semaBag))
+	}
+	if len(d.Notes) == 0 || d.Notes[0].Span == d.Primary {
+		t.Fatalf("expected a note pointing at the previous borrow, got %+v", d.Notes)
+	}
+}
+
+func TestBorrowPassReportsReturnedLocalReference(t *testing.T) {
+	parseBag, semaBag := runSemaOnSnippet(t, `
+type Point = { x: int, y: int };
+
+fn alias() -> &int {
+	let x: int = 1;
+	let r: &int = &x;
+	return r;
+}
+
+fn byval(p: Point) -> &int {
+	return &p.x;
+}
+`)
+	if parseBag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(parseBag))
+	}
+	count := 0
+	for _, d := range semaBag.Items() {
+		if d.Code != diag.SemaBorrowEscapesFn {
+			continue
+		}
+		count++
+		if len(d.Notes) == 0 {
+			t.Fatalf("expected a note at the borrow site, got none")
+		}
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 %s diagnostics, got %s", diag.SemaBorrowEscapesFn.ID(), diagnosticsSummary(semaBag))
+	}
+}
+
+func TestBorrowPassAllowsReturningBorrowedParams(t *testing.T) {
+	parseBag, semaBag := runSemaOnSnippet(t, `
+type Point = { x: int, y: int };
+
+let G: int = 5;
+
+fn through(p: &Point) -> &int {
+	return &p.x;
+}
+
+fn global() -> &int {
+	return &G;
+}
+`)
+	if parseBag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(parseBag))
+	}
+	if findDiag(semaBag, diag.SemaBorrowEscapesFn) != nil {
+		t.Fatalf("unexpected escape diagnostic: %s", diagnosticsSummary(semaBag))
+	}
+}
+
+func TestBorrowPassReturnedLocalNotesDeclaration(t *testing.T) {
+	src := `
+fn f() -> &int {
+	let x = 0;
+	return &x;
+}
+`
+	parseBag, semaBag := runSemaOnSnippet(t, src)
+	if parseBag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(parseBag))
+	}
+	d := findDiag(semaBag, diag.SemaBorrowEscapesFn)
+	if d == nil {
+		t.Fatalf("expected %s, got %s", diag.SemaBorrowEscapesFn.ID(), diagnosticsSummary(semaBag))
+	}
+	declStart := strings.Index(src, "let x")
+	found := false
+	for _, note := range d.Notes {
+		if int(note.Span.Start) == declStart {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a note at the declaration of 'x', got %+v", d.Notes)
+	}
+}
+
+func TestBorrowPassReportsLocalEscapingThroughStructField(t *testing.T) {
+	parseBag, semaBag := runSemaOnSnippet(t, `
+type Holder = { r: &int, n: int };
+
+fn literal() -> Holder {
+	let x: int = 0;
+	return Holder { r: &x, n: 1 };
+}
+
+fn binding() -> Holder {
+	let x: int = 0;
+	let h: Holder = Holder { r: &x, n: 1 };
+	return h;
+}
+
+fn viaField() -> &int {
+	let x: int = 0;
+	let h: Holder = Holder { r: &x, n: 1 };
+	return h.r;
+}
+
+fn plain() -> int {
+	let x: int = 0;
+	let h: Holder = Holder { r: &x, n: 1 };
+	return h.n;
+}
+
+fn forwarded(h: Holder) -> &int {
+	return h.r;
+}
+`)
+	if parseBag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(parseBag))
+	}
+	count := 0
+	for _, d := range semaBag.Items() {
+		if d.Code == diag.SemaBorrowEscapesFn {
+			count++
+		}
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 %s diagnostics, got %s", diag.SemaBorrowEscapesFn.ID(), diagnosticsSummary(semaBag))
+	}
+}
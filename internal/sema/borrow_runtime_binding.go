@@ -39,6 +39,7 @@ func (tc *typeChecker) updateBindingValue(symID symbols.SymbolID, expr ast.ExprI
 	}
 	bid := tc.bindingBorrowForExpr(symID, expr)
 	tc.bindingBorrow[symID] = bid
+	tc.rememberBindingOrigins(symID, expr)
 	if bid != NoBorrowID && tc.borrowBindings != nil {
 		if _, exists := tc.borrowBindings[bid]; !exists {
 			tc.borrowBindings[bid] = symID
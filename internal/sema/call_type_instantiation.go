@@ -72,7 +72,10 @@ func (tc *typeChecker) instantiateTypeKeyWithInference(key symbols.TypeKey, actu
 		}
 		if hasLen {
 			lenType := types.NoTypeID
-			if _, actualLen, okLen := tc.arrayFixedInfo(actual); okLen && actualLen > 0 {
+			if _, err := parseUint(lengthKey); err == nil {
+				// `T[3]`: длина задана литералом, а не выводится из аргумента
+				lengthKey = ""
+			} else if _, actualLen, okLen := tc.arrayFixedInfo(actual); okLen && actualLen > 0 {
 				lenType = tc.types.Intern(types.MakeConstUint(actualLen))
 			}
 			if lengthKey != "" {
@@ -55,9 +55,12 @@ type Result struct {
 	MagicBinarySymbols     map[ast.ExprID]symbols.SymbolID   // Resolved magic symbols for binary operators
 	IndexSymbols           map[ast.ExprID]symbols.SymbolID   // Resolved magic symbols for index expressions
 	IndexSetSymbols        map[ast.ExprID]symbols.SymbolID   // Resolved magic symbols for index assignment
+	ReceiverAdjusts        map[ast.ExprID]ReceiverAdjust     // Auto-borrow/deref of method call receivers
 	BindingTypes           map[symbols.SymbolID]types.TypeID // Maps symbol IDs to their resolved types
 	ItemScopes             map[ast.ItemID]symbols.ScopeID    // Maps items to their scopes (for HIR lowering)
 	BlockingCaptures       map[ast.ExprID][]symbols.SymbolID // Captures for blocking { ... } expressions
+	ParallelCaptures       map[ast.ExprID][]symbols.SymbolID // Captures for parallel map/reduce bodies
+	AsyncCaptures          map[ast.ExprID][]symbols.SymbolID // Captures for async { ... } blocks
 }
 
 // Check performs semantic analysis (type inference, borrow checks, etc.).
@@ -80,7 +83,10 @@ func Check(ctx context.Context, builder *ast.Builder, fileID ast.FileID, opts Op
 		MagicBinarySymbols:     make(map[ast.ExprID]symbols.SymbolID),
 		IndexSymbols:           make(map[ast.ExprID]symbols.SymbolID),
 		IndexSetSymbols:        make(map[ast.ExprID]symbols.SymbolID),
+		ReceiverAdjusts:        make(map[ast.ExprID]ReceiverAdjust),
 		BlockingCaptures:       make(map[ast.ExprID][]symbols.SymbolID),
+		ParallelCaptures:       make(map[ast.ExprID][]symbols.SymbolID),
+		AsyncCaptures:          make(map[ast.ExprID][]symbols.SymbolID),
 	}
 	if opts.Types != nil {
 		res.TypeInterner = opts.Types
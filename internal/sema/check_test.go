@@ -53,8 +53,8 @@ func TestBinaryTypeMismatchEmitsDiagnostic(t *testing.T) {
 	if len(items) == 0 {
 		t.Fatalf("expected diagnostics")
 	}
-	if items[0].Code != diag.SemaInvalidBinaryOperands {
-		t.Fatalf("expected %v, got %v", diag.SemaInvalidBinaryOperands, items[0].Code)
+	if items[0].Code != diag.SemaIncompatibleOperands {
+		t.Fatalf("expected %v, got %v", diag.SemaIncompatibleOperands, items[0].Code)
 	}
 }
 
@@ -102,7 +102,7 @@ func TestTypeCheckerStructFieldAccess(t *testing.T) {
 	ageValue := builder.Exprs.NewLiteral(source.Span{}, ast.ExprLitInt, intLiteral)
 	nameValue := builder.Exprs.NewLiteral(source.Span{}, ast.ExprLitString, strLiteral)
 	personTypeExpr := builder.Types.NewPath(source.Span{}, []ast.TypePathSegment{{Name: personName}})
-	structExpr := builder.Exprs.NewStruct(source.Span{}, personTypeExpr, []ast.ExprStructField{
+	structExpr := builder.Exprs.NewStruct(source.Span{}, personTypeExpr, ast.NoExprID, []ast.ExprStructField{
 		{Name: ageField, Value: ageValue},
 		{Name: nameField, Value: nameValue},
 	}, nil, false, false)
@@ -0,0 +1,66 @@
+package sema
+
+import (
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func checkCompareSource(t *testing.T, src string) *diag.Bag {
+	t.Helper()
+	builder, fileID, bag := parseSource(t, src)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(bag))
+	}
+	symRes := resolveSymbols(t, builder, fileID)
+	semaBag := diag.NewBag(16)
+	Check(t.Context(), builder, fileID, Options{
+		Reporter: &diag.BagReporter{Bag: semaBag},
+		Symbols:  symRes,
+	})
+	return semaBag
+}
+
+func TestCompareRangePatternOnIntegerSubject(t *testing.T) {
+	bag := checkCompareSource(t, `
+fn bucket(x: uint8) -> int {
+    return compare x {
+        0 => 0;
+        1..10 => 1;
+        10..=200 => 2;
+        finally => 3;
+    };
+}
+`)
+	if len(bag.Items()) != 0 {
+		t.Fatalf("unexpected sema diagnostics: %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestCompareRangePatternRequiresNumericSubject(t *testing.T) {
+	bag := checkCompareSource(t, `
+fn bucket(s: string) -> int {
+    return compare s {
+        1..3 => 1;
+        finally => 0;
+    };
+}
+`)
+	if !bag.HasErrors() || bag.Items()[0].Code != diag.SemaTypeMismatch {
+		t.Fatalf("expected SemaTypeMismatch for range pattern on string, got %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestCompareRangePatternBoundOutOfRange(t *testing.T) {
+	bag := checkCompareSource(t, `
+fn bucket(x: uint8) -> int {
+    return compare x {
+        1..300 => 1;
+        finally => 0;
+    };
+}
+`)
+	if !bag.HasErrors() {
+		t.Fatal("expected an error for a range bound that does not fit uint8")
+	}
+}
@@ -34,7 +34,7 @@ func TestConstGenericIdentityByValue(t *testing.T) {
         type Maze<const N:int, const M:int> = {};
 
         fn use(maze: Maze<SIZE, SIZE>) {
-            let other: Maze<3, 3> = maze;
+            let _other: Maze<3, 3> = maze;
         }
     `)
 
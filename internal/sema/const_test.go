@@ -62,7 +62,7 @@ const A = 10;
 const B: int = A + 2;
 
 fn main() {
-    let value = B;
+    let _value = B;
 }
 `
 	bag := runConstSema(t, src)
@@ -92,7 +92,7 @@ const A: uint = 1:uint;
 const B: uint = 1 to uint;
 
 fn main() {
-    let value = A + B;
+    let _value = A + B;
 }
 `
 	bag := runConstSema(t, src)
@@ -118,7 +118,7 @@ func TestConstInType(t *testing.T) {
 const N = 4;
 
 fn main() {
-    let buf: int[N];
+    let _buf: int[N];
 }
 `
 	bag := runConstSema(t, src)
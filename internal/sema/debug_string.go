@@ -0,0 +1,96 @@
+package sema
+
+import (
+	"strings"
+
+	"surge/internal/types"
+)
+
+// isDebugStringCast reports whether `value to string` falls back to the
+// built-in debug format: the source is an array, struct, tuple or tagged union
+// with no __to(string) of its own, and everything nested inside it can be rendered.
+func (tc *typeChecker) isDebugStringCast(source, target types.TypeID) bool {
+	if tc.types == nil || tc.resolveAlias(target) != tc.types.Builtins().String {
+		return false
+	}
+	id := tc.valueType(source)
+	tt, ok := tc.types.Lookup(id)
+	if !ok {
+		return false
+	}
+	if _, isArray := tc.arrayElemType(id); !isArray && tt.Kind != types.KindStruct && tt.Kind != types.KindTuple && tt.Kind != types.KindUnion {
+		return false
+	}
+	return tc.debugStringable(id, make(map[types.TypeID]bool))
+}
+
+// debugStringable reports whether the debug format can render values of the
+// type: scalars and strings, arrays and tuples of renderable elements, and
+// structs and tagged unions whose fields and payloads are renderable. Maps,
+// enums and runtime handle structs are not.
+func (tc *typeChecker) debugStringable(id types.TypeID, seen map[types.TypeID]bool) bool {
+	id = tc.valueType(id)
+	tt, ok := tc.types.Lookup(id)
+	if !ok {
+		return false
+	}
+	switch tt.Kind {
+	case types.KindString, types.KindInt, types.KindUint, types.KindFloat, types.KindBool, types.KindNothing, types.KindUnit:
+		return true
+	}
+	if seen[id] {
+		return true
+	}
+	seen[id] = true
+	if elem, ok := tc.arrayElemType(id); ok {
+		return tc.debugStringable(elem, seen)
+	}
+	switch tt.Kind {
+	case types.KindStruct:
+		if _, _, isMap := tc.types.MapInfo(id); isMap {
+			return false
+		}
+		info, ok := tc.types.StructInfo(id)
+		if !ok || info == nil {
+			return false
+		}
+		for _, field := range info.Fields {
+			if strings.HasPrefix(tc.lookupName(field.Name), "__") || !tc.debugStringable(field.Type, seen) {
+				return false
+			}
+		}
+		return true
+	case types.KindTuple:
+		info, ok := tc.types.TupleInfo(id)
+		if !ok || info == nil || len(info.Elems) == 0 {
+			return false
+		}
+		for _, elem := range info.Elems {
+			if !tc.debugStringable(elem, seen) {
+				return false
+			}
+		}
+		return true
+	case types.KindUnion:
+		info, ok := tc.types.UnionInfo(id)
+		if !ok || info == nil {
+			return false
+		}
+		for _, member := range info.Members {
+			switch member.Kind {
+			case types.UnionMemberType:
+				if !tc.debugStringable(member.Type, seen) {
+					return false
+				}
+			case types.UnionMemberTag:
+				for _, arg := range member.TagArgs {
+					if !tc.debugStringable(arg, seen) {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -2,7 +2,6 @@ package sema
 
 import (
 	"fmt"
-	"strings"
 
 	"surge/internal/ast"
 	"surge/internal/diag"
@@ -41,29 +40,8 @@ func (tc *typeChecker) validateDirectiveBlock(block *ast.DirectiveBlock) {
 		return
 	}
 
-	// Look for module by last path segment matching namespace
-	// If multiple modules match, prefer the one with pragma directive
-	var foundExports *symbols.ModuleExports
-	var foundPath string
-	for path, exp := range tc.exports {
-		// Extract last segment of module path
-		lastSeg := path
-		if idx := strings.LastIndex(path, "/"); idx >= 0 {
-			lastSeg = path[idx+1:]
-		}
-		if lastSeg == namespace {
-			// Prefer directive modules over non-directive modules
-			if foundExports == nil {
-				foundExports = exp
-				foundPath = path
-			} else if exp.PragmaFlags&ast.PragmaFlagDirective != 0 {
-				// This module has pragma directive, prefer it
-				foundExports = exp
-				foundPath = path
-			}
-		}
-	}
-	_ = foundPath // Used for debugging, may be removed later
+	// Модуль ищется по последнему сегменту пути, директивные модули в приоритете.
+	foundExports := symbols.FindDirectiveModule(tc.exports, namespace)
 
 	if foundExports == nil {
 		tc.reportDirectiveError(
@@ -339,7 +339,7 @@ func (tc *typeChecker) isKnownTypeName(id source.StringID) bool {
 	}
 	switch name {
 	case "int", "int8", "int16", "int32", "int64",
-		"uint", "uint8", "uint16", "uint32", "uint64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "char",
 		"float", "float16", "float32", "float64",
 		"bool", "string", "nothing", "unit":
 		return true
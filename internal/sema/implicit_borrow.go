@@ -196,6 +196,17 @@ func (tc *typeChecker) dropImplicitBorrowsForCall(sym *symbols.Symbol, args []ca
 }
 
 func (tc *typeChecker) applyCallOwnership(sym *symbols.Symbol, args []callArg) {
+	tc.applyCallArgsOwnershipMode(sym, args, false)
+}
+
+// applyGenericCallMoves marks arguments passed to by-value parameters of a
+// generic call as moved. Borrows for reference parameters are left to the
+// implicit-borrow bookkeeping of generic calls.
+func (tc *typeChecker) applyGenericCallMoves(sym *symbols.Symbol, args []callArg) {
+	tc.applyCallArgsOwnershipMode(sym, args, true)
+}
+
+func (tc *typeChecker) applyCallArgsOwnershipMode(sym *symbols.Symbol, args []callArg, movesOnly bool) {
 	if sym == nil || sym.Signature == nil {
 		return
 	}
@@ -233,6 +244,9 @@ func (tc *typeChecker) applyCallOwnership(sym *symbols.Symbol, args []callArg) {
 		if paramIndex >= len(sig.Params) {
 			continue
 		}
+		if movesOnly && strings.HasPrefix(strings.TrimSpace(string(sig.Params[paramIndex])), "&") {
+			continue
+		}
 		tc.applyParamOwnership(sig.Params[paramIndex], arg.expr, arg.ty, tc.exprSpan(arg.expr))
 	}
 }
@@ -58,6 +58,10 @@ func (tc *typeChecker) tryImplicitConversion(src, target types.TypeID) (types.Ty
 	if tc.isNumericType(src) && tc.isNumericType(target) {
 		return types.NoTypeID, false, false
 	}
+	// char ↔ integer conversions are always spelled with `to`
+	if tc.isCharType(src) || tc.isCharType(target) {
+		return types.NoTypeID, false, false
+	}
 
 	candidates := tc.collectToMethods(src, target)
 	switch len(candidates) {
@@ -45,6 +45,14 @@ func (tc *typeChecker) isReferenceType(id types.TypeID) bool {
 	return ok && tt.Kind == types.KindReference
 }
 
+func (tc *typeChecker) isMutReferenceType(id types.TypeID) bool {
+	if id == types.NoTypeID || tc.types == nil {
+		return false
+	}
+	tt, ok := tc.types.Lookup(tc.resolveAlias(id))
+	return ok && tt.Kind == types.KindReference && tt.Mutable
+}
+
 func (tc *typeChecker) applyMethodReceiverOwnership(symID symbols.SymbolID, recvExpr ast.ExprID, recvType types.TypeID) {
 	if !symID.IsValid() || !recvExpr.IsValid() {
 		return
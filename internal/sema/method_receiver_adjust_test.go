@@ -0,0 +1,152 @@
+package sema
+
+import (
+	"context"
+	"testing"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+)
+
+func checkReceiverSource(t *testing.T, src string) (*ast.Builder, Result, *diag.Bag) {
+	t.Helper()
+	builder, fileID, parseBag := parseSource(t, src)
+	if parseBag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(parseBag))
+	}
+	syms := resolveSymbols(t, builder, fileID)
+	bag := diag.NewBag(16)
+	res := Check(context.Background(), builder, fileID, Options{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Symbols:  syms,
+	})
+	return builder, res, bag
+}
+
+// receiverAdjustsByMethod groups recorded adjustments by the called method name.
+func receiverAdjustsByMethod(builder *ast.Builder, res Result) map[string][]ReceiverAdjust {
+	out := make(map[string][]ReceiverAdjust)
+	for callID, adjust := range res.ReceiverAdjusts {
+		call, ok := builder.Exprs.Call(callID)
+		if !ok || call == nil {
+			continue
+		}
+		member, ok := builder.Exprs.Member(call.Target)
+		if !ok || member == nil {
+			continue
+		}
+		name, _ := builder.StringsInterner.Lookup(member.Field)
+		out[name] = append(out[name], adjust)
+	}
+	return out
+}
+
+func TestMethodReceiverAutoBorrow(t *testing.T) {
+	src := `
+type Grid = { cells: int[] };
+
+extern<Grid> {
+    fn set(self: &mut Grid, i: int, v: int) -> nothing { self.cells[i] = v; }
+    fn first(self: &Grid) -> int { return self.cells[0]; }
+}
+
+fn through_ref(g: &mut Grid) -> int {
+    g.set(0, 1);
+    return g.first();
+}
+
+fn main() {
+    let mut g: Grid = { cells = [0] };
+    g.set(0, 2);
+    let _ = g.first();
+    let _ = through_ref(&mut g);
+}
+`
+	builder, res, bag := checkReceiverSource(t, src)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected semantics errors: %s", diagnosticsSummary(bag))
+	}
+	got := receiverAdjustsByMethod(builder, res)
+	if want := []ReceiverAdjust{ReceiverBorrowMut}; !equalAdjusts(got["set"], want) {
+		t.Fatalf("set: expected %v, got %v", want, got["set"])
+	}
+	if want := []ReceiverAdjust{ReceiverBorrow, ReceiverReborrow}; !equalAdjusts(got["first"], want) {
+		t.Fatalf("first: expected %v, got %v", want, got["first"])
+	}
+}
+
+func TestMethodReceiverAutoDerefCopy(t *testing.T) {
+	src := `
+@copy
+type P = { x: int };
+
+extern<P> {
+    fn get(self: P) -> int { return self.x; }
+}
+
+fn read(p: &P) -> int { return p.get(); }
+
+fn main() {
+    let p: P = { x = 1 };
+    let _ = p.get();
+    let _ = read(&p);
+}
+`
+	builder, res, bag := checkReceiverSource(t, src)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected semantics errors: %s", diagnosticsSummary(bag))
+	}
+	got := receiverAdjustsByMethod(builder, res)
+	if want := []ReceiverAdjust{ReceiverDeref}; !equalAdjusts(got["get"], want) {
+		t.Fatalf("get: expected %v, got %v", want, got["get"])
+	}
+}
+
+func TestMethodReceiverAutoDerefRequiresCopy(t *testing.T) {
+	src := `
+type B = { items: int[] };
+
+extern<B> {
+    fn take(self: B) -> int { return 0; }
+}
+
+fn read(b: &B) -> int { return b.take(); }
+`
+	_, _, bag := checkReceiverSource(t, src)
+	if !hasCode(bag, diag.SemaUnresolvedSymbol) {
+		t.Fatalf("expected %v for by-value self on &B, got %s", diag.SemaUnresolvedSymbol, diagnosticsSummary(bag))
+	}
+}
+
+func TestMethodReceiverSharedRefCannotBorrowMut(t *testing.T) {
+	src := `
+type Grid = { cells: int[] };
+
+extern<Grid> {
+    fn set(self: &mut Grid, i: int, v: int) -> nothing { self.cells[i] = v; }
+}
+
+fn bad(g: &Grid) -> nothing { g.set(0, 1); }
+`
+	_, _, bag := checkReceiverSource(t, src)
+	if !hasCode(bag, diag.SemaBorrowImmutable) {
+		t.Fatalf("expected %v for &mut self on &Grid, got %s", diag.SemaBorrowImmutable, diagnosticsSummary(bag))
+	}
+}
+
+func equalAdjusts(got, want []ReceiverAdjust) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[ReceiverAdjust]int, len(got))
+	for _, a := range got {
+		seen[a]++
+	}
+	for _, a := range want {
+		if seen[a] == 0 {
+			return false
+		}
+		seen[a]--
+	}
+	return true
+}
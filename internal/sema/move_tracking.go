@@ -1,6 +1,8 @@
 package sema
 
 import (
+	"fmt"
+
 	"surge/internal/diag"
 	"surge/internal/source"
 	"surge/internal/symbols"
@@ -29,7 +31,8 @@ func (tc *typeChecker) checkUseAfterMove(symID symbols.SymbolID, span source.Spa
 	if !symID.IsValid() || tc.movedBindings == nil {
 		return
 	}
-	if _, moved := tc.movedBindings[symID]; !moved {
+	movedAt, moved := tc.movedBindings[symID]
+	if !moved {
 		return
 	}
 	name := "_"
@@ -38,11 +41,21 @@ func (tc *typeChecker) checkUseAfterMove(symID symbols.SymbolID, span source.Spa
 			name = symName
 		}
 	}
+	msg := fmt.Sprintf("use of moved value '%s'", name)
 	if tc.isTaskType(tc.bindingType(symID)) {
-		tc.report(diag.SemaUseAfterMove, span, "use of moved task '%s'; call %s.clone() to keep a handle", name, name)
+		msg = fmt.Sprintf("use of moved task '%s'; call %s.clone() to keep a handle", name, name)
+	}
+	if tc.reporter == nil {
 		return
 	}
-	tc.report(diag.SemaUseAfterMove, span, "use of moved value '%s'", name)
+	builder := diag.ReportError(tc.reporter, diag.SemaUseAfterMove, span, msg)
+	if builder == nil {
+		return
+	}
+	if movedAt != (source.Span{}) {
+		builder.WithNote(movedAt, fmt.Sprintf("'%s' was moved here", name))
+	}
+	builder.Emit()
 }
 
 func (tc *typeChecker) snapshotMovedBindings() map[symbols.SymbolID]source.Span {
@@ -0,0 +1,97 @@
+package sema
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func runOperandSema(t *testing.T, src string) *diag.Bag {
+	t.Helper()
+	builder, fileID, bag := parseSource(t, src)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(bag))
+	}
+	symRes := resolveSymbols(t, builder, fileID)
+	semaBag := diag.NewBag(16)
+	Check(t.Context(), builder, fileID, Options{
+		Reporter: &diag.BagReporter{Bag: semaBag},
+		Symbols:  symRes,
+	})
+	return semaBag
+}
+
+func TestCompareIntWithStringIsIncompatible(t *testing.T) {
+	bag := runOperandSema(t, `
+fn main() {
+    let a: int = 1;
+    let s: string = "x";
+    let same = a == s;
+    let sum = a + s;
+}
+`)
+	count := 0
+	for _, d := range bag.Items() {
+		if d.Code != diag.SemaIncompatibleOperands {
+			continue
+		}
+		count++
+		if !strings.Contains(d.Message, "int and string") {
+			t.Fatalf("message should name both operand types, got %q", d.Message)
+		}
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 SemaIncompatibleOperands, got %s", diagnosticsSummary(bag))
+	}
+	for _, d := range bag.Items() {
+		if strings.Contains(d.Message, "op#") {
+			t.Fatalf("operator label must be printable, got %q", d.Message)
+		}
+	}
+}
+
+func TestCompareIntWithNarrowInt(t *testing.T) {
+	bag := runOperandSema(t, `
+fn main() {
+    let a: int = 1;
+    let b: int8 = 3;
+    let lit = b == 3;
+    let mixed = a == b;
+}
+`)
+	if hasCode(bag, diag.SemaIncompatibleOperands) {
+		t.Fatalf("int8 is assignable to int, got %s", diagnosticsSummary(bag))
+	}
+	// Политика: числовые операторы требуют одинаковых типов; литерал приводится к int8.
+	items := bag.Items()
+	if len(items) != 1 || items[0].Code != diag.SemaInvalidBinaryOperands || !strings.Contains(items[0].Message, "==") {
+		t.Fatalf("expected one same-type diagnostic for a == b, got %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestRangeBoundsRequireSameIntegerType(t *testing.T) {
+	bag := runOperandSema(t, `
+fn main() {
+    let a: int32 = 0;
+    let b: int = 3;
+    let lit = a..3;
+    let mixed = a..b;
+    let fl = 0.5..1.5;
+}
+`)
+	var messages []string
+	for _, d := range bag.Items() {
+		if d.Code != diag.SemaRangeTypeMismatch {
+			continue
+		}
+		messages = append(messages, d.Message)
+	}
+	want := []string{
+		"range bounds must have the same integer type, got int32 and int",
+		"range bounds must be integers, got float",
+	}
+	if strings.Join(messages, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected range diagnostics: %s", diagnosticsSummary(bag))
+	}
+}
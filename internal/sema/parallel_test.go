@@ -0,0 +1,103 @@
+package sema
+
+import (
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func runParallelSema(t *testing.T, src string) *diag.Bag {
+	t.Helper()
+	builder, fileID, bag := parseSource(t, src)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(bag))
+	}
+	symRes := resolveSymbols(t, builder, fileID)
+	semaBag := diag.NewBag(16)
+	Check(t.Context(), builder, fileID, Options{
+		Reporter: &diag.BagReporter{Bag: semaBag},
+		Symbols:  symRes,
+	})
+	return semaBag
+}
+
+func TestParallelMapAndReduceTypes(t *testing.T) {
+	bag := runParallelSema(t, `
+fn main() {
+    let xs: int[] = [1, 2, 3];
+    let ys: int[] = parallel map xs with (x) => x * 2;
+    let names: string[] = parallel map xs with (x) => "n";
+    let total: int = parallel reduce xs with 0, (acc, x) => acc + x;
+}
+`)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected diagnostics: %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestParallelResultTypeMismatch(t *testing.T) {
+	bag := runParallelSema(t, `
+fn main() {
+    let xs: int[] = [1, 2, 3];
+    let ys: int = parallel map xs with (x) => x * 2;
+}
+`)
+	if !hasCode(bag, diag.SemaTypeMismatch) {
+		t.Fatalf("expected SemaTypeMismatch, got %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestParallelReduceBodyMustMatchInit(t *testing.T) {
+	bag := runParallelSema(t, `
+fn main() {
+    let xs: int[] = [1, 2, 3];
+    let seen = parallel reduce xs with false, (acc, x) => x;
+}
+`)
+	if !hasCode(bag, diag.SemaTypeMismatch) {
+		t.Fatalf("expected SemaTypeMismatch, got %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestParallelArgsArity(t *testing.T) {
+	bag := runParallelSema(t, `
+fn main() {
+    let xs: int[] = [1, 2, 3];
+    let ys = parallel map xs with (x, y) => x;
+    let total = parallel reduce xs with 0, (acc) => acc;
+}
+`)
+	count := 0
+	for _, d := range bag.Items() {
+		if d.Code == diag.SemaParallelArgs {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 SemaParallelArgs diagnostics, got %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestParallelBodyCannotMutateCaptures(t *testing.T) {
+	bag := runParallelSema(t, `
+fn bump(r: &mut int, x: int) -> int {
+    return x;
+}
+
+fn main() {
+    let xs: int[] = [1, 2, 3];
+    let mut seen = 0;
+    let k = 2;
+    let ys = parallel map xs with (x) => bump(&mut seen, x * k);
+}
+`)
+	count := 0
+	for _, d := range bag.Items() {
+		if d.Code == diag.SemaParallelCaptureMutation {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 SemaParallelCaptureMutation diagnostic, got %s", diagnosticsSummary(bag))
+	}
+}
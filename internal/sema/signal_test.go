@@ -0,0 +1,74 @@
+package sema
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func runSignalSema(t *testing.T, src string) *diag.Bag {
+	t.Helper()
+	builder, fileID, bag := parseSource(t, src)
+	if bag.HasErrors() {
+		t.Fatalf("unexpected parse diagnostics: %s", diagnosticsSummary(bag))
+	}
+	symRes := resolveSymbols(t, builder, fileID)
+	semaBag := diag.NewBag(16)
+	Check(t.Context(), builder, fileID, Options{
+		Reporter: &diag.BagReporter{Bag: semaBag},
+		Symbols:  symRes,
+	})
+	return semaBag
+}
+
+func TestSignalTargetTakesValueType(t *testing.T) {
+	bag := runSignalSema(t, `
+fn main() {
+    signal total := 1 + 2;
+    let doubled: int = total * 2;
+}
+`)
+	for _, d := range bag.Items() {
+		if d.Code != diag.FutSignalNotSupported {
+			t.Fatalf("unexpected diagnostic: %s", diagnosticsSummary(bag))
+		}
+	}
+}
+
+func TestSignalTargetTypeMismatch(t *testing.T) {
+	bag := runSignalSema(t, `
+fn main() {
+    signal total := 1 + 2;
+    let flag: bool = total;
+}
+`)
+	found := false
+	for _, d := range bag.Items() {
+		if d.Code == diag.SemaTypeMismatch {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected SemaTypeMismatch, got %s", diagnosticsSummary(bag))
+	}
+}
+
+func TestSignalRequiresValue(t *testing.T) {
+	bag := runSignalSema(t, `
+fn log() {}
+
+fn main() {
+    signal total := log();
+}
+`)
+	found := false
+	for _, d := range bag.Items() {
+		if d.Code == diag.SemaTypeMismatch && strings.Contains(d.Message, "signal target 'total' requires a value") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected signal value diagnostic, got %s", diagnosticsSummary(bag))
+	}
+}
@@ -240,9 +240,9 @@ func parseArrayKey(raw string) (elem, lengthKey string, length uint64, hasLen, o
 	s := strings.TrimSpace(raw)
 	if len(s) >= 2 && s[0] == '[' && s[len(s)-1] == ']' {
 		content := strings.TrimSpace(s[1 : len(s)-1])
-		if parts := strings.Split(content, ";"); len(parts) == 2 {
-			elem = strings.TrimSpace(parts[0])
-			lengthStr := strings.TrimSpace(parts[1])
+		if semi := topLevelSemicolon(content); semi >= 0 {
+			elem = strings.TrimSpace(content[:semi])
+			lengthStr := strings.TrimSpace(content[semi+1:])
 			lengthKey = lengthStr
 			hasLen = true
 			if lengthStr != "" {
@@ -293,3 +293,24 @@ func (tc *typeChecker) constValueFromType(id types.TypeID) (uint64, bool) {
 	}
 	return uint64(tt.Count), true
 }
+
+// topLevelSemicolon finds the `;` separating element and length in `[T; N]`,
+// skipping the ones of nested fixed arrays such as `[[int; 2]; 3]`.
+func topLevelSemicolon(s string) int {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '<', '[', '(':
+			depth++
+		case '>', ']', ')':
+			if depth > 0 {
+				depth--
+			}
+		case ';':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -16,7 +16,8 @@ import (
 //   - Array compatibility (element type + length matching)
 //   - Tuple compatibility (element-wise assignability)
 //   - Function type compatibility (parameter and return types)
-//   - Numeric widening (e.g., int32 to int64)
+//   - Numeric widening (e.g., int32 to int64), &mut T to &T and heir upcasts
+//     (types.Interner.AssignableTo)
 //
 // The allowAlias parameter controls whether type aliases should be resolved
 // before comparison. This is typically true for user-facing checks.
@@ -111,8 +112,8 @@ func (tc *typeChecker) typesAssignable(expected, actual types.TypeID, allowAlias
 		return tc.typesAssignable(expFn.Result, actFn.Result, allowAlias)
 	}
 
-	// Numeric widening: smaller numeric types can be assigned to larger ones
-	if tc.numericWidenable(actual, expected) {
+	// Numeric widening, &mut T -> &T and heir upcasts are shared with the backends
+	if tc.types != nil && tc.types.AssignableTo(actual, expected) {
 		return true
 	}
 
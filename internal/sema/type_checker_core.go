@@ -111,6 +111,8 @@ type typeChecker struct {
 	arrayViewBindings           map[symbols.SymbolID]struct{}
 	assignmentLHSDepth          int
 	movedBindings               map[symbols.SymbolID]source.Span
+	returnedBorrows             []returnedBorrow
+	bindingOrigins              map[symbols.SymbolID][]borrowOrigin
 }
 
 type returnContext struct {
@@ -227,6 +229,8 @@ func (tc *typeChecker) run() {
 	tc.arrayViewExprs = make(map[ast.ExprID]struct{})
 	tc.arrayViewBindings = make(map[symbols.SymbolID]struct{})
 	tc.movedBindings = make(map[symbols.SymbolID]source.Span)
+	tc.returnedBorrows = tc.returnedBorrows[:0]
+	tc.bindingOrigins = make(map[symbols.SymbolID][]borrowOrigin)
 	tc.taskContainers = make(map[Place]*taskContainerInfo)
 
 	file := tc.builder.Files.Get(tc.fileID)
@@ -276,14 +280,17 @@ func (tc *typeChecker) run() {
 	root := tc.fileScope()
 	rootPushed := tc.pushScope(root)
 	for _, itemID := range file.Items {
-		tc.walkItem(itemID)
+		if tc.symbols.ItemEnabled(itemID) {
+			tc.walkItem(itemID)
+		}
 	}
 	if rootPushed {
 		tc.leaveScope()
 	}
 	done()
 
-	done = phase("flush_borrow")
+	done = phase("borrow")
+	tc.checkEscapingBorrows()
 	tc.flushBorrowResults()
 	done()
 
@@ -34,11 +34,20 @@ func (tc *typeChecker) walkForInStmt(id ast.StmtID, stmt *ast.Stmt) {
 
 	var loopSym symbols.SymbolID
 	if forIn.Pattern != source.NoStringID {
-		if symID := tc.stmtSymbols[id]; symID.IsValid() && elemType != types.NoTypeID {
+		symID := tc.stmtSymbols[id]
+		if forIn.Index != source.NoStringID {
+			symID = tc.symbolInScope(scope, forIn.Pattern, symbols.SymbolLet)
+		}
+		if symID.IsValid() && elemType != types.NoTypeID {
 			tc.bindingTypes[symID] = elemType
 			loopSym = symID
 		}
 	}
+	if forIn.Index != source.NoStringID {
+		if symID := tc.symbolInScope(scope, forIn.Index, symbols.SymbolLet); symID.IsValid() {
+			tc.bindingTypes[symID] = tc.types.Builtins().Int
+		}
+	}
 
 	movedBefore := tc.bindingMoved(loopSym)
 	tc.walkStmt(forIn.Body)
@@ -302,6 +302,10 @@ func (tc *typeChecker) literalCoercible(target, from types.TypeID) bool {
 	if !ok {
 		return false
 	}
+	// char не смешивается с целыми литералами ни в одну сторону
+	if tc.isCharType(target) || tc.isCharType(from) {
+		return tc.isCharType(target) && tc.isCharType(from)
+	}
 	switch sourceKind {
 	case types.KindInt:
 		return targetKind == types.KindInt || targetKind == types.KindUint
@@ -276,6 +276,7 @@ func (tc *typeChecker) walkStmt(id ast.StmtID) {
 					tc.observeMove(ret.Expr, tc.exprSpan(ret.Expr))
 					if explicitReturn {
 						tc.applyReturnPathChecks(ret.Expr)
+						tc.noteReturnedBorrow(ret.Expr)
 					}
 				}
 			}
@@ -361,9 +362,15 @@ func (tc *typeChecker) walkStmt(id ast.StmtID) {
 	case ast.StmtSignal:
 		if signal := tc.builder.Stmts.Signal(id); signal != nil {
 			tc.reporter.Report(diag.FutSignalNotSupported, diag.SevError, stmt.Span, "'signal' is not supported in v1, reserved for future use", nil, nil)
+			tc.checkSignalStmt(id, signal)
 		}
 	case ast.StmtBreak:
-		tc.noteTaskContainerLoopBreak()
+		if tc.builder.Stmts.Jump(id) != nil {
+			// break с меткой может покинуть несколько циклов сразу.
+			tc.noteTaskContainerLoopReturn()
+		} else {
+			tc.noteTaskContainerLoopBreak()
+		}
 	case ast.StmtContinue:
 		// no-op for task containers
 	case ast.StmtDrop:
@@ -469,6 +476,21 @@ func (tc *typeChecker) handleLetDefaultInit(scope symbols.ScopeID, typeExpr ast.
 	}
 }
 
+// checkSignalStmt types the reactive expression of `signal name := expr;` and
+// gives the signal binding its type, so later uses are checked as for `let`.
+func (tc *typeChecker) checkSignalStmt(id ast.StmtID, signal *ast.SignalStmt) {
+	valueType := tc.typeExpr(signal.Value)
+	if valueType == types.NoTypeID {
+		return
+	}
+	if tc.types != nil && tc.resolveAlias(valueType) == tc.types.Builtins().Nothing {
+		tc.report(diag.SemaTypeMismatch, tc.exprSpan(signal.Value),
+			"signal target '%s' requires a value, got %s", tc.lookupName(signal.Name), tc.typeLabel(valueType))
+		return
+	}
+	tc.setBindingType(tc.symbolForStmt(id), valueType)
+}
+
 func (tc *typeChecker) symbolForStmt(id ast.StmtID) symbols.SymbolID {
 	if tc.stmtSymbols == nil {
 		return symbols.NoSymbolID
@@ -14,7 +14,7 @@ func (tc *typeChecker) registerTypeDecls(file *ast.File) {
 	}
 	for _, itemID := range file.Items {
 		item := tc.builder.Items.Get(itemID)
-		if item == nil || item.Kind != ast.ItemType {
+		if item == nil || item.Kind != ast.ItemType || !tc.symbols.ItemEnabled(itemID) {
 			continue
 		}
 		typeItem, ok := tc.builder.Items.Type(itemID)
@@ -67,7 +67,7 @@ func (tc *typeChecker) recordTypeDeclAttrs(file *ast.File) {
 	}
 	for _, itemID := range file.Items {
 		item := tc.builder.Items.Get(itemID)
-		if item == nil || item.Kind != ast.ItemType {
+		if item == nil || item.Kind != ast.ItemType || !tc.symbols.ItemEnabled(itemID) {
 			continue
 		}
 		typeItem, ok := tc.builder.Items.Type(itemID)
@@ -57,7 +57,9 @@ func (tc *typeChecker) resolveTypeExprWithScope(id ast.TypeID, scope symbols.Sco
 				if arr.Kind == ast.ArraySized {
 					lengthArg := tc.resolveArrayLengthArg(arr, expr.Span)
 					if lengthArg == types.NoTypeID {
-						tc.report(diag.SemaTypeMismatch, expr.Span, "array length must be a constant")
+						if !arr.HasConstLen {
+							tc.report(diag.SemaTypeMismatch, expr.Span, "array length must be a constant")
+						}
 						break
 					}
 					result = tc.instantiateArrayFixedWithArg(elem, lengthArg)
@@ -314,12 +316,12 @@ func (tc *typeChecker) resolveArrayLengthArg(arr *ast.TypeArray, span source.Spa
 		return tc.types.Intern(types.MakeConstUint(uint32(arr.ConstLength)))
 	}
 	if lenVal, ok := tc.constUintValue(arr.Length, nil); ok {
+		arr.HasConstLen = true
+		arr.ConstLength = lenVal
 		if lenVal > uint64(^uint32(0)) {
 			tc.report(diag.SemaTypeMismatch, span, "array length %d exceeds limit", lenVal)
 			return types.NoTypeID
 		}
-		arr.HasConstLen = true
-		arr.ConstLength = lenVal
 		return tc.types.Intern(types.MakeConstUint(uint32(lenVal)))
 	}
 	if ident, ok := tc.builder.Exprs.Ident(arr.Length); ok && ident != nil {
@@ -351,6 +351,8 @@ func (tc *typeChecker) builtinTypeByName(name string) types.TypeID {
 		return tc.types.Builtins().Uint32
 	case "uint64":
 		return tc.types.Builtins().Uint64
+	case "char":
+		return tc.types.Builtins().Char
 	case "float":
 		return tc.types.Builtins().Float
 	case "float16":
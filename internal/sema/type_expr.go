@@ -88,9 +88,7 @@ func (tc *typeChecker) typeExpr(id ast.ExprID) types.TypeID {
 	case ast.ExprRace:
 		ty = tc.typeSelectExpr(id, true, expr.Span)
 	case ast.ExprParallel:
-		if par, ok := tc.builder.Exprs.Parallel(id); ok && par != nil {
-			tc.reporter.Report(diag.FutParallelNotSupported, diag.SevError, expr.Span, "'parallel' requires multi-threading (v2+)", nil, nil)
-		}
+		ty = tc.typeExprParallel(id, expr.Span)
 	case ast.ExprAsync:
 		ty = tc.typeExprAsync(id, expr.Span)
 	case ast.ExprBlocking:
@@ -462,15 +462,9 @@ func (tc *typeChecker) conversionCost(actual, expected types.TypeID, isLiteral,
 	if isLiteral && tc.literalCoercible(expected, actual) {
 		return 1, true
 	}
-	if aInfo, okA := tc.numericInfo(actual); okA {
-		if eInfo, okE := tc.numericInfo(expected); okE && aInfo.kind == eInfo.kind {
-			if aInfo.width != types.WidthAny && eInfo.width == types.WidthAny {
-				return 1, true
-			}
-			if aInfo.width < eInfo.width {
-				return 1, true
-			}
-		}
+	// numeric widening, &mut T -> &T and heir upcasts
+	if tc.types.AssignableTo(actual, expected) {
+		return 1, true
 	}
 	// Try implicit conversion (cost 2, lower priority than other conversions)
 	if allowImplicitTo {
@@ -77,6 +77,7 @@ func (tc *typeChecker) typeExprCall(id ast.ExprID, span source.Span, call *ast.E
 					tc.recordImplicitConversionsForMethodCall(sym, member.Target, receiverType, call.Args, argTypes)
 				}
 				if !receiverIsType {
+					tc.recordReceiverAdjust(id, tc.symbolFromID(symID), receiverType, member.Target)
 					tc.applyMethodReceiverOwnership(symID, member.Target, receiverType)
 					if sym := tc.symbolFromID(symID); sym != nil && sym.Signature != nil {
 						if len(sym.Signature.Params) > 0 {
@@ -131,7 +132,17 @@ func (tc *typeChecker) typeExprCall(id ast.ExprID, span source.Span, call *ast.E
 			tc.report(diag.SemaIntrinsicBadContext, span, "timeout(...) is only available in async/task context; call it inside async/task and await it via x.await()")
 		}
 	}
-	return tc.callResultType(id, call, span)
+	resultType := tc.callResultType(id, call, span)
+	// Свободная форма try_send(ch, v) проверяется так же, как ch.try_send(v);
+	// типы аргументов известны только после разбора вызова.
+	if ident, okIdent := tc.builder.Exprs.Ident(call.Target); okIdent && ident != nil &&
+		tc.lookupName(ident.Name) == "try_send" && len(call.Args) == 2 &&
+		tc.isChannelType(tc.result.ExprTypes[call.Args[0].Value]) {
+		if tc.checkChannelSendValue(call.Args[1].Value, tc.exprSpan(call.Args[1].Value)) {
+			return types.NoTypeID
+		}
+	}
+	return resultType
 }
 
 func (tc *typeChecker) typeExprIndex(id ast.ExprID, span source.Span) types.TypeID {
@@ -259,6 +270,9 @@ func (tc *typeChecker) typeExprCast(id ast.ExprID, span source.Span) types.TypeI
 	if cast.Type.IsValid() && tc.literalCoercible(targetType, sourceType) && tc.isLiteralExpr(cast.Value) {
 		return targetType
 	}
+	if tc.isDebugStringCast(castSource, targetType) {
+		return targetType
+	}
 	tc.reportMissingCastMethod(sourceType, targetType, span)
 	return types.NoTypeID
 }
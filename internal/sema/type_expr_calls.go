@@ -155,6 +155,7 @@ func (tc *typeChecker) callResultType(callID ast.ExprID, call *ast.ExprCallData,
 				return types.NoTypeID
 			}
 			tc.recordImplicitConversionsForCall(sym, args)
+			tc.applyGenericCallMoves(sym, args)
 			tc.dropImplicitBorrowsForCall(sym, args, selGeneric.result)
 		}
 		// Check for deprecated function usage
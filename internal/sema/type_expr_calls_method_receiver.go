@@ -0,0 +1,115 @@
+package sema
+
+import (
+	"strings"
+
+	"surge/internal/ast"
+	"surge/internal/symbols"
+	"surge/internal/types"
+)
+
+// ReceiverAdjust describes how a method call receiver is adapted to the self parameter.
+type ReceiverAdjust uint8
+
+const (
+	// ReceiverAsIs passes the receiver unchanged (types already match).
+	ReceiverAsIs ReceiverAdjust = iota
+	// ReceiverBorrow takes `&recv` for a `self: &T` method called on a value.
+	ReceiverBorrow
+	// ReceiverBorrowMut takes `&mut recv` for a `self: &mut T` method called on a value
+	// or on an element `xs[i]` of a mutable place.
+	ReceiverBorrowMut
+	// ReceiverReborrow passes `&*recv` for a `self: &T` method called on `&mut T`.
+	ReceiverReborrow
+	// ReceiverDeref copies `*recv` for a `self: T` method called on `&T`/`&mut T` (Copy T only).
+	ReceiverDeref
+)
+
+func (a ReceiverAdjust) String() string {
+	switch a {
+	case ReceiverBorrow:
+		return "borrow"
+	case ReceiverBorrowMut:
+		return "borrow-mut"
+	case ReceiverReborrow:
+		return "reborrow"
+	case ReceiverDeref:
+		return "deref"
+	default:
+		return "as-is"
+	}
+}
+
+// methodSelfCompatible extends selfParamCompatible with receiver auto-deref:
+// a by-value self accepts a reference to a Copy receiver.
+func (tc *typeChecker) methodSelfCompatible(recv types.TypeID, selfKey, candidateKey symbols.TypeKey) bool {
+	if tc.selfParamCompatible(recv, selfKey, candidateKey) {
+		return true
+	}
+	return tc.receiverAutoDerefs(recv, selfKey, candidateKey)
+}
+
+func (tc *typeChecker) receiverAutoDerefs(recv types.TypeID, selfKey, candidateKey symbols.TypeKey) bool {
+	selfStr := strings.TrimSpace(string(selfKey))
+	if selfStr == "" || strings.HasPrefix(selfStr, "&") || strings.HasPrefix(selfStr, "own ") || strings.HasPrefix(selfStr, "*") {
+		return false
+	}
+	tt, ok := tc.types.Lookup(tc.resolveAlias(recv))
+	if !ok || tt.Kind != types.KindReference || tt.Elem == types.NoTypeID {
+		return false
+	}
+	innerRecv := tc.typeKeyForType(tt.Elem)
+	if !typeKeyMatchesWithGenerics(selfKey, innerRecv) && !typeKeyMatchesWithGenerics(selfKey, candidateKey) {
+		return false
+	}
+	return tc.isCopyType(tt.Elem)
+}
+
+// receiverAdjustment picks the adjustment needed to pass recv as the self parameter.
+func (tc *typeChecker) receiverAdjustment(recv types.TypeID, recvExpr ast.ExprID, selfKey symbols.TypeKey) ReceiverAdjust {
+	selfStr := strings.TrimSpace(string(selfKey))
+	recvTT, ok := tc.types.Lookup(tc.resolveAlias(recv))
+	isRef := ok && recvTT.Kind == types.KindReference
+	switch {
+	case strings.HasPrefix(selfStr, "&mut "):
+		if !isRef {
+			return ReceiverBorrowMut
+		}
+		if !recvTT.Mutable && tc.isMutableIndexPlace(recvExpr) {
+			// xs[i] has type &T: borrow the element place mutably instead.
+			return ReceiverBorrowMut
+		}
+		return ReceiverAsIs
+	case strings.HasPrefix(selfStr, "&"):
+		if !isRef {
+			return ReceiverBorrow
+		}
+		if recvTT.Mutable {
+			return ReceiverReborrow
+		}
+		return ReceiverAsIs
+	case strings.HasPrefix(selfStr, "own "), strings.HasPrefix(selfStr, "*"):
+		return ReceiverAsIs
+	default:
+		if isRef && !typeKeyMatchesWithGenerics(selfKey, tc.typeKeyForType(recv)) {
+			return ReceiverDeref
+		}
+		return ReceiverAsIs
+	}
+}
+
+// recordReceiverAdjust remembers the receiver adjustment of a resolved method call
+// so HIR lowering can materialize the borrow or deref.
+func (tc *typeChecker) recordReceiverAdjust(callID ast.ExprID, sym *symbols.Symbol, recv types.TypeID, recvExpr ast.ExprID) {
+	if tc.result == nil || sym == nil || sym.Signature == nil || !sym.Signature.HasSelf || len(sym.Signature.Params) == 0 {
+		return
+	}
+	adjust := tc.receiverAdjustment(recv, recvExpr, sym.Signature.Params[0])
+	if adjust == ReceiverAsIs {
+		return
+	}
+	if tc.result.ReceiverAdjusts == nil {
+		tc.result.ReceiverAdjusts = make(map[ast.ExprID]ReceiverAdjust)
+	}
+	tc.result.ReceiverAdjusts[callID] = adjust
+}
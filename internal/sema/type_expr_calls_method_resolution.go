@@ -70,7 +70,7 @@ func (tc *typeChecker) matchMethodSignature(name string, recv types.TypeID, recv
 			}
 			subst := tc.methodSubst(recv, recvCand.key, sig)
 			switch {
-			case len(sig.Params) > 0 && tc.selfParamCompatible(recv, sig.Params[0], recvCand.key):
+			case len(sig.Params) > 0 && tc.methodSelfCompatible(recv, sig.Params[0], recvCand.key):
 				if !tc.selfParamAddressable(sig.Params[0], recv, recvExpr, &borrowInfo) {
 					continue
 				}
@@ -127,7 +127,7 @@ func (tc *typeChecker) reportSingleMethodCandidateMismatch(name string, recv typ
 			subst := tc.methodSubst(recv, recvCand.key, sig)
 			usesSelf := false
 			switch {
-			case len(sig.Params) > 0 && tc.selfParamCompatible(recv, sig.Params[0], recvCand.key):
+			case len(sig.Params) > 0 && tc.methodSelfCompatible(recv, sig.Params[0], recvCand.key):
 				if !tc.selfParamAddressable(sig.Params[0], recv, recvExpr, nil) {
 					continue
 				}
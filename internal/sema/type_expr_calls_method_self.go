@@ -13,6 +13,14 @@ func (tc *typeChecker) selfParamAddressable(selfKey symbols.TypeKey, recv types.
 	switch {
 	case strings.HasPrefix(selfStr, "&mut "):
 		if tc.isReferenceType(recv) {
+			// &T receiver cannot be reborrowed as &mut T, unless it is an
+			// element of a mutable place: xs[i] is typed &T but can be borrowed mutably.
+			if !tc.isMutReferenceType(recv) && !tc.isMutableIndexPlace(recvExpr) {
+				if info != nil {
+					info.record(recvExpr, true, borrowFailureImmutable)
+				}
+				return false
+			}
 			return true
 		}
 		if !recvExpr.IsValid() {
@@ -143,3 +151,28 @@ func (tc *typeChecker) selfParamCompatible(recv types.TypeID, selfKey, candidate
 
 	return false
 }
+
+// isMutableIndexPlace reports whether expr is a place reached through an index
+// (xs[i], xs[i].field) whose base binding is writable. Such places are typed as
+// shared references, yet the element itself may be borrowed mutably.
+func (tc *typeChecker) isMutableIndexPlace(expr ast.ExprID) bool {
+	desc, ok := tc.resolvePlace(expr)
+	if !ok {
+		return false
+	}
+	sawIndex := false
+	for _, seg := range desc.Segments {
+		if seg.Kind == PlaceSegmentIndex {
+			sawIndex = true
+			break
+		}
+	}
+	if !sawIndex {
+		return false
+	}
+	if ty := tc.bindingType(desc.Base); tc.isReferenceType(ty) {
+		// Writing through a reference needs &mut, even for a `let mut` binding.
+		return tc.isMutReferenceType(ty)
+	}
+	return tc.isMutableBinding(desc.Base)
+}
@@ -216,7 +216,7 @@ func (tc *typeChecker) resolveMethodCallSymbol(member *ast.ExprMemberData, recv
 			subst := tc.methodSubst(recv, recvCand.key, sig)
 			switch {
 			case sig.HasSelf:
-				if !tc.selfParamCompatible(recv, sig.Params[0], recvCand.key) {
+				if !tc.methodSelfCompatible(recv, sig.Params[0], recvCand.key) {
 					continue
 				}
 				if !tc.selfParamAddressable(sig.Params[0], recv, recvExpr, nil) {
@@ -281,6 +281,13 @@ func (tc *typeChecker) handleCloneCall(callID ast.ExprID, args []callArg, span s
 	methods := tc.lookupMagicMethods(typeKey, "__clone")
 
 	if len(methods) == 0 {
+		if tc.isBuiltinClonable(innerType, nil) {
+			// Без __clone: clone остаётся интринсиком и копирует значение глубоко.
+			if args[0].expr.IsValid() {
+				tc.applyParamOwnership(symbols.TypeKey("&"), args[0].expr, args[0].ty, tc.exprSpan(args[0].expr))
+			}
+			return innerType
+		}
 		tc.report(diag.SemaTypeNotClonable, span,
 			"type %s is not clonable (no __clone method defined)", tc.typeLabel(innerType))
 		return types.NoTypeID
@@ -311,6 +318,95 @@ func (tc *typeChecker) handleCloneCall(callID ast.ExprID, args []callArg, span s
 	return types.NoTypeID
 }
 
+// isBuiltinClonable reports whether clone can deep-copy a type that has no __clone
+// of its own: strings, arrays, tuples and plain structs/unions whose members are
+// Copy, define __clone or are built-in clonable themselves. Maps, tasks, channels,
+// locks and @intrinsic types are resources and stay non-clonable.
+func (tc *typeChecker) isBuiltinClonable(id types.TypeID, visiting map[types.TypeID]bool) bool {
+	if id == types.NoTypeID || tc.types == nil {
+		return false
+	}
+	resolved := tc.resolveAlias(id)
+	if tc.isCopyType(resolved) {
+		return true
+	}
+	if visiting[resolved] {
+		return true
+	}
+	if len(tc.lookupMagicMethods(tc.typeKeyForType(resolved), "__clone")) > 0 {
+		return true
+	}
+	if tc.isTaskType(resolved) || tc.isChannelType(resolved) || tc.isLockType(resolved) || tc.typeHasAttr(resolved, "intrinsic") {
+		return false
+	}
+	if _, _, ok := tc.types.MapInfo(resolved); ok {
+		return false
+	}
+	if visiting == nil {
+		visiting = make(map[types.TypeID]bool)
+	}
+	visiting[resolved] = true
+	defer delete(visiting, resolved)
+
+	if elem, _, _, ok := tc.arrayInfo(resolved); ok {
+		return tc.isBuiltinClonable(elem, visiting)
+	}
+	tt, ok := tc.types.Lookup(resolved)
+	if !ok {
+		return false
+	}
+	switch tt.Kind {
+	case types.KindString:
+		return true
+	case types.KindOwn:
+		return tc.isBuiltinClonable(tt.Elem, visiting)
+	case types.KindTuple:
+		info, ok := tc.types.TupleInfo(resolved)
+		if !ok || info == nil {
+			return false
+		}
+		for _, elem := range info.Elems {
+			if !tc.isBuiltinClonable(elem, visiting) {
+				return false
+			}
+		}
+		return true
+	case types.KindStruct:
+		info, ok := tc.types.StructInfo(resolved)
+		if !ok || info == nil {
+			return false
+		}
+		for _, field := range info.Fields {
+			if !tc.isBuiltinClonable(field.Type, visiting) {
+				return false
+			}
+		}
+		return true
+	case types.KindUnion:
+		info, ok := tc.types.UnionInfo(resolved)
+		if !ok || info == nil {
+			return false
+		}
+		for _, member := range info.Members {
+			switch member.Kind {
+			case types.UnionMemberType:
+				if !tc.isBuiltinClonable(member.Type, visiting) {
+					return false
+				}
+			case types.UnionMemberTag:
+				for _, arg := range member.TagArgs {
+					if !tc.isBuiltinClonable(arg, visiting) {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
 func (tc *typeChecker) ensureCloneMethodSymbol(sig *symbols.FunctionSignature, recv types.TypeID, fallback source.Span) symbols.SymbolID {
 	if symID := tc.ensureMagicMethodSymbol("__clone", sig, fallback); symID.IsValid() {
 		return symID
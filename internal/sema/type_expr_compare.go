@@ -87,6 +87,57 @@ func (tc *typeChecker) inferComparePatternTypes(pattern ast.ExprID, subject type
 	}
 }
 
+// checkComparePatternRange типизирует границы паттерна `lo..hi` / `lo..=hi` типом субъекта:
+// диапазон сопоставляется только с числовыми значениями.
+func (tc *typeChecker) checkComparePatternRange(pattern ast.ExprID, subject types.TypeID) {
+	bin, ok := tc.builder.Exprs.Binary(pattern)
+	if !ok || bin == nil || subject == types.NoTypeID || tc.types == nil {
+		return
+	}
+	expected := tc.resolveAlias(tc.stripOwnType(subject))
+	if tt, ok := tc.types.Lookup(expected); ok && tt.Kind == types.KindReference {
+		expected = tc.resolveAlias(tt.Elem)
+	}
+	if !tc.isNumericType(expected) {
+		tc.report(diag.SemaTypeMismatch, tc.exprSpan(pattern), "range pattern requires a numeric subject, got %s", tc.typeLabel(subject))
+		return
+	}
+	for _, bound := range []ast.ExprID{bin.Left, bin.Right} {
+		if applied, _ := tc.materializeNumericLiteral(bound, expected); applied {
+			continue
+		}
+		boundType := tc.typeExpr(bound)
+		if boundType != types.NoTypeID && !tc.typesAssignable(expected, boundType, true) {
+			tc.report(diag.SemaTypeMismatch, tc.exprSpan(bound), "range pattern bound must be %s, got %s", tc.typeLabel(expected), tc.typeLabel(boundType))
+		}
+	}
+}
+
+// checkComparePatternOr типизирует каждую альтернативу or-паттерна как отдельный
+// паттерн: диапазоны — через checkComparePatternRange, остальные — как обычно.
+// Альтернативы ничего не связывают (это проверяет резолвер).
+func (tc *typeChecker) checkComparePatternOr(pattern ast.ExprID, subject types.TypeID) {
+	for _, alt := range tc.builder.Exprs.OrPatternAlternatives(pattern) {
+		// `..` связывает слабее `|`, поэтому диапазон внутри or-паттерна пишется в скобках.
+		inner := tc.unwrapGroupExpr(alt)
+		if bin, ok := tc.builder.Exprs.Binary(inner); ok && bin != nil &&
+			(bin.Op == ast.ExprBinaryRange || bin.Op == ast.ExprBinaryRangeInclusive) {
+			tc.checkComparePatternRange(inner, subject)
+			continue
+		}
+		tc.inferComparePatternTypes(alt, subject)
+	}
+}
+
+// comparePatternAlternatives returns the alternatives of an or-pattern arm,
+// or the arm pattern itself for every other kind.
+func (tc *typeChecker) comparePatternAlternatives(arm ast.ExprCompareArm) []ast.ExprID {
+	if arm.PatternKind == ast.ComparePatternOr && tc.builder != nil {
+		return tc.builder.Exprs.OrPatternAlternatives(arm.Pattern)
+	}
+	return []ast.ExprID{arm.Pattern}
+}
+
 func (tc *typeChecker) unionTagPayloadTypes(subject types.TypeID, tag source.StringID) []types.TypeID {
 	if tag == source.NoStringID || tc.types == nil {
 		return nil
@@ -286,9 +337,14 @@ func (tc *typeChecker) compareAlwaysMatches(cmp *ast.ExprCompareData) bool {
 		if arm.Guard.IsValid() {
 			continue
 		}
-		if arm.IsFinally || tc.isWildcardPattern(arm.Pattern) || tc.isNamedBindingPattern(arm.Pattern) {
+		if arm.IsFinally || tc.isNamedBindingPattern(arm.Pattern) {
 			return true
 		}
+		for _, pattern := range tc.comparePatternAlternatives(arm) {
+			if tc.isWildcardPattern(pattern) {
+				return true
+			}
+		}
 	}
 	subjectType, ok := tc.compareSubjectType(cmp)
 	if !ok {
@@ -341,20 +397,22 @@ func (tc *typeChecker) compareBoolAlwaysMatches(cmp *ast.ExprCompareData) bool {
 		if arm.Guard.IsValid() || !arm.Pattern.IsValid() || tc.builder == nil {
 			continue
 		}
-		pattern := tc.unwrapGroupExpr(arm.Pattern)
-		node := tc.builder.Exprs.Get(pattern)
-		if node == nil || node.Kind != ast.ExprLit {
-			continue
-		}
-		lit, ok := tc.builder.Exprs.Literal(pattern)
-		if !ok || lit == nil {
-			continue
-		}
-		switch lit.Kind {
-		case ast.ExprLitTrue:
-			matchedTrue = true
-		case ast.ExprLitFalse:
-			matchedFalse = true
+		for _, alt := range tc.comparePatternAlternatives(arm) {
+			pattern := tc.unwrapGroupExpr(alt)
+			node := tc.builder.Exprs.Get(pattern)
+			if node == nil || node.Kind != ast.ExprLit {
+				continue
+			}
+			lit, ok := tc.builder.Exprs.Literal(pattern)
+			if !ok || lit == nil {
+				continue
+			}
+			switch lit.Kind {
+			case ast.ExprLitTrue:
+				matchedTrue = true
+			case ast.ExprLitFalse:
+				matchedFalse = true
+			}
 		}
 	}
 	return matchedTrue && matchedFalse
@@ -493,11 +551,11 @@ func (tc *typeChecker) consumeCompareMembers(remaining []types.UnionMember, arm
 	if len(remaining) == 0 {
 		return remaining
 	}
-	matched := tc.matchedUnionMembers(arm.Pattern, remaining, arm.IsFinally)
-	if len(matched) == 0 {
-		return remaining
+	for _, pattern := range tc.comparePatternAlternatives(arm) {
+		matched := tc.matchedUnionMembers(pattern, remaining, arm.IsFinally)
+		remaining = tc.dropUnionMembers(remaining, matched)
 	}
-	return tc.dropUnionMembers(remaining, matched)
+	return remaining
 }
 
 func (tc *typeChecker) matchedUnionMembers(pattern ast.ExprID, members []types.UnionMember, isFinally bool) []int {
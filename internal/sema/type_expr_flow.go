@@ -35,7 +35,14 @@ func (tc *typeChecker) typeExprCompare(id ast.ExprID, span source.Span) types.Ty
 		if narrowed := tc.narrowCompareSubjectType(valueType, remainingMembers); narrowed != types.NoTypeID {
 			armSubject = narrowed
 		}
-		tc.inferComparePatternTypes(arm.Pattern, armSubject)
+		switch arm.PatternKind {
+		case ast.ComparePatternRange:
+			tc.checkComparePatternRange(arm.Pattern, armSubject)
+		case ast.ComparePatternOr:
+			tc.checkComparePatternOr(arm.Pattern, armSubject)
+		default:
+			tc.inferComparePatternTypes(arm.Pattern, armSubject)
+		}
 		if arm.Guard.IsValid() {
 			tc.ensureBoolContext(arm.Guard, tc.exprSpan(arm.Guard))
 		}
@@ -150,7 +157,52 @@ func (tc *typeChecker) typeExprAsync(id ast.ExprID, span source.Span) types.Type
 	if !ok || asyncData == nil {
 		return types.NoTypeID
 	}
-	return tc.taskBlockPayload(span, asyncData.Body, true)
+	resultType := tc.taskBlockPayload(span, asyncData.Body, true)
+	tc.checkAsyncCaptures(id, asyncData.Body)
+	return resultType
+}
+
+// checkAsyncCaptures records the local bindings an async block reads from the
+// enclosing function. The task gets its own copy of each capture (non-copy
+// values are moved in), so a spawned task never shares a slot with its parent;
+// assigning or mutably borrowing a capture inside the block is rejected since
+// the parent would not observe the write.
+func (tc *typeChecker) checkAsyncCaptures(id ast.ExprID, body ast.StmtID) {
+	captures := tc.collectCaptures(body, ast.NoExprID)
+	ids := make([]symbols.SymbolID, 0, len(captures))
+	for _, cap := range captures {
+		sym := tc.symbolFromID(cap.symID)
+		if sym == nil || sym.Kind == symbols.SymbolConst || !tc.isFunctionLocalScope(sym.Scope) {
+			continue
+		}
+		name := tc.lookupName(sym.Name)
+		switch {
+		case cap.mutated:
+			tc.report(diag.SemaAsyncCaptureMutation, cap.span,
+				"async block cannot assign or mutably borrow captured variable '%s'", name)
+		case tc.isMutReferenceType(tc.bindingType(cap.symID)):
+			tc.report(diag.SemaAsyncCaptureMutation, cap.span,
+				"async block cannot capture mutable reference '%s'", name)
+		}
+		tc.observeMove(cap.exprID, cap.span)
+		ids = append(ids, cap.symID)
+	}
+	if len(ids) > 0 && tc.result != nil && tc.result.AsyncCaptures != nil {
+		tc.result.AsyncCaptures[id] = ids
+	}
+}
+
+// isFunctionLocalScope reports whether scope belongs to a function body, as
+// opposed to file- or module-level declarations that tasks reach as globals.
+func (tc *typeChecker) isFunctionLocalScope(scope symbols.ScopeID) bool {
+	if tc.symbols == nil || tc.symbols.Table == nil || tc.symbols.Table.Scopes == nil {
+		return false
+	}
+	data := tc.symbols.Table.Scopes.Get(scope)
+	if data == nil {
+		return false
+	}
+	return data.Kind == symbols.ScopeFunction || data.Kind == symbols.ScopeBlock
 }
 
 func (tc *typeChecker) typeExprBlocking(id ast.ExprID, span source.Span) types.TypeID {
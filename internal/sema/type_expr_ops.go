@@ -26,6 +26,8 @@ func (tc *typeChecker) literalType(kind ast.ExprLitKind) types.TypeID {
 		return b.Bool
 	case ast.ExprLitNothing:
 		return b.Nothing
+	case ast.ExprLitChar:
+		return tc.charType()
 	default:
 		return types.NoTypeID
 	}
@@ -509,38 +511,53 @@ func (tc *typeChecker) typeBinaryFallback(span source.Span, data *ast.ExprBinary
 		case types.BinaryResultBool:
 			return tc.types.Builtins().Bool
 		case types.BinaryResultRange:
-			// Both operands must have compatible types
-			if leftType == types.NoTypeID || rightType == types.NoTypeID {
+			// Границы — один и тот же целочисленный тип; литерал к этому
+			// моменту уже материализован под тип другой границы.
+			if !tc.sameType(tc.valueType(leftType), tc.valueType(rightType)) {
+				tc.report(diag.SemaRangeTypeMismatch, span,
+					"range bounds must have the same integer type, got %s and %s",
+					tc.typeLabel(leftType), tc.typeLabel(rightType))
 				return types.NoTypeID
 			}
-			// Determine element type from operands
-			var elemType types.TypeID
-			//nolint:gocritic // if-else chain is clearer here than switch
-			if tc.sameType(leftType, rightType) {
-				elemType = leftType
-			} else if tc.typesAssignable(leftType, rightType, true) {
-				elemType = leftType
-			} else if tc.typesAssignable(rightType, leftType, true) {
-				elemType = rightType
-			} else {
+			if info, ok := tc.numericInfo(tc.valueType(leftType)); !ok || info.kind == numericFloat {
 				tc.report(diag.SemaRangeTypeMismatch, span,
-					"range operands have incompatible types %s and %s",
-					tc.typeLabel(leftType), tc.typeLabel(rightType))
+					"range bounds must be integers, got %s", tc.typeLabel(leftType))
 				return types.NoTypeID
 			}
-			return tc.resolveRangeType(elemType, span, tc.currentScope())
+			return tc.resolveRangeType(leftType, span, tc.currentScope())
 		}
 	}
+	if tc.incompatibleOperands(data.Op, leftType, rightType) {
+		tc.report(diag.SemaIncompatibleOperands, span, "operator %s has incompatible operand types %s and %s", tc.binaryOpLabel(data.Op), tc.typeLabel(leftType), tc.typeLabel(rightType))
+		return types.NoTypeID
+	}
 	tc.report(diag.SemaInvalidBinaryOperands, span, "operator %s cannot be applied to %s and %s", tc.binaryOpLabel(data.Op), tc.typeLabel(leftType), tc.typeLabel(rightType))
 	return types.NoTypeID
 }
 
+// incompatibleOperands reports whether an arithmetic or comparison operator
+// failed because neither operand type is assignable to the other. Such code
+// would otherwise only surface as a runtime type mismatch in the VM.
+func (tc *typeChecker) incompatibleOperands(op ast.ExprBinaryOp, left, right types.TypeID) bool {
+	if base, ok := binaryAssignmentBaseOp(op); ok {
+		op = base
+	}
+	if !isNumericBinaryOp(op) || op == ast.ExprBinaryShiftLeft || op == ast.ExprBinaryShiftRight {
+		return false
+	}
+	if left == types.NoTypeID || right == types.NoTypeID {
+		return false
+	}
+	left, right = tc.valueType(left), tc.valueType(right)
+	return !tc.typesAssignable(left, right, true) && !tc.typesAssignable(right, left, true)
+}
+
 func (tc *typeChecker) materializeNumericBinaryLiterals(
 	op ast.ExprBinaryOp,
 	leftExpr, rightExpr ast.ExprID,
 	leftType, rightType types.TypeID,
 ) (leftOut, rightOut types.TypeID, ok bool) {
-	if !isNumericBinaryOp(op) {
+	if !isNumericBinaryOp(op) && op != ast.ExprBinaryRange && op != ast.ExprBinaryRangeInclusive {
 		return leftType, rightType, true
 	}
 	if leftType == types.NoTypeID || rightType == types.NoTypeID {
@@ -0,0 +1,112 @@
+package sema
+
+import (
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/source"
+	"surge/internal/symbols"
+	"surge/internal/types"
+)
+
+// typeExprParallel types `parallel map xs with (x) => body` as U[] where U is
+// the body type, and `parallel reduce xs with init, (acc, x) => body` as the
+// initializer type. Element bindings take the for-in element type of xs.
+func (tc *typeChecker) typeExprParallel(id ast.ExprID, span source.Span) types.TypeID {
+	par, ok := tc.builder.Exprs.Parallel(id)
+	if !ok || par == nil {
+		return types.NoTypeID
+	}
+	iterType := tc.typeExpr(par.Iterable)
+	elemType := types.NoTypeID
+	if iterType != types.NoTypeID {
+		elemType = tc.inferForInElementType(par.Iterable, iterType, tc.exprSpan(par.Iterable))
+	}
+
+	switch par.Kind {
+	case ast.ExprParallelMap:
+		if !tc.checkParallelArgs(par, 1, span) {
+			tc.typeExpr(par.Body)
+			return types.NoTypeID
+		}
+		tc.setBindingType(tc.symbolForExpr(par.Args[0]), elemType)
+		bodyType := tc.typeExpr(par.Body)
+		tc.checkParallelCaptures(id, par)
+		if bodyType == types.NoTypeID {
+			return types.NoTypeID
+		}
+		if tc.types != nil && tc.resolveAlias(bodyType) == tc.types.Builtins().Nothing {
+			tc.report(diag.SemaTypeMismatch, tc.exprSpan(par.Body), "parallel map body must produce a value, got %s", tc.typeLabel(bodyType))
+			return types.NoTypeID
+		}
+		return tc.instantiateArrayType(bodyType)
+
+	case ast.ExprParallelReduce:
+		initType := tc.typeExpr(par.Init)
+		if !tc.checkParallelArgs(par, 2, span) {
+			tc.typeExpr(par.Body)
+			return initType
+		}
+		tc.setBindingType(tc.symbolForExpr(par.Args[0]), initType)
+		tc.setBindingType(tc.symbolForExpr(par.Args[1]), elemType)
+		bodyType := tc.typeExprWithExpected(par.Body, initType)
+		tc.checkParallelCaptures(id, par)
+		if initType != types.NoTypeID && bodyType != types.NoTypeID {
+			tc.ensureBindingTypeMatch(ast.NoTypeID, initType, bodyType, par.Body)
+		}
+		return initType
+	}
+	return types.NoTypeID
+}
+
+// checkParallelCaptures records the outer bindings the body reads and rejects
+// writes to them: a backend may run the body on several threads at once, each
+// with its own copy of the captures.
+func (tc *typeChecker) checkParallelCaptures(id ast.ExprID, par *ast.ExprParallelData) {
+	args := make(map[symbols.SymbolID]struct{}, len(par.Args))
+	for _, arg := range par.Args {
+		args[tc.symbolForExpr(arg)] = struct{}{}
+	}
+	captures := tc.collectCaptures(ast.NoStmtID, par.Body)
+	ids := make([]symbols.SymbolID, 0, len(captures))
+	for _, cap := range captures {
+		if _, ok := args[cap.symID]; ok {
+			continue
+		}
+		name := "value"
+		if sym := tc.symbolFromID(cap.symID); sym != nil {
+			name = tc.lookupName(sym.Name)
+		}
+		switch {
+		case cap.mutated:
+			tc.report(diag.SemaParallelCaptureMutation, cap.span,
+				"parallel body cannot assign or mutably borrow captured variable '%s'", name)
+		case tc.isMutReferenceType(tc.bindingType(cap.symID)):
+			tc.report(diag.SemaParallelCaptureMutation, cap.span,
+				"parallel body cannot capture mutable reference '%s'", name)
+		}
+		ids = append(ids, cap.symID)
+	}
+	if len(ids) > 0 && tc.result != nil && tc.result.ParallelCaptures != nil {
+		tc.result.ParallelCaptures[id] = ids
+	}
+}
+
+// checkParallelArgs reports an argument list that is not exactly want plain
+// identifiers: (x) for map, (acc, x) for reduce.
+func (tc *typeChecker) checkParallelArgs(par *ast.ExprParallelData, want int, span source.Span) bool {
+	mode := "map"
+	if par.Kind == ast.ExprParallelReduce {
+		mode = "reduce"
+	}
+	if len(par.Args) != want {
+		tc.report(diag.SemaParallelArgs, span, "parallel %s expects %d argument(s), got %d", mode, want, len(par.Args))
+		return false
+	}
+	for _, arg := range par.Args {
+		if node := tc.builder.Exprs.Get(arg); node == nil || node.Kind != ast.ExprIdent {
+			tc.report(diag.SemaParallelArgs, tc.exprSpan(arg), "parallel %s argument must be an identifier", mode)
+			return false
+		}
+	}
+	return true
+}
@@ -184,6 +184,13 @@ func (tc *typeChecker) inferStructLiteralType(data *ast.ExprStructData, scope sy
 	}
 
 	bindings := make(map[string]types.TypeID, len(paramNames))
+	if data.Base.IsValid() {
+		if baseType := tc.result.ExprTypes[data.Base]; baseType != types.NoTypeID {
+			if key := tc.typeKeyForType(structType); key != "" {
+				tc.instantiateTypeKeyWithInference(key, baseType, bindings, paramSet)
+			}
+		}
+	}
 
 	externFields := tc.externFieldsForType(structType)
 	expectedByName := make(map[source.StringID]types.TypeID, len(info.Fields)+len(externFields))
@@ -294,6 +301,7 @@ func (tc *typeChecker) validateStructLiteralFields(structType types.TypeID, data
 			fieldMap[f.Name] = f
 		}
 	}
+	hasBase := tc.checkStructSpreadBase(normalized, data)
 	seen := make(map[source.StringID]struct{}, len(fieldMap))
 	for _, field := range data.Fields {
 		spec, ok := fieldMap[field.Name]
@@ -308,6 +316,10 @@ func (tc *typeChecker) validateStructLiteralFields(structType types.TypeID, data
 			seen[field.Name] = struct{}{}
 		}
 	}
+	if hasBase {
+		// недостающие поля копируются из base
+		return
+	}
 	for name := range fieldMap {
 		if _, ok := seen[name]; ok {
 			continue
@@ -316,6 +328,23 @@ func (tc *typeChecker) validateStructLiteralFields(structType types.TypeID, data
 	}
 }
 
+// checkStructSpreadBase validates `..base` of a struct literal: base must have
+// exactly the literal's struct type. Reports true when a base is present, so that
+// missing fields are not reported twice for an ill-typed base.
+func (tc *typeChecker) checkStructSpreadBase(structType types.TypeID, data *ast.ExprStructData) bool {
+	if data == nil || !data.Base.IsValid() {
+		return false
+	}
+	baseType := tc.result.ExprTypes[data.Base]
+	if baseType == types.NoTypeID {
+		return true
+	}
+	if tc.isReferenceType(baseType) || tc.valueType(baseType) != structType {
+		tc.report(diag.SemaTypeMismatch, tc.exprSpan(data.Base), "struct spread base must be %s, got %s", tc.typeLabel(structType), tc.typeLabel(baseType))
+	}
+	return true
+}
+
 func (tc *typeChecker) validatePositionalStructLiteral(structType types.TypeID, info *types.StructInfo, data *ast.ExprStructData, span source.Span) {
 	if info == nil {
 		return
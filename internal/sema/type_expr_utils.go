@@ -48,6 +48,9 @@ func (tc *typeChecker) familyOf(id types.TypeID) types.FamilyMask {
 	case types.KindInt:
 		return types.FamilySignedInt
 	case types.KindUint:
+		if tt.IsChar() {
+			return types.FamilyAny
+		}
 		return types.FamilyUnsignedInt
 	case types.KindFloat:
 		return types.FamilyFloat
@@ -100,6 +103,9 @@ func (tc *typeChecker) typeLabel(id types.TypeID) string {
 	case types.KindInt:
 		return numericTypeLabel("int", tt.Width)
 	case types.KindUint:
+		if tt.IsChar() {
+			return "char"
+		}
 		return numericTypeLabel("uint", tt.Width)
 	case types.KindFloat:
 		return numericTypeLabel("float", tt.Width)
@@ -248,6 +254,10 @@ func methodNameForBinaryOp(op ast.ExprBinaryOp) string {
 func (tc *typeChecker) reportMissingBinaryMethod(op ast.ExprBinaryOp, left, right types.TypeID, span source.Span) {
 	name := methodNameForBinaryOp(op)
 	label := tc.binaryOpLabel(op)
+	if tc.incompatibleOperands(op, left, right) {
+		tc.report(diag.SemaIncompatibleOperands, span, "operator %s has incompatible operand types %s and %s", label, tc.typeLabel(left), tc.typeLabel(right))
+		return
+	}
 	if name != "" {
 		tc.report(diag.SemaInvalidBinaryOperands, span, "operator %s (%s) is not defined for %s and %s", label, name, tc.typeLabel(left), tc.typeLabel(right))
 		return
@@ -247,10 +247,20 @@ func (tc *typeChecker) typeExprStruct(id ast.ExprID, span source.Span) types.Typ
 	if !ok || data == nil {
 		return types.NoTypeID
 	}
+	baseType := types.NoTypeID
+	if data.Base.IsValid() {
+		// base только читается: недостающие поля копируются, сам base не перемещается
+		baseType = tc.typeExpr(data.Base)
+	}
 	for _, field := range data.Fields {
 		tc.typeExpr(field.Value)
 	}
 	if !data.Type.IsValid() {
+		// `{ ..base, f = v }` без аннотации берёт тип из base
+		if info, _ := tc.structInfoForType(baseType); info != nil && !tc.isReferenceType(baseType) {
+			tc.validateStructLiteralFields(baseType, data, span)
+			return baseType
+		}
 		return types.NoTypeID
 	}
 	scope := tc.scopeOrFile(tc.currentScope())
@@ -185,6 +185,9 @@ func (tc *typeChecker) familyKeyForType(id types.TypeID) symbols.TypeKey {
 	case types.KindInt:
 		return symbols.TypeKey("int")
 	case types.KindUint:
+		if tt.IsChar() {
+			return ""
+		}
 		return symbols.TypeKey("uint")
 	case types.KindFloat:
 		return symbols.TypeKey("float")
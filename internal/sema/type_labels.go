@@ -36,6 +36,18 @@ func (tc *typeChecker) binaryOpLabel(op ast.ExprBinaryOp) string {
 		return "<<"
 	case ast.ExprBinaryShiftRight:
 		return ">>"
+	case ast.ExprBinaryEq:
+		return "=="
+	case ast.ExprBinaryNotEq:
+		return "!="
+	case ast.ExprBinaryLess:
+		return "<"
+	case ast.ExprBinaryLessEq:
+		return "<="
+	case ast.ExprBinaryGreater:
+		return ">"
+	case ast.ExprBinaryGreaterEq:
+		return ">="
 	case ast.ExprBinaryAddAssign:
 		return "+="
 	case ast.ExprBinarySubAssign:
@@ -151,6 +163,9 @@ func (tc *typeChecker) typeKeyForType(id types.TypeID) symbols.TypeKey {
 			return symbols.TypeKey("int")
 		}
 	case types.KindUint:
+		if tt.IsChar() {
+			return symbols.TypeKey("char")
+		}
 		switch tt.Width {
 		case types.Width8:
 			return symbols.TypeKey("uint8")
@@ -485,6 +500,8 @@ func (tc *typeChecker) typeFromKey(key symbols.TypeKey) types.TypeID {
 		return tc.types.Builtins().Uint32
 	case "uint64":
 		return tc.types.Builtins().Uint64
+	case "char":
+		return tc.types.Builtins().Char
 	case "float":
 		return tc.types.Builtins().Float
 	case "float16":
@@ -29,6 +29,9 @@ func (tc *typeChecker) numericInfo(id types.TypeID) (numericInfo, bool) {
 	case types.KindInt:
 		return numericInfo{kind: numericSigned, width: tt.Width}, true
 	case types.KindUint:
+		if tt.IsChar() {
+			return numericInfo{}, false
+		}
 		return numericInfo{kind: numericUnsigned, width: tt.Width}, true
 	case types.KindFloat:
 		return numericInfo{kind: numericFloat, width: tt.Width}, true
@@ -37,15 +40,6 @@ func (tc *typeChecker) numericInfo(id types.TypeID) (numericInfo, bool) {
 	}
 }
 
-func (tc *typeChecker) numericWidenable(from, to types.TypeID) bool {
-	fromInfo, okFrom := tc.numericInfo(from)
-	toInfo, okTo := tc.numericInfo(to)
-	if !okFrom || !okTo || fromInfo.kind != toInfo.kind {
-		return false
-	}
-	return widthCanWiden(fromInfo.width, toInfo.width)
-}
-
 func widthCanWiden(from, to types.Width) bool {
 	if from == to {
 		return true
@@ -85,3 +85,17 @@ func (tc *typeChecker) rangePayload(id types.TypeID) (types.TypeID, bool) {
 	}
 	return info.TypeArgs[0], true
 }
+
+// charType returns the type of character literals: the builtin `char`.
+func (tc *typeChecker) charType() types.TypeID {
+	return tc.types.Builtins().Char
+}
+
+// isCharType reports whether id (after alias resolution) is the builtin char.
+func (tc *typeChecker) isCharType(id types.TypeID) bool {
+	if id == types.NoTypeID || tc.types == nil {
+		return false
+	}
+	tt, ok := tc.types.Lookup(tc.resolveAlias(id))
+	return ok && tt.IsChar()
+}
@@ -0,0 +1,88 @@
+package symbols
+
+import (
+	"errors"
+	"fmt"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/fix"
+	"surge/internal/source"
+)
+
+// checkAssignCondition warns about `if (x = 0)` / `while x = 0`, where `==` was
+// almost certainly meant. Only an assignment that is the whole condition (possibly
+// in parentheses) is reported: `if ok && (x = next())` keeps the assignment as written.
+func (fr *fileResolver) checkAssignCondition(cond ast.ExprID) {
+	inner := cond
+	for {
+		group, ok := fr.builder.Exprs.Group(inner)
+		if !ok || group == nil {
+			break
+		}
+		inner = group.Inner
+	}
+	bin, ok := fr.builder.Exprs.Binary(inner)
+	if !ok || bin == nil || bin.Op != ast.ExprBinaryAssign {
+		return
+	}
+	left := fr.builder.Exprs.Get(bin.Left)
+	right := fr.builder.Exprs.Get(bin.Right)
+	expr := fr.builder.Exprs.Get(inner)
+	if left == nil || right == nil || expr == nil {
+		return
+	}
+	b := diag.ReportWarning(fr.resolver.reporter, diag.SemaAssignInCondition, expr.Span, "assignment used as a condition; did you mean '=='?")
+	if b == nil {
+		return
+	}
+	// Позицию `=` резолвер не знает: оператор ищется между операндами,
+	// когда фикс материализуется и исходник уже доступен.
+	gap := source.Span{File: expr.Span.File, Start: left.Span.End, End: right.Span.Start}
+	fixID := fix.MakeFixID(diag.SemaAssignInCondition, expr.Span)
+	b.WithFixSuggestion(&diag.Fix{
+		ID:            fixID,
+		Title:         "compare with '=='",
+		Kind:          diag.FixKindQuickFix,
+		Applicability: diag.FixApplicabilitySafeWithHeuristics,
+		IsPreferred:   true,
+		Thunk:         assignOperatorFix{id: fixID, gap: gap},
+	})
+	b.Emit()
+}
+
+// assignOperatorFix заменяет `=` между операндами на `==`.
+type assignOperatorFix struct {
+	id  string
+	gap source.Span
+}
+
+func (f assignOperatorFix) ID() string {
+	return f.id
+}
+
+func (f assignOperatorFix) Build(ctx diag.FixBuildContext) (diag.Fix, error) {
+	if ctx.FileSet == nil || !ctx.FileSet.HasFile(f.gap.File) {
+		return diag.Fix{}, errors.New("source file is not available")
+	}
+	content := ctx.FileSet.Get(f.gap.File).Content
+	if int(f.gap.End) > len(content) || f.gap.Start > f.gap.End {
+		return diag.Fix{}, fmt.Errorf("span %s is out of range", f.gap)
+	}
+	// Между операндами допускаются только пробелы вокруг `=`; комментарий
+	// или что-то ещё делает правку неочевидной, и фикс не строится.
+	for pos := f.gap.Start; pos < f.gap.End; pos++ {
+		c := content[pos]
+		if c == '=' {
+			op := source.Span{File: f.gap.File, Start: pos, End: pos + 1}
+			return *fix.ReplaceSpan("compare with '=='", op, "==", "=",
+				fix.WithApplicability(diag.FixApplicabilitySafeWithHeuristics),
+				fix.Preferred(),
+			), nil
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			break
+		}
+	}
+	return diag.Fix{}, errors.New("assignment operator not found between operands")
+}
@@ -0,0 +1,240 @@
+package symbols
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/source"
+	"surge/internal/token"
+)
+
+// CfgSettings holds build settings consulted by `@cfg(...)` attributes (e.g. os, arch).
+type CfgSettings map[string]string
+
+// DefaultCfgSettings returns settings describing the host platform.
+func DefaultCfgSettings() CfgSettings {
+	return CfgSettings{
+		"os":   runtime.GOOS,
+		"arch": runtime.GOARCH,
+	}
+}
+
+// CfgSettingsForTarget returns the host settings with `os` and `arch` taken
+// from an LLVM target triple such as "aarch64-apple-darwin". Components are
+// mapped to Go GOOS/GOARCH names so host and cross builds agree; an empty
+// triple describes the host.
+func CfgSettingsForTarget(triple string) CfgSettings {
+	settings := DefaultCfgSettings()
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(triple)), "-")
+	if len(parts) == 0 || parts[0] == "" {
+		return settings
+	}
+	settings["arch"] = cfgArch(parts[0])
+	for _, part := range parts[1:] {
+		if osName, ok := cfgOS(part); ok {
+			settings["os"] = osName
+			break
+		}
+	}
+	return settings
+}
+
+func cfgArch(arch string) string {
+	switch {
+	case arch == "x86_64" || arch == "amd64":
+		return "amd64"
+	case arch == "aarch64" || arch == "arm64":
+		return "arm64"
+	case arch == "i386" || arch == "i486" || arch == "i586" || arch == "i686":
+		return "386"
+	case strings.HasPrefix(arch, "arm") || strings.HasPrefix(arch, "thumb"):
+		return "arm"
+	case arch == "wasm32":
+		return "wasm"
+	}
+	return arch
+}
+
+func cfgOS(part string) (string, bool) {
+	switch {
+	case part == "linux":
+		return "linux", true
+	case strings.HasPrefix(part, "darwin") || strings.HasPrefix(part, "macos"):
+		return "darwin", true
+	case part == "windows" || part == "win32":
+		return "windows", true
+	case strings.HasPrefix(part, "freebsd"):
+		return "freebsd", true
+	case part == "wasi":
+		return "wasip1", true
+	}
+	return "", false
+}
+
+// ApplyFlags overrides settings with `--cfg` values: `key=value`, or a bare
+// `key` which sets it to "true".
+func (s CfgSettings) ApplyFlags(flags []string) error {
+	for _, flag := range flags {
+		key, value, hasValue := strings.Cut(flag, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return fmt.Errorf("invalid --cfg %q: expected key=value or key", flag)
+		}
+		if !hasValue {
+			value = "true"
+		}
+		s[key] = strings.TrimSpace(value)
+	}
+	return nil
+}
+
+// cfgItems returns the top-level items whose @cfg predicate holds. Excluded items
+// are recorded in the table so later phases (sema, HIR, backends) skip them; the
+// parsed file is not modified.
+func (fr *fileResolver) cfgItems(file *ast.File, settings CfgSettings, report bool) []ast.ItemID {
+	if file == nil || len(file.Items) == 0 {
+		return nil
+	}
+	if settings == nil {
+		settings = DefaultCfgSettings()
+	}
+	kept := make([]ast.ItemID, 0, len(file.Items))
+	for _, itemID := range file.Items {
+		if fr.itemCfgEnabled(itemID, settings, report) {
+			kept = append(kept, itemID)
+			continue
+		}
+		fr.result.Table.disableItem(itemID)
+	}
+	return kept
+}
+
+// ItemCfgDisabled reports whether the item was excluded by a failing @cfg predicate.
+func (t *Table) ItemCfgDisabled(itemID ast.ItemID) bool {
+	if t == nil {
+		return false
+	}
+	_, off := t.cfgOff[itemID]
+	return off
+}
+
+func (t *Table) disableItem(itemID ast.ItemID) {
+	if t.cfgOff == nil {
+		t.cfgOff = make(map[ast.ItemID]struct{})
+	}
+	t.cfgOff[itemID] = struct{}{}
+}
+
+// ItemEnabled reports whether a top-level item takes part in compilation,
+// i.e. it was not excluded by @cfg during resolution.
+func (r *Result) ItemEnabled(itemID ast.ItemID) bool {
+	return r == nil || !r.Table.ItemCfgDisabled(itemID)
+}
+
+func (fr *fileResolver) itemCfgEnabled(itemID ast.ItemID, settings CfgSettings, report bool) bool {
+	start, count := itemAttrRange(fr.builder, itemID)
+	if count == 0 || !start.IsValid() {
+		return true
+	}
+	for _, attr := range fr.builder.Items.CollectAttrs(start, count) {
+		name, ok := fr.builder.StringsInterner.Lookup(attr.Name)
+		if !ok || !strings.EqualFold(name, "cfg") {
+			continue
+		}
+		enabled, valid := fr.evalCfg(&attr, settings, report)
+		if valid && !enabled {
+			return false
+		}
+	}
+	return true
+}
+
+// evalCfg evaluates `@cfg(key = "value", flag, ...)`: every predicate must hold.
+// A bare identifier holds when the setting is present and not "false".
+// Malformed predicates are reported and the item is kept.
+func (fr *fileResolver) evalCfg(attr *ast.Attr, settings CfgSettings, report bool) (enabled, valid bool) {
+	if len(attr.Args) == 0 {
+		fr.reportCfgInvalid(attr, report, "@cfg expects at least one predicate")
+		return true, false
+	}
+	enabled = true
+	for _, arg := range attr.Args {
+		key, value, isFlag, ok := fr.cfgPredicate(arg)
+		if !ok {
+			fr.reportCfgInvalid(attr, report, "@cfg predicates must be `key = \"value\"` or a bare identifier")
+			return true, false
+		}
+		got, present := settings[key]
+		if isFlag {
+			enabled = enabled && present && got != "false"
+		} else {
+			enabled = enabled && present && got == value
+		}
+	}
+	return enabled, true
+}
+
+func (fr *fileResolver) cfgPredicate(arg ast.ExprID) (key, value string, isFlag, ok bool) {
+	if ident, found := fr.builder.Exprs.Ident(arg); found && ident != nil {
+		return fr.lookupCfgName(ident.Name), "", true, true
+	}
+	bin, found := fr.builder.Exprs.Binary(arg)
+	if !found || bin == nil || bin.Op != ast.ExprBinaryAssign {
+		return "", "", false, false
+	}
+	ident, found := fr.builder.Exprs.Ident(bin.Left)
+	if !found || ident == nil {
+		return "", "", false, false
+	}
+	lit, found := fr.builder.Exprs.Literal(bin.Right)
+	if !found || lit == nil || lit.Kind != ast.ExprLitString {
+		return "", "", false, false
+	}
+	value = token.UnquoteString(fr.builder.StringsInterner.MustLookup(lit.Value))
+	return fr.lookupCfgName(ident.Name), value, false, true
+}
+
+func (fr *fileResolver) lookupCfgName(id source.StringID) string {
+	name, _ := fr.builder.StringsInterner.Lookup(id)
+	return name
+}
+
+func (fr *fileResolver) reportCfgInvalid(attr *ast.Attr, report bool, msg string) {
+	if !report {
+		return
+	}
+	if b := diag.ReportError(fr.resolver.reporter, diag.SemaCfgInvalid, attr.Span, msg); b != nil {
+		b.Emit()
+	}
+}
+
+// itemAttrRange returns the attribute range of an item @cfg may guard: fn, type, let and const
+// (the targets ast.AttrTargetFn|AttrTargetType|AttrTargetLet allow).
+func itemAttrRange(builder *ast.Builder, itemID ast.ItemID) (ast.AttrID, uint32) {
+	item := builder.Items.Get(itemID)
+	if item == nil {
+		return ast.NoAttrID, 0
+	}
+	switch item.Kind {
+	case ast.ItemFn:
+		if fn, ok := builder.Items.Fn(itemID); ok && fn != nil {
+			return fn.AttrStart, fn.AttrCount
+		}
+	case ast.ItemLet:
+		if let, ok := builder.Items.Let(itemID); ok && let != nil {
+			return let.AttrStart, let.AttrCount
+		}
+	case ast.ItemConst:
+		if c, ok := builder.Items.Const(itemID); ok && c != nil {
+			return c.AttrStart, c.AttrCount
+		}
+	case ast.ItemType:
+		if typ, ok := builder.Items.Type(itemID); ok && typ != nil {
+			return typ.AttrStart, typ.AttrCount
+		}
+	}
+	return ast.NoAttrID, 0
+}
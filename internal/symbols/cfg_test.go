@@ -0,0 +1,149 @@
+package symbols
+
+import (
+	"testing"
+
+	"surge/internal/diag"
+)
+
+func TestResolveFileCfgSelectsMatchingItems(t *testing.T) {
+	src := `
+        @cfg(os = "linux")
+        fn platform() -> int { return 1; }
+        @cfg(os = "windows")
+        fn platform() -> int { return 2; }
+        @cfg(os = "windows")
+        type Handle = int;
+        @cfg(debug)
+        let verbose = true;
+        fn common() {}
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	res := ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+		Cfg:      CfgSettings{"os": "linux", "debug": "true"},
+	})
+	if bag.Len() != 0 {
+		t.Fatalf("unexpected diagnostics: %+v", bag.Items())
+	}
+
+	counts := make(map[string]int)
+	for _, sym := range res.Table.Symbols.Data() {
+		counts[builder.StringsInterner.MustLookup(sym.Name)]++
+	}
+	if counts["platform"] != 1 {
+		t.Fatalf("expected exactly one platform symbol, got %d", counts["platform"])
+	}
+	if counts["Handle"] != 0 {
+		t.Fatalf("expected Handle to be excluded by cfg")
+	}
+	if counts["verbose"] != 1 || counts["common"] != 1 {
+		t.Fatalf("expected verbose and common to be declared, got %v", counts)
+	}
+	items := builder.Files.Get(fileID).Items
+	if len(items) != 5 {
+		t.Fatalf("expected the parsed file to keep all 5 items, got %d", len(items))
+	}
+	enabled := 0
+	for _, itemID := range items {
+		if res.ItemEnabled(itemID) {
+			enabled++
+		}
+	}
+	if enabled != 3 {
+		t.Fatalf("expected 3 items enabled by cfg, got %d", enabled)
+	}
+}
+
+func TestResolveFileCfgRawStringValue(t *testing.T) {
+	src := `
+        @cfg(os = r"linux")
+        fn raw_linux() {}
+        @cfg(os = r#"win"dows"#)
+        fn raw_windows() {}
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	res := ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Cfg:      CfgSettings{"os": "linux"},
+	})
+	if bag.Len() != 0 {
+		t.Fatalf("unexpected diagnostics: %+v", bag.Items())
+	}
+	items := builder.Files.Get(fileID).Items
+	if len(items) != 2 || !res.ItemEnabled(items[0]) || res.ItemEnabled(items[1]) {
+		t.Fatalf("expected only raw_linux to be enabled")
+	}
+}
+
+func TestResolveFileCfgMalformedPredicate(t *testing.T) {
+	src := `
+        @cfg(1 + 2)
+        fn kept() {}
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	res := ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Cfg:      CfgSettings{},
+	})
+	if bag.Len() != 1 || bag.Items()[0].Code != diag.SemaCfgInvalid {
+		t.Fatalf("expected a single SemaCfgInvalid diagnostic, got %+v", bag.Items())
+	}
+	items := builder.Files.Get(fileID).Items
+	if len(items) != 1 || !res.ItemEnabled(items[0]) {
+		t.Fatalf("expected malformed cfg item to be kept")
+	}
+}
+
+func TestCfgSettingsForTarget(t *testing.T) {
+	cases := []struct {
+		triple   string
+		os, arch string
+	}{
+		{"x86_64-linux-gnu", "linux", "amd64"},
+		{"x86_64-unknown-linux-gnu", "linux", "amd64"},
+		{"aarch64-apple-darwin", "darwin", "arm64"},
+		{"arm64-apple-macosx14.0.0", "darwin", "arm64"},
+		{"x86_64-pc-windows-msvc", "windows", "amd64"},
+		{"i686-linux-gnu", "linux", "386"},
+	}
+	for _, tc := range cases {
+		got := CfgSettingsForTarget(tc.triple)
+		if got["os"] != tc.os || got["arch"] != tc.arch {
+			t.Fatalf("%s: got os=%q arch=%q, want os=%q arch=%q", tc.triple, got["os"], got["arch"], tc.os, tc.arch)
+		}
+	}
+	host := DefaultCfgSettings()
+	if got := CfgSettingsForTarget(""); got["os"] != host["os"] || got["arch"] != host["arch"] {
+		t.Fatalf("empty triple must describe the host, got %v", got)
+	}
+}
+
+func TestCfgSettingsApplyFlags(t *testing.T) {
+	settings := CfgSettings{"os": "linux"}
+	if err := settings.ApplyFlags([]string{"os=windows", "debug", "feature = fast"}); err != nil {
+		t.Fatal(err)
+	}
+	if settings["os"] != "windows" || settings["debug"] != "true" || settings["feature"] != "fast" {
+		t.Fatalf("unexpected settings: %v", settings)
+	}
+	if err := settings.ApplyFlags([]string{"=x"}); err == nil {
+		t.Fatalf("expected an error for an empty key")
+	}
+}
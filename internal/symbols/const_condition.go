@@ -0,0 +1,131 @@
+package symbols
+
+import (
+	"fmt"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+)
+
+// maxConstConditionDepth ограничивает раскрытие цепочек const, чтобы цикл
+// `const A = B; const B = A;` не завёл свёртку в бесконечную рекурсию
+// (сам цикл сообщает sema).
+const maxConstConditionDepth = 16
+
+// checkConstCondition folds the condition of an if/while/for and warns when it
+// is always true or always false. `while true` and a missing for condition are
+// the idiomatic infinite loop and stay silent.
+func (fr *fileResolver) checkConstCondition(stmtID ast.StmtID, cond ast.ExprID, loop bool) {
+	value, ok := fr.constBool(cond, 0)
+	if !ok {
+		return
+	}
+	if loop && value {
+		return
+	}
+	expr := fr.builder.Exprs.Get(cond)
+	if expr == nil {
+		return
+	}
+	msg := fmt.Sprintf("condition is always %t", value)
+	switch {
+	case loop:
+		msg += "; the loop body never runs"
+	case value:
+		if ifStmt := fr.builder.Stmts.If(stmtID); ifStmt != nil && ifStmt.Else.IsValid() {
+			msg += "; the else branch is unreachable"
+		}
+	default:
+		msg += "; the branch is unreachable"
+	}
+	if b := diag.ReportWarning(fr.resolver.reporter, diag.SemaConstantCondition, expr.Span, msg); b != nil {
+		b.Emit()
+	}
+}
+
+// constBool folds boolean literals, `!`, `&&`, `||`, `==`/`!=` and references
+// to boolean consts declared in this file. It must run after cond was walked,
+// so identifiers already have their symbols in Result.ExprSymbols.
+func (fr *fileResolver) constBool(id ast.ExprID, depth int) (value, ok bool) {
+	if depth > maxConstConditionDepth {
+		return false, false
+	}
+	expr := fr.builder.Exprs.Get(id)
+	if expr == nil {
+		return false, false
+	}
+	switch expr.Kind {
+	case ast.ExprLit:
+		lit, _ := fr.builder.Exprs.Literal(id)
+		if lit == nil {
+			return false, false
+		}
+		switch lit.Kind {
+		case ast.ExprLitTrue:
+			return true, true
+		case ast.ExprLitFalse:
+			return false, true
+		}
+	case ast.ExprGroup:
+		if group, _ := fr.builder.Exprs.Group(id); group != nil {
+			return fr.constBool(group.Inner, depth+1)
+		}
+	case ast.ExprUnary:
+		if un, _ := fr.builder.Exprs.Unary(id); un != nil && un.Op == ast.ExprUnaryNot {
+			v, ok := fr.constBool(un.Operand, depth+1)
+			return !v, ok
+		}
+	case ast.ExprBinary:
+		bin, _ := fr.builder.Exprs.Binary(id)
+		if bin == nil {
+			return false, false
+		}
+		switch bin.Op {
+		case ast.ExprBinaryLogicalAnd, ast.ExprBinaryLogicalOr:
+			left, leftOK := fr.constBool(bin.Left, depth+1)
+			if !leftOK {
+				return false, false
+			}
+			// Левый операнд уже решает результат: `false && x`, `true || x`.
+			if left == (bin.Op == ast.ExprBinaryLogicalOr) {
+				return left, true
+			}
+			return fr.constBool(bin.Right, depth+1)
+		case ast.ExprBinaryEq, ast.ExprBinaryNotEq:
+			left, leftOK := fr.constBool(bin.Left, depth+1)
+			right, rightOK := fr.constBool(bin.Right, depth+1)
+			if !leftOK || !rightOK {
+				return false, false
+			}
+			return (left == right) == (bin.Op == ast.ExprBinaryEq), true
+		}
+	case ast.ExprIdent:
+		return fr.constBoolSymbol(fr.result.ExprSymbols[id], depth)
+	}
+	return false, false
+}
+
+func (fr *fileResolver) constBoolSymbol(symID SymbolID, depth int) (value, ok bool) {
+	if !symID.IsValid() {
+		return false, false
+	}
+	sym := fr.result.Table.Symbols.Get(symID)
+	if sym == nil || sym.Kind != SymbolConst || sym.Decl.ASTFile != fr.fileID {
+		return false, false
+	}
+	var init ast.ExprID
+	switch {
+	case sym.Decl.Item.IsValid():
+		if item, _ := fr.builder.Items.Const(sym.Decl.Item); item != nil {
+			init = item.Value
+		}
+	case sym.Decl.Stmt.IsValid():
+		if stmt := fr.builder.Stmts.Const(sym.Decl.Stmt); stmt != nil {
+			init = stmt.Value
+		}
+	}
+	if !init.IsValid() {
+		return false, false
+	}
+	return fr.constBool(init, depth+1)
+}
@@ -0,0 +1,341 @@
+package symbols
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+)
+
+// DirectiveFunc describes how many arguments one overload of a directive
+// function accepts. MaxArgs is -1 for a variadic function.
+type DirectiveFunc struct {
+	MinArgs int
+	MaxArgs int
+}
+
+// Accepts reports whether a call with n arguments matches the overload.
+func (f DirectiveFunc) Accepts(n int) bool {
+	return n >= f.MinArgs && (f.MaxArgs < 0 || n <= f.MaxArgs)
+}
+
+func (f DirectiveFunc) String() string {
+	switch {
+	case f.MaxArgs < 0:
+		return fmt.Sprintf("at least %d", f.MinArgs)
+	case f.MinArgs == f.MaxArgs:
+		return fmt.Sprintf("%d", f.MinArgs)
+	default:
+		return fmt.Sprintf("%d to %d", f.MinArgs, f.MaxArgs)
+	}
+}
+
+// DirectiveSchema lists the calls a directive namespace accepts. A schema is
+// registered by a module with `pragma directive`: each public function of the
+// module becomes a directive call.
+type DirectiveSchema struct {
+	Namespace string
+	Funcs     map[string][]DirectiveFunc
+}
+
+// NewDirectiveSchema builds the schema of namespace from the exports of its
+// directive module.
+func NewDirectiveSchema(namespace string, exports *ModuleExports) *DirectiveSchema {
+	schema := &DirectiveSchema{Namespace: namespace, Funcs: make(map[string][]DirectiveFunc)}
+	if exports == nil {
+		return schema
+	}
+	for name, overloads := range exports.Symbols {
+		for i := range overloads {
+			sym := &overloads[i]
+			if sym.Kind != SymbolFunction || sym.Flags&SymbolFlagPublic == 0 {
+				continue
+			}
+			schema.Funcs[name] = append(schema.Funcs[name], directiveFuncFromSignature(sym.Signature))
+		}
+	}
+	return schema
+}
+
+func directiveFuncFromSignature(sig *FunctionSignature) DirectiveFunc {
+	if sig == nil {
+		return DirectiveFunc{MaxArgs: -1}
+	}
+	fn := DirectiveFunc{MaxArgs: len(sig.Params)}
+	for i := range sig.Params {
+		if i < len(sig.Variadic) && sig.Variadic[i] {
+			fn.MaxArgs = -1
+			continue
+		}
+		if i < len(sig.Defaults) && sig.Defaults[i] {
+			continue
+		}
+		fn.MinArgs++
+	}
+	return fn
+}
+
+// Lookup returns the overloads registered for a directive call.
+func (s *DirectiveSchema) Lookup(name string) ([]DirectiveFunc, bool) {
+	if s == nil {
+		return nil, false
+	}
+	fns, ok := s.Funcs[name]
+	return fns, ok && len(fns) > 0
+}
+
+// FindDirectiveModule returns the module a directive namespace refers to: the
+// module whose last path segment equals namespace. Modules with `pragma
+// directive` win over ordinary ones, then the lexically smaller path.
+func FindDirectiveModule(exports map[string]*ModuleExports, namespace string) *ModuleExports {
+	paths := make([]string, 0, 1)
+	for path := range exports {
+		lastSeg := path
+		if idx := strings.LastIndex(path, "/"); idx >= 0 {
+			lastSeg = path[idx+1:]
+		}
+		if lastSeg == namespace && exports[path] != nil {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	var found *ModuleExports
+	for _, path := range paths {
+		exp := exports[path]
+		if exp.PragmaFlags&ast.PragmaFlagDirective != 0 {
+			return exp
+		}
+		if found == nil {
+			found = exp
+		}
+	}
+	return found
+}
+
+// DirectiveCall is one parsed directive line: `ns.name(args)` or
+// `ns::name(args)`. Args holds the source text of each top-level argument.
+type DirectiveCall struct {
+	Namespace string
+	Name      string
+	Args      []string
+}
+
+// ParseDirectiveCall parses the text of a directive line. Arguments are only
+// split, not parsed: the call name and arity are all the resolver checks.
+func ParseDirectiveCall(text string) (DirectiveCall, bool) {
+	text = strings.TrimSuffix(strings.TrimSpace(text), ";")
+	ns, rest, ok := scanDirectiveIdent(text)
+	if !ok {
+		return DirectiveCall{}, false
+	}
+	switch {
+	case strings.HasPrefix(rest, "::"):
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "."):
+		rest = rest[1:]
+	default:
+		return DirectiveCall{}, false
+	}
+	name, rest, ok := scanDirectiveIdent(rest)
+	if !ok {
+		return DirectiveCall{}, false
+	}
+	rest = strings.TrimSpace(rest)
+	if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return DirectiveCall{}, false
+	}
+	args, ok := splitDirectiveArgs(rest[1 : len(rest)-1])
+	if !ok {
+		return DirectiveCall{}, false
+	}
+	return DirectiveCall{Namespace: ns, Name: name, Args: args}, true
+}
+
+func scanDirectiveIdent(s string) (ident, rest string, ok bool) {
+	end := 0
+	for end < len(s) {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		if r != '_' && !unicode.IsLetter(r) && (end == 0 || !unicode.IsDigit(r)) {
+			break
+		}
+		end += size
+	}
+	if end == 0 {
+		return "", s, false
+	}
+	return s[:end], s[end:], true
+}
+
+// splitDirectiveArgs делит аргументы верхнего уровня: запятые внутри скобок
+// и строковых литералов не разделяют аргументы, висячая запятая допускается.
+func splitDirectiveArgs(inner string) ([]string, bool) {
+	if strings.TrimSpace(inner) == "" {
+		return nil, true
+	}
+	depth := 0
+	start := 0
+	var args []string
+	var quote byte
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		if quote != 0 {
+			switch c {
+			case '\\':
+				i++
+			case quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth < 0 {
+				return nil, false
+			}
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(inner[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 || quote != 0 {
+		return nil, false
+	}
+	if last := strings.TrimSpace(inner[start:]); last != "" {
+		args = append(args, last)
+	} else if len(args) == 0 {
+		return nil, false
+	}
+	return args, true
+}
+
+// resolveDirectives проверяет строки директивных блоков по схеме пространства
+// имён. Неизвестное пространство блока сообщает sema (SemaDirectiveUnknownNamespace),
+// здесь проверяются вызовы внутри блока уже найденного директивного модуля.
+func (fr *fileResolver) resolveDirectives() {
+	file := fr.builder.Files.Get(fr.fileID)
+	if file == nil || len(file.Directives) == 0 || fr.declareOnly {
+		return
+	}
+	for i := range file.Directives {
+		block := &file.Directives[i]
+		fr.noteNameUse(block.Namespace)
+		for _, line := range block.Lines {
+			fr.noteDirectiveNameUses(fr.lookupString(line.Text))
+		}
+		if fr.resolver == nil || fr.resolver.reporter == nil {
+			continue
+		}
+		namespace := fr.lookupString(block.Namespace)
+		module := FindDirectiveModule(fr.moduleExports, namespace)
+		if module == nil || module.PragmaFlags&ast.PragmaFlagDirective == 0 {
+			continue
+		}
+		schema := fr.directiveSchema(namespace, module)
+		for _, line := range block.Lines {
+			fr.checkDirectiveLine(schema, line)
+		}
+	}
+}
+
+func (fr *fileResolver) directiveSchema(namespace string, module *ModuleExports) *DirectiveSchema {
+	if schema, ok := fr.directiveSchemas[namespace]; ok {
+		return schema
+	}
+	if fr.directiveSchemas == nil {
+		fr.directiveSchemas = make(map[string]*DirectiveSchema)
+	}
+	schema := NewDirectiveSchema(namespace, module)
+	fr.directiveSchemas[namespace] = schema
+	return schema
+}
+
+func (fr *fileResolver) checkDirectiveLine(schema *DirectiveSchema, line ast.DirectiveLine) {
+	call, ok := ParseDirectiveCall(fr.lookupString(line.Text))
+	if !ok {
+		return
+	}
+	reporter := fr.resolver.reporter
+	if call.Namespace != schema.Namespace {
+		msg := fmt.Sprintf("unknown directive namespace '%s' in a '%s:' block", call.Namespace, schema.Namespace)
+		if b := diag.ReportError(reporter, diag.SemaUnknownDirective, line.Span, msg); b != nil {
+			b.Emit()
+		}
+		return
+	}
+	fns, ok := schema.Lookup(call.Name)
+	if !ok {
+		msg := fmt.Sprintf("unknown directive '%s.%s'", call.Namespace, call.Name)
+		b := diag.ReportError(reporter, diag.SemaUnknownDirective, line.Span, msg)
+		if b == nil {
+			return
+		}
+		if suggestion, ok := schema.nearest(call.Name); ok {
+			b.WithNote(line.Span, fmt.Sprintf("did you mean `%s.%s`?", call.Namespace, suggestion))
+		}
+		b.Emit()
+		return
+	}
+	for _, fn := range fns {
+		if fn.Accepts(len(call.Args)) {
+			return
+		}
+	}
+	expected := make([]string, 0, len(fns))
+	for _, fn := range fns {
+		expected = append(expected, fn.String())
+	}
+	msg := fmt.Sprintf("directive '%s.%s' expects %s arguments, got %d",
+		call.Namespace, call.Name, strings.Join(expected, " or "), len(call.Args))
+	if b := diag.ReportError(reporter, diag.SemaDirectiveArity, line.Span, msg); b != nil {
+		b.Emit()
+	}
+}
+
+// nearest ищет вызов схемы, ближайший к name, по тем же правилам, что и nearestName.
+func (s *DirectiveSchema) nearest(name string) (string, bool) {
+	limit := utf8.RuneCountInString(name) / 3
+	if limit == 0 {
+		return "", false
+	}
+	names := make([]string, 0, len(s.Funcs))
+	for candidate := range s.Funcs {
+		names = append(names, candidate)
+	}
+	sort.Strings(names)
+	best, bestDist := "", limit+1
+	for _, candidate := range names {
+		if dist := editDistance(name, candidate, bestDist); dist < bestDist {
+			best, bestDist = candidate, dist
+		}
+	}
+	return best, best != ""
+}
+
+// noteDirectiveNameUses отмечает идентификаторы строки директивы как
+// использованные: импорт, нужный только директивам, не должен считаться лишним.
+func (fr *fileResolver) noteDirectiveNameUses(text string) {
+	if fr.builder == nil || fr.builder.StringsInterner == nil {
+		return
+	}
+	for text != "" {
+		ident, rest, ok := scanDirectiveIdent(text)
+		if !ok {
+			_, size := utf8.DecodeRuneInString(text)
+			text = text[size:]
+			continue
+		}
+		fr.noteNameUse(fr.builder.StringsInterner.Intern(ident))
+		text = rest
+	}
+}
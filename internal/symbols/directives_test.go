@@ -0,0 +1,98 @@
+package symbols
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/diag/diagtest"
+	"surge/internal/lexer"
+	"surge/internal/parser"
+	"surge/internal/source"
+)
+
+func resolveDirectiveSnippet(t *testing.T, src string) *diag.Bag {
+	t.Helper()
+	fs := source.NewFileSetWithBase("")
+	fileID := fs.AddVirtual("snippet.sg", []byte(src))
+	bag := diag.NewBag(32)
+	builder := ast.NewBuilder(ast.Hints{}, nil)
+	result := parser.ParseFile(context.Background(), fs, lexer.New(fs.Get(fileID), lexer.Options{}), builder, parser.Options{
+		Reporter:      &diag.BagReporter{Bag: bag},
+		MaxErrors:     uint(bag.Cap()),
+		DirectiveMode: parser.DirectiveModeCollect,
+	})
+	if bag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bag))
+	}
+
+	exports := NewModuleExports("stdlib/directives/test")
+	exports.PragmaFlags = ast.PragmaFlagDirective
+	exports.Add(&ExportedSymbol{
+		Name:      "eq",
+		Kind:      SymbolFunction,
+		Flags:     SymbolFlagPublic,
+		Signature: &FunctionSignature{Params: []TypeKey{"T", "T"}},
+	})
+	exports.Add(&ExportedSymbol{
+		Name:      "assert",
+		Kind:      SymbolFunction,
+		Flags:     SymbolFlagPublic,
+		Signature: &FunctionSignature{Params: []TypeKey{"bool"}},
+	})
+	_ = ResolveFile(builder, result.File, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+		ModuleExports: map[string]*ModuleExports{
+			"stdlib/directives/test": exports,
+		},
+	})
+	return bag
+}
+
+func TestResolveDirectiveCallMatchesSchema(t *testing.T) {
+	bag := resolveDirectiveSnippet(t, `
+import stdlib/directives::test;
+
+/// test:
+/// test.eq(add(1, 2), 3)
+/// test::assert(add(0, 0) == 0,)
+/// test.eq(pair(1, ","), (1, ","))
+pub fn add(a: int, b: int) -> int { return a + b; }
+`)
+	diagtest.AssertNoDiagnostics(t, bag)
+}
+
+func TestResolveDirectiveUnknownCallAndArity(t *testing.T) {
+	bag := resolveDirectiveSnippet(t, `
+import stdlib/directives::test;
+
+/// test:
+/// test.asert(add(1, 2) == 3)
+/// test.eq(add(1, 2))
+/// bench.eq(1, 1)
+pub fn add(a: int, b: int) -> int { return a + b; }
+`)
+	want := []struct {
+		code diag.Code
+		msg  string
+	}{
+		{diag.SemaUnknownDirective, "unknown directive 'test.asert'"},
+		{diag.SemaDirectiveArity, "directive 'test.eq' expects 2 arguments, got 1"},
+		{diag.SemaUnknownDirective, "unknown directive namespace 'bench' in a 'test:' block"},
+	}
+	items := bag.Items()
+	if len(items) != len(want) {
+		t.Fatalf("expected %d diagnostics, got %s", len(want), diagtest.Summary(bag))
+	}
+	for i, w := range want {
+		if items[i].Code != w.code || items[i].Message != w.msg {
+			t.Fatalf("diagnostic %d: want %s %q, got %s %q", i, w.code.ID(), w.msg, items[i].Code.ID(), items[i].Message)
+		}
+	}
+	if notes := items[0].Notes; len(notes) != 1 || !strings.Contains(notes[0].Msg, "did you mean `test.assert`?") {
+		t.Fatalf("expected a suggestion for test.asert, got %+v", notes)
+	}
+}
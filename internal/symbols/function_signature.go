@@ -2,9 +2,11 @@ package symbols
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"surge/internal/ast"
+	"surge/internal/numlit"
 	"surge/internal/source"
 )
 
@@ -138,7 +140,14 @@ func makeTypeKey(builder *ast.Builder, typeID ast.TypeID) TypeKey {
 		}
 	case ast.TypeExprArray:
 		if arr, ok := builder.Types.Array(typeID); ok {
-			return TypeKey("[" + string(makeTypeKey(builder, arr.Elem)) + "]")
+			elem := string(makeTypeKey(builder, arr.Elem))
+			if arr.Kind == ast.ArraySized {
+				// Ключ совпадает с sema typeKeyForType: `[T; N]`.
+				if length, ok := arrayLengthKey(builder, arr); ok {
+					return TypeKey("[" + elem + "; " + length + "]")
+				}
+			}
+			return TypeKey("[" + elem + "]")
 		}
 	case ast.TypeExprTuple:
 		if tup, ok := builder.Types.Tuple(typeID); ok {
@@ -165,6 +174,67 @@ func makeTypeKey(builder *ast.Builder, typeID ast.TypeID) TypeKey {
 	return TypeKey(fmt.Sprintf("type#%d", typeID))
 }
 
+// arrayLengthKey renders the length of `T[N]`: a literal, a const/generic name,
+// or literal arithmetic folded to a number.
+func arrayLengthKey(builder *ast.Builder, arr *ast.TypeArray) (string, bool) {
+	if arr.HasConstLen {
+		return strconv.FormatUint(arr.ConstLength, 10), true
+	}
+	if ident, ok := builder.Exprs.Ident(arr.Length); ok && ident != nil {
+		return builder.StringsInterner.MustLookup(ident.Name), true
+	}
+	if value, ok := foldArrayLength(builder, arr.Length); ok {
+		return strconv.FormatUint(value, 10), true
+	}
+	return "", false
+}
+
+func foldArrayLength(builder *ast.Builder, id ast.ExprID) (uint64, bool) {
+	expr := builder.Exprs.Get(id)
+	if expr == nil {
+		return 0, false
+	}
+	switch expr.Kind {
+	case ast.ExprLit:
+		lit, ok := builder.Exprs.Literal(id)
+		if !ok || lit == nil || (lit.Kind != ast.ExprLitInt && lit.Kind != ast.ExprLitUint) {
+			return 0, false
+		}
+		return numlit.ParseUint64(builder.StringsInterner.MustLookup(lit.Value))
+	case ast.ExprGroup:
+		group, ok := builder.Exprs.Group(id)
+		if !ok || group == nil {
+			return 0, false
+		}
+		return foldArrayLength(builder, group.Inner)
+	case ast.ExprBinary:
+		bin, ok := builder.Exprs.Binary(id)
+		if !ok || bin == nil {
+			return 0, false
+		}
+		left, okL := foldArrayLength(builder, bin.Left)
+		right, okR := foldArrayLength(builder, bin.Right)
+		if !okL || !okR {
+			return 0, false
+		}
+		switch bin.Op {
+		case ast.ExprBinaryAdd:
+			if sum := left + right; sum >= left {
+				return sum, true
+			}
+		case ast.ExprBinarySub:
+			if left >= right {
+				return left - right, true
+			}
+		case ast.ExprBinaryMul:
+			if left == 0 || right <= ^uint64(0)/left {
+				return left * right, true
+			}
+		}
+	}
+	return 0, false
+}
+
 func signaturesEqual(a, b *FunctionSignature) bool {
 	if a == nil || b == nil {
 		return a == b
@@ -194,3 +264,89 @@ func signatureDiffersFromAll(sig *FunctionSignature, symbols []*Symbol) bool {
 	}
 	return true
 }
+
+// ambiguousOverloads returns the symbols whose signatures accept some positional
+// call that sig accepts as well, so that no call of that shape can pick one.
+func ambiguousOverloads(sig *FunctionSignature, symbols []*Symbol) []*Symbol {
+	var out []*Symbol
+	for _, sym := range symbols {
+		if sym == nil || sym.Flags&SymbolFlagBuiltin != 0 {
+			continue
+		}
+		if overloadsOverlap(sig, sym.Signature) {
+			out = append(out, sym)
+		}
+	}
+	return out
+}
+
+// overloadsOverlap reports whether some argument count is accepted by both
+// signatures with identical parameter types at every position. Defaulted
+// parameters make a signature accept shorter calls; a variadic tail makes it
+// accept any longer one. Call resolution penalises variadic candidates, so a
+// variadic overload never ties with a non-variadic one.
+func overloadsOverlap(a, b *FunctionSignature) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	aMin, aMax := signatureArity(a)
+	bMin, bMax := signatureArity(b)
+	if (aMax < 0) != (bMax < 0) {
+		return false
+	}
+	lo := max(aMin, bMin)
+	hi := len(a.Params) + len(b.Params) + 1
+	if aMax >= 0 {
+		hi = min(hi, aMax)
+	}
+	if bMax >= 0 {
+		hi = min(hi, bMax)
+	}
+	for n := lo; n <= hi; n++ {
+		same := true
+		for i := range n {
+			key := paramKeyAt(a, i)
+			if key == "" || key != paramKeyAt(b, i) {
+				same = false
+				break
+			}
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+// signatureArity returns the minimum and maximum number of positional
+// arguments a signature accepts, matching the arity check of call resolution:
+// a variadic signature needs every parameter before the variadic one and has
+// no maximum (-1).
+func signatureArity(sig *FunctionSignature) (minArgs, maxArgs int) {
+	for i := range sig.Params {
+		if i < len(sig.Variadic) && sig.Variadic[i] {
+			return len(sig.Params) - 1, -1
+		}
+	}
+	for i := range sig.Params {
+		if i >= len(sig.Defaults) || !sig.Defaults[i] {
+			minArgs++
+		}
+	}
+	return minArgs, len(sig.Params)
+}
+
+// paramKeyAt returns the parameter type an argument at position i binds to.
+func paramKeyAt(sig *FunctionSignature, i int) TypeKey {
+	for j := range sig.Params {
+		if j < len(sig.Variadic) && sig.Variadic[j] {
+			if i >= j {
+				return sig.Params[j]
+			}
+		}
+		if j == i {
+			return sig.Params[j]
+		}
+	}
+	return ""
+}
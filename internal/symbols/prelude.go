@@ -13,6 +13,7 @@ func builtinPreludeEntries() []PreludeEntry {
 		{Name: "uint16", Kind: SymbolType, Flags: SymbolFlagBuiltin},
 		{Name: "uint32", Kind: SymbolType, Flags: SymbolFlagBuiltin},
 		{Name: "uint64", Kind: SymbolType, Flags: SymbolFlagBuiltin},
+		{Name: "char", Kind: SymbolType, Flags: SymbolFlagBuiltin},
 		{Name: "bool", Kind: SymbolType, Flags: SymbolFlagBuiltin},
 		{Name: "float", Kind: SymbolType, Flags: SymbolFlagBuiltin},
 		{Name: "float16", Kind: SymbolType, Flags: SymbolFlagBuiltin},
@@ -24,6 +24,10 @@ type ResolveOptions struct {
 	ModuleScope   ScopeID
 	DeclareOnly   bool
 	ReuseDecls    bool
+	Cfg           CfgSettings // settings for @cfg items; nil means DefaultCfgSettings
+	// CheckRelativeImports reports relative imports whose module file is missing
+	// under BaseDir. The driver leaves it off: its module graph reports them.
+	CheckRelativeImports bool
 }
 
 // Result captures resolve artefacts for one file.
@@ -102,15 +106,22 @@ func ResolveFile(builder *ast.Builder, fileID ast.FileID, opts *ResolveOptions)
 		aliasExports:        make(map[source.StringID]*ModuleExports),
 		aliasModulePaths:    make(map[source.StringID]string),
 		syntheticImportSyms: make(map[string]SymbolID),
+		globImports:         make(map[source.StringID]globImport),
 		noStd:               noStd,
 		declareOnly:         opts.DeclareOnly,
 		reuseDecls:          opts.ReuseDecls,
+		checkRelative:       opts.CheckRelativeImports,
 	}
 	fr.injectCoreExports()
-	fr.predeclareConstItems(file.Items)
-	for _, itemID := range file.Items {
+	// второй проход модуля (ReuseDecls) повторно вычисляет @cfg — не дублируем ошибки
+	items := fr.cfgItems(file, opts.Cfg, !opts.ReuseDecls)
+	fr.predeclareConstItems(items)
+	for _, itemID := range items {
 		fr.handleItem(itemID)
 	}
+	fr.checkGlobImportCollisions()
+	fr.resolveDirectives()
+	fr.reportUnusedBindings(items)
 
 	if opts.Validate {
 		if err := table.Validate(); err != nil {
@@ -138,12 +149,25 @@ type fileResolver struct {
 	moduleImports       map[string]source.Span
 	moduleExports       map[string]*ModuleExports
 	aliasExports        map[source.StringID]*ModuleExports
+	directiveSchemas    map[string]*DirectiveSchema
 	aliasModulePaths    map[source.StringID]string
 	syntheticImportSyms map[string]SymbolID
+	globImports         map[source.StringID]globImport
 	noStd               bool
 	declareOnly         bool
 	reuseDecls          bool
+	checkRelative       bool
 	typeParamStack      [][]source.StringID
+	loopLabels          []loopLabel
+	callTarget          ast.ExprID // target of the call being walked; narrows name suggestions
+	localLets           []localLet
+	usedNames           map[source.StringID]struct{}
+}
+
+// loopLabel is an enclosing labeled loop visible to break/continue.
+type loopLabel struct {
+	name source.StringID
+	span source.Span
 }
 
 func (fr *fileResolver) pushTypeParams(params []source.StringID) {
@@ -292,6 +316,26 @@ func (fr *fileResolver) reportInvalidOverride(name source.StringID, span source.
 	b.Emit()
 }
 
+func (fr *fileResolver) reportAmbiguousOverloadSet(name source.StringID, span source.Span, clash []*Symbol) {
+	reporter := fr.resolver.reporter
+	if reporter == nil {
+		return
+	}
+	nameStr := fr.builder.StringsInterner.MustLookup(name)
+	msg := fmt.Sprintf("@overload of '%s' is ambiguous: some calls match more than one overload", nameStr)
+	b := diag.ReportError(reporter, diag.SemaAmbiguousOverloadSet, span, msg)
+	if b == nil {
+		return
+	}
+	for _, sym := range clash {
+		if sym.Span == (source.Span{}) {
+			continue
+		}
+		b.WithNote(sym.Span, "overlapping overload declared here")
+	}
+	b.Emit()
+}
+
 func (fr *fileResolver) attachPreviousNotes(b *diag.ReportBuilder, existing []SymbolID) {
 	if b == nil {
 		return
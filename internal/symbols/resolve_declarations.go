@@ -468,6 +468,10 @@ func (fr *fileResolver) declareFunctionWithAttrs(fnItem *ast.FnItem, span, keywo
 				fr.reportInvalidOverride(fnItem.Name, span, "@overload duplicates existing signature; use @override", existing)
 				return NoSymbolID, false
 			}
+			if clash := ambiguousOverloads(newSig, existingSymbols); len(clash) > 0 {
+				fr.reportAmbiguousOverloadSet(fnItem.Name, span, clash)
+				return NoSymbolID, false
+			}
 		case hasOverride:
 			match := false
 			for _, sym := range existingSymbols {
@@ -2,7 +2,9 @@ package symbols
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"surge/internal/ast"
@@ -15,6 +17,9 @@ import (
 // Поддерживает импорт отдельных символов, групп символов и импорт всех символов (import *).
 func (fr *fileResolver) declareImport(itemID ast.ItemID, importItem *ast.ImportItem, itemSpan source.Span) {
 	modulePath := fr.resolveImportModulePath(importItem.Module, itemSpan)
+	if !fr.reuseDecls {
+		fr.checkRelativeImportTarget(importItem, modulePath, itemSpan)
+	}
 	hasItems := importItem.HasOne || len(importItem.Group) > 0 || importItem.ImportAll
 
 	if !hasItems {
@@ -82,9 +87,9 @@ func (fr *fileResolver) declareModuleAlias(itemID ast.ItemID, alias source.Strin
 
 // declareImportName объявляет импортируемый символ с указанным именем.
 // Поддерживает алиасы для импортируемых символов.
-func (fr *fileResolver) declareImportName(itemID ast.ItemID, name, original source.StringID, module []source.StringID, modulePath string, span source.Span) {
+func (fr *fileResolver) declareImportName(itemID ast.ItemID, name, original source.StringID, module []source.StringID, modulePath string, span source.Span) SymbolID {
 	if name == source.NoStringID {
-		return
+		return NoSymbolID
 	}
 	decl := SymbolDecl{
 		SourceFile: fr.sourceFile,
@@ -93,14 +98,14 @@ func (fr *fileResolver) declareImportName(itemID ast.ItemID, name, original sour
 	}
 	if reused := fr.findExistingSymbol(name, SymbolImport, decl); reused.IsValid() {
 		fr.appendItemSymbol(itemID, reused)
-		return
+		return reused
 	}
 	if existingID, existing := fr.findCompatibleImport(name, modulePath, original); existing != nil {
 		fr.appendItemSymbol(itemID, existingID)
 		if !fr.reuseDecls {
 			fr.reportDuplicateImport(name, span, existing.Span, "imported name")
 		}
-		return
+		return existingID
 	}
 	if symID, ok := fr.resolver.Declare(name, span, SymbolImport, SymbolFlagImported, decl); ok {
 		if sym := fr.result.Table.Symbols.Get(symID); sym != nil {
@@ -115,11 +120,14 @@ func (fr *fileResolver) declareImportName(itemID ast.ItemID, name, original sour
 			}
 		}
 		fr.appendItemSymbol(itemID, symID)
+		return symID
 	}
+	return NoSymbolID
 }
 
 // declareImportAll импортирует все публичные символы из указанного модуля.
 // Символы с атрибутом @hidden уже отфильтрованы в CollectExports.
+// Имена обходятся в отсортированном порядке, чтобы символы и диагностики были детерминированы.
 func (fr *fileResolver) declareImportAll(itemID ast.ItemID, module []source.StringID, modulePath string, span source.Span) {
 	if modulePath == "" {
 		return
@@ -131,13 +139,103 @@ func (fr *fileResolver) declareImportAll(itemID ast.ItemID, module []source.Stri
 		return
 	}
 
-	// Импортируем все публичные символы
-	// @hidden символы уже отфильтрованы в CollectExports
+	names := make([]string, 0, len(exports.Symbols))
 	for name := range exports.Symbols {
-		// Импортируем символ
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
 		nameID := fr.builder.StringsInterner.Intern(name)
-		fr.declareImportName(itemID, nameID, nameID, module, modulePath, span)
+		if !fr.reuseDecls && fr.globImportConflicts(itemID, nameID, modulePath, span) {
+			continue
+		}
+		if symID := fr.declareImportName(itemID, nameID, nameID, module, modulePath, span); symID.IsValid() {
+			fr.globImports[nameID] = globImport{symbol: symID, modulePath: modulePath, span: span}
+		}
+	}
+}
+
+// globImport запоминает имя, пришедшее из `import module::*`, для поиска коллизий.
+type globImport struct {
+	symbol     SymbolID
+	modulePath string
+	span       source.Span
+}
+
+// globImportConflicts проверяет, не занято ли имя из glob-импорта в текущем файле.
+// Повторный импорт того же модуля молча пропускается; коллизии с другим glob-импортом
+// или с объявлением выше по файлу репортятся как SemaDuplicateSymbol.
+func (fr *fileResolver) globImportConflicts(itemID ast.ItemID, name source.StringID, modulePath string, span source.Span) bool {
+	if prev, ok := fr.globImports[name]; ok {
+		if prev.modulePath != modulePath {
+			msg := fmt.Sprintf("'%s' is ambiguous: imported by both '%s::*' and '%s::*'", fr.lookupString(name), prev.modulePath, modulePath)
+			fr.reportGlobCollision(span, prev.span, msg, "previous glob import here")
+		}
+		return true
+	}
+	scope := fr.result.Table.Scopes.Get(fr.resolver.CurrentScope())
+	if scope == nil {
+		return false
+	}
+	for _, id := range scope.NameIndex[name] {
+		sym := fr.result.Table.Symbols.Get(id)
+		if sym == nil || sym.Decl.ASTFile != fr.fileID || sym.Decl.Item == itemID {
+			continue
+		}
+		if sym.Kind == SymbolImport && sym.ModulePath == modulePath {
+			return true
+		}
+		msg := fmt.Sprintf("'%s' imported by '%s::*' conflicts with an existing declaration", fr.lookupString(name), modulePath)
+		fr.reportGlobCollision(span, sym.Span, msg, "previous declaration here")
+		return true
 	}
+	return false
+}
+
+// checkGlobImportCollisions репортит объявления, появившиеся в файле после
+// glob-импорта с тем же именем.
+func (fr *fileResolver) checkGlobImportCollisions() {
+	if fr.reuseDecls || len(fr.globImports) == 0 {
+		return
+	}
+	scope := fr.result.Table.Scopes.Get(fr.result.FileScope)
+	if scope == nil {
+		return
+	}
+	names := make([]source.StringID, 0, len(fr.globImports))
+	for name := range fr.globImports {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return fr.globImports[names[i]].symbol < fr.globImports[names[j]].symbol })
+	for _, name := range names {
+		glob := fr.globImports[name]
+		for _, id := range scope.NameIndex[name] {
+			sym := fr.result.Table.Symbols.Get(id)
+			if id == glob.symbol || sym == nil || sym.Decl.ASTFile != fr.fileID {
+				continue
+			}
+			if sym.Kind == SymbolImport || sym.Kind == SymbolModule {
+				continue
+			}
+			msg := fmt.Sprintf("'%s' conflicts with a name imported by '%s::*'", fr.lookupString(name), glob.modulePath)
+			fr.reportGlobCollision(sym.Span, glob.span, msg, "glob import here")
+		}
+	}
+}
+
+func (fr *fileResolver) reportGlobCollision(span, prev source.Span, msg, note string) {
+	if fr.resolver == nil || fr.resolver.reporter == nil {
+		return
+	}
+	builder := diag.ReportError(fr.resolver.reporter, diag.SemaDuplicateSymbol, span, msg)
+	if builder == nil {
+		return
+	}
+	if prev != (source.Span{}) {
+		builder.WithNote(prev, note)
+	}
+	builder.Emit()
 }
 
 // trackModuleImport отслеживает импорт модуля и проверяет на дубликаты.
@@ -307,6 +405,73 @@ func (fr *fileResolver) resolveImportModulePath(module []source.StringID, span s
 	return joined
 }
 
+// checkRelativeImportTarget проверяет, что относительный импорт (`./`, `../`)
+// указывает на существующий модуль: файл `<path>.sg` или каталог с .sg-файлами
+// относительно корня проекта. Импорты вида `./dir::module` считаются найденными,
+// если существует dir/module, как и модули, уже известные по ModuleExports.
+func (fr *fileResolver) checkRelativeImportTarget(importItem *ast.ImportItem, modulePath string, span source.Span) {
+	if !fr.checkRelative || fr.baseDir == "" || modulePath == "" || len(importItem.Module) == 0 {
+		return
+	}
+	if first := fr.lookupString(importItem.Module[0]); first != "." && first != ".." {
+		return
+	}
+	// Путь, выходящий за корень проекта, уже отвергнут при сборе импортов.
+	if strings.HasPrefix(modulePath, ".") || fr.moduleExports[modulePath] != nil || moduleExistsOnDisk(fr.baseDir, modulePath) {
+		return
+	}
+	// import ./dir::name может ссылаться на модуль dir/name.
+	var members []source.StringID
+	if importItem.HasOne {
+		members = append(members, importItem.One.Name)
+	}
+	for _, pair := range importItem.Group {
+		members = append(members, pair.Name)
+	}
+	for _, member := range members {
+		candidate := modulePath + "/" + fr.lookupString(member)
+		if fr.moduleExports[candidate] != nil || moduleExistsOnDisk(fr.baseDir, candidate) {
+			return
+		}
+	}
+
+	if fr.resolver == nil || fr.resolver.reporter == nil {
+		return
+	}
+	msg := ModuleNotFoundMessage(fr.baseDir, modulePath)
+	if b := diag.ReportError(fr.resolver.reporter, diag.SemaModuleNotFound, span, msg); b != nil {
+		b.Emit()
+	}
+}
+
+// ModuleNotFoundMessage формирует текст SemaModuleNotFound с путём к файлу
+// модуля, который искали относительно baseDir.
+func ModuleNotFoundMessage(baseDir, modulePath string) string {
+	return fmt.Sprintf("module %q not found: %s does not exist", modulePath, modulePathFile(baseDir, modulePath))
+}
+
+// modulePathFile возвращает путь к файлу модуля относительно корня проекта.
+func modulePathFile(baseDir, modulePath string) string {
+	return filepath.Join(baseDir, filepath.FromSlash(modulePath)+".sg")
+}
+
+// moduleExistsOnDisk сообщает, есть ли файл модуля или каталог с .sg-файлами.
+func moduleExistsOnDisk(baseDir, modulePath string) bool {
+	if st, err := os.Stat(modulePathFile(baseDir, modulePath)); err == nil && !st.IsDir() {
+		return true
+	}
+	entries, err := os.ReadDir(filepath.Join(baseDir, filepath.FromSlash(modulePath)))
+	if err != nil {
+		return false
+	}
+	for _, ent := range entries {
+		if !ent.IsDir() && filepath.Ext(ent.Name()) == ".sg" {
+			return true
+		}
+	}
+	return false
+}
+
 // moduleSegmentsToStrings конвертирует сегменты модуля из StringID в строки.
 func (fr *fileResolver) moduleSegmentsToStrings(module []source.StringID) []string {
 	if len(module) == 0 || fr.builder == nil || fr.builder.StringsInterner == nil {
@@ -0,0 +1,96 @@
+package symbols
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// valueKindMask — всё, что может стоять на месте значения; контракты значениями не бывают.
+	valueKindMask = SymbolModule.Mask() | SymbolImport.Mask() | SymbolFunction.Mask() |
+		SymbolLet.Mask() | SymbolConst.Mask() | SymbolType.Mask() | SymbolParam.Mask() | SymbolTag.Mask()
+	// callableKindMask — цели вызова: функции, конструкторы тегов и типов, импортированные имена.
+	callableKindMask = SymbolImport.Mask() | SymbolFunction.Mask() | SymbolType.Mask() | SymbolTag.Mask()
+)
+
+// nearestName ищет видимое имя, ближайшее к name по расстоянию редактирования
+// (перестановка соседних букв считается одной правкой: `reutrn` -> `return`).
+// Порог растёт с длиной имени, а у имён короче трёх символов подсказок нет:
+// для `x` почти любое короткое имя оказалось бы "близким".
+// При равном расстоянии побеждает более внутренняя область видимости,
+// внутри одной области — лексикографически меньшее имя.
+func (fr *fileResolver) nearestName(name string, mask KindMask) (string, bool) {
+	limit := utf8.RuneCountInString(name) / 3
+	if limit == 0 || fr.resolver == nil || fr.resolver.table == nil {
+		return "", false
+	}
+	best := ""
+	bestDist := limit + 1
+	seen := make(map[string]struct{})
+	scopeID := fr.resolver.CurrentScope()
+	for scopeID.IsValid() {
+		scope := fr.resolver.table.Scopes.Get(scopeID)
+		if scope == nil {
+			break
+		}
+		scopeBest, scopeDist := "", bestDist
+		for nameID := range scope.NameIndex {
+			candidate := fr.lookupString(nameID)
+			if candidate == "" || candidate == "_" || strings.HasPrefix(candidate, "__") {
+				continue
+			}
+			if _, ok := seen[candidate]; ok {
+				continue
+			}
+			if len(fr.resolver.lookupInScope(scopeID, nameID, mask)) == 0 {
+				continue
+			}
+			seen[candidate] = struct{}{}
+			dist := editDistance(name, candidate, scopeDist)
+			if dist < scopeDist || (dist == scopeDist && scopeBest != "" && candidate < scopeBest) {
+				scopeBest, scopeDist = candidate, dist
+			}
+		}
+		if scopeBest != "" && scopeDist < bestDist {
+			best, bestDist = scopeBest, scopeDist
+		}
+		scopeID = scope.Parent
+	}
+	return best, best != ""
+}
+
+// editDistance считает расстояние Дамерау–Левенштейна (вариант optimal string
+// alignment) между a и b. Как только расстояние заведомо превышает limit,
+// возвращается limit+1 — точное значение тогда уже не нужно.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return min(prev[len(rb)], limit+1)
+}
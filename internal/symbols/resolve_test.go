@@ -2,12 +2,15 @@ package symbols
 
 import (
 	"context"
-	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"surge/internal/ast"
 	"surge/internal/diag"
+	"surge/internal/diag/diagtest"
+	"surge/internal/fix"
 	"surge/internal/lexer"
 	"surge/internal/parser"
 	"surge/internal/source"
@@ -17,7 +20,7 @@ func TestResolveFileDeclaresTopLevelSymbols(t *testing.T) {
 	src := `
         import foo::Bar;
         let answer = 42;
-        fn compute() {}
+        fn compute(bar: Bar) {}
         type ID = nothing;
     `
 	builder, fileID, parseBag := parseSnippet(t, src)
@@ -80,13 +83,419 @@ func TestResolveFileDuplicateLetReported(t *testing.T) {
 	}
 }
 
+func TestResolveLoopLabels(t *testing.T) {
+	src := `
+        fn f() {
+            outer: while true {
+                for x in 0..3 {
+                    continue outer;
+                }
+                break outer;
+            }
+            break missing;
+            dup: while true {
+                dup: while true {}
+            }
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	if bag.Len() != 2 {
+		t.Fatalf("expected 2 diagnostics, got %s", diagtest.Summary(bag))
+	}
+	diagtest.AssertHasCode(t, bag, diag.SemaUnknownLoopLabel)
+	diagtest.AssertHasCode(t, bag, diag.SemaDuplicateLoopLabel)
+}
+
+func TestResolveOrPatternRejectsBindings(t *testing.T) {
+	src := `
+        tag Hit(int);
+
+        fn f(v: int, w: Hit) {
+            let limit = 3;
+            compare v {
+                1 | limit => 1;
+                finally => 0;
+            };
+            compare w {
+                Hit(n) | Hit(_) => 1;
+            };
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	if bag.Len() != 2 {
+		t.Fatalf("expected 2 diagnostics, got %s", diagtest.Summary(bag))
+	}
+	diagtest.AssertHasCode(t, bag, diag.SemaInvalidOrPattern)
+}
+
+func TestResolveConstantConditionWarns(t *testing.T) {
+	src := `
+        fn f() {
+            if false {
+                return;
+            }
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	if bag.Len() != 1 {
+		t.Fatalf("expected 1 diagnostic, got %s", diagtest.Summary(bag))
+	}
+	d := bag.Items()[0]
+	if d.Code != diag.SemaConstantCondition || d.Severity != diag.SevWarning {
+		t.Fatalf("expected SemaConstantCondition warning, got %s", diagtest.Summary(bag))
+	}
+	if !strings.Contains(d.Message, "always false") {
+		t.Fatalf("expected `if false` to fold to false, got %q", d.Message)
+	}
+}
+
+func TestResolveDynamicConditionIsSilent(t *testing.T) {
+	src := `
+        const enabled = true;
+        fn f(flag: bool) {
+            if flag {
+                return;
+            }
+            if flag && enabled {
+                return;
+            }
+            while true {
+                break;
+            }
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	// `while true` folds but is not reported: it is the idiomatic infinite loop.
+	diagtest.AssertNoDiagnostics(t, bag)
+}
+
+func TestResolveAssignInConditionSuggestsEquality(t *testing.T) {
+	src := `fn f() {
+    let mut x = 1;
+    if (x = 0) {
+        return;
+    }
+}
+`
+	fs := source.NewFileSetWithBase("")
+	builder := ast.NewBuilder(ast.Hints{}, nil)
+	fileID, parseBag := parseVirtualFile(t, fs, builder, "cond.sg", src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	if bag.Len() != 1 {
+		t.Fatalf("expected 1 diagnostic, got %s", diagtest.Summary(bag))
+	}
+	d := bag.Items()[0]
+	if d.Code != diag.SemaAssignInCondition || d.Severity != diag.SevWarning {
+		t.Fatalf("expected SemaAssignInCondition warning, got %s", diagtest.Summary(bag))
+	}
+	if len(d.Fixes) != 1 {
+		t.Fatalf("expected one fix, got %d", len(d.Fixes))
+	}
+	resolved, err := d.Fixes[0].Resolve(diag.FixBuildContext{FileSet: fs})
+	if err != nil {
+		t.Fatalf("materialize fix: %v", err)
+	}
+	if resolved.Applicability != diag.FixApplicabilitySafeWithHeuristics {
+		t.Fatalf("expected SafeWithHeuristics, got %v", resolved.Applicability)
+	}
+	fixed, err := fix.ApplyEdits([]byte(src), resolved.Edits)
+	if err != nil {
+		t.Fatalf("apply fix: %v", err)
+	}
+	if !strings.Contains(string(fixed), "if (x == 0) {") {
+		t.Fatalf("expected '=' to become '==', got:\n%s", fixed)
+	}
+}
+
+func TestResolveEqualityConditionIsClean(t *testing.T) {
+	src := `
+        fn f() {
+            let mut x = 1;
+            if (x == 0) {
+                return;
+            }
+            while x == 1 {
+                x = 2;
+            }
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	diagtest.AssertNoDiagnostics(t, bag)
+}
+
+func TestResolveUnusedLocalSuggestsUnderscore(t *testing.T) {
+	src := `fn f(unused_param: int) -> int {
+    let mut tally = 1;
+    let (a, b) = (2, 3);
+    let _skipped = 4;
+    return a;
+}
+`
+	fs := source.NewFileSetWithBase("")
+	builder := ast.NewBuilder(ast.Hints{}, nil)
+	fileID, parseBag := parseVirtualFile(t, fs, builder, "unused.sg", src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	if bag.Len() != 2 {
+		t.Fatalf("expected 2 diagnostics, got %s", diagtest.Summary(bag))
+	}
+	var edits []diag.TextEdit
+	for _, d := range bag.Items() {
+		if d.Code != diag.SemaUnusedLocal || d.Severity != diag.SevWarning {
+			t.Fatalf("expected SemaUnusedLocal warnings, got %s", diagtest.Summary(bag))
+		}
+		if len(d.Fixes) != 1 {
+			t.Fatalf("%s: expected one fix, got %d", d.Message, len(d.Fixes))
+		}
+		resolved, err := d.Fixes[0].Resolve(diag.FixBuildContext{FileSet: fs})
+		if err != nil {
+			t.Fatalf("materialize fix: %v", err)
+		}
+		edits = append(edits, resolved.Edits...)
+	}
+	fixed, err := fix.ApplyEdits([]byte(src), edits)
+	if err != nil {
+		t.Fatalf("apply fixes: %v", err)
+	}
+	if !strings.Contains(string(fixed), "let mut _tally = 1;") || !strings.Contains(string(fixed), "let (a, _b) = (2, 3);") {
+		t.Fatalf("expected bindings prefixed with '_', got:\n%s", fixed)
+	}
+}
+
+func TestResolveUnusedImportIsReported(t *testing.T) {
+	src := `
+        import foo::Gone;
+        import foo::{Used, Unused};
+        fn f(u: Used) {}
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	if bag.Len() != 2 {
+		t.Fatalf("expected 2 diagnostics, got %s", diagtest.Summary(bag))
+	}
+	gone, unused := bag.Items()[0], bag.Items()[1]
+	if gone.Code != diag.SemaUnusedImport || gone.Message != "unused import 'Gone'" {
+		t.Fatalf("unexpected diagnostic: %s", diagtest.Summary(bag))
+	}
+	if len(gone.Fixes) != 1 || len(gone.Fixes[0].Edits) != 1 || gone.Fixes[0].Edits[0].NewText != "" {
+		t.Fatalf("expected a fix removing the import, got %+v", gone.Fixes)
+	}
+	// Used из той же группы нужен, поэтому импорт целиком не удаляется.
+	if unused.Code != diag.SemaUnusedImport || unused.Message != "unused import 'Unused'" || len(unused.Fixes) != 0 {
+		t.Fatalf("expected a fixless warning for 'Unused', got %s (fixes %+v)", diagtest.Summary(bag), unused.Fixes)
+	}
+}
+
+func TestResolveShadowedLocalReadBeforeShadowIsUsed(t *testing.T) {
+	src := `
+        fn f() -> int {
+            let x = 1;
+            while true {
+                let x = x + 1;
+                return x;
+            }
+            return 0;
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	// Внешний x прочитан в инициализаторе внутреннего: остаётся только
+	// предупреждение о затенении.
+	if bag.Len() != 1 || bag.Items()[0].Code != diag.SemaShadowSymbol {
+		t.Fatalf("expected only a shadowing warning, got %s", diagtest.Summary(bag))
+	}
+}
+
+func TestResolveUnresolvedSuggestsNearestName(t *testing.T) {
+	src := `
+        fn compute(value: int) -> int {
+            let counter = value;
+            return coutner + counter;
+        }
+        fn run() -> int {
+            return comptue(1);
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	want := map[string]string{
+		"cannot resolve 'coutner'": "counter",
+		"cannot resolve 'comptue'": "compute",
+	}
+	if bag.Len() != len(want) {
+		t.Fatalf("expected %d diagnostics, got %s", len(want), diagtest.Summary(bag))
+	}
+	for _, d := range bag.Items() {
+		suggestion, ok := want[d.Message]
+		if !ok || d.Code != diag.SemaUnresolvedSymbol {
+			t.Fatalf("unexpected diagnostic: %s", diagtest.Summary(bag))
+		}
+		if len(d.Fixes) != 1 || len(d.Fixes[0].Edits) != 1 || d.Fixes[0].Edits[0].NewText != suggestion {
+			t.Fatalf("%s: expected a fix renaming to %q, got %+v", d.Message, suggestion, d.Fixes)
+		}
+		if len(d.Notes) != 1 || !strings.Contains(d.Notes[0].Msg, "did you mean `"+suggestion+"`?") {
+			t.Fatalf("%s: expected a did-you-mean note, got %+v", d.Message, d.Notes)
+		}
+	}
+}
+
+func TestResolveUnresolvedFarNameHasNoSuggestion(t *testing.T) {
+	src := `
+        fn compute(value: int) -> int {
+            let counter = value;
+            return zebra_total + x + counter;
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	diagtest.AssertNoDiagnostics(t, parseBag)
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	if bag.Len() != 2 {
+		t.Fatalf("expected 2 diagnostics, got %s", diagtest.Summary(bag))
+	}
+	for _, d := range bag.Items() {
+		if len(d.Fixes) != 0 || len(d.Notes) != 0 {
+			t.Fatalf("%s: expected no suggestion, got fixes %+v notes %+v", d.Message, d.Fixes, d.Notes)
+		}
+	}
+}
+
+func TestResolveParallelArgsScopedToBody(t *testing.T) {
+	src := `
+        fn main() {
+            let xs = [1, 2, 3];
+            let _ys = parallel map xs with (x) => x * 2;
+            let _total = parallel reduce xs with 0, (acc, x) => acc + x;
+            let _leaked = acc;
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+	if bag.Len() != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", bag.Len(), bag.Items())
+	}
+	if got := bag.Items()[0].Code; got != diag.SemaUnresolvedSymbol {
+		t.Fatalf("expected SemaUnresolvedSymbol, got %v", got)
+	}
+}
+
 func TestResolveLetTuplePatternDeclaresBindings(t *testing.T) {
 	src := `
         fn main() {
             let (a, b, c) = (1, 2, 3);
-            let use_a = a;
-            let use_b = b;
-            let use_c = c;
+            let _use_a = a;
+            let _use_b = b;
+            let _use_c = c;
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+	if bag.Len() != 0 {
+		t.Fatalf("unexpected semantic diagnostics: %v", bag.Items())
+	}
+}
+
+func TestResolveSignalDeclaresTarget(t *testing.T) {
+	src := `
+        fn main() {
+            let base = 1;
+            signal total := base + 1;
+            let _doubled = total * 2;
         }
     `
 	builder, fileID, parseBag := parseSnippet(t, src)
@@ -104,6 +513,31 @@ func TestResolveLetTuplePatternDeclaresBindings(t *testing.T) {
 	}
 }
 
+func TestResolveSignalRedeclarationReported(t *testing.T) {
+	src := `
+        fn main() {
+            let total = 1;
+            signal total := total + 2;
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+	if bag.Len() != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", bag.Len(), bag.Items())
+	}
+	if got := bag.Items()[0].Code; got != diag.SemaDuplicateSymbol {
+		t.Fatalf("expected SemaDuplicateSymbol, got %v", got)
+	}
+}
+
 func TestResolveAllowsFunctionOverloads(t *testing.T) {
 	src := `
         fn compute() {}
@@ -135,6 +569,68 @@ func TestResolveAllowsFunctionOverloads(t *testing.T) {
 	}
 }
 
+func TestResolveAcceptsDistinguishableOverloadSet(t *testing.T) {
+	src := `
+        fn compute(a: int) {}
+        @overload fn compute(a: int, b: int) {}
+        @overload fn compute(a: string, b: int = 1) {}
+        @overload fn compute(a: int, b: string, ...rest: int) {}
+        @overload fn compute(a: int, ...rest: int) {}
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+	})
+
+	if bag.Len() != 0 {
+		t.Fatalf("did not expect diagnostics, got %d: %s", bag.Len(), bag.Items()[0].Message)
+	}
+}
+
+func TestResolveRejectsAmbiguousOverloadSet(t *testing.T) {
+	cases := map[string]string{
+		"default": `
+        fn compute(a: int) {}
+        @overload fn compute(a: int, b: int = 1) {}
+    `,
+		"variadic": `
+        fn compute(...xs: int) {}
+        @overload fn compute(a: int, ...rest: int) {}
+    `,
+	}
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder, fileID, parseBag := parseSnippet(t, src)
+			if parseBag.Len() != 0 {
+				t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+			}
+
+			bag := diag.NewBag(8)
+			_ = ResolveFile(builder, fileID, &ResolveOptions{
+				Reporter: &diag.BagReporter{Bag: bag},
+				Validate: true,
+			})
+
+			if bag.Len() != 1 {
+				t.Fatalf("expected 1 diagnostic, got %d", bag.Len())
+			}
+			item := bag.Items()[0]
+			if item.Code != diag.SemaAmbiguousOverloadSet {
+				t.Fatalf("expected SemaAmbiguousOverloadSet, got %v", item.Code)
+			}
+			if len(item.Notes) != 1 {
+				t.Fatalf("expected a note pointing at the overlapping overload, got %d", len(item.Notes))
+			}
+		})
+	}
+}
+
 func TestResolveExternOverloadKeepsDistinctSymbolsOnReuse(t *testing.T) {
 	src := `
         type Point = { x: int, y: int }
@@ -378,7 +874,7 @@ func TestResolveOverrideMatchingSignature(t *testing.T) {
 		Validate: true,
 	})
 
-	expectNoDiagnostics(t, bag)
+	diagtest.AssertNoDiagnostics(t, bag)
 }
 
 func TestResolveTagAndFunctionSameNameAllowed(t *testing.T) {
@@ -424,9 +920,7 @@ func TestResolveAmbiguousConstructorCall(t *testing.T) {
 		Validate: true,
 	})
 
-	if !containsCode(bag, diag.SemaAmbiguousCtorOrFn) {
-		t.Fatalf("expected SemaAmbiguousCtorOrFn diagnostic, got %+v", bag.Items())
-	}
+	diagtest.AssertHasCode(t, bag, diag.SemaAmbiguousCtorOrFn)
 }
 
 func TestResolveImportDefaultAlias(t *testing.T) {
@@ -458,7 +952,7 @@ func TestResolveImportDefaultAlias(t *testing.T) {
 		},
 	})
 
-	expectNoDiagnostics(t, bag)
+	diagtest.AssertNoDiagnostics(t, bag)
 }
 
 func TestResolveImportExplicitAlias(t *testing.T) {
@@ -490,7 +984,7 @@ func TestResolveImportExplicitAlias(t *testing.T) {
 		},
 	})
 
-	expectNoDiagnostics(t, bag)
+	diagtest.AssertNoDiagnostics(t, bag)
 }
 
 func TestResolveImportSingleItem(t *testing.T) {
@@ -522,7 +1016,113 @@ func TestResolveImportSingleItem(t *testing.T) {
 		},
 	})
 
-	expectNoDiagnostics(t, bag)
+	diagtest.AssertNoDiagnostics(t, bag)
+}
+
+func TestResolveImportGlobDeclaresPublicExports(t *testing.T) {
+	src := `
+        import foo::*;
+
+        fn wrapper() {
+            run();
+            helper();
+        }
+    `
+	builder, fileID, parseBag := parseSnippet(t, src)
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	exports := NewModuleExports("foo")
+	exports.Add(&ExportedSymbol{Name: "run", Kind: SymbolFunction, Flags: SymbolFlagPublic})
+	exports.Add(&ExportedSymbol{Name: "helper", Kind: SymbolFunction, Flags: SymbolFlagPublic})
+
+	bag := diag.NewBag(8)
+	res := ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter: &diag.BagReporter{Bag: bag},
+		Validate: true,
+		ModuleExports: map[string]*ModuleExports{
+			"foo": exports,
+		},
+	})
+
+	diagtest.AssertNoDiagnostics(t, bag)
+	var imported []string
+	for _, itemID := range builder.Files.Get(fileID).Items {
+		if _, ok := builder.Items.Import(itemID); !ok {
+			continue
+		}
+		for _, symID := range res.ItemSymbols[itemID] {
+			sym := res.Table.Symbols.Get(symID)
+			if sym.Kind != SymbolImport || sym.ModulePath != "foo" {
+				t.Fatalf("unexpected glob symbol %+v", sym)
+			}
+			imported = append(imported, builder.StringsInterner.MustLookup(sym.Name))
+		}
+	}
+	if got := strings.Join(imported, ","); got != "helper,run" {
+		t.Fatalf("expected glob to import helper,run in order, got %q", got)
+	}
+}
+
+func TestResolveImportGlobCollisions(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "local declaration",
+			src: `
+        import foo::*;
+        fn run() {}
+    `,
+			want: "'run' conflicts with a name imported by 'foo::*'",
+		},
+		{
+			name: "declaration before glob",
+			src: `
+        fn run() {}
+        import foo::*;
+    `,
+			want: "'run' imported by 'foo::*' conflicts with an existing declaration",
+		},
+		{
+			name: "two globs",
+			src: `
+        import foo::*;
+        import bar::*;
+    `,
+			want: "'run' is ambiguous: imported by both 'foo::*' and 'bar::*'",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder, fileID, parseBag := parseSnippet(t, tc.src)
+			if parseBag.Len() != 0 {
+				t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+			}
+			foo := NewModuleExports("foo")
+			foo.Add(&ExportedSymbol{Name: "run", Kind: SymbolFunction, Flags: SymbolFlagPublic})
+			bar := NewModuleExports("bar")
+			bar.Add(&ExportedSymbol{Name: "run", Kind: SymbolFunction, Flags: SymbolFlagPublic})
+
+			bag := diag.NewBag(8)
+			_ = ResolveFile(builder, fileID, &ResolveOptions{
+				Reporter:      &diag.BagReporter{Bag: bag},
+				Validate:      true,
+				ModuleExports: map[string]*ModuleExports{"foo": foo, "bar": bar},
+			})
+
+			if bag.Len() != 1 {
+				t.Fatalf("expected 1 diagnostic, got %s", diagtest.Summary(bag))
+			}
+			d := bag.Items()[0]
+			if d.Code != diag.SemaDuplicateSymbol || d.Message != tc.want {
+				t.Fatalf("expected SemaDuplicateSymbol %q, got %s", tc.want, diagtest.Summary(bag))
+			}
+		})
+	}
 }
 
 func TestModuleImportsVisibleAcrossFiles(t *testing.T) {
@@ -534,7 +1134,7 @@ pragma module;
 import foo::Bar;
 `)
 	if bagA.Len() != 0 {
-		t.Fatalf("unexpected parse diagnostics: %s", diagSummary(bagA))
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bagA))
 	}
 	fileB, bagB := parseVirtualFile(t, fs, builder, "b.sg", `
 pragma module;
@@ -543,7 +1143,7 @@ fn main() {
 }
 `)
 	if bagB.Len() != 0 {
-		t.Fatalf("unexpected parse diagnostics: %s", diagSummary(bagB))
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bagB))
 	}
 
 	exports := NewModuleExports("foo")
@@ -575,7 +1175,7 @@ fn main() {
 	})
 
 	if bag.HasErrors() {
-		t.Fatalf("unexpected resolve diagnostics: %s", diagSummary(bag))
+		t.Fatalf("unexpected resolve diagnostics: %s", diagtest.Summary(bag))
 	}
 
 	bag2 := diag.NewBag(16)
@@ -587,7 +1187,7 @@ fn main() {
 		ReuseDecls:    true,
 	})
 	if bag2.HasErrors() {
-		t.Fatalf("unexpected resolve diagnostics: %s", diagSummary(bag2))
+		t.Fatalf("unexpected resolve diagnostics: %s", diagtest.Summary(bag2))
 	}
 	if resB.FileScope == NoScopeID {
 		t.Fatalf("missing file scope")
@@ -603,14 +1203,14 @@ pragma module;
 import foo/bar;
 `)
 	if bagA.Len() != 0 {
-		t.Fatalf("unexpected parse diagnostics: %s", diagSummary(bagA))
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bagA))
 	}
 	fileB, bagB := parseVirtualFile(t, fs, builder, "b.sg", `
 pragma module;
 fn main() { bar.baz(); }
 `)
 	if bagB.Len() != 0 {
-		t.Fatalf("unexpected parse diagnostics: %s", diagSummary(bagB))
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bagB))
 	}
 
 	exports := NewModuleExports("foo/bar")
@@ -642,7 +1242,7 @@ fn main() { bar.baz(); }
 	})
 
 	if bag.HasErrors() {
-		t.Fatalf("unexpected resolve diagnostics: %s", diagSummary(bag))
+		t.Fatalf("unexpected resolve diagnostics: %s", diagtest.Summary(bag))
 	}
 
 	bag2 := diag.NewBag(16)
@@ -654,7 +1254,7 @@ fn main() { bar.baz(); }
 		ReuseDecls:    true,
 	})
 	if bag2.HasErrors() {
-		t.Fatalf("unexpected resolve diagnostics: %s", diagSummary(bag2))
+		t.Fatalf("unexpected resolve diagnostics: %s", diagtest.Summary(bag2))
 	}
 }
 
@@ -667,7 +1267,7 @@ pragma module;
 import foo::Bar;
 `)
 	if bagA.Len() != 0 {
-		t.Fatalf("unexpected parse diagnostics: %s", diagSummary(bagA))
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bagA))
 	}
 	fileB, bagB := parseVirtualFile(t, fs, builder, "b.sg", `
 pragma module;
@@ -675,7 +1275,7 @@ import foo::Bar;
 fn main() { let tmp = Bar; }
 `)
 	if bagB.Len() != 0 {
-		t.Fatalf("unexpected parse diagnostics: %s", diagSummary(bagB))
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bagB))
 	}
 
 	exports := NewModuleExports("foo")
@@ -707,7 +1307,7 @@ fn main() { let tmp = Bar; }
 	})
 
 	if bag.HasErrors() {
-		t.Fatalf("unexpected resolve diagnostics: %s", diagSummary(bag))
+		t.Fatalf("unexpected resolve diagnostics: %s", diagtest.Summary(bag))
 	}
 	if !bag.HasWarnings() {
 		t.Fatalf("expected import duplicate warning, got none")
@@ -720,7 +1320,7 @@ fn main() { let tmp = Bar; }
 		}
 	}
 	if !found {
-		t.Fatalf("expected duplicate import warning, got %s", diagSummary(bag))
+		t.Fatalf("expected duplicate import warning, got %s", diagtest.Summary(bag))
 	}
 }
 
@@ -748,10 +1348,64 @@ func TestResolveDuplicateModuleImport(t *testing.T) {
 	}
 }
 
+func TestResolveRelativeImportPresentModule(t *testing.T) {
+	baseDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(baseDir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(baseDir, "sub", "present.sg"), []byte("pub fn f() {}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	builder, fileID, parseBag := parseSnippet(t, "import ./sub/present;\n")
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter:             &diag.BagReporter{Bag: bag},
+		Validate:             true,
+		ModulePath:           "main",
+		FilePath:             filepath.Join(baseDir, "main.sg"),
+		BaseDir:              baseDir,
+		CheckRelativeImports: true,
+	})
+
+	diagtest.AssertNoDiagnostics(t, bag)
+}
+
+func TestResolveRelativeImportMissingModule(t *testing.T) {
+	baseDir := t.TempDir()
+	builder, fileID, parseBag := parseSnippet(t, "import ./sub/absent;\n")
+	if parseBag.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %d", parseBag.Len())
+	}
+
+	bag := diag.NewBag(8)
+	_ = ResolveFile(builder, fileID, &ResolveOptions{
+		Reporter:             &diag.BagReporter{Bag: bag},
+		Validate:             true,
+		ModulePath:           "main",
+		FilePath:             filepath.Join(baseDir, "main.sg"),
+		BaseDir:              baseDir,
+		CheckRelativeImports: true,
+	})
+
+	if bag.Len() != 1 || bag.Items()[0].Code != diag.SemaModuleNotFound {
+		t.Fatalf("expected SemaModuleNotFound, got %s", diagtest.Summary(bag))
+	}
+	attempted := filepath.Join(baseDir, "sub", "absent.sg")
+	if msg := bag.Items()[0].Message; !strings.Contains(msg, attempted) {
+		t.Fatalf("expected attempted path %q in message, got %q", attempted, msg)
+	}
+}
+
 func TestResolveModuleAndItemImportDoesNotConflict(t *testing.T) {
 	src := `
         import foo;
         import foo::bar;
+        fn f(v: bar) {}
     `
 	builder, fileID, parseBag := parseSnippet(t, src)
 	if parseBag.Len() != 0 {
@@ -892,9 +1546,7 @@ func TestResolveModuleMemberMissing(t *testing.T) {
 		},
 	})
 
-	if !containsCode(bag, diag.SemaModuleMemberNotFound) {
-		t.Fatalf("expected SemaModuleMemberNotFound, got %+v", bag.Items())
-	}
+	diagtest.AssertHasCode(t, bag, diag.SemaModuleMemberNotFound)
 }
 
 func TestResolveModuleMemberNotPublic(t *testing.T) {
@@ -925,9 +1577,7 @@ func TestResolveModuleMemberNotPublic(t *testing.T) {
 		},
 	})
 
-	if !containsCode(bag, diag.SemaModuleMemberNotPublic) {
-		t.Fatalf("expected SemaModuleMemberNotPublic, got %+v", bag.Items())
-	}
+	diagtest.AssertHasCode(t, bag, diag.SemaModuleMemberNotPublic)
 }
 
 func TestResolveFunctionNameStyleWarning(t *testing.T) {
@@ -1032,7 +1682,7 @@ func TestResolveIntrinsicAnyModule(t *testing.T) {
 func TestResolveIntrinsicHasBody(t *testing.T) {
 	src := `
 	    @intrinsic fn rt_alloc(size: uint) -> *byte {
-	        let x = size;
+	        let _x = size;
 	    }
 	`
 	builder, fileID, parseBag := parseSnippet(t, src)
@@ -1102,9 +1752,7 @@ func TestResolveExternIntrinsicDuplicate(t *testing.T) {
 		ModuleExports: coreIntrinsicsExports(builder),
 	})
 
-	if !containsCode(bag, diag.SemaFnOverride) {
-		t.Fatalf("expected SemaFnOverride, got %+v", bag.Items())
-	}
+	diagtest.AssertHasCode(t, bag, diag.SemaFnOverride)
 }
 
 func TestResolveExternCoreMagicRequiresOverload(t *testing.T) {
@@ -1125,9 +1773,7 @@ func TestResolveExternCoreMagicRequiresOverload(t *testing.T) {
 		ModuleExports: coreIntrinsicsExports(builder),
 	})
 
-	if !containsCode(bag, diag.SemaFnOverride) {
-		t.Fatalf("expected SemaFnOverride, got %+v", bag.Items())
-	}
+	diagtest.AssertHasCode(t, bag, diag.SemaFnOverride)
 }
 
 func TestResolveExternOverrideIntrinsicForbidden(t *testing.T) {
@@ -1148,9 +1794,7 @@ func TestResolveExternOverrideIntrinsicForbidden(t *testing.T) {
 		ModuleExports: coreIntrinsicsExports(builder),
 	})
 
-	if !containsCode(bag, diag.SemaFnOverride) {
-		t.Fatalf("expected SemaFnOverride, got %+v", bag.Items())
-	}
+	diagtest.AssertHasCode(t, bag, diag.SemaFnOverride)
 }
 
 func TestResolveExternOverridePublicMustStayPublic(t *testing.T) {
@@ -1172,9 +1816,7 @@ func TestResolveExternOverridePublicMustStayPublic(t *testing.T) {
 		Validate: true,
 	})
 
-	if !containsCode(bag, diag.SemaFnOverride) {
-		t.Fatalf("expected SemaFnOverride, got %+v", bag.Items())
-	}
+	diagtest.AssertHasCode(t, bag, diag.SemaFnOverride)
 }
 
 func TestResolveExternOverridePrivateAllowed(t *testing.T) {
@@ -1205,6 +1847,7 @@ func TestResolveLocalShadowingWarning(t *testing.T) {
 	src := `
             fn f(a: int) {
                 let a = 1;
+                return a;
             }
 	`
 	builder, fileID, parseBag := parseSnippet(t, src)
@@ -1235,6 +1878,7 @@ func TestResolveLocalDuplicateLet(t *testing.T) {
 	    fn f() {
 	        let value = 0;
 	        let value = 1;
+	        return value;
 	    }
 	`
 	builder, fileID, parseBag := parseSnippet(t, src)
@@ -1384,15 +2028,6 @@ func parseVirtualFile(t *testing.T, fs *source.FileSet, builder *ast.Builder, na
 	return result.File, bag
 }
 
-func containsCode(bag *diag.Bag, code diag.Code) bool {
-	for _, item := range bag.Items() {
-		if item.Code == code {
-			return true
-		}
-	}
-	return false
-}
-
 func coreIntrinsicsExports(builder *ast.Builder) map[string]*ModuleExports {
 	exports := NewModuleExports("core")
 	symbols := []ExportedSymbol{
@@ -1427,24 +2062,59 @@ func coreIntrinsicsExports(builder *ast.Builder) map[string]*ModuleExports {
 	return map[string]*ModuleExports{"core": exports}
 }
 
-func expectNoDiagnostics(t *testing.T, bag *diag.Bag) {
-	t.Helper()
-	if bag == nil {
-		return
+func TestResolveDuplicateAcrossFilesNotesPreviousFile(t *testing.T) {
+	fs := source.NewFileSetWithBase("")
+	builder := ast.NewBuilder(ast.Hints{}, nil)
+
+	fileA, bagA := parseVirtualFile(t, fs, builder, "a.sg", `
+pragma module;
+let limit: int = 1;
+let seed: int = 7;
+`)
+	if bagA.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bagA))
 	}
-	if bag.Len() != 0 {
-		t.Fatalf("expected no diagnostics, got %d: %s", bag.Len(), diagSummary(bag))
+	fileB, bagB := parseVirtualFile(t, fs, builder, "b.sg", `
+pragma module;
+let limit: int = 2;
+fn main() { let seed: int = 3; }
+`)
+	if bagB.Len() != 0 {
+		t.Fatalf("unexpected parse diagnostics: %s", diagtest.Summary(bagB))
 	}
-}
 
-func diagSummary(bag *diag.Bag) string {
-	if bag == nil {
-		return ""
+	table := NewTable(Hints{}, builder.StringsInterner)
+	moduleScope := table.ModuleRoot("mod", source.Span{})
+	bag := diag.NewBag(16)
+	reporter := &diag.BagReporter{Bag: bag}
+	for _, file := range []ast.FileID{fileA, fileB} {
+		ResolveFile(builder, file, &ResolveOptions{
+			Table:       table,
+			Reporter:    reporter,
+			ModuleScope: moduleScope,
+		})
 	}
-	items := bag.Items()
-	parts := make([]string, len(items))
-	for i, d := range items {
-		parts[i] = fmt.Sprintf("%s %s", d.Code, d.Message)
+
+	srcA, _ := fs.GetLatest("a.sg")
+	srcB, _ := fs.GetLatest("b.sg")
+	var dup, shadow *diag.Diagnostic
+	for _, item := range bag.Items() {
+		switch item.Code {
+		case diag.SemaDuplicateSymbol:
+			dup = item
+		case diag.SemaShadowSymbol:
+			shadow = item
+		}
+	}
+	if dup == nil || shadow == nil {
+		t.Fatalf("expected duplicate and shadow diagnostics, got %s", diagtest.Summary(bag))
+	}
+	for _, d := range []*diag.Diagnostic{dup, shadow} {
+		if d.Primary.File != srcB {
+			t.Fatalf("%s: expected primary span in b.sg, got file %d", d.Code.ID(), d.Primary.File)
+		}
+		if len(d.Notes) != 1 || d.Notes[0].Span.File != srcA {
+			t.Fatalf("%s: expected a note in a.sg (file %d), got %+v", d.Code.ID(), srcA, d.Notes)
+		}
 	}
-	return strings.Join(parts, "; ")
 }
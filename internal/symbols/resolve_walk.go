@@ -1,7 +1,10 @@
 package symbols
 
 import (
+	"fmt"
+
 	"surge/internal/ast"
+	"surge/internal/diag"
 	"surge/internal/source"
 )
 
@@ -94,7 +97,10 @@ func (fr *fileResolver) walkFn(owner ScopeOwner, fnItem *ast.FnItem) {
 	}
 	fr.walkTypeExpr(fnItem.ReturnType)
 	if fnItem.Body.IsValid() {
+		outerLabels := fr.loopLabels
+		fr.loopLabels = nil
 		fr.walkStmt(fnItem.Body)
+		fr.loopLabels = outerLabels
 	}
 	fr.resolver.Leave(scopeID)
 }
@@ -153,7 +159,9 @@ func (fr *fileResolver) walkStmt(stmtID ast.StmtID) {
 			ASTFile:    fr.fileID,
 			Stmt:       stmtID,
 		}
-		fr.resolver.Declare(letStmt.Name, stmt.Span, SymbolLet, flags, decl)
+		if symID, ok := fr.resolver.Declare(letStmt.Name, stmt.Span, SymbolLet, flags, decl); ok {
+			fr.trackLocalLet(symID, letStmt.Name, ast.NoExprID, stmt.Span)
+		}
 	case ast.StmtConst:
 		constStmt := fr.builder.Stmts.Const(stmtID)
 		if constStmt == nil || constStmt.Name == source.NoStringID {
@@ -169,6 +177,8 @@ func (fr *fileResolver) walkStmt(stmtID ast.StmtID) {
 			return
 		}
 		fr.walkExpr(ifStmt.Cond)
+		fr.checkAssignCondition(ifStmt.Cond)
+		fr.checkConstCondition(stmtID, ifStmt.Cond, false)
 		fr.walkStmt(ifStmt.Then)
 		if ifStmt.Else.IsValid() {
 			fr.walkStmt(ifStmt.Else)
@@ -179,7 +189,11 @@ func (fr *fileResolver) walkStmt(stmtID ast.StmtID) {
 			return
 		}
 		fr.walkExpr(whileStmt.Cond)
+		fr.checkAssignCondition(whileStmt.Cond)
+		fr.checkConstCondition(stmtID, whileStmt.Cond, true)
+		pushed := fr.pushLoopLabel(whileStmt.Label, whileStmt.LabelSpan)
 		fr.walkStmt(whileStmt.Body)
+		fr.popLoopLabel(pushed)
 	case ast.StmtForClassic:
 		forStmt := fr.builder.Stmts.ForClassic(stmtID)
 		if forStmt == nil {
@@ -197,8 +211,11 @@ func (fr *fileResolver) walkStmt(stmtID ast.StmtID) {
 			fr.walkStmt(forStmt.Init)
 		}
 		fr.walkExpr(forStmt.Cond)
+		fr.checkConstCondition(stmtID, forStmt.Cond, true)
 		fr.walkExpr(forStmt.Post)
+		pushed := fr.pushLoopLabel(forStmt.Label, forStmt.LabelSpan)
 		fr.walkStmt(forStmt.Body)
+		fr.popLoopLabel(pushed)
 		fr.resolver.Leave(scopeID)
 	case ast.StmtForIn:
 		forIn := fr.builder.Stmts.ForIn(stmtID)
@@ -213,6 +230,16 @@ func (fr *fileResolver) walkStmt(stmtID ast.StmtID) {
 		}
 		scopeID := fr.resolver.Enter(ScopeBlock, owner, stmt.Span)
 		fr.walkTypeExpr(forIn.Type)
+		if forIn.Index != source.NoStringID && !fr.isWildcard(forIn.Index) {
+			// Индекс объявляется первым, чтобы последним символом stmt остался элемент.
+			decl := SymbolDecl{
+				SourceFile: fr.sourceFile,
+				ASTFile:    fr.fileID,
+				Stmt:       stmtID,
+			}
+			span := preferSpan(forIn.IndexSpan, stmt.Span)
+			fr.resolver.Declare(forIn.Index, span, SymbolLet, 0, decl)
+		}
 		if forIn.Pattern != source.NoStringID && !fr.isWildcard(forIn.Pattern) {
 			decl := SymbolDecl{
 				SourceFile: fr.sourceFile,
@@ -223,7 +250,9 @@ func (fr *fileResolver) walkStmt(stmtID ast.StmtID) {
 			fr.resolver.Declare(forIn.Pattern, span, SymbolLet, 0, decl)
 		}
 		fr.walkExpr(forIn.Iterable)
+		pushed := fr.pushLoopLabel(forIn.Label, forIn.LabelSpan)
 		fr.walkStmt(forIn.Body)
+		fr.popLoopLabel(pushed)
 		fr.resolver.Leave(scopeID)
 	case ast.StmtExpr:
 		exprStmt := fr.builder.Stmts.Expr(stmtID)
@@ -232,9 +261,21 @@ func (fr *fileResolver) walkStmt(stmtID ast.StmtID) {
 		}
 	case ast.StmtSignal:
 		signalStmt := fr.builder.Stmts.Signal(stmtID)
-		if signalStmt != nil {
-			fr.walkExpr(signalStmt.Value)
+		if signalStmt == nil {
+			return
+		}
+		// The reactive expression is resolved before the target is bound,
+		// so `signal x := x + 1` refers to the outer x.
+		fr.walkExpr(signalStmt.Value)
+		if signalStmt.Name == source.NoStringID || fr.isWildcard(signalStmt.Name) {
+			return
+		}
+		decl := SymbolDecl{
+			SourceFile: fr.sourceFile,
+			ASTFile:    fr.fileID,
+			Stmt:       stmtID,
 		}
+		fr.resolver.Declare(signalStmt.Name, stmt.Span, SymbolLet, 0, decl)
 	case ast.StmtDrop:
 		if dropStmt := fr.builder.Stmts.Drop(stmtID); dropStmt != nil {
 			fr.walkExpr(dropStmt.Expr)
@@ -250,10 +291,54 @@ func (fr *fileResolver) walkStmt(stmtID ast.StmtID) {
 			fr.walkExpr(retStmt.Expr)
 		}
 	case ast.StmtBreak, ast.StmtContinue:
+		if jump := fr.builder.Stmts.Jump(stmtID); jump != nil {
+			fr.checkLoopLabel(jump)
+		}
 	default:
 	}
 }
 
+// pushLoopLabel makes a loop label visible to break/continue in the loop body.
+// A label that repeats an enclosing one is reported and not pushed.
+func (fr *fileResolver) pushLoopLabel(name source.StringID, span source.Span) bool {
+	if name == source.NoStringID {
+		return false
+	}
+	for i := len(fr.loopLabels) - 1; i >= 0; i-- {
+		outer := fr.loopLabels[i]
+		if outer.name != name {
+			continue
+		}
+		msg := fmt.Sprintf("loop label '%s' is already used by an enclosing loop", fr.builder.StringsInterner.MustLookup(name))
+		if b := diag.ReportError(fr.resolver.reporter, diag.SemaDuplicateLoopLabel, span, msg); b != nil {
+			b.WithNote(outer.span, "enclosing loop label")
+			b.Emit()
+		}
+		return false
+	}
+	fr.loopLabels = append(fr.loopLabels, loopLabel{name: name, span: span})
+	return true
+}
+
+func (fr *fileResolver) popLoopLabel(pushed bool) {
+	if pushed {
+		fr.loopLabels = fr.loopLabels[:len(fr.loopLabels)-1]
+	}
+}
+
+// checkLoopLabel reports a labeled break/continue whose label names no enclosing loop.
+func (fr *fileResolver) checkLoopLabel(jump *ast.LoopJumpStmt) {
+	for i := len(fr.loopLabels) - 1; i >= 0; i-- {
+		if fr.loopLabels[i].name == jump.Label {
+			return
+		}
+	}
+	msg := fmt.Sprintf("no enclosing loop is labeled '%s'", fr.builder.StringsInterner.MustLookup(jump.Label))
+	if b := diag.ReportError(fr.resolver.reporter, diag.SemaUnknownLoopLabel, jump.LabelSpan, msg); b != nil {
+		b.Emit()
+	}
+}
+
 func (fr *fileResolver) walkExpr(exprID ast.ExprID) {
 	if !exprID.IsValid() {
 		return
@@ -293,7 +378,10 @@ func (fr *fileResolver) walkExpr(exprID ast.ExprID) {
 		if data == nil {
 			return
 		}
+		outerCallTarget := fr.callTarget
+		fr.callTarget = data.Target
 		fr.walkExpr(data.Target)
+		fr.callTarget = outerCallTarget
 		for _, arg := range data.Args {
 			fr.walkExpr(arg.Value)
 		}
@@ -384,13 +472,20 @@ func (fr *fileResolver) walkExpr(exprID ast.ExprID) {
 		if data == nil || !data.Body.IsValid() {
 			return
 		}
+		// Тело async — отдельная функция: метки внешних циклов в нём не видны.
+		outerLabels := fr.loopLabels
+		fr.loopLabels = nil
 		fr.walkStmt(data.Body)
+		fr.loopLabels = outerLabels
 	case ast.ExprBlocking:
 		data, _ := fr.builder.Exprs.Blocking(exprID)
 		if data == nil || !data.Body.IsValid() {
 			return
 		}
+		outerLabels := fr.loopLabels
+		fr.loopLabels = nil
 		fr.walkStmt(data.Body)
+		fr.loopLabels = outerLabels
 	case ast.ExprBlock:
 		data, _ := fr.builder.Exprs.Block(exprID)
 		if data == nil {
@@ -415,10 +510,21 @@ func (fr *fileResolver) walkExpr(exprID ast.ExprID) {
 		}
 		fr.walkExpr(data.Iterable)
 		fr.walkExpr(data.Init)
+		// Arguments are bindings local to the body, like compare arm patterns.
+		scope := fr.resolver.Enter(ScopeBlock, ScopeOwner{
+			Kind:       ScopeOwnerExpr,
+			SourceFile: fr.sourceFile,
+			ASTFile:    fr.fileID,
+			Expr:       exprID,
+		}, expr.Span)
 		for _, arg := range data.Args {
-			fr.walkExpr(arg)
+			fr.bindComparePattern(arg)
 		}
+		outerLabels := fr.loopLabels
+		fr.loopLabels = nil
 		fr.walkExpr(data.Body)
+		fr.loopLabels = outerLabels
+		fr.resolver.Leave(scope)
 	case ast.ExprCompare:
 		data, _ := fr.builder.Exprs.Compare(exprID)
 		if data == nil {
@@ -432,7 +538,11 @@ func (fr *fileResolver) walkExpr(exprID ast.ExprID) {
 				ASTFile:    fr.fileID,
 				Expr:       exprID,
 			}, arm.PatternSpan)
-			fr.bindComparePattern(arm.Pattern)
+			if arm.PatternKind == ast.ComparePatternOr {
+				fr.walkOrPattern(arm.Pattern)
+			} else {
+				fr.bindComparePattern(arm.Pattern)
+			}
 			fr.walkExpr(arm.Guard)
 			fr.walkExpr(arm.Result)
 			fr.resolver.Leave(scope)
@@ -467,6 +577,9 @@ func (fr *fileResolver) walkExpr(exprID ast.ExprID) {
 		if data.Type.IsValid() {
 			fr.walkTypeExpr(data.Type)
 		}
+		if data.Base.IsValid() {
+			fr.walkExpr(data.Base)
+		}
 		for _, f := range data.Fields {
 			fr.walkExpr(f.Value)
 		}
@@ -5,6 +5,7 @@ import (
 
 	"surge/internal/ast"
 	"surge/internal/diag"
+	"surge/internal/fix"
 	"surge/internal/source"
 )
 
@@ -25,6 +26,8 @@ func (fr *fileResolver) bindComparePattern(exprID ast.ExprID) {
 		if fr.builder.StringsInterner.MustLookup(ident.Name) == "_" {
 			return
 		}
+		// Голое имя в паттерне может оказаться импортированным тегом.
+		fr.noteNameUse(ident.Name)
 		decl := SymbolDecl{
 			SourceFile: fr.sourceFile,
 			ASTFile:    fr.fileID,
@@ -54,6 +57,60 @@ func (fr *fileResolver) bindComparePattern(exprID ast.ExprID) {
 	}
 }
 
+// walkOrPattern резолвит альтернативы or-паттерна `p1 | p2 | ...`.
+// Альтернативы ничего не связывают: допустимы литералы, диапазоны, `_`,
+// `nothing` и теги, у которых вместо полезной нагрузки стоит `_`. Иначе
+// разные альтернативы связывали бы разные имена, и тело арма не знало бы, какие из них живы.
+func (fr *fileResolver) walkOrPattern(pattern ast.ExprID) {
+	for _, alt := range fr.builder.Exprs.OrPatternAlternatives(pattern) {
+		node := fr.builder.Exprs.Get(alt)
+		if node == nil {
+			continue
+		}
+		switch node.Kind {
+		case ast.ExprIdent:
+			ident, _ := fr.builder.Exprs.Ident(alt)
+			if ident == nil {
+				continue
+			}
+			if name := fr.lookupString(ident.Name); name == "_" || name == "nothing" {
+				continue
+			}
+			fr.walkExpr(alt)
+			symID, ok := fr.result.ExprSymbols[alt]
+			if !ok {
+				continue // unresolved name is reported by walkExpr
+			}
+			if sym := fr.result.Table.Symbols.Get(symID); sym != nil && sym.Kind == SymbolTag {
+				continue
+			}
+			fr.reportInvalidOrPattern(node.Span, fmt.Sprintf("or-pattern alternative '%s' is not a tag; alternatives cannot bind variables", fr.lookupString(ident.Name)))
+		case ast.ExprCall:
+			call, _ := fr.builder.Exprs.Call(alt)
+			if call == nil {
+				continue
+			}
+			fr.walkExpr(call.Target)
+			for _, arg := range call.Args {
+				if ident, ok := fr.builder.Exprs.Ident(arg.Value); ok && ident != nil && fr.isWildcard(ident.Name) {
+					continue
+				}
+				fr.reportInvalidOrPattern(fr.builder.Exprs.Get(arg.Value).Span, "or-pattern alternatives cannot destructure tag payloads; use '_'")
+			}
+		case ast.ExprTuple:
+			fr.reportInvalidOrPattern(node.Span, "or-pattern alternatives cannot destructure tuples")
+		default:
+			fr.walkExpr(alt)
+		}
+	}
+}
+
+func (fr *fileResolver) reportInvalidOrPattern(span source.Span, msg string) {
+	if b := diag.ReportError(fr.resolver.reporter, diag.SemaInvalidOrPattern, span, msg); b != nil {
+		b.Emit()
+	}
+}
+
 func (fr *fileResolver) bindLetPattern(pattern ast.ExprID, isMut bool, stmtSpan source.Span, stmtID ast.StmtID) {
 	if !pattern.IsValid() || fr.builder == nil {
 		return
@@ -92,6 +149,7 @@ func (fr *fileResolver) bindLetPattern(pattern ast.ExprID, isMut bool, stmtSpan
 		span := node.Span
 		if symID, ok := fr.resolver.Declare(ident.Name, span, SymbolLet, flags, decl); ok {
 			fr.result.ExprSymbols[pattern] = symID
+			fr.trackLocalLet(symID, ident.Name, pattern, span)
 		}
 	default:
 		// Fallback: walk the expression to resolve any identifiers inside.
@@ -107,6 +165,7 @@ func (fr *fileResolver) resolveIdent(exprID ast.ExprID, span source.Span, name s
 		fr.reportWildcardValue(span)
 		return
 	}
+	fr.noteNameUse(name)
 	if symID, ok := fr.resolver.Lookup(name); ok {
 		if fr.tryResolveImportSymbol(exprID, span, symID) {
 			return
@@ -118,10 +177,10 @@ func (fr *fileResolver) resolveIdent(exprID ast.ExprID, span source.Span, name s
 		// Type parameters are handled by the type checker; treat as resolved.
 		return
 	}
-	fr.reportUnresolved(name, span)
+	fr.reportUnresolved(exprID, name, span)
 }
 
-func (fr *fileResolver) reportUnresolved(name source.StringID, span source.Span) {
+func (fr *fileResolver) reportUnresolved(exprID ast.ExprID, name source.StringID, span source.Span) {
 	if fr.resolver == nil || fr.resolver.reporter == nil {
 		return
 	}
@@ -130,9 +189,27 @@ func (fr *fileResolver) reportUnresolved(name source.StringID, span source.Span)
 		return
 	}
 	msg := fmt.Sprintf("cannot resolve '%s'", nameStr)
-	if b := diag.ReportError(fr.resolver.reporter, diag.SemaUnresolvedSymbol, span, msg); b != nil {
-		b.Emit()
+	b := diag.ReportError(fr.resolver.reporter, diag.SemaUnresolvedSymbol, span, msg)
+	if b == nil {
+		return
 	}
+	mask := valueKindMask
+	if exprID.IsValid() && exprID == fr.callTarget {
+		mask = callableKindMask
+	}
+	if suggestion, ok := fr.nearestName(nameStr, mask); ok {
+		b.WithNote(span, fmt.Sprintf("did you mean `%s`?", suggestion))
+		b.WithFixSuggestion(fix.ReplaceSpan(
+			fmt.Sprintf("rename to '%s'", suggestion),
+			span,
+			suggestion,
+			nameStr,
+			fix.WithID(fix.MakeFixID(diag.SemaUnresolvedSymbol, span)),
+			fix.WithKind(diag.FixKindQuickFix),
+			fix.WithApplicability(diag.FixApplicabilitySafeWithHeuristics),
+		))
+	}
+	b.Emit()
 }
 
 func (fr *fileResolver) reportWildcardValue(span source.Span) {
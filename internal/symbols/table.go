@@ -5,6 +5,7 @@ import (
 
 	"fortio.org/safecast"
 
+	"surge/internal/ast"
 	"surge/internal/source"
 )
 
@@ -18,6 +19,8 @@ type Table struct {
 	Strings  *source.Interner
 	fileRoot map[source.FileID]ScopeID
 	modRoot  map[string]ScopeID
+	// items excluded by a failing @cfg predicate; the AST itself is left intact
+	cfgOff map[ast.ItemID]struct{}
 }
 
 // NewTable builds a fresh table with optional capacity hints.
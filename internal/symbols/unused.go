@@ -0,0 +1,231 @@
+package symbols
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fortio.org/safecast"
+
+	"surge/internal/ast"
+	"surge/internal/diag"
+	"surge/internal/fix"
+	"surge/internal/source"
+)
+
+// localLet — локальная let-привязка, которую нужно проверить на использование.
+// declExpr задан для привязок из паттерна `let (a, b) = ...`: этот ident сам
+// лежит в ExprSymbols, и его нельзя считать чтением.
+type localLet struct {
+	symbol   SymbolID
+	name     source.StringID
+	declExpr ast.ExprID
+	span     source.Span
+}
+
+func (fr *fileResolver) trackLocalLet(symID SymbolID, name source.StringID, declExpr ast.ExprID, span source.Span) {
+	if strings.HasPrefix(fr.lookupString(name), "_") {
+		return
+	}
+	fr.localLets = append(fr.localLets, localLet{symbol: symID, name: name, declExpr: declExpr, span: span})
+}
+
+// noteNameUse запоминает имя, встреченное в выражении. Импорт резолвится в
+// синтетический символ экспорта, поэтому его использование ищется по имени.
+func (fr *fileResolver) noteNameUse(name source.StringID) {
+	if name == source.NoStringID {
+		return
+	}
+	if fr.usedNames == nil {
+		fr.usedNames = make(map[source.StringID]struct{})
+	}
+	fr.usedNames[name] = struct{}{}
+}
+
+// reportUnusedBindings warns about local lets that are never read and named
+// imports that are never referenced. Parameters are not checked. Bare module
+// imports (`import ./util;`) are not checked either: they also bring the
+// module's extern methods into scope, which is invisible to the resolver.
+// Names starting with `_` are exempt.
+func (fr *fileResolver) reportUnusedBindings(items []ast.ItemID) {
+	if fr.declareOnly || fr.resolver == nil || fr.resolver.reporter == nil {
+		return
+	}
+	fr.reportUnusedLocals()
+	fr.reportUnusedImports(items)
+}
+
+func (fr *fileResolver) reportUnusedLocals() {
+	if len(fr.localLets) == 0 {
+		return
+	}
+	declExprs := make(map[ast.ExprID]struct{}, len(fr.localLets))
+	for _, l := range fr.localLets {
+		if l.declExpr.IsValid() {
+			declExprs[l.declExpr] = struct{}{}
+		}
+	}
+	read := make(map[SymbolID]struct{}, len(fr.result.ExprSymbols))
+	for exprID, symID := range fr.result.ExprSymbols {
+		if _, isDecl := declExprs[exprID]; !isDecl {
+			read[symID] = struct{}{}
+		}
+	}
+	for _, l := range fr.localLets {
+		if _, ok := read[l.symbol]; ok {
+			continue
+		}
+		name := fr.lookupString(l.name)
+		msg := fmt.Sprintf("unused variable '%s'", name)
+		b := diag.ReportWarning(fr.resolver.reporter, diag.SemaUnusedLocal, l.span, msg)
+		if b == nil {
+			continue
+		}
+		title := fmt.Sprintf("rename to '_%s'", name)
+		fixID := fix.MakeFixID(diag.SemaUnusedLocal, l.span)
+		if l.declExpr.IsValid() {
+			b.WithFixSuggestion(fix.InsertText(title, l.span.ZeroideToStart(), "_", "",
+				fix.WithID(fixID),
+				fix.Preferred(),
+			))
+		} else {
+			// Span простого let покрывает весь оператор; позиция имени
+			// находится по исходнику, когда фикс материализуется.
+			b.WithFixSuggestion(&diag.Fix{
+				ID:            fixID,
+				Title:         title,
+				Kind:          diag.FixKindQuickFix,
+				Applicability: diag.FixApplicabilityAlwaysSafe,
+				IsPreferred:   true,
+				Thunk:         letNamePrefixFix{id: fixID, title: title, stmt: l.span, name: name},
+			})
+		}
+		b.Emit()
+	}
+}
+
+func (fr *fileResolver) reportUnusedImports(items []ast.ItemID) {
+	scanned := false
+	for _, itemID := range items {
+		item := fr.builder.Items.Get(itemID)
+		if item == nil || item.Kind != ast.ItemImport {
+			continue
+		}
+		importItem, ok := fr.builder.Items.Import(itemID)
+		if !ok || importItem == nil || importItem.ImportAll || (!importItem.HasOne && len(importItem.Group) == 0) {
+			continue
+		}
+		if !scanned {
+			fr.noteTypeNameUses()
+			scanned = true
+		}
+		total := 0
+		var unused []string
+		for _, symID := range fr.result.ItemSymbols[itemID] {
+			sym := fr.result.Table.Symbols.Get(symID)
+			if sym == nil || sym.Kind != SymbolImport || sym.Decl.Item != itemID {
+				continue
+			}
+			total++
+			name := fr.lookupString(sym.Name)
+			if strings.HasPrefix(name, "_") {
+				continue
+			}
+			if _, used := fr.usedNames[sym.Name]; !used {
+				unused = append(unused, name)
+			}
+		}
+		if len(unused) == 0 {
+			continue
+		}
+		if len(unused) < total {
+			// Часть группы используется: удалять нечего, только предупреждаем.
+			for _, name := range unused {
+				msg := fmt.Sprintf("unused import '%s'", name)
+				if b := diag.ReportWarning(fr.resolver.reporter, diag.SemaUnusedImport, item.Span, msg); b != nil {
+					b.Emit()
+				}
+			}
+			continue
+		}
+		msg := fmt.Sprintf("unused import '%s'", unused[0])
+		if len(unused) > 1 {
+			msg = fmt.Sprintf("unused imports '%s'", strings.Join(unused, "', '"))
+		}
+		b := diag.ReportWarning(fr.resolver.reporter, diag.SemaUnusedImport, item.Span, msg)
+		if b == nil {
+			continue
+		}
+		b.WithFixSuggestion(fix.DeleteSpan("remove unused import", item.Span, "",
+			fix.WithID(fix.MakeFixID(diag.SemaUnusedImport, item.Span)),
+			fix.WithApplicability(diag.FixApplicabilitySafeWithHeuristics),
+			fix.Preferred(),
+		))
+		b.Emit()
+	}
+}
+
+// noteTypeNameUses собирает имена из типовых путей и ограничений дженериков
+// этого файла. Типы резолвит sema, а не резолвер, поэтому обходятся арены AST.
+func (fr *fileResolver) noteTypeNameUses() {
+	types := fr.builder.Types
+	for i := uint32(1); i <= types.Arena.Len(); i++ {
+		typ := types.Arena.Get(i)
+		if typ == nil || typ.Kind != ast.TypeExprPath || typ.Span.File != fr.sourceFile {
+			continue
+		}
+		path := types.Paths.Get(uint32(typ.Payload))
+		if path == nil {
+			continue
+		}
+		for _, seg := range path.Segments {
+			fr.noteNameUse(seg.Name)
+		}
+	}
+	bounds := fr.builder.Items.TypeParamBounds
+	for i := uint32(1); i <= bounds.Len(); i++ {
+		if bound := bounds.Get(i); bound != nil && bound.NameSpan.File == fr.sourceFile {
+			fr.noteNameUse(bound.Name)
+		}
+	}
+}
+
+// letNamePrefixFix вставляет `_` перед именем в `let [mut] name ...`.
+type letNamePrefixFix struct {
+	id    string
+	title string
+	stmt  source.Span
+	name  string
+}
+
+func (f letNamePrefixFix) ID() string {
+	return f.id
+}
+
+func (f letNamePrefixFix) Build(ctx diag.FixBuildContext) (diag.Fix, error) {
+	if ctx.FileSet == nil || !ctx.FileSet.HasFile(f.stmt.File) {
+		return diag.Fix{}, errors.New("source file is not available")
+	}
+	content := ctx.FileSet.Get(f.stmt.File).Content
+	if int(f.stmt.End) > len(content) || f.stmt.Start > f.stmt.End {
+		return diag.Fix{}, fmt.Errorf("span %s is out of range", f.stmt)
+	}
+	text := string(content[f.stmt.Start:f.stmt.End])
+	rest := strings.TrimPrefix(text, "let")
+	if len(rest) == len(text) {
+		return diag.Fix{}, errors.New("let keyword not found")
+	}
+	rest = strings.TrimLeft(rest, " \t\r\n")
+	if after, ok := strings.CutPrefix(rest, "mut"); ok && len(after) > 0 && strings.ContainsRune(" \t\r\n", rune(after[0])) {
+		rest = strings.TrimLeft(after, " \t\r\n")
+	}
+	if !strings.HasPrefix(rest, f.name) {
+		return diag.Fix{}, fmt.Errorf("binding '%s' not found", f.name)
+	}
+	offset, err := safecast.Conv[uint32](len(text) - len(rest))
+	if err != nil {
+		return diag.Fix{}, err
+	}
+	at := source.Span{File: f.stmt.File, Start: f.stmt.Start + offset, End: f.stmt.Start + offset}
+	return *fix.InsertText(f.title, at, "_", "", fix.Preferred()), nil
+}
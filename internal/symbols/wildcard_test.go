@@ -53,7 +53,7 @@ func TestWildcardAsValueIsError(t *testing.T) {
 	src := `
             fn main() {
                 let _ = 1;
-                let x = _;
+                let _x = _;
             }
         `
 	builder, fileID, parseBag := parseSnippet(t, src)
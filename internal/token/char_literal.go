@@ -0,0 +1,83 @@
+package token
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrCharEmpty reports `''`.
+	ErrCharEmpty = errors.New("empty character literal")
+	// ErrCharTooLong reports a literal holding more than one Unicode scalar.
+	ErrCharTooLong = errors.New("character literal must contain exactly one character")
+	// ErrCharBadEscape reports an unknown or malformed escape sequence.
+	ErrCharBadEscape = errors.New("invalid escape in character literal")
+)
+
+// CharLiteralValue decodes a CharLit text (`'a'`, `'\n'`, `'\u{1F600}'`) into its code point.
+// Supported escapes: \n \t \r \0 \\ \' \" and \u{hex} (1-6 digits, a valid Unicode scalar).
+func CharLiteralValue(text string) (rune, error) {
+	if len(text) < 2 || text[0] != '\'' || text[len(text)-1] != '\'' {
+		return 0, ErrCharBadEscape
+	}
+	body := text[1 : len(text)-1]
+	if body == "" {
+		return 0, ErrCharEmpty
+	}
+	var (
+		r    rune
+		size int
+	)
+	if body[0] == '\\' {
+		var err error
+		r, size, err = decodeCharEscape(body)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		r, size = utf8.DecodeRuneInString(body)
+		if r == utf8.RuneError && size <= 1 {
+			return 0, ErrCharBadEscape
+		}
+	}
+	if size != len(body) {
+		return 0, ErrCharTooLong
+	}
+	return r, nil
+}
+
+func decodeCharEscape(body string) (r rune, size int, err error) {
+	if len(body) < 2 {
+		return 0, 0, ErrCharBadEscape
+	}
+	switch body[1] {
+	case 'n':
+		return '\n', 2, nil
+	case 't':
+		return '\t', 2, nil
+	case 'r':
+		return '\r', 2, nil
+	case '0':
+		return 0, 2, nil
+	case '\\', '\'', '"':
+		return rune(body[1]), 2, nil
+	case 'u':
+		end := strings.IndexByte(body, '}')
+		if len(body) < 4 || body[2] != '{' || end < 0 {
+			return 0, 0, ErrCharBadEscape
+		}
+		digits := body[3:end]
+		if digits == "" || len(digits) > 6 {
+			return 0, 0, ErrCharBadEscape
+		}
+		v, parseErr := strconv.ParseUint(digits, 16, 32)
+		if parseErr != nil || !utf8.ValidRune(rune(v)) {
+			return 0, 0, ErrCharBadEscape
+		}
+		return rune(v), end + 1, nil
+	default:
+		return 0, 0, ErrCharBadEscape
+	}
+}
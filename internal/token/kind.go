@@ -114,6 +114,8 @@ const (
 	StringLit
 	// FStringLit represents the formatted string literal token.
 	FStringLit
+	// CharLit represents the character literal token ('a', '\n', '\u{1F600}').
+	CharLit
 
 	// Plus represents the plus operator token.
 	Plus // +
@@ -112,6 +112,8 @@ func (k Kind) String() string {
 		return "StringLit"
 	case FStringLit:
 		return "FStringLit"
+	case CharLit:
+		return "CharLit"
 	case Plus:
 		return "Plus"
 	case Minus:
@@ -15,6 +15,7 @@ func TestIsLiteral(t *testing.T) {
 	lits := []token.Kind{
 		token.NothingLit, token.IntLit, token.UintLit,
 		token.FloatLit, token.BoolLit, token.StringLit, token.FStringLit,
+		token.CharLit,
 	}
 	for _, k := range lits {
 		if !tok(k).IsLiteral() {
@@ -0,0 +1,64 @@
+package token
+
+import "strings"
+
+// IsRawString reports whether a StringLit text is a raw literal (`r"..."` or `r#"..."#`).
+// Raw literals keep their prefix in Text; consumers use it as the "do not unescape" flag.
+func IsRawString(text string) bool {
+	return len(text) >= 3 && text[0] == 'r' && (text[1] == '"' || text[1] == '#')
+}
+
+// RawStringBody returns the verbatim contents of a raw string literal text,
+// stripping the `r`, the `#` guards and the quotes. ok is false for non-raw text.
+func RawStringBody(text string) (body string, ok bool) {
+	if !IsRawString(text) {
+		return "", false
+	}
+	rest := text[1:]
+	hashes := len(rest) - len(strings.TrimLeft(rest, "#"))
+	closing := "\"" + rest[:hashes]
+	rest = rest[hashes:]
+	if !strings.HasPrefix(rest, "\"") || !strings.HasSuffix(rest[1:], closing) {
+		return "", false
+	}
+	return rest[1 : len(rest)-len(closing)], true
+}
+
+// UnquoteString returns the value of a StringLit text: raw literals are taken
+// verbatim, ordinary ones lose their quotes and have `\\ \" \n \t \r` decoded
+// (any other escaped byte stands for itself).
+func UnquoteString(text string) string {
+	if body, ok := RawStringBody(text); ok {
+		return body
+	}
+	if len(text) >= 2 && text[0] == '"' && text[len(text)-1] == '"' {
+		text = text[1 : len(text)-1]
+	}
+	if !strings.Contains(text, "\\") {
+		return text
+	}
+	var sb strings.Builder
+	sb.Grow(len(text))
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if ch != '\\' {
+			sb.WriteByte(ch)
+			continue
+		}
+		if i+1 >= len(text) {
+			break
+		}
+		i++
+		switch text[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		default:
+			sb.WriteByte(text[i])
+		}
+	}
+	return sb.String()
+}
@@ -12,10 +12,10 @@ type Token struct {
 	Leading []Trivia
 }
 
-// IsLiteral reports whether the token is a numeric, boolean, or string literal.
+// IsLiteral reports whether the token is a numeric, boolean, character, or string literal.
 func (t Token) IsLiteral() bool {
 	switch t.Kind {
-	case NothingLit, IntLit, UintLit, FloatLit, BoolLit, StringLit, FStringLit:
+	case NothingLit, IntLit, UintLit, FloatLit, BoolLit, StringLit, FStringLit, CharLit:
 		return true
 	default:
 		return false
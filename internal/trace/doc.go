@@ -17,6 +17,7 @@
 //   - StreamTracer: Immediate write to output (file/stderr)
 //   - RingTracer: Circular buffer for crash dumps
 //   - MultiTracer: Combines multiple tracers
+//   - SamplingTracer: Periodic snapshots of open spans for statistical profiles
 //
 // # Levels
 //
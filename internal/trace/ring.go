@@ -1,8 +1,11 @@
 package trace
 
 import (
+	"fmt"
 	"io"
+	"strings"
 	"sync"
+	"time"
 )
 
 // RingTracer keeps the last N events in memory (circular buffer).
@@ -80,6 +83,55 @@ func (t *RingTracer) Dump(w io.Writer, format Format) error {
 	return nil
 }
 
+// DumpRecentSpans writes the last n spans from the buffer as an indented tree,
+// one line per span in begin order. Spans without an end event are marked
+// "open": after a crash they are the operations that were in progress.
+// Parents evicted from the buffer are not shown; their children start at the left.
+func (t *RingTracer) DumpRecentSpans(w io.Writer, n int) error {
+	type spanLine struct {
+		ev    Event
+		dur   time.Duration
+		ended bool
+	}
+	var spans []*spanLine
+	byID := make(map[uint64]*spanLine)
+	for _, ev := range t.Snapshot() {
+		switch ev.Kind {
+		case KindSpanBegin:
+			line := &spanLine{ev: ev}
+			spans = append(spans, line)
+			byID[ev.SpanID] = line
+		case KindSpanEnd:
+			if line := byID[ev.SpanID]; line != nil {
+				line.dur = ev.Time.Sub(line.ev.Time)
+				line.ended = true
+			}
+		}
+	}
+	if n > 0 && len(spans) > n {
+		spans = spans[len(spans)-n:]
+	}
+	shown := make(map[uint64]*spanLine, len(spans))
+	for _, line := range spans {
+		shown[line.ev.SpanID] = line
+	}
+
+	for _, line := range spans {
+		depth := 0
+		for parent := shown[line.ev.ParentID]; parent != nil && depth < len(spans); parent = shown[parent.ev.ParentID] {
+			depth++
+		}
+		state := "open"
+		if line.ended {
+			state = line.dur.String()
+		}
+		if _, err := fmt.Fprintf(w, "%s%s [%s] %s\n", strings.Repeat("  ", depth), line.ev.Name, line.ev.Scope, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Flush is a no-op for RingTracer since everything is in memory.
 func (t *RingTracer) Flush() error {
 	return nil
@@ -0,0 +1,193 @@
+package trace
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultSampleInterval is the sampling period used when none is specified.
+const DefaultSampleInterval = time.Millisecond
+
+// SampleStat aggregates the samples taken while a span with a given name was open.
+type SampleStat struct {
+	Name  string // span name
+	Scope Scope  // scope of the first span seen with this name
+	Self  uint64 // samples where the span was a leaf (no open child)
+	Total uint64 // samples where the span was open at all
+}
+
+type openSpan struct {
+	name   string
+	scope  Scope
+	parent uint64
+}
+
+// SamplingTracer records which spans are open at a fixed interval instead of
+// storing every begin/end. The result is a statistical profile that stays cheap
+// on large modules where full detail tracing would distort timings.
+type SamplingTracer struct {
+	level    Level
+	interval time.Duration
+
+	mu   sync.Mutex // guards open; held only for map updates and snapshot copies
+	open map[uint64]openSpan
+
+	statsMu sync.Mutex
+	stats   map[string]*SampleStat
+	samples atomic.Uint64
+
+	buf     []openSpan // reused by the sampler goroutine only
+	parents map[uint64]struct{}
+	ids     []uint64
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// NewSamplingTracer creates a SamplingTracer and starts its sampling goroutine.
+// interval <= 0 selects DefaultSampleInterval. Close stops the sampler.
+func NewSamplingTracer(interval time.Duration, level Level) *SamplingTracer {
+	t := newSamplingTracer(interval, level)
+	t.wg.Add(1)
+	go t.run()
+	return t
+}
+
+func newSamplingTracer(interval time.Duration, level Level) *SamplingTracer {
+	if interval <= 0 {
+		interval = DefaultSampleInterval
+	}
+	return &SamplingTracer{
+		level:    level,
+		interval: interval,
+		open:     make(map[uint64]openSpan),
+		stats:    make(map[string]*SampleStat),
+		parents:  make(map[uint64]struct{}),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Emit tracks span begin/end; other event kinds are ignored.
+func (t *SamplingTracer) Emit(ev *Event) {
+	if ev == nil || !t.level.ShouldEmit(ev.Scope) {
+		return
+	}
+	switch ev.Kind {
+	case KindSpanBegin:
+		t.mu.Lock()
+		t.open[ev.SpanID] = openSpan{name: ev.Name, scope: ev.Scope, parent: ev.ParentID}
+		t.mu.Unlock()
+	case KindSpanEnd:
+		t.mu.Lock()
+		delete(t.open, ev.SpanID)
+		t.mu.Unlock()
+	}
+}
+
+func (t *SamplingTracer) run() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.sample()
+		case <-t.stopCh:
+			return
+		}
+	}
+}
+
+// sample takes one snapshot of the open spans. The span lock is held only for
+// the copy; aggregation happens outside of it so Emit is not delayed.
+func (t *SamplingTracer) sample() {
+	t.mu.Lock()
+	t.buf = t.buf[:0]
+	t.ids = t.ids[:0]
+	for id, sp := range t.open {
+		t.buf = append(t.buf, sp)
+		t.ids = append(t.ids, id)
+	}
+	t.mu.Unlock()
+
+	if len(t.buf) == 0 {
+		return
+	}
+
+	clear(t.parents)
+	for _, sp := range t.buf {
+		if sp.parent != 0 {
+			t.parents[sp.parent] = struct{}{}
+		}
+	}
+
+	t.statsMu.Lock()
+	for i, sp := range t.buf {
+		st := t.stats[sp.name]
+		if st == nil {
+			st = &SampleStat{Name: sp.name, Scope: sp.scope}
+			t.stats[sp.name] = st
+		}
+		st.Total++
+		if _, hasChild := t.parents[t.ids[i]]; !hasChild {
+			st.Self++
+		}
+	}
+	t.statsMu.Unlock()
+	t.samples.Add(1)
+}
+
+// Samples returns the number of snapshots that saw at least one open span.
+func (t *SamplingTracer) Samples() uint64 {
+	return t.samples.Load()
+}
+
+// Profile returns per-span sample counts, hottest (by Self) first.
+func (t *SamplingTracer) Profile() []SampleStat {
+	t.statsMu.Lock()
+	result := make([]SampleStat, 0, len(t.stats))
+	for _, st := range t.stats {
+		result = append(result, *st)
+	}
+	t.statsMu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Self != result[j].Self {
+			return result[i].Self > result[j].Self
+		}
+		if result[i].Total != result[j].Total {
+			return result[i].Total > result[j].Total
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Flush is a no-op: samples live in memory until Profile is read.
+func (t *SamplingTracer) Flush() error {
+	return nil
+}
+
+// Close stops the sampling goroutine. The collected profile stays readable.
+func (t *SamplingTracer) Close() error {
+	t.once.Do(func() {
+		close(t.stopCh)
+		t.wg.Wait()
+	})
+	return nil
+}
+
+// Level returns the current tracing level.
+func (t *SamplingTracer) Level() Level {
+	return t.level
+}
+
+// Enabled returns true if tracing is active.
+func (t *SamplingTracer) Enabled() bool {
+	return t.level > LevelOff
+}
@@ -0,0 +1,69 @@
+package trace
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSamplingTracerHottestSpanDominates(t *testing.T) {
+	// Семплер без тикера: снимки берём вручную, чтобы тест не зависел от планировщика.
+	sampler := newSamplingTracer(time.Hour, LevelDetail)
+	ring := NewRingTracer(16, LevelDetail)
+	multi := NewMultiTracer(LevelDetail, ring, sampler)
+
+	root := Begin(multi, ScopeDriver, "diag", 0)
+	hot := Begin(multi, ScopePass, "sema", root.ID())
+	for range 9 {
+		sampler.sample()
+	}
+	hot.End("")
+	cold := Begin(multi, ScopePass, "parse", root.ID())
+	sampler.sample()
+	cold.End("")
+	root.End("")
+	sampler.sample() // ничего не открыто — снимок не засчитывается
+
+	if got := sampler.Samples(); got != 10 {
+		t.Fatalf("expected 10 samples, got %d", got)
+	}
+	profile := sampler.Profile()
+	if len(profile) != 3 {
+		t.Fatalf("expected 3 span names, got %+v", profile)
+	}
+	if profile[0].Name != "sema" || profile[0].Self != 9 {
+		t.Fatalf("expected sema to dominate with 9 self samples, got %+v", profile[0])
+	}
+	byName := map[string]SampleStat{}
+	for _, st := range profile {
+		byName[st.Name] = st
+	}
+	if st := byName["parse"]; st.Self != 1 || st.Total != 1 {
+		t.Fatalf("unexpected parse stats: %+v", st)
+	}
+	if st := byName["diag"]; st.Self != 0 || st.Total != 10 {
+		t.Fatalf("root span should be open in every sample but never a leaf, got %+v", st)
+	}
+	if len(ring.Snapshot()) != 6 {
+		t.Fatalf("MultiTracer should still forward events to the ring, got %d", len(ring.Snapshot()))
+	}
+}
+
+func TestSamplingTracerTickerCollectsSamples(t *testing.T) {
+	sampler := NewSamplingTracer(time.Millisecond, LevelPhase)
+	span := Begin(sampler, ScopePass, "borrow", 0)
+	deadline := time.Now().Add(2 * time.Second)
+	for sampler.Samples() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	span.End("")
+	if err := sampler.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := sampler.Close(); err != nil {
+		t.Fatalf("second close: %v", err)
+	}
+	profile := sampler.Profile()
+	if len(profile) != 1 || profile[0].Name != "borrow" || profile[0].Self == 0 {
+		t.Fatalf("expected borrow samples from the ticker, got %+v", profile)
+	}
+}
@@ -73,6 +73,7 @@ type Config struct {
 	Format     Format        // output format (FormatAuto for auto-detection)
 	Output     io.Writer     // for stream mode (if nil, use OutputPath)
 	OutputPath string        // alternative: file path ("-" for stderr)
+	Append     bool          // append to OutputPath instead of truncating it
 	RingSize   int           // for ring mode (default 4096)
 	Heartbeat  time.Duration // heartbeat interval (0 = disabled)
 }
@@ -137,7 +138,12 @@ func openOutput(cfg Config) (io.Writer, error) {
 		return os.Stderr, nil
 	}
 
-	f, err := os.Create(cfg.OutputPath)
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if cfg.Append {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	// #nosec G304 -- trace output path is user-specified
+	f, err := os.OpenFile(cfg.OutputPath, flags, 0o644)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open trace output: %w", err)
 	}
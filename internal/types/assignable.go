@@ -0,0 +1,92 @@
+package types //nolint:revive
+
+// maxAssignDepth bounds alias and struct-base chains walked by AssignableTo.
+const maxAssignDepth = 32
+
+// AssignableTo reports whether a value of type from can be stored where to is
+// expected without an explicit conversion. It is the type-level part of
+// assignability shared by sema and both backends:
+//   - identical types, also after alias resolution;
+//   - numeric widening within one family (int8 -> int32 -> int, uint8 -> uint64, float32 -> float);
+//   - &mut T -> &T, &T/&mut T -> &Base when T extends Base;
+//   - heir upcasts: a struct extending Base (directly or transitively) -> Base.
+//
+// Checks that depend on declarations rather than on types alone (copy types,
+// own coercions, union membership) stay in sema.
+func (in *Interner) AssignableTo(from, to TypeID) bool {
+	if in == nil || from == NoTypeID || to == NoTypeID {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	from = in.resolveAliasChain(from)
+	to = in.resolveAliasChain(to)
+	if from == to {
+		return true
+	}
+	fromTT, okFrom := in.Lookup(from)
+	toTT, okTo := in.Lookup(to)
+	if !okFrom || !okTo {
+		return false
+	}
+	switch toTT.Kind {
+	case KindInt, KindUint, KindFloat:
+		return fromTT.Kind == toTT.Kind && fromTT.IsChar() == toTT.IsChar() && widthWidens(fromTT.Width, toTT.Width)
+	case KindReference:
+		if fromTT.Kind != KindReference {
+			return false
+		}
+		if toTT.Mutable {
+			// &mut T инвариантна: через неё можно записать значение целевого типа
+			return fromTT.Mutable && in.resolveAliasChain(fromTT.Elem) == in.resolveAliasChain(toTT.Elem)
+		}
+		return in.structExtends(in.resolveAliasChain(fromTT.Elem), in.resolveAliasChain(toTT.Elem))
+	case KindStruct:
+		return fromTT.Kind == KindStruct && in.structExtends(from, to)
+	default:
+		return false
+	}
+}
+
+// widthWidens reports whether a numeric of width from fits into width to.
+// WidthAny is arbitrary precision and therefore the widest.
+func widthWidens(from, to Width) bool {
+	if from == to || to == WidthAny {
+		return true
+	}
+	if from == WidthAny {
+		return false
+	}
+	return from < to
+}
+
+// structExtends reports whether typ is base itself or reaches it through struct bases.
+func (in *Interner) structExtends(typ, base TypeID) bool {
+	for range maxAssignDepth {
+		if typ == base {
+			return true
+		}
+		next, ok := in.StructBase(typ)
+		if !ok {
+			return false
+		}
+		typ = in.resolveAliasChain(next)
+	}
+	return false
+}
+
+func (in *Interner) resolveAliasChain(id TypeID) TypeID {
+	for range maxAssignDepth {
+		tt, ok := in.Lookup(id)
+		if !ok || tt.Kind != KindAlias {
+			return id
+		}
+		target, ok := in.AliasTarget(id)
+		if !ok || target == id {
+			return id
+		}
+		id = target
+	}
+	return id
+}
@@ -0,0 +1,88 @@
+package types //nolint:revive
+
+import (
+	"testing"
+
+	"surge/internal/source"
+)
+
+func TestAssignableToIntWidthWidening(t *testing.T) {
+	in := NewInterner()
+	b := in.Builtins()
+	cases := []struct {
+		from, to TypeID
+		want     bool
+	}{
+		{b.Int8, b.Int32, true},
+		{b.Int32, b.Int, true},
+		{b.Uint8, b.Uint64, true},
+		{b.Float32, b.Float64, true},
+		{b.Int64, b.Int32, false},
+		{b.Int, b.Int64, false},
+		{b.Uint8, b.Int32, false},
+		{b.Int32, b.Float64, false},
+		{b.Char, b.Uint32, false},
+		{b.Uint32, b.Char, false},
+		{b.Char, b.Uint64, false},
+		{b.Char, b.Char, true},
+	}
+	for _, tc := range cases {
+		if got := in.AssignableTo(tc.from, tc.to); got != tc.want {
+			t.Fatalf("AssignableTo(%v, %v) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestAssignableToReferences(t *testing.T) {
+	in := NewInterner()
+	b := in.Builtins()
+	refMut := in.Intern(MakeReference(b.Int, true))
+	ref := in.Intern(MakeReference(b.Int, false))
+	if !in.AssignableTo(refMut, ref) {
+		t.Fatalf("&mut int must be assignable to &int")
+	}
+	if in.AssignableTo(ref, refMut) {
+		t.Fatalf("&int must not be assignable to &mut int")
+	}
+	if in.AssignableTo(in.Intern(MakeReference(b.Int8, false)), ref) {
+		t.Fatalf("references must not widen their element type")
+	}
+}
+
+func TestAssignableToHeirUpcast(t *testing.T) {
+	in := NewInterner()
+	strs := source.NewInterner()
+	base := in.RegisterStruct(strs.Intern("Base"), source.Span{})
+	child := in.RegisterStruct(strs.Intern("Child"), source.Span{})
+	grandchild := in.RegisterStruct(strs.Intern("Grandchild"), source.Span{})
+	other := in.RegisterStruct(strs.Intern("Other"), source.Span{})
+	in.SetStructBase(child, base)
+	in.SetStructBase(grandchild, child)
+
+	if !in.AssignableTo(child, base) || !in.AssignableTo(grandchild, base) {
+		t.Fatalf("heir structs must upcast to their bases")
+	}
+	if in.AssignableTo(base, child) {
+		t.Fatalf("base must not downcast to heir")
+	}
+	if in.AssignableTo(other, base) {
+		t.Fatalf("unrelated struct must not be assignable")
+	}
+
+	alias := in.RegisterAlias(strs.Intern("BaseAlias"), source.Span{})
+	in.SetAliasTarget(alias, base)
+	if !in.AssignableTo(grandchild, alias) {
+		t.Fatalf("upcast must see through aliases")
+	}
+
+	childRef := in.Intern(MakeReference(child, false))
+	childMut := in.Intern(MakeReference(child, true))
+	baseRef := in.Intern(MakeReference(base, false))
+	baseMut := in.Intern(MakeReference(base, true))
+	if !in.AssignableTo(childRef, baseRef) || !in.AssignableTo(childMut, baseRef) {
+		t.Fatalf("&Child and &mut Child must be assignable to &Base")
+	}
+	if in.AssignableTo(childMut, baseMut) {
+		t.Fatalf("&mut Child must not be assignable to &mut Base")
+	}
+}
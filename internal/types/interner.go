@@ -25,6 +25,7 @@ type Builtins struct {
 	Uint16  TypeID
 	Uint32  TypeID
 	Uint64  TypeID
+	Char    TypeID
 	Float   TypeID
 	Float16 TypeID
 	Float32 TypeID
@@ -80,6 +81,7 @@ func NewInterner() *Interner {
 	in.builtins.Uint16 = in.Intern(MakeUint(Width16))
 	in.builtins.Uint32 = in.Intern(MakeUint(Width32))
 	in.builtins.Uint64 = in.Intern(MakeUint(Width64))
+	in.builtins.Char = in.Intern(MakeChar())
 	in.builtins.Float = in.Intern(MakeFloat(WidthAny))
 	in.builtins.Float16 = in.Intern(MakeFloat(Width16))
 	in.builtins.Float32 = in.Intern(MakeFloat(Width32))
@@ -1,6 +1,10 @@
 package types //nolint:revive
 
-import "testing"
+import (
+	"testing"
+
+	"surge/internal/source"
+)
 
 func TestInternerBuiltins(t *testing.T) {
 	in := NewInterner()
@@ -107,3 +111,49 @@ func TestIsCopy(t *testing.T) {
 		t.Error("NoTypeID should not be Copy")
 	}
 }
+
+func TestZeroValueKindOptionIsNothing(t *testing.T) {
+	in := NewInterner()
+	b := in.Builtins()
+	option := in.RegisterUnionInstance(source.StringID(1), source.Span{}, []TypeID{b.Int})
+	in.SetUnionMembers(option, []UnionMember{
+		{Kind: UnionMemberTag, TagName: source.StringID(2), TagArgs: []TypeID{b.Int}},
+		{Kind: UnionMemberNothing, Type: b.Nothing},
+	})
+	if got := in.ZeroValueKind(option); got != ZeroNothing {
+		t.Fatalf("expected Option<int> zero to be nothing, got %s", got)
+	}
+	tagsOnly := in.RegisterUnion(source.StringID(3), source.Span{})
+	in.SetUnionMembers(tagsOnly, []UnionMember{{Kind: UnionMemberTag, TagName: source.StringID(4)}})
+	if got := in.ZeroValueKind(tagsOnly); got != ZeroNone {
+		t.Fatalf("expected a union without nothing to have no zero, got %s", got)
+	}
+}
+
+func TestZeroValueKindStructIsFieldWise(t *testing.T) {
+	in := NewInterner()
+	b := in.Builtins()
+	point := in.RegisterStruct(source.StringID(1), source.Span{})
+	in.SetStructFields(point, []StructField{
+		{Name: source.StringID(2), Type: b.Int},
+		{Name: source.StringID(3), Type: b.Bool},
+		{Name: source.StringID(4), Type: b.String},
+	})
+	if got := in.ZeroValueKind(point); got != ZeroFields {
+		t.Fatalf("expected struct zero to be field-wise, got %s", got)
+	}
+	for _, field := range in.StructFields(point) {
+		if in.ZeroValueKind(field.Type) == ZeroNone {
+			t.Fatalf("field type#%d has no zero value", field.Type)
+		}
+	}
+	if got := in.ZeroValueKind(in.Intern(MakeOwn(point))); got != ZeroFields {
+		t.Fatalf("expected own struct zero to be field-wise, got %s", got)
+	}
+
+	holder := in.RegisterStruct(source.StringID(5), source.Span{})
+	in.SetStructFields(holder, []StructField{{Name: source.StringID(6), Type: in.Intern(MakeReference(b.Int, false))}})
+	if got := in.ZeroValueKind(holder); got != ZeroNone {
+		t.Fatalf("expected a struct with a reference field to have no zero, got %s", got)
+	}
+}
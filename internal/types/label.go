@@ -39,6 +39,9 @@ func labelDepth(typesIn *Interner, id TypeID, depth int) string {
 	case KindInt:
 		return formatIntType(tt.Width, true)
 	case KindUint:
+		if tt.IsChar() {
+			return "char"
+		}
 		return formatIntType(tt.Width, false)
 	case KindFloat:
 		return formatFloatType(tt.Width)
@@ -142,6 +142,20 @@ func MakeUint(width Width) Type {
 	return Type{Kind: KindUint, Width: width}
 }
 
+// charPayload marks the KindUint descriptor of the builtin char type.
+const charPayload = 1
+
+// MakeChar describes char: a Unicode scalar stored like uint32 (backends lower
+// it as such) but interned under its own TypeID, so it does not mix with integers.
+func MakeChar() Type {
+	return Type{Kind: KindUint, Width: Width32, Payload: charPayload}
+}
+
+// IsChar reports whether the descriptor is the builtin char type.
+func (t Type) IsChar() bool {
+	return t.Kind == KindUint && t.Payload == charPayload
+}
+
 // MakeFloat describes a floating-point type.
 func MakeFloat(width Width) Type {
 	return Type{Kind: KindFloat, Width: width}
@@ -0,0 +1,155 @@
+package types //nolint:revive
+
+import "fmt"
+
+// ZeroKind describes the canonical zero (default) value of a type. Backends
+// use it to initialize locals, struct fields and `default<T>()` the same way.
+type ZeroKind uint8
+
+const (
+	// ZeroNone marks a type without a zero value: references, functions,
+	// generic parameters, enums and unions without a `nothing` member.
+	ZeroNone ZeroKind = iota
+	// ZeroUnit is the only value of unit and nothing.
+	ZeroUnit
+	// ZeroBool is false.
+	ZeroBool
+	// ZeroNumber is 0 for integers and 0.0 for floats.
+	ZeroNumber
+	// ZeroString is the empty string.
+	ZeroString
+	// ZeroNullPtr is the null raw pointer.
+	ZeroNullPtr
+	// ZeroNothing is the `nothing` member of a union, e.g. Option<T>.
+	ZeroNothing
+	// ZeroEmpty is an empty dynamic array or map.
+	ZeroEmpty
+	// ZeroFields zeroes every field of a struct, element of a tuple or slot
+	// of a fixed-size array.
+	ZeroFields
+	// ZeroConst is the value carried by a const type.
+	ZeroConst
+)
+
+func (k ZeroKind) String() string {
+	switch k {
+	case ZeroNone:
+		return "none"
+	case ZeroUnit:
+		return "unit"
+	case ZeroBool:
+		return "false"
+	case ZeroNumber:
+		return "0"
+	case ZeroString:
+		return "\"\""
+	case ZeroNullPtr:
+		return "null"
+	case ZeroNothing:
+		return "nothing"
+	case ZeroEmpty:
+		return "empty"
+	case ZeroFields:
+		return "fields"
+	case ZeroConst:
+		return "const"
+	default:
+		return fmt.Sprintf("ZeroKind(%d)", k)
+	}
+}
+
+// ZeroValueKind returns the canonical zero value of id. Aliases and own are
+// looked through. A struct, tuple or fixed array has a zero value only when
+// every element does; a type that contains itself by value has none.
+func (in *Interner) ZeroValueKind(id TypeID) ZeroKind {
+	return in.zeroValueKind(id, make(map[TypeID]struct{}))
+}
+
+func (in *Interner) zeroValueKind(id TypeID, visiting map[TypeID]struct{}) ZeroKind {
+	if in == nil {
+		return ZeroNone
+	}
+	tt, ok := in.Lookup(id)
+	if !ok {
+		return ZeroNone
+	}
+	switch tt.Kind {
+	case KindAlias:
+		target, ok := in.AliasTarget(id)
+		if !ok || target == NoTypeID {
+			return ZeroNone
+		}
+		return in.zeroValueKind(target, visiting)
+	case KindOwn:
+		return in.zeroValueKind(tt.Elem, visiting)
+	case KindUnit, KindNothing:
+		return ZeroUnit
+	case KindBool:
+		return ZeroBool
+	case KindInt, KindUint, KindFloat:
+		return ZeroNumber
+	case KindString:
+		return ZeroString
+	case KindPointer:
+		return ZeroNullPtr
+	case KindConst:
+		return ZeroConst
+	case KindUnion:
+		info, ok := in.UnionInfo(id)
+		if !ok || info == nil {
+			return ZeroNone
+		}
+		for _, member := range info.Members {
+			if member.Kind == UnionMemberNothing {
+				return ZeroNothing
+			}
+		}
+		return ZeroNone
+	case KindArray:
+		if tt.Count == ArrayDynamicLength {
+			return ZeroEmpty
+		}
+		return in.zeroElements(id, []TypeID{tt.Elem}, visiting)
+	case KindTuple:
+		info, ok := in.TupleInfo(id)
+		if !ok || info == nil {
+			return ZeroNone
+		}
+		return in.zeroElements(id, info.Elems, visiting)
+	case KindStruct:
+		if _, _, ok := in.MapInfo(id); ok {
+			return ZeroEmpty
+		}
+		if _, ok := in.ArrayInfo(id); ok {
+			return ZeroEmpty
+		}
+		if elem, _, ok := in.ArrayFixedInfo(id); ok {
+			return in.zeroElements(id, []TypeID{elem}, visiting)
+		}
+		fields := in.StructFields(id)
+		elems := make([]TypeID, 0, len(fields))
+		for _, field := range fields {
+			elems = append(elems, field.Type)
+		}
+		return in.zeroElements(id, elems, visiting)
+	default:
+		// KindReference, KindFn, KindEnum, KindGenericParam, KindInvalid
+		return ZeroNone
+	}
+}
+
+// zeroElements returns ZeroFields when every element type of the aggregate id
+// has a zero value.
+func (in *Interner) zeroElements(id TypeID, elems []TypeID, visiting map[TypeID]struct{}) ZeroKind {
+	if _, ok := visiting[id]; ok {
+		return ZeroNone
+	}
+	visiting[id] = struct{}{}
+	defer delete(visiting, id)
+	for _, elem := range elems {
+		if in.zeroValueKind(elem, visiting) == ZeroNone {
+			return ZeroNone
+		}
+	}
+	return ZeroFields
+}
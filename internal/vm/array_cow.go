@@ -0,0 +1,37 @@
+package vm
+
+// unshareArrayForWrite implements copy-on-write for arrays: cloneForShare hands out
+// the same handle (RefCount > 1) instead of deep-copying, so before projecting into
+// an array for a write we give the slot its own shallow copy. Elements are retained,
+// nested arrays are unshared lazily when a write projects into them.
+func (vm *VM) unshareArrayForWrite(slot Location, v Value) (Value, *VMError) {
+	if !slot.IsMut || v.Kind != VKHandleArray || v.H == 0 {
+		return v, nil
+	}
+	obj, vmErr := vm.heapAliveForRef(v.H)
+	if vmErr != nil {
+		return Value{}, vmErr
+	}
+	if obj.Kind != OKArray || obj.RefCount <= 1 {
+		return v, nil
+	}
+	elems := make([]Value, 0, len(obj.Arr))
+	for _, el := range obj.Arr {
+		shared, vmErr := vm.cloneForShare(el)
+		if vmErr != nil {
+			for _, prev := range elems {
+				vm.dropValue(prev)
+			}
+			return Value{}, vmErr
+		}
+		elems = append(elems, shared)
+	}
+	h := vm.Heap.AllocArray(obj.TypeID, elems)
+	owned := MakeHandleArray(h, v.TypeID)
+	// storeLocation отпускает старый handle, так что остальные владельцы его сохраняют.
+	if vmErr := vm.storeLocation(slot, owned); vmErr != nil {
+		vm.dropValue(owned)
+		return Value{}, vmErr
+	}
+	return owned, nil
+}
@@ -136,6 +136,12 @@ func (vm *VM) arrayOwnedFromValue(val Value) (*Object, *VMError) {
 		if vmErr != nil {
 			return nil, vmErr
 		}
+		if val.Kind == VKRefMut {
+			loaded, vmErr = vm.unshareArrayForWrite(val.Loc, loaded)
+			if vmErr != nil {
+				return nil, vmErr
+			}
+		}
 		val = loaded
 	}
 	if val.Kind != VKHandleArray {
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"fmt"
+	"strings"
 
 	"surge/internal/asyncrt"
 	"surge/internal/mir"
@@ -434,6 +435,8 @@ func (vm *VM) pollTask(task *asyncrt.Task) (asyncrt.PollOutcome, *VMError) {
 		return vm.pollSleepTask(task)
 	case asyncrt.TaskKindTimeout:
 		return vm.pollTimeoutTask(task)
+	case asyncrt.TaskKindParallel:
+		return vm.pollParallelTask(task)
 	default:
 		outcome, vmErr := vm.pollUserTask(task)
 		if vmErr != nil {
@@ -519,6 +522,24 @@ func (vm *VM) runReadyOne() (bool, *VMError) {
 	return true, nil
 }
 
+// deadlockError reports that the executor ran out of work while tasks are
+// still waiting, listing each stuck task with the key it is parked on.
+func (vm *VM) deadlockError() *VMError {
+	var b strings.Builder
+	b.WriteString("async deadlock: no task can make progress")
+	if exec := vm.ensureExecutor(); exec != nil {
+		for i, task := range exec.StuckTasks() {
+			if i == 0 {
+				b.WriteString("; stuck tasks: ")
+			} else {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "task %d waiting on %s", task.ID, task.Key)
+		}
+	}
+	return vm.eb.makeError(PanicDeadlock, b.String())
+}
+
 func (vm *VM) runUntilDone(id asyncrt.TaskID, resultType types.TypeID) (Value, *VMError) {
 	exec := vm.ensureExecutor()
 	if exec == nil {
@@ -548,7 +569,7 @@ func (vm *VM) runUntilDone(id asyncrt.TaskID, resultType types.TypeID) (Value, *
 			return Value{}, nil
 		}
 		if !ran {
-			return Value{}, vm.eb.makeError(PanicUnimplemented, "async deadlock")
+			return Value{}, vm.deadlockError()
 		}
 	}
 }
@@ -0,0 +1,140 @@
+package vm
+
+import (
+	"fmt"
+
+	"fortio.org/safecast"
+
+	"surge/internal/mir"
+	"surge/internal/types"
+)
+
+// cloneDeep returns a copy of v that shares no mutable state with it.
+// Strings and big numbers are immutable, so their handles are shared; arrays,
+// structs and tags are copied element by element. A nested struct or tag whose
+// type defines __clone is copied by calling it with a reference to src.
+// A weak reference is copied as is: the copy points at the same referent and
+// does not retain it either.
+func (vm *VM) cloneDeep(v Value, src Location, hasSrc bool) (Value, *VMError) {
+	if v.Kind == VKWeak || !v.IsHeap() || v.H == 0 {
+		return v, nil
+	}
+	obj, vmErr := vm.heapAliveForRef(v.H)
+	if vmErr != nil {
+		return Value{}, vmErr
+	}
+	if hasSrc && (obj.Kind == OKStruct || obj.Kind == OKTag) {
+		if fn := vm.userCloneFunc(obj.TypeID); fn != nil {
+			return vm.callSync(fn, []Value{MakeRef(src, fn.Locals[0].Type)})
+		}
+	}
+	switch obj.Kind {
+	case OKArray, OKArraySlice:
+		view, vmErr := vm.arrayViewFromHandle(v.H)
+		if vmErr != nil {
+			return Value{}, vmErr
+		}
+		elems, vmErr := vm.cloneElems(view.length, LKArrayElem, v.H, func(i int) Value {
+			return view.baseObj.Arr[view.start+i]
+		})
+		if vmErr != nil {
+			return Value{}, vmErr
+		}
+		typeID := obj.TypeID
+		if obj.Kind == OKArraySlice {
+			typeID = view.baseObj.TypeID
+		}
+		return MakeHandleArray(vm.Heap.AllocArray(typeID, elems), v.TypeID), nil
+	case OKStruct:
+		fields, vmErr := vm.cloneElems(len(obj.Fields), LKStructField, v.H, func(i int) Value {
+			return obj.Fields[i]
+		})
+		if vmErr != nil {
+			return Value{}, vmErr
+		}
+		return MakeHandleStruct(vm.Heap.AllocStruct(obj.TypeID, fields), v.TypeID), nil
+	case OKTag:
+		fields, vmErr := vm.cloneElems(len(obj.Tag.Fields), LKTagField, v.H, func(i int) Value {
+			return obj.Tag.Fields[i]
+		})
+		if vmErr != nil {
+			return Value{}, vmErr
+		}
+		return MakeHandleTag(vm.Heap.AllocTag(obj.TypeID, obj.Tag.TagSym, fields), v.TypeID), nil
+	default:
+		return vm.cloneForShare(v)
+	}
+}
+
+// cloneElems deep-copies n elements of the object at h; kind selects the
+// location used to pass an element to a user __clone.
+func (vm *VM) cloneElems(n int, kind LocKind, h Handle, at func(int) Value) ([]Value, *VMError) {
+	out := make([]Value, 0, n)
+	for i := range n {
+		idx, err := safecast.Conv[int32](i)
+		if err != nil {
+			vm.dropValues(out)
+			return nil, vm.eb.makeError(PanicOutOfBounds, fmt.Sprintf("clone index %d overflows", i))
+		}
+		loc := Location{Kind: kind, Handle: h, Index: idx}
+		el, vmErr := vm.cloneDeep(at(i), loc, true)
+		if vmErr != nil {
+			vm.dropValues(out)
+			return nil, vmErr
+		}
+		out = append(out, el)
+	}
+	return out, nil
+}
+
+func (vm *VM) dropValues(vals []Value) {
+	for _, v := range vals {
+		vm.dropValue(v)
+	}
+}
+
+// userCloneFunc returns the __clone method declared for typeID, if any.
+func (vm *VM) userCloneFunc(typeID types.TypeID) *mir.Func {
+	if vm.M == nil || vm.M.Meta == nil || typeID == types.NoTypeID {
+		return nil
+	}
+	fnID, ok := vm.M.Meta.CloneFuncs[resolveAlias(vm.Types, typeID)]
+	if !ok {
+		return nil
+	}
+	return vm.M.Funcs[fnID]
+}
+
+// callSync runs fn to completion on a fresh stack and returns its result.
+// Intrinsics use it to call back into user code (e.g. __clone).
+func (vm *VM) callSync(fn *mir.Func, args []Value) (Value, *VMError) {
+	frame := NewFrame(fn)
+	if len(args) > len(frame.Locals) {
+		return Value{}, vm.eb.makeError(PanicUnimplemented, fmt.Sprintf("too many arguments: got %d, expected at most %d", len(args), len(frame.Locals)))
+	}
+	for i, arg := range args {
+		localID, err := safecast.Conv[mir.LocalID](i)
+		if err != nil {
+			return Value{}, vm.eb.makeError(PanicUnimplemented, fmt.Sprintf("invalid argument index %d", i))
+		}
+		if vmErr := vm.writeLocal(frame, localID, arg); vmErr != nil {
+			return Value{}, vmErr
+		}
+	}
+
+	savedStack := vm.Stack
+	savedCapture := vm.captureReturn
+	var ret Value
+	vm.captureReturn = &ret
+	vm.Stack = []*Frame{frame}
+	defer func() {
+		vm.Stack = savedStack
+		vm.captureReturn = savedCapture
+	}()
+	for len(vm.Stack) > 0 && !vm.Halted {
+		if vmErr := vm.Step(); vmErr != nil {
+			return Value{}, vmErr
+		}
+	}
+	return ret, nil
+}
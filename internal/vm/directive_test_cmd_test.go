@@ -0,0 +1,141 @@
+package vm_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSurgeTestRunsDirectiveAssertions(t *testing.T) {
+	root := repoRoot(t)
+	surge := buildSurgeBinary(t, root)
+
+	tmpDir := t.TempDir()
+	passPath := filepath.Join(tmpDir, "pass.sg")
+	passSource := `import stdlib/directives/test;
+
+/// test:
+/// test.eq(add(1, 2), 3)
+/// test.ne(add(1, 1), 3)
+fn add(a: int, b: int) -> int { return a + b; }
+
+@entrypoint
+fn main() -> int {
+    return add(1, 2);
+}
+`
+	if err := os.WriteFile(passPath, []byte(passSource), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	stdout, stderr, code := runSurgeWithInput(t, root, surge, "", "test", passPath)
+	if code != 0 {
+		t.Fatalf("surge test failed (exit=%d)\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	if !strings.Contains(stdout, "pass.sg#0 (test) ... PASS") ||
+		!strings.Contains(stdout, "1 total, 0 skipped, 1 passed, 0 failed") {
+		t.Fatalf("unexpected output:\n%s", stdout)
+	}
+
+	failPath := filepath.Join(tmpDir, "fail.sg")
+	failSource := `import stdlib/directives/test;
+
+/// test:
+/// test.eq(double(2), 4)
+fn double(x: int) -> int { return x * 2; }
+
+/// test:
+/// test.assert(double(1) == 2)
+/// test.eq(double(3), 7)
+fn triple(x: int) -> int { return x * 3; }
+`
+	if err := os.WriteFile(failPath, []byte(failSource), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	stdout, stderr, code = runSurgeWithInput(t, root, surge, "", "test", failPath)
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	for _, want := range []string{
+		"fail.sg#0 (test) ... PASS",
+		"fail.sg#1 (test) ... FAIL",
+		"fail.sg:9: test.eq(double(3), 7)",
+		"2 total, 0 skipped, 1 passed, 1 failed",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("missing %q in output:\n%s", want, stdout)
+		}
+	}
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("surge test left files behind: %v", entries)
+	}
+}
+
+func TestSurgeTestResolvesRelativeImportsWithoutTempFiles(t *testing.T) {
+	root := repoRoot(t)
+	surge := buildSurgeBinary(t, root)
+
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "lib"), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	utilSource := "pub fn add(a: int, b: int) -> int { return a + b; }\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "lib", "util.sg"), []byte(utilSource), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	mainPath := filepath.Join(tmpDir, "main.sg")
+	mainSource := `import stdlib/directives/test;
+import ./lib/util;
+
+/// test:
+/// test.eq(util.add(1, 2), 3)
+fn zero() -> int { return 0; }
+`
+	if err := os.WriteFile(mainPath, []byte(mainSource), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	cmd := exec.Command(surge, "test", "main.sg")
+	cmd.Dir = tmpDir
+	cmd.Env = envWithStdlib(root)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("surge test failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "main.sg#0 (test) ... PASS") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("surge test wrote files next to the source: %v", entries)
+	}
+}
+
+func TestSurgeTestRendersDiagnosticsWithSource(t *testing.T) {
+	root := repoRoot(t)
+	surge := buildSurgeBinary(t, root)
+
+	path := filepath.Join(t.TempDir(), "bad.sg")
+	if err := os.WriteFile(path, []byte("fn f() -> int { return missing; }\n"), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	_, stderr, code := runSurgeWithInput(t, root, surge, "", "test", "--color=off", path)
+	if code == 0 {
+		t.Fatalf("expected surge test to fail\nstderr:\n%s", stderr)
+	}
+	for _, want := range []string{
+		"bad.sg:1:24: ERROR SEM3005: cannot resolve 'missing'",
+		"  1 | fn f() -> int { return missing; }",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Fatalf("missing %q in stderr:\n%s", want, stderr)
+		}
+	}
+}
@@ -22,12 +22,68 @@ func (vm *VM) execDrop(frame *Frame, localID mir.LocalID) *VMError {
 	if slot.IsDropped {
 		return vm.eb.makeError(PanicRCUseAfterFree, fmt.Sprintf("use-after-free: local %q used after drop", slot.Name))
 	}
+	if borrower, ok := vm.liveBorrower(frame, localID); ok {
+		return vm.eb.makeError(PanicDropWhileBorrowed, fmt.Sprintf("drop of %q while borrowed by %q", slot.Name, frame.Locals[borrower].Name))
+	}
 
 	vm.dropValue(slot.V)
 	slot.IsDropped = true
 	return nil
 }
 
+// dropPoint identifies a drop instruction within a function.
+type dropPoint struct {
+	fn *mir.Func
+	bb mir.BlockID
+	ip int
+}
+
+// liveBorrower finds a named reference local that still points at localID and
+// is read after the current instruction. A borrow must end (end_borrow, or its
+// last use) before the borrowed value is dropped.
+func (vm *VM) liveBorrower(frame *Frame, localID mir.LocalID) (mir.LocalID, bool) {
+	if frame == nil || frame.Func == nil {
+		return mir.NoLocalID, false
+	}
+	var live map[mir.LocalID]bool
+	for id := range frame.Locals {
+		slot := &frame.Locals[id]
+		if !slot.IsInit || slot.IsMoved || slot.IsDropped {
+			continue
+		}
+		if slot.V.Kind != VKRef && slot.V.Kind != VKRefMut {
+			continue
+		}
+		loc := slot.V.Loc
+		if loc.Kind != LKLocal || loc.FrameRef != frame || loc.Local != int32(localID) { //nolint:gosec // local ids fit in int32
+			continue
+		}
+		if !frame.Func.Locals[id].Sym.IsValid() {
+			continue
+		}
+		if live == nil {
+			live = vm.liveAfterDrop(frame)
+		}
+		if live[mir.LocalID(id)] { //nolint:gosec // bounded by local count
+			return mir.LocalID(id), true //nolint:gosec // bounded by local count
+		}
+	}
+	return mir.NoLocalID, false
+}
+
+func (vm *VM) liveAfterDrop(frame *Frame) map[mir.LocalID]bool {
+	key := dropPoint{fn: frame.Func, bb: frame.BB, ip: frame.IP}
+	if live, ok := vm.dropLive[key]; ok {
+		return live
+	}
+	live := mir.LiveAfter(frame.Func, frame.BB, frame.IP)
+	if vm.dropLive == nil {
+		vm.dropLive = make(map[dropPoint]map[mir.LocalID]bool)
+	}
+	vm.dropLive[key] = live
+	return live
+}
+
 func (vm *VM) execDropGlobal(globalID mir.GlobalID) *VMError {
 	if int(globalID) < 0 || int(globalID) >= len(vm.Globals) {
 		return vm.eb.makeError(PanicOutOfBounds, fmt.Sprintf("invalid global id %d", globalID))
@@ -105,6 +161,8 @@ func (vm *VM) dropAsyncTasks() {
 			state.state = Value{}
 			state.pins = taskStatePins{}
 			task.State = nil
+		} else if state, ok := task.State.(*parallelState); ok && state != nil {
+			vm.dropValues(state.args)
 		} else if v, ok := task.State.(Value); ok {
 			vm.dropValue(v)
 		}
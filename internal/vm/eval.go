@@ -3,9 +3,9 @@ package vm
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"surge/internal/mir"
+	"surge/internal/token"
 	"surge/internal/types"
 	"surge/internal/vm/bignum"
 )
@@ -226,7 +226,7 @@ func (vm *VM) evalOperand(frame *Frame, op *mir.Operand) (Value, *VMError) {
 		return MakeRef(loc, op.Type), nil
 
 	case mir.OperandAddrOfMut:
-		loc, vmErr := vm.EvalPlace(frame, op.Place)
+		loc, vmErr := vm.evalPlaceForWrite(frame, op.Place)
 		if vmErr != nil {
 			return Value{}, vmErr
 		}
@@ -318,35 +318,5 @@ func (vm *VM) evalConst(c *mir.Const) Value {
 }
 
 func unescapeStringLiteral(raw string) string {
-	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
-		raw = raw[1 : len(raw)-1]
-	}
-	var sb strings.Builder
-	sb.Grow(len(raw))
-	for i := 0; i < len(raw); i++ {
-		ch := raw[i]
-		if ch != '\\' {
-			sb.WriteByte(ch)
-			continue
-		}
-		if i+1 >= len(raw) {
-			break
-		}
-		i++
-		switch raw[i] {
-		case '\\':
-			sb.WriteByte('\\')
-		case '"':
-			sb.WriteByte('"')
-		case 'n':
-			sb.WriteByte('\n')
-		case 't':
-			sb.WriteByte('\t')
-		case 'r':
-			sb.WriteByte('\r')
-		default:
-			sb.WriteByte(raw[i])
-		}
-	}
-	return sb.String()
+	return token.UnquoteString(raw)
 }
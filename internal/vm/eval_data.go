@@ -266,6 +266,13 @@ func (vm *VM) evalStructLit(frame *Frame, lit *mir.StructLit) (Value, *VMError)
 	for i := range fields {
 		fields[i] = Value{Kind: VKInvalid}
 	}
+	var base Value
+	if lit.HasBase {
+		base, vmErr = vm.evalOperand(frame, &lit.Base)
+		if vmErr != nil {
+			return Value{}, vmErr
+		}
+	}
 	for i := range lit.Fields {
 		f := &lit.Fields[i]
 		val, vmErr := vm.evalOperand(frame, &f.Value)
@@ -278,10 +285,51 @@ func (vm *VM) evalStructLit(frame *Frame, lit *mir.StructLit) (Value, *VMError)
 		}
 		fields[idx] = val
 	}
+	if lit.HasBase {
+		if vmErr := vm.fillStructSpreadFields(base, fields); vmErr != nil {
+			vm.dropValues(fields)
+			return Value{}, vmErr
+		}
+	}
 	h := vm.Heap.AllocStruct(layout.TypeID, fields)
 	return MakeHandleStruct(h, lit.TypeID), nil
 }
 
+// fillStructSpreadFields заполняет поля, не указанные в `{ ..base, ... }`, копиями полей base.
+// base потребляется.
+func (vm *VM) fillStructSpreadFields(base Value, fields []Value) *VMError {
+	defer vm.dropValue(base)
+	target := base
+	if base.Kind == VKRef || base.Kind == VKRefMut {
+		v, vmErr := vm.loadLocationRaw(base.Loc)
+		if vmErr != nil {
+			return vmErr
+		}
+		target = v
+	}
+	if target.Kind != VKHandleStruct {
+		return vm.eb.typeMismatch("struct", target.Kind.String())
+	}
+	sobj := vm.Heap.Get(target.H)
+	if sobj == nil {
+		return vm.eb.makeError(PanicOutOfBounds, "invalid struct handle")
+	}
+	if sobj.Kind != OKStruct || len(sobj.Fields) != len(fields) {
+		return vm.eb.typeMismatch("struct", fmt.Sprintf("%v", sobj.Kind))
+	}
+	for i := range fields {
+		if fields[i].Kind != VKInvalid {
+			continue
+		}
+		v, vmErr := vm.cloneForShare(sobj.Fields[i])
+		if vmErr != nil {
+			return vmErr
+		}
+		fields[i] = v
+	}
+	return nil
+}
+
 func (vm *VM) evalFieldAccess(frame *Frame, fa *mir.FieldAccess) (Value, *VMError) {
 	if fa == nil {
 		return Value{}, vm.eb.makeError(PanicUnimplemented, "nil field access")
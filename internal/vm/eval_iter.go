@@ -3,6 +3,7 @@ package vm
 import (
 	"fmt"
 
+	"surge/internal/checked"
 	"surge/internal/mir"
 	"surge/internal/types"
 	"surge/internal/vm/bignum"
@@ -246,7 +247,7 @@ func (vm *VM) rangeValueAddOne(cur Value, elemType types.TypeID) (Value, *VMErro
 	}
 	switch cur.Kind {
 	case VKInt:
-		res, ok := AddInt64Checked(cur.Int, 1)
+		res, ok := checked.AddInt64(cur.Int, 1)
 		if !ok {
 			if vm.isUnboundedInt(elemType) {
 				base := bignum.IntFromInt64(cur.Int)
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"surge/internal/ast"
+	"surge/internal/checked"
 	"surge/internal/types"
 	"surge/internal/vm/bignum"
 )
@@ -162,7 +163,7 @@ func (vm *VM) evalUnaryMinus(operand Value) (Value, *VMError) {
 		}
 		minVal := int64(math.MinInt64)
 		if width != types.WidthAny {
-			if minValRange, _, ok := intRangeForWidth(width); ok {
+			if minValRange, _, ok := checked.IntRange(width); ok {
 				minVal = minValRange
 			}
 		}
@@ -170,7 +171,7 @@ func (vm *VM) evalUnaryMinus(operand Value) (Value, *VMError) {
 			return Value{}, vm.eb.intOverflow()
 		}
 		res := -operand.Int
-		if !checkSignedWidth(res, width) {
+		if !checked.FitsSigned(res, width) {
 			return Value{}, vm.eb.intOverflow()
 		}
 		return MakeInt(res, operand.TypeID), nil
@@ -3,8 +3,8 @@ package vm
 import (
 	"fmt"
 	"math"
-	"math/bits"
 
+	"surge/internal/checked"
 	"surge/internal/types"
 	"surge/internal/vm/bignum"
 )
@@ -124,14 +124,14 @@ func (vm *VM) evalIntAdd(left, right Value) (Value, *VMError) {
 		return Value{}, vm.eb.typeMismatch("numeric", fmt.Sprintf("%s and %s", left.Kind, right.Kind))
 	}
 	if kind == types.KindUint {
-		sum, carry := bits.Add64(asUint64(left.Int), asUint64(right.Int), 0)
-		if carry != 0 || !checkUnsignedWidth(sum, width) {
+		sum, ok := checked.AddUint64(asUint64(left.Int), asUint64(right.Int))
+		if !ok || !checked.FitsUnsigned(sum, width) {
 			return Value{}, vm.eb.intOverflow()
 		}
 		return MakeInt(asInt64(sum), left.TypeID), nil
 	}
-	res, ok := AddInt64Checked(left.Int, right.Int)
-	if !ok || !checkSignedWidth(res, width) {
+	res, ok := checked.AddInt64(left.Int, right.Int)
+	if !ok || !checked.FitsSigned(res, width) {
 		return Value{}, vm.eb.intOverflow()
 	}
 	return MakeInt(res, left.TypeID), nil
@@ -210,19 +210,14 @@ func (vm *VM) evalIntSub(left, right Value) (Value, *VMError) {
 		return Value{}, vm.eb.typeMismatch("numeric", fmt.Sprintf("%s and %s", left.Kind, right.Kind))
 	}
 	if kind == types.KindUint {
-		ua := asUint64(left.Int)
-		ub := asUint64(right.Int)
-		if ua < ub {
-			return Value{}, vm.eb.intOverflow()
-		}
-		res := ua - ub
-		if !checkUnsignedWidth(res, width) {
+		res, ok := checked.SubUint64(asUint64(left.Int), asUint64(right.Int))
+		if !ok || !checked.FitsUnsigned(res, width) {
 			return Value{}, vm.eb.intOverflow()
 		}
 		return MakeInt(asInt64(res), left.TypeID), nil
 	}
-	res, ok := SubInt64Checked(left.Int, right.Int)
-	if !ok || !checkSignedWidth(res, width) {
+	res, ok := checked.SubInt64(left.Int, right.Int)
+	if !ok || !checked.FitsSigned(res, width) {
 		return Value{}, vm.eb.intOverflow()
 	}
 	return MakeInt(res, left.TypeID), nil
@@ -307,14 +302,14 @@ func (vm *VM) evalIntMul(left, right Value) (Value, *VMError) {
 		return Value{}, vm.eb.typeMismatch("numeric", fmt.Sprintf("%s and %s", left.Kind, right.Kind))
 	}
 	if kind == types.KindUint {
-		hi, lo := bits.Mul64(asUint64(left.Int), asUint64(right.Int))
-		if hi != 0 || !checkUnsignedWidth(lo, width) {
+		res, ok := checked.MulUint64(asUint64(left.Int), asUint64(right.Int))
+		if !ok || !checked.FitsUnsigned(res, width) {
 			return Value{}, vm.eb.intOverflow()
 		}
-		return MakeInt(asInt64(lo), left.TypeID), nil
+		return MakeInt(asInt64(res), left.TypeID), nil
 	}
-	res, ok := MulInt64Checked(left.Int, right.Int)
-	if !ok || !checkSignedWidth(res, width) {
+	res, ok := checked.MulInt64(left.Int, right.Int)
+	if !ok || !checked.FitsSigned(res, width) {
 		return Value{}, vm.eb.intOverflow()
 	}
 	return MakeInt(res, left.TypeID), nil
@@ -399,7 +394,7 @@ func (vm *VM) evalIntDiv(left, right Value) (Value, *VMError) {
 			return Value{}, vm.eb.divisionByZero()
 		}
 		res := ua / ub
-		if !checkUnsignedWidth(res, width) {
+		if !checked.FitsUnsigned(res, width) {
 			return Value{}, vm.eb.intOverflow()
 		}
 		return MakeInt(asInt64(res), left.TypeID), nil
@@ -409,7 +404,7 @@ func (vm *VM) evalIntDiv(left, right Value) (Value, *VMError) {
 	}
 	minVal := int64(math.MinInt64)
 	if width != types.WidthAny {
-		if minValRange, _, ok := intRangeForWidth(width); ok {
+		if minValRange, _, ok := checked.IntRange(width); ok {
 			minVal = minValRange
 		}
 	}
@@ -514,7 +509,7 @@ func (vm *VM) evalIntMod(left, right Value) (Value, *VMError) {
 			return Value{}, vm.eb.divisionByZero()
 		}
 		res := ua % ub
-		if !checkUnsignedWidth(res, width) {
+		if !checked.FitsUnsigned(res, width) {
 			return Value{}, vm.eb.intOverflow()
 		}
 		return MakeInt(asInt64(res), left.TypeID), nil
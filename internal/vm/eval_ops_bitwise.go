@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 
+	"surge/internal/checked"
 	"surge/internal/types"
 	"surge/internal/vm/bignum"
 )
@@ -198,7 +199,7 @@ func (vm *VM) evalIntShiftLeft(left, right Value) (Value, *VMError) {
 	shift := uint(right.Int)
 	if kind == types.KindUint {
 		val := asUint64(left.Int)
-		if maxVal, ok := uintMaxForWidth(width); ok {
+		if maxVal, ok := checked.UintMax(width); ok {
 			if shift > 0 && val > maxVal>>shift {
 				return Value{}, vm.eb.intOverflow()
 			}
@@ -210,7 +211,7 @@ func (vm *VM) evalIntShiftLeft(left, right Value) (Value, *VMError) {
 	minVal := int64(math.MinInt64)
 	maxVal := int64(math.MaxInt64)
 	if width != types.WidthAny {
-		if minValRange, maxValRange, ok := intRangeForWidth(width); ok {
+		if minValRange, maxValRange, ok := checked.IntRange(width); ok {
 			minVal = minValRange
 			maxVal = maxValRange
 		}
@@ -221,7 +222,7 @@ func (vm *VM) evalIntShiftLeft(left, right Value) (Value, *VMError) {
 		}
 	}
 	res := left.Int << shift
-	if !checkSignedWidth(res, width) {
+	if !checked.FitsSigned(res, width) {
 		return Value{}, vm.eb.intOverflow()
 	}
 	return MakeInt(res, left.TypeID), nil
@@ -54,6 +54,9 @@ func (vm *VM) evalEqual(left, right Value) (Value, *VMError) {
 			return Value{}, vm.eb.makeError(PanicOutOfBounds, "invalid string handle")
 		}
 		result = vm.stringBytes(lObj) == vm.stringBytes(rObj)
+	case VKWeak:
+		// слабые ссылки равны, если указывают на один объект, даже освобождённый
+		result = left.H == right.H
 	default:
 		result = left.H == right.H
 	}
@@ -108,6 +111,8 @@ func (vm *VM) evalNotEqual(left, right Value) (Value, *VMError) {
 			return Value{}, vm.eb.makeError(PanicOutOfBounds, "invalid string handle")
 		}
 		result = vm.stringBytes(lObj) != vm.stringBytes(rObj)
+	case VKWeak:
+		result = left.H != right.H
 	default:
 		result = left.H != right.H
 	}
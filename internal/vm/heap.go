@@ -15,6 +15,10 @@ type Heap struct {
 	nextAllocID uint64
 	objs        map[Handle]*Object
 
+	// Счётчики аллокаций и освобождений по видам объектов, см. Heap.Stats.
+	kindAllocs [objectKindCount]uint64
+	kindFrees  [objectKindCount]uint64
+
 	vm *VM
 }
 
@@ -46,13 +50,32 @@ func (h *Heap) alloc(kind ObjectKind, typeID types.TypeID) (Handle, *Object) {
 		AllocID: allocID,
 	}
 	h.objs[handle] = obj
+	if int(kind) < objectKindCount {
+		h.kindAllocs[kind]++
+	}
 	if h.vm != nil {
 		h.vm.heapCounters.allocCount++
 		h.vm.heapCounters.rcIncrCount++
+		if vmErr := h.vm.checkHeapLimits(); vmErr != nil {
+			panic(vmErr)
+		}
 	}
 	return handle, obj
 }
 
+// layoutAlign returns the layout alignment the native backend would pass to
+// rt_alloc for typeID, so @align(N) is visible on VM objects too.
+func (h *Heap) layoutAlign(typeID types.TypeID) int {
+	if h.vm == nil || h.vm.Layout == nil || typeID == types.NoTypeID {
+		return 0
+	}
+	align, err := h.vm.Layout.AlignOf(typeID)
+	if err != nil {
+		return 0
+	}
+	return align
+}
+
 // AllocString allocates a string object on the heap.
 func (h *Heap) AllocString(typeID types.TypeID, s string) Handle {
 	handle, obj := h.alloc(OKString, typeID)
@@ -227,6 +250,7 @@ func (h *Heap) AllocMap(typeID types.TypeID) Handle {
 // AllocStruct allocates a struct object on the heap.
 func (h *Heap) AllocStruct(typeID types.TypeID, fields []Value) Handle {
 	handle, obj := h.alloc(OKStruct, typeID)
+	obj.Align = h.layoutAlign(typeID)
 	obj.Fields = append([]Value(nil), fields...)
 	if h.vm != nil && h.vm.Trace != nil {
 		h.vm.Trace.TraceHeapAlloc(obj.Kind, handle, obj)
@@ -237,6 +261,7 @@ func (h *Heap) AllocStruct(typeID types.TypeID, fields []Value) Handle {
 // AllocTag allocates a tagged union object on the heap.
 func (h *Heap) AllocTag(typeID types.TypeID, tagSym symbols.SymbolID, fields []Value) Handle {
 	handle, obj := h.alloc(OKTag, typeID)
+	obj.Align = h.layoutAlign(typeID)
 	obj.Tag.TagSym = tagSym
 	obj.Tag.Fields = append([]Value(nil), fields...)
 	if h.vm != nil && h.vm.Trace != nil {
@@ -361,6 +386,9 @@ func (h *Heap) Free(handle Handle) {
 		h.panic(PanicUnimplemented, fmt.Sprintf("free called with non-zero refcount: handle %d rc=%d (alloc=%d)", handle, obj.RefCount, obj.AllocID))
 	}
 
+	if int(obj.Kind) < objectKindCount {
+		h.kindFrees[obj.Kind]++
+	}
 	if h.vm != nil {
 		h.vm.heapCounters.freeCount++
 	}
@@ -0,0 +1,37 @@
+package vm
+
+import (
+	"testing"
+
+	"surge/internal/source"
+	"surge/internal/types"
+)
+
+func TestHeapRecordsLayoutAlignOverride(t *testing.T) {
+	vm := New(nil, NewTestRuntime(nil, ""), source.NewFileSet(), types.NewInterner(), nil)
+	names := source.NewInterner()
+	builtins := vm.Types.Builtins()
+
+	plain := vm.Types.RegisterStruct(names.Intern("Plain"), source.Span{})
+	line := vm.Types.RegisterStruct(names.Intern("Line"), source.Span{})
+	fields := []types.StructField{
+		{Name: names.Intern("a"), Type: builtins.Uint8},
+		{Name: names.Intern("b"), Type: builtins.Uint32},
+	}
+	vm.Types.SetStructFields(plain, fields)
+	vm.Types.SetStructFields(line, fields)
+	align := 64
+	vm.Types.SetTypeLayoutAttrs(line, types.LayoutAttrs{AlignOverride: &align})
+
+	for typeID, want := range map[types.TypeID]int{plain: 4, line: 64} {
+		h := vm.Heap.AllocStruct(typeID, []Value{MakeInt(1, builtins.Uint8), MakeInt(2, builtins.Uint32)})
+		if got := vm.Heap.Get(h).Align; got != want {
+			t.Fatalf("type#%d: expected object align %d, got %d", typeID, want, got)
+		}
+		layoutAlign, err := vm.Layout.AlignOf(typeID)
+		if err != nil || layoutAlign != want {
+			t.Fatalf("type#%d: expected layout align %d, got %d (%v)", typeID, want, layoutAlign, err)
+		}
+		vm.Heap.Release(h)
+	}
+}
@@ -23,6 +23,61 @@ type heapStatsSnapshot struct {
 	rcDecrCount uint64
 }
 
+// HeapStats is a snapshot of VM heap usage. Objects and raw memory blocks are
+// counted together in the totals; ByKind lists heap objects only.
+type HeapStats struct {
+	Allocs      uint64 // allocations since the VM was created
+	Frees       uint64
+	LiveObjects uint64
+	LiveBytes   uint64
+	ByKind      map[ObjectKind]HeapKindStats
+}
+
+// HeapKindStats counts the heap objects of one kind.
+type HeapKindStats struct {
+	Allocs    uint64
+	Frees     uint64
+	Live      uint64
+	LiveBytes uint64
+}
+
+// HeapStats returns the current heap usage. It walks the whole heap, so it is
+// meant for diagnostics rather than hot paths.
+func (vm *VM) HeapStats() HeapStats {
+	snap := vm.heapStatsSnapshot()
+	return HeapStats{
+		Allocs:      snap.allocCount,
+		Frees:       snap.freeCount,
+		LiveObjects: snap.liveBlocks,
+		LiveBytes:   snap.liveBytes,
+		ByKind:      vm.Heap.Stats().ByKind,
+	}
+}
+
+// heapBytesCheckInterval — как часто (в аллокациях) пересчитывается размер
+// кучи для MaxHeapBytes: точный подсчёт обходит все объекты.
+const heapBytesCheckInterval = 256
+
+// checkHeapLimits is called after every allocation. The object cap is exact;
+// the byte cap is checked every heapBytesCheckInterval allocations.
+func (vm *VM) checkHeapLimits() *VMError {
+	if vm.MaxHeapObjects <= 0 && vm.MaxHeapBytes <= 0 {
+		return nil
+	}
+	live := vm.heapCounters.allocCount - vm.heapCounters.freeCount
+	if vm.MaxHeapObjects > 0 && live > safeUint64FromInt(vm.MaxHeapObjects) {
+		stats := vm.HeapStats()
+		return vm.eb.makeError(PanicOutOfMemory, fmt.Sprintf("out of memory: %d live heap objects exceed the limit of %d (%d bytes live)", stats.LiveObjects, vm.MaxHeapObjects, stats.LiveBytes))
+	}
+	if vm.MaxHeapBytes > 0 && vm.heapCounters.allocCount%heapBytesCheckInterval == 0 {
+		stats := vm.HeapStats()
+		if stats.LiveBytes > safeUint64FromInt(vm.MaxHeapBytes) {
+			return vm.eb.makeError(PanicOutOfMemory, fmt.Sprintf("out of memory: %d live heap bytes exceed the limit of %d (%d objects live)", stats.LiveBytes, vm.MaxHeapBytes, stats.LiveObjects))
+		}
+	}
+	return nil
+}
+
 func safeUint64FromInt(n int) uint64 {
 	if n <= 0 {
 		return 0
@@ -0,0 +1,86 @@
+package vm
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// heapReportMaxObjects limits how many live objects the heap report lists.
+const heapReportMaxObjects = 32
+
+// Stats returns the usage of heap objects: totals and a breakdown by object
+// kind. Raw memory blocks are not heap objects; VM.HeapStats adds them to the
+// totals.
+func (h *Heap) Stats() HeapStats {
+	stats := HeapStats{ByKind: make(map[ObjectKind]HeapKindStats)}
+	if h == nil {
+		return stats
+	}
+	h.initIfNeeded()
+	for k := range objectKindCount {
+		if h.kindAllocs[k] == 0 {
+			continue
+		}
+		stats.ByKind[ObjectKind(k)] = HeapKindStats{Allocs: h.kindAllocs[k], Frees: h.kindFrees[k]}
+		stats.Allocs += h.kindAllocs[k]
+		stats.Frees += h.kindFrees[k]
+	}
+	for handle := Handle(1); handle < h.next; handle++ {
+		obj, ok := h.lookup(handle)
+		if !ok || obj == nil || obj.Freed || obj.RefCount == 0 {
+			continue
+		}
+		size := h.vm.heapObjectBytes(obj)
+		kind := stats.ByKind[obj.Kind]
+		kind.Live++
+		kind.LiveBytes += size
+		stats.ByKind[obj.Kind] = kind
+		stats.LiveObjects++
+		stats.LiveBytes += size
+	}
+	return stats
+}
+
+// WriteHeapReport writes heap usage by object kind and lists the objects that
+// are still alive. With VM.HeapReport set it runs when the program exits,
+// after frames and globals are dropped and before the leak check.
+func (vm *VM) WriteHeapReport(w io.Writer) error {
+	stats := vm.Heap.Stats()
+	if _, err := fmt.Fprintf(w, "heap report: %d allocated, %d freed, %d live objects (%d bytes)\n",
+		stats.Allocs, stats.Frees, stats.LiveObjects, stats.LiveBytes); err != nil {
+		return err
+	}
+	kinds := make([]ObjectKind, 0, len(stats.ByKind))
+	for kind := range stats.ByKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	for _, kind := range kinds {
+		ks := stats.ByKind[kind]
+		if _, err := fmt.Fprintf(w, "  %-12s %d allocated, %d freed, %d live\n",
+			vm.objectKindLabel(kind)+":", ks.Allocs, ks.Frees, ks.Live); err != nil {
+			return err
+		}
+	}
+	if stats.LiveObjects == 0 || vm.Heap == nil {
+		return nil
+	}
+	listed := uint64(0)
+	for handle := Handle(1); handle < vm.Heap.next && listed < heapReportMaxObjects; handle++ {
+		obj, ok := vm.Heap.lookup(handle)
+		if !ok || obj == nil || obj.Freed || obj.RefCount == 0 {
+			continue
+		}
+		listed++
+		if _, err := fmt.Fprintf(w, "  live #%d (alloc %d): %s\n", handle, obj.AllocID, vm.objectSummary(obj)); err != nil {
+			return err
+		}
+	}
+	if rest := stats.LiveObjects - listed; rest > 0 {
+		if _, err := fmt.Fprintf(w, "  ... %d more live objects\n", rest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
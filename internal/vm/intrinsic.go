@@ -176,6 +176,11 @@ func (vm *VM) callIntrinsic(frame *Frame, call *mir.CallInstr, writes *[]LocalWr
 	case "close":
 		return vm.handleChannelClose(frame, call)
 
+	case "downgrade":
+		return vm.handleDowngrade(frame, call, writes)
+	case "upgrade":
+		return vm.handleWeakUpgrade(frame, call, writes)
+
 	case "rt_write_stdout":
 		return vm.handleWriteStdout(frame, call, writes)
 	case "rt_write_stderr":
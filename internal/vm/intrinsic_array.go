@@ -168,6 +168,12 @@ func (vm *VM) handleArrayGetMut(frame *Frame, call *mir.CallInstr, writes *[]Loc
 		if loadErr != nil {
 			return loadErr
 		}
+		if arrVal.Kind == VKRefMut {
+			loaded, loadErr = vm.unshareArrayForWrite(arrVal.Loc, loaded)
+			if loadErr != nil {
+				return loadErr
+			}
+		}
 		arrVal = loaded
 	}
 	if arrVal.Kind != VKHandleArray {
@@ -218,7 +218,7 @@ func (vm *VM) handleTimeout(frame *Frame, call *mir.CallInstr, writes *[]LocalWr
 			return nil
 		}
 		if !ran {
-			return vm.eb.makeError(PanicUnimplemented, "async deadlock")
+			return vm.deadlockError()
 		}
 	}
 }
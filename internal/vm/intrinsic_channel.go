@@ -103,7 +103,7 @@ func (vm *VM) handleChannelSend(frame *Frame, call *mir.CallInstr) *VMError {
 		}
 		if !ran {
 			vm.dropValue(val)
-			return vm.eb.makeError(PanicUnimplemented, "async deadlock")
+			return vm.deadlockError()
 		}
 	}
 }
@@ -190,7 +190,7 @@ func (vm *VM) handleChannelRecv(frame *Frame, call *mir.CallInstr, writes *[]Loc
 			return nil
 		}
 		if !ran {
-			return vm.eb.makeError(PanicUnimplemented, "async deadlock")
+			return vm.deadlockError()
 		}
 	}
 }
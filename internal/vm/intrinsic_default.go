@@ -30,6 +30,9 @@ func (vm *VM) handleDefault(frame *Frame, call *mir.CallInstr, writes *[]LocalWr
 	if targetType == types.NoTypeID {
 		return vm.eb.makeError(PanicUnimplemented, "invalid type arguments for default")
 	}
+	if vm.Types != nil && vm.Types.ZeroValueKind(targetType) == types.ZeroNone {
+		return vm.eb.makeError(PanicTypeMismatch, fmt.Sprintf("default is not defined for %s", types.Label(vm.Types, targetType)))
+	}
 	val, vmErr := vm.defaultValue(targetType)
 	if vmErr != nil {
 		return vmErr
@@ -125,6 +128,8 @@ func (vm *VM) defaultValue(typeID types.TypeID) (Value, *VMError) {
 			return vm.defaultArray(typeID, elem, int(length))
 		}
 		return vm.defaultStruct(typeID)
+	case types.KindTuple:
+		return vm.defaultTuple(typeID)
 	case types.KindUnion:
 		layout, vmErr := vm.tagLayoutFor(typeID)
 		if vmErr != nil {
@@ -163,6 +168,26 @@ func (vm *VM) defaultStruct(typeID types.TypeID) (Value, *VMError) {
 	return MakeHandleStruct(h, typeID), nil
 }
 
+func (vm *VM) defaultTuple(typeID types.TypeID) (Value, *VMError) {
+	info, ok := vm.Types.TupleInfo(typeID)
+	if !ok || info == nil {
+		return Value{}, vm.eb.makeError(PanicUnimplemented, fmt.Sprintf("missing tuple info for type#%d", typeID))
+	}
+	elems := make([]Value, 0, len(info.Elems))
+	for _, elemType := range info.Elems {
+		val, vmErr := vm.defaultValue(elemType)
+		if vmErr != nil {
+			for _, e := range elems {
+				vm.dropValue(e)
+			}
+			return Value{}, vmErr
+		}
+		elems = append(elems, val)
+	}
+	h := vm.Heap.AllocStruct(typeID, elems)
+	return MakeHandleStruct(h, typeID), nil
+}
+
 func (vm *VM) defaultArray(typeID, elemType types.TypeID, length int) (Value, *VMError) {
 	if length < 0 {
 		return Value{}, vm.eb.makeError(PanicInvalidNumericConversion, "array length out of range")
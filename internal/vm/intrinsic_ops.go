@@ -102,7 +102,8 @@ func (vm *VM) handleLen(frame *Frame, call *mir.CallInstr, writes *[]LocalWrite)
 	return nil
 }
 
-// handleClone handles the __clone intrinsic.
+// handleClone handles the __clone intrinsic: a deep copy of the receiver.
+// Nested values whose type defines __clone are copied by calling it.
 func (vm *VM) handleClone(frame *Frame, call *mir.CallInstr, writes *[]LocalWrite) *VMError {
 	if !call.HasDst {
 		return vm.eb.makeError(PanicTypeMismatch, "__clone requires a destination")
@@ -122,10 +123,8 @@ func (vm *VM) handleClone(frame *Frame, call *mir.CallInstr, writes *[]LocalWrit
 		}
 		arg = v
 	}
-	if arg.Kind != VKHandleString {
-		return vm.eb.typeMismatch("string", arg.Kind.String())
-	}
-	clone, vmErr := vm.cloneForShare(arg)
+	// Сам получатель копируем встроенно: его __clone — это и есть этот вызов.
+	clone, vmErr := vm.cloneDeep(arg, Location{}, false)
 	if vmErr != nil {
 		return vmErr
 	}
@@ -142,7 +141,8 @@ func (vm *VM) handleClone(frame *Frame, call *mir.CallInstr, writes *[]LocalWrit
 	return nil
 }
 
-// handleCloneValue handles the clone intrinsic for Copy types.
+// handleCloneValue handles the clone intrinsic for Copy types and for types
+// cloned by the built-in deep copy.
 func (vm *VM) handleCloneValue(frame *Frame, call *mir.CallInstr, writes *[]LocalWrite) *VMError {
 	if !call.HasDst {
 		return vm.eb.makeError(PanicTypeMismatch, "clone requires a destination")
@@ -174,21 +174,23 @@ func (vm *VM) handleCloneValue(frame *Frame, call *mir.CallInstr, writes *[]Loca
 		}
 		replaceArg(v, false)
 	}
+	dstLocal := call.Dst.Local
+	dstType := frame.Locals[dstLocal].TypeID
 	if arg.IsHeap() {
+		// Типы без __clone (sema пропускает только структурно клонируемые) копируются глубоко.
+		copyType := vm.Types == nil || dstType == types.NoTypeID || vm.Types.IsCopy(resolveAlias(vm.Types, dstType))
+		var clone Value
 		var cloneErr *VMError
-		clone, cloneErr := vm.cloneForShare(arg)
+		if copyType {
+			clone, cloneErr = vm.cloneForShare(arg)
+		} else {
+			clone, cloneErr = vm.cloneDeep(arg, Location{}, false)
+		}
 		if cloneErr != nil {
 			return cloneErr
 		}
 		replaceArg(clone, true)
 	}
-	dstLocal := call.Dst.Local
-	dstType := frame.Locals[dstLocal].TypeID
-	if vm.Types != nil && dstType != types.NoTypeID {
-		if !vm.Types.IsCopy(resolveAlias(vm.Types, dstType)) {
-			return vm.eb.makeError(PanicTypeMismatch, "clone requires a Copy type")
-		}
-	}
 	arg.TypeID = dstType
 	if vmErr := vm.writeLocal(frame, dstLocal, arg); vmErr != nil {
 		return vmErr
@@ -272,6 +274,9 @@ func (vm *VM) handleIndexSet(frame *Frame, call *mir.CallInstr, writes *[]LocalW
 
 	if objVal.Kind == VKRef || objVal.Kind == VKRefMut {
 		v, loadErr := vm.loadLocationRaw(objVal.Loc)
+		if loadErr == nil && objVal.Kind == VKRefMut {
+			v, loadErr = vm.unshareArrayForWrite(objVal.Loc, v)
+		}
 		if loadErr != nil {
 			vm.dropValue(val)
 			return loadErr
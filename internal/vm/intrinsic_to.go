@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 
+	"surge/internal/checked"
 	"surge/internal/types"
 	"surge/internal/vm/bignum"
 )
@@ -99,6 +100,13 @@ func (vm *VM) evalIntrinsicTo(src Value, dstType types.TypeID) (Value, *VMError)
 			}
 			h := vm.Heap.AllocString(strTy, bignum.FormatInt(bignum.IntFromInt64(src.Int)))
 			return MakeHandleString(h, strTy), nil
+		case VKHandleArray, VKHandleStruct, VKHandleTag, VKNothing, VKWeak:
+			s, vmErr := vm.debugString(src)
+			if vmErr != nil {
+				return Value{}, vmErr
+			}
+			h := vm.Heap.AllocString(strTy, s)
+			return MakeHandleString(h, strTy), nil
 		default:
 			return Value{}, vm.eb.unimplemented("__to to string")
 		}
@@ -109,7 +117,7 @@ func (vm *VM) evalIntrinsicTo(src Value, dstType types.TypeID) (Value, *VMError)
 			if vmErr != nil {
 				return Value{}, vmErr
 			}
-			minVal, maxVal, ok := intRangeForWidth(dstTT.Width)
+			minVal, maxVal, ok := checked.IntRange(dstTT.Width)
 			if !ok {
 				return Value{}, vm.eb.unimplemented("__to to fixed-width int")
 			}
@@ -167,7 +175,7 @@ func (vm *VM) evalIntrinsicTo(src Value, dstType types.TypeID) (Value, *VMError)
 			if vmErr != nil {
 				return Value{}, vmErr
 			}
-			maxVal, ok := uintMaxForWidth(dstTT.Width)
+			maxVal, ok := checked.UintMax(dstTT.Width)
 			if !ok {
 				return Value{}, vm.eb.unimplemented("__to to fixed-width uint")
 			}
@@ -309,36 +317,6 @@ func (vm *VM) evalIntrinsicTo(src Value, dstType types.TypeID) (Value, *VMError)
 	}
 }
 
-func intRangeForWidth(width types.Width) (minVal, maxVal int64, ok bool) {
-	switch width {
-	case types.Width8:
-		return math.MinInt8, math.MaxInt8, true
-	case types.Width16:
-		return math.MinInt16, math.MaxInt16, true
-	case types.Width32:
-		return math.MinInt32, math.MaxInt32, true
-	case types.Width64:
-		return math.MinInt64, math.MaxInt64, true
-	default:
-		return 0, 0, false
-	}
-}
-
-func uintMaxForWidth(width types.Width) (uint64, bool) {
-	switch width {
-	case types.Width8:
-		return math.MaxUint8, true
-	case types.Width16:
-		return math.MaxUint16, true
-	case types.Width32:
-		return math.MaxUint32, true
-	case types.Width64:
-		return math.MaxUint64, true
-	default:
-		return 0, false
-	}
-}
-
 func (vm *VM) toInt64ForCast(src Value) (int64, *VMError) {
 	switch src.Kind {
 	case VKInt:
@@ -0,0 +1,143 @@
+package vm
+
+import (
+	"strconv"
+	"strings"
+
+	"surge/internal/types"
+)
+
+// debugString renders an aggregate in the debug format used by `to string` on
+// arrays, structs, tuples and tags (see docs/LANGUAGE.md):
+//
+//	[1, 2, 3]
+//	Point { x: 1, y: 2 }
+//	(1, "a"), (1,)
+//	Some(3), nothing
+//
+// Nested strings are quoted; nested values always use this format, even when
+// their type declares its own __to(string).
+func (vm *VM) debugString(v Value) (string, *VMError) {
+	var b strings.Builder
+	if vmErr := vm.writeDebugValue(&b, v, false); vmErr != nil {
+		return "", vmErr
+	}
+	return b.String(), nil
+}
+
+func (vm *VM) writeDebugValue(b *strings.Builder, v Value, nested bool) *VMError {
+	switch v.Kind {
+	case VKNothing:
+		b.WriteString("nothing")
+	case VKHandleString:
+		s := vm.stringBytes(vm.Heap.Get(v.H))
+		if nested {
+			s = strconv.Quote(s)
+		}
+		b.WriteString(s)
+	case VKHandleArray:
+		view, vmErr := vm.arrayViewFromHandle(v.H)
+		if vmErr != nil {
+			return vmErr
+		}
+		b.WriteByte('[')
+		for i := range view.length {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			if vmErr := vm.writeDebugValue(b, view.baseObj.Arr[view.start+i], true); vmErr != nil {
+				return vmErr
+			}
+		}
+		b.WriteByte(']')
+	case VKHandleStruct:
+		obj := vm.Heap.Get(v.H)
+		if vm.isTupleObject(obj) {
+			return vm.writeDebugTuple(b, obj.Fields)
+		}
+		layout, vmErr := vm.layouts.Struct(obj.TypeID)
+		if vmErr != nil {
+			return vmErr
+		}
+		b.WriteString(typeLabel(vm.Types, obj.TypeID))
+		if len(obj.Fields) == 0 {
+			b.WriteString(" {}")
+			return nil
+		}
+		b.WriteString(" { ")
+		for i, field := range obj.Fields {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			if i < len(layout.FieldNames) {
+				b.WriteString(layout.FieldNames[i])
+				b.WriteString(": ")
+			}
+			if vmErr := vm.writeDebugValue(b, field, true); vmErr != nil {
+				return vmErr
+			}
+		}
+		b.WriteString(" }")
+	case VKHandleTag:
+		obj := vm.Heap.Get(v.H)
+		b.WriteString(vm.tagName(obj))
+		if len(obj.Tag.Fields) == 0 {
+			return nil
+		}
+		b.WriteByte('(')
+		for i, field := range obj.Tag.Fields {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			if vmErr := vm.writeDebugValue(b, field, true); vmErr != nil {
+				return vmErr
+			}
+		}
+		b.WriteByte(')')
+	case VKWeak:
+		// содержимое не печатается: слабая ссылка не должна продлевать жизнь объекту
+		b.WriteString("weak(")
+		if obj, ok := vm.Heap.lookup(v.H); ok && !obj.Freed && obj.RefCount != 0 {
+			b.WriteString("alive")
+		} else {
+			b.WriteString("dropped")
+		}
+		b.WriteByte(')')
+	default:
+		strTy := vm.Types.Builtins().String
+		out, vmErr := vm.evalIntrinsicTo(v, strTy)
+		if vmErr != nil {
+			return vmErr
+		}
+		b.WriteString(vm.stringBytes(vm.Heap.Get(out.H)))
+		vm.dropValue(out)
+	}
+	return nil
+}
+
+// isTupleObject reports whether a struct object holds a tuple. Tuple literals
+// are allocated without a type, so an untyped struct object is a tuple too.
+func (vm *VM) isTupleObject(obj *Object) bool {
+	if obj.TypeID == types.NoTypeID {
+		return true
+	}
+	tt, ok := vm.Types.Lookup(vm.valueType(obj.TypeID))
+	return ok && tt.Kind == types.KindTuple
+}
+
+func (vm *VM) writeDebugTuple(b *strings.Builder, elems []Value) *VMError {
+	b.WriteByte('(')
+	for i, elem := range elems {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if vmErr := vm.writeDebugValue(b, elem, true); vmErr != nil {
+			return vmErr
+		}
+	}
+	if len(elems) == 1 {
+		b.WriteByte(',')
+	}
+	b.WriteByte(')')
+	return nil
+}
@@ -0,0 +1,147 @@
+package vm_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const buildEmitSource = `@entrypoint
+fn main() -> int {
+    print("built natively");
+    return 3;
+}
+`
+
+// writeBuildEmitProgram writes buildEmitSource into a fresh directory, so the
+// build output lands in its target/ instead of the repository's.
+func writeBuildEmitProgram(t *testing.T) (dir, file string) {
+	t.Helper()
+	dir = t.TempDir()
+	file = filepath.Join(dir, "emit_demo.sg")
+	if err := os.WriteFile(file, []byte(buildEmitSource), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	return dir, file
+}
+
+func runSurgeInDir(t *testing.T, dir, surge string, env []string, args ...string) (stdout, stderr string, exitCode int) {
+	t.Helper()
+	cmd := exec.Command(surge, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	return runCommand(t, cmd, "")
+}
+
+func TestBuildEmitLLRespectsTarget(t *testing.T) {
+	root := repoRoot(t)
+	surge := buildSurgeBinary(t, root)
+	dir, file := writeBuildEmitProgram(t)
+
+	stdout, stderr, code := runSurgeInDir(t, dir, surge, envWithStdlib(root), "build", "--emit=ll", "--target=aarch64-unknown-linux-gnu", file)
+	if code != 0 {
+		t.Fatalf("build failed (code=%d)\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	llPath := filepath.Join(dir, "target", "debug", "emit_demo.ll")
+	ir, err := os.ReadFile(llPath)
+	if err != nil {
+		t.Fatalf("read %s: %v", llPath, err)
+	}
+	if !strings.HasPrefix(string(ir), "target triple = \"aarch64-unknown-linux-gnu\"\n") {
+		t.Fatalf("unexpected IR preamble:\n%s", firstLines(string(ir), 3))
+	}
+	if !strings.Contains(string(ir), "define") {
+		t.Fatalf("IR has no function definitions:\n%s", firstLines(string(ir), 10))
+	}
+}
+
+func TestBuildRejectsTargetWithoutLayout(t *testing.T) {
+	root := repoRoot(t)
+	surge := buildSurgeBinary(t, root)
+	dir, file := writeBuildEmitProgram(t)
+
+	stdout, stderr, code := runSurgeInDir(t, dir, surge, envWithStdlib(root), "build", "--emit=ll", "--target=i686-linux-gnu", file)
+	if code == 0 {
+		t.Fatalf("expected build to fail\nstdout:\n%s\nstderr:\n%s", stdout, stderr)
+	}
+	if !strings.Contains(stderr, `unsupported target triple "i686-linux-gnu"`) {
+		t.Fatalf("unexpected stderr:\n%s", stderr)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "target", "debug", "emit_demo.ll")); err == nil {
+		t.Fatalf("build wrote IR for a target without a layout")
+	}
+}
+
+func TestBuildMissingToolchainReportsError(t *testing.T) {
+	root := repoRoot(t)
+	surge := buildSurgeBinary(t, root)
+	dir, file := writeBuildEmitProgram(t)
+
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range envWithStdlib(root) {
+		if !strings.HasPrefix(kv, "PATH=") {
+			env = append(env, kv)
+		}
+	}
+	env = append(env, "PATH="+t.TempDir())
+
+	for _, tc := range []struct {
+		emit string
+		want string
+	}{
+		{emit: "bin", want: "clang not found"},
+		{emit: "obj", want: "neither clang nor llc found"},
+	} {
+		t.Run(tc.emit, func(t *testing.T) {
+			_, stderr, code := runSurgeInDir(t, dir, surge, env, "build", "--emit="+tc.emit, file)
+			if code == 0 {
+				t.Fatalf("expected build to fail without a toolchain")
+			}
+			if !strings.Contains(stderr, tc.want) {
+				t.Fatalf("expected %q in stderr, got:\n%s", tc.want, stderr)
+			}
+			if strings.Contains(stderr, "panic") {
+				t.Fatalf("build panicked:\n%s", stderr)
+			}
+		})
+	}
+}
+
+func TestBuildEmitObjAndBin(t *testing.T) {
+	ensureLLVMToolchain(t)
+	root := repoRoot(t)
+	surge := buildSurgeBinary(t, root)
+	dir, file := writeBuildEmitProgram(t)
+	env := envWithStdlib(root)
+
+	stdout, stderr, code := runSurgeInDir(t, dir, surge, env, "build", "--emit=obj", file)
+	if code != 0 {
+		t.Fatalf("obj build failed (code=%d)\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	objPath := filepath.Join(dir, "target", "debug", "emit_demo.o")
+	if st, err := os.Stat(objPath); err != nil || st.Size() == 0 {
+		t.Fatalf("expected a non-empty object file at %s (err=%v)", objPath, err)
+	}
+
+	stdout, stderr, code = runSurgeInDir(t, dir, surge, env, "build", "--emit=bin", file)
+	if code != 0 {
+		t.Fatalf("bin build failed (code=%d)\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	binOut, binErr, binCode := runBinary(t, filepath.Join(dir, "target", "debug", "emit_demo"))
+	if binCode != 3 {
+		t.Fatalf("expected exit code 3, got %d\nstderr:\n%s", binCode, binErr)
+	}
+	if binOut != "built natively\n" {
+		t.Fatalf("unexpected stdout: %q", binOut)
+	}
+}
+
+func firstLines(s string, n int) string {
+	lines := strings.SplitN(s, "\n", n+1)
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return strings.Join(lines, "\n")
+}
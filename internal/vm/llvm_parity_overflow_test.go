@@ -0,0 +1,48 @@
+package vm_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLLVMParityIntOverflowPanics(t *testing.T) {
+	skipTimeoutTests(t)
+	root := repoRoot(t)
+
+	if _, err := exec.LookPath("clang"); err != nil {
+		t.Skip("clang not installed; skipping LLVM parity test")
+	}
+	if _, err := exec.LookPath("ar"); err != nil {
+		t.Skip("ar not installed; skipping LLVM parity test")
+	}
+
+	surge := buildSurgeBinary(t, root)
+	parityEnv := envForParity(root)
+	sgRel := filepath.ToSlash(filepath.Join("testdata", "llvm_parity", "int_overflow.sg"))
+
+	vmOut, vmErr, vmCode := runSurgeWithEnv(t, root, surge, parityEnv, "run", "--backend=vm", sgRel)
+
+	buildOut, buildErr, buildCode := runSurgeWithEnv(t, root, surge, parityEnv, "build", sgRel)
+	if buildCode != 0 {
+		t.Fatalf("build failed (code=%d)\nstdout:\n%s\nstderr:\n%s", buildCode, buildOut, buildErr)
+	}
+
+	binPath := filepath.Join(root, "target", "debug", "int_overflow")
+	llOut, llErr, llCode := runBinary(t, binPath)
+
+	if vmCode == 0 || llCode != vmCode {
+		t.Fatalf("exit code mismatch: vm=%d llvm=%d", vmCode, llCode)
+	}
+	if llOut != vmOut {
+		t.Fatalf("stdout mismatch:\n--- vm ---\n%s\n--- llvm ---\n%s", vmOut, llOut)
+	}
+	// VM дописывает к панике позицию и backtrace, нативный рантайм — нет.
+	const want = "panic VM1101: integer overflow"
+	vmFirst, _, _ := strings.Cut(vmErr, "\n")
+	llFirst, _, _ := strings.Cut(llErr, "\n")
+	if vmFirst != want || llFirst != want {
+		t.Fatalf("expected %q from both backends:\n--- vm ---\n%s\n--- llvm ---\n%s", want, vmErr, llErr)
+	}
+}
@@ -41,8 +41,10 @@ func TestLLVMParity(t *testing.T) {
 		{name: "exit_code", file: "exit_code.sg"},
 		{name: "panic", file: "panic.sg"},
 		{name: "string_concat", file: "string_concat.sg"},
+		{name: "debug_to_string", file: "debug_to_string.sg"},
 		{name: "from_str_fixed_width", file: "from_str_fixed_width.sg"},
 		{name: "array_range_indexing", file: "array_range_indexing.sg"},
+		{name: "range_for", file: "range_for.sg"},
 		{name: "byte_array_append_string", file: "byte_array_append_string.sg"},
 		{name: "stdlib_bytes", file: "stdlib_bytes.sg"},
 		{name: "tagged_switch", file: "tagged_switch.sg"},
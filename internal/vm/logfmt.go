@@ -218,6 +218,10 @@ func NewLogPanicEvent(vmErr *VMError, files *source.FileSet) LogPanicEvent {
 	}
 	bt := make([]string, 0, len(vmErr.Backtrace))
 	for _, f := range vmErr.Backtrace {
+		if f.Omitted > 0 {
+			bt = append(bt, fmt.Sprintf("... %d frames omitted", f.Omitted))
+			continue
+		}
 		bt = append(bt, fmt.Sprintf("%s@%s", f.FuncName, formatSpan(f.Span, files)))
 	}
 	return LogPanicEvent{
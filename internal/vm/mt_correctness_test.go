@@ -454,6 +454,48 @@ fn main() -> int {
 	runMTSource(t, source, 20*time.Second)
 }
 
+func TestMTParallelMapReduce(t *testing.T) {
+	ensureLLVMToolchain(t)
+	t.Parallel()
+
+	source := `@entrypoint
+fn main() -> int {
+    let mut xs: int[] = [];
+    let mut i = 0;
+    while i < 4096 {
+        xs.push(i);
+        i = i + 1;
+    }
+    let k = 3;
+    let ys = parallel map xs with (x) => x * k;
+    let total = parallel reduce ys with 0, (acc, y) => acc + y;
+    if total != 25159680 {
+        print("bad total " + (total to string));
+        return 1;
+    }
+    let mut j = 0;
+    while j < 4096 {
+        if ys[j] != j * 3 {
+            print("bad element " + (j to string));
+            return 1;
+        }
+        j = j + 1;
+    }
+    print("ok");
+    return 0;
+}
+`
+
+	runMTSource(t, source, 20*time.Second)
+}
+
+func TestMTSpawnCapturesAreIsolated(t *testing.T) {
+	ensureLLVMToolchain(t)
+	t.Parallel()
+
+	runMTSource(t, asyncCaptureIsolationSource, 20*time.Second)
+}
+
 func TestMTBlockingPool(t *testing.T) {
 	ensureLLVMToolchain(t)
 	t.Parallel()
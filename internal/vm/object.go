@@ -36,6 +36,9 @@ const (
 	OKRange
 )
 
+// objectKindCount is the number of ObjectKind values.
+const objectKindCount = int(OKRange) + 1
+
 // StringKind identifies the kind of string representation.
 type StringKind uint8
 
@@ -91,6 +94,7 @@ type Object struct {
 	HeapHeader
 	TypeID  types.TypeID
 	AllocID uint64
+	Align   int // layout alignment of TypeID for structs and tags; metadata only
 
 	Str           string
 	StrKind       StringKind
@@ -18,12 +18,14 @@ const (
 	PanicOutOfBounds          PanicCode = 1004 // VM1004: out of bounds
 	PanicUnsupportedIntrinsic PanicCode = 1005 // VM1005: unsupported intrinsic
 	PanicUnsupportedParseType PanicCode = 1006 // VM1006: unsupported parse type
+	PanicStackOverflow        PanicCode = 1007 // VM1007: call depth limit exceeded
 
 	PanicIntOverflow        PanicCode = 1101 // VM1101: integer overflow
 	PanicMemoryLeakDetected PanicCode = 1201 // VM1201: memory leak detected
 	PanicDoubleFree         PanicCode = 1202 // VM1202: double free
 	PanicInvalidHandle      PanicCode = 1203 // VM1203: invalid handle
 	PanicUseAfterFree       PanicCode = 1204 // VM1204: use after free
+	PanicOutOfMemory        PanicCode = 1205 // VM1205: heap limit exceeded
 
 	PanicSwitchTagMissingDefault   PanicCode = 2001 // VM2001: switch_tag missing default
 	PanicSwitchTagOnNonTag         PanicCode = 2002 // VM2002: switch_tag on non-tag value
@@ -52,6 +54,9 @@ const (
 
 	PanicRCUseAfterFree     PanicCode = 3301 // VM3301: use-after-free (RC heap)
 	PanicRCHeapLeakDetected PanicCode = 3302 // VM3302: heap leak detected (RC heap)
+	PanicDropWhileBorrowed  PanicCode = 3303 // VM3303: drop of a value with a live borrow
+
+	PanicDeadlock PanicCode = 3401 // VM3401: every async task is parked and nothing can wake them
 
 	PanicAsyncBackendNotImplemented PanicCode = 9001 // VMX9001: async backend not implemented
 )
@@ -68,6 +73,7 @@ func (c PanicCode) String() string {
 type BacktraceFrame struct {
 	FuncName string
 	Span     source.Span
+	Omitted  int // >0: placeholder for this many elided frames
 }
 
 // VMError represents a runtime panic in the VM.
@@ -98,8 +104,15 @@ func (p *VMError) FormatWithFiles(files *source.FileSet) string {
 	// Backtrace
 	if len(p.Backtrace) > 0 {
 		sb.WriteString("backtrace:\n")
-		for i, frame := range p.Backtrace {
-			fmt.Fprintf(&sb, "  %d: %s at %s\n", i, frame.FuncName, formatSpan(frame.Span, files))
+		depth := 0
+		for _, frame := range p.Backtrace {
+			if frame.Omitted > 0 {
+				fmt.Fprintf(&sb, "  ... %d frames omitted\n", frame.Omitted)
+				depth += frame.Omitted
+				continue
+			}
+			fmt.Fprintf(&sb, "  %d: %s at %s\n", depth, frame.FuncName, formatSpan(frame.Span, files))
+			depth++
 		}
 	}
 
@@ -161,6 +174,30 @@ func (eb *errorBuilder) makeError(code PanicCode, msg string) *VMError {
 	return e
 }
 
+// A stack overflow keeps only the innermost frames, which show the recursion,
+// and the outermost ones, which show where it was entered.
+const (
+	stackOverflowTopFrames    = 8
+	stackOverflowBottomFrames = 2
+)
+
+func (eb *errorBuilder) stackOverflow(callee *Frame, limit int) *VMError {
+	name := "<nil>"
+	if callee != nil && callee.Func != nil {
+		name = callee.Func.Name
+	}
+	e := eb.makeError(PanicStackOverflow, fmt.Sprintf("stack overflow: call to %s exceeds the limit of %d frames", name, limit))
+	omitted := len(e.Backtrace) - stackOverflowTopFrames - stackOverflowBottomFrames
+	if omitted > 1 {
+		short := make([]BacktraceFrame, 0, stackOverflowTopFrames+stackOverflowBottomFrames+1)
+		short = append(short, e.Backtrace[:stackOverflowTopFrames]...)
+		short = append(short, BacktraceFrame{FuncName: "...", Omitted: omitted})
+		short = append(short, e.Backtrace[len(e.Backtrace)-stackOverflowBottomFrames:]...)
+		e.Backtrace = short
+	}
+	return e
+}
+
 func (eb *errorBuilder) useBeforeInit(localName string) *VMError {
 	return eb.makeError(PanicUseBeforeInit, fmt.Sprintf("local %q used before initialization", localName))
 }
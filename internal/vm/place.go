@@ -11,6 +11,16 @@ import (
 
 // EvalPlace evaluates a place expression and returns its location.
 func (vm *VM) EvalPlace(frame *Frame, p mir.Place) (Location, *VMError) {
+	return vm.evalPlace(frame, p, false)
+}
+
+// evalPlaceForWrite evaluates a place that is about to be written through:
+// shared arrays on the projection path are unshared first (copy-on-write).
+func (vm *VM) evalPlaceForWrite(frame *Frame, p mir.Place) (Location, *VMError) {
+	return vm.evalPlace(frame, p, true)
+}
+
+func (vm *VM) evalPlace(frame *Frame, p mir.Place, forWrite bool) (Location, *VMError) {
 	if vm == nil || frame == nil {
 		return Location{}, &VMError{Code: PanicInvalidLocation, Message: "invalid location: nil frame"}
 	}
@@ -129,12 +139,20 @@ func (vm *VM) EvalPlace(frame *Frame, p mir.Place) (Location, *VMError) {
 			if vmErr != nil {
 				return Location{}, vmErr
 			}
+			slot := loc
 			for i := 0; i < 8 && (v.Kind == VKRef || v.Kind == VKRefMut); i++ {
+				slot = v.Loc
 				v, vmErr = vm.loadLocationRaw(v.Loc)
 				if vmErr != nil {
 					return Location{}, vmErr
 				}
 			}
+			if forWrite {
+				v, vmErr = vm.unshareArrayForWrite(slot, v)
+				if vmErr != nil {
+					return Location{}, vmErr
+				}
+			}
 			if v.Kind != VKHandleArray {
 				return Location{}, vm.eb.invalidLocation(fmt.Sprintf("index projection on non-array value (got %s)", v.Kind))
 			}
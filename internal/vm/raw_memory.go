@@ -41,6 +41,12 @@ func (vm *VM) rawAlloc(size, align int) (Handle, *VMError) {
 		align: align,
 	}
 	vm.heapCounters.allocCount++
+	if vmErr := vm.checkHeapLimits(); vmErr != nil {
+		// Отклонённый блок не должен оставаться живым.
+		delete(vm.rawMem.allocs, h)
+		vm.heapCounters.allocCount--
+		return 0, vmErr
+	}
 	return h, nil
 }
 
@@ -0,0 +1,31 @@
+package vm
+
+import (
+	"testing"
+
+	"surge/internal/source"
+	"surge/internal/types"
+)
+
+func TestRawAllocOverCapLeavesNoBlock(t *testing.T) {
+	vm := New(nil, NewTestRuntime(nil, ""), source.NewFileSet(), types.NewInterner(), nil)
+	vm.MaxHeapObjects = 1
+
+	if _, vmErr := vm.rawAlloc(16, 8); vmErr != nil {
+		t.Fatalf("unexpected error for the first block: %v", vmErr)
+	}
+	before := vm.HeapStats()
+	h, vmErr := vm.rawAlloc(32, 8)
+	if vmErr == nil || vmErr.Code != PanicOutOfMemory {
+		t.Fatalf("expected out of memory, got %v", vmErr)
+	}
+	if h != 0 {
+		t.Fatalf("expected no handle on failure, got %d", h)
+	}
+	if len(vm.rawMem.allocs) != 1 {
+		t.Fatalf("rejected block stayed allocated: %d blocks", len(vm.rawMem.allocs))
+	}
+	if after := vm.HeapStats(); after.LiveObjects != before.LiveObjects || after.LiveBytes != before.LiveBytes || after.Allocs != before.Allocs {
+		t.Fatalf("heap stats changed by a rejected allocation: before %+v, after %+v", before, after)
+	}
+}
@@ -0,0 +1,33 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSurgeRunPrintsAndForwardsExitCode(t *testing.T) {
+	requireVMBackend(t)
+	root := repoRoot(t)
+	surge := buildSurgeBinary(t, root)
+
+	srcPath := filepath.Join(t.TempDir(), "main.sg")
+	source := `@entrypoint
+fn main() -> int {
+    print("hello from run");
+    return 3;
+}
+`
+	if err := os.WriteFile(srcPath, []byte(source), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+
+	stdout, stderr, code := runSurgeWithInput(t, root, surge, "", "run", "--ui", "off", srcPath)
+	if code != 3 {
+		t.Fatalf("exit code: got %d, want 3\nstdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	if strings.TrimSpace(stdout) != "hello from run" {
+		t.Fatalf("unexpected stdout: %q\nstderr:\n%s", stdout, stderr)
+	}
+}
@@ -31,6 +31,10 @@ func (vm *VM) finishShutdown(checkLeaks bool) {
 	vm.dropAllFrames()
 	vm.dropGlobals()
 	vm.dropAsyncTasks()
+	if vm.HeapReport != nil {
+		// Отчёт best-effort: ошибка записи не должна менять исход программы.
+		_ = vm.WriteHeapReport(vm.HeapReport)
+	}
 	if checkLeaks {
 		vm.checkLeaksOrPanic()
 	}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"unicode/utf8"
 
+	"surge/internal/ast"
 	"surge/internal/mir"
 	"surge/internal/source"
 	"surge/internal/types"
@@ -227,6 +228,14 @@ func (t *Tracer) formatInstr(instr *mir.Instr) string {
 		return fmt.Sprintf("%s = await %s", t.formatPlace(instr.Await.Dst), t.formatOperand(&instr.Await.Task))
 	case mir.InstrSpawn:
 		return fmt.Sprintf("%s = spawn %s", t.formatPlace(instr.Spawn.Dst), t.formatOperand(&instr.Spawn.Value))
+	case mir.InstrParallel:
+		if instr.Parallel.Kind == ast.ExprParallelReduce {
+			return fmt.Sprintf("%s = parallel reduce fn.%d(%s, %s)",
+				t.formatPlace(instr.Parallel.Dst), instr.Parallel.FuncID,
+				t.formatOperand(&instr.Parallel.Iterable), t.formatOperand(&instr.Parallel.Init))
+		}
+		return fmt.Sprintf("%s = parallel map fn.%d(%s)",
+			t.formatPlace(instr.Parallel.Dst), instr.Parallel.FuncID, t.formatOperand(&instr.Parallel.Iterable))
 	case mir.InstrPoll:
 		return fmt.Sprintf("%s = poll %s ? bb%d : bb%d",
 			t.formatPlace(instr.Poll.Dst),
@@ -318,6 +327,12 @@ func (t *Tracer) formatRValue(rv *mir.RValue) string {
 		return fmt.Sprintf("%s[%s]", t.formatOperand(&rv.Index.Object), t.formatOperand(&rv.Index.Index))
 	case mir.RValueStructLit:
 		out := fmt.Sprintf("struct_lit %s {", t.typeLabel(rv.StructLit.TypeID))
+		if rv.StructLit.HasBase {
+			out += ".." + t.formatOperand(&rv.StructLit.Base)
+			if len(rv.StructLit.Fields) > 0 {
+				out += ", "
+			}
+		}
 		for i := range rv.StructLit.Fields {
 			f := &rv.StructLit.Fields[i]
 			if i > 0 {
@@ -517,6 +532,9 @@ func (t *Tracer) formatValue(v Value) string {
 		}
 		return fmt.Sprintf("bigfloat(%s)", s)
 
+	case VKWeak:
+		return "weak " + t.formatHandleValue("object", v.H)
+
 	default:
 		return v.String()
 	}
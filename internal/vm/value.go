@@ -48,6 +48,9 @@ const (
 	VKBigUint
 	// VKBigFloat represents a big float handle value.
 	VKBigFloat
+
+	// VKWeak represents a weak reference to a heap object; it does not retain it.
+	VKWeak
 )
 
 // String returns a human-readable name for the value kind.
@@ -87,6 +90,8 @@ func (k ValueKind) String() string {
 		return "biguint"
 	case VKBigFloat:
 		return "bigfloat"
+	case VKWeak:
+		return "weak"
 	default:
 		return fmt.Sprintf("ValueKind(%d)", k)
 	}
@@ -158,6 +163,8 @@ func (v Value) String() string {
 		return "biguint"
 	case VKBigFloat:
 		return "bigfloat"
+	case VKWeak:
+		return "weak"
 	default:
 		return fmt.Sprintf("<unknown:%d>", v.Kind)
 	}
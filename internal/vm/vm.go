@@ -2,6 +2,7 @@ package vm
 
 import (
 	"fmt"
+	"io"
 
 	"surge/internal/asyncrt"
 	"surge/internal/layout"
@@ -12,7 +13,17 @@ import (
 
 // Options configures VM execution.
 type Options struct {
-	Trace bool // Enable execution tracing
+	Trace         bool // Enable execution tracing
+	MaxStackDepth int  // call frames allowed before PanicStackOverflow; 0 disables the limit
+}
+
+// DefaultMaxStackDepth is the call depth a new VM allows before it panics with
+// PanicStackOverflow.
+const DefaultMaxStackDepth = 10000
+
+// DefaultOptions returns the options a new VM starts with.
+func DefaultOptions() Options {
+	return Options{MaxStackDepth: DefaultMaxStackDepth}
 }
 
 // VM is a direct MIR interpreter.
@@ -36,6 +47,7 @@ type VM struct {
 	AsyncConfig   asyncrt.Config
 	ExitCode      int
 	Halted        bool
+	Options       Options
 	started       bool
 	fsFiles       map[uint64]*vmFile
 	fsNextHandle  uint64
@@ -44,12 +56,17 @@ type VM struct {
 	netNextListen uint64
 	netNextConn   uint64
 
+	MaxHeapObjects int       // live heap objects and raw blocks allowed before PanicOutOfMemory; 0 disables the limit
+	MaxHeapBytes   int       // live heap bytes allowed before PanicOutOfMemory; 0 disables the limit
+	HeapReport     io.Writer // receives WriteHeapReport output when the program exits; nil disables the report
+
 	eb                  *errorBuilder // for creating errors with backtrace
 	captureReturn       *Value
 	asyncCapture        *asyncExit
 	asyncPendingParkKey asyncrt.WakerKey
 	pollDepth           int
 	deferredShutdown    shutdownState
+	dropLive            map[dropPoint]map[mir.LocalID]bool
 }
 
 // New creates a new VM for executing the given MIR module.
@@ -62,6 +79,7 @@ func New(m *mir.Module, rt Runtime, files *source.FileSet, typeInterner *types.I
 		Trace:    trace,
 		ExitCode: 0,
 		Halted:   false,
+		Options:  DefaultOptions(),
 	}
 	if m != nil && m.Meta != nil && m.Meta.Layout != nil {
 		vm.Layout = m.Meta.Layout
@@ -214,6 +232,9 @@ func (vm *VM) Step() (vmErr *VMError) {
 		return vmErr
 	}
 	if pushFrame != nil {
+		if limit := vm.Options.MaxStackDepth; limit > 0 && len(vm.Stack) >= limit {
+			return vm.eb.stackOverflow(pushFrame, limit)
+		}
 		vm.Stack = append(vm.Stack, pushFrame)
 		return nil
 	}
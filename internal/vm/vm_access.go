@@ -72,6 +72,7 @@ func (vm *VM) writeLocal(frame *Frame, id mir.LocalID, val Value) *VMError {
 		if retagged, ok := vm.retagUnionValue(val, expectedType); ok {
 			val = retagged
 		}
+		val = vm.reconcileStoreType(val, expectedType)
 	}
 	if val.Kind == VKNothing && expectedType != types.NoTypeID && vm.tagLayouts != nil {
 		if tagLayout, ok := vm.tagLayouts.Layout(vm.valueType(expectedType)); ok && tagLayout != nil {
@@ -112,6 +113,7 @@ func (vm *VM) writeGlobal(id mir.GlobalID, val Value) *VMError {
 		if retagged, ok := vm.retagUnionValue(val, expectedType); ok {
 			val = retagged
 		}
+		val = vm.reconcileStoreType(val, expectedType)
 	}
 	if val.Kind == VKNothing && expectedType != types.NoTypeID && vm.tagLayouts != nil {
 		if tagLayout, ok := vm.tagLayouts.Layout(vm.valueType(expectedType)); ok && tagLayout != nil {
@@ -133,6 +135,25 @@ func (vm *VM) writeGlobal(id mir.GlobalID, val Value) *VMError {
 	return nil
 }
 
+// reconcileStoreType adjusts a stored value to the static type of its slot where
+// types.Interner.AssignableTo allows an implicit coercion: &mut T stored into a
+// &T slot becomes a shared reference.
+func (vm *VM) reconcileStoreType(val Value, expected types.TypeID) Value {
+	if val.Kind != VKRefMut || val.TypeID == types.NoTypeID || val.TypeID == expected || vm.Types == nil {
+		return val
+	}
+	tt, ok := vm.Types.Lookup(expected)
+	if !ok || tt.Kind != types.KindReference || tt.Mutable {
+		return val
+	}
+	if !vm.Types.AssignableTo(val.TypeID, expected) {
+		return val
+	}
+	val.Kind = VKRef
+	val.TypeID = expected
+	return val
+}
+
 // moveLocal marks a local as moved.
 func (vm *VM) moveLocal(frame *Frame, id mir.LocalID) {
 	if int(id) < 0 || int(id) >= len(frame.Locals) {
@@ -0,0 +1,103 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"surge/internal/vm"
+)
+
+func TestVMArrayCopyOnWriteIsolatesMutation(t *testing.T) {
+	requireVMBackend(t)
+	source := `fn push_into(xs: &mut int[], v: int) {
+    xs.push(v);
+}
+
+@entrypoint
+fn main() -> int {
+    let a: int[][] = [[1, 2], [3, 4]];
+    let extra: int[][] = [[5]];
+    let mut b: int[][] = a + extra;
+    b[0][0] = 9;
+    if a[0][0] != 1 {
+        return 1;
+    }
+    if b[0][0] != 9 {
+        return 2;
+    }
+    push_into(&mut b[1], 7);
+    if len(a[1]) != 2 {
+        return 3;
+    }
+    if len(b[1]) != 3 || b[1][2] != 7 {
+        return 4;
+    }
+    let mut c: int[][] = b + extra;
+    c[2][0] = 6;
+    if extra[0][0] != 5 || b[2][0] != 5 || c[2][0] != 6 {
+        return 5;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("copy-on-write isolation broken, exit code %d", res.exitCode)
+	}
+}
+
+// BenchmarkVMSharedArrayReads concatenates arrays of large rows and only reads
+// them: rows are shared by handle, so allocations do not scale with row length.
+func BenchmarkVMSharedArrayReads(b *testing.B) {
+	if strings.TrimSpace(os.Getenv("SURGE_BACKEND")) == "llvm" {
+		b.Skip("VM-only benchmark")
+	}
+	source := `fn make_row(n: int) -> int[] {
+    let mut row: int[] = [];
+    let mut i = 0;
+    while i < n {
+        row.push(i);
+        i = i + 1;
+    }
+    return row;
+}
+
+@entrypoint
+fn main() -> int {
+    let rows: int[][] = [make_row(512), make_row(512), make_row(512)];
+    let tail: int[][] = [make_row(512)];
+    let mut sum = 0;
+    let mut k = 0;
+    while k < 64 {
+        let joined: int[][] = rows + tail;
+        sum = sum + joined[k % 4][k];
+        k = k + 1;
+    }
+    if sum == 0 {
+        return 1;
+    }
+    return 0;
+}
+`
+	path := filepath.Join(b.TempDir(), "shared_reads.sg")
+	if err := os.WriteFile(path, []byte(source), 0o600); err != nil {
+		b.Fatalf("write source: %v", err)
+	}
+	mirMod, files, typesInterner := compileToMIR(b, path)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		exitCode, vmErr := runVM(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+		if vmErr != nil {
+			b.Fatalf("vm error: %s", vmErr.FormatWithFiles(files))
+		}
+		if exitCode != 0 {
+			b.Fatalf("unexpected exit code %d", exitCode)
+		}
+	}
+}
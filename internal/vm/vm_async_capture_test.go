@@ -0,0 +1,61 @@
+package vm_test
+
+import "testing"
+
+const asyncCaptureIsolationSource = `fn make_data(n: int) -> int[] {
+    let mut xs: int[] = [];
+    let mut i = 0;
+    while i < n {
+        xs.push(i);
+        i = i + 1;
+    }
+    return xs;
+}
+
+fn checksum(xs: int[]) -> int {
+    let mut total = 0;
+    for x in xs {
+        total = total + x;
+    }
+    return total;
+}
+
+@entrypoint
+fn main() -> int {
+    let mut base = 7;
+    let a = make_data(1024);
+    let b = make_data(1024);
+    let t1 = spawn async {
+        checkpoint().await();
+        return checksum(a) + base;
+    };
+    let t2 = spawn async {
+        checkpoint().await();
+        return checksum(b) + base * 2;
+    };
+    base = 0;
+    let r1 = compare t1.await() {
+        Success(v) => v;
+        Cancelled() => -1;
+    };
+    let r2 = compare t2.await() {
+        Success(v) => v;
+        Cancelled() => -1;
+    };
+    if r1 != 523783 || r2 != 523790 || base != 0 {
+        print("bad " + (r1 to string) + " " + (r2 to string));
+        return 1;
+    }
+    print("ok");
+    return 0;
+}
+`
+
+func TestVMAsyncBlockCapturesAreSnapshots(t *testing.T) {
+	requireVMBackend(t)
+
+	res := runProgramFromSource(t, asyncCaptureIsolationSource, runOptions{})
+	if res.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstderr:\n%s", res.exitCode, res.stderr)
+	}
+}
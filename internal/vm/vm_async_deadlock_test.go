@@ -0,0 +1,46 @@
+package vm_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVMAsyncMutualWaitReportsDeadlock(t *testing.T) {
+	requireVMBackend(t)
+
+	res := runProgramFromSource(t, `async fn wait_then_send(inbox: &Channel<int>, outbox: &Channel<int>) -> int {
+    let v = compare inbox.recv() {
+        Some(x) => x;
+        nothing => -1;
+    };
+    outbox.send(v);
+    return v;
+}
+
+@entrypoint
+fn main() -> int {
+    let a = make_channel::<int>(0:uint);
+    let b = make_channel::<int>(0:uint);
+    let t1 = spawn wait_then_send(&a, &b);
+    let t2 = spawn wait_then_send(&b, &a);
+    let r1 = compare t1.await() {
+        Success(v) => v;
+        Cancelled() => -1;
+    };
+    let r2 = compare t2.await() {
+        Success(v) => v;
+        Cancelled() => -1;
+    };
+    return r1 + r2;
+}
+`, runOptions{})
+	for _, want := range []string{
+		"panic VM3401",
+		"task 1 waiting on chan_recv(#1)",
+		"task 2 waiting on chan_recv(#2)",
+	} {
+		if !strings.Contains(res.stderr, want) {
+			t.Fatalf("expected %q in stderr, got:\n%s", want, res.stderr)
+		}
+	}
+}
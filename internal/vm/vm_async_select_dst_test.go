@@ -0,0 +1,77 @@
+package vm_test
+
+import (
+	"testing"
+
+	"surge/internal/mir"
+	"surge/internal/vm"
+)
+
+// Lowering always selects into a temporary index local, so the test rewrites the
+// MIR to select straight into a struct field and reads the index back from it.
+func TestVMSelectIntoStructField(t *testing.T) {
+	requireVMBackend(t)
+	sourceCode := `type Box = { picked: int32, extra: int };
+
+@entrypoint
+fn main() -> int {
+    let r = (async {
+        let ch = make_channel::<int>(1);
+        ch.send(5);
+        let mut b = Box { picked: 9:int32, extra: 0 };
+        let v = select {
+            sleep(100).await() => 20;
+            ch.recv() => 10;
+        };
+        return (b.picked to int) * 100 + v + b.extra;
+    }).await();
+
+    compare r {
+        Success(v) => return v;
+        Cancelled() => return 1;
+    };
+}
+`
+	mirMod, files, typesInterner := compileToMIRFromSource(t, sourceCode)
+
+	rewritten := 0
+	for _, f := range mirMod.Funcs {
+		boxLocal := mir.NoLocalID
+		for i := range f.Locals {
+			if f.Locals[i].Name == "b" {
+				boxLocal = mir.LocalID(i)
+			}
+		}
+		if boxLocal == mir.NoLocalID {
+			continue
+		}
+		picked := mir.Place{Local: boxLocal, Proj: []mir.PlaceProj{{Kind: mir.PlaceProjField, FieldName: "picked", FieldIdx: -1}}}
+		for bi := range f.Blocks {
+			for ii := range f.Blocks[bi].Instrs {
+				instr := &f.Blocks[bi].Instrs[ii]
+				if instr.Kind != mir.InstrSelect || len(instr.Select.Dst.Proj) != 0 {
+					continue
+				}
+				index := instr.Select.Dst
+				instr.Select.Dst = picked
+				ready := &f.Blocks[instr.Select.ReadyBB]
+				ready.Instrs = append([]mir.Instr{{Kind: mir.InstrAssign, Assign: mir.AssignInstr{
+					Dst: index,
+					Src: mir.RValue{Kind: mir.RValueUse, Use: mir.Operand{Kind: mir.OperandCopy, Place: picked}},
+				}}}, ready.Instrs...)
+				rewritten++
+			}
+		}
+	}
+	if rewritten == 0 {
+		t.Fatal("expected a select instruction to rewrite")
+	}
+
+	exitCode, vmErr := runVM(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+	if vmErr != nil {
+		t.Fatalf("unexpected error: %v", vmErr.Error())
+	}
+	if exitCode != 110 {
+		t.Fatalf("expected exit code 110 (arm 1 stored into b.picked), got %d", exitCode)
+	}
+}
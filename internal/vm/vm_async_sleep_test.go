@@ -0,0 +1,38 @@
+package vm_test
+
+import "testing"
+
+func TestVMAsyncSleepersResumeInDeadlineOrder(t *testing.T) {
+	sourceCode := `async fn sleeper(id: int, ms: uint, ch: Channel<int>) -> nothing {
+    sleep(ms).await();
+    ch.send(id);
+    return nothing;
+}
+
+@entrypoint
+fn main() -> int {
+    let ch = make_channel::<int>(2:uint);
+    let slow: Task<nothing> = spawn sleeper(1, 30:uint, ch);
+    let fast: Task<nothing> = spawn sleeper(2, 10:uint, ch);
+    slow.await();
+    fast.await();
+
+    let first = compare ch.recv() {
+        Some(v) => v;
+        nothing => 0;
+    };
+    let second = compare ch.recv() {
+        Some(v) => v;
+        nothing => 0;
+    };
+    return first * 10 + second;
+}`
+
+	result := runProgramFromSource(t, sourceCode, runOptions{})
+	if result.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", result.stderr)
+	}
+	if result.exitCode != 21 {
+		t.Fatalf("expected the shorter sleep to resume first (exit 21), got %d", result.exitCode)
+	}
+}
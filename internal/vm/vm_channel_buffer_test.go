@@ -0,0 +1,82 @@
+package vm_test
+
+import "testing"
+
+func TestVMBufferedChannelCapacityTwo(t *testing.T) {
+	requireVMBackend(t)
+
+	res := runProgramFromSource(t, `fn take(ch: &Channel<int>) -> int {
+    return compare ch.recv() {
+        Some(v) => v;
+        nothing => -1;
+    };
+}
+
+@entrypoint
+fn main() -> int {
+    let ch = make_channel::<int>(2:uint);
+    ch.send(10);
+    ch.send(20);
+    if ch.try_send(30) {
+        return 1;
+    }
+    if take(&ch) != 10 {
+        return 2;
+    }
+    if !ch.try_send(30) {
+        return 3;
+    }
+    ch.close();
+    if take(&ch) != 20 || take(&ch) != 30 {
+        return 4;
+    }
+    if take(&ch) != -1 {
+        return 5;
+    }
+    return 0;
+}
+`, runOptions{})
+	if res.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstderr:\n%s", res.exitCode, res.stderr)
+	}
+}
+
+func TestVMTrySendOnFullChannelReturnsFalse(t *testing.T) {
+	requireVMBackend(t)
+
+	res := runProgramFromSource(t, `@entrypoint
+fn main() -> int {
+    let ch = make_channel::<int>(1:uint);
+    if !try_send(&ch, 1) {
+        return 1;
+    }
+    if try_send(&ch, 2) {
+        return 2;
+    }
+    return compare try_recv(&ch) {
+        Some(v) => v - 1;
+        nothing => 3;
+    };
+}
+`, runOptions{})
+	if res.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstderr:\n%s", res.exitCode, res.stderr)
+	}
+}
+
+func TestVMTryRecvOnEmptyChannelReturnsNothing(t *testing.T) {
+	requireVMBackend(t)
+
+	res := runProgramFromSource(t, `@entrypoint
+fn main() -> int {
+    let ch = make_channel::<int>(1:uint);
+    return compare try_recv(&ch) {
+        Some(_) => 1;
+        nothing => 0;
+    };
+}
+`, runOptions{})
+	if res.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstderr:\n%s", res.exitCode, res.stderr)
+	}
+}
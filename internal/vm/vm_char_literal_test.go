@@ -0,0 +1,39 @@
+package vm_test
+
+import "testing"
+
+func TestVMCharLiteralsAreCodePoints(t *testing.T) {
+	requireVMBackend(t)
+	source := `fn code(c: char) -> uint32 {
+    return c to uint32;
+}
+
+@entrypoint
+fn main() -> int {
+    if code('a') != 97 {
+        return 1;
+    }
+    let nl: char = '\n';
+    if nl != '\n' || code(nl) != 10 {
+        return 2;
+    }
+    if code('\u{1F600}') != 128512 {
+        return 3;
+    }
+    if code('Ж') != 1046 || 'Ж' <= 'z' {
+        return 4;
+    }
+    if code('\'') != 39 {
+        return 5;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("char literal mismatch, exit code %d", res.exitCode)
+	}
+}
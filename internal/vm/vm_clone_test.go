@@ -0,0 +1,130 @@
+package vm_test
+
+import "testing"
+
+func TestVMCloneStringIsIndependent(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let s: string = "client";
+    let mut c: string = s.__clone();
+    c = c + "-copy";
+    if s != "client" {
+        return 1;
+    }
+    if c != "client-copy" {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("string clone is not independent, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMCloneNestedStructDeepCopies(t *testing.T) {
+	requireVMBackend(t)
+	source := `type Inner = { name: string, tags: string[] };
+type Outer = { id: int, inner: Inner, rows: int[][] };
+
+@entrypoint
+fn main() -> int {
+    let o: Outer = { id = 1, inner = { name = "a", tags = ["x"] }, rows = [[1, 2]] };
+    let mut c: Outer = clone(&o);
+    c.id = 2;
+    c.inner.name = "b";
+    c.inner.tags.push("y");
+    c.rows[0][0] = 9;
+    if o.id != 1 || o.inner.name != "a" {
+        return 1;
+    }
+    if len(o.inner.tags) != 1 || o.rows[0][0] != 1 {
+        return 2;
+    }
+    if c.id != 2 || c.inner.name != "b" || len(c.inner.tags) != 2 || c.rows[0][0] != 9 {
+        return 3;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("nested struct clone shares state with the original, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMCloneDispatchesNestedUserClone(t *testing.T) {
+	requireVMBackend(t)
+	source := `type Counted = { n: int };
+
+extern<Counted> {
+    pub fn __clone(self: &Counted) -> Counted {
+        return { n = self.n + 100 };
+    }
+}
+
+type Holder = { c: Counted, cs: Counted[] };
+
+@entrypoint
+fn main() -> int {
+    let h: Holder = { c = { n = 1 }, cs = [{ n = 2 }] };
+    let copy: Holder = clone(&h);
+    if copy.c.n != 101 || copy.cs[0].n != 102 {
+        return 1;
+    }
+    if h.c.n != 1 || h.cs[0].n != 2 {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("user __clone was not dispatched, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMCloneIgnoresFreeFunctionNamedClone(t *testing.T) {
+	requireVMBackend(t)
+	source := `type Counted = { n: int };
+
+fn __clone(seed: &int) -> Counted {
+    return { n = *seed + 100 };
+}
+
+type Holder = { c: Counted, cs: Counted[] };
+
+@entrypoint
+fn main() -> int {
+    let h: Holder = { c = { n = 1 }, cs = [{ n = 2 }] };
+    let copy: Holder = clone(&h);
+    if copy.c.n != 1 || copy.cs[0].n != 2 {
+        return 1;
+    }
+    let seed: int = 1;
+    let made: Counted = __clone(&seed);
+    if made.n != 101 {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("free __clone was dispatched as Counted's clone, exit code %d", res.exitCode)
+	}
+}
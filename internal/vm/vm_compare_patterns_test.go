@@ -0,0 +1,98 @@
+package vm_test
+
+import "testing"
+
+func TestVMCompareLiteralAndRangePatterns(t *testing.T) {
+	requireVMBackend(t)
+	source := `fn classify(x: int) -> int {
+    return compare x {
+        1 => 10;
+        2..5 => 20;
+        5..=7 => 30;
+        finally => 0;
+    };
+}
+
+fn first_match(x: int) -> int {
+    return compare x {
+        0..10 => 1;
+        5 => 2;
+        n if n < 0 => 3;
+        finally => 4;
+    };
+}
+
+@entrypoint
+fn main() -> int {
+    if classify(1) != 10 {
+        return 1;
+    }
+    if classify(2) != 20 || classify(4) != 20 {
+        return 2;
+    }
+    if classify(5) != 30 || classify(7) != 30 {
+        return 3;
+    }
+    if classify(0) != 0 || classify(8) != 0 {
+        return 4;
+    }
+    if first_match(5) != 1 {
+        return 5;
+    }
+    if first_match(-3) != 3 || first_match(10) != 4 {
+        return 6;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("compare pattern mismatch, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMCompareOrPatternMatchesAnyAlternative(t *testing.T) {
+	requireVMBackend(t)
+	source := `fn classify(x: int) -> int {
+    return compare x {
+        1 | 2 | 3 => 10;
+        (5..7) | 9 => 20;
+        finally => 0;
+    };
+}
+
+fn present(v: Option<int>) -> int {
+    return compare v {
+        Some(_) | nothing => 1;
+    };
+}
+
+@entrypoint
+fn main() -> int {
+    if classify(1) != 10 || classify(2) != 10 || classify(3) != 10 {
+        return 1;
+    }
+    if classify(0) != 0 || classify(4) != 0 || classify(7) != 0 {
+        return 2;
+    }
+    if classify(5) != 20 || classify(9) != 20 {
+        return 3;
+    }
+    let none: Option<int> = nothing;
+    if present(Some(1)) != 1 || present(none) != 1 {
+        return 4;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("or-pattern mismatch, exit code %d", res.exitCode)
+	}
+}
@@ -0,0 +1,47 @@
+package vm_test
+
+import "testing"
+
+func TestVMDebugStringComposites(t *testing.T) {
+	requireVMBackend(t)
+	source := `type Point = { x: int, y: int };
+type Line = { from: Point, till: Point, name: string };
+
+@entrypoint
+fn main() -> int {
+    let line = Line { from: Point { x: 1, y: 2 }, till: Point { x: 3, y: 4 }, name: "diag" };
+    let lineStr = line to string;
+    if lineStr != "Line { from: Point { x: 1, y: 2 }, till: Point { x: 3, y: 4 }, name: \"diag\" }" {
+        return 1;
+    }
+    let points: Point[] = [Point { x: 0, y: 0 }, Point { x: -1, y: 5 }];
+    let pointsStr = points to string;
+    if pointsStr != "[Point { x: 0, y: 0 }, Point { x: -1, y: 5 }]" {
+        return 2;
+    }
+    let words: string[] = ["a", "b"];
+    let wordsStr = words to string;
+    if wordsStr != "[\"a\", \"b\"]" {
+        return 3;
+    }
+    let pair = (7, "seven");
+    let pairStr = pair to string;
+    if pairStr != "(7, \"seven\")" {
+        return 4;
+    }
+    let nested: (int, (bool, int[])) = (1, (true, [2, 3]));
+    let nestedStr = nested to string;
+    if nestedStr != "(1, (true, [2, 3]))" {
+        return 5;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("debug string mismatch, exit code %d", res.exitCode)
+	}
+}
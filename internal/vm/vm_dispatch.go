@@ -60,6 +60,12 @@ func (vm *VM) execInstr(frame *Frame, instr *mir.Instr) (advanceIP bool, pushFra
 			return false, nil, vmErr
 		}
 
+	case mir.InstrParallel:
+		hasStore, storeLoc, storeVal, writes, vmErr = vm.execInstrParallel(frame, instr, writes)
+		if vmErr != nil {
+			return false, nil, vmErr
+		}
+
 	case mir.InstrPoll:
 		pollRes, pollErr := vm.execInstrPoll(frame, instr, writes)
 		vmErr = pollErr
@@ -201,7 +207,7 @@ func (vm *VM) execInstrAssign(frame *Frame, instr *mir.Instr, writes []LocalWrit
 			return false, Location{}, Value{}, writes, nil
 		}
 	}
-	loc, vmErr := vm.EvalPlace(frame, dst)
+	loc, vmErr := vm.evalPlaceForWrite(frame, dst)
 	if vmErr != nil {
 		return false, Location{}, Value{}, writes, vmErr
 	}
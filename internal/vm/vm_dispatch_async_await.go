@@ -50,7 +50,7 @@ func (vm *VM) execInstrAwait(frame *Frame, instr *mir.Instr, writes []LocalWrite
 			return false, Location{}, Value{}, writes, nil
 		}
 	}
-	loc, vmErr := vm.EvalPlace(frame, dst)
+	loc, vmErr := vm.evalPlaceForWrite(frame, dst)
 	if vmErr != nil {
 		return false, Location{}, Value{}, writes, vmErr
 	}
@@ -100,7 +100,7 @@ func (vm *VM) execInstrSpawn(frame *Frame, instr *mir.Instr, writes []LocalWrite
 			return false, Location{}, Value{}, writes, nil
 		}
 	}
-	loc, vmErr := vm.EvalPlace(frame, dst)
+	loc, vmErr := vm.evalPlaceForWrite(frame, dst)
 	if vmErr != nil {
 		return false, Location{}, Value{}, writes, vmErr
 	}
@@ -147,7 +147,19 @@ func (vm *VM) execInstrChanRecv(frame *Frame, instr *mir.Instr, writes []LocalWr
 				return res, nil
 			}
 		}
-		return res, vm.eb.makeError(PanicUnimplemented, "chan_recv destination projection unsupported")
+		loc, vmErr := vm.evalPlaceForWrite(frame, dst)
+		if vmErr != nil {
+			return res, vmErr
+		}
+		if vmErr := vm.storeLocation(loc, doneVal); vmErr != nil {
+			return res, vmErr
+		}
+		res.hasStore = true
+		res.storeLoc = loc
+		res.storeVal = doneVal
+		res.doJump = true
+		res.jumpBB = instr.ChanRecv.ReadyBB
+		return res, nil
 	}
 
 	switch task.ResumeKind {
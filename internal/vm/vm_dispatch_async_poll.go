@@ -87,7 +87,7 @@ func (vm *VM) execInstrPoll(frame *Frame, instr *mir.Instr, writes []LocalWrite)
 				return res, nil
 			}
 		}
-		loc, vmErr := vm.EvalPlace(frame, dst)
+		loc, vmErr := vm.evalPlaceForWrite(frame, dst)
 		if vmErr != nil {
 			return res, vmErr
 		}
@@ -178,5 +178,17 @@ func (vm *VM) execInstrJoinAll(frame *Frame, instr *mir.Instr, writes []LocalWri
 			return res, nil
 		}
 	}
-	return res, vm.eb.makeError(PanicUnimplemented, "join_all destination projection unsupported")
+	loc, vmErr := vm.evalPlaceForWrite(frame, dst)
+	if vmErr != nil {
+		return res, vmErr
+	}
+	if vmErr := vm.storeLocation(loc, doneVal); vmErr != nil {
+		return res, vmErr
+	}
+	res.hasStore = true
+	res.storeLoc = loc
+	res.storeVal = doneVal
+	res.doJump = true
+	res.jumpBB = instr.JoinAll.ReadyBB
+	return res, nil
 }
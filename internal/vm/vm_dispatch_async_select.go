@@ -232,7 +232,24 @@ func (vm *VM) execInstrSelect(frame *Frame, instr *mir.Instr, writes []LocalWrit
 			res.jumpBB = instr.Select.ReadyBB
 			return res, nil
 		}
-		return res, vm.eb.makeError(PanicUnimplemented, "select destination projection unsupported")
+		selType, vmErr := vm.projectedDstType(frame, dst)
+		if vmErr != nil {
+			return res, vmErr
+		}
+		selVal := MakeInt(int64(selectedIndex), selType)
+		loc, vmErr := vm.evalPlaceForWrite(frame, dst)
+		if vmErr != nil {
+			return res, vmErr
+		}
+		if vmErr := vm.storeLocation(loc, selVal); vmErr != nil {
+			return res, vmErr
+		}
+		res.hasStore = true
+		res.storeLoc = loc
+		res.storeVal = selVal
+		res.doJump = true
+		res.jumpBB = instr.Select.ReadyBB
+		return res, nil
 	}
 
 	selectID := currentTask.SelectID
@@ -131,7 +131,19 @@ func (vm *VM) execInstrTimeout(frame *Frame, instr *mir.Instr, writes []LocalWri
 				return res, nil
 			}
 		}
-		return res, vm.eb.makeError(PanicUnimplemented, "timeout destination projection unsupported")
+		loc, vmErr := vm.evalPlaceForWrite(frame, dst)
+		if vmErr != nil {
+			return res, vmErr
+		}
+		if vmErr := vm.storeLocation(loc, doneVal); vmErr != nil {
+			return res, vmErr
+		}
+		res.hasStore = true
+		res.storeLoc = loc
+		res.storeVal = doneVal
+		res.doJump = true
+		res.jumpBB = instr.Timeout.ReadyBB
+		return res, nil
 	}
 
 	vm.asyncPendingParkKey = asyncrt.JoinKey(timeoutID)
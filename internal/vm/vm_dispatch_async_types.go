@@ -18,7 +18,7 @@ type pollExecResult struct {
 
 func (vm *VM) awaitResultType(frame *Frame, dst mir.Place) (types.TypeID, *VMError) {
 	if len(dst.Proj) != 0 {
-		return types.NoTypeID, vm.eb.makeError(PanicUnimplemented, "await destination projection unsupported")
+		return vm.projectedDstType(frame, dst)
 	}
 	switch dst.Kind {
 	case mir.PlaceGlobal:
@@ -36,7 +36,7 @@ func (vm *VM) awaitResultType(frame *Frame, dst mir.Place) (types.TypeID, *VMErr
 
 func (vm *VM) joinResultType(frame *Frame, dst mir.Place) (types.TypeID, *VMError) {
 	if len(dst.Proj) != 0 {
-		return types.NoTypeID, vm.eb.makeError(PanicUnimplemented, "join_all destination projection unsupported")
+		return vm.projectedDstType(frame, dst)
 	}
 	switch dst.Kind {
 	case mir.PlaceGlobal:
@@ -51,3 +51,21 @@ func (vm *VM) joinResultType(frame *Frame, dst mir.Place) (types.TypeID, *VMErro
 		return frame.Locals[dst.Local].TypeID, nil
 	}
 }
+
+// projectedDstType returns the type of the slot a projected destination
+// (`s.field`, `arr[i]`, `*r`) currently holds; async results are built with it
+// before being stored there.
+func (vm *VM) projectedDstType(frame *Frame, dst mir.Place) (types.TypeID, *VMError) {
+	loc, vmErr := vm.EvalPlace(frame, dst)
+	if vmErr != nil {
+		return types.NoTypeID, vmErr
+	}
+	cur, vmErr := vm.loadLocationRaw(loc)
+	if vmErr != nil {
+		return types.NoTypeID, vmErr
+	}
+	if cur.TypeID == types.NoTypeID {
+		return types.NoTypeID, vm.eb.invalidLocation(fmt.Sprintf("destination %s has no type", loc))
+	}
+	return cur.TypeID, nil
+}
@@ -0,0 +1,176 @@
+package vm
+
+import (
+	"fmt"
+
+	"surge/internal/ast"
+	"surge/internal/asyncrt"
+	"surge/internal/mir"
+)
+
+// parallelState is the payload of a parallel step task: one call of the
+// per-element function built by MIR lowering. The task owns args.
+type parallelState struct {
+	fn   *mir.Func
+	args []Value
+}
+
+// execInstrParallel runs a parallel map/reduce on the async executor.
+// Map spawns one step task per element and collects the results in element
+// order, so the output matches the input order regardless of scheduling.
+// Reduce folds the elements in order, one step task per element, like the
+// native runtime's rt_parallel_reduce.
+func (vm *VM) execInstrParallel(frame *Frame, instr *mir.Instr, writes []LocalWrite) (hasStore bool, storeLoc Location, storeVal Value, writesOut []LocalWrite, vmErr *VMError) {
+	ins := &instr.Parallel
+	fn := vm.M.Funcs[ins.FuncID]
+	if fn == nil {
+		return false, Location{}, Value{}, writes, vm.eb.makeError(PanicUnimplemented, fmt.Sprintf("missing parallel function %d", ins.FuncID))
+	}
+	exec := vm.ensureExecutor()
+	if exec == nil {
+		return false, Location{}, Value{}, writes, vm.eb.makeError(PanicUnimplemented, "async executor missing")
+	}
+	dstType, vmErr := vm.awaitResultType(frame, ins.Dst)
+	if vmErr != nil {
+		return false, Location{}, Value{}, writes, vmErr
+	}
+	state, vmErr := vm.evalStructLit(frame, &ins.State)
+	if vmErr != nil {
+		return false, Location{}, Value{}, writes, vmErr
+	}
+	defer vm.dropValue(state)
+	iterable, vmErr := vm.evalOperand(frame, &ins.Iterable)
+	if vmErr != nil {
+		return false, Location{}, Value{}, writes, vmErr
+	}
+	defer vm.dropValue(iterable)
+	if iterable.Kind != VKHandleArray {
+		return false, Location{}, Value{}, writes, vm.eb.typeMismatch("array", iterable.Kind.String())
+	}
+	view, vmErr := vm.arrayViewFromHandle(iterable.H)
+	if vmErr != nil {
+		return false, Location{}, Value{}, writes, vmErr
+	}
+	elems := view.baseObj.Arr[view.start : view.start+view.length]
+
+	var res Value
+	if ins.Kind == ast.ExprParallelReduce {
+		res, vmErr = vm.evalOperand(frame, &ins.Init)
+		if vmErr != nil {
+			return false, Location{}, Value{}, writes, vmErr
+		}
+		for _, elem := range elems {
+			id, spawnErr := vm.spawnParallelStep(exec, fn, state, res, elem)
+			if spawnErr != nil {
+				return false, Location{}, Value{}, writes, spawnErr
+			}
+			res, vmErr = vm.runParallelStep(exec, id)
+			if vmErr != nil || vm.Halted {
+				return false, Location{}, Value{}, writes, vmErr
+			}
+		}
+	} else {
+		// Все шаги ставятся в очередь сразу; результаты забираются по порядку элементов.
+		ids := make([]asyncrt.TaskID, 0, len(elems))
+		for _, elem := range elems {
+			id, spawnErr := vm.spawnParallelStep(exec, fn, state, elem)
+			if spawnErr != nil {
+				return false, Location{}, Value{}, writes, spawnErr
+			}
+			ids = append(ids, id)
+		}
+		out := make([]Value, 0, len(ids))
+		for _, id := range ids {
+			v, stepErr := vm.runParallelStep(exec, id)
+			if stepErr != nil || vm.Halted {
+				vm.dropValues(out)
+				return false, Location{}, Value{}, writes, stepErr
+			}
+			out = append(out, v)
+		}
+		res = MakeHandleArray(vm.Heap.AllocArray(dstType, out), dstType)
+	}
+
+	dst := ins.Dst
+	if len(dst.Proj) == 0 && dst.Kind != mir.PlaceGlobal {
+		localID := dst.Local
+		vmErr = vm.writeLocal(frame, localID, res)
+		if vmErr != nil {
+			return false, Location{}, Value{}, writes, vmErr
+		}
+		writes = append(writes, LocalWrite{
+			LocalID: localID,
+			Name:    frame.Locals[localID].Name,
+			Value:   frame.Locals[localID].V,
+		})
+		return false, Location{}, Value{}, writes, nil
+	}
+	loc, vmErr := vm.evalPlaceForWrite(frame, dst)
+	if vmErr != nil {
+		return false, Location{}, Value{}, writes, vmErr
+	}
+	if vmErr := vm.storeLocation(loc, res); vmErr != nil {
+		return false, Location{}, Value{}, writes, vmErr
+	}
+	return true, loc, res, writes, nil
+}
+
+// spawnParallelStep enqueues fn(state, args...). State and every element are
+// shared with the caller, so the task gets its own references; a reduce
+// accumulator is moved into the task.
+func (vm *VM) spawnParallelStep(exec *asyncrt.Executor, fn *mir.Func, state Value, args ...Value) (asyncrt.TaskID, *VMError) {
+	owned := make([]Value, 0, 1+len(args))
+	st, vmErr := vm.cloneForShare(state)
+	if vmErr != nil {
+		return 0, vmErr
+	}
+	owned = append(owned, st)
+	last := len(args) - 1
+	for i, arg := range args {
+		if i < last {
+			owned = append(owned, arg)
+			continue
+		}
+		el, vmErr := vm.cloneForShare(arg)
+		if vmErr != nil {
+			vm.dropValues(owned)
+			return 0, vmErr
+		}
+		owned = append(owned, el)
+	}
+	return exec.SpawnParallel(&parallelState{fn: fn, args: owned}), nil
+}
+
+// runParallelStep polls a step task to completion and hands its result to the
+// caller. Steps never park, so a single poll finishes them; the executor's
+// current task is restored afterwards so the caller's children stay attached.
+func (vm *VM) runParallelStep(exec *asyncrt.Executor, id asyncrt.TaskID) (Value, *VMError) {
+	task := exec.Task(id)
+	if task == nil {
+		return Value{}, vm.eb.makeError(PanicInvalidHandle, fmt.Sprintf("invalid task id %d", id))
+	}
+	prev := exec.Current()
+	exec.SetCurrent(id)
+	task.Status = asyncrt.TaskRunning
+	outcome, vmErr := vm.pollTask(task)
+	exec.SetCurrent(prev)
+	if vmErr != nil {
+		return Value{}, vmErr
+	}
+	res, _ := outcome.Value.(Value)
+	exec.MarkDone(id, asyncrt.TaskResultSuccess, nil)
+	return res, nil
+}
+
+func (vm *VM) pollParallelTask(task *asyncrt.Task) (asyncrt.PollOutcome, *VMError) {
+	state, ok := task.State.(*parallelState)
+	if !ok || state == nil {
+		return asyncrt.PollOutcome{}, vm.eb.makeError(PanicUnimplemented, "parallel state missing")
+	}
+	task.State = nil
+	res, vmErr := vm.callSync(state.fn, state.args)
+	if vmErr != nil {
+		return asyncrt.PollOutcome{}, vmErr
+	}
+	return asyncrt.PollOutcome{Kind: asyncrt.PollDoneSuccess, Value: res}, nil
+}
@@ -0,0 +1,54 @@
+package vm_test
+
+import "testing"
+
+// TestVMEntrypointReturnMapsToExitCode pins the entrypoint return -> exit code mapping
+// done by __surge_start: int is forwarded as is, nothing exits with 0.
+func TestVMEntrypointReturnMapsToExitCode(t *testing.T) {
+	requireVMBackend(t)
+	tests := []struct {
+		name   string
+		source string
+		want   int
+	}{
+		{
+			name: "int",
+			source: `@entrypoint
+fn main() -> int {
+    return 7;
+}
+`,
+			want: 7,
+		},
+		{
+			name: "nothing",
+			source: `@entrypoint
+fn main() {
+    let x = 7;
+    let _ = x;
+}
+`,
+			want: 0,
+		},
+		{
+			name: "explicit_nothing",
+			source: `@entrypoint
+fn main() -> nothing {
+    return nothing;
+}
+`,
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := runProgramFromSource(t, tt.source, runOptions{})
+			if res.stderr != "" {
+				t.Fatalf("unexpected VM error:\n%s", res.stderr)
+			}
+			if res.exitCode != tt.want {
+				t.Fatalf("exit code: got %d, want %d", res.exitCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package vm_test
+
+import "testing"
+
+func TestVMTryDivReturnsErringInsteadOfPanicking(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let ok = (7).try_div(2);
+    let q = compare ok {
+        Success(v) => v;
+        _ => -1;
+    };
+    if q != 3 {
+        return 1;
+    }
+    let bad = (7).try_div(0);
+    let code = compare bad {
+        Success(_) => 0:uint;
+        err => err.code;
+    };
+    if code != 3203:uint {
+        return 2;
+    }
+    let rem = (9:uint).try_mod(0:uint);
+    if !rem.is_error() {
+        return 3;
+    }
+    let xs: int[] = [1, 2, 3];
+    let hit = xs.try_get(-1);
+    let miss = xs.try_get(3);
+    if !hit.is_success() || !miss.is_error() {
+        return 4;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("fallible ops returned wrong result, exit code %d", res.exitCode)
+	}
+}
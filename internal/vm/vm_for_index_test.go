@@ -0,0 +1,51 @@
+package vm_test
+
+import "testing"
+
+func TestVMForInIndexCountsFromZero(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let arr: int[] = [10, 20, 30];
+    let mut expected: int = 0;
+    let mut sum: int = 0;
+    for (i, x) in arr {
+        if i != expected {
+            return 1;
+        }
+        if x != arr[i] {
+            return 2;
+        }
+        expected = expected + 1;
+        if i == 1 {
+            continue;
+        }
+        sum = sum + x;
+    }
+    if expected != 3 {
+        return 3;
+    }
+    if sum != 40 {
+        return 4;
+    }
+    let mut seen: int = 0;
+    for (j, v) in 5..8 {
+        if v - j != 5 {
+            return 5;
+        }
+        seen = seen + 1;
+    }
+    if seen != 3 {
+        return 6;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("enumerated for-in mismatch, exit code %d", res.exitCode)
+	}
+}
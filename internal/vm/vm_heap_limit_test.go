@@ -0,0 +1,61 @@
+package vm_test
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/vm"
+)
+
+const allocationLoopSource = `@entrypoint
+fn main() -> int {
+    let mut items: string[] = [];
+    let mut i = 0;
+    while i >= 0 {
+        items.push(i to string);
+        i = i + 1;
+    }
+    return 0;
+}
+`
+
+func TestVMMaxHeapObjectsStopsAllocationLoop(t *testing.T) {
+	mirMod, files, typesInterner := compileToMIRFromSource(t, allocationLoopSource)
+
+	vmInstance := vm.New(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+	vmInstance.MaxHeapObjects = 1000
+	vmErr := vmInstance.Run()
+	if vmErr == nil {
+		t.Fatal("expected out of memory panic")
+	}
+	if vmErr.Code != vm.PanicOutOfMemory {
+		t.Fatalf("expected %s, got %s: %s", vm.PanicOutOfMemory, vmErr.Code, vmErr.Message)
+	}
+	if !strings.Contains(vmErr.Message, "1001 live heap objects exceed the limit of 1000") {
+		t.Fatalf("unexpected message: %q", vmErr.Message)
+	}
+	stats := vmInstance.HeapStats()
+	if stats.LiveObjects < 1000 || stats.LiveObjects > 1001 {
+		t.Fatalf("expected the heap to stop at the cap, got %+v", stats)
+	}
+	if stats.Allocs-stats.Frees != stats.LiveObjects {
+		t.Fatalf("inconsistent heap stats: %+v", stats)
+	}
+}
+
+func TestVMMaxHeapBytesStopsAllocationLoop(t *testing.T) {
+	mirMod, files, typesInterner := compileToMIRFromSource(t, allocationLoopSource)
+
+	vmInstance := vm.New(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+	vmInstance.MaxHeapBytes = 4096
+	vmErr := vmInstance.Run()
+	if vmErr == nil || vmErr.Code != vm.PanicOutOfMemory {
+		t.Fatalf("expected out of memory panic, got %v", vmErr)
+	}
+	if !strings.Contains(vmErr.Message, "live heap bytes exceed the limit of 4096") {
+		t.Fatalf("unexpected message: %q", vmErr.Message)
+	}
+	if stats := vmInstance.HeapStats(); stats.LiveBytes <= 4096 {
+		t.Fatalf("expected live bytes above the cap, got %+v", stats)
+	}
+}
@@ -0,0 +1,76 @@
+package vm_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"surge/internal/vm"
+)
+
+func TestVMHeapReportNoLiveObjectsAfterMain(t *testing.T) {
+	src := `type Point = { x: int, label: string };
+
+@entrypoint
+fn main() -> int {
+    let s: string = "heap" + "report";
+    let items: int[] = [1, 2, 3];
+    let p = Point { x: 1, label: "p" };
+    return 0;
+}
+`
+	mirMod, files, typesInterner := compileToMIRFromSource(t, src)
+
+	var report bytes.Buffer
+	vmInstance := vm.New(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+	vmInstance.HeapReport = &report
+	if vmErr := vmInstance.Run(); vmErr != nil {
+		t.Fatalf("unexpected panic: %s", vmErr.Error())
+	}
+
+	stats := vmInstance.Heap.Stats()
+	if stats.LiveObjects != 0 || stats.LiveBytes != 0 {
+		t.Fatalf("expected no live objects, got %+v", stats)
+	}
+	for _, kind := range []vm.ObjectKind{vm.OKString, vm.OKArray, vm.OKStruct} {
+		ks, ok := stats.ByKind[kind]
+		if !ok || ks.Allocs == 0 {
+			t.Fatalf("expected allocations of kind %d, got %+v", kind, stats.ByKind)
+		}
+		if ks.Allocs != ks.Frees || ks.Live != 0 {
+			t.Fatalf("kind %d: unbalanced stats %+v", kind, ks)
+		}
+	}
+	if stats.Allocs != stats.Frees {
+		t.Fatalf("expected every allocation freed, got %+v", stats)
+	}
+	if !strings.Contains(report.String(), "0 live objects (0 bytes)") {
+		t.Fatalf("unexpected heap report:\n%s", report.String())
+	}
+	if strings.Contains(report.String(), "live #") {
+		t.Fatalf("heap report lists live objects:\n%s", report.String())
+	}
+}
+
+func TestVMHeapReportListsLiveObjects(t *testing.T) {
+	mirMod, files, typesInterner := compileToMIRFromSource(t, allocationLoopSource)
+
+	vmInstance := vm.New(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+	vmInstance.MaxHeapObjects = 100
+	if vmErr := vmInstance.Run(); vmErr == nil || vmErr.Code != vm.PanicOutOfMemory {
+		t.Fatalf("expected out of memory panic, got %v", vmErr)
+	}
+
+	stats := vmInstance.Heap.Stats()
+	if stats.ByKind[vm.OKString].Live == 0 {
+		t.Fatalf("expected live strings, got %+v", stats.ByKind)
+	}
+	var report bytes.Buffer
+	if err := vmInstance.WriteHeapReport(&report); err != nil {
+		t.Fatalf("WriteHeapReport: %v", err)
+	}
+	out := report.String()
+	if !strings.Contains(out, "live #") || !strings.Contains(out, "more live objects") {
+		t.Fatalf("expected live objects in the report:\n%s", out)
+	}
+}
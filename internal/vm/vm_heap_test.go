@@ -131,3 +131,52 @@ func TestVMHeapDoubleFreePanics(t *testing.T) {
 	}()
 	h.Release(handle)
 }
+
+func TestVMHeapDropWhileBorrowedPanics(t *testing.T) {
+	requireVMBackend(t)
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let s: string = "xy";
+    let r = &s;
+    @drop s;
+    return len(r) to int;
+}
+`
+	mirMod, files, typesInterner := compileToMIRFromSource(t, sourceCode)
+	rt := vm.NewTestRuntime(nil, "")
+	_, vmErr := runVM(mirMod, rt, files, typesInterner, nil)
+
+	if vmErr == nil {
+		t.Fatal("expected panic, got nil")
+	}
+	if vmErr.Code != vm.PanicDropWhileBorrowed {
+		t.Fatalf("expected %v, got %v", vm.PanicDropWhileBorrowed, vmErr.Code)
+	}
+	out := vmErr.FormatWithFiles(files)
+	if !strings.Contains(out, "panic VM3303") || !strings.Contains(out, `drop of "s" while borrowed by "r"`) {
+		t.Fatalf("expected panic naming both locals, got:\n%s", out)
+	}
+}
+
+func TestVMHeapDropAfterEndBorrowSucceeds(t *testing.T) {
+	requireVMBackend(t)
+	sourceCode := `@entrypoint
+fn main() -> int {
+    let s: string = "xy";
+    let r = &s;
+    let n: int = len(r) to int;
+    @drop r;
+    @drop s;
+    return n;
+}
+`
+	mirMod, files, typesInterner := compileToMIRFromSource(t, sourceCode)
+	rt := vm.NewTestRuntime(nil, "")
+	exitCode, vmErr := runVM(mirMod, rt, files, typesInterner, nil)
+	if vmErr != nil {
+		t.Fatalf("unexpected VM error: %v", vmErr.Error())
+	}
+	if exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", exitCode)
+	}
+}
@@ -0,0 +1,82 @@
+package vm_test
+
+import "testing"
+
+func TestVMLabeledBreakExitsOuterLoop(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let mut rows: int = 0;
+    let mut cells: int = 0;
+    outer: while rows < 10 {
+        for col in 0..5 {
+            if rows == 2 && col == 3 {
+                break outer;
+            }
+            cells = cells + 1;
+        }
+        rows = rows + 1;
+    }
+    if rows != 2 {
+        return 1;
+    }
+    if cells != 13 {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("labeled break mismatch, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMLabeledContinueSkipsToOuterIteration(t *testing.T) {
+	requireVMBackend(t)
+	// Внешний цикл по диапазону: continue outer обязан выполнить шаг итератора,
+	// иначе цикл зациклится на первой итерации.
+	source := `@entrypoint
+fn main() -> int {
+    let mut outerRuns: int = 0;
+    let mut inner: int = 0;
+    outer: for i in 0..4 {
+        outerRuns = outerRuns + 1;
+        for (let mut j: int = 0; j < 5; j = j + 1) {
+            if j > i {
+                continue outer;
+            }
+            inner = inner + 1;
+        }
+        return 10;
+    }
+    if outerRuns != 4 {
+        return 1;
+    }
+    if inner != 10 {
+        return 2;
+    }
+    let mut total: int = 0;
+    rows: for (let mut r: int = 0; r < 3; r = r + 1) {
+        while true {
+            total = total + 1;
+            continue rows;
+        }
+    }
+    if total != 3 {
+        return 3;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("labeled continue mismatch, exit code %d", res.exitCode)
+	}
+}
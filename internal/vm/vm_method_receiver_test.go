@@ -0,0 +1,130 @@
+package vm_test
+
+import "testing"
+
+func TestVMMethodReceiverAutoBorrowAndDeref(t *testing.T) {
+	requireVMBackend(t)
+	source := `@copy
+type P = { x: int };
+
+extern<P> {
+    fn get(self: P) -> int { return self.x; }
+    fn bump(self: &mut P) -> nothing { self.x = self.x + 1; }
+}
+
+type Grid = { cells: int[] };
+
+extern<Grid> {
+    fn set(self: &mut Grid, i: int, v: int) -> nothing { self.cells[i] = v; }
+}
+
+fn fill(g: &mut Grid) -> nothing {
+    g.set(0, 5);
+}
+
+fn read(p: &P) -> int { return p.get(); }
+
+@entrypoint
+fn main() -> int {
+    let mut p: P = { x = 1 };
+    p.bump();
+    let mut g: Grid = { cells = [0, 0] };
+    fill(&mut g);
+    g.set(1, 7);
+    if read(&p) != 2 {
+        return 1;
+    }
+    if g.cells[0] != 5 || g.cells[1] != 7 {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("receiver adjustment failed, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMMethodReceiverArrayFieldThroughMutRef(t *testing.T) {
+	requireVMBackend(t)
+	source := `type Entry = { name: string, borrowers: string[] };
+
+extern<Entry> {
+    fn lend(self: &mut Entry, who: string) -> nothing {
+        self.borrowers.push(who);
+    }
+}
+
+fn record(entry: &mut Entry, who: string) -> nothing {
+    entry.borrowers.push(who);
+}
+
+@entrypoint
+fn main() -> int {
+    let mut entry: Entry = { name = "book", borrowers = [] };
+    record(&mut entry, "ann");
+    entry.lend("bob");
+    let mut shelf: Entry[] = [{ name = "map", borrowers = [] }];
+    shelf[0].lend("cy");
+    shelf[0].borrowers.push("dan");
+    if len(entry.borrowers) != 2 || entry.borrowers[0] != "ann" || entry.borrowers[1] != "bob" {
+        return 1;
+    }
+    if len(shelf[0].borrowers) != 2 || shelf[0].borrowers[1] != "dan" {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("array field was not mutated in place, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMMethodReceiverSharedReborrowOfMutRef(t *testing.T) {
+	requireVMBackend(t)
+	source := `type Grid = { cells: int[] };
+
+extern<Grid> {
+    fn first(self: &Grid) -> int { return self.cells[0]; }
+}
+
+type Holder = { g: &mut Grid, inner: Grid };
+
+fn via_param(g: &mut Grid) -> int {
+    return g.first();
+}
+
+fn via_field(h: &mut Holder) -> int {
+    return h.g.first() + h.inner.first();
+}
+
+@entrypoint
+fn main() -> int {
+    let mut g: Grid = { cells = [3] };
+    if via_param(&mut g) != 3 {
+        return 1;
+    }
+    let mut h: Holder = { g = &mut g, inner = { cells = [4] } };
+    if via_field(&mut h) != 7 {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("shared reborrow of a &mut receiver failed, exit code %d", res.exitCode)
+	}
+}
@@ -0,0 +1,31 @@
+package vm_test
+
+import "testing"
+
+func TestVMNumericSeparatorsValues(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    if 1_000_000 != 1000000 {
+        return 1;
+    }
+    if 0xFF_FF != 65535 {
+        return 2;
+    }
+    if 0b1010_0101 != 165 {
+        return 3;
+    }
+    if 3.14_15 != 3.1415 {
+        return 4;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("numeric separator mismatch, exit code %d", res.exitCode)
+	}
+}
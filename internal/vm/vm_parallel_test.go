@@ -0,0 +1,99 @@
+package vm_test
+
+import "testing"
+
+func TestVMParallelMapKeepsElementOrder(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let xs: int[] = [1, 2, 3, 4, 5];
+    let offset = 10;
+    let ys = parallel map xs with (x) => x * x + offset;
+    if ys.__len() != 5 {
+        return 1;
+    }
+    let want: int[] = [11, 14, 19, 26, 35];
+    let mut i: int = 0;
+    while i < 5 {
+        if ys[i] != want[i] {
+            return 2 + i;
+        }
+        i = i + 1;
+    }
+    let empty: int[] = [];
+    let none = parallel map empty with (x) => x + 1;
+    if none.__len() != 0 {
+        return 10;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("parallel map mismatch, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMParallelReduceSumsValues(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let xs: int[] = [1, 2, 3, 4, 5];
+    let total = parallel reduce xs with 0, (acc, x) => acc + x;
+    if total != 15 {
+        return 1;
+    }
+    let empty: int[] = [];
+    let seed = parallel reduce empty with 7, (acc, x) => acc + x;
+    if seed != 7 {
+        return 2;
+    }
+    let squares = parallel reduce 1..4 with 0, (acc, x) => acc + x * x;
+    if squares != 14 {
+        return 3;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("parallel reduce mismatch, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMParallelStepsKeepOrderWithHeapValues(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let words: string[] = ["a", "b", "c"];
+    let suffix = "!";
+    let loud = parallel map words with (w) => w + suffix;
+    if loud[0] != "a!" || loud[1] != "b!" || loud[2] != "c!" {
+        return 1;
+    }
+    let digits: int[] = [1, 2, 3, 4];
+    let number = parallel reduce digits with 0, (acc, d) => acc * 10 + d;
+    if number != 1234 {
+        return 2;
+    }
+    let joined = parallel reduce words with "", (acc, w) => acc + w;
+    if joined != "abc" {
+        return 3;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("parallel step order mismatch, exit code %d", res.exitCode)
+	}
+}
@@ -0,0 +1,68 @@
+package vm_test
+
+import "testing"
+
+func TestVMRangeForYieldsBounds(t *testing.T) {
+	requireVMBackend(t)
+	// Цифры складываются в число: 0,1,2 -> 12, 0,1,2,3 -> 123.
+	source := `@entrypoint
+fn main() -> int {
+    let mut exclusive = 0;
+    for x in 0..3 {
+        exclusive = exclusive * 10 + x;
+    }
+    if exclusive != 12 {
+        return 1;
+    }
+    let mut inclusive = 0;
+    for x in 0..=3 {
+        inclusive = inclusive * 10 + x;
+    }
+    if inclusive != 123 {
+        return 2;
+    }
+    let r = 0..=3;
+    let mut stored = 0;
+    for x in r {
+        stored = stored * 10 + x;
+    }
+    if stored != 123 {
+        return 3;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("range iteration mismatch, exit code %d", res.exitCode)
+	}
+}
+
+func TestVMRangeForEmptyRangeSkipsBody(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let mut runs = 0;
+    for _ in 3..3 {
+        runs = runs + 1;
+    }
+    for _ in 3..1 {
+        runs = runs + 1;
+    }
+    for _ in 3..=2 {
+        runs = runs + 1;
+    }
+    return runs;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("empty ranges ran the body %d times", res.exitCode)
+	}
+}
@@ -0,0 +1,27 @@
+package vm_test
+
+import "testing"
+
+func TestVMRawStringSkipsEscapeDecoding(t *testing.T) {
+	requireVMBackend(t)
+	source := `@entrypoint
+fn main() -> int {
+    let path = r"C:\path\to";
+    if path != "C:\\path\\to" {
+        return 1;
+    }
+    let quoted = r#"say "hi" \n"#;
+    if quoted != "say \"hi\" \\n" {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("raw string mismatch, exit code %d", res.exitCode)
+	}
+}
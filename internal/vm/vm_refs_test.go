@@ -262,3 +262,32 @@ fn main() -> nothing {
 		t.Fatalf("expected final projection to be deref, got %v", lastProj.Kind)
 	}
 }
+
+func TestVMRefsReborrowOfFieldThroughMutRefHasNoStrayDeref(t *testing.T) {
+	sourceCode := `type Entry = { name: string, borrowers: string[] };
+
+fn tally(xs: &string[]) -> int {
+    return len(xs) to int;
+}
+
+fn total(entry: &mut Entry) -> int {
+    let r: &string[] = &*entry.borrowers;
+    return tally(r);
+}
+
+@entrypoint
+fn main() -> int {
+    let mut e: Entry = { name = "a", borrowers = ["x"] };
+    return total(&mut e);
+}
+`
+	mirMod, _, typesIn := compileToMIRFromSource(t, sourceCode)
+	if err := mir.Verify(mirMod, typesIn); err != nil {
+		t.Fatalf("MIR verification failed:\n%v", err)
+	}
+
+	result := runProgramFromSource(t, sourceCode, runOptions{})
+	if result.exitCode != 1 {
+		t.Fatalf("expected exit code 1, got %d\nstderr:\n%s", result.exitCode, result.stderr)
+	}
+}
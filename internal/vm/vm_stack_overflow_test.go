@@ -0,0 +1,66 @@
+package vm_test
+
+import (
+	"strings"
+	"testing"
+
+	"surge/internal/vm"
+)
+
+const unboundedRecursionSource = `fn down(n: int) -> int {
+    return down(n + 1) + 1;
+}
+
+@entrypoint
+fn main() -> int {
+    return down(0);
+}
+`
+
+func TestVMUnboundedRecursionPanicsWithStackOverflow(t *testing.T) {
+	mirMod, files, typesInterner := compileToMIRFromSource(t, unboundedRecursionSource)
+
+	vmInstance := vm.New(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+	vmErr := vmInstance.Run()
+	if vmErr == nil {
+		t.Fatal("expected stack overflow panic")
+	}
+	if vmErr.Code != vm.PanicStackOverflow {
+		t.Fatalf("expected %s, got %s: %s", vm.PanicStackOverflow, vmErr.Code, vmErr.Message)
+	}
+	if !strings.Contains(vmErr.Message, "limit of 10000 frames") {
+		t.Fatalf("unexpected message: %q", vmErr.Message)
+	}
+	if len(vmErr.Backtrace) != 11 {
+		t.Fatalf("expected a truncated backtrace of 11 frames, got %d", len(vmErr.Backtrace))
+	}
+
+	out := vmErr.FormatWithFiles(files)
+	for _, want := range []string{
+		"panic VM1007: stack overflow: call to down",
+		"  0: down at ",
+		"  ... 9990 frames omitted\n",
+		"  9998: main at ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestVMMaxStackDepthOption(t *testing.T) {
+	mirMod, files, typesInterner := compileToMIRFromSource(t, unboundedRecursionSource)
+
+	vmInstance := vm.New(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+	vmInstance.Options.MaxStackDepth = 5
+	vmErr := vmInstance.Run()
+	if vmErr == nil || vmErr.Code != vm.PanicStackOverflow {
+		t.Fatalf("expected stack overflow panic, got %v", vmErr)
+	}
+	if len(vmErr.Backtrace) != 5 {
+		t.Fatalf("expected the full 5-frame backtrace, got %d", len(vmErr.Backtrace))
+	}
+	if !strings.Contains(vmErr.Message, "limit of 5 frames") {
+		t.Fatalf("unexpected message: %q", vmErr.Message)
+	}
+}
@@ -0,0 +1,60 @@
+package vm_test
+
+import "testing"
+
+func TestVMStructSpreadOverridesOneField(t *testing.T) {
+	requireVMBackend(t)
+	source := `type Point = { x: int, y: int, name: string };
+
+@entrypoint
+fn main() -> int {
+    let p: Point = { x = 1, y = 2, name = "p" };
+    let q: Point = { ..p, y = 7 };
+    if q.x != 1 || q.y != 7 || q.name != "p" {
+        return 1;
+    }
+    if p.y != 2 || p.name != "p" {
+        return 2;
+    }
+    let r = Point { ..q, name = "r" };
+    if r.x != 1 || r.y != 7 || r.name != "r" || q.name != "p" {
+        return 3;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected VM error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("struct spread produced wrong fields, exit code %d", res.exitCode)
+	}
+}
+
+func TestStructSpreadDoesNotShareArrayFieldWithBase(t *testing.T) {
+	source := `type Config = { name: string, tags: int[], level: int };
+
+@entrypoint
+fn main() -> int {
+    let base: Config = { name = "base", tags = [1, 2], level = 1 };
+    let mut derived: Config = { ..base, level = 2 };
+    derived.tags[0] = 9;
+    derived.tags.push(3);
+    if base.tags[0] != 1 || len(base.tags) != 2 {
+        return 1;
+    }
+    if derived.tags[0] != 9 || len(derived.tags) != 3 || derived.level != 2 {
+        return 2;
+    }
+    return 0;
+}
+`
+	res := runProgramFromSource(t, source, runOptions{})
+	if res.stderr != "" {
+		t.Fatalf("unexpected error:\n%s", res.stderr)
+	}
+	if res.exitCode != 0 {
+		t.Fatalf("spread result shares its array field with base, exit code %d", res.exitCode)
+	}
+}
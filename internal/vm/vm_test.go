@@ -16,7 +16,7 @@ import (
 )
 
 // compileToMIR compiles a .sg file to MIR.
-func compileToMIR(t *testing.T, filePath string) (*mir.Module, *source.FileSet, *types.Interner) {
+func compileToMIR(t testing.TB, filePath string) (*mir.Module, *source.FileSet, *types.Interner) {
 	t.Helper()
 
 	opts := driver.DiagnoseOptions{
@@ -64,7 +64,7 @@ func compileToMIR(t *testing.T, filePath string) (*mir.Module, *source.FileSet,
 		t.Fatalf("monomorphization failed: %v", err)
 	}
 
-	mirMod, err := mir.LowerModule(mm, result.Sema)
+	mirMod, err := mir.LowerModuleWithOptions(mm, result.Sema, mir.LowerOptions{ParallelRuntime: true})
 	if err != nil {
 		t.Fatalf("MIR lowering failed: %v", err)
 	}
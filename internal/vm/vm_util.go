@@ -44,6 +44,10 @@ func (vm *VM) resolveCallTarget(frame *Frame, call *mir.CallInstr) *mir.Func {
 	if name == "" {
 		return nil
 	}
+	if strings.HasPrefix(name, "__async_block$") {
+		// Async blocks are lowered to synthetic functions without a symbol.
+		return vm.findFunction(name)
+	}
 	if shouldDeferToIntrinsicFallback(name) {
 		return nil
 	}
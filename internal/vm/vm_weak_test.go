@@ -0,0 +1,80 @@
+package vm_test
+
+import (
+	"testing"
+
+	"surge/internal/vm"
+)
+
+func runWeakProgram(t *testing.T, src string) *vm.VM {
+	t.Helper()
+	mirMod, files, typesInterner := compileToMIRFromSource(t, src)
+	vmInstance := vm.New(mirMod, vm.NewTestRuntime(nil, ""), files, typesInterner, nil)
+	if vmErr := vmInstance.Run(); vmErr != nil {
+		t.Fatalf("unexpected panic: %s", vmErr.FormatWithFiles(files))
+	}
+	return vmInstance
+}
+
+func TestVMWeakBackReferenceFreesCycle(t *testing.T) {
+	vmInstance := runWeakProgram(t, `type Child = { parent: Option<Weak<Parent>>, v: int };
+type Parent = { name: string, child: Child };
+
+fn parent_name(c: &Child) -> string {
+    return compare c.parent {
+        Some(w) => compare w.upgrade() {
+            Some(p) => p.name;
+            nothing => "dropped";
+        };
+        nothing => "orphan";
+    };
+}
+
+fn weak_parent(p: &Parent) -> Weak<Parent> {
+    return downgrade(p);
+}
+
+fn make_parent() -> Parent {
+    let mut p = Parent { name: "root", child: Child { parent: nothing, v: 1 } };
+    let back = weak_parent(&p);
+    p.child.parent = Some(back);
+    return p;
+}
+
+fn back_name() -> string {
+    let p = make_parent();
+    return parent_name(&p.child);
+}
+
+fn dangling() -> Weak<Parent> {
+    let p = make_parent();
+    return weak_parent(&p);
+}
+
+@entrypoint
+fn main() -> int {
+    if back_name() != "root" {
+        return 1;
+    }
+    let w = dangling();
+    let copy = w;
+    if copy != w {
+        return 2;
+    }
+    let label = w to string;
+    if label != "weak(dropped)" {
+        return 3;
+    }
+    return compare w.upgrade() {
+        Some(_) => 4;
+        nothing => 0;
+    };
+}
+`)
+	if code := vmInstance.ExitCode; code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	if live := vmInstance.Heap.LiveCount(); live != 0 {
+		t.Fatalf("expected the parent/child cycle to be freed, %d objects alive", live)
+	}
+}
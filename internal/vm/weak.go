@@ -0,0 +1,178 @@
+package vm
+
+import (
+	"fmt"
+
+	"surge/internal/mir"
+	"surge/internal/types"
+)
+
+// Weak references point at a heap object without retaining it, so a back-edge
+// stored as a weak value does not keep a cycle alive. Handles are never reused
+// within a run and freed objects stay in the heap table, which lets a weak
+// reference tell whether its referent has been dropped.
+
+// Downgrade returns a weak reference to the heap value v.
+// v keeps its own strong reference; the weak value is not released on drop.
+func (h *Heap) Downgrade(v Value) Value {
+	if !v.IsHeap() || v.H == 0 {
+		h.panic(PanicTypeMismatch, fmt.Sprintf("cannot downgrade %s to a weak reference", v.Kind))
+	}
+	h.Get(v.H)
+	return Value{
+		TypeID: v.TypeID,
+		Kind:   VKWeak,
+		H:      v.H,
+	}
+}
+
+// Upgrade returns a new strong reference to the referent of w, or nothing if
+// the referent has already been freed. The caller owns the returned reference.
+func (h *Heap) Upgrade(w Value) Value {
+	if w.Kind != VKWeak {
+		h.panic(PanicTypeMismatch, fmt.Sprintf("cannot upgrade %s: not a weak reference", w.Kind))
+	}
+	obj, ok := h.lookup(w.H)
+	if !ok || obj.Freed || obj.RefCount == 0 {
+		return MakeNothing()
+	}
+	kind, ok := strongValueKind(obj.Kind)
+	if !ok {
+		h.panic(PanicTypeMismatch, fmt.Sprintf("cannot upgrade weak reference to %v object", obj.Kind))
+	}
+	h.Retain(w.H)
+	return Value{
+		TypeID: w.TypeID,
+		Kind:   kind,
+		H:      w.H,
+	}
+}
+
+// handleDowngrade implements `downgrade<T>(value: &T) -> Weak<T>`.
+func (vm *VM) handleDowngrade(frame *Frame, call *mir.CallInstr, writes *[]LocalWrite) *VMError {
+	if call == nil || !call.HasDst {
+		return vm.eb.makeError(PanicUnimplemented, "downgrade missing destination")
+	}
+	if len(call.Args) != 1 {
+		return vm.eb.makeError(PanicTypeMismatch, "downgrade expects 1 argument")
+	}
+	arg, vmErr := vm.evalOperand(frame, &call.Args[0])
+	if vmErr != nil {
+		return vmErr
+	}
+	defer vm.dropValue(arg)
+	target := arg
+	if arg.Kind == VKRef || arg.Kind == VKRefMut {
+		target, vmErr = vm.loadLocationRaw(arg.Loc)
+		if vmErr != nil {
+			return vmErr
+		}
+	}
+	if !target.IsHeap() || target.H == 0 {
+		return vm.eb.typeMismatch("&heap value", target.Kind.String())
+	}
+	if _, vmErr := vm.heapAliveForRef(target.H); vmErr != nil {
+		return vmErr
+	}
+	dstLocal := call.Dst.Local
+	weak := vm.Heap.Downgrade(target)
+	weak.TypeID = frame.Locals[dstLocal].TypeID
+	return vm.writeWeakResult(frame, dstLocal, weak, writes)
+}
+
+// handleWeakUpgrade implements `Weak<T>.upgrade(self: &Weak<T>) -> Option<T>`.
+func (vm *VM) handleWeakUpgrade(frame *Frame, call *mir.CallInstr, writes *[]LocalWrite) *VMError {
+	if call == nil || !call.HasDst {
+		return vm.eb.makeError(PanicUnimplemented, "upgrade missing destination")
+	}
+	if len(call.Args) != 1 {
+		return vm.eb.makeError(PanicTypeMismatch, "upgrade expects 1 argument")
+	}
+	arg, vmErr := vm.evalOperand(frame, &call.Args[0])
+	if vmErr != nil {
+		return vmErr
+	}
+	weak := arg
+	if arg.Kind == VKRef || arg.Kind == VKRefMut {
+		weak, vmErr = vm.loadLocationRaw(arg.Loc)
+		if vmErr != nil {
+			return vmErr
+		}
+	}
+	if weak.Kind != VKWeak {
+		return vm.eb.typeMismatch("Weak<T>", weak.Kind.String())
+	}
+	dstLocal := call.Dst.Local
+	dstType := frame.Locals[dstLocal].TypeID
+	var res Value
+	if strong := vm.Heap.Upgrade(weak); strong.Kind == VKNothing {
+		res, vmErr = vm.makeOptionNothing(dstType)
+	} else {
+		// тип payload берётся из Option<T>, а не из Weak<T>
+		strong.TypeID = types.NoTypeID
+		res, vmErr = vm.makeOptionSome(dstType, strong)
+		if vmErr != nil {
+			vm.dropValue(strong)
+		}
+	}
+	if vmErr != nil {
+		return vmErr
+	}
+	return vm.writeWeakResult(frame, dstLocal, res, writes)
+}
+
+func (vm *VM) writeWeakResult(frame *Frame, dst mir.LocalID, val Value, writes *[]LocalWrite) *VMError {
+	if vmErr := vm.writeLocal(frame, dst, val); vmErr != nil {
+		vm.dropValue(val)
+		return vmErr
+	}
+	if writes != nil {
+		*writes = append(*writes, LocalWrite{
+			LocalID: dst,
+			Name:    frame.Locals[dst].Name,
+			Value:   val,
+		})
+	}
+	return nil
+}
+
+// LiveCount returns the number of heap objects that are still alive.
+func (h *Heap) LiveCount() int {
+	if h == nil {
+		return 0
+	}
+	h.initIfNeeded()
+	count := 0
+	for handle := Handle(1); handle < h.next; handle++ {
+		obj, ok := h.lookup(handle)
+		if ok && !obj.Freed && obj.RefCount != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func strongValueKind(kind ObjectKind) (ValueKind, bool) {
+	switch kind {
+	case OKString:
+		return VKHandleString, true
+	case OKArray, OKArraySlice:
+		return VKHandleArray, true
+	case OKMap:
+		return VKHandleMap, true
+	case OKStruct:
+		return VKHandleStruct, true
+	case OKTag:
+		return VKHandleTag, true
+	case OKRange:
+		return VKHandleRange, true
+	case OKBigInt:
+		return VKBigInt, true
+	case OKBigUint:
+		return VKBigUint, true
+	case OKBigFloat:
+		return VKBigFloat, true
+	default:
+		return VKInvalid, false
+	}
+}
@@ -0,0 +1,76 @@
+package vm
+
+import (
+	"testing"
+
+	"surge/internal/source"
+	"surge/internal/types"
+)
+
+// newParentChild builds parent.child -> child (strong) and child.parent -> parent
+// (weak or strong), leaving the caller with the only outside reference to parent.
+func newParentChild(vm *VM, weakBack bool) Value {
+	builtins := vm.Types.Builtins()
+	child := vm.Heap.AllocStruct(types.NoTypeID, []Value{MakeNothing(), MakeInt(2, builtins.Int)})
+	parent := MakeHandleStruct(vm.Heap.AllocStruct(types.NoTypeID, []Value{MakeHandleStruct(child, types.NoTypeID)}), types.NoTypeID)
+	if weakBack {
+		vm.Heap.Get(child).Fields[0] = vm.Heap.Downgrade(parent)
+	} else {
+		vm.Heap.Retain(parent.H)
+		vm.Heap.Get(child).Fields[0] = parent
+	}
+	return parent
+}
+
+func TestWeakBackReferenceFreesCycleOnDrop(t *testing.T) {
+	vm := New(nil, NewTestRuntime(nil, ""), source.NewFileSet(), types.NewInterner(), nil)
+	parent := newParentChild(vm, true)
+	child := vm.Heap.Get(parent.H).Fields[0].H
+	back := vm.Heap.Get(child).Fields[0]
+
+	if got := vm.Heap.LiveCount(); got != 2 {
+		t.Fatalf("expected 2 live objects, got %d", got)
+	}
+	up := vm.Heap.Upgrade(back)
+	if up.Kind != VKHandleStruct || up.H != parent.H {
+		t.Fatalf("expected weak reference to upgrade to the parent, got %s", up)
+	}
+	if rc := vm.Heap.Get(parent.H).RefCount; rc != 2 {
+		t.Fatalf("upgrade should retain the parent, refcount %d", rc)
+	}
+	vm.dropValue(up)
+	if rc := vm.Heap.Get(parent.H).RefCount; rc != 1 {
+		t.Fatalf("weak reference must not retain the parent, refcount %d", rc)
+	}
+
+	vm.dropValue(parent)
+	if got := vm.Heap.LiveCount(); got != 0 {
+		t.Fatalf("expected the cycle to be freed, %d objects still alive", got)
+	}
+	if got := vm.Heap.Upgrade(back); got.Kind != VKNothing {
+		t.Fatalf("expected a dangling weak reference to read as nothing, got %s", got)
+	}
+	vm.dropValue(back)
+}
+
+func TestStrongBackReferenceKeepsCycleAlive(t *testing.T) {
+	vm := New(nil, NewTestRuntime(nil, ""), source.NewFileSet(), types.NewInterner(), nil)
+	parent := newParentChild(vm, false)
+
+	vm.dropValue(parent)
+	if got := vm.Heap.LiveCount(); got != 2 {
+		t.Fatalf("expected the strong cycle to leak 2 objects, got %d", got)
+	}
+}
+
+func TestDowngradeRejectsNonHeapValues(t *testing.T) {
+	vm := New(nil, NewTestRuntime(nil, ""), source.NewFileSet(), types.NewInterner(), nil)
+	defer func() {
+		r := recover()
+		vmErr, ok := r.(*VMError)
+		if !ok || vmErr.Code != PanicTypeMismatch {
+			t.Fatalf("expected %v panic, got %v", PanicTypeMismatch, r)
+		}
+	}()
+	vm.Heap.Downgrade(MakeInt(1, vm.Types.Builtins().Int))
+}
@@ -7,28 +7,6 @@ import (
 	"surge/internal/vm/bignum"
 )
 
-func checkSignedWidth(value int64, width types.Width) bool {
-	if width == types.WidthAny {
-		return true
-	}
-	minVal, maxVal, ok := intRangeForWidth(width)
-	if !ok {
-		return true
-	}
-	return value >= minVal && value <= maxVal
-}
-
-func checkUnsignedWidth(value uint64, width types.Width) bool {
-	if width == types.WidthAny {
-		return true
-	}
-	maxVal, ok := uintMaxForWidth(width)
-	if !ok {
-		return true
-	}
-	return value <= maxVal
-}
-
 func widthBits(width types.Width) (int, bool) {
 	switch width {
 	case types.Width8:
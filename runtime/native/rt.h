@@ -13,12 +13,25 @@ void* rt_alloc(uint64_t size, uint64_t align);
 void rt_free(uint8_t* ptr, uint64_t size, uint64_t align);
 void* rt_realloc(uint8_t* ptr, uint64_t old_size, uint64_t new_size, uint64_t align);
 void rt_memcpy(uint8_t* dst, const uint8_t* src, uint64_t n);
+// Kinds accepted by rt_release; the LLVM backend emits them for `@drop`.
+enum {
+    RT_RELEASE_BLOCK = 0,  // struct/tuple/tag/fixed array block of `size` bytes
+    RT_RELEASE_STRING = 1, // string handle, size read from the header
+    RT_RELEASE_ARRAY = 2,  // dynamic array; `size`/`align` describe one element
+    RT_RELEASE_TASK = 3,   // reference-counted task handle
+};
+void rt_release(void* ptr, uint64_t kind, uint64_t size, uint64_t align);
+void rt_string_release(void* s);
+void rt_array_release(void* header, uint64_t elem_stride, uint64_t elem_align);
 void rt_memmove(uint8_t* dst, const uint8_t* src, uint64_t n);
 void rt_array_forget_allocation(const void* ptr);
 bool rt_array_is_view(const void* header);
 void* rt_array_slice(void* array_slot, void* r, uint64_t elem_stride);
 void* rt_array_slice_fixed(void* data_slot, void* r, uint64_t length, uint64_t elem_stride);
 void rt_array_sync_views(void* array_header);
+typedef void (*rt_clone_fn)(void* dst, const void* src);
+void rt_clone_elems(void* dst, const void* src, uint64_t count, uint64_t elem_stride, void* clone_fn);
+void* rt_array_clone(const void* header, uint64_t elem_stride, uint64_t elem_align, void* clone_fn);
 void rt_array_append_raw_bytes(void* array_slot, const uint8_t* src, uint64_t len);
 void rt_byte_array_append_range(void* dst_slot,
                                 const void* src_array,
@@ -43,10 +56,14 @@ void rt_term_write(const void* bytes);
 void rt_term_flush(void);
 void* rt_term_read_event(void);
 void* rt_readline(void);
+void rt_init(int32_t argc, char** argv);
 void rt_exit(int64_t code);
+int32_t rt_finish(int64_t code);
 void rt_panic(const uint8_t* ptr, uint64_t length);
 void rt_panic_numeric(const uint8_t* ptr, uint64_t length);
 void rt_panic_bounds(uint64_t kind, int64_t index, int64_t length);
+void rt_panic_div_zero(void);
+void rt_panic_overflow(void);
 int64_t rt_monotonic_now(void);
 uint64_t rt_worker_count(void);
 void* rt_heap_stats(void);
@@ -104,6 +121,7 @@ uint64_t rt_string_len_bytes(void* s);
 uint32_t rt_string_index(void* s, int64_t index);
 void* rt_string_slice(void* s, void* r);
 void* rt_string_bytes_view(void* s);
+void* rt_string_clone(void* s);
 void* rt_string_concat(void* a, void* b);
 void* rt_string_repeat(void* s, int64_t count);
 bool rt_string_eq(void* a, void* b);
@@ -181,7 +199,20 @@ uint8_t rt_task_poll(void* task, uint64_t* out_bits);
 void rt_task_await(void* task, uint8_t* out_kind, uint64_t* out_bits);
 void rt_task_cancel(void* task);
 void* rt_task_clone(void* task);
+void rt_task_release(void* task);
 void* rt_blocking_submit(uint64_t fn_id, void* state, uint64_t state_size, uint64_t state_align);
+void* rt_parallel_map(void* array,
+                      uint64_t in_stride,
+                      uint64_t out_stride,
+                      uint64_t out_align,
+                      void* fn,
+                      void* state);
+void rt_parallel_reduce(void* array, uint64_t in_stride, void* acc, void* fn, void* state);
+void* rt_debug_quote(void* s);
+void* rt_debug_array(const uint8_t* data, uint64_t len, uint64_t stride, void* fmt);
+void* rt_debug_struct(void* name, uint64_t count, void** names, void** values);
+void* rt_debug_tuple(uint64_t count, void** values);
+void* rt_debug_tag(void* name, uint64_t count, void** values);
 uint8_t rt_timeout_poll(void* task, uint64_t ms, uint64_t* out_bits);
 int64_t rt_select_poll_tasks(uint64_t count, void** tasks, int64_t default_index);
 int64_t rt_select_poll(uint64_t count,
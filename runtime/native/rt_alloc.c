@@ -129,6 +129,28 @@ void* rt_heap_stats(void) {
     return stats;
 }
 
+// rt_release frees the heap block owned by a dropped value. Nested handles are
+// not followed: only the top-level block is released.
+void rt_release(void* ptr, uint64_t kind, uint64_t size, uint64_t align) {
+    if (ptr == NULL) {
+        return;
+    }
+    switch (kind) {
+    case RT_RELEASE_STRING:
+        rt_string_release(ptr);
+        break;
+    case RT_RELEASE_ARRAY:
+        rt_array_release(ptr, size, align);
+        break;
+    case RT_RELEASE_TASK:
+        rt_task_release(ptr);
+        break;
+    default:
+        rt_free((uint8_t*)ptr, size, align);
+        break;
+    }
+}
+
 void rt_memcpy(uint8_t* dst, const uint8_t* src, uint64_t n) {
     if (n == 0) {
         return;
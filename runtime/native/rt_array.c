@@ -101,6 +101,69 @@ void rt_array_forget_allocation(const void* ptr) {
     }
 }
 
+// rt_array_release frees a dynamic array header and, unless the header is a
+// view into another array, its element storage.
+void rt_array_release(void* header, uint64_t elem_stride, uint64_t elem_align) {
+    SurgeArrayHeader* arr = (SurgeArrayHeader*)header;
+    if (arr == NULL) {
+        return;
+    }
+    if (!array_is_view(arr) && arr->data != NULL) {
+        uint64_t size = arr->cap;
+        if (elem_stride != 0 && size <= UINT64_MAX / elem_stride) {
+            size *= elem_stride;
+        }
+        rt_free((uint8_t*)arr->data, size, elem_align);
+    }
+    rt_free((uint8_t*)arr, (uint64_t)sizeof(SurgeArrayHeader), (uint64_t)alignof(SurgeArrayHeader));
+}
+
+// rt_clone_elems copies count elements from src to dst. clone_fn deep-copies
+// one element; NULL means the elements are plain bytes.
+void rt_clone_elems(void* dst, const void* src, uint64_t count, uint64_t elem_stride, void* clone_fn) {
+    if (dst == NULL || src == NULL || count == 0) {
+        return;
+    }
+    if (clone_fn == NULL) {
+        rt_memcpy((uint8_t*)dst, (const uint8_t*)src, count * elem_stride);
+        return;
+    }
+    rt_clone_fn fn = (rt_clone_fn)clone_fn;
+    for (uint64_t i = 0; i < count; i++) {
+        fn((uint8_t*)dst + i * elem_stride, (const uint8_t*)src + i * elem_stride);
+    }
+}
+
+// rt_array_clone returns an owning copy of a dynamic array (or slice view)
+// with capacity equal to its length.
+void* rt_array_clone(const void* header, uint64_t elem_stride, uint64_t elem_align, void* clone_fn) {
+    const SurgeArrayHeader* src = (const SurgeArrayHeader*)header;
+    SurgeArrayHeader* out = (SurgeArrayHeader*)rt_alloc((uint64_t)sizeof(SurgeArrayHeader),
+                                                        (uint64_t)alignof(SurgeArrayHeader));
+    if (out == NULL) {
+        array_panic("array allocation failed");
+        return NULL;
+    }
+    uint64_t len = src != NULL ? src->len : 0;
+    out->len = len;
+    out->cap = len;
+    out->data = NULL;
+    if (len == 0) {
+        return out;
+    }
+    if (elem_stride != 0 && len > UINT64_MAX / elem_stride) {
+        array_panic("array capacity out of range");
+        return NULL;
+    }
+    out->data = rt_alloc(len * elem_stride, elem_align);
+    if (out->data == NULL) {
+        array_panic("array allocation failed");
+        return NULL;
+    }
+    rt_clone_elems(out->data, src->data, len, elem_stride, clone_fn);
+    return out;
+}
+
 void rt_array_sync_views(void* array_header) {
     SurgeArrayHeader* base = (SurgeArrayHeader*)array_header;
     if (base == NULL) {
@@ -197,6 +197,20 @@ void rt_task_await(void* task, uint8_t* out_kind, uint64_t* out_bits) {
     rt_unlock(ex);
 }
 
+void rt_task_release(void* task) {
+    rt_task* target = task_from_handle(task);
+    if (target == NULL) {
+        return;
+    }
+    rt_executor* ex = ensure_exec();
+    if (ex == NULL) {
+        return;
+    }
+    rt_lock(ex);
+    task_release(ex, target);
+    rt_unlock(ex);
+}
+
 void rt_task_cancel(void* task) {
     rt_executor* ex = ensure_exec();
     if (ex == NULL) {
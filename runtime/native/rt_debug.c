@@ -0,0 +1,281 @@
+#include "rt.h"
+
+#include <stdbool.h>
+#include <stdint.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+// Debug formatting behind `to string` on arrays, structs, tuples and tags in the LLVM
+// backend. The output matches the VM (internal/vm/intrinsic_to_debug.go):
+//
+//   [1, 2, 3]   Point { x: 1, y: 2 }   (1, "a")   Some(3)   nothing
+//
+// Compiler-emitted formatters render each element, field and payload into a
+// fresh string; the helpers below copy those parts into the result and release
+// them. All string arguments are handle values, not handle addresses.
+
+typedef void* (*rt_debug_fmt_fn)(const void* value);
+
+typedef struct rt_debug_buf {
+    uint8_t* data;
+    size_t len;
+    size_t cap;
+} rt_debug_buf;
+
+static void debug_panic(const char* msg) {
+    rt_panic((const uint8_t*)msg, (uint64_t)strlen(msg));
+}
+
+static void debug_reserve(rt_debug_buf* b, size_t extra) {
+    if (extra > SIZE_MAX - b->len) {
+        debug_panic("debug string length out of range");
+    }
+    size_t need = b->len + extra;
+    if (need <= b->cap) {
+        return;
+    }
+    size_t cap = b->cap == 0 ? 64 : b->cap;
+    while (cap < need) {
+        cap = cap > SIZE_MAX / 2 ? need : cap * 2;
+    }
+    uint8_t* data = (uint8_t*)realloc(b->data, cap);
+    if (data == NULL) {
+        debug_panic("debug string allocation failed");
+    }
+    b->data = data;
+    b->cap = cap;
+}
+
+static void debug_write(rt_debug_buf* b, const void* data, size_t len) {
+    if (len == 0) {
+        return;
+    }
+    debug_reserve(b, len);
+    memcpy(b->data + b->len, data, len);
+    b->len += len;
+}
+
+static void debug_write_cstr(rt_debug_buf* b, const char* s) {
+    debug_write(b, s, strlen(s));
+}
+
+// debug_write_part appends a string handle and releases it.
+static void debug_write_part(rt_debug_buf* b, void* s) {
+    if (s == NULL) {
+        return;
+    }
+    debug_write(b, rt_string_ptr(&s), (size_t)rt_string_len_bytes(&s));
+    rt_string_release(s);
+}
+
+static void* debug_finish(rt_debug_buf* b) {
+    void* out = rt_string_from_bytes(b->data, (uint64_t)b->len);
+    free(b->data);
+    return out;
+}
+
+// debug_rune_printable approximates Go's unicode.IsPrint, which the VM relies on
+// through strconv.Quote: controls, non-ASCII spaces and format characters are
+// escaped, everything else is kept as is.
+static bool debug_rune_printable(uint32_t r) {
+    if (r < 0x20 || r == 0x7F) {
+        return false;
+    }
+    if (r < 0x80) {
+        return true;
+    }
+    if ((r >= 0x80 && r <= 0xA0) || r == 0xAD || r == 0x1680 || r == 0x3000 || r == 0xFEFF) {
+        return false;
+    }
+    if ((r >= 0x2000 && r <= 0x200F) || (r >= 0x2028 && r <= 0x202F) || (r >= 0x205F && r <= 0x2064)) {
+        return false;
+    }
+    if ((r >= 0xFFF9 && r <= 0xFFFB) || r == 0xFFFE || r == 0xFFFF) {
+        return false;
+    }
+    return true;
+}
+
+// debug_decode_rune decodes one UTF-8 sequence; it returns 0 for invalid input.
+static size_t debug_decode_rune(const uint8_t* p, size_t n, uint32_t* out) {
+    uint8_t c = p[0];
+    size_t size = 0;
+    uint32_t r = 0;
+    uint32_t min = 0;
+    if (c < 0x80) {
+        *out = c;
+        return 1;
+    }
+    if ((c & 0xE0) == 0xC0) {
+        size = 2;
+        r = c & 0x1F;
+        min = 0x80;
+    } else if ((c & 0xF0) == 0xE0) {
+        size = 3;
+        r = c & 0x0F;
+        min = 0x800;
+    } else if ((c & 0xF8) == 0xF0) {
+        size = 4;
+        r = c & 0x07;
+        min = 0x10000;
+    } else {
+        return 0;
+    }
+    if (size > n) {
+        return 0;
+    }
+    for (size_t i = 1; i < size; i++) {
+        if ((p[i] & 0xC0) != 0x80) {
+            return 0;
+        }
+        r = (r << 6) | (p[i] & 0x3F);
+    }
+    if (r < min || r > 0x10FFFF || (r >= 0xD800 && r <= 0xDFFF)) {
+        return 0;
+    }
+    *out = r;
+    return size;
+}
+
+void* rt_debug_quote(void* s) {
+    const uint8_t* data = rt_string_ptr(&s);
+    size_t len = (size_t)rt_string_len_bytes(&s);
+    rt_debug_buf b = {0};
+    char esc[16];
+    debug_write_cstr(&b, "\"");
+    size_t i = 0;
+    while (i < len) {
+        uint8_t c = data[i];
+        switch (c) {
+        case '"':
+            debug_write_cstr(&b, "\\\"");
+            i++;
+            continue;
+        case '\\':
+            debug_write_cstr(&b, "\\\\");
+            i++;
+            continue;
+        case '\a':
+            debug_write_cstr(&b, "\\a");
+            i++;
+            continue;
+        case '\b':
+            debug_write_cstr(&b, "\\b");
+            i++;
+            continue;
+        case '\f':
+            debug_write_cstr(&b, "\\f");
+            i++;
+            continue;
+        case '\n':
+            debug_write_cstr(&b, "\\n");
+            i++;
+            continue;
+        case '\r':
+            debug_write_cstr(&b, "\\r");
+            i++;
+            continue;
+        case '\t':
+            debug_write_cstr(&b, "\\t");
+            i++;
+            continue;
+        case '\v':
+            debug_write_cstr(&b, "\\v");
+            i++;
+            continue;
+        default:
+            break;
+        }
+        uint32_t r = 0;
+        size_t size = debug_decode_rune(data + i, len - i, &r);
+        if (size == 0) {
+            snprintf(esc, sizeof(esc), "\\x%02x", c);
+            debug_write_cstr(&b, esc);
+            i++;
+            continue;
+        }
+        if (debug_rune_printable(r)) {
+            debug_write(&b, data + i, size);
+        } else if (r < 0x80) {
+            snprintf(esc, sizeof(esc), "\\x%02x", (unsigned)r);
+            debug_write_cstr(&b, esc);
+        } else if (r < 0x10000) {
+            snprintf(esc, sizeof(esc), "\\u%04x", (unsigned)r);
+            debug_write_cstr(&b, esc);
+        } else {
+            snprintf(esc, sizeof(esc), "\\U%08x", (unsigned)r);
+            debug_write_cstr(&b, esc);
+        }
+        i += size;
+    }
+    debug_write_cstr(&b, "\"");
+    return debug_finish(&b);
+}
+
+void* rt_debug_array(const uint8_t* data, uint64_t len, uint64_t stride, void* fmt) {
+    rt_debug_fmt_fn fn = (rt_debug_fmt_fn)fmt;
+    rt_debug_buf b = {0};
+    debug_write_cstr(&b, "[");
+    for (uint64_t i = 0; i < len; i++) {
+        if (i > 0) {
+            debug_write_cstr(&b, ", ");
+        }
+        debug_write_part(&b, fn(data + i * stride));
+    }
+    debug_write_cstr(&b, "]");
+    return debug_finish(&b);
+}
+
+void* rt_debug_struct(void* name, uint64_t count, void** names, void** values) {
+    rt_debug_buf b = {0};
+    debug_write_part(&b, name);
+    if (count == 0) {
+        debug_write_cstr(&b, " {}");
+        return debug_finish(&b);
+    }
+    debug_write_cstr(&b, " { ");
+    for (uint64_t i = 0; i < count; i++) {
+        if (i > 0) {
+            debug_write_cstr(&b, ", ");
+        }
+        debug_write_part(&b, names[i]);
+        debug_write_cstr(&b, ": ");
+        debug_write_part(&b, values[i]);
+    }
+    debug_write_cstr(&b, " }");
+    return debug_finish(&b);
+}
+
+void* rt_debug_tuple(uint64_t count, void** values) {
+    rt_debug_buf b = {0};
+    debug_write_cstr(&b, "(");
+    for (uint64_t i = 0; i < count; i++) {
+        if (i > 0) {
+            debug_write_cstr(&b, ", ");
+        }
+        debug_write_part(&b, values[i]);
+    }
+    if (count == 1) {
+        debug_write_cstr(&b, ",");
+    }
+    debug_write_cstr(&b, ")");
+    return debug_finish(&b);
+}
+
+void* rt_debug_tag(void* name, uint64_t count, void** values) {
+    rt_debug_buf b = {0};
+    debug_write_part(&b, name);
+    if (count == 0) {
+        return debug_finish(&b);
+    }
+    debug_write_cstr(&b, "(");
+    for (uint64_t i = 0; i < count; i++) {
+        if (i > 0) {
+            debug_write_cstr(&b, ", ");
+        }
+        debug_write_part(&b, values[i]);
+    }
+    debug_write_cstr(&b, ")");
+    return debug_finish(&b);
+}
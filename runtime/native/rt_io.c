@@ -164,6 +164,12 @@ void rt_exit(int64_t code) {
     exit((int)code);
 }
 
+int32_t rt_finish(int64_t code) {
+    rt_exec_trace_dump();
+    rt_sched_trace_dump();
+    return (int32_t)code;
+}
+
 void rt_panic(const uint8_t* ptr, uint64_t length) {
     static const uint8_t prefix[] = "panic: ";
     rt_write_stderr(prefix, (uint64_t)(sizeof(prefix) - 1));
@@ -194,6 +200,18 @@ void rt_panic_numeric(const uint8_t* ptr, uint64_t length) {
     _exit(1);
 }
 
+void rt_panic_div_zero(void) {
+    static const uint8_t msg[] = "panic VM3203: division by zero\n";
+    rt_write_stderr(msg, (uint64_t)(sizeof(msg) - 1));
+    _exit(1);
+}
+
+void rt_panic_overflow(void) {
+    static const uint8_t msg[] = "panic VM1101: integer overflow\n";
+    rt_write_stderr(msg, (uint64_t)(sizeof(msg) - 1));
+    _exit(1);
+}
+
 void rt_panic_bounds(uint64_t kind, int64_t index, int64_t length) {
     const char* code = "VM1004";
     if (kind == 1) {
@@ -0,0 +1,151 @@
+#include "rt.h"
+
+#include <pthread.h>
+#include <stdint.h>
+#include <string.h>
+
+// Fork-join helpers behind `parallel map` / `parallel reduce` in the LLVM backend.
+// Element callbacks are thunks emitted by the compiler with a uniform ABI, so the
+// runtime only deals with element strides and opaque pointers.
+
+typedef struct SurgeArrayHeader {
+    uint64_t len;
+    uint64_t cap;
+    void* data;
+} SurgeArrayHeader;
+
+typedef void (*rt_parallel_map_fn)(void* state, const void* elem, void* out);
+typedef void (*rt_parallel_reduce_fn)(void* state, void* acc, const void* elem);
+
+// Below this many elements per worker the fork is not worth a thread start.
+#define RT_PARALLEL_MIN_CHUNK 64
+
+typedef struct rt_parallel_chunk {
+    const uint8_t* in;
+    uint8_t* out;
+    uint64_t start;
+    uint64_t end;
+    uint64_t in_stride;
+    uint64_t out_stride;
+    rt_parallel_map_fn fn;
+    void* state;
+} rt_parallel_chunk;
+
+static void parallel_panic(const char* msg) {
+    rt_panic((const uint8_t*)msg, (uint64_t)strlen(msg));
+}
+
+static void parallel_map_range(const rt_parallel_chunk* chunk) {
+    for (uint64_t i = chunk->start; i < chunk->end; i++) {
+        chunk->fn(chunk->state, chunk->in + i * chunk->in_stride, chunk->out + i * chunk->out_stride);
+    }
+}
+
+static void* parallel_map_worker(void* arg) {
+    parallel_map_range((const rt_parallel_chunk*)arg);
+    return NULL;
+}
+
+static uint64_t parallel_thread_count(uint64_t len) {
+    uint64_t workers = rt_worker_count();
+    if (workers < 1) {
+        workers = 1;
+    }
+    uint64_t by_size = len / RT_PARALLEL_MIN_CHUNK;
+    if (by_size < 1) {
+        by_size = 1;
+    }
+    return workers < by_size ? workers : by_size;
+}
+
+void* rt_parallel_map(void* array,
+                      uint64_t in_stride,
+                      uint64_t out_stride,
+                      uint64_t out_align,
+                      void* fn,
+                      void* state) {
+    const SurgeArrayHeader* src = (const SurgeArrayHeader*)array;
+    uint64_t len = src != NULL ? src->len : 0;
+    if (out_align == 0) {
+        out_align = 1;
+    }
+    if (out_stride != 0 && len > UINT64_MAX / out_stride) {
+        parallel_panic("parallel map length out of range");
+        return NULL;
+    }
+    SurgeArrayHeader* dst =
+        (SurgeArrayHeader*)rt_alloc((uint64_t)sizeof(SurgeArrayHeader), (uint64_t)_Alignof(SurgeArrayHeader));
+    uint8_t* data = (uint8_t*)rt_alloc(len * out_stride, out_align);
+    if (dst == NULL || (data == NULL && len > 0)) {
+        parallel_panic("parallel map allocation failed");
+        return NULL;
+    }
+    dst->len = len;
+    dst->cap = len;
+    dst->data = data;
+    if (len == 0) {
+        return dst;
+    }
+
+    uint64_t threads = parallel_thread_count(len);
+    rt_parallel_chunk* chunks =
+        (rt_parallel_chunk*)rt_alloc(threads * (uint64_t)sizeof(rt_parallel_chunk),
+                                     (uint64_t)_Alignof(rt_parallel_chunk));
+    pthread_t* tids = (pthread_t*)rt_alloc(threads * (uint64_t)sizeof(pthread_t), (uint64_t)_Alignof(pthread_t));
+    if (chunks == NULL || tids == NULL) {
+        parallel_panic("parallel map allocation failed");
+        return NULL;
+    }
+    uint64_t per = len / threads;
+    uint64_t extra = len % threads;
+    uint64_t start = 0;
+    for (uint64_t t = 0; t < threads; t++) {
+        uint64_t count = per + (t < extra ? 1 : 0);
+        chunks[t] = (rt_parallel_chunk){
+            .in = (const uint8_t*)src->data,
+            .out = data,
+            .start = start,
+            .end = start + count,
+            .in_stride = in_stride,
+            .out_stride = out_stride,
+            .fn = (rt_parallel_map_fn)fn,
+            .state = state,
+        };
+        start += count;
+    }
+    // Chunk 0 runs on the calling thread; the rest get their own threads.
+    uint64_t started = 1;
+    for (uint64_t t = 1; t < threads; t++) {
+        if (pthread_create(&tids[t], NULL, parallel_map_worker, &chunks[t]) != 0) {
+            break;
+        }
+        started++;
+    }
+    for (uint64_t t = started; t < threads; t++) {
+        parallel_map_range(&chunks[t]);
+    }
+    parallel_map_range(&chunks[0]);
+    for (uint64_t t = 1; t < started; t++) {
+        pthread_join(tids[t], NULL);
+    }
+    rt_free((uint8_t*)tids, threads * (uint64_t)sizeof(pthread_t), (uint64_t)_Alignof(pthread_t));
+    rt_free((uint8_t*)chunks,
+            threads * (uint64_t)sizeof(rt_parallel_chunk),
+            (uint64_t)_Alignof(rt_parallel_chunk));
+    return dst;
+}
+
+// `parallel reduce` has no separate combiner, so partial accumulators from
+// different chunks cannot be merged; elements are folded in order on the
+// calling thread.
+void rt_parallel_reduce(void* array, uint64_t in_stride, void* acc, void* fn, void* state) {
+    const SurgeArrayHeader* src = (const SurgeArrayHeader*)array;
+    if (src == NULL || src->len == 0) {
+        return;
+    }
+    rt_parallel_reduce_fn step = (rt_parallel_reduce_fn)fn;
+    const uint8_t* in = (const uint8_t*)src->data;
+    for (uint64_t i = 0; i < src->len; i++) {
+        step(state, acc, in + i * in_stride);
+    }
+}
@@ -295,6 +295,36 @@ void* rt_string_from_bytes(const uint8_t* ptr, uint64_t len) {
     return (void*)s;
 }
 
+// rt_string_clone returns a fresh copy of s that shares no storage with it.
+void* rt_string_clone(void* s) {
+    const SurgeString* src = (const SurgeString*)s;
+    if (src == NULL) {
+        return rt_string_from_bytes(NULL, 0);
+    }
+    size_t total = sizeof(SurgeString) + (size_t)src->len_bytes + 1;
+    SurgeString* out = (SurgeString*)rt_alloc((uint64_t)total, (uint64_t)alignof(SurgeString));
+    if (out == NULL) {
+        return NULL;
+    }
+    out->len_cp = src->len_cp;
+    out->len_bytes = src->len_bytes;
+    if (src->len_bytes > 0) {
+        rt_memcpy(out->data, src->data, src->len_bytes);
+    }
+    out->data[src->len_bytes] = 0;
+    return (void*)out;
+}
+
+void rt_string_release(void* s) {
+    SurgeString* str = (SurgeString*)s;
+    if (str == NULL) {
+        return;
+    }
+    rt_free((uint8_t*)str,
+            (uint64_t)(sizeof(SurgeString) + (size_t)str->len_bytes + 1),
+            (uint64_t)alignof(SurgeString));
+}
+
 const uint8_t* rt_string_ptr(void* s) {
     if (s == NULL) {
         return NULL;
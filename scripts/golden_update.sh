@@ -72,6 +72,12 @@ generate_outputs() {
 		fi
 	fi
 
+	# Generate structured AST dumps for files in ast_dump directory
+	if [[ "${src}" == *"/ast_dump/"* ]]; then
+		"${SURGE_BIN}" parse --format json "${src}" > "${dir}/${name}.ast.json" 2>/dev/null || true
+		"${SURGE_BIN}" parse --format sexpr "${src}" > "${dir}/${name}.ast.sexpr" 2>/dev/null || true
+	fi
+
 	# Generate HIR output for files in hir directory
 	if [[ "${src}" == *"/hir/"* ]]; then
 		"${SURGE_BIN}" diag --format short --emit-hir "${src}" > "${dir}/${name}.hir" 2>&1 || true